@@ -0,0 +1,118 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	bitops "math/bits"
+)
+
+// ListAccumulator maintains the merkle state of an append-only List[T, N], where
+// each append is O(log N) and the root can be retrieved at any time. It is meant
+// for ever growing lists such as block history accumulators or deposits, where
+// rehashing the entire list on every change would be wasteful.
+//
+// Only lists of composite items are supported (i.e. where every item occupies a
+// leaf chunk of the trie), which includes lists of 32 byte blobs via AppendRoot.
+// Lists of packed basic types (e.g. List[uint64, N]) are not supported.
+type ListAccumulator[T Object] struct {
+	fork   Fork         // Fork to hash the appended items in
+	limit  uint64       // Maximum number of items allowed in the list
+	depth  int          // Depth of the data trie, derived from the limit
+	count  uint64       // Number of items appended so far
+	branch [65][32]byte // Left-side sub-trie roots waiting for their siblings
+}
+
+// NewListAccumulator creates an empty accumulator for a list of non-monolithic
+// items, with at most maxItems elements. If the item type contains fork-specific
+// rules, use NewListAccumulatorOnFork.
+func NewListAccumulator[T Object](maxItems uint64) *ListAccumulator[T] {
+	return NewListAccumulatorOnFork[T](maxItems, ForkUnknown)
+}
+
+// NewListAccumulatorOnFork creates an empty accumulator for a list of monolithic
+// items, with at most maxItems elements, hashing the items in the given fork.
+func NewListAccumulatorOnFork[T Object](maxItems uint64, fork Fork) *ListAccumulator[T] {
+	var depth int
+	if maxItems > 1 {
+		depth = bitops.Len64(maxItems - 1)
+	}
+	return &ListAccumulator[T]{
+		fork:  fork,
+		limit: maxItems,
+		depth: depth,
+	}
+}
+
+// Count returns the number of items appended to the list so far.
+func (acc *ListAccumulator[T]) Count() uint64 {
+	return acc.count
+}
+
+// Append hashes an item and adds its root to the end of the list.
+func (acc *ListAccumulator[T]) Append(item T) error {
+	if acc.count >= acc.limit {
+		return ErrMaxItemsExceeded
+	}
+	return acc.AppendRoot(HashSequentialOnFork(item, acc.fork))
+}
+
+// AppendRoot adds a leaf chunk to the end of the list. It can be used to append
+// items whose roots were already computed, or to accumulate lists of 32 byte
+// blobs (e.g. List[Root, N]) where the items are the leaves themselves.
+func (acc *ListAccumulator[T]) AppendRoot(root [32]byte) error {
+	if acc.count >= acc.limit {
+		return ErrMaxItemsExceeded
+	}
+	acc.count++
+
+	// Merge the new leaf with all the completed left siblings, storing the root
+	// of the first sub-trie that remains incomplete
+	node, size := root, acc.count
+	for level := 0; level <= acc.depth; level++ {
+		if size&1 == 1 {
+			acc.branch[level] = node
+			return nil
+		}
+		node = accumulatorHash(acc.branch[level], node)
+		size >>= 1
+	}
+	return nil
+}
+
+// Root returns the merkle root of the list, with its length mixed in.
+func (acc *ListAccumulator[T]) Root() [32]byte {
+	var node [32]byte
+	if acc.depth < 64 && acc.count == 1<<acc.depth {
+		// The trie is full, its root was already stored by the last append. A
+		// trie of depth 64 can never fill up, the count would overflow first.
+		node = acc.branch[acc.depth]
+	} else {
+		// The trie is incomplete, fill it with zero sub-tries on the right
+		size := acc.count
+		for level := 0; level < acc.depth; level++ {
+			if size&1 == 1 {
+				node = accumulatorHash(acc.branch[level], node)
+			} else {
+				node = accumulatorHash(node, hasherZeroCache[level])
+			}
+			size >>= 1
+		}
+	}
+	var length [32]byte
+	binary.LittleEndian.PutUint64(length[:8], acc.count)
+
+	return accumulatorHash(node, length)
+}
+
+// accumulatorHash hashes two sibling nodes together.
+func accumulatorHash(left [32]byte, right [32]byte) [32]byte {
+	var buffer [64]byte
+	copy(buffer[:32], left[:])
+	copy(buffer[32:], right[:])
+
+	return sha256.Sum256(buffer[:])
+}
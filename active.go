@@ -0,0 +1,146 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+// ActiveFields reports which top level fields of an object's type are present in
+// a fork, as a bitvector with one bit per field in definition order, set for the
+// ones active in the fork. The object itself is only used for its type, so it
+// may be empty.
+//
+// The field indices are stable across forks (fields are never reordered, only
+// added and removed), matching the active fields bitvector of StableContainers.
+// The bitvector is the smallest go-bitfield one holding all the fields of the
+// type (Bitvector8 to Bitvector512), so its packed bytes may need to be padded
+// to the capacity of the container to form such a bitvector.
+//
+// The object needs to implement NamedObject, so its fields can be told apart.
+// Read-only types (generated with value receivers) are supported too, as the
+// fields are only ever encoded.
+func ActiveFields(obj Object, fork Fork) (bitfield.Bitfield, error) {
+	names, active, err := activeFieldsOf(obj, fork)
+	if err != nil {
+		return nil, err
+	}
+	var bits bitfield.Bitfield
+	switch n := len(names); {
+	case n <= 8:
+		bits = bitfield.NewBitvector8()
+	case n <= 32:
+		bits = bitfield.NewBitvector32()
+	case n <= 64:
+		bits = bitfield.NewBitvector64()
+	case n <= 128:
+		bits = bitfield.NewBitvector128()
+	case n <= 256:
+		bits = bitfield.NewBitvector256()
+	case n <= 512:
+		bits = bitfield.NewBitvector512()
+	default:
+		return nil, fmt.Errorf("ssz: %T has %d fields, more than the largest bitvector", obj, n)
+	}
+	for i, name := range names {
+		bits.SetBitAt(uint64(i), active[name])
+	}
+	return bits, nil
+}
+
+// activeTracer tracks the output positions at which the top level fields of an
+// object start while it is being encoded.
+type activeTracer struct {
+	blob   []byte // Buffer the object is being encoded into
+	depth  int    // Nesting depth of the object currently being encoded
+	starts []int  // Output positions of the top level field definitions
+}
+
+// field records the start of a new field, if it is a top level one.
+func (t *activeTracer) field(enc *Encoder) {
+	if t.depth == 1 {
+		t.starts = append(t.starts, len(t.blob)-len(enc.outBuffer))
+	}
+}
+
+// activeFieldSet is the set of top level fields of a type active in a fork.
+type activeFieldSet struct {
+	names  []string        // Names of the fields in definition order
+	active map[string]bool // Fields present in the fork
+}
+
+// activeFieldsCache is a cache of the active fields of types per fork.
+var activeFieldsCache sync.Map // map[fieldLayoutKey]*activeFieldSet
+
+// activeFieldsOf retrieves the names of the top level fields of an object's type
+// in definition order, along with the set of them active in a fork.
+func activeFieldsOf(obj Object, fork Fork) ([]string, map[string]bool, error) {
+	named, ok := obj.(NamedObject)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %T has no field names", ErrUnknownField, obj)
+	}
+	key := fieldLayoutKey{typ: reflect.TypeOf(obj), fork: fork}
+	if set, ok := activeFieldsCache.Load(key); ok {
+		return set.(*activeFieldSet).names, set.(*activeFieldSet).active, nil
+	}
+	// Fields not in the fork are defined without producing any output, so encode
+	// an empty instance and check which fields wrote something. Dynamic fields
+	// are defined twice (offset and content), only their offset counts.
+	fresh := newObjectOf(obj)
+
+	trace := &activeTracer{blob: make([]byte, SizeOnFork(fresh, fork))}
+	if err := encodeToBytes(trace.blob, fresh, fork, LittleEndian, false, nil, trace); err != nil {
+		return nil, nil, err
+	}
+	var (
+		fields = named.NamesSSZ()
+		names  []string
+		seen   = make(map[string]bool)
+		active = make(map[string]bool)
+	)
+	for i, field := range fields {
+		if seen[field] {
+			continue
+		}
+		seen[field] = true
+		names = append(names, field)
+
+		end := len(trace.blob)
+		if i+1 < len(trace.starts) {
+			end = trace.starts[i+1]
+		}
+		if i < len(trace.starts) && end > trace.starts[i] {
+			active[field] = true
+		}
+	}
+	activeFieldsCache.Store(key, &activeFieldSet{names: names, active: active})
+	return names, active, nil
+}
+
+// checkActiveFields verifies that the active fields bitvector of a payload (bits
+// packed little endian, as on the wire) matches the fields of an object's type
+// present in the fork being decoded, failing with ErrActiveFieldsMismatch if not.
+func checkActiveFields(bits []byte, obj Object, fork Fork) error {
+	names, active, err := activeFieldsOf(obj, fork)
+	if err != nil {
+		return err
+	}
+	for i, name := range names {
+		have := i/8 < len(bits) && bits[i/8]&(1<<(i%8)) != 0
+		if have != active[name] {
+			return fmt.Errorf("%w: %T.%s present %v, fork %s expects %v", ErrActiveFieldsMismatch, obj, name, have, forkName(fork), active[name])
+		}
+	}
+	for i := len(names); i < 8*len(bits); i++ {
+		if bits[i/8]&(1<<(i%8)) != 0 {
+			return fmt.Errorf("%w: field #%d present, %T has %d fields", ErrActiveFieldsMismatch, i, obj, len(names))
+		}
+	}
+	return nil
+}
@@ -5,31 +5,74 @@
 package main
 
 import (
-	"bytes"
 	"flag"
 	"fmt"
-	"go/format"
-	"go/types"
 	"log"
 	"os"
 	"strings"
 
-	"golang.org/x/tools/go/packages"
+	"github.com/karalabe/ssz/gen"
 )
 
+// sszPkgPath is the import path of the ssz library, whose calls are vetted.
+const sszPkgPath = "github.com/karalabe/ssz"
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "describe" {
+		runDescribe(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "vet" {
+		runVet(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "vectors" {
+		runVectors(os.Args[2:])
+		return
+	}
 	var (
 		pkgdir   = flag.String("dir", ".", "input package")
 		output   = flag.String("out", "-", "output file (default is stdout)")
 		typename = flag.String("type", "", "type to generate methods for")
+		extras   = flag.String("extras", "", "extra methods to generate (clone, equal, cache, getters, reset, upgrade)")
+		gentests = flag.Bool("tests", false, "generate round-trip tests and fuzz targets into <out>_test.go")
+		lenient  = flag.Bool("lenient", false, "ignore unknown ssz struct tags instead of rejecting them")
+		sizetab  = flag.Bool("sizetable", false, "resolve the per-fork static sizes at generation time instead of package init")
+		values   = flag.Bool("valuereceivers", false, "generate the ssz methods on value receivers, making the types encode-only")
+		check    = flag.Bool("check", false, "exit with an error if the output file is stale, instead of regenerating it")
 	)
 	flag.Parse()
 
-	cfg := Config{Dir: *pkgdir}
-	if len(*typename) > 0 {
-		cfg.Types = strings.Split(*typename, ",")
+	opts := &gen.Options{Dir: *pkgdir, Tests: *gentests, Lenient: *lenient, SizeTable: *sizetab, ValueReceivers: *values}
+	if len(*extras) > 0 {
+		opts.Extras = strings.Split(*extras, ",")
 	}
-	code, err := cfg.process()
+	if opts.Tests && (*output == "-" || !strings.HasSuffix(*output, ".go")) {
+		fatal("test generation requires a .go output file")
+	}
+	types := splitTypes(*typename)
+
+	if *check {
+		if *output == "-" {
+			fatal("staleness checking requires an output file")
+		}
+		code, err := os.ReadFile(*output)
+		if err != nil && !os.IsNotExist(err) {
+			fatal(err)
+		}
+		fresh, err := gen.Check(".", types, opts, code)
+		if err != nil {
+			fatal(err)
+		}
+		if !fresh {
+			fatal(fmt.Sprintf("%s is stale, regenerate it", *output))
+		}
+		return
+	}
+	// Display a single log for mass generates
+	log.Printf("Generating SSZ bindings for: %v", types)
+
+	code, tests, err := gen.Generate(".", types, opts)
 	if err != nil {
 		fatal(err)
 	}
@@ -38,84 +81,69 @@ func main() {
 	} else if err := os.WriteFile(*output, code, 0600); err != nil {
 		fatal(err)
 	}
+	if tests != nil {
+		if err := os.WriteFile(strings.TrimSuffix(*output, ".go")+"_test.go", tests, 0600); err != nil {
+			fatal(err)
+		}
+	}
 }
 
-func fatal(args ...interface{}) {
-	fmt.Fprintln(os.Stderr, args...)
-	os.Exit(1)
-}
-
-type Config struct {
-	Dir   string // input package directory
-	Types []string
-}
-
-// process generates the Go code.
-func (cfg *Config) process() ([]byte, error) {
-	// Display a single log for mass generates
-	log.Printf("Generating SSZ bindings for: %v", cfg.Types)
+// runDescribe is the entry point of the `sszgen describe` subcommand, dumping the
+// resolved ssz schema of the requested types as JSON.
+func runDescribe(args []string) {
+	var (
+		flags    = flag.NewFlagSet("describe", flag.ExitOnError)
+		pkgdir   = flags.String("dir", ".", "input package")
+		output   = flags.String("out", "-", "output file (default is stdout)")
+		typename = flags.String("type", "", "type to describe the schema of")
+		lenient  = flags.Bool("lenient", false, "ignore unknown ssz struct tags instead of rejecting them")
+	)
+	flags.Parse(args)
 
-	// Load the ssz library package and the target package to generate into
-	pcfg := &packages.Config{
-		Mode: packages.NeedName | packages.NeedTypes | packages.NeedImports | packages.NeedDeps,
-		Dir:  cfg.Dir,
-	}
-	ps, err := packages.Load(pcfg, sszPkgPath, ".")
+	blob, err := gen.Describe(".", splitTypes(*typename), &gen.Options{Dir: *pkgdir, Lenient: *lenient})
 	if err != nil {
-		return nil, err
-	}
-	if len(ps) == 0 {
-		return nil, fmt.Errorf("no Go package found in %s", cfg.Dir)
+		fatal(err)
 	}
-	if len(ps) != 2 {
-		return nil, fmt.Errorf("at most one package can be processed at the same time")
+	if *output == "-" {
+		os.Stdout.Write(blob)
+	} else if err := os.WriteFile(*output, blob, 0600); err != nil {
+		fatal(err)
 	}
-	packages.PrintErrors(ps)
+}
 
-	// Pick out the library package for interfaces and the target package for types
+// runVectors is the entry point of the `sszgen vectors` subcommand, generating
+// randomized valid encodings of the requested types along with their expected
+// merkle roots, for differential testing against other ssz implementations.
+func runVectors(args []string) {
 	var (
-		library *types.Package
-		target  *types.Package
+		flags    = flag.NewFlagSet("vectors", flag.ExitOnError)
+		pkgdir   = flags.String("dir", ".", "input package")
+		output   = flags.String("out", "vectors", "output directory")
+		typename = flags.String("type", "", "types to generate test vectors for")
+		runs     = flags.Int("runs", 16, "number of test vectors to generate per type")
+		forkname = flags.String("fork", "", "fork to generate the test vectors in (monolithic types)")
+		seed     = flags.Int64("seed", 1, "seed of the random generator, for reproducibility")
+		lenient  = flags.Bool("lenient", false, "ignore unknown ssz struct tags instead of rejecting them")
 	)
-	for _, p := range ps {
-		if len(p.Errors) > 0 {
-			return nil, fmt.Errorf("package %s has errors", p.PkgPath)
-		}
-		if p.PkgPath == sszPkgPath {
-			library = p.Types
-		} else {
-			target = p.Types
-		}
-	}
-	// Parse the package in the context of the ssz library
-	parser := newParseContext(library)
+	flags.Parse(args)
 
-	types, err := parser.parsePackage(target, cfg.Types)
-	if err != nil {
-		return nil, err
-	}
-	var (
-		ctx    = newGenContext(target)
-		chunks [][]byte
-	)
-	for _, typ := range types {
-		ret, err := generate(ctx, typ)
-		if err != nil {
-			return nil, err
-		}
-		chunks = append(chunks, ret)
+	opts := &gen.Options{Dir: *pkgdir, Lenient: *lenient}
+	vopts := &gen.VectorsOptions{Runs: *runs, Fork: *forkname, Seed: *seed}
+	if err := gen.Vectors(".", splitTypes(*typename), *output, opts, vopts); err != nil {
+		fatal(err)
 	}
-	code := bytes.Join(chunks, []byte("\n\n"))
+}
 
-	// Add package and imports definition and format code
-	code = append(ctx.header(), code...)
-	code, err = format.Source(code)
-	if err != nil {
-		return nil, err
+// splitTypes splits a comma separated list of type names, returning nil for an
+// empty list (i.e. all types).
+func splitTypes(names string) []string {
+	if len(names) == 0 {
+		return nil
 	}
-	// Add build comments.
-	// This is done here to avoid processing these lines with gofmt.
-	var header bytes.Buffer
-	fmt.Fprint(&header, "// Code generated by github.com/karalabe/ssz. DO NOT EDIT.\n\n")
-	return append(header.Bytes(), code...), nil
+	return strings.Split(names, ",")
+}
+
+func fatal(args ...interface{}) {
+	fmt.Fprintln(os.Stderr, args...)
+	os.Exit(1)
 }
@@ -0,0 +1,434 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"os"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// runVet loads the requested packages and checks every DefineSSZ and SizeSSZ
+// method in them (hand-written or generated) for inconsistencies that would only
+// surface at runtime, reporting the findings in the same format as go vet.
+func runVet(args []string) {
+	var (
+		flags  = flag.NewFlagSet("vet", flag.ExitOnError)
+		pkgdir = flags.String("dir", ".", "directory to resolve the package patterns in")
+		tests  = flags.Bool("tests", false, "also check the types declared in test files")
+	)
+	flags.Parse(args)
+
+	patterns := flags.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+	pcfg := &packages.Config{
+		Mode:  packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps,
+		Dir:   *pkgdir,
+		Tests: *tests,
+	}
+	ps, err := packages.Load(pcfg, patterns...)
+	if err != nil {
+		fatal(err)
+	}
+	// Check all the packages, deduplicating the findings since test variants of
+	// the packages will contain the same files. Packages that fail to load are
+	// reported and skipped.
+	var (
+		failed   = packages.PrintErrors(ps) > 0
+		seen     = make(map[string]bool)
+		findings []vetReport
+	)
+	for _, p := range ps {
+		if len(p.Errors) > 0 {
+			continue
+		}
+		for _, finding := range vetPackage(p) {
+			if key := finding.String(); !seen[key] {
+				seen[key] = true
+				findings = append(findings, finding)
+			}
+		}
+	}
+	sort.Slice(findings, func(i, j int) bool {
+		a, b := findings[i].pos, findings[j].pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	for _, finding := range findings {
+		fmt.Fprintln(os.Stderr, finding)
+	}
+	if failed || len(findings) > 0 {
+		os.Exit(1)
+	}
+}
+
+// vetCall is a call into the ssz package from within a DefineSSZ or SizeSSZ.
+type vetCall struct {
+	pos   token.Pos
+	name  string    // Name of the ssz function called (e.g. DefineDynamicBytesOffset)
+	field string    // Field operated on, with any address-of operator stripped
+	args  string    // Remaining arguments after the field (limits, fork filters)
+	conds []vetCond // Conditionals the call is nested in (e.g. fork switches)
+}
+
+// vetCond is a branch of an if statement that a call is nested in.
+type vetCond struct {
+	expr   string // Condition of the if statement
+	branch bool   // Whether the call is in the body (true) or the else branch
+}
+
+// vetMethods are the ssz methods of a single type that need to be cross-checked.
+type vetMethods struct {
+	define []*vetCall // Define calls from DefineSSZ, in source order
+	size   []*vetCall // Size calls from SizeSSZ, in source order
+	sized  bool       // Whether the type has a SizeSSZ method at all
+	asym   bool       // Whether DefineSSZ has asymmetric parts (not checked)
+}
+
+// vetReport is a finding positioned within the source code.
+type vetReport struct {
+	pos  token.Position
+	recv string // Type whose ssz methods the finding is about
+	msg  string
+}
+
+// String formats the finding the same way as go vet.
+func (r vetReport) String() string {
+	return fmt.Sprintf("%s: %s: %s", r.pos, r.recv, r.msg)
+}
+
+// vetPackage checks all the ssz types within a single package.
+func vetPackage(p *packages.Package) []vetReport {
+	var (
+		methods = make(map[string]*vetMethods)
+		order   []string
+	)
+	for _, file := range p.Syntax {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || fn.Body == nil || len(fn.Recv.List) != 1 {
+				continue
+			}
+			if fn.Name.Name != "DefineSSZ" && fn.Name.Name != "SizeSSZ" {
+				continue
+			}
+			recv := types.ExprString(fn.Recv.List[0].Type)
+			recv = strings.TrimPrefix(recv, "*")
+
+			m, ok := methods[recv]
+			if !ok {
+				m = new(vetMethods)
+				methods[recv] = m
+				order = append(order, recv)
+			}
+			calls, skips := vetCollect(p.TypesInfo, fn.Body)
+			if fn.Name.Name == "DefineSSZ" {
+				m.define, m.asym = calls, skips
+			} else {
+				m.size, m.sized = calls, true
+			}
+		}
+	}
+	var findings []vetReport
+	for _, recv := range order {
+		for _, finding := range vetMethodsOf(methods[recv]) {
+			findings = append(findings, vetReport{pos: p.Fset.Position(finding.pos), recv: recv, msg: finding.msg})
+		}
+	}
+	return findings
+}
+
+// vetCollect gathers all the calls into the ssz package from a method body, not
+// descending into function literals (asymmetric encoders, decoders and hashers
+// are out of scope). The returned flag reports whether any were skipped.
+func vetCollect(info *types.Info, body *ast.BlockStmt) ([]*vetCall, bool) {
+	var (
+		calls []*vetCall
+		ifs   []*ast.IfStmt // If statements in pre-order (outer ones first)
+		skips bool
+	)
+	ast.Inspect(body, func(n ast.Node) bool {
+		if _, ok := n.(*ast.FuncLit); ok {
+			skips = true
+			return false
+		}
+		if stmt, ok := n.(*ast.IfStmt); ok {
+			ifs = append(ifs, stmt)
+			return true
+		}
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		// Unwrap any explicit type parameters and resolve the called function
+		fun := call.Fun
+		switch f := fun.(type) {
+		case *ast.IndexExpr:
+			fun = f.X
+		case *ast.IndexListExpr:
+			fun = f.X
+		}
+		sel, ok := fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		obj, ok := info.Uses[sel.Sel].(*types.Func)
+		if !ok || obj.Pkg() == nil || obj.Pkg().Path() != sszPkgPath {
+			return true
+		}
+		// Object level fork guards don't operate on fields
+		if obj.Name() == "DefineObjectFork" {
+			return true
+		}
+		// Free functions take the codec or sizer first, methods are called on
+		// them directly, either way pick out the field operated on. Methods of
+		// the ssz interfaces (e.g. sizing a type parameter) are not field ops.
+		field := 1
+		if recv := obj.Type().(*types.Signature).Recv(); recv != nil {
+			if types.IsInterface(recv.Type()) {
+				return true
+			}
+			field = 0
+		}
+		if len(call.Args) <= field {
+			return true
+		}
+		args := make([]string, 0, len(call.Args)-field-1)
+		for _, arg := range call.Args[field+1:] {
+			args = append(args, types.ExprString(arg))
+		}
+		calls = append(calls, &vetCall{
+			pos:   call.Pos(),
+			name:  obj.Name(),
+			field: strings.TrimPrefix(types.ExprString(call.Args[field]), "&"),
+			args:  strings.Join(args, ", "),
+		})
+		return true
+	})
+	// Attach the branches of the if statements (e.g. fork specific encodings) to
+	// each call nested within them
+	within := func(pos token.Pos, n ast.Node) bool {
+		return n != nil && n.Pos() <= pos && pos < n.End()
+	}
+	for _, call := range calls {
+		for _, stmt := range ifs {
+			switch {
+			case within(call.pos, stmt.Body):
+				call.conds = append(call.conds, vetCond{expr: types.ExprString(stmt.Cond), branch: true})
+			case within(call.pos, stmt.Else):
+				call.conds = append(call.conds, vetCond{expr: types.ExprString(stmt.Cond), branch: false})
+			}
+		}
+	}
+	return calls, skips
+}
+
+// vetMaxConds is the maximum number of distinct conditions whose combinations
+// are checked individually. Beyond it, all the calls are checked as one.
+const vetMaxConds = 10
+
+// vetPaths splits the define calls of a type into all the possible paths through
+// their conditionals, each path containing only the calls executed when the
+// conditions evaluate a certain way.
+//
+// The size calls are not split, since SizeSSZ legitimately guards the sizes of
+// fields missing from some forks, which DefineSSZ handles in the calls instead.
+func vetPaths(m *vetMethods) [][]*vetCall {
+	var (
+		conds []string
+		index = make(map[string]int)
+	)
+	for _, call := range m.define {
+		for _, cond := range call.conds {
+			if _, ok := index[cond.expr]; !ok {
+				index[cond.expr] = len(conds)
+				conds = append(conds, cond.expr)
+			}
+		}
+	}
+	if len(conds) == 0 || len(conds) > vetMaxConds {
+		return [][]*vetCall{m.define}
+	}
+	filter := func(calls []*vetCall, mask int) []*vetCall {
+		var taken []*vetCall
+		for _, call := range calls {
+			ok := true
+			for _, cond := range call.conds {
+				if (mask&(1<<index[cond.expr]) != 0) != cond.branch {
+					ok = false
+					break
+				}
+			}
+			if ok {
+				taken = append(taken, call)
+			}
+		}
+		return taken
+	}
+	paths := make([][]*vetCall, 0, 1<<len(conds))
+	for mask := 0; mask < 1<<len(conds); mask++ {
+		paths = append(paths, filter(m.define, mask))
+	}
+	return paths
+}
+
+// vetFinding is a single issue found within a type's ssz methods.
+type vetFinding struct {
+	pos token.Pos
+	msg string
+}
+
+// vetMethodsOf cross-checks the DefineSSZ and SizeSSZ methods of a single type,
+// along every path through their conditionals. Findings common to multiple paths
+// are deduplicated by the caller.
+func vetMethodsOf(m *vetMethods) []vetFinding {
+	var findings []vetFinding
+	for _, define := range vetPaths(m) {
+		findings = append(findings, vetPathOf(m, define)...)
+	}
+	return findings
+}
+
+// vetPathOf cross-checks a single path through the DefineSSZ and SizeSSZ methods
+// of a type.
+func vetPathOf(m *vetMethods, define []*vetCall) []vetFinding {
+	var (
+		findings []vetFinding
+		offsets  []*vetCall              // Offset definitions, in source order
+		offsetOf = map[string]*vetCall{} // Offset definitions by field
+		content  = map[string]*vetCall{} // Content definitions by field
+		dynamics bool                    // Whether any content was defined yet
+	)
+	report := func(pos token.Pos, format string, args ...any) {
+		findings = append(findings, vetFinding{pos: pos, msg: fmt.Sprintf(format, args...)})
+	}
+	for _, call := range define {
+		if !strings.HasPrefix(call.name, "Define") {
+			continue
+		}
+		kind, base := vetKind(call.name)
+		switch kind {
+		case "Offset":
+			if _, ok := offsetOf[call.field]; ok {
+				report(call.pos, "offset of %s defined twice", call.field)
+				continue
+			}
+			if dynamics {
+				report(call.pos, "offset of %s defined after dynamic contents", call.field)
+			}
+			offsets = append(offsets, call)
+			offsetOf[call.field] = call
+
+		case "Content":
+			dynamics = true
+
+			offset, ok := offsetOf[call.field]
+			if !ok {
+				report(call.pos, "content of %s defined without an offset", call.field)
+				continue
+			}
+			if _, ok := content[call.field]; ok {
+				report(call.pos, "content of %s defined twice", call.field)
+				continue
+			}
+			content[call.field] = call
+
+			if _, want := vetKind(offset.name); base != want {
+				report(call.pos, "content of %s defined via %s, offset via %s", call.field, call.name, offset.name)
+			}
+			if call.args != offset.args {
+				report(call.pos, "content of %s defined with (%s), offset with (%s)", call.field, call.args, offset.args)
+			}
+			// Contents must follow the order of the offsets, otherwise they'd
+			// be decoded into the wrong fields
+			if next := offsets[len(content)-1]; next != offset {
+				report(call.pos, "content of %s defined out of order, expected content of %s", call.field, next.field)
+			}
+		default:
+			if dynamics {
+				report(call.pos, "static field %s defined after dynamic contents", call.field)
+			}
+		}
+	}
+	for _, offset := range offsets {
+		if _, ok := content[offset.field]; !ok {
+			report(offset.pos, "offset of %s defined without a content", offset.field)
+		}
+	}
+	// Cross-check the dynamic fields with the sizes calculated in SizeSSZ, unless
+	// some of the fields are defined asymmetrically and thus unknown
+	if !m.sized || m.asym {
+		return findings
+	}
+	// Sizes switching on the fork are not split into paths, so a field is sized
+	// correctly if any of its size calls match the offset of the path
+	matched := make(map[string]bool)
+	for _, call := range m.size {
+		if offset, ok := offsetOf[call.field]; ok {
+			if _, want := vetKind(offset.name); strings.TrimPrefix(call.name, "Size") == want {
+				matched[call.field] = true
+			}
+		}
+	}
+	sized := make(map[string]bool)
+	for _, call := range m.size {
+		if !strings.HasPrefix(call.name, "Size") {
+			continue
+		}
+		// Objects can also be sized generically, but only the dynamic ones are
+		// of interest here
+		offset, ok := offsetOf[call.field]
+		if call.name == "SizeOf" {
+			if ok {
+				sized[call.field] = true
+			}
+			continue
+		}
+		sized[call.field] = true
+
+		if !ok {
+			report(call.pos, "size of %s calculated, but it is not a dynamic field", call.field)
+			continue
+		}
+		if _, want := vetKind(offset.name); strings.TrimPrefix(call.name, "Size") != want && !matched[call.field] {
+			report(call.pos, "size of %s calculated via %s, offset defined via %s", call.field, call.name, offset.name)
+		}
+	}
+	for _, offset := range offsets {
+		if !sized[offset.field] {
+			report(offset.pos, "size of dynamic field %s not calculated in SizeSSZ", offset.field)
+		}
+	}
+	return findings
+}
+
+// vetKind splits the name of a Define call into its kind (Offset, Content or
+// empty for static fields) and the base encoding it operates on, normalizing the
+// variants that pair up with each other.
+func vetKind(name string) (string, string) {
+	base := strings.TrimSuffix(strings.TrimPrefix(name, "Define"), "OnFork")
+	for _, kind := range []string{"Offset", "Content"} {
+		if strings.HasSuffix(base, kind) {
+			base = strings.TrimSuffix(base, kind)
+			base = strings.Replace(base, "UTF8String", "String", 1)
+			return kind, strings.Replace(base, "SortedSliceOf", "SliceOf", 1)
+		}
+	}
+	return "", base
+}
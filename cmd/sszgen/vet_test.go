@@ -0,0 +1,213 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// vetTestSource is a package with hand-written ssz methods, one type per kind
+// of inconsistency the vet command should detect, plus a correct one.
+const vetTestSource = `package vettest
+
+import "github.com/karalabe/ssz"
+
+type Good struct {
+	A uint64
+	B []byte
+	C []uint64
+}
+
+func (obj *Good) SizeSSZ(siz *ssz.Sizer, fixed bool) uint32 {
+	size := uint32(8 + 4 + 4)
+	if fixed {
+		return size
+	}
+	size += ssz.SizeDynamicBytes(siz, obj.B)
+	size += ssz.SizeSliceOfUint64s(siz, obj.C)
+	return size
+}
+
+func (obj *Good) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &obj.A)
+	ssz.DefineDynamicBytesOffset(codec, &obj.B, 32)
+	ssz.DefineSliceOfUint64sOffset(codec, &obj.C, 16)
+
+	ssz.DefineDynamicBytesContent(codec, &obj.B, 32)
+	ssz.DefineSliceOfUint64sContent(codec, &obj.C, 16)
+}
+
+type Forked struct {
+	A uint64
+	B []byte
+}
+
+func (obj *Forked) SizeSSZ(siz *ssz.Sizer, fixed bool) uint32 {
+	size := uint32(8 + 4)
+	if fixed {
+		return size
+	}
+	return size + ssz.SizeDynamicBytes(siz, obj.B)
+}
+
+func (obj *Forked) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &obj.A)
+	if codec.Fork() >= ssz.ForkDeneb {
+		ssz.DefineDynamicBytesOffset(codec, &obj.B, 64)
+		ssz.DefineDynamicBytesContent(codec, &obj.B, 64)
+	} else {
+		ssz.DefineDynamicBytesOffset(codec, &obj.B, 32)
+		ssz.DefineDynamicBytesContent(codec, &obj.B, 32)
+	}
+}
+
+type NoContent struct {
+	B []byte
+}
+
+func (obj *NoContent) SizeSSZ(siz *ssz.Sizer, fixed bool) uint32 {
+	return 4 + ssz.SizeDynamicBytes(siz, obj.B)
+}
+
+func (obj *NoContent) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineDynamicBytesOffset(codec, &obj.B, 32)
+}
+
+type OutOfOrder struct {
+	B []byte
+	C []byte
+}
+
+func (obj *OutOfOrder) SizeSSZ(siz *ssz.Sizer, fixed bool) uint32 {
+	return 8 + ssz.SizeDynamicBytes(siz, obj.B) + ssz.SizeDynamicBytes(siz, obj.C)
+}
+
+func (obj *OutOfOrder) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineDynamicBytesOffset(codec, &obj.B, 32)
+	ssz.DefineDynamicBytesOffset(codec, &obj.C, 32)
+	ssz.DefineDynamicBytesContent(codec, &obj.C, 32)
+	ssz.DefineDynamicBytesContent(codec, &obj.B, 32)
+}
+
+type Mismatched struct {
+	B []byte
+	C []uint64
+}
+
+func (obj *Mismatched) SizeSSZ(siz *ssz.Sizer, fixed bool) uint32 {
+	return 8 + ssz.SizeDynamicBytes(siz, obj.B)
+}
+
+func (obj *Mismatched) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineDynamicBytesOffset(codec, &obj.B, 32)
+	ssz.DefineSliceOfUint64sOffset(codec, &obj.C, 16)
+	ssz.DefineDynamicBytesContent(codec, &obj.B, 64)
+	ssz.DefineSliceOfUint64sContent(codec, &obj.C, 16)
+}
+
+type LateStatic struct {
+	A uint64
+	B []byte
+}
+
+func (obj *LateStatic) SizeSSZ(siz *ssz.Sizer, fixed bool) uint32 {
+	return 12 + ssz.SizeDynamicBytes(siz, obj.B)
+}
+
+func (obj *LateStatic) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineDynamicBytesOffset(codec, &obj.B, 32)
+	ssz.DefineDynamicBytesContent(codec, &obj.B, 32)
+	ssz.DefineUint64(codec, &obj.A)
+}
+`
+
+// vetTestPackage writes a throwaway package within the module (so it can import
+// the library) and loads it the same way the vet command does.
+func vetTestPackage(t *testing.T, src string) *packages.Package {
+	t.Helper()
+
+	if err := os.MkdirAll("testdata", 0o755); err != nil {
+		t.Fatalf("failed to create testdata folder: %v", err)
+	}
+	dir, err := os.MkdirTemp("testdata", "vettest")
+	if err != nil {
+		t.Fatalf("failed to create test package: %v", err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+		os.Remove("testdata") // only if empty
+	})
+	if err := os.WriteFile(filepath.Join(dir, "types.go"), []byte(src), 0o644); err != nil {
+		t.Fatalf("failed to write test package: %v", err)
+	}
+	return vetLoad(t, dir)
+}
+
+// vetLoad loads a single package for vetting, failing the test on any error.
+func vetLoad(t *testing.T, dir string) *packages.Package {
+	t.Helper()
+
+	pcfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps,
+		Dir:  dir,
+	}
+	ps, err := packages.Load(pcfg, ".")
+	if err != nil {
+		t.Fatalf("failed to load package: %v", err)
+	}
+	if len(ps) != 1 || len(ps[0].Errors) > 0 {
+		t.Fatalf("failed to load package: %v", ps[0].Errors)
+	}
+	return ps[0]
+}
+
+// Tests that the vet command reports the inconsistencies between the DefineSSZ
+// and SizeSSZ methods of hand-written types, and nothing for correct ones.
+func TestVet(t *testing.T) {
+	findings := make(map[string][]string)
+	for _, finding := range vetPackage(vetTestPackage(t, vetTestSource)) {
+		findings[finding.recv] = append(findings[finding.recv], finding.msg)
+	}
+	for recv, want := range map[string][]string{
+		"Good":   nil,
+		"Forked": nil,
+		"NoContent": {
+			"offset of obj.B defined without a content",
+		},
+		"OutOfOrder": {
+			"content of obj.C defined out of order, expected content of obj.B",
+			"content of obj.B defined out of order, expected content of obj.C",
+		},
+		"Mismatched": {
+			"content of obj.B defined with (64), offset with (32)",
+			"size of dynamic field obj.C not calculated in SizeSSZ",
+		},
+		"LateStatic": {
+			"static field obj.A defined after dynamic contents",
+		},
+	} {
+		have := findings[recv]
+		sort.Strings(have)
+		sort.Strings(want)
+		if strings.Join(have, "\n") != strings.Join(want, "\n") {
+			t.Errorf("%s: findings mismatch:\nhave: %q\nwant: %q", recv, have, want)
+		}
+	}
+}
+
+// Tests that the code generated by sszgen for the test types passes the vet.
+func TestVetGenerated(t *testing.T) {
+	if findings := vetPackage(vetLoad(t, "../../tests/testtypes/consensus-spec-tests")); len(findings) > 0 {
+		for _, finding := range findings {
+			t.Errorf("unexpected finding: %v", finding)
+		}
+	}
+}
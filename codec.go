@@ -5,7 +5,10 @@
 package ssz
 
 import (
+	"fmt"
 	"math/big"
+	"reflect"
+	"unsafe"
 
 	"github.com/holiman/uint256"
 	"github.com/prysmaticlabs/go-bitfield"
@@ -15,13 +18,24 @@ import (
 // define their schemas once and have that work for both operations at once
 // (with the same speed as explicitly typing them out would, of course).
 type Codec struct {
-	fork Fork // Context for cross-fork monolith types
+	fork  Fork      // Context for cross-fork monolith types
+	order ByteOrder // Byte order of the basic types (profile specific)
+
+	entries map[unsafe.Pointer]any // Sorted entries of the maps in the current operation
 
 	enc *Encoder
 	dec *Decoder
 	has *Hasher
 }
 
+// Fork retrieves the current fork (if any) that the codec is operating in. It is
+// meant to be used by hand-written DefineSSZ methods that need to branch on the
+// fork beyond what field filters can express (e.g. choosing between two nested
+// object types).
+func (c *Codec) Fork() Fork {
+	return c.fork
+}
+
 // DefineEncoder uses a dedicated encoder in case the types SSZ conversion is for
 // some reason asymmetric (e.g. encoding depends on fields, decoding depends on
 // outer context).
@@ -55,14 +69,97 @@ func (c *Codec) DefineHasher(impl func(has *Hasher)) {
 	}
 }
 
+// EncodeNested serializes a standalone ssz object at the current position of the
+// encoder. It is meant to be used from within custom encoders (DefineEncoder) to
+// embed standard objects into non-standard wire formats, without corrupting the
+// dynamic offset tracking of the outer object.
+func (c *Codec) EncodeNested(obj Object) {
+	if c.enc != nil {
+		c.enc.encodeNested(obj)
+	}
+}
+
+// DecodeNested parses a standalone ssz object of the given size from the current
+// position of the decoder. It is meant to be used from within custom decoders
+// (DefineDecoder) to extract standard objects from non-standard wire formats,
+// without corrupting the dynamic offset tracking of the outer object.
+func (c *Codec) DecodeNested(obj Object, size uint32) {
+	if c.dec != nil {
+		c.dec.decodeNested(obj, size)
+	}
+}
+
+// DefineObjectFork restricts an entire object to the forks matching a filter. It
+// must be called at the start of DefineSSZ, which needs to return immediately if
+// false is reported: the object does not exist in the codec's fork, so encoding,
+// decoding or hashing it fails with ErrObjectNotInFork.
+//
+// Fork agnostic operations (i.e. ForkUnknown) are always permitted, the guard is
+// meant to catch monolithic objects being used with a fork predating them.
+func DefineObjectFork(c *Codec, filter ForkFilter) bool {
+	if c.fork == ForkUnknown || filter.Active(c.fork) {
+		return true
+	}
+	err := fmt.Errorf("%w: %s, fork %s", ErrObjectNotInFork, filter, forkName(c.fork))
+	switch {
+	case c.enc != nil:
+		if c.enc.err == nil {
+			c.enc.err = err
+		}
+	case c.dec != nil:
+		if c.dec.err == nil {
+			c.dec.err = err
+		}
+	default:
+		if c.has.broken == nil {
+			c.has.broken = err
+		}
+	}
+	return false
+}
+
+// DefineReadOnly restricts an entire object to encoding and hashing. It must be
+// called at the start of DefineSSZ, which needs to return immediately if false
+// is reported: the object is being decoded into, which fails with the error
+// ErrReadOnlyObject.
+//
+// The guard is meant for types with value receivers, where decoding would fill
+// a copy of the object and silently drop the data.
+func DefineReadOnly(c *Codec) bool {
+	if c.dec == nil {
+		return true
+	}
+	if c.dec.err == nil {
+		c.dec.err = ErrReadOnlyObject
+	}
+	return false
+}
+
+// DefineWithFork runs the field definitions in fn with the codec's fork context
+// overridden, restoring it afterwards. It is meant to embed payloads that are
+// bound to a specific (legacy) fork into objects of any fork.
+//
+// The fields defined in fn are sized via the overridden fork too, so SizeSSZ
+// must size them consistently, via SizeWithFork.
+func DefineWithFork(c *Codec, fork Fork, fn func()) {
+	prev := c.fork
+	c.fork = fork
+	defer func() { c.fork = prev }()
+
+	fn()
+}
+
 // DefineBool defines the next field as a 1 byte boolean.
 func DefineBool[T ~bool](c *Codec, v *T) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeBool(c.enc, *v)
 		return
 	}
 	if c.dec != nil {
-		DecodeBool(c.dec, v)
+		if c.dec.nextField() {
+			DecodeBool(c.dec, v)
+		}
 		return
 	}
 	HashBool(c.has, *v)
@@ -72,11 +169,14 @@ func DefineBool[T ~bool](c *Codec, v *T) {
 // in a fork.
 func DefineBoolPointerOnFork[T ~bool](c *Codec, v **T, filter ForkFilter) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeBoolPointerOnFork(c.enc, *v, filter)
 		return
 	}
 	if c.dec != nil {
-		DecodeBoolPointerOnFork(c.dec, v, filter)
+		if c.dec.nextField() {
+			DecodeBoolPointerOnFork(c.dec, v, filter)
+		}
 		return
 	}
 	HashBoolPointerOnFork(c.has, *v, filter)
@@ -85,11 +185,14 @@ func DefineBoolPointerOnFork[T ~bool](c *Codec, v **T, filter ForkFilter) {
 // DefineUint8 defines the next field as a uint8.
 func DefineUint8[T ~uint8](c *Codec, n *T) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeUint8(c.enc, *n)
 		return
 	}
 	if c.dec != nil {
-		DecodeUint8(c.dec, n)
+		if c.dec.nextField() {
+			DecodeUint8(c.dec, n)
+		}
 		return
 	}
 	HashUint8(c.has, *n)
@@ -98,11 +201,14 @@ func DefineUint8[T ~uint8](c *Codec, n *T) {
 // DefineUint8PointerOnFork defines the next field as a uint8 if present in a fork.
 func DefineUint8PointerOnFork[T ~uint8](c *Codec, n **T, filter ForkFilter) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeUint8PointerOnFork(c.enc, *n, filter)
 		return
 	}
 	if c.dec != nil {
-		DecodeUint8PointerOnFork(c.dec, n, filter)
+		if c.dec.nextField() {
+			DecodeUint8PointerOnFork(c.dec, n, filter)
+		}
 		return
 	}
 	HashUint8PointerOnFork(c.has, *n, filter)
@@ -111,11 +217,14 @@ func DefineUint8PointerOnFork[T ~uint8](c *Codec, n **T, filter ForkFilter) {
 // DefineUint16 defines the next field as a uint16.
 func DefineUint16[T ~uint16](c *Codec, n *T) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeUint16(c.enc, *n)
 		return
 	}
 	if c.dec != nil {
-		DecodeUint16(c.dec, n)
+		if c.dec.nextField() {
+			DecodeUint16(c.dec, n)
+		}
 		return
 	}
 	HashUint16(c.has, *n)
@@ -124,11 +233,14 @@ func DefineUint16[T ~uint16](c *Codec, n *T) {
 // DefineUint16PointerOnFork defines the next field as a uint16 if present in a fork.
 func DefineUint16PointerOnFork[T ~uint16](c *Codec, n **T, filter ForkFilter) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeUint16PointerOnFork(c.enc, *n, filter)
 		return
 	}
 	if c.dec != nil {
-		DecodeUint16PointerOnFork(c.dec, n, filter)
+		if c.dec.nextField() {
+			DecodeUint16PointerOnFork(c.dec, n, filter)
+		}
 		return
 	}
 	HashUint16PointerOnFork(c.has, *n, filter)
@@ -137,11 +249,14 @@ func DefineUint16PointerOnFork[T ~uint16](c *Codec, n **T, filter ForkFilter) {
 // DefineUint32 defines the next field as a uint32.
 func DefineUint32[T ~uint32](c *Codec, n *T) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeUint32(c.enc, *n)
 		return
 	}
 	if c.dec != nil {
-		DecodeUint32(c.dec, n)
+		if c.dec.nextField() {
+			DecodeUint32(c.dec, n)
+		}
 		return
 	}
 	HashUint32(c.has, *n)
@@ -150,11 +265,14 @@ func DefineUint32[T ~uint32](c *Codec, n *T) {
 // DefineUint32PointerOnFork defines the next field as a uint32 if present in a fork.
 func DefineUint32PointerOnFork[T ~uint32](c *Codec, n **T, filter ForkFilter) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeUint32PointerOnFork(c.enc, *n, filter)
 		return
 	}
 	if c.dec != nil {
-		DecodeUint32PointerOnFork(c.dec, n, filter)
+		if c.dec.nextField() {
+			DecodeUint32PointerOnFork(c.dec, n, filter)
+		}
 		return
 	}
 	HashUint32PointerOnFork(c.has, *n, filter)
@@ -163,11 +281,14 @@ func DefineUint32PointerOnFork[T ~uint32](c *Codec, n **T, filter ForkFilter) {
 // DefineUint64 defines the next field as a uint64.
 func DefineUint64[T ~uint64](c *Codec, n *T) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeUint64(c.enc, *n)
 		return
 	}
 	if c.dec != nil {
-		DecodeUint64(c.dec, n)
+		if c.dec.nextField() {
+			DecodeUint64(c.dec, n)
+		}
 		return
 	}
 	HashUint64(c.has, *n)
@@ -176,11 +297,14 @@ func DefineUint64[T ~uint64](c *Codec, n *T) {
 // DefineUint64PointerOnFork defines the next field as a uint64 if present in a fork.
 func DefineUint64PointerOnFork[T ~uint64](c *Codec, n **T, filter ForkFilter) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeUint64PointerOnFork(c.enc, *n, filter)
 		return
 	}
 	if c.dec != nil {
-		DecodeUint64PointerOnFork(c.dec, n, filter)
+		if c.dec.nextField() {
+			DecodeUint64PointerOnFork(c.dec, n, filter)
+		}
 		return
 	}
 	HashUint64PointerOnFork(c.has, *n, filter)
@@ -189,11 +313,14 @@ func DefineUint64PointerOnFork[T ~uint64](c *Codec, n **T, filter ForkFilter) {
 // DefineUint256 defines the next field as a uint256.
 func DefineUint256(c *Codec, n **uint256.Int) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeUint256(c.enc, *n)
 		return
 	}
 	if c.dec != nil {
-		DecodeUint256(c.dec, n)
+		if c.dec.nextField() {
+			DecodeUint256(c.dec, n)
+		}
 		return
 	}
 	HashUint256(c.has, *n)
@@ -202,11 +329,55 @@ func DefineUint256(c *Codec, n **uint256.Int) {
 // DefineUint256OnFork defines the next field as a uint256 if present in a fork.
 func DefineUint256OnFork(c *Codec, n **uint256.Int, filter ForkFilter) {
 	if c.enc != nil {
+		c.enc.nextField()
+		EncodeUint256OnFork(c.enc, *n, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeUint256OnFork(c.dec, n, filter)
+		}
+		return
+	}
+	HashUint256OnFork(c.has, *n, filter)
+}
+
+// DefineUint256Max defines the next field as a uint256, rejecting values above a
+// maximum when decoding (or when encoding in checked mode).
+func DefineUint256Max(c *Codec, n **uint256.Int, max uint256.Int) {
+	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked {
+			c.enc.checkUint256(*n, &max)
+		}
+		EncodeUint256(c.enc, *n)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeUint256Max(c.dec, n, max)
+		}
+		return
+	}
+	HashUint256(c.has, *n)
+}
+
+// DefineUint256MaxOnFork defines the next field as a uint256 if present in a
+// fork, rejecting values above a maximum when decoding (or when encoding in
+// checked mode).
+func DefineUint256MaxOnFork(c *Codec, n **uint256.Int, max uint256.Int, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked && filter.Active(c.fork) {
+			c.enc.checkUint256(*n, &max)
+		}
 		EncodeUint256OnFork(c.enc, *n, filter)
 		return
 	}
 	if c.dec != nil {
-		DecodeUint256OnFork(c.dec, n, filter)
+		if c.dec.nextField() {
+			DecodeUint256MaxOnFork(c.dec, n, max, filter)
+		}
 		return
 	}
 	HashUint256OnFork(c.has, *n, filter)
@@ -215,11 +386,14 @@ func DefineUint256OnFork(c *Codec, n **uint256.Int, filter ForkFilter) {
 // DefineUint256BigInt defines the next field as a uint256.
 func DefineUint256BigInt(c *Codec, n **big.Int) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeUint256BigInt(c.enc, *n)
 		return
 	}
 	if c.dec != nil {
-		DecodeUint256BigInt(c.dec, n)
+		if c.dec.nextField() {
+			DecodeUint256BigInt(c.dec, n)
+		}
 		return
 	}
 	HashUint256BigInt(c.has, *n)
@@ -229,11 +403,55 @@ func DefineUint256BigInt(c *Codec, n **big.Int) {
 // fork.
 func DefineUint256BigIntOnFork(c *Codec, n **big.Int, filter ForkFilter) {
 	if c.enc != nil {
+		c.enc.nextField()
+		EncodeUint256BigIntOnFork(c.enc, *n, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeUint256BigIntOnFork(c.dec, n, filter)
+		}
+		return
+	}
+	HashUint256BigIntOnFork(c.has, *n, filter)
+}
+
+// DefineUint256BigIntMax defines the next field as a uint256, rejecting values
+// above a maximum when decoding (or when encoding in checked mode).
+func DefineUint256BigIntMax(c *Codec, n **big.Int, max uint256.Int) {
+	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked {
+			c.enc.checkUint256BigInt(*n, &max)
+		}
+		EncodeUint256BigInt(c.enc, *n)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeUint256BigIntMax(c.dec, n, max)
+		}
+		return
+	}
+	HashUint256BigInt(c.has, *n)
+}
+
+// DefineUint256BigIntMaxOnFork defines the next field as a uint256 if present in
+// a fork, rejecting values above a maximum when decoding (or when encoding in
+// checked mode).
+func DefineUint256BigIntMaxOnFork(c *Codec, n **big.Int, max uint256.Int, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked && filter.Active(c.fork) {
+			c.enc.checkUint256BigInt(*n, &max)
+		}
 		EncodeUint256BigIntOnFork(c.enc, *n, filter)
 		return
 	}
 	if c.dec != nil {
-		DecodeUint256BigIntOnFork(c.dec, n, filter)
+		if c.dec.nextField() {
+			DecodeUint256BigIntMaxOnFork(c.dec, n, max, filter)
+		}
 		return
 	}
 	HashUint256BigIntOnFork(c.has, *n, filter)
@@ -243,11 +461,14 @@ func DefineUint256BigIntOnFork(c *Codec, n **big.Int, filter ForkFilter) {
 // can be used for byte arrays.
 func DefineStaticBytes[T commonBytesLengths](c *Codec, blob *T) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeStaticBytes(c.enc, blob)
 		return
 	}
 	if c.dec != nil {
-		DecodeStaticBytes(c.dec, blob)
+		if c.dec.nextField() {
+			DecodeStaticBytes(c.dec, blob)
+		}
 		return
 	}
 	HashStaticBytes(c.has, blob)
@@ -257,11 +478,14 @@ func DefineStaticBytes[T commonBytesLengths](c *Codec, blob *T) {
 // in a fork. This method can be used for byte arrays.
 func DefineStaticBytesPointerOnFork[T commonBytesLengths](c *Codec, blob **T, filter ForkFilter) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeStaticBytesPointerOnFork(c.enc, *blob, filter)
 		return
 	}
 	if c.dec != nil {
-		DecodeStaticBytesPointerOnFork(c.dec, blob, filter)
+		if c.dec.nextField() {
+			DecodeStaticBytesPointerOnFork(c.dec, blob, filter)
+		}
 		return
 	}
 	HashStaticBytesPointerOnFork(c.has, *blob, filter)
@@ -272,11 +496,18 @@ func DefineStaticBytesPointerOnFork[T commonBytesLengths](c *Codec, blob **T, fi
 // needs runtime size validation.
 func DefineCheckedStaticBytes(c *Codec, blob *[]byte, size uint64) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeCheckedStaticBytes(c.enc, *blob, size)
 		return
 	}
 	if c.dec != nil {
-		DecodeCheckedStaticBytes(c.dec, blob, size)
+		if c.dec.nextField() {
+			DecodeCheckedStaticBytes(c.dec, blob, size)
+		}
+		return
+	}
+	if c.has.schema != nil {
+		c.has.schema.field("CheckedStaticBytes", int(size), nil)
 		return
 	}
 	HashCheckedStaticBytes(c.has, *blob)
@@ -285,11 +516,17 @@ func DefineCheckedStaticBytes(c *Codec, blob *[]byte, size uint64) {
 // DefineDynamicBytesOffset defines the next field as dynamic binary blob.
 func DefineDynamicBytesOffset(c *Codec, blob *[]byte, maxSize uint64) {
 	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked {
+			c.enc.checkBytes(len(*blob), maxSize)
+		}
 		EncodeDynamicBytesOffset(c.enc, *blob)
 		return
 	}
 	if c.dec != nil {
-		DecodeDynamicBytesOffset(c.dec, blob)
+		if c.dec.nextField() {
+			DecodeDynamicBytesOffset(c.dec, blob)
+		}
 		return
 	}
 	HashDynamicBytes(c.has, *blob, maxSize)
@@ -299,11 +536,17 @@ func DefineDynamicBytesOffset(c *Codec, blob *[]byte, maxSize uint64) {
 // if present in a fork.
 func DefineDynamicBytesOffsetOnFork(c *Codec, blob *[]byte, maxSize uint64, filter ForkFilter) {
 	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked && filter.Active(c.fork) {
+			c.enc.checkBytes(len(*blob), maxSize)
+		}
 		EncodeDynamicBytesOffsetOnFork(c.enc, *blob, filter)
 		return
 	}
 	if c.dec != nil {
-		DecodeDynamicBytesOffsetOnFork(c.dec, blob, filter)
+		if c.dec.nextField() {
+			DecodeDynamicBytesOffsetOnFork(c.dec, blob, filter)
+		}
 		return
 	}
 	HashDynamicBytesOnFork(c.has, *blob, maxSize, filter)
@@ -312,11 +555,14 @@ func DefineDynamicBytesOffsetOnFork(c *Codec, blob *[]byte, maxSize uint64, filt
 // DefineDynamicBytesContent defines the next field as dynamic binary blob.
 func DefineDynamicBytesContent(c *Codec, blob *[]byte, maxSize uint64) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeDynamicBytesContent(c.enc, *blob)
 		return
 	}
 	if c.dec != nil {
-		DecodeDynamicBytesContent(c.dec, blob, maxSize)
+		if c.dec.nextField() {
+			DecodeDynamicBytesContent(c.dec, blob, maxSize)
+		}
 		return
 	}
 	// No hashing, done at the offset position
@@ -326,11 +572,123 @@ func DefineDynamicBytesContent(c *Codec, blob *[]byte, maxSize uint64) {
 // if present in a fork.
 func DefineDynamicBytesContentOnFork(c *Codec, blob *[]byte, maxSize uint64, filter ForkFilter) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeDynamicBytesContentOnFork(c.enc, *blob, filter)
 		return
 	}
 	if c.dec != nil {
-		DecodeDynamicBytesContentOnFork(c.dec, blob, maxSize, filter)
+		if c.dec.nextField() {
+			DecodeDynamicBytesContentOnFork(c.dec, blob, maxSize, filter)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineStringOffset defines the next field as a string, encoded as a dynamic
+// binary blob.
+func DefineStringOffset[T ~string](c *Codec, str *T, maxSize uint64) {
+	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked {
+			c.enc.checkBytes(len(*str), maxSize)
+		}
+		EncodeStringOffset(c.enc, *str)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeStringOffset(c.dec, str)
+		}
+		return
+	}
+	HashString(c.has, *str, maxSize)
+}
+
+// DefineStringOffsetOnFork defines the next field as a string, encoded as a
+// dynamic binary blob if present in a fork.
+func DefineStringOffsetOnFork[T ~string](c *Codec, str *T, maxSize uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked && filter.Active(c.fork) {
+			c.enc.checkBytes(len(*str), maxSize)
+		}
+		EncodeStringOffsetOnFork(c.enc, *str, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeStringOffsetOnFork(c.dec, str, filter)
+		}
+		return
+	}
+	HashStringOnFork(c.has, *str, maxSize, filter)
+}
+
+// DefineStringContent defines the next field as a string, encoded as a dynamic
+// binary blob.
+func DefineStringContent[T ~string](c *Codec, str *T, maxSize uint64) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeStringContent(c.enc, *str)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeStringContent(c.dec, str, maxSize)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineStringContentOnFork defines the next field as a string, encoded as a
+// dynamic binary blob if present in a fork.
+func DefineStringContentOnFork[T ~string](c *Codec, str *T, maxSize uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeStringContentOnFork(c.enc, *str, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeStringContentOnFork(c.dec, str, maxSize, filter)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineUTF8StringContent defines the next field as a string, encoded as a
+// dynamic binary blob, which is validated to be UTF-8 when decoding.
+func DefineUTF8StringContent[T ~string](c *Codec, str *T, maxSize uint64) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeStringContent(c.enc, *str)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeUTF8StringContent(c.dec, str, maxSize)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineUTF8StringContentOnFork defines the next field as a string, encoded as
+// a dynamic binary blob, which is validated to be UTF-8 when decoding, if present
+// in a fork.
+func DefineUTF8StringContentOnFork[T ~string](c *Codec, str *T, maxSize uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeStringContentOnFork(c.enc, *str, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeUTF8StringContentOnFork(c.dec, str, maxSize, filter)
+		}
 		return
 	}
 	// No hashing, done at the offset position
@@ -339,11 +697,14 @@ func DefineDynamicBytesContentOnFork(c *Codec, blob *[]byte, maxSize uint64, fil
 // DefineStaticObject defines the next field as a static ssz object.
 func DefineStaticObject[T newableStaticObject[U], U any](c *Codec, obj *T) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeStaticObject(c.enc, *obj)
 		return
 	}
 	if c.dec != nil {
-		DecodeStaticObject(c.dec, obj)
+		if c.dec.nextField() {
+			DecodeStaticObject(c.dec, obj)
+		}
 		return
 	}
 	HashStaticObject(c.has, *obj)
@@ -353,11 +714,14 @@ func DefineStaticObject[T newableStaticObject[U], U any](c *Codec, obj *T) {
 // present in a fork.
 func DefineStaticObjectOnFork[T newableStaticObject[U], U any](c *Codec, obj *T, filter ForkFilter) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeStaticObjectOnFork(c.enc, *obj, filter)
 		return
 	}
 	if c.dec != nil {
-		DecodeStaticObjectOnFork(c.dec, obj, filter)
+		if c.dec.nextField() {
+			DecodeStaticObjectOnFork(c.dec, obj, filter)
+		}
 		return
 	}
 	HashStaticObjectOnFork(c.has, *obj, filter)
@@ -366,11 +730,14 @@ func DefineStaticObjectOnFork[T newableStaticObject[U], U any](c *Codec, obj *T,
 // DefineDynamicObjectOffset defines the next field as a dynamic ssz object.
 func DefineDynamicObjectOffset[T newableDynamicObject[U], U any](c *Codec, obj *T) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeDynamicObjectOffset(c.enc, *obj)
 		return
 	}
 	if c.dec != nil {
-		DecodeDynamicObjectOffset(c.dec, obj)
+		if c.dec.nextField() {
+			DecodeDynamicObjectOffset(c.dec, obj)
+		}
 		return
 	}
 	HashDynamicObject(c.has, *obj)
@@ -380,11 +747,14 @@ func DefineDynamicObjectOffset[T newableDynamicObject[U], U any](c *Codec, obj *
 // if present in a fork.
 func DefineDynamicObjectOffsetOnFork[T newableDynamicObject[U], U any](c *Codec, obj *T, filter ForkFilter) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeDynamicObjectOffsetOnFork(c.enc, *obj, filter)
 		return
 	}
 	if c.dec != nil {
-		DecodeDynamicObjectOffsetOnFork(c.dec, obj, filter)
+		if c.dec.nextField() {
+			DecodeDynamicObjectOffsetOnFork(c.dec, obj, filter)
+		}
 		return
 	}
 	HashDynamicObjectOnFork(c.has, *obj, filter)
@@ -393,11 +763,14 @@ func DefineDynamicObjectOffsetOnFork[T newableDynamicObject[U], U any](c *Codec,
 // DefineDynamicObjectContent defines the next field as a dynamic ssz object.
 func DefineDynamicObjectContent[T newableDynamicObject[U], U any](c *Codec, obj *T) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeDynamicObjectContent(c.enc, *obj)
 		return
 	}
 	if c.dec != nil {
-		DecodeDynamicObjectContent(c.dec, obj)
+		if c.dec.nextField() {
+			DecodeDynamicObjectContent(c.dec, obj)
+		}
 		return
 	}
 	// No hashing, done at the offset position
@@ -407,11 +780,116 @@ func DefineDynamicObjectContent[T newableDynamicObject[U], U any](c *Codec, obj
 // if present in a fork.
 func DefineDynamicObjectContentOnFork[T newableDynamicObject[U], U any](c *Codec, obj *T, filter ForkFilter) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeDynamicObjectContentOnFork(c.enc, *obj, filter)
 		return
 	}
 	if c.dec != nil {
-		DecodeDynamicObjectContentOnFork(c.dec, obj, filter)
+		if c.dec.nextField() {
+			DecodeDynamicObjectContentOnFork(c.dec, obj, filter)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineGenericStaticObject defines the next field as a static ssz object passed
+// as a type parameter of a generic container.
+func DefineGenericStaticObject[T StaticObject](c *Codec, obj *T) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeGenericStaticObject(c.enc, *obj)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeGenericStaticObject(c.dec, obj)
+		}
+		return
+	}
+	HashGenericStaticObject(c.has, *obj)
+}
+
+// DefineGenericStaticObjectOnFork defines the next field as a static ssz object
+// passed as a type parameter of a generic container if present in a fork.
+func DefineGenericStaticObjectOnFork[T StaticObject](c *Codec, obj *T, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeGenericStaticObjectOnFork(c.enc, *obj, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeGenericStaticObjectOnFork(c.dec, obj, filter)
+		}
+		return
+	}
+	HashGenericStaticObjectOnFork(c.has, *obj, filter)
+}
+
+// DefineGenericDynamicObjectOffset defines the next field as a dynamic ssz object
+// passed as a type parameter of a generic container.
+func DefineGenericDynamicObjectOffset[T DynamicObject](c *Codec, obj *T) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeGenericDynamicObjectOffset(c.enc, *obj)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeGenericDynamicObjectOffset(c.dec, obj)
+		}
+		return
+	}
+	HashGenericDynamicObject(c.has, *obj)
+}
+
+// DefineGenericDynamicObjectOffsetOnFork defines the next field as a dynamic ssz
+// object passed as a type parameter of a generic container if present in a fork.
+func DefineGenericDynamicObjectOffsetOnFork[T DynamicObject](c *Codec, obj *T, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeGenericDynamicObjectOffsetOnFork(c.enc, *obj, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeGenericDynamicObjectOffsetOnFork(c.dec, obj, filter)
+		}
+		return
+	}
+	HashGenericDynamicObjectOnFork(c.has, *obj, filter)
+}
+
+// DefineGenericDynamicObjectContent defines the next field as a dynamic ssz
+// object passed as a type parameter of a generic container.
+func DefineGenericDynamicObjectContent[T DynamicObject](c *Codec, obj *T) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeGenericDynamicObjectContent(c.enc, *obj)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeGenericDynamicObjectContent(c.dec, obj)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineGenericDynamicObjectContentOnFork defines the next field as a dynamic ssz
+// object passed as a type parameter of a generic container if present in a fork.
+func DefineGenericDynamicObjectContentOnFork[T DynamicObject](c *Codec, obj *T, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeGenericDynamicObjectContentOnFork(c.enc, *obj, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeGenericDynamicObjectContentOnFork(c.dec, obj, filter)
+		}
 		return
 	}
 	// No hashing, done at the offset position
@@ -420,11 +898,14 @@ func DefineDynamicObjectContentOnFork[T newableDynamicObject[U], U any](c *Codec
 // DefineArrayOfBits defines the next field as a static array of (packed) bits.
 func DefineArrayOfBits[T commonBitsLengths](c *Codec, bits *T, size uint64) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeArrayOfBits(c.enc, bits)
 		return
 	}
 	if c.dec != nil {
-		DecodeArrayOfBits(c.dec, bits, size)
+		if c.dec.nextField() {
+			DecodeArrayOfBits(c.dec, bits, size)
+		}
 		return
 	}
 	HashArrayOfBits(c.has, bits)
@@ -434,25 +915,104 @@ func DefineArrayOfBits[T commonBitsLengths](c *Codec, bits *T, size uint64) {
 // (packed) bits if present in a fork.
 func DefineArrayOfBitsPointerOnFork[T commonBitsLengths](c *Codec, bits **T, size uint64, filter ForkFilter) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeArrayOfBitsPointerOnFork(c.enc, *bits, filter)
 		return
 	}
 	if c.dec != nil {
-		DecodeArrayOfBitsPointerOnFork(c.dec, bits, size, filter)
+		if c.dec.nextField() {
+			DecodeArrayOfBitsPointerOnFork(c.dec, bits, size, filter)
+		}
 		return
 	}
 	HashArrayOfBitsPointerOnFork(c.has, *bits, filter)
 }
 
+// DefineUnsafeArrayOfBits defines the next field as a static array of static
+// arrays of (packed) bits.
+func DefineUnsafeArrayOfBits[T commonBitsLengths](c *Codec, bits []T, size uint64) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeUnsafeArrayOfBits(c.enc, bits)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeUnsafeArrayOfBits(c.dec, bits, size)
+		}
+		return
+	}
+	HashUnsafeArrayOfBits(c.has, bits)
+}
+
+// DefineCheckedArrayOfBits defines the next field as a static array of static
+// arrays of (packed) bits. This method can be used for plain slices of bit
+// arrays, which is more expensive since it needs runtime size validation.
+func DefineCheckedArrayOfBits[T commonBitsLengths](c *Codec, bits *[]T, items uint64, size uint64) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeCheckedArrayOfBits(c.enc, *bits, items)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeCheckedArrayOfBits(c.dec, bits, items, size)
+		}
+		return
+	}
+	HashCheckedArrayOfBits(c.has, *bits, items)
+}
+
+// DefineCheckedBits defines the next field as a static (packed) bit slice, such
+// as one of go-bitfield's bitvectors. This method can be used for plain byte
+// slices, which is more expensive, since it needs runtime size validation.
+func DefineCheckedBits[T ~[]byte](c *Codec, bits *T, size uint64) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeCheckedBits(c.enc, *bits, size)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeCheckedBits(c.dec, bits, size)
+		}
+		return
+	}
+	HashCheckedBits(c.has, *bits, size)
+}
+
+// DefineUnsafeArrayOfCheckedBits defines the next field as a static array of
+// (packed) bit slices, such as go-bitfield's bitvectors.
+func DefineUnsafeArrayOfCheckedBits[T ~[]byte](c *Codec, bits []T, size uint64) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeUnsafeArrayOfCheckedBits(c.enc, bits, size)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeUnsafeArrayOfCheckedBits(c.dec, bits, size)
+		}
+		return
+	}
+	HashUnsafeArrayOfCheckedBits(c.has, bits, size)
+}
+
 // DefineSliceOfBitsOffset defines the next field as a dynamic slice of (packed)
 // bits.
 func DefineSliceOfBitsOffset(c *Codec, bits *bitfield.Bitlist, maxBits uint64) {
 	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked {
+			c.enc.checkItems(int(bits.Len()), maxBits)
+		}
 		EncodeSliceOfBitsOffset(c.enc, *bits)
 		return
 	}
 	if c.dec != nil {
-		DecodeSliceOfBitsOffset(c.dec, bits)
+		if c.dec.nextField() {
+			DecodeSliceOfBitsOffset(c.dec, bits)
+		}
 		return
 	}
 	HashSliceOfBits(c.has, *bits, maxBits)
@@ -462,11 +1022,17 @@ func DefineSliceOfBitsOffset(c *Codec, bits *bitfield.Bitlist, maxBits uint64) {
 // (packed) bits if present in a fork.
 func DefineSliceOfBitsOffsetOnFork(c *Codec, bits *bitfield.Bitlist, maxBits uint64, filter ForkFilter) {
 	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked && filter.Active(c.fork) {
+			c.enc.checkItems(int(bits.Len()), maxBits)
+		}
 		EncodeSliceOfBitsOffsetOnFork(c.enc, *bits, filter)
 		return
 	}
 	if c.dec != nil {
-		DecodeSliceOfBitsOffsetOnFork(c.dec, bits, filter)
+		if c.dec.nextField() {
+			DecodeSliceOfBitsOffsetOnFork(c.dec, bits, filter)
+		}
 		return
 	}
 	HashSliceOfBitsOnFork(c.has, *bits, maxBits, filter)
@@ -476,11 +1042,14 @@ func DefineSliceOfBitsOffsetOnFork(c *Codec, bits *bitfield.Bitlist, maxBits uin
 // bits.
 func DefineSliceOfBitsContent(c *Codec, bits *bitfield.Bitlist, maxBits uint64) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeSliceOfBitsContent(c.enc, *bits)
 		return
 	}
 	if c.dec != nil {
-		DecodeSliceOfBitsContent(c.dec, bits, maxBits)
+		if c.dec.nextField() {
+			DecodeSliceOfBitsContent(c.dec, bits, maxBits)
+		}
 		return
 	}
 	// No hashing, done at the offset position
@@ -490,11 +1059,14 @@ func DefineSliceOfBitsContent(c *Codec, bits *bitfield.Bitlist, maxBits uint64)
 // (packed) bits if present in a fork.
 func DefineSliceOfBitsContentOnFork(c *Codec, bits *bitfield.Bitlist, maxBits uint64, filter ForkFilter) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeSliceOfBitsContentOnFork(c.enc, *bits, filter)
 		return
 	}
 	if c.dec != nil {
-		DecodeSliceOfBitsContentOnFork(c.dec, bits, maxBits, filter)
+		if c.dec.nextField() {
+			DecodeSliceOfBitsContentOnFork(c.dec, bits, maxBits, filter)
+		}
 		return
 	}
 	// No hashing, done at the offset position
@@ -503,11 +1075,14 @@ func DefineSliceOfBitsContentOnFork(c *Codec, bits *bitfield.Bitlist, maxBits ui
 // DefineArrayOfUint64s defines the next field as a static array of uint64s.
 func DefineArrayOfUint64s[T commonUint64sLengths](c *Codec, ns *T) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeArrayOfUint64s(c.enc, ns)
 		return
 	}
 	if c.dec != nil {
-		DecodeArrayOfUint64s(c.dec, ns)
+		if c.dec.nextField() {
+			DecodeArrayOfUint64s(c.dec, ns)
+		}
 		return
 	}
 	HashArrayOfUint64s(c.has, ns)
@@ -517,180 +1092,709 @@ func DefineArrayOfUint64s[T commonUint64sLengths](c *Codec, ns *T) {
 // uint64s if present in a fork.
 func DefineArrayOfUint64sPointerOnFork[T commonUint64sLengths](c *Codec, ns **T, filter ForkFilter) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeArrayOfUint64sPointerOnFork(c.enc, *ns, filter)
 		return
 	}
 	if c.dec != nil {
-		DecodeArrayOfUint64sPointerOnFork(c.dec, ns, filter)
+		if c.dec.nextField() {
+			DecodeArrayOfUint64sPointerOnFork(c.dec, ns, filter)
+		}
 		return
 	}
 	HashArrayOfUint64sPointerOnFork(c.has, *ns, filter)
 }
 
-// DefineSliceOfUint64sOffset defines the next field as a dynamic slice of uint64s.
-func DefineSliceOfUint64sOffset[T ~uint64](c *Codec, ns *[]T, maxItems uint64) {
+// DefineCheckedStaticUint64s defines the next field as a static array of uint64s.
+// This method can be used for plain uint64 slices, which is more expensive since
+// it needs runtime size validation.
+func DefineCheckedStaticUint64s[T ~uint64](c *Codec, ns *[]T, size uint64) {
 	if c.enc != nil {
-		EncodeSliceOfUint64sOffset(c.enc, *ns)
+		c.enc.nextField()
+		EncodeCheckedStaticUint64s(c.enc, *ns, size)
 		return
 	}
 	if c.dec != nil {
-		DecodeSliceOfUint64sOffset(c.dec, ns)
+		if c.dec.nextField() {
+			DecodeCheckedStaticUint64s(c.dec, ns, size)
+		}
 		return
 	}
-	HashSliceOfUint64s(c.has, *ns, maxItems)
+	HashCheckedStaticUint64s(c.has, *ns, size)
 }
 
-// DefineSliceOfUint64sOffsetOnFork defines the next field as a dynamic slice of
-// uint64s if present in a fork.
-func DefineSliceOfUint64sOffsetOnFork[T ~uint64](c *Codec, ns *[]T, maxItems uint64, filter ForkFilter) {
+// DefineArrayOfUint16s defines the next field as a static array of uint16s.
+func DefineArrayOfUint16s[T commonUint16sLengths](c *Codec, ns *T) {
 	if c.enc != nil {
-		EncodeSliceOfUint64sOffsetOnFork(c.enc, *ns, filter)
+		c.enc.nextField()
+		EncodeArrayOfUint16s(c.enc, ns)
 		return
 	}
 	if c.dec != nil {
-		DecodeSliceOfUint64sOffsetOnFork(c.dec, ns, filter)
+		if c.dec.nextField() {
+			DecodeArrayOfUint16s(c.dec, ns)
+		}
 		return
 	}
-	HashSliceOfUint64sOnFork(c.has, *ns, maxItems, filter)
+	HashArrayOfUint16s(c.has, ns)
 }
 
-// DefineSliceOfUint64sContent defines the next field as a dynamic slice of uint64s.
-func DefineSliceOfUint64sContent[T ~uint64](c *Codec, ns *[]T, maxItems uint64) {
+// DefineArrayOfUint16sPointerOnFork defines the next field as a static array of
+// uint16s if present in a fork.
+func DefineArrayOfUint16sPointerOnFork[T commonUint16sLengths](c *Codec, ns **T, filter ForkFilter) {
 	if c.enc != nil {
-		EncodeSliceOfUint64sContent(c.enc, *ns)
+		c.enc.nextField()
+		EncodeArrayOfUint16sPointerOnFork(c.enc, *ns, filter)
 		return
 	}
 	if c.dec != nil {
-		DecodeSliceOfUint64sContent(c.dec, ns, maxItems)
+		if c.dec.nextField() {
+			DecodeArrayOfUint16sPointerOnFork(c.dec, ns, filter)
+		}
 		return
 	}
-	// No hashing, done at the offset position
+	HashArrayOfUint16sPointerOnFork(c.has, *ns, filter)
 }
 
-// DefineSliceOfUint64sContentOnFork defines the next field as a dynamic slice of
-// uint64s if present in a fork.
-func DefineSliceOfUint64sContentOnFork[T ~uint64](c *Codec, ns *[]T, maxItems uint64, filter ForkFilter) {
+// DefineArrayOfUint32s defines the next field as a static array of uint32s.
+func DefineArrayOfUint32s[T commonUint32sLengths](c *Codec, ns *T) {
 	if c.enc != nil {
-		EncodeSliceOfUint64sContentOnFork(c.enc, *ns, filter)
+		c.enc.nextField()
+		EncodeArrayOfUint32s(c.enc, ns)
 		return
 	}
 	if c.dec != nil {
-		DecodeSliceOfUint64sContentOnFork(c.dec, ns, maxItems, filter)
+		if c.dec.nextField() {
+			DecodeArrayOfUint32s(c.dec, ns)
+		}
 		return
 	}
-	// No hashing, done at the offset position
+	HashArrayOfUint32s(c.has, ns)
 }
 
-// DefineArrayOfStaticBytes defines the next field as a static array of static
-// binary blobs.
-func DefineArrayOfStaticBytes[T commonBytesArrayLengths[U], U commonBytesLengths](c *Codec, blobs *T) {
+// DefineArrayOfUint32sPointerOnFork defines the next field as a static array of
+// uint32s if present in a fork.
+func DefineArrayOfUint32sPointerOnFork[T commonUint32sLengths](c *Codec, ns **T, filter ForkFilter) {
 	if c.enc != nil {
-		EncodeArrayOfStaticBytes[T, U](c.enc, blobs)
+		c.enc.nextField()
+		EncodeArrayOfUint32sPointerOnFork(c.enc, *ns, filter)
 		return
 	}
 	if c.dec != nil {
-		DecodeArrayOfStaticBytes[T, U](c.dec, blobs)
+		if c.dec.nextField() {
+			DecodeArrayOfUint32sPointerOnFork(c.dec, ns, filter)
+		}
 		return
 	}
-	HashArrayOfStaticBytes[T, U](c.has, blobs)
+	HashArrayOfUint32sPointerOnFork(c.has, *ns, filter)
 }
 
-// DefineUnsafeArrayOfStaticBytes defines the next field as a static array of
-// static binary blobs. This method operates on plain slices of byte arrays and
-// will crash if provided a slice of a non-array. Its purpose is to get around
-// Go's generics limitations in generated code (use DefineArrayOfStaticBytes).
-func DefineUnsafeArrayOfStaticBytes[T commonBytesLengths](c *Codec, blobs []T) {
+// DefineArrayOfArrayOfUint16s defines the next field as a static array of static
+// arrays of uint16s (e.g. [4][8192]uint16). The outer array is passed as a slice to
+// get around Go's generics limitations in generated code.
+func DefineArrayOfArrayOfUint16s[T commonUint16sLengths](c *Codec, ns []T) {
 	if c.enc != nil {
-		EncodeUnsafeArrayOfStaticBytes(c.enc, blobs)
+		c.enc.nextField()
+		EncodeArrayOfArrayOfUint16s(c.enc, ns)
 		return
 	}
 	if c.dec != nil {
-		DecodeUnsafeArrayOfStaticBytes(c.dec, blobs)
+		if c.dec.nextField() {
+			DecodeArrayOfArrayOfUint16s(c.dec, ns)
+		}
 		return
 	}
-	HashUnsafeArrayOfStaticBytes(c.has, blobs)
+	HashArrayOfArrayOfUint16s(c.has, ns)
 }
 
-// DefineCheckedArrayOfStaticBytes defines the next field as a static array of
-// static binary blobs. This method can be used for plain slices of byte arrays,
-// which is more expensive since it needs runtime size validation.
-func DefineCheckedArrayOfStaticBytes[T commonBytesLengths](c *Codec, blobs *[]T, size uint64) {
+// DefineArrayOfArrayOfUint32s defines the next field as a static array of static
+// arrays of uint32s (e.g. [4][8192]uint32). The outer array is passed as a slice to
+// get around Go's generics limitations in generated code.
+func DefineArrayOfArrayOfUint32s[T commonUint32sLengths](c *Codec, ns []T) {
 	if c.enc != nil {
-		EncodeCheckedArrayOfStaticBytes(c.enc, *blobs, size)
+		c.enc.nextField()
+		EncodeArrayOfArrayOfUint32s(c.enc, ns)
 		return
 	}
 	if c.dec != nil {
-		DecodeCheckedArrayOfStaticBytes(c.dec, blobs, size)
+		if c.dec.nextField() {
+			DecodeArrayOfArrayOfUint32s(c.dec, ns)
+		}
 		return
 	}
-	HashCheckedArrayOfStaticBytes(c.has, *blobs)
+	HashArrayOfArrayOfUint32s(c.has, ns)
 }
 
-// DefineSliceOfStaticBytesOffset defines the next field as a dynamic slice of
-// static binary blobs.
-func DefineSliceOfStaticBytesOffset[T commonBytesLengths](c *Codec, bytes *[]T, maxItems uint64) {
+// DefineArrayOfArrayOfUint64s defines the next field as a static array of static
+// arrays of uint64s (e.g. [4][8192]uint64). The outer array is passed as a slice to
+// get around Go's generics limitations in generated code.
+func DefineArrayOfArrayOfUint64s[T commonUint64sLengths](c *Codec, ns []T) {
 	if c.enc != nil {
-		EncodeSliceOfStaticBytesOffset(c.enc, *bytes)
+		c.enc.nextField()
+		EncodeArrayOfArrayOfUint64s(c.enc, ns)
 		return
 	}
 	if c.dec != nil {
-		DecodeSliceOfStaticBytesOffset(c.dec, bytes)
+		if c.dec.nextField() {
+			DecodeArrayOfArrayOfUint64s(c.dec, ns)
+		}
 		return
 	}
-	HashSliceOfStaticBytes(c.has, *bytes, maxItems)
+	HashArrayOfArrayOfUint64s(c.has, ns)
 }
 
-// DefineSliceOfStaticBytesOffsetOnFork defines the next field as a dynamic slice
-// of static binary blobs if present in a fork.
-func DefineSliceOfStaticBytesOffsetOnFork[T commonBytesLengths](c *Codec, bytes *[]T, maxItems uint64, filter ForkFilter) {
+// DefineSliceOfUint16sOffset defines the next field as a dynamic slice of uint16s.
+func DefineSliceOfUint16sOffset[T ~uint16](c *Codec, ns *[]T, maxItems uint64) {
 	if c.enc != nil {
-		EncodeSliceOfStaticBytesOffsetOnFork(c.enc, *bytes, filter)
+		c.enc.nextField()
+		if c.enc.checked {
+			c.enc.checkItems(len(*ns), maxItems)
+		}
+		EncodeSliceOfUint16sOffset(c.enc, *ns)
 		return
 	}
 	if c.dec != nil {
-		DecodeSliceOfStaticBytesOffsetOnFork(c.dec, bytes, filter)
+		if c.dec.nextField() {
+			DecodeSliceOfUint16sOffset(c.dec, ns)
+		}
 		return
 	}
-	HashSliceOfStaticBytesOnFork(c.has, *bytes, maxItems, filter)
+	HashSliceOfUint16s(c.has, *ns, maxItems)
 }
 
-// DefineSliceOfStaticBytesContent defines the next field as a dynamic slice of static
-// binary blobs.
-func DefineSliceOfStaticBytesContent[T commonBytesLengths](c *Codec, blobs *[]T, maxItems uint64) {
+// DefineSliceOfUint16sOffsetOnFork defines the next field as a dynamic slice of
+// uint16s if present in a fork.
+func DefineSliceOfUint16sOffsetOnFork[T ~uint16](c *Codec, ns *[]T, maxItems uint64, filter ForkFilter) {
 	if c.enc != nil {
-		EncodeSliceOfStaticBytesContent(c.enc, *blobs)
+		c.enc.nextField()
+		if c.enc.checked && filter.Active(c.fork) {
+			c.enc.checkItems(len(*ns), maxItems)
+		}
+		EncodeSliceOfUint16sOffsetOnFork(c.enc, *ns, filter)
 		return
 	}
 	if c.dec != nil {
-		DecodeSliceOfStaticBytesContent(c.dec, blobs, maxItems)
+		if c.dec.nextField() {
+			DecodeSliceOfUint16sOffsetOnFork(c.dec, ns, filter)
+		}
 		return
 	}
-	// No hashing, done at the offset position
+	HashSliceOfUint16sOnFork(c.has, *ns, maxItems, filter)
 }
 
-// DefineSliceOfStaticBytesContentOnFork defines the next field as a dynamic slice
-// of static binary blobs if present in a fork.
-func DefineSliceOfStaticBytesContentOnFork[T commonBytesLengths](c *Codec, blobs *[]T, maxItems uint64, filter ForkFilter) {
+// DefineSliceOfUint16sContent defines the next field as a dynamic slice of uint16s.
+func DefineSliceOfUint16sContent[T ~uint16](c *Codec, ns *[]T, maxItems uint64) {
 	if c.enc != nil {
-		EncodeSliceOfStaticBytesContentOnFork(c.enc, *blobs, filter)
+		c.enc.nextField()
+		EncodeSliceOfUint16sContent(c.enc, *ns)
 		return
 	}
 	if c.dec != nil {
-		DecodeSliceOfStaticBytesContentOnFork(c.dec, blobs, maxItems, filter)
+		if c.dec.nextField() {
+			DecodeSliceOfUint16sContent(c.dec, ns, maxItems)
+		}
 		return
 	}
 	// No hashing, done at the offset position
 }
 
-// DefineSliceOfDynamicBytesOffset defines the next field as a dynamic slice of
-// dynamic binary blobs.
+// DefineSliceOfUint16sContentOnFork defines the next field as a dynamic slice of
+// uint16s if present in a fork.
+func DefineSliceOfUint16sContentOnFork[T ~uint16](c *Codec, ns *[]T, maxItems uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeSliceOfUint16sContentOnFork(c.enc, *ns, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSliceOfUint16sContentOnFork(c.dec, ns, maxItems, filter)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineSliceOfUint32sOffset defines the next field as a dynamic slice of uint32s.
+func DefineSliceOfUint32sOffset[T ~uint32](c *Codec, ns *[]T, maxItems uint64) {
+	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked {
+			c.enc.checkItems(len(*ns), maxItems)
+		}
+		EncodeSliceOfUint32sOffset(c.enc, *ns)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSliceOfUint32sOffset(c.dec, ns)
+		}
+		return
+	}
+	HashSliceOfUint32s(c.has, *ns, maxItems)
+}
+
+// DefineSliceOfUint32sOffsetOnFork defines the next field as a dynamic slice of
+// uint32s if present in a fork.
+func DefineSliceOfUint32sOffsetOnFork[T ~uint32](c *Codec, ns *[]T, maxItems uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked && filter.Active(c.fork) {
+			c.enc.checkItems(len(*ns), maxItems)
+		}
+		EncodeSliceOfUint32sOffsetOnFork(c.enc, *ns, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSliceOfUint32sOffsetOnFork(c.dec, ns, filter)
+		}
+		return
+	}
+	HashSliceOfUint32sOnFork(c.has, *ns, maxItems, filter)
+}
+
+// DefineSliceOfUint32sContent defines the next field as a dynamic slice of uint32s.
+func DefineSliceOfUint32sContent[T ~uint32](c *Codec, ns *[]T, maxItems uint64) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeSliceOfUint32sContent(c.enc, *ns)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSliceOfUint32sContent(c.dec, ns, maxItems)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineSliceOfUint32sContentOnFork defines the next field as a dynamic slice of
+// uint32s if present in a fork.
+func DefineSliceOfUint32sContentOnFork[T ~uint32](c *Codec, ns *[]T, maxItems uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeSliceOfUint32sContentOnFork(c.enc, *ns, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSliceOfUint32sContentOnFork(c.dec, ns, maxItems, filter)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineSliceOfUint64sOffset defines the next field as a dynamic slice of uint64s.
+func DefineSliceOfUint64sOffset[T ~uint64](c *Codec, ns *[]T, maxItems uint64) {
+	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked {
+			c.enc.checkItems(len(*ns), maxItems)
+		}
+		EncodeSliceOfUint64sOffset(c.enc, *ns)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSliceOfUint64sOffset(c.dec, ns)
+		}
+		return
+	}
+	HashSliceOfUint64s(c.has, *ns, maxItems)
+}
+
+// DefineSliceOfUint64sOffsetOnFork defines the next field as a dynamic slice of
+// uint64s if present in a fork.
+func DefineSliceOfUint64sOffsetOnFork[T ~uint64](c *Codec, ns *[]T, maxItems uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked && filter.Active(c.fork) {
+			c.enc.checkItems(len(*ns), maxItems)
+		}
+		EncodeSliceOfUint64sOffsetOnFork(c.enc, *ns, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSliceOfUint64sOffsetOnFork(c.dec, ns, filter)
+		}
+		return
+	}
+	HashSliceOfUint64sOnFork(c.has, *ns, maxItems, filter)
+}
+
+// DefineSliceOfUint64sContent defines the next field as a dynamic slice of uint64s.
+func DefineSliceOfUint64sContent[T ~uint64](c *Codec, ns *[]T, maxItems uint64) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeSliceOfUint64sContent(c.enc, *ns)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSliceOfUint64sContent(c.dec, ns, maxItems)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineSliceOfUint64sContentOnFork defines the next field as a dynamic slice of
+// uint64s if present in a fork.
+func DefineSliceOfUint64sContentOnFork[T ~uint64](c *Codec, ns *[]T, maxItems uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeSliceOfUint64sContentOnFork(c.enc, *ns, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSliceOfUint64sContentOnFork(c.dec, ns, maxItems, filter)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineSortedSliceOfUint64sContent defines the next field as a dynamic slice of
+// uint64s, which is validated to be strictly increasing when decoding.
+func DefineSortedSliceOfUint64sContent[T ~uint64](c *Codec, ns *[]T, maxItems uint64) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeSliceOfUint64sContent(c.enc, *ns)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSortedSliceOfUint64sContent(c.dec, ns, maxItems)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineSortedSliceOfUint64sContentOnFork defines the next field as a dynamic
+// slice of uint64s, which is validated to be strictly increasing when decoding,
+// if present in a fork.
+func DefineSortedSliceOfUint64sContentOnFork[T ~uint64](c *Codec, ns *[]T, maxItems uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeSliceOfUint64sContentOnFork(c.enc, *ns, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSortedSliceOfUint64sContentOnFork(c.dec, ns, maxItems, filter)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineSliceOfUint64sPointerOffsetOnFork defines the next field as a dynamic
+// slice of uint64s behind a pointer if present in a fork. The pointer is nil if
+// the field is not active, and non-nil (even if empty) otherwise.
+func DefineSliceOfUint64sPointerOffsetOnFork[T ~uint64](c *Codec, ns **[]T, maxItems uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked && filter.Active(c.fork) && *ns != nil {
+			c.enc.checkItems(len(**ns), maxItems)
+		}
+		EncodeSliceOfUint64sPointerOffsetOnFork(c.enc, *ns, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSliceOfUint64sPointerOffsetOnFork(c.dec, ns, filter)
+		}
+		return
+	}
+	HashSliceOfUint64sPointerOnFork(c.has, *ns, maxItems, filter)
+}
+
+// DefineSliceOfUint64sPointerContentOnFork defines the next field as a dynamic
+// slice of uint64s behind a pointer if present in a fork.
+func DefineSliceOfUint64sPointerContentOnFork[T ~uint64](c *Codec, ns **[]T, maxItems uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeSliceOfUint64sPointerContentOnFork(c.enc, *ns, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSliceOfUint64sPointerContentOnFork(c.dec, ns, maxItems, filter)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineArrayOfStaticBytes defines the next field as a static array of static
+// binary blobs.
+func DefineArrayOfStaticBytes[T commonBytesArrayLengths[U], U commonBytesLengths](c *Codec, blobs *T) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeArrayOfStaticBytes[T, U](c.enc, blobs)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeArrayOfStaticBytes[T, U](c.dec, blobs)
+		}
+		return
+	}
+	HashArrayOfStaticBytes[T, U](c.has, blobs)
+}
+
+// DefineUnsafeArrayOfStaticBytes defines the next field as a static array of
+// static binary blobs. This method operates on plain slices of byte arrays and
+// will crash if provided a slice of a non-array. Its purpose is to get around
+// Go's generics limitations in generated code (use DefineArrayOfStaticBytes).
+func DefineUnsafeArrayOfStaticBytes[T commonBytesLengths](c *Codec, blobs []T) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeUnsafeArrayOfStaticBytes(c.enc, blobs)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeUnsafeArrayOfStaticBytes(c.dec, blobs)
+		}
+		return
+	}
+	HashUnsafeArrayOfStaticBytes(c.has, blobs)
+}
+
+// DefineCheckedArrayOfStaticBytes defines the next field as a static array of
+// static binary blobs. This method can be used for plain slices of byte arrays,
+// which is more expensive since it needs runtime size validation.
+func DefineCheckedArrayOfStaticBytes[T commonBytesLengths](c *Codec, blobs *[]T, size uint64) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeCheckedArrayOfStaticBytes(c.enc, *blobs, size)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeCheckedArrayOfStaticBytes(c.dec, blobs, size)
+		}
+		return
+	}
+	if c.has.schema != nil {
+		item := reflect.TypeFor[T]().Len()
+		c.has.schema.field("CheckedArrayOfStaticBytes", int(size)*item, []int{int(size), item})
+		return
+	}
+	HashCheckedArrayOfStaticBytes(c.has, *blobs)
+}
+
+// DefineSliceOfStaticBytesOffset defines the next field as a dynamic slice of
+// static binary blobs.
+func DefineSliceOfStaticBytesOffset[T commonBytesLengths](c *Codec, bytes *[]T, maxItems uint64) {
+	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked {
+			c.enc.checkItems(len(*bytes), maxItems)
+		}
+		EncodeSliceOfStaticBytesOffset(c.enc, *bytes)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSliceOfStaticBytesOffset(c.dec, bytes)
+		}
+		return
+	}
+	HashSliceOfStaticBytes(c.has, *bytes, maxItems)
+}
+
+// DefineSliceOfStaticBytesOffsetOnFork defines the next field as a dynamic slice
+// of static binary blobs if present in a fork.
+func DefineSliceOfStaticBytesOffsetOnFork[T commonBytesLengths](c *Codec, bytes *[]T, maxItems uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked && filter.Active(c.fork) {
+			c.enc.checkItems(len(*bytes), maxItems)
+		}
+		EncodeSliceOfStaticBytesOffsetOnFork(c.enc, *bytes, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSliceOfStaticBytesOffsetOnFork(c.dec, bytes, filter)
+		}
+		return
+	}
+	HashSliceOfStaticBytesOnFork(c.has, *bytes, maxItems, filter)
+}
+
+// DefineSliceOfStaticBytesContent defines the next field as a dynamic slice of static
+// binary blobs.
+func DefineSliceOfStaticBytesContent[T commonBytesLengths](c *Codec, blobs *[]T, maxItems uint64) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeSliceOfStaticBytesContent(c.enc, *blobs)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSliceOfStaticBytesContent(c.dec, blobs, maxItems)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineSliceOfStaticBytesContentOnFork defines the next field as a dynamic slice
+// of static binary blobs if present in a fork.
+func DefineSliceOfStaticBytesContentOnFork[T commonBytesLengths](c *Codec, blobs *[]T, maxItems uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeSliceOfStaticBytesContentOnFork(c.enc, *blobs, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSliceOfStaticBytesContentOnFork(c.dec, blobs, maxItems, filter)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineSliceOfStaticBytesPointerOffsetOnFork defines the next field as a
+// dynamic slice of static binary blobs behind a pointer if present in a fork.
+// The pointer is nil if the field is not active, and non-nil (even if empty)
+// otherwise.
+func DefineSliceOfStaticBytesPointerOffsetOnFork[T commonBytesLengths](c *Codec, blobs **[]T, maxItems uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked && filter.Active(c.fork) && *blobs != nil {
+			c.enc.checkItems(len(**blobs), maxItems)
+		}
+		EncodeSliceOfStaticBytesPointerOffsetOnFork(c.enc, *blobs, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSliceOfStaticBytesPointerOffsetOnFork(c.dec, blobs, filter)
+		}
+		return
+	}
+	HashSliceOfStaticBytesPointerOnFork(c.has, *blobs, maxItems, filter)
+}
+
+// DefineSliceOfStaticBytesPointerContentOnFork defines the next field as a
+// dynamic slice of static binary blobs behind a pointer if present in a fork.
+func DefineSliceOfStaticBytesPointerContentOnFork[T commonBytesLengths](c *Codec, blobs **[]T, maxItems uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeSliceOfStaticBytesPointerContentOnFork(c.enc, *blobs, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSliceOfStaticBytesPointerContentOnFork(c.dec, blobs, maxItems, filter)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineCheckedArrayOfDynamicBytesOffset defines the next field as a static
+// array of dynamic binary blobs. This method can be used for plain slices of
+// byte slices, which is more expensive since it needs runtime size validation.
+func DefineCheckedArrayOfDynamicBytesOffset(c *Codec, blobs *[][]byte, size uint64, maxSize uint64) {
+	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked {
+			c.enc.checkBlobs(*blobs, maxSize)
+		}
+		EncodeCheckedArrayOfDynamicBytesOffset(c.enc, *blobs, size)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeCheckedArrayOfDynamicBytesOffset(c.dec, blobs)
+		}
+		return
+	}
+	HashCheckedArrayOfDynamicBytes(c.has, *blobs, size, maxSize)
+}
+
+// DefineCheckedArrayOfDynamicBytesOffsetOnFork defines the next field as a static
+// array of dynamic binary blobs if present in a fork.
+func DefineCheckedArrayOfDynamicBytesOffsetOnFork(c *Codec, blobs *[][]byte, size uint64, maxSize uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked && filter.Active(c.fork) {
+			c.enc.checkBlobs(*blobs, maxSize)
+		}
+		EncodeCheckedArrayOfDynamicBytesOffsetOnFork(c.enc, *blobs, size, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeCheckedArrayOfDynamicBytesOffsetOnFork(c.dec, blobs, filter)
+		}
+		return
+	}
+	HashCheckedArrayOfDynamicBytesOnFork(c.has, *blobs, size, maxSize, filter)
+}
+
+// DefineCheckedArrayOfDynamicBytesContent defines the next field as a static
+// array of dynamic binary blobs.
+func DefineCheckedArrayOfDynamicBytesContent(c *Codec, blobs *[][]byte, size uint64, maxSize uint64) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeCheckedArrayOfDynamicBytesContent(c.enc, *blobs, size)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeCheckedArrayOfDynamicBytesContent(c.dec, blobs, size, maxSize)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineCheckedArrayOfDynamicBytesContentOnFork defines the next field as a
+// static array of dynamic binary blobs if present in a fork.
+func DefineCheckedArrayOfDynamicBytesContentOnFork(c *Codec, blobs *[][]byte, size uint64, maxSize uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeCheckedArrayOfDynamicBytesContentOnFork(c.enc, *blobs, size, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeCheckedArrayOfDynamicBytesContentOnFork(c.dec, blobs, size, maxSize, filter)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineSliceOfDynamicBytesOffset defines the next field as a dynamic slice of
+// dynamic binary blobs.
 func DefineSliceOfDynamicBytesOffset(c *Codec, blobs *[][]byte, maxItems uint64, maxSize uint64) {
 	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked {
+			c.enc.checkItems(len(*blobs), maxItems)
+			c.enc.checkBlobs(*blobs, maxSize)
+		}
 		EncodeSliceOfDynamicBytesOffset(c.enc, *blobs)
 		return
 	}
 	if c.dec != nil {
-		DecodeSliceOfDynamicBytesOffset(c.dec, blobs)
+		if c.dec.nextField() {
+			DecodeSliceOfDynamicBytesOffset(c.dec, blobs)
+		}
 		return
 	}
 	HashSliceOfDynamicBytes(c.has, *blobs, maxItems, maxSize)
@@ -700,11 +1804,18 @@ func DefineSliceOfDynamicBytesOffset(c *Codec, blobs *[][]byte, maxItems uint64,
 // of dynamic binary blobs if present in a fork.
 func DefineSliceOfDynamicBytesOffsetOnFork(c *Codec, blobs *[][]byte, maxItems uint64, maxSize uint64, filter ForkFilter) {
 	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked && filter.Active(c.fork) {
+			c.enc.checkItems(len(*blobs), maxItems)
+			c.enc.checkBlobs(*blobs, maxSize)
+		}
 		EncodeSliceOfDynamicBytesOffsetOnFork(c.enc, *blobs, filter)
 		return
 	}
 	if c.dec != nil {
-		DecodeSliceOfDynamicBytesOffsetOnFork(c.dec, blobs, filter)
+		if c.dec.nextField() {
+			DecodeSliceOfDynamicBytesOffsetOnFork(c.dec, blobs, filter)
+		}
 		return
 	}
 	HashSliceOfDynamicBytesOnFork(c.has, *blobs, maxItems, maxSize, filter)
@@ -714,11 +1825,14 @@ func DefineSliceOfDynamicBytesOffsetOnFork(c *Codec, blobs *[][]byte, maxItems u
 // dynamic binary blobs.
 func DefineSliceOfDynamicBytesContent(c *Codec, blobs *[][]byte, maxItems uint64, maxSize uint64) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeSliceOfDynamicBytesContent(c.enc, *blobs)
 		return
 	}
 	if c.dec != nil {
-		DecodeSliceOfDynamicBytesContent(c.dec, blobs, maxItems, maxSize)
+		if c.dec.nextField() {
+			DecodeSliceOfDynamicBytesContent(c.dec, blobs, maxItems, maxSize)
+		}
 		return
 	}
 	// No hashing, done at the offset position
@@ -728,11 +1842,14 @@ func DefineSliceOfDynamicBytesContent(c *Codec, blobs *[][]byte, maxItems uint64
 // slice of dynamic binary blobs.
 func DefineSliceOfDynamicBytesContentOnFork(c *Codec, blobs *[][]byte, maxItems uint64, maxSize uint64, filter ForkFilter) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeSliceOfDynamicBytesContentOnFork(c.enc, *blobs, filter)
 		return
 	}
 	if c.dec != nil {
-		DecodeSliceOfDynamicBytesContentOnFork(c.dec, blobs, maxItems, maxSize, filter)
+		if c.dec.nextField() {
+			DecodeSliceOfDynamicBytesContentOnFork(c.dec, blobs, maxItems, maxSize, filter)
+		}
 		return
 	}
 	// No hashing, done at the offset position
@@ -742,11 +1859,17 @@ func DefineSliceOfDynamicBytesContentOnFork(c *Codec, blobs *[][]byte, maxItems
 // static ssz objects.
 func DefineSliceOfStaticObjectsOffset[T newableStaticObject[U], U any](c *Codec, objects *[]T, maxItems uint64) {
 	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked {
+			c.enc.checkItems(len(*objects), maxItems)
+		}
 		EncodeSliceOfStaticObjectsOffset(c.enc, *objects)
 		return
 	}
 	if c.dec != nil {
-		DecodeSliceOfStaticObjectsOffset(c.dec, objects)
+		if c.dec.nextField() {
+			DecodeSliceOfStaticObjectsOffset(c.dec, objects)
+		}
 		return
 	}
 	HashSliceOfStaticObjects(c.has, *objects, maxItems)
@@ -756,11 +1879,17 @@ func DefineSliceOfStaticObjectsOffset[T newableStaticObject[U], U any](c *Codec,
 // slice of static ssz objects if present in a fork.
 func DefineSliceOfStaticObjectsOffsetOnFork[T newableStaticObject[U], U any](c *Codec, objects *[]T, maxItems uint64, filter ForkFilter) {
 	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked && filter.Active(c.fork) {
+			c.enc.checkItems(len(*objects), maxItems)
+		}
 		EncodeSliceOfStaticObjectsOffsetOnFork(c.enc, *objects, filter)
 		return
 	}
 	if c.dec != nil {
-		DecodeSliceOfStaticObjectsOffsetOnFork(c.dec, objects, filter)
+		if c.dec.nextField() {
+			DecodeSliceOfStaticObjectsOffsetOnFork(c.dec, objects, filter)
+		}
 		return
 	}
 	HashSliceOfStaticObjectsOnFork(c.has, *objects, maxItems, filter)
@@ -770,11 +1899,14 @@ func DefineSliceOfStaticObjectsOffsetOnFork[T newableStaticObject[U], U any](c *
 // ssz objects.
 func DefineSliceOfStaticObjectsContent[T newableStaticObject[U], U any](c *Codec, objects *[]T, maxItems uint64) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeSliceOfStaticObjectsContent(c.enc, *objects)
 		return
 	}
 	if c.dec != nil {
-		DecodeSliceOfStaticObjectsContent(c.dec, objects, maxItems)
+		if c.dec.nextField() {
+			DecodeSliceOfStaticObjectsContent(c.dec, objects, maxItems)
+		}
 		return
 	}
 	// No hashing, done at the offset position
@@ -784,11 +1916,128 @@ func DefineSliceOfStaticObjectsContent[T newableStaticObject[U], U any](c *Codec
 // slice of static ssz objects if present in a fork.
 func DefineSliceOfStaticObjectsContentOnFork[T newableStaticObject[U], U any](c *Codec, objects *[]T, maxItems uint64, filter ForkFilter) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeSliceOfStaticObjectsContentOnFork(c.enc, *objects, filter)
 		return
 	}
 	if c.dec != nil {
-		DecodeSliceOfStaticObjectsContentOnFork(c.dec, objects, maxItems, filter)
+		if c.dec.nextField() {
+			DecodeSliceOfStaticObjectsContentOnFork(c.dec, objects, maxItems, filter)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineSliceOfStaticObjectValuesOffset defines the next field as a dynamic slice
+// of static ssz objects stored by value (i.e. []T instead of []*T), avoiding the
+// per item indirection and allocation.
+func DefineSliceOfStaticObjectValuesOffset[T newableStaticObject[U], U any](c *Codec, objects *[]U, maxItems uint64) {
+	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked {
+			c.enc.checkItems(len(*objects), maxItems)
+		}
+		EncodeSliceOfStaticObjectValuesOffset[T](c.enc, *objects)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSliceOfStaticObjectValuesOffset[T](c.dec, objects)
+		}
+		return
+	}
+	HashSliceOfStaticObjectValues[T](c.has, *objects, maxItems)
+}
+
+// DefineSliceOfStaticObjectValuesOffsetOnFork defines the next field as a dynamic
+// slice of static ssz objects stored by value if present in a fork.
+func DefineSliceOfStaticObjectValuesOffsetOnFork[T newableStaticObject[U], U any](c *Codec, objects *[]U, maxItems uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked && filter.Active(c.fork) {
+			c.enc.checkItems(len(*objects), maxItems)
+		}
+		EncodeSliceOfStaticObjectValuesOffsetOnFork[T](c.enc, *objects, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSliceOfStaticObjectValuesOffsetOnFork[T](c.dec, objects, filter)
+		}
+		return
+	}
+	HashSliceOfStaticObjectValuesOnFork[T](c.has, *objects, maxItems, filter)
+}
+
+// DefineSliceOfStaticObjectValuesContent defines the next field as a dynamic slice
+// of static ssz objects stored by value.
+func DefineSliceOfStaticObjectValuesContent[T newableStaticObject[U], U any](c *Codec, objects *[]U, maxItems uint64) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeSliceOfStaticObjectValuesContent[T](c.enc, *objects)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSliceOfStaticObjectValuesContent[T](c.dec, objects, maxItems)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineSliceOfStaticObjectValuesContentOnFork defines the next field as a dynamic
+// slice of static ssz objects stored by value if present in a fork.
+func DefineSliceOfStaticObjectValuesContentOnFork[T newableStaticObject[U], U any](c *Codec, objects *[]U, maxItems uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeSliceOfStaticObjectValuesContentOnFork[T](c.enc, *objects, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSliceOfStaticObjectValuesContentOnFork[T](c.dec, objects, maxItems, filter)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineSliceOfStaticObjectsPointerOffsetOnFork defines the next field as a
+// dynamic slice of static ssz objects behind a pointer if present in a fork.
+// The pointer is nil if the field is not active, and non-nil (even if empty)
+// otherwise.
+func DefineSliceOfStaticObjectsPointerOffsetOnFork[T newableStaticObject[U], U any](c *Codec, objects **[]T, maxItems uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked && filter.Active(c.fork) && *objects != nil {
+			c.enc.checkItems(len(**objects), maxItems)
+		}
+		EncodeSliceOfStaticObjectsPointerOffsetOnFork(c.enc, *objects, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSliceOfStaticObjectsPointerOffsetOnFork(c.dec, objects, filter)
+		}
+		return
+	}
+	HashSliceOfStaticObjectsPointerOnFork(c.has, *objects, maxItems, filter)
+}
+
+// DefineSliceOfStaticObjectsPointerContentOnFork defines the next field as a
+// dynamic slice of static ssz objects behind a pointer if present in a fork.
+func DefineSliceOfStaticObjectsPointerContentOnFork[T newableStaticObject[U], U any](c *Codec, objects **[]T, maxItems uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeSliceOfStaticObjectsPointerContentOnFork(c.enc, *objects, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSliceOfStaticObjectsPointerContentOnFork(c.dec, objects, maxItems, filter)
+		}
 		return
 	}
 	// No hashing, done at the offset position
@@ -798,11 +2047,17 @@ func DefineSliceOfStaticObjectsContentOnFork[T newableStaticObject[U], U any](c
 // dynamic ssz objects.
 func DefineSliceOfDynamicObjectsOffset[T newableDynamicObject[U], U any](c *Codec, objects *[]T, maxItems uint64) {
 	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked {
+			c.enc.checkItems(len(*objects), maxItems)
+		}
 		EncodeSliceOfDynamicObjectsOffset(c.enc, *objects)
 		return
 	}
 	if c.dec != nil {
-		DecodeSliceOfDynamicObjectsOffset(c.dec, objects)
+		if c.dec.nextField() {
+			DecodeSliceOfDynamicObjectsOffset(c.dec, objects)
+		}
 		return
 	}
 	HashSliceOfDynamicObjects(c.has, *objects, maxItems)
@@ -812,11 +2067,17 @@ func DefineSliceOfDynamicObjectsOffset[T newableDynamicObject[U], U any](c *Code
 // slice of dynamic ssz objects if present in a fork.
 func DefineSliceOfDynamicObjectsOffsetOnFork[T newableDynamicObject[U], U any](c *Codec, objects *[]T, maxItems uint64, filter ForkFilter) {
 	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked && filter.Active(c.fork) {
+			c.enc.checkItems(len(*objects), maxItems)
+		}
 		EncodeSliceOfDynamicObjectsOffsetOnFork(c.enc, *objects, filter)
 		return
 	}
 	if c.dec != nil {
-		DecodeSliceOfDynamicObjectsOffsetOnFork(c.dec, objects, filter)
+		if c.dec.nextField() {
+			DecodeSliceOfDynamicObjectsOffsetOnFork(c.dec, objects, filter)
+		}
 		return
 	}
 	HashSliceOfDynamicObjectsOnFork(c.has, *objects, maxItems, filter)
@@ -826,11 +2087,14 @@ func DefineSliceOfDynamicObjectsOffsetOnFork[T newableDynamicObject[U], U any](c
 // of dynamic ssz objects.
 func DefineSliceOfDynamicObjectsContent[T newableDynamicObject[U], U any](c *Codec, objects *[]T, maxItems uint64) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeSliceOfDynamicObjectsContent(c.enc, *objects)
 		return
 	}
 	if c.dec != nil {
-		DecodeSliceOfDynamicObjectsContent(c.dec, objects, maxItems)
+		if c.dec.nextField() {
+			DecodeSliceOfDynamicObjectsContent(c.dec, objects, maxItems)
+		}
 		return
 	}
 	// No hashing, done at the offset position
@@ -840,11 +2104,201 @@ func DefineSliceOfDynamicObjectsContent[T newableDynamicObject[U], U any](c *Cod
 // slice of dynamic ssz objects if present in a fork.
 func DefineSliceOfDynamicObjectsContentOnFork[T newableDynamicObject[U], U any](c *Codec, objects *[]T, maxItems uint64, filter ForkFilter) {
 	if c.enc != nil {
+		c.enc.nextField()
 		EncodeSliceOfDynamicObjectsContentOnFork(c.enc, *objects, filter)
 		return
 	}
 	if c.dec != nil {
-		DecodeSliceOfDynamicObjectsContentOnFork(c.dec, objects, maxItems, filter)
+		if c.dec.nextField() {
+			DecodeSliceOfDynamicObjectsContentOnFork(c.dec, objects, maxItems, filter)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineSliceOfDynamicObjectsPointerOffsetOnFork defines the next field as a
+// dynamic slice of dynamic ssz objects behind a pointer if present in a fork.
+// The pointer is nil if the field is not active, and non-nil (even if empty)
+// otherwise.
+func DefineSliceOfDynamicObjectsPointerOffsetOnFork[T newableDynamicObject[U], U any](c *Codec, objects **[]T, maxItems uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked && filter.Active(c.fork) && *objects != nil {
+			c.enc.checkItems(len(**objects), maxItems)
+		}
+		EncodeSliceOfDynamicObjectsPointerOffsetOnFork(c.enc, *objects, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSliceOfDynamicObjectsPointerOffsetOnFork(c.dec, objects, filter)
+		}
+		return
+	}
+	HashSliceOfDynamicObjectsPointerOnFork(c.has, *objects, maxItems, filter)
+}
+
+// DefineSliceOfDynamicObjectsPointerContentOnFork defines the next field as a
+// dynamic slice of dynamic ssz objects behind a pointer if present in a fork.
+func DefineSliceOfDynamicObjectsPointerContentOnFork[T newableDynamicObject[U], U any](c *Codec, objects **[]T, maxItems uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeSliceOfDynamicObjectsPointerContentOnFork(c.enc, *objects, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeSliceOfDynamicObjectsPointerContentOnFork(c.dec, objects, maxItems, filter)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineMapOfStaticEntriesOffset defines the next field as a map encoded as a
+// dynamic slice of static ssz key/value containers, sorted by key.
+func DefineMapOfStaticEntriesOffset[T newableStaticMapEntry[K, V, U], U any, K comparable, V any](c *Codec, m *map[K]V, maxItems uint64) {
+	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked {
+			c.enc.checkItems(len(*m), maxItems)
+		}
+		EncodeMapOfStaticEntriesOffset[T](c.enc, *m)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeMapOfStaticEntriesOffset[T](c.dec, m)
+		}
+		return
+	}
+	HashMapOfStaticEntries[T](c.has, *m, maxItems)
+}
+
+// DefineMapOfStaticEntriesOffsetOnFork defines the next field as a map encoded as
+// a dynamic slice of static ssz key/value containers if present in a fork.
+func DefineMapOfStaticEntriesOffsetOnFork[T newableStaticMapEntry[K, V, U], U any, K comparable, V any](c *Codec, m *map[K]V, maxItems uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked && filter.Active(c.fork) {
+			c.enc.checkItems(len(*m), maxItems)
+		}
+		EncodeMapOfStaticEntriesOffsetOnFork[T](c.enc, *m, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeMapOfStaticEntriesOffsetOnFork[T](c.dec, m, filter)
+		}
+		return
+	}
+	HashMapOfStaticEntriesOnFork[T](c.has, *m, maxItems, filter)
+}
+
+// DefineMapOfStaticEntriesContent defines the next field as a map encoded as a
+// dynamic slice of static ssz key/value containers, sorted by key.
+func DefineMapOfStaticEntriesContent[T newableStaticMapEntry[K, V, U], U any, K comparable, V any](c *Codec, m *map[K]V, maxItems uint64) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeMapOfStaticEntriesContent[T](c.enc, *m)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeMapOfStaticEntriesContent[T](c.dec, m, maxItems)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineMapOfStaticEntriesContentOnFork defines the next field as a map encoded
+// as a dynamic slice of static ssz key/value containers if present in a fork.
+func DefineMapOfStaticEntriesContentOnFork[T newableStaticMapEntry[K, V, U], U any, K comparable, V any](c *Codec, m *map[K]V, maxItems uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeMapOfStaticEntriesContentOnFork[T](c.enc, *m, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeMapOfStaticEntriesContentOnFork[T](c.dec, m, maxItems, filter)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineMapOfDynamicEntriesOffset defines the next field as a map encoded as a
+// dynamic slice of dynamic ssz key/value containers, sorted by key.
+func DefineMapOfDynamicEntriesOffset[T newableDynamicMapEntry[K, V, U], U any, K comparable, V any](c *Codec, m *map[K]V, maxItems uint64) {
+	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked {
+			c.enc.checkItems(len(*m), maxItems)
+		}
+		EncodeMapOfDynamicEntriesOffset[T](c.enc, *m)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeMapOfDynamicEntriesOffset[T](c.dec, m)
+		}
+		return
+	}
+	HashMapOfDynamicEntries[T](c.has, *m, maxItems)
+}
+
+// DefineMapOfDynamicEntriesOffsetOnFork defines the next field as a map encoded as
+// a dynamic slice of dynamic ssz key/value containers if present in a fork.
+func DefineMapOfDynamicEntriesOffsetOnFork[T newableDynamicMapEntry[K, V, U], U any, K comparable, V any](c *Codec, m *map[K]V, maxItems uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		if c.enc.checked && filter.Active(c.fork) {
+			c.enc.checkItems(len(*m), maxItems)
+		}
+		EncodeMapOfDynamicEntriesOffsetOnFork[T](c.enc, *m, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeMapOfDynamicEntriesOffsetOnFork[T](c.dec, m, filter)
+		}
+		return
+	}
+	HashMapOfDynamicEntriesOnFork[T](c.has, *m, maxItems, filter)
+}
+
+// DefineMapOfDynamicEntriesContent defines the next field as a map encoded as a
+// dynamic slice of dynamic ssz key/value containers, sorted by key.
+func DefineMapOfDynamicEntriesContent[T newableDynamicMapEntry[K, V, U], U any, K comparable, V any](c *Codec, m *map[K]V, maxItems uint64) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeMapOfDynamicEntriesContent[T](c.enc, *m)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeMapOfDynamicEntriesContent[T](c.dec, m, maxItems)
+		}
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineMapOfDynamicEntriesContentOnFork defines the next field as a map encoded
+// as a dynamic slice of dynamic ssz key/value containers if present in a fork.
+func DefineMapOfDynamicEntriesContentOnFork[T newableDynamicMapEntry[K, V, U], U any, K comparable, V any](c *Codec, m *map[K]V, maxItems uint64, filter ForkFilter) {
+	if c.enc != nil {
+		c.enc.nextField()
+		EncodeMapOfDynamicEntriesContentOnFork[T](c.enc, *m, filter)
+		return
+	}
+	if c.dec != nil {
+		if c.dec.nextField() {
+			DecodeMapOfDynamicEntriesContentOnFork[T](c.dec, m, maxItems, filter)
+		}
 		return
 	}
 	// No hashing, done at the offset position
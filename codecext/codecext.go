@@ -0,0 +1,157 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package codecext exposes the minimal codec internals needed to implement new
+// Define primitives outside of the ssz package (e.g. for exotic containers),
+// without having to copy its unsafe code or wait for upstream support.
+//
+// A primitive is implemented as a trio of encoder, decoder and hasher functions
+// passed to Define. Static primitives write and read their data directly; dynamic
+// ones are split in two Define calls the same way the built-in ones are: one at
+// the offset position (writing and reading the offset, and doing all the hashing)
+// and one at the content position (writing and reading the data, no hashing).
+//
+// All the operations are no-ops after a failure, so the primitives do not need
+// to check for errors after every call, only before doing expensive work.
+package codecext
+
+import (
+	"github.com/karalabe/ssz"
+	"github.com/karalabe/ssz/internal/extension"
+)
+
+// Define defines the next field of an object via a third party primitive. The
+// encoder, decoder or hasher is invoked depending on the operation the codec is
+// running; any of them may be nil if the primitive has nothing to do for it (e.g.
+// no hashing at the content position of a dynamic field).
+func Define(c *ssz.Codec, encode func(enc *ssz.Encoder), decode func(dec *ssz.Decoder), hash func(h *ssz.Hasher)) {
+	c.DefineEncoder(func(enc *ssz.Encoder) {
+		extension.EncoderField(enc)
+		if encode != nil {
+			encode(enc)
+		}
+	})
+	c.DefineDecoder(func(dec *ssz.Decoder) {
+		if extension.DecoderField(dec) && decode != nil {
+			decode(dec)
+		}
+	})
+	c.DefineHasher(func(h *ssz.Hasher) {
+		if extension.HasherField(h) && hash != nil {
+			hash(h)
+		}
+	})
+}
+
+// Checked reports whether the encoder is running in checked mode, in which the
+// primitives are expected to validate the size limits of their data and fail the
+// encoding if exceeded.
+func Checked(enc *ssz.Encoder) bool {
+	return extension.EncoderChecked(enc)
+}
+
+// Write writes a raw blob into the output of the encoder.
+func Write(enc *ssz.Encoder, blob []byte) {
+	extension.EncoderWrite(enc, blob)
+}
+
+// WriteOffset writes the offset of a dynamic field into the output of the encoder,
+// reserving size bytes for its content, which needs to be written afterwards at
+// the content position of the field.
+func WriteOffset(enc *ssz.Encoder, size uint32) {
+	extension.EncoderOffset(enc, size)
+}
+
+// FailEncode aborts the encoding with an error, unless it already failed.
+func FailEncode(enc *ssz.Encoder, err error) {
+	extension.EncoderFail(enc, err)
+}
+
+// EncodeErr retrieves the error the encoding failed with, if any.
+func EncodeErr(enc *ssz.Encoder) error {
+	return extension.EncoderErr(enc)
+}
+
+// Read fills a raw blob from the input of the decoder.
+func Read(dec *ssz.Decoder, blob []byte) {
+	extension.DecoderRead(dec, blob)
+}
+
+// ReadOffset reads and validates the offset of a dynamic field from the input of
+// the decoder. The size of the content is available at the content position of
+// the field via ReadSize.
+func ReadOffset(dec *ssz.Decoder) {
+	extension.DecoderOffset(dec)
+}
+
+// ReadSize retrieves the size of the content of the next dynamic field, derived
+// from the offsets read. It returns 0 if the decoding already failed.
+func ReadSize(dec *ssz.Decoder) uint32 {
+	return extension.DecoderSize(dec)
+}
+
+// DescendSlot starts decoding a sub-slot of the given length (e.g. the content of
+// a dynamic field containing further dynamic items), within which offsets are
+// relative to its start. Every call needs to be paired with an AscendSlot.
+func DescendSlot(dec *ssz.Decoder, length uint32) {
+	extension.DecoderDescend(dec, length)
+}
+
+// AscendSlot finishes decoding a sub-slot, failing the decoding if it was not
+// consumed exactly, and restores the state of the outer slot.
+func AscendSlot(dec *ssz.Decoder) {
+	extension.DecoderAscend(dec)
+}
+
+// Charge accounts for n freshly allocated bytes against the decoding budget, if
+// one was configured, failing the decoding (and returning false) if exceeded.
+func Charge(dec *ssz.Decoder, n uint64) bool {
+	return extension.DecoderCharge(dec, n)
+}
+
+// FailDecode aborts the decoding with an error, unless it already failed.
+func FailDecode(dec *ssz.Decoder, err error) {
+	extension.DecoderFail(dec, err)
+}
+
+// DecodeErr retrieves the error the decoding failed with, if any.
+func DecodeErr(dec *ssz.Decoder) error {
+	return extension.DecoderErr(dec)
+}
+
+// InsertChunk inserts a 32 byte leaf chunk into the current layer of the hasher.
+func InsertChunk(h *ssz.Hasher, chunk [32]byte) {
+	extension.HasherChunk(h, chunk)
+}
+
+// InsertBlob splits a blob into zero padded 32 byte leaf chunks and inserts them
+// into the current layer of the hasher.
+func InsertBlob(h *ssz.Hasher, blob []byte) {
+	extension.HasherBlob(h, blob)
+}
+
+// DescendLayer starts a new layer in the hasher, the chunks of which are merkleized
+// into a single root when terminated via AscendLayer.
+func DescendLayer(h *ssz.Hasher) {
+	extension.HasherDescend(h, false)
+}
+
+// AscendLayer terminates a layer started with DescendLayer, padding it to the
+// given number of chunks (0 = only to the next power of two).
+func AscendLayer(h *ssz.Hasher, capacity uint64) {
+	extension.HasherAscend(h, capacity)
+}
+
+// DescendMixinLayer starts a new layer in the hasher, the chunks of which are
+// merkleized into a single root with a length mixed in when terminated via
+// AscendMixinLayer.
+func DescendMixinLayer(h *ssz.Hasher) {
+	extension.HasherDescend(h, true)
+}
+
+// AscendMixinLayer terminates a layer started with DescendMixinLayer, padding it
+// to the given number of chunks and mixing in the given length.
+func AscendMixinLayer(h *ssz.Hasher, length uint64, capacity uint64) {
+	extension.HasherMixin(h, length, capacity)
+}
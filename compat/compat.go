@@ -0,0 +1,148 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package compat is a wire compatibility harness, checking the encodings and
+// merkle roots of objects against golden files. Downstream users can call it in
+// their tests to guarantee that a library (or generator) upgrade never silently
+// changes the encodings of their types.
+//
+// Golden files are created (or updated after a deliberate format change) by
+// running the tests with SSZ_UPDATE_GOLDEN=1 set in the environment.
+package compat
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/karalabe/ssz"
+)
+
+// Check verifies that the encoding and merkle root of a non-monolithic object
+// match the ones recorded in a golden file. If the type contains fork-specific
+// rules, use CheckOnFork.
+func Check(t testing.TB, obj ssz.Object, golden string) {
+	t.Helper()
+	CheckOnFork(t, obj, ssz.ForkUnknown, golden)
+}
+
+// CheckOnFork verifies that the encoding and merkle root of a monolithic object
+// in a fork match the ones recorded in a golden file. If the type does not
+// contain fork-specific rules, you can also use Check.
+//
+// Beside the encoding side, the golden encoding is also decoded into a fresh
+// instance of the object's type and re-encoded, ensuring that the data written
+// by older versions is still readable.
+func CheckOnFork(t testing.TB, obj ssz.Object, fork ssz.Fork, golden string) {
+	t.Helper()
+
+	blob := make([]byte, ssz.SizeOnFork(obj, fork))
+	if err := ssz.EncodeToBytesOnFork(blob, obj, fork); err != nil {
+		t.Fatalf("compat: failed to encode %T: %v", obj, err)
+	}
+	root := ssz.HashSequentialOnFork(obj, fork)
+
+	if os.Getenv("SSZ_UPDATE_GOLDEN") == "1" {
+		if err := writeGolden(golden, blob, root); err != nil {
+			t.Fatalf("compat: failed to write golden file %s: %v", golden, err)
+		}
+		return
+	}
+	want, wantRoot, err := readGolden(golden)
+	if err != nil {
+		t.Fatalf("compat: failed to read golden file %s (set SSZ_UPDATE_GOLDEN=1 to create it): %v", golden, err)
+	}
+	if !bytes.Equal(blob, want) {
+		t.Errorf("compat: %T encoding changed: %s", obj, describeMismatch(blob, want))
+	}
+	if root != wantRoot {
+		t.Errorf("compat: %T merkle root changed: have %#x, want %#x", obj, root, wantRoot)
+	}
+	// Ensure the golden encoding can still be decoded and round trips
+	dec := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(ssz.Object)
+	if err := ssz.DecodeFromBytesOnFork(want, dec, fork); err != nil {
+		t.Errorf("compat: failed to decode golden %T: %v", obj, err)
+		return
+	}
+	reenc := make([]byte, ssz.SizeOnFork(dec, fork))
+	if err := ssz.EncodeToBytesOnFork(reenc, dec, fork); err != nil {
+		t.Errorf("compat: failed to re-encode golden %T: %v", obj, err)
+		return
+	}
+	if !bytes.Equal(reenc, want) {
+		t.Errorf("compat: %T golden re-encoding changed: %s", obj, describeMismatch(reenc, want))
+	}
+}
+
+// describeMismatch returns a short description of where two encodings differ,
+// without dumping the (possibly huge) blobs.
+func describeMismatch(have, want []byte) string {
+	for i := 0; i < min(len(have), len(want)); i++ {
+		if have[i] != want[i] {
+			return fmt.Sprintf("first difference at byte %d: have %#02x, want %#02x", i, have[i], want[i])
+		}
+	}
+	return fmt.Sprintf("length mismatch: have %d bytes, want %d bytes", len(have), len(want))
+}
+
+// writeGolden writes the encoding and merkle root of an object into a golden
+// file, creating the parent directories if needed.
+func writeGolden(path string, blob []byte, root [32]byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data := fmt.Sprintf("encoding: 0x%x\nroot: 0x%x\n", blob, root)
+	return os.WriteFile(path, []byte(data), 0644)
+}
+
+// readGolden reads the encoding and merkle root of an object from a golden file.
+func readGolden(path string) ([]byte, [32]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+	defer f.Close()
+
+	var (
+		blob []byte
+		root [32]byte
+
+		haveBlob, haveRoot bool
+	)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<30)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ": ")
+		if !ok {
+			continue
+		}
+		data, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+		if err != nil {
+			return nil, [32]byte{}, fmt.Errorf("invalid %s: %v", key, err)
+		}
+		switch key {
+		case "encoding":
+			blob, haveBlob = data, true
+		case "root":
+			if len(data) != 32 {
+				return nil, [32]byte{}, fmt.Errorf("invalid root length: %d", len(data))
+			}
+			copy(root[:], data)
+			haveRoot = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, [32]byte{}, err
+	}
+	if !haveBlob || !haveRoot {
+		return nil, [32]byte{}, fmt.Errorf("missing encoding or root")
+	}
+	return blob, root, nil
+}
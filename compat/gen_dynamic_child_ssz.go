@@ -0,0 +1,33 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 2e598b3b42012321756361bcf056cba3cbedc1b24305d65da0f9b7c28f28dca1
+
+package compat
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *DynamicChild) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
+	size = 8 + 4
+	if fixed {
+		return size
+	}
+	size += ssz.SizeDynamicBytes(sizer, obj.Bytes)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *DynamicChild) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineUint64(codec, &obj.Slot)                  // Field  (0) -  Slot - 8 bytes
+	ssz.DefineDynamicBytesOffset(codec, &obj.Bytes, 64) // Offset (1) - Bytes - 4 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineDynamicBytesContent(codec, &obj.Bytes, 64) // Field  (1) - Bytes - ? bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *DynamicChild) NamesSSZ() []string {
+	return []string{"Slot", "Bytes", "Bytes"}
+}
@@ -0,0 +1,22 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 370d34c424bb3895996966bcbd30fa23c3e90e2a7abc3315c102b5763a99a945
+
+package compat
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *StaticChild) SizeSSZ(sizer *ssz.Sizer) uint32 {
+	return 8 + 32
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *StaticChild) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &obj.Epoch)     // Field  (0) - Epoch -  8 bytes
+	ssz.DefineStaticBytes(codec, &obj.Root) // Field  (1) -  Root - 32 bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *StaticChild) NamesSSZ() []string {
+	return []string{"Epoch", "Root"}
+}
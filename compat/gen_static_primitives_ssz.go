@@ -0,0 +1,41 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 19051828a93f5ebfff8c59f6fdcd57073b82ff5c8f86af94437409ade8ca8785
+
+package compat
+
+import "github.com/karalabe/ssz"
+
+// Cached static size computed on package init.
+var staticSizeCacheStaticPrimitives = ssz.PrecomputeStaticSizeCache((*StaticPrimitives)(nil))
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *StaticPrimitives) SizeSSZ(sizer *ssz.Sizer) (size uint32) {
+	if fork := int(sizer.Fork()); fork < len(staticSizeCacheStaticPrimitives) {
+		return staticSizeCacheStaticPrimitives[fork]
+	}
+	size = 1 + 1 + 2 + 4 + 8 + 32 + 32 + 32 + 1 + 16*2 + 16*4 + 64*8 + 8*32 + (*StaticChild)(nil).SizeSSZ(sizer)
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *StaticPrimitives) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineBool(codec, &obj.Bool)                         // Field  ( 0) -    Bool -   1 bytes
+	ssz.DefineUint8(codec, &obj.Uint8)                       // Field  ( 1) -   Uint8 -   1 bytes
+	ssz.DefineUint16(codec, &obj.Uint16)                     // Field  ( 2) -  Uint16 -   2 bytes
+	ssz.DefineUint32(codec, &obj.Uint32)                     // Field  ( 3) -  Uint32 -   4 bytes
+	ssz.DefineUint64(codec, &obj.Uint64)                     // Field  ( 4) -  Uint64 -   8 bytes
+	ssz.DefineUint256(codec, &obj.Uint256)                   // Field  ( 5) - Uint256 -  32 bytes
+	ssz.DefineUint256BigInt(codec, &obj.BigInt)              // Field  ( 6) -  BigInt -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.Bytes)                 // Field  ( 7) -   Bytes -  32 bytes
+	ssz.DefineArrayOfBits(codec, &obj.Bits, 4)               // Field  ( 8) -    Bits -   1 bytes
+	ssz.DefineArrayOfUint16s(codec, &obj.Uint16s)            // Field  ( 9) - Uint16s -  32 bytes
+	ssz.DefineArrayOfUint32s(codec, &obj.Uint32s)            // Field  (10) - Uint32s -  64 bytes
+	ssz.DefineArrayOfUint64s(codec, &obj.Uint64s)            // Field  (11) - Uint64s - 512 bytes
+	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.Hashes[:]) // Field  (12) -  Hashes - 256 bytes
+	ssz.DefineStaticObject(codec, &obj.Child)                // Field  (13) -   Child -   ? bytes (StaticChild)
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *StaticPrimitives) NamesSSZ() []string {
+	return []string{"Bool", "Uint8", "Uint16", "Uint32", "Uint64", "Uint256", "BigInt", "Bytes", "Bits", "Uint16s", "Uint32s", "Uint64s", "Hashes", "Child"}
+}
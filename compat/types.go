@@ -0,0 +1,109 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package compat
+
+import (
+	"math/big"
+
+	"github.com/holiman/uint256"
+	"github.com/karalabe/ssz"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+//go:generate go run ../cmd/sszgen -type StaticChild -out gen_static_child_ssz.go
+//go:generate go run ../cmd/sszgen -type StaticPrimitives -out gen_static_primitives_ssz.go
+//go:generate go run ../cmd/sszgen -type DynamicChild -out gen_dynamic_child_ssz.go
+//go:generate go run ../cmd/sszgen -type DynamicPrimitives -out gen_dynamic_primitives_ssz.go
+
+// StaticChild is a small static container nested into the primitive fixtures.
+type StaticChild struct {
+	Epoch uint64
+	Root  [32]byte
+}
+
+// StaticPrimitives is a container holding every static primitive supported by
+// the library.
+type StaticPrimitives struct {
+	Bool    bool
+	Uint8   uint8
+	Uint16  uint16
+	Uint32  uint32
+	Uint64  uint64
+	Uint256 *uint256.Int
+	BigInt  *big.Int `ssz-size:"32"`
+	Bytes   [32]byte
+	Bits    [1]byte `ssz-size:"4" ssz:"bits"`
+	Uint16s [16]uint16
+	Uint32s [16]uint32
+	Uint64s [64]uint64
+	Hashes  [8][32]byte
+	Child   *StaticChild
+}
+
+// DynamicChild is a small dynamic container nested into the primitive fixtures.
+type DynamicChild struct {
+	Slot  uint64
+	Bytes []byte `ssz-max:"64"`
+}
+
+// DynamicPrimitives is a container holding every dynamic primitive supported by
+// the library, along with the static ones (via a nested container).
+type DynamicPrimitives struct {
+	Static      *StaticPrimitives
+	Bytes       []byte           `ssz-max:"64"`
+	String      string           `ssz-max:"64"`
+	Bits        bitfield.Bitlist `ssz-max:"64"`
+	Uint16s     []uint16         `ssz-max:"16"`
+	Uint32s     []uint32         `ssz-max:"16"`
+	Uint64s     []uint64         `ssz-max:"16"`
+	Hashes      [][32]byte       `ssz-max:"16"`
+	Blobs       [][]byte         `ssz-max:"16,64"`
+	Children    []*StaticChild   `ssz-max:"16"`
+	ChildValues []StaticChild    `ssz-max:"16"`
+	Nested      []*DynamicChild  `ssz-max:"16"`
+	Child       *DynamicChild
+}
+
+// Fixtures returns a set of named objects covering all the primitives of the
+// library, both zero and populated, whose encodings are pinned down by golden
+// files in the library's own tests.
+func Fixtures() map[string]ssz.Object {
+	static := &StaticPrimitives{
+		Bool:    true,
+		Uint8:   0x01,
+		Uint16:  0x0203,
+		Uint32:  0x04050607,
+		Uint64:  0x08090a0b0c0d0e0f,
+		Uint256: uint256.NewInt(0x10111213),
+		BigInt:  big.NewInt(0x14151617),
+		Bits:    [1]byte{0x05},
+		Child:   &StaticChild{Epoch: 18, Root: [32]byte{19}},
+	}
+	static.Bytes[0], static.Bytes[31] = 20, 21
+	static.Uint16s[0], static.Uint32s[1], static.Uint64s[63] = 22, 23, 24
+	static.Hashes[0][0], static.Hashes[7][31] = 25, 26
+
+	dynamic := &DynamicPrimitives{
+		Static:      static,
+		Bytes:       []byte{27, 28},
+		String:      "ssz",
+		Bits:        bitfield.Bitlist{0x0d},
+		Uint16s:     []uint16{29, 30},
+		Uint32s:     []uint32{31},
+		Uint64s:     []uint64{32, 33, 34},
+		Hashes:      [][32]byte{{35}, {36}},
+		Blobs:       [][]byte{{37}, {}, {38, 39}},
+		Children:    []*StaticChild{{Epoch: 40}},
+		ChildValues: []StaticChild{{Epoch: 41}, {Root: [32]byte{42}}},
+		Nested:      []*DynamicChild{{Slot: 43}, {Bytes: []byte{44}}},
+		Child:       &DynamicChild{Slot: 45, Bytes: []byte{46, 47}},
+	}
+	return map[string]ssz.Object{
+		"static_zero":  new(StaticPrimitives),
+		"static":       static,
+		"dynamic_zero": new(DynamicPrimitives),
+		"dynamic":      dynamic,
+	}
+}
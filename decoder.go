@@ -5,11 +5,16 @@
 package ssz
 
 import (
+	"bufio"
 	"encoding/binary"
 	"fmt"
 	"io"
 	"math/big"
 	"math/bits"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 	"unsafe"
 
 	"github.com/holiman/uint256"
@@ -19,8 +24,9 @@ import (
 // Decoder is a wrapper around an io.Reader or a []byte buffer to implement SSZ
 // decoding in a streaming or buffered way. It has the following behaviors:
 //
-//  1. The decoder does not buffer, simply reads from the wrapped input stream
-//     directly. If you need buffering, that is up to you.
+//  1. The decoder reads ahead from the wrapped input stream in chunks of up to
+//     StreamReadAhead bytes, but never past the declared size of the message,
+//     so the stream can be used further after decoding.
 //
 //  2. The decoder does not return errors that were hit during reading from the
 //     underlying input stream from individual encoding methods. Since there
@@ -48,9 +54,11 @@ import (
 //     aggressively enough (neither does it allow explicitly directing it to),
 //     and in such tight loops, extra calls matter on performance.
 type Decoder struct {
-	inReader io.Reader // Underlying input stream to read from (streaming mode)
-	inRead   uint32    // Bytes already consumed from the reader (streaming mode)
-	inReads  []uint32  // Stack of consumed bytes from outer calls (streaming mode)
+	inReader io.Reader        // Underlying input stream to read from (streaming mode)
+	inLimit  io.LimitedReader // Input stream limited to the message size (streaming mode)
+	inAhead  *bufio.Reader    // Read-ahead buffer over the limited stream (streaming mode)
+	inRead   uint32           // Bytes already consumed from the reader (streaming mode)
+	inReads  []uint32         // Stack of consumed bytes from outer calls (streaming mode)
 
 	inBuffer  []byte    // Underlying input buffer to read from (buffered mode)
 	inBufPtr  uintptr   // Starting pointer in the input buffer (buffered mode)
@@ -72,6 +80,34 @@ type Decoder struct {
 
 	sizes  []uint32   // Computed sizes for the dynamic objects
 	sizess [][]uint32 // Stack of computed sizes from outer calls
+
+	field  int              // Number of fields defined in the current object (error context)
+	object Object           // Object whose fields are being defined (error context)
+	skip   skippedField     // First field skipped by a fork filter in the current slot
+	skips  []skippedField   // Stack of skipped fields from outer slots
+	trace  *dumpTracer      // Field layout collector for Dump (nil when not dumping)
+	mask   *fieldMasker     // Fields to seek past in the top level object (nil = decode all)
+	alloc  func(int) []byte // Custom byte slice allocator (nil = make)
+
+	skipUnknown bool                 // Whether to skip unknown trailing fields of the top level object
+	onUnknown   func(uint32, []byte) // Callback to report the skipped unknown fields to (nil = ignore)
+	unknownAt   uint32               // Offset of the unknown fixed fields pending to be skipped
+	unknownGap  uint32               // Size of the unknown fixed fields pending to be skipped
+
+	budget uint64 // Maximum number of bytes to allocate for the decoded fields (0 = unlimited)
+	spent  uint64 // Number of bytes allocated for the decoded fields so far
+
+	prealloc float64 // Capacity headroom factor for reallocated dynamic fields (<= 1 = exact)
+
+	stats Stats // Structural counters of the decoding (sszdebug builds only)
+}
+
+// skippedField is a field of an object that was not decoded, because its fork
+// filter was inactive in the fork being decoded.
+type skippedField struct {
+	obj    Object     // Object containing the field (nil = no skipped field)
+	field  int        // Index of the field within the object
+	filter ForkFilter // Fork filter that excluded the field
 }
 
 // DecodeBool parses a boolean.
@@ -117,7 +153,8 @@ func DecodeBool[T ~bool](dec *Decoder, v *T) {
 // if it is not allocated yet.
 func DecodeBoolPointerOnFork[T ~bool](dec *Decoder, v **T, filter ForkFilter) {
 	// If the field is not active in the current fork, clear out the output
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
 		*v = nil
 		return
 	}
@@ -154,7 +191,8 @@ func DecodeUint8[T ~uint8](dec *Decoder, n *T) {
 // if it is not allocated yet.
 func DecodeUint8PointerOnFork[T ~uint8](dec *Decoder, n **T, filter ForkFilter) {
 	// If the field is not active in the current fork, clear out the output
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
 		*n = nil
 		return
 	}
@@ -172,14 +210,14 @@ func DecodeUint16[T ~uint16](dec *Decoder, n *T) {
 	}
 	if dec.inReader != nil {
 		_, dec.err = io.ReadFull(dec.inReader, dec.buf[:2])
-		*n = T(binary.LittleEndian.Uint16(dec.buf[:2]))
+		*n = T(dec.codec.order.uint16(dec.buf[:2]))
 		dec.inRead += 2
 	} else {
 		if len(dec.inBuffer) < 2 {
 			dec.err = io.ErrUnexpectedEOF
 			return
 		}
-		*n = T(binary.LittleEndian.Uint16(dec.inBuffer))
+		*n = T(dec.codec.order.uint16(dec.inBuffer))
 		dec.inBuffer = dec.inBuffer[2:]
 	}
 }
@@ -191,7 +229,8 @@ func DecodeUint16[T ~uint16](dec *Decoder, n *T) {
 // if it is not allocated yet.
 func DecodeUint16PointerOnFork[T ~uint16](dec *Decoder, n **T, filter ForkFilter) {
 	// If the field is not active in the current fork, clear out the output
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
 		*n = nil
 		return
 	}
@@ -209,14 +248,14 @@ func DecodeUint32[T ~uint32](dec *Decoder, n *T) {
 	}
 	if dec.inReader != nil {
 		_, dec.err = io.ReadFull(dec.inReader, dec.buf[:4])
-		*n = T(binary.LittleEndian.Uint32(dec.buf[:4]))
+		*n = T(dec.codec.order.uint32(dec.buf[:4]))
 		dec.inRead += 4
 	} else {
 		if len(dec.inBuffer) < 4 {
 			dec.err = io.ErrUnexpectedEOF
 			return
 		}
-		*n = T(binary.LittleEndian.Uint32(dec.inBuffer))
+		*n = T(dec.codec.order.uint32(dec.inBuffer))
 		dec.inBuffer = dec.inBuffer[4:]
 	}
 }
@@ -228,7 +267,8 @@ func DecodeUint32[T ~uint32](dec *Decoder, n *T) {
 // if it is not allocated yet.
 func DecodeUint32PointerOnFork[T ~uint32](dec *Decoder, n **T, filter ForkFilter) {
 	// If the field is not active in the current fork, clear out the output
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
 		*n = nil
 		return
 	}
@@ -246,14 +286,14 @@ func DecodeUint64[T ~uint64](dec *Decoder, n *T) {
 	}
 	if dec.inReader != nil {
 		_, dec.err = io.ReadFull(dec.inReader, dec.buf[:8])
-		*n = T(binary.LittleEndian.Uint64(dec.buf[:8]))
+		*n = T(dec.codec.order.uint64(dec.buf[:8]))
 		dec.inRead += 8
 	} else {
 		if len(dec.inBuffer) < 8 {
 			dec.err = io.ErrUnexpectedEOF
 			return
 		}
-		*n = T(binary.LittleEndian.Uint64(dec.inBuffer))
+		*n = T(dec.codec.order.uint64(dec.inBuffer))
 		dec.inBuffer = dec.inBuffer[8:]
 	}
 }
@@ -265,7 +305,8 @@ func DecodeUint64[T ~uint64](dec *Decoder, n *T) {
 // if it is not allocated yet.
 func DecodeUint64PointerOnFork[T ~uint64](dec *Decoder, n **T, filter ForkFilter) {
 	// If the field is not active in the current fork, clear out the output
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
 		*n = nil
 		return
 	}
@@ -291,7 +332,7 @@ func DecodeUint256(dec *Decoder, n **uint256.Int) {
 		if *n == nil {
 			*n = new(uint256.Int)
 		}
-		(*n).UnmarshalSSZ(dec.buf[:32])
+		dec.codec.order.uint256(dec.buf[:32], *n)
 	} else {
 		if len(dec.inBuffer) < 32 {
 			dec.err = io.ErrUnexpectedEOF
@@ -300,7 +341,7 @@ func DecodeUint256(dec *Decoder, n **uint256.Int) {
 		if *n == nil {
 			*n = new(uint256.Int)
 		}
-		(*n).UnmarshalSSZ(dec.inBuffer[:32])
+		dec.codec.order.uint256(dec.inBuffer[:32], *n)
 		dec.inBuffer = dec.inBuffer[32:]
 	}
 }
@@ -308,7 +349,8 @@ func DecodeUint256(dec *Decoder, n **uint256.Int) {
 // DecodeUint256OnFork parses a uint256 if present in a fork.
 func DecodeUint256OnFork(dec *Decoder, n **uint256.Int, filter ForkFilter) {
 	// If the field is not active in the current fork, clear out the output
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
 		*n = nil
 		return
 	}
@@ -316,6 +358,27 @@ func DecodeUint256OnFork(dec *Decoder, n **uint256.Int, filter ForkFilter) {
 	DecodeUint256(dec, n)
 }
 
+// DecodeUint256Max parses a uint256, rejecting values above a maximum.
+func DecodeUint256Max(dec *Decoder, n **uint256.Int, max uint256.Int) {
+	DecodeUint256(dec, n)
+	if dec.err == nil && (*n).Gt(&max) {
+		dec.err = fmt.Errorf("%w: decoded %s, max %s", ErrMaxValueExceeded, (*n).Dec(), max.Dec())
+	}
+}
+
+// DecodeUint256MaxOnFork parses a uint256 if present in a fork, rejecting values
+// above a maximum.
+func DecodeUint256MaxOnFork(dec *Decoder, n **uint256.Int, max uint256.Int, filter ForkFilter) {
+	// If the field is not active in the current fork, clear out the output
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		*n = nil
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	DecodeUint256Max(dec, n, max)
+}
+
 // DecodeUint256BigInt parses a uint256 into a big.Int.
 func DecodeUint256BigInt(dec *Decoder, n **big.Int) {
 	if dec.err != nil {
@@ -328,14 +391,14 @@ func DecodeUint256BigInt(dec *Decoder, n **big.Int) {
 		}
 		dec.inRead += 32
 
-		dec.bufInt.UnmarshalSSZ(dec.buf[:32])
+		dec.codec.order.uint256(dec.buf[:32], &dec.bufInt)
 		dec.bufInt.IntoBig(n)
 	} else {
 		if len(dec.inBuffer) < 32 {
 			dec.err = io.ErrUnexpectedEOF
 			return
 		}
-		dec.bufInt.UnmarshalSSZ(dec.inBuffer[:32])
+		dec.codec.order.uint256(dec.inBuffer[:32], &dec.bufInt)
 		dec.bufInt.IntoBig(n)
 		dec.inBuffer = dec.inBuffer[32:]
 	}
@@ -344,7 +407,8 @@ func DecodeUint256BigInt(dec *Decoder, n **big.Int) {
 // DecodeUint256BigIntOnFork parses a uint256 into a big.Int if present in a fork.
 func DecodeUint256BigIntOnFork(dec *Decoder, n **big.Int, filter ForkFilter) {
 	// If the field is not active in the current fork, clear out the output
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
 		*n = nil
 		return
 	}
@@ -352,6 +416,31 @@ func DecodeUint256BigIntOnFork(dec *Decoder, n **big.Int, filter ForkFilter) {
 	DecodeUint256BigInt(dec, n)
 }
 
+// DecodeUint256BigIntMax parses a uint256 into a big.Int, rejecting values above
+// a maximum.
+func DecodeUint256BigIntMax(dec *Decoder, n **big.Int, max uint256.Int) {
+	DecodeUint256BigInt(dec, n)
+
+	// The decoded value is still available in the scratch uint256, check that
+	// instead of the big.Int to avoid conversions
+	if dec.err == nil && dec.bufInt.Gt(&max) {
+		dec.err = fmt.Errorf("%w: decoded %s, max %s", ErrMaxValueExceeded, dec.bufInt.Dec(), max.Dec())
+	}
+}
+
+// DecodeUint256BigIntMaxOnFork parses a uint256 into a big.Int if present in a
+// fork, rejecting values above a maximum.
+func DecodeUint256BigIntMaxOnFork(dec *Decoder, n **big.Int, max uint256.Int, filter ForkFilter) {
+	// If the field is not active in the current fork, clear out the output
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		*n = nil
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	DecodeUint256BigIntMax(dec, n, max)
+}
+
 // DecodeStaticBytes parses a static binary blob.
 func DecodeStaticBytes[T commonBytesLengths](dec *Decoder, blob *T) {
 	if dec.err != nil {
@@ -378,7 +467,8 @@ func DecodeStaticBytes[T commonBytesLengths](dec *Decoder, blob *T) {
 // If not, the bytes are set to nil.
 func DecodeStaticBytesPointerOnFork[T commonBytesLengths](dec *Decoder, blob **T, filter ForkFilter) {
 	// If the field is not active in the current fork, clear out the output
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
 		*blob = nil
 		return
 	}
@@ -396,7 +486,10 @@ func DecodeCheckedStaticBytes(dec *Decoder, blob *[]byte, size uint64) {
 	}
 	// Expand the byte slice if needed and fill it with the data
 	if uint64(cap(*blob)) < size {
-		*blob = make([]byte, size)
+		if !dec.charge(size) {
+			return
+		}
+		*blob = dec.allocBytes(int(size))
 	} else {
 		*blob = (*blob)[:size]
 	}
@@ -422,7 +515,8 @@ func DecodeDynamicBytesOffset(dec *Decoder, blob *[]byte) {
 // present in a fork.
 func DecodeDynamicBytesOffsetOnFork(dec *Decoder, blob *[]byte, filter ForkFilter) {
 	// If the field is not active in the current fork, skip parsing the offset
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
 		return
 	}
 	// Otherwise fall back to the standard decoder
@@ -442,7 +536,11 @@ func DecodeDynamicBytesContent(dec *Decoder, blob *[]byte, maxSize uint64) {
 	}
 	// Expand the byte slice if needed and fill it with the data
 	if uint32(cap(*blob)) < size {
-		*blob = make([]byte, size)
+		capacity := dec.preallocSize(uint64(size), maxSize)
+		if !dec.charge(capacity) {
+			return
+		}
+		*blob = dec.allocBytes(int(capacity))[:size]
 	} else {
 		*blob = (*blob)[:size]
 	}
@@ -465,7 +563,8 @@ func DecodeDynamicBytesContent(dec *Decoder, blob *[]byte, maxSize uint64) {
 // DecodeDynamicBytesContentOnFork is the lazy data reader of DecodeDynamicBytesOffsetOnFork.
 func DecodeDynamicBytesContentOnFork(dec *Decoder, blob *[]byte, maxSize uint64, filter ForkFilter) {
 	// If the field is not active in the current fork, clear out the output
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
 		*blob = nil
 		return
 	}
@@ -473,21 +572,109 @@ func DecodeDynamicBytesContentOnFork(dec *Decoder, blob *[]byte, maxSize uint64,
 	DecodeDynamicBytesContent(dec, blob, maxSize)
 }
 
+// DecodeStringOffset parses the offset of a string encoded as a dynamic binary
+// blob.
+func DecodeStringOffset[T ~string](dec *Decoder, str *T) {
+	dec.decodeOffset(false)
+}
+
+// DecodeStringOffsetOnFork parses the offset of a string encoded as a dynamic
+// binary blob if present in a fork.
+func DecodeStringOffsetOnFork[T ~string](dec *Decoder, str *T, filter ForkFilter) {
+	// If the field is not active in the current fork, skip parsing the offset
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	DecodeStringOffset(dec, str)
+}
+
+// DecodeStringContent is the lazy data reader of DecodeStringOffset.
+func DecodeStringContent[T ~string](dec *Decoder, str *T, maxSize uint64) {
+	if dec.err != nil {
+		return
+	}
+	// Compute the length of the string based on the seen offsets
+	size := dec.retrieveSize()
+	if uint64(size) > maxSize {
+		dec.err = fmt.Errorf("%w: decoded %d, max %d", ErrMaxLengthExceeded, size, maxSize)
+		return
+	}
+	// Strings are immutable, so a fresh one needs to be allocated either way
+	if !dec.charge(uint64(size)) {
+		return
+	}
+	if dec.inReader != nil {
+		blob := make([]byte, size)
+		if _, dec.err = io.ReadFull(dec.inReader, blob); dec.err != nil {
+			return
+		}
+		dec.inRead += size
+		*str = T(blob)
+	} else {
+		if uint32(len(dec.inBuffer)) < size {
+			dec.err = io.ErrUnexpectedEOF
+			return
+		}
+		*str = T(dec.inBuffer[:size])
+		dec.inBuffer = dec.inBuffer[size:]
+	}
+}
+
+// DecodeStringContentOnFork is the lazy data reader of DecodeStringOffsetOnFork.
+func DecodeStringContentOnFork[T ~string](dec *Decoder, str *T, maxSize uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, clear out the output
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		*str = ""
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	DecodeStringContent(dec, str, maxSize)
+}
+
+// DecodeUTF8StringContent is the lazy data reader of DecodeStringOffset, which
+// also validates that the decoded string is valid UTF-8.
+func DecodeUTF8StringContent[T ~string](dec *Decoder, str *T, maxSize uint64) {
+	DecodeStringContent(dec, str, maxSize)
+	if dec.err == nil && !utf8.ValidString(string(*str)) {
+		dec.err = ErrInvalidUTF8
+	}
+}
+
+// DecodeUTF8StringContentOnFork is the lazy data reader of DecodeStringOffsetOnFork,
+// which also validates that the decoded string is valid UTF-8.
+func DecodeUTF8StringContentOnFork[T ~string](dec *Decoder, str *T, maxSize uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, clear out the output
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		*str = ""
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	DecodeUTF8StringContent(dec, str, maxSize)
+}
+
 // DecodeStaticObject parses a static ssz object.
 func DecodeStaticObject[T newableStaticObject[U], U any](dec *Decoder, obj *T) {
 	if dec.err != nil {
 		return
 	}
 	if *obj == nil {
+		if !chargeItems[U](dec, 1) {
+			return
+		}
 		*obj = T(new(U))
 	}
-	(*obj).DefineSSZ(dec.codec)
+	dec.decodeObject(*obj)
 }
 
 // DecodeStaticObjectOnFork parses a static ssz object if present in a fork.
 func DecodeStaticObjectOnFork[T newableStaticObject[U], U any](dec *Decoder, obj *T, filter ForkFilter) {
 	// If the field is not active in the current fork, clear out the output
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
 		*obj = nil
 		return
 	}
@@ -503,7 +690,8 @@ func DecodeDynamicObjectOffset[T newableDynamicObject[U], U any](dec *Decoder, o
 // DecodeDynamicObjectOffsetOnFork parses a dynamic ssz object if present in a fork.
 func DecodeDynamicObjectOffsetOnFork[T newableDynamicObject[U], U any](dec *Decoder, obj *T, filter ForkFilter) {
 	// If the field is not active in the current fork, skip parsing the offset
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
 		return
 	}
 	// Otherwise fall back to the standard decoder
@@ -523,17 +711,21 @@ func DecodeDynamicObjectContent[T newableDynamicObject[U], U any](dec *Decoder,
 	defer dec.ascendFromSlot()
 
 	if *obj == nil {
+		if !chargeItems[U](dec, 1) {
+			return
+		}
 		*obj = T(new(U))
 	}
 	dec.startDynamics((*obj).SizeSSZ(dec.sizer, true))
-	(*obj).DefineSSZ(dec.codec)
+	dec.decodeObject(*obj)
 	dec.flushDynamics()
 }
 
 // DecodeDynamicObjectContentOnFork is the lazy data reader of DecodeDynamicObjectOffsetOnFork.
 func DecodeDynamicObjectContentOnFork[T newableDynamicObject[U], U any](dec *Decoder, obj *T, filter ForkFilter) {
 	// If the field is not active in the current fork, clear out the output
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
 		*obj = nil
 		return
 	}
@@ -541,6 +733,83 @@ func DecodeDynamicObjectContentOnFork[T newableDynamicObject[U], U any](dec *Dec
 	DecodeDynamicObjectContent(dec, obj)
 }
 
+// DecodeGenericStaticObject parses a static ssz object passed as a type parameter
+// of a generic container.
+func DecodeGenericStaticObject[T StaticObject](dec *Decoder, obj *T) {
+	if dec.err != nil {
+		return
+	}
+	if isNilGeneric(*obj) {
+		*obj = newGeneric[T]()
+	}
+	dec.decodeObject(*obj)
+}
+
+// DecodeGenericStaticObjectOnFork parses a static ssz object passed as a type
+// parameter of a generic container if present in a fork.
+func DecodeGenericStaticObjectOnFork[T StaticObject](dec *Decoder, obj *T, filter ForkFilter) {
+	// If the field is not active in the current fork, clear out the output
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		var zero T
+		*obj = zero
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	DecodeGenericStaticObject(dec, obj)
+}
+
+// DecodeGenericDynamicObjectOffset parses a dynamic ssz object passed as a type
+// parameter of a generic container.
+func DecodeGenericDynamicObjectOffset[T DynamicObject](dec *Decoder, obj *T) {
+	dec.decodeOffset(false)
+}
+
+// DecodeGenericDynamicObjectOffsetOnFork parses a dynamic ssz object passed as a
+// type parameter of a generic container if present in a fork.
+func DecodeGenericDynamicObjectOffsetOnFork[T DynamicObject](dec *Decoder, obj *T, filter ForkFilter) {
+	// If the field is not active in the current fork, skip parsing the offset
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	DecodeGenericDynamicObjectOffset(dec, obj)
+}
+
+// DecodeGenericDynamicObjectContent is the lazy data reader of DecodeGenericDynamicObjectOffset.
+func DecodeGenericDynamicObjectContent[T DynamicObject](dec *Decoder, obj *T) {
+	if dec.err != nil {
+		return
+	}
+	// Compute the length of the object based on the seen offsets
+	size := dec.retrieveSize()
+
+	// Descend into a new data slot to track/verify a new sub-length
+	dec.descendIntoSlot(size)
+	defer dec.ascendFromSlot()
+
+	if isNilGeneric(*obj) {
+		*obj = newGeneric[T]()
+	}
+	dec.startDynamics((*obj).SizeSSZ(dec.sizer, true))
+	dec.decodeObject(*obj)
+	dec.flushDynamics()
+}
+
+// DecodeGenericDynamicObjectContentOnFork is the lazy data reader of DecodeGenericDynamicObjectOffsetOnFork.
+func DecodeGenericDynamicObjectContentOnFork[T DynamicObject](dec *Decoder, obj *T, filter ForkFilter) {
+	// If the field is not active in the current fork, clear out the output
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		var zero T
+		*obj = zero
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	DecodeGenericDynamicObjectContent(dec, obj)
+}
+
 // DecodeArrayOfBits parses a static array of (packed) bits.
 func DecodeArrayOfBits[T commonBitsLengths](dec *Decoder, bits *T, size uint64) {
 	if dec.err != nil {
@@ -564,20 +833,15 @@ func DecodeArrayOfBits[T commonBitsLengths](dec *Decoder, bits *T, size uint64)
 		copy(bitvector, dec.inBuffer)
 		dec.inBuffer = dec.inBuffer[len(bitvector):]
 	}
-	// TODO(karalabe): This can probably be done more optimally...
-	for i := size; i < uint64(len(bitvector)<<3); i++ {
-		if bitvector[i>>3]&(1<<(i&0x7)) > 0 {
-			dec.err = fmt.Errorf("%w: bit %d set, size %d bits", ErrJunkInBitvector, i+1, size)
-			return
-		}
-	}
+	dec.checkBitvector(bitvector, size)
 }
 
 // DecodeArrayOfBitsPointerOnFork parses a static array of (packed) bits if present
 // in a fork. If not, the bit array pointer is set to nil.
 func DecodeArrayOfBitsPointerOnFork[T commonBitsLengths](dec *Decoder, bits **T, size uint64, filter ForkFilter) {
 	// If the field is not active in the current fork, clear out the output
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
 		*bits = nil
 		return
 	}
@@ -588,6 +852,59 @@ func DecodeArrayOfBitsPointerOnFork[T commonBitsLengths](dec *Decoder, bits **T,
 	DecodeArrayOfBits(dec, *bits, size)
 }
 
+// DecodeUnsafeArrayOfBits parses a static array of static arrays of (packed)
+// bits.
+func DecodeUnsafeArrayOfBits[T commonBitsLengths](dec *Decoder, bits []T, size uint64) {
+	for i := 0; i < len(bits); i++ { // don't range loop, T is an array, copy is expensive
+		DecodeArrayOfBits(dec, &bits[i], size)
+	}
+}
+
+// DecodeCheckedArrayOfBits parses a static array of static arrays of (packed)
+// bits.
+func DecodeCheckedArrayOfBits[T commonBitsLengths](dec *Decoder, bits *[]T, items uint64, size uint64) {
+	if dec.err != nil {
+		return
+	}
+	// Expand the bit-array slice if needed and fill it with the data
+	if uint64(cap(*bits)) < items {
+		if !chargeItems[T](dec, items) {
+			return
+		}
+		*bits = allocArrays[T](dec, int(items))
+	} else {
+		*bits = (*bits)[:items]
+	}
+	DecodeUnsafeArrayOfBits(dec, *bits, size)
+}
+
+// DecodeCheckedBits parses a static (packed) bit slice, such as one of
+// go-bitfield's bitvectors.
+func DecodeCheckedBits[T ~[]byte](dec *Decoder, bits *T, size uint64) {
+	bitvector := []byte(*bits)
+	DecodeCheckedStaticBytes(dec, &bitvector, (size+7)>>3)
+	if dec.err != nil {
+		return
+	}
+	*bits = T(bitvector)
+	dec.checkBitvector(bitvector, size)
+}
+
+// DecodeUnsafeArrayOfCheckedBits parses a static array of (packed) bit slices,
+// such as go-bitfield's bitvectors.
+func DecodeUnsafeArrayOfCheckedBits[T ~[]byte](dec *Decoder, bits []T, size uint64) {
+	blobSize := (size + 7) >> 3
+	for i := 0; i < len(bits); i++ {
+		bitvector := []byte(bits[i])
+		DecodeCheckedStaticBytes(dec, &bitvector, blobSize)
+		if dec.err != nil {
+			return
+		}
+		bits[i] = T(bitvector)
+		dec.checkBitvector(bitvector, size)
+	}
+}
+
 // DecodeSliceOfBitsOffset parses a dynamic slice of (packed) bits.
 func DecodeSliceOfBitsOffset(dec *Decoder, bitlist *bitfield.Bitlist) {
 	dec.decodeOffset(false)
@@ -597,7 +914,8 @@ func DecodeSliceOfBitsOffset(dec *Decoder, bitlist *bitfield.Bitlist) {
 // in a fork.
 func DecodeSliceOfBitsOffsetOnFork(dec *Decoder, bitlist *bitfield.Bitlist, filter ForkFilter) {
 	// If the field is not active in the current fork, skip parsing the offset
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
 		return
 	}
 	// Otherwise fall back to the standard decoder
@@ -622,7 +940,10 @@ func DecodeSliceOfBitsContent(dec *Decoder, bitlist *bitfield.Bitlist, maxBits u
 	}
 	// Expand the slice if needed and read the bits
 	if uint32(cap(*bitlist)) < size {
-		*bitlist = make([]byte, size)
+		if !dec.charge(uint64(size)) {
+			return
+		}
+		*bitlist = dec.allocBytes(int(size))
 	} else {
 		*bitlist = (*bitlist)[:size]
 	}
@@ -655,7 +976,8 @@ func DecodeSliceOfBitsContent(dec *Decoder, bitlist *bitfield.Bitlist, maxBits u
 // DecodeSliceOfBitsContentOnFork is the lazy data reader of DecodeSliceOfBitsOffsetOnFork.
 func DecodeSliceOfBitsContentOnFork(dec *Decoder, bitlist *bitfield.Bitlist, maxBits uint64, filter ForkFilter) {
 	// If the field is not active in the current fork, clear out the output
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
 		*bitlist = nil
 		return
 	}
@@ -678,7 +1000,7 @@ func DecodeArrayOfUint64s[T commonUint64sLengths](dec *Decoder, ns *T) {
 			if dec.err != nil {
 				return
 			}
-			nums[i] = binary.LittleEndian.Uint64(dec.buf[:8])
+			nums[i] = dec.codec.order.uint64(dec.buf[:8])
 			dec.inRead += 8
 		}
 	} else {
@@ -687,7 +1009,7 @@ func DecodeArrayOfUint64s[T commonUint64sLengths](dec *Decoder, ns *T) {
 				dec.err = io.ErrUnexpectedEOF
 				return
 			}
-			nums[i] = binary.LittleEndian.Uint64(dec.inBuffer)
+			nums[i] = dec.codec.order.uint64(dec.inBuffer)
 			dec.inBuffer = dec.inBuffer[8:]
 		}
 	}
@@ -697,7 +1019,8 @@ func DecodeArrayOfUint64s[T commonUint64sLengths](dec *Decoder, ns *T) {
 // in a fork. If not, the bit array pointer is set to nil.
 func DecodeArrayOfUint64sPointerOnFork[T commonUint64sLengths](dec *Decoder, ns **T, filter ForkFilter) {
 	// If the field is not active in the current fork, clear out the output
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
 		*ns = nil
 		return
 	}
@@ -708,138 +1031,527 @@ func DecodeArrayOfUint64sPointerOnFork[T commonUint64sLengths](dec *Decoder, ns
 	DecodeArrayOfUint64s(dec, *ns)
 }
 
-// DecodeSliceOfUint64sOffset parses a dynamic slice of uint64s.
-func DecodeSliceOfUint64sOffset[T ~uint64](dec *Decoder, ns *[]T) {
-	dec.decodeOffset(false)
-}
-
-// DecodeSliceOfUint64sOffsetOnFork parses a dynamic slice of uint64s if present
-// in a fork.
-func DecodeSliceOfUint64sOffsetOnFork[T ~uint64](dec *Decoder, ns *[]T, filter ForkFilter) {
-	// If the field is not active in the current fork, skip parsing the offset
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
-		return
-	}
-	// Otherwise fall back to the standard decoder
-	DecodeSliceOfUint64sOffset(dec, ns)
-}
-
-// DecodeSliceOfUint64sContent is the lazy data reader of DecodeSliceOfUint64sOffset.
-func DecodeSliceOfUint64sContent[T ~uint64](dec *Decoder, ns *[]T, maxItems uint64) {
+// DecodeCheckedStaticUint64s parses a static array of uint64s into a plain slice.
+func DecodeCheckedStaticUint64s[T ~uint64](dec *Decoder, ns *[]T, size uint64) {
 	if dec.err != nil {
 		return
 	}
-	// Compute the length of the encoded binaries based on the seen offsets
-	size := dec.retrieveSize()
-	if size == 0 {
-		// Empty slice, remove anything extra
-		if *ns == nil {
-			*ns = make([]T, 0) // Don't leave nil, init to empty
-		} else {
-			*ns = (*ns)[:0]
+	// Expand the slice if needed and fill it with the data
+	if uint64(cap(*ns)) < size {
+		if !chargeItems[T](dec, size) {
+			return
 		}
-		return
-	}
-	// Compute the number of items based on the item size of the type
-	if size&7 != 0 {
-		dec.err = fmt.Errorf("%w: length %d, item size %d", ErrDynamicStaticsIndivisible, size, 8)
-		return
-	}
-	itemCount := size >> 3
-	if uint64(itemCount) > maxItems {
-		dec.err = fmt.Errorf("%w: decoded %d, max %d", ErrMaxItemsExceeded, itemCount, maxItems)
-		return
-	}
-	// Expand the slice if needed and decode the objects
-	if uint32(cap(*ns)) < itemCount {
-		*ns = make([]T, itemCount)
+		*ns = make([]T, size)
 	} else {
-		*ns = (*ns)[:itemCount]
+		*ns = (*ns)[:size]
 	}
 	if dec.inReader != nil {
-		for i := uint32(0); i < itemCount; i++ {
+		for i := uint64(0); i < size; i++ {
 			_, dec.err = io.ReadFull(dec.inReader, dec.buf[:8])
 			if dec.err != nil {
 				return
 			}
-			(*ns)[i] = T(binary.LittleEndian.Uint64(dec.buf[:8]))
+			(*ns)[i] = T(dec.codec.order.uint64(dec.buf[:8]))
+			dec.inRead += 8
 		}
-		dec.inRead += 8 * itemCount
 	} else {
-		for i := uint32(0); i < itemCount; i++ {
+		for i := uint64(0); i < size; i++ {
 			if len(dec.inBuffer) < 8 {
 				dec.err = io.ErrUnexpectedEOF
 				return
 			}
-			(*ns)[i] = T(binary.LittleEndian.Uint64(dec.inBuffer))
+			(*ns)[i] = T(dec.codec.order.uint64(dec.inBuffer))
 			dec.inBuffer = dec.inBuffer[8:]
 		}
 	}
 }
 
-// DecodeSliceOfUint64sContentOnFork is the lazy data reader of DecodeSliceOfUint64sOffsetOnFork.
-func DecodeSliceOfUint64sContentOnFork[T ~uint64](dec *Decoder, ns *[]T, maxItems uint64, filter ForkFilter) {
-	// If the field is not active in the current fork, clear out the output
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
-		*ns = nil
+// DecodeArrayOfUint16s parses a static array of uint16s.
+func DecodeArrayOfUint16s[T commonUint16sLengths](dec *Decoder, ns *T) {
+	if dec.err != nil {
 		return
 	}
-	// Otherwise fall back to the standard decoder
-	DecodeSliceOfUint64sContent(dec, ns, maxItems)
-}
-
-// DecodeArrayOfStaticBytes parses a static array of static binary blobs.
-func DecodeArrayOfStaticBytes[T commonBytesArrayLengths[U], U commonBytesLengths](dec *Decoder, blobs *T) {
-	// The code below should have used `(*blobs)[:]`, alas Go's generics compiler
+	// The code below should have used `*blob[:]`, alas Go's generics compiler
 	// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-	DecodeUnsafeArrayOfStaticBytes(dec, unsafe.Slice(&(*blobs)[0], len(*blobs)))
-}
+	nums := unsafe.Slice(&(*ns)[0], len(*ns))
 
-// DecodeUnsafeArrayOfStaticBytes parses a static array of static binary blobs.
-func DecodeUnsafeArrayOfStaticBytes[T commonBytesLengths](dec *Decoder, blobs []T) {
-	if dec.err != nil {
-		return
-	}
 	if dec.inReader != nil {
-		for i := 0; i < len(blobs); i++ {
-			// The code below should have used `(*blobs)[i][:]`, alas Go's generics compiler
-			// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-			_, dec.err = io.ReadFull(dec.inReader, unsafe.Slice(&(blobs)[i][0], len((blobs)[i])))
+		for i := 0; i < len(nums); i++ {
+			_, dec.err = io.ReadFull(dec.inReader, dec.buf[:2])
 			if dec.err != nil {
 				return
 			}
-			dec.inRead += uint32(len((blobs)[i]))
+			nums[i] = dec.codec.order.uint16(dec.buf[:2])
+			dec.inRead += 2
 		}
 	} else {
-		for i := 0; i < len(blobs); i++ {
-			if len(dec.inBuffer) < len((blobs)[i]) {
+		for i := 0; i < len(nums); i++ {
+			if len(dec.inBuffer) < 2 {
 				dec.err = io.ErrUnexpectedEOF
 				return
 			}
-			// The code below should have used `blobs[i][:]`, alas Go's generics compiler
-			// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-			copy(unsafe.Slice(&(blobs)[i][0], len((blobs)[i])), dec.inBuffer)
-			dec.inBuffer = dec.inBuffer[len((blobs)[i]):]
+			nums[i] = dec.codec.order.uint16(dec.inBuffer)
+			dec.inBuffer = dec.inBuffer[2:]
 		}
 	}
 }
 
-// DecodeCheckedArrayOfStaticBytes parses a static array of static binary blobs.
-func DecodeCheckedArrayOfStaticBytes[T commonBytesLengths](dec *Decoder, blobs *[]T, size uint64) {
-	if dec.err != nil {
+// DecodeArrayOfUint16sPointerOnFork parses a static array of uint16s if present
+// in a fork. If not, the array pointer is set to nil.
+func DecodeArrayOfUint16sPointerOnFork[T commonUint16sLengths](dec *Decoder, ns **T, filter ForkFilter) {
+	// If the field is not active in the current fork, clear out the output
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		*ns = nil
 		return
 	}
-	// Expand the byte-array slice if needed and fill it with the data
-	if uint64(cap(*blobs)) < size {
-		*blobs = make([]T, size)
-	} else {
-		*blobs = (*blobs)[:size]
+	// Otherwise fall back to the standard decoder
+	if *ns == nil {
+		*ns = new(T)
 	}
-	if dec.inReader != nil {
-		for i := 0; i < len(*blobs); i++ {
-			// The code below should have used `(*blobs)[i][:]`, alas Go's generics compiler
-			// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-			_, dec.err = io.ReadFull(dec.inReader, unsafe.Slice(&(*blobs)[i][0], len((*blobs)[i])))
+	DecodeArrayOfUint16s(dec, *ns)
+}
+
+// DecodeArrayOfUint32s parses a static array of uint32s.
+func DecodeArrayOfUint32s[T commonUint32sLengths](dec *Decoder, ns *T) {
+	if dec.err != nil {
+		return
+	}
+	// The code below should have used `*blob[:]`, alas Go's generics compiler
+	// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
+	nums := unsafe.Slice(&(*ns)[0], len(*ns))
+
+	if dec.inReader != nil {
+		for i := 0; i < len(nums); i++ {
+			_, dec.err = io.ReadFull(dec.inReader, dec.buf[:4])
+			if dec.err != nil {
+				return
+			}
+			nums[i] = dec.codec.order.uint32(dec.buf[:4])
+			dec.inRead += 4
+		}
+	} else {
+		for i := 0; i < len(nums); i++ {
+			if len(dec.inBuffer) < 4 {
+				dec.err = io.ErrUnexpectedEOF
+				return
+			}
+			nums[i] = dec.codec.order.uint32(dec.inBuffer)
+			dec.inBuffer = dec.inBuffer[4:]
+		}
+	}
+}
+
+// DecodeArrayOfUint32sPointerOnFork parses a static array of uint32s if present
+// in a fork. If not, the array pointer is set to nil.
+func DecodeArrayOfUint32sPointerOnFork[T commonUint32sLengths](dec *Decoder, ns **T, filter ForkFilter) {
+	// If the field is not active in the current fork, clear out the output
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		*ns = nil
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	if *ns == nil {
+		*ns = new(T)
+	}
+	DecodeArrayOfUint32s(dec, *ns)
+}
+
+// DecodeArrayOfArrayOfUint16s parses a static array of static arrays of uint16s.
+func DecodeArrayOfArrayOfUint16s[T commonUint16sLengths](dec *Decoder, ns []T) {
+	for i := 0; i < len(ns); i++ {
+		DecodeArrayOfUint16s(dec, &ns[i])
+	}
+}
+
+// DecodeArrayOfArrayOfUint32s parses a static array of static arrays of uint32s.
+func DecodeArrayOfArrayOfUint32s[T commonUint32sLengths](dec *Decoder, ns []T) {
+	for i := 0; i < len(ns); i++ {
+		DecodeArrayOfUint32s(dec, &ns[i])
+	}
+}
+
+// DecodeArrayOfArrayOfUint64s parses a static array of static arrays of uint64s.
+func DecodeArrayOfArrayOfUint64s[T commonUint64sLengths](dec *Decoder, ns []T) {
+	for i := 0; i < len(ns); i++ {
+		DecodeArrayOfUint64s(dec, &ns[i])
+	}
+}
+
+// DecodeSliceOfUint16sOffset parses a dynamic slice of uint16s.
+func DecodeSliceOfUint16sOffset[T ~uint16](dec *Decoder, ns *[]T) {
+	dec.decodeOffset(false)
+}
+
+// DecodeSliceOfUint16sOffsetOnFork parses a dynamic slice of uint16s if present
+// in a fork.
+func DecodeSliceOfUint16sOffsetOnFork[T ~uint16](dec *Decoder, ns *[]T, filter ForkFilter) {
+	// If the field is not active in the current fork, skip parsing the offset
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	DecodeSliceOfUint16sOffset(dec, ns)
+}
+
+// DecodeSliceOfUint16sContent is the lazy data reader of DecodeSliceOfUint16sOffset.
+func DecodeSliceOfUint16sContent[T ~uint16](dec *Decoder, ns *[]T, maxItems uint64) {
+	if dec.err != nil {
+		return
+	}
+	// Compute the length of the encoded binaries based on the seen offsets
+	size := dec.retrieveSize()
+	if size == 0 {
+		// Empty slice, remove anything extra
+		if *ns == nil {
+			*ns = make([]T, 0) // Don't leave nil, init to empty
+		} else {
+			*ns = (*ns)[:0]
+		}
+		return
+	}
+	// Compute the number of items based on the item size of the type
+	if size&1 != 0 {
+		dec.err = fmt.Errorf("%w: length %d, item size %d", ErrDynamicStaticsIndivisible, size, 2)
+		return
+	}
+	itemCount := size >> 1
+	if uint64(itemCount) > maxItems {
+		dec.err = fmt.Errorf("%w: decoded %d, max %d", ErrMaxItemsExceeded, itemCount, maxItems)
+		return
+	}
+	// Expand the slice if needed and decode the objects
+	if uint32(cap(*ns)) < itemCount {
+		if !chargeItems[T](dec, uint64(itemCount)) {
+			return
+		}
+		*ns = make([]T, itemCount)
+	} else {
+		*ns = (*ns)[:itemCount]
+	}
+	if dec.inReader != nil {
+		for i := uint32(0); i < itemCount; i++ {
+			_, dec.err = io.ReadFull(dec.inReader, dec.buf[:2])
+			if dec.err != nil {
+				return
+			}
+			(*ns)[i] = T(dec.codec.order.uint16(dec.buf[:2]))
+		}
+		dec.inRead += 2 * itemCount
+	} else {
+		for i := uint32(0); i < itemCount; i++ {
+			if len(dec.inBuffer) < 2 {
+				dec.err = io.ErrUnexpectedEOF
+				return
+			}
+			(*ns)[i] = T(dec.codec.order.uint16(dec.inBuffer))
+			dec.inBuffer = dec.inBuffer[2:]
+		}
+	}
+}
+
+// DecodeSliceOfUint16sContentOnFork is the lazy data reader of DecodeSliceOfUint16sOffsetOnFork.
+func DecodeSliceOfUint16sContentOnFork[T ~uint16](dec *Decoder, ns *[]T, maxItems uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, clear out the output
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		*ns = nil
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	DecodeSliceOfUint16sContent(dec, ns, maxItems)
+}
+
+// DecodeSliceOfUint32sOffset parses a dynamic slice of uint32s.
+func DecodeSliceOfUint32sOffset[T ~uint32](dec *Decoder, ns *[]T) {
+	dec.decodeOffset(false)
+}
+
+// DecodeSliceOfUint32sOffsetOnFork parses a dynamic slice of uint32s if present
+// in a fork.
+func DecodeSliceOfUint32sOffsetOnFork[T ~uint32](dec *Decoder, ns *[]T, filter ForkFilter) {
+	// If the field is not active in the current fork, skip parsing the offset
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	DecodeSliceOfUint32sOffset(dec, ns)
+}
+
+// DecodeSliceOfUint32sContent is the lazy data reader of DecodeSliceOfUint32sOffset.
+func DecodeSliceOfUint32sContent[T ~uint32](dec *Decoder, ns *[]T, maxItems uint64) {
+	if dec.err != nil {
+		return
+	}
+	// Compute the length of the encoded binaries based on the seen offsets
+	size := dec.retrieveSize()
+	if size == 0 {
+		// Empty slice, remove anything extra
+		if *ns == nil {
+			*ns = make([]T, 0) // Don't leave nil, init to empty
+		} else {
+			*ns = (*ns)[:0]
+		}
+		return
+	}
+	// Compute the number of items based on the item size of the type
+	if size&3 != 0 {
+		dec.err = fmt.Errorf("%w: length %d, item size %d", ErrDynamicStaticsIndivisible, size, 4)
+		return
+	}
+	itemCount := size >> 2
+	if uint64(itemCount) > maxItems {
+		dec.err = fmt.Errorf("%w: decoded %d, max %d", ErrMaxItemsExceeded, itemCount, maxItems)
+		return
+	}
+	// Expand the slice if needed and decode the objects
+	if uint32(cap(*ns)) < itemCount {
+		if !chargeItems[T](dec, uint64(itemCount)) {
+			return
+		}
+		*ns = make([]T, itemCount)
+	} else {
+		*ns = (*ns)[:itemCount]
+	}
+	if dec.inReader != nil {
+		for i := uint32(0); i < itemCount; i++ {
+			_, dec.err = io.ReadFull(dec.inReader, dec.buf[:4])
+			if dec.err != nil {
+				return
+			}
+			(*ns)[i] = T(dec.codec.order.uint32(dec.buf[:4]))
+		}
+		dec.inRead += 4 * itemCount
+	} else {
+		for i := uint32(0); i < itemCount; i++ {
+			if len(dec.inBuffer) < 4 {
+				dec.err = io.ErrUnexpectedEOF
+				return
+			}
+			(*ns)[i] = T(dec.codec.order.uint32(dec.inBuffer))
+			dec.inBuffer = dec.inBuffer[4:]
+		}
+	}
+}
+
+// DecodeSliceOfUint32sContentOnFork is the lazy data reader of DecodeSliceOfUint32sOffsetOnFork.
+func DecodeSliceOfUint32sContentOnFork[T ~uint32](dec *Decoder, ns *[]T, maxItems uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, clear out the output
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		*ns = nil
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	DecodeSliceOfUint32sContent(dec, ns, maxItems)
+}
+
+// DecodeSliceOfUint64sOffset parses a dynamic slice of uint64s.
+func DecodeSliceOfUint64sOffset[T ~uint64](dec *Decoder, ns *[]T) {
+	dec.decodeOffset(false)
+}
+
+// DecodeSliceOfUint64sOffsetOnFork parses a dynamic slice of uint64s if present
+// in a fork.
+func DecodeSliceOfUint64sOffsetOnFork[T ~uint64](dec *Decoder, ns *[]T, filter ForkFilter) {
+	// If the field is not active in the current fork, skip parsing the offset
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	DecodeSliceOfUint64sOffset(dec, ns)
+}
+
+// DecodeSliceOfUint64sContent is the lazy data reader of DecodeSliceOfUint64sOffset.
+func DecodeSliceOfUint64sContent[T ~uint64](dec *Decoder, ns *[]T, maxItems uint64) {
+	if dec.err != nil {
+		return
+	}
+	// Compute the length of the encoded binaries based on the seen offsets
+	size := dec.retrieveSize()
+	if size == 0 {
+		// Empty slice, remove anything extra
+		if *ns == nil {
+			*ns = make([]T, 0) // Don't leave nil, init to empty
+		} else {
+			*ns = (*ns)[:0]
+		}
+		return
+	}
+	// Compute the number of items based on the item size of the type
+	if size&7 != 0 {
+		dec.err = fmt.Errorf("%w: length %d, item size %d", ErrDynamicStaticsIndivisible, size, 8)
+		return
+	}
+	itemCount := size >> 3
+	if uint64(itemCount) > maxItems {
+		dec.err = fmt.Errorf("%w: decoded %d, max %d", ErrMaxItemsExceeded, itemCount, maxItems)
+		return
+	}
+	// Expand the slice if needed and decode the objects
+	if uint32(cap(*ns)) < itemCount {
+		if !chargeItems[T](dec, uint64(itemCount)) {
+			return
+		}
+		*ns = make([]T, itemCount)
+	} else {
+		*ns = (*ns)[:itemCount]
+	}
+	if dec.inReader != nil {
+		for i := uint32(0); i < itemCount; i++ {
+			_, dec.err = io.ReadFull(dec.inReader, dec.buf[:8])
+			if dec.err != nil {
+				return
+			}
+			(*ns)[i] = T(dec.codec.order.uint64(dec.buf[:8]))
+		}
+		dec.inRead += 8 * itemCount
+	} else {
+		for i := uint32(0); i < itemCount; i++ {
+			if len(dec.inBuffer) < 8 {
+				dec.err = io.ErrUnexpectedEOF
+				return
+			}
+			(*ns)[i] = T(dec.codec.order.uint64(dec.inBuffer))
+			dec.inBuffer = dec.inBuffer[8:]
+		}
+	}
+}
+
+// DecodeSliceOfUint64sContentOnFork is the lazy data reader of DecodeSliceOfUint64sOffsetOnFork.
+func DecodeSliceOfUint64sContentOnFork[T ~uint64](dec *Decoder, ns *[]T, maxItems uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, clear out the output
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		*ns = nil
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	DecodeSliceOfUint64sContent(dec, ns, maxItems)
+}
+
+// DecodeSortedSliceOfUint64sContent is the lazy data reader of
+// DecodeSliceOfUint64sOffset, which also validates that the decoded items are
+// strictly increasing (e.g. attesting validator indices).
+func DecodeSortedSliceOfUint64sContent[T ~uint64](dec *Decoder, ns *[]T, maxItems uint64) {
+	DecodeSliceOfUint64sContent(dec, ns, maxItems)
+	if dec.err != nil {
+		return
+	}
+	for i := 1; i < len(*ns); i++ {
+		if (*ns)[i] <= (*ns)[i-1] {
+			dec.err = fmt.Errorf("%w: item %d is %d, previous %d", ErrUnsortedItems, i, (*ns)[i], (*ns)[i-1])
+			return
+		}
+	}
+}
+
+// DecodeSortedSliceOfUint64sContentOnFork is the lazy data reader of
+// DecodeSliceOfUint64sOffsetOnFork, which also validates that the decoded items
+// are strictly increasing.
+func DecodeSortedSliceOfUint64sContentOnFork[T ~uint64](dec *Decoder, ns *[]T, maxItems uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, clear out the output
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		*ns = nil
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	DecodeSortedSliceOfUint64sContent(dec, ns, maxItems)
+}
+
+// DecodeSliceOfUint64sPointerOffsetOnFork parses a dynamic slice of uint64s
+// behind a pointer if present in a fork. If not, the pointer is set to nil,
+// otherwise it's allocated even if the list turns out empty.
+func DecodeSliceOfUint64sPointerOffsetOnFork[T ~uint64](dec *Decoder, ns **[]T, filter ForkFilter) {
+	// If the field is not active in the current fork, clear out the output
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		*ns = nil
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	if *ns == nil {
+		*ns = new([]T)
+	}
+	DecodeSliceOfUint64sOffset(dec, *ns)
+}
+
+// DecodeSliceOfUint64sPointerContentOnFork is the lazy data reader of
+// DecodeSliceOfUint64sPointerOffsetOnFork.
+func DecodeSliceOfUint64sPointerContentOnFork[T ~uint64](dec *Decoder, ns **[]T, maxItems uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, clear out the output
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		*ns = nil
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	if *ns == nil {
+		*ns = new([]T)
+	}
+	DecodeSliceOfUint64sContent(dec, *ns, maxItems)
+}
+
+// DecodeArrayOfStaticBytes parses a static array of static binary blobs.
+func DecodeArrayOfStaticBytes[T commonBytesArrayLengths[U], U commonBytesLengths](dec *Decoder, blobs *T) {
+	// The code below should have used `(*blobs)[:]`, alas Go's generics compiler
+	// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
+	DecodeUnsafeArrayOfStaticBytes(dec, unsafe.Slice(&(*blobs)[0], len(*blobs)))
+}
+
+// DecodeUnsafeArrayOfStaticBytes parses a static array of static binary blobs.
+func DecodeUnsafeArrayOfStaticBytes[T commonBytesLengths](dec *Decoder, blobs []T) {
+	if dec.err != nil {
+		return
+	}
+	if dec.inReader != nil {
+		for i := 0; i < len(blobs); i++ {
+			// The code below should have used `(*blobs)[i][:]`, alas Go's generics compiler
+			// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
+			_, dec.err = io.ReadFull(dec.inReader, unsafe.Slice(&(blobs)[i][0], len((blobs)[i])))
+			if dec.err != nil {
+				return
+			}
+			dec.inRead += uint32(len((blobs)[i]))
+		}
+	} else {
+		for i := 0; i < len(blobs); i++ {
+			if len(dec.inBuffer) < len((blobs)[i]) {
+				dec.err = io.ErrUnexpectedEOF
+				return
+			}
+			// The code below should have used `blobs[i][:]`, alas Go's generics compiler
+			// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
+			copy(unsafe.Slice(&(blobs)[i][0], len((blobs)[i])), dec.inBuffer)
+			dec.inBuffer = dec.inBuffer[len((blobs)[i]):]
+		}
+	}
+}
+
+// DecodeCheckedArrayOfStaticBytes parses a static array of static binary blobs.
+func DecodeCheckedArrayOfStaticBytes[T commonBytesLengths](dec *Decoder, blobs *[]T, size uint64) {
+	if dec.err != nil {
+		return
+	}
+	// Expand the byte-array slice if needed and fill it with the data
+	if uint64(cap(*blobs)) < size {
+		if !chargeItems[T](dec, size) {
+			return
+		}
+		*blobs = allocArrays[T](dec, int(size))
+	} else {
+		*blobs = (*blobs)[:size]
+	}
+	if dec.inReader != nil {
+		for i := 0; i < len(*blobs); i++ {
+			// The code below should have used `(*blobs)[i][:]`, alas Go's generics compiler
+			// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
+			_, dec.err = io.ReadFull(dec.inReader, unsafe.Slice(&(*blobs)[i][0], len((*blobs)[i])))
 			if dec.err != nil {
 				return
 			}
@@ -868,7 +1580,8 @@ func DecodeSliceOfStaticBytesOffset[T commonBytesLengths](dec *Decoder, blobs *[
 // blobs if present in a fork.
 func DecodeSliceOfStaticBytesOffsetOnFork[T commonBytesLengths](dec *Decoder, blobs *[]T, filter ForkFilter) {
 	// If the field is not active in the current fork, skip parsing the offset
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
 		return
 	}
 	// Otherwise fall back to the standard decoder
@@ -906,7 +1619,10 @@ func DecodeSliceOfStaticBytesContent[T commonBytesLengths](dec *Decoder, blobs *
 	}
 	// Expand the slice if needed and decode the objects
 	if uint32(cap(*blobs)) < itemCount {
-		*blobs = make([]T, itemCount)
+		if !chargeItems[T](dec, uint64(itemCount)) {
+			return
+		}
+		*blobs = allocArrays[T](dec, int(itemCount))
 	} else {
 		*blobs = (*blobs)[:itemCount]
 	}
@@ -941,7 +1657,8 @@ func DecodeSliceOfStaticBytesContent[T commonBytesLengths](dec *Decoder, blobs *
 // DecodeSliceOfStaticBytesContentOnFork is the lazy data reader of DecodeSliceOfStaticBytesOffsetOnFork.
 func DecodeSliceOfStaticBytesContentOnFork[T commonBytesLengths](dec *Decoder, blobs *[]T, maxItems uint64, filter ForkFilter) {
 	// If the field is not active in the current fork, clear out the output
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
 		*blobs = nil
 		return
 	}
@@ -949,6 +1666,117 @@ func DecodeSliceOfStaticBytesContentOnFork[T commonBytesLengths](dec *Decoder, b
 	DecodeSliceOfStaticBytesContent(dec, blobs, maxItems)
 }
 
+// DecodeSliceOfStaticBytesPointerOffsetOnFork parses a dynamic slice of static
+// binary blobs behind a pointer if present in a fork. If not, the pointer is
+// set to nil, otherwise it's allocated even if the list turns out empty.
+func DecodeSliceOfStaticBytesPointerOffsetOnFork[T commonBytesLengths](dec *Decoder, blobs **[]T, filter ForkFilter) {
+	// If the field is not active in the current fork, clear out the output
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		*blobs = nil
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	if *blobs == nil {
+		*blobs = new([]T)
+	}
+	DecodeSliceOfStaticBytesOffset(dec, *blobs)
+}
+
+// DecodeSliceOfStaticBytesPointerContentOnFork is the lazy data reader of
+// DecodeSliceOfStaticBytesPointerOffsetOnFork.
+func DecodeSliceOfStaticBytesPointerContentOnFork[T commonBytesLengths](dec *Decoder, blobs **[]T, maxItems uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, clear out the output
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		*blobs = nil
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	if *blobs == nil {
+		*blobs = new([]T)
+	}
+	DecodeSliceOfStaticBytesContent(dec, *blobs, maxItems)
+}
+
+// DecodeCheckedArrayOfDynamicBytesOffset parses a static array of dynamic binary
+// blobs.
+func DecodeCheckedArrayOfDynamicBytesOffset(dec *Decoder, blobs *[][]byte) {
+	dec.decodeOffset(false)
+}
+
+// DecodeCheckedArrayOfDynamicBytesOffsetOnFork parses a static array of dynamic
+// binary blobs if present in a fork.
+func DecodeCheckedArrayOfDynamicBytesOffsetOnFork(dec *Decoder, blobs *[][]byte, filter ForkFilter) {
+	// If the field is not active in the current fork, skip parsing the offset
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	DecodeCheckedArrayOfDynamicBytesOffset(dec, blobs)
+}
+
+// DecodeCheckedArrayOfDynamicBytesContent is the lazy data reader of DecodeCheckedArrayOfDynamicBytesOffset.
+func DecodeCheckedArrayOfDynamicBytesContent(dec *Decoder, blobs *[][]byte, size uint64, maxSize uint64) {
+	if dec.err != nil {
+		return
+	}
+	// Compute the length of the blob array based on the seen offsets and sanity
+	// check that there's enough data to hold the offset table at least
+	length := dec.retrieveSize()
+	if uint64(length) < 4*size {
+		dec.err = fmt.Errorf("%w: %d bytes available, %d offsets expected", ErrShortCounterOffset, length, size)
+		return
+	}
+	// Descend into a new data slot to track/verify a new sub-length
+	dec.descendIntoSlot(length)
+	defer dec.ascendFromSlot()
+
+	// Since we're decoding a static array of dynamic objects (blobs here), the
+	// first offset must point exactly to the end of the offset table.
+	dec.decodeOffset(true)
+	if dec.err != nil {
+		return
+	}
+	if uint64(dec.offset) != 4*size {
+		dec.err = fmt.Errorf("%w: decoded %d, type expects %d", ErrFirstOffsetMismatch, dec.offset, 4*size)
+		return
+	}
+	dec.decodeOffsetTable(uint32(size))
+	if dec.err != nil {
+		return
+	}
+	// Expand the blob slice if needed
+	if uint64(cap(*blobs)) < size {
+		if !chargeItems[[]byte](dec, size) {
+			return
+		}
+		*blobs = make([][]byte, size)
+	} else {
+		*blobs = (*blobs)[:size]
+	}
+	for i := uint64(0); i < size; i++ {
+		DecodeDynamicBytesContent(dec, &(*blobs)[i], maxSize)
+		if dec.err != nil {
+			dec.annotateError("[" + strconv.Itoa(int(i)) + "]")
+			return
+		}
+	}
+}
+
+// DecodeCheckedArrayOfDynamicBytesContentOnFork is the lazy data reader of DecodeCheckedArrayOfDynamicBytesOffsetOnFork.
+func DecodeCheckedArrayOfDynamicBytesContentOnFork(dec *Decoder, blobs *[][]byte, size uint64, maxSize uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, clear out the output
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		*blobs = nil
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	DecodeCheckedArrayOfDynamicBytesContent(dec, blobs, size, maxSize)
+}
+
 // DecodeSliceOfDynamicBytesOffset parses a dynamic slice of dynamic binary blobs.
 func DecodeSliceOfDynamicBytesOffset(dec *Decoder, blobs *[][]byte) {
 	dec.decodeOffset(false)
@@ -958,7 +1786,8 @@ func DecodeSliceOfDynamicBytesOffset(dec *Decoder, blobs *[][]byte) {
 // blobs if present in a fork.
 func DecodeSliceOfDynamicBytesOffsetOnFork(dec *Decoder, blobs *[][]byte, filter ForkFilter) {
 	// If the field is not active in the current fork, skip parsing the offset
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
 		return
 	}
 	// Otherwise fall back to the standard decoder
@@ -1010,24 +1839,92 @@ func DecodeSliceOfDynamicBytesContent(dec *Decoder, blobs *[][]byte, maxItems ui
 		dec.err = fmt.Errorf("%w: decoded %d, max %d", ErrMaxItemsExceeded, items, maxItems)
 		return
 	}
-	// Expand the blob slice if needed
-	if uint32(cap(*blobs)) < items {
-		*blobs = make([][]byte, items)
+	dec.decodeOffsetTable(items)
+	if dec.err != nil {
+		return
+	}
+	// The blobs are laid out back to back in a single flat arena, which is kept in
+	// the spare slot past the end of the blob slice for later decodes to reuse,
+	// irrespective of how the individual blob sizes shifted.
+	arena := stashedArena(*blobs)
+
+	// Expand the blob slice if needed, keeping a spare slot for the arena
+	if uint32(cap(*blobs)) <= items {
+		capacity := dec.preallocSize(uint64(items), maxItems) + 1
+		if !chargeItems[[]byte](dec, capacity) {
+			return
+		}
+		*blobs = make([][]byte, items, capacity)
 	} else {
 		*blobs = (*blobs)[:items]
 	}
-	for i := uint32(1); i < items; i++ {
-		DecodeDynamicBytesOffset(dec, &(*blobs)[i])
+	// Expand the arena if needed to fit all the blobs (everything after the
+	// offsets) and fill it with the data
+	if total := size - items*4; uint32(len(arena)) < total {
+		if !dec.charge(uint64(total)) {
+			return
+		}
+		arena = dec.allocBytes(int(total))
 	}
+	full := (*blobs)[:cap(*blobs)]
+	full[len(full)-1] = arena
+
+	var pos uint32
 	for i := uint32(0); i < items; i++ {
-		DecodeDynamicBytesContent(dec, &(*blobs)[i], maxSize)
+		size := dec.retrieveSize()
+		if uint64(size) > maxSize {
+			dec.err = fmt.Errorf("%w: decoded %d, max %d", ErrMaxLengthExceeded, size, maxSize)
+			dec.annotateError("[" + strconv.Itoa(int(i)) + "]")
+			return
+		}
+		// Cap all blobs to their sizes to avoid appends overwriting the next one
+		blob := arena[pos : pos+size : pos+size]
+		pos += size
+
+		// Inline:
+		//
+		// DecodeStaticBytes(dec, blob)
+		if dec.inReader != nil {
+			_, dec.err = io.ReadFull(dec.inReader, blob)
+			dec.inRead += size
+		} else {
+			if uint32(len(dec.inBuffer)) < size {
+				dec.err = io.ErrUnexpectedEOF
+			} else {
+				copy(blob, dec.inBuffer)
+				dec.inBuffer = dec.inBuffer[size:]
+			}
+		}
+		if dec.err != nil {
+			dec.annotateError("[" + strconv.Itoa(int(i)) + "]")
+			return
+		}
+		(*blobs)[i] = blob
+	}
+}
+
+// stashedArena recovers the arena a slice of blobs was previously decoded into
+// from the spare slot past its end. The arena is only trusted if the first blob
+// still starts at its beginning, otherwise the slot was overwritten by the user
+// (e.g. by appending to the slice) and the memory is not the decoder's to reuse.
+func stashedArena(blobs [][]byte) []byte {
+	if cap(blobs) < 2 {
+		return nil
+	}
+	full := blobs[:cap(blobs)]
+
+	arena := full[len(full)-1]
+	if cap(arena) == 0 || unsafe.SliceData(full[0]) != unsafe.SliceData(arena) {
+		return nil
 	}
+	return arena[:cap(arena)]
 }
 
 // DecodeSliceOfDynamicBytesContentOnFork is the lazy data reader of DecodeSliceOfDynamicBytesOffsetOnFork.
 func DecodeSliceOfDynamicBytesContentOnFork(dec *Decoder, blobs *[][]byte, maxItems uint64, maxSize uint64, filter ForkFilter) {
 	// If the field is not active in the current fork, clear out the output
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
 		*blobs = nil
 		return
 	}
@@ -1044,27 +1941,213 @@ func DecodeSliceOfStaticObjectsOffset[T newableStaticObject[U], U any](dec *Deco
 // objects if present in a fork.
 func DecodeSliceOfStaticObjectsOffsetOnFork[T newableStaticObject[U], U any](dec *Decoder, objects *[]T, filter ForkFilter) {
 	// If the field is not active in the current fork, skip parsing the offset
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	DecodeSliceOfStaticObjectsOffset(dec, objects)
+}
+
+// DecodeSliceOfStaticObjectsContent is the lazy data reader of DecodeSliceOfStaticObjectsOffset.
+func DecodeSliceOfStaticObjectsContent[T newableStaticObject[U], U any](dec *Decoder, objects *[]T, maxItems uint64) {
+	if dec.err != nil {
+		return
+	}
+	// Compute the length of the encoded objects based on the seen offsets
+	size := dec.retrieveSize()
+	if size == 0 {
+		// Empty slice, remove anything extra
+		if *objects == nil {
+			*objects = make([]T, 0) // Don't leave nil, init to empty
+		} else {
+			*objects = (*objects)[:0]
+		}
+		return
+	}
+	// Compute the number of items based on the item size of the type
+	var sizer T // SizeSSZ is on *U, objects is static, so nil T is fine
+
+	itemSize := sizer.SizeSSZ(dec.sizer)
+	if size%itemSize != 0 {
+		dec.err = fmt.Errorf("%w: length %d, item size %d", ErrDynamicStaticsIndivisible, size, itemSize)
+		return
+	}
+	itemCount := size / itemSize
+	if uint64(itemCount) > maxItems {
+		dec.err = fmt.Errorf("%w: decoded %d, max %d", ErrMaxItemsExceeded, itemCount, maxItems)
+		return
+	}
+	// Expand the slice if needed and decode the objects
+	if uint32(cap(*objects)) < itemCount {
+		if !chargeItems[T](dec, uint64(itemCount)) {
+			return
+		}
+		*objects = make([]T, itemCount)
+	} else {
+		*objects = (*objects)[:itemCount]
+	}
+	// Descend into a new data slot to track/verify a new sub-length
+	dec.descendIntoSlot(size)
+	defer dec.ascendFromSlot()
+
+	for i := uint32(0); i < itemCount; i++ {
+		if (*objects)[i] == nil {
+			if !chargeItems[U](dec, 1) {
+				return
+			}
+			(*objects)[i] = new(U)
+		}
+		dec.decodeObject((*objects)[i])
+		if dec.err != nil {
+			dec.annotateError("[" + strconv.Itoa(int(i)) + "]")
+			return
+		}
+	}
+}
+
+// DecodeSliceOfStaticObjectsContentOnFork is the lazy data reader of DecodeSliceOfStaticObjectsOffsetOnFork.
+func DecodeSliceOfStaticObjectsContentOnFork[T newableStaticObject[U], U any](dec *Decoder, objects *[]T, maxItems uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, clear out the output
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		*objects = nil
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	DecodeSliceOfStaticObjectsContent(dec, objects, maxItems)
+}
+
+// DecodeSliceOfStaticObjectValuesOffset parses a dynamic slice of static ssz
+// objects stored by value.
+func DecodeSliceOfStaticObjectValuesOffset[T newableStaticObject[U], U any](dec *Decoder, objects *[]U) {
+	dec.decodeOffset(false)
+}
+
+// DecodeSliceOfStaticObjectValuesOffsetOnFork parses a dynamic slice of static ssz
+// objects stored by value if present in a fork.
+func DecodeSliceOfStaticObjectValuesOffsetOnFork[T newableStaticObject[U], U any](dec *Decoder, objects *[]U, filter ForkFilter) {
+	// If the field is not active in the current fork, skip parsing the offset
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	DecodeSliceOfStaticObjectValuesOffset[T](dec, objects)
+}
+
+// DecodeSliceOfStaticObjectValuesContent is the lazy data reader of DecodeSliceOfStaticObjectValuesOffset.
+func DecodeSliceOfStaticObjectValuesContent[T newableStaticObject[U], U any](dec *Decoder, objects *[]U, maxItems uint64) {
+	if dec.err != nil {
+		return
+	}
+	// Compute the length of the encoded objects based on the seen offsets
+	size := dec.retrieveSize()
+	if size == 0 {
+		// Empty slice, remove anything extra
+		if *objects == nil {
+			*objects = make([]U, 0) // Don't leave nil, init to empty
+		} else {
+			*objects = (*objects)[:0]
+		}
+		return
+	}
+	// Compute the number of items based on the item size of the type
+	var sizer T // SizeSSZ is on *U, objects is static, so nil T is fine
+
+	itemSize := sizer.SizeSSZ(dec.sizer)
+	if size%itemSize != 0 {
+		dec.err = fmt.Errorf("%w: length %d, item size %d", ErrDynamicStaticsIndivisible, size, itemSize)
+		return
+	}
+	itemCount := size / itemSize
+	if uint64(itemCount) > maxItems {
+		dec.err = fmt.Errorf("%w: decoded %d, max %d", ErrMaxItemsExceeded, itemCount, maxItems)
+		return
+	}
+	// Expand the slice if needed and decode the objects in place. The items are
+	// allocated in one go, with no per item indirection.
+	if uint32(cap(*objects)) < itemCount {
+		if !chargeItems[U](dec, uint64(itemCount)) {
+			return
+		}
+		*objects = make([]U, itemCount)
+	} else {
+		*objects = (*objects)[:itemCount]
+	}
+	// Descend into a new data slot to track/verify a new sub-length
+	dec.descendIntoSlot(size)
+	defer dec.ascendFromSlot()
+
+	for i := uint32(0); i < itemCount; i++ {
+		dec.decodeObject(T(&(*objects)[i]))
+		if dec.err != nil {
+			dec.annotateError("[" + strconv.Itoa(int(i)) + "]")
+			return
+		}
+	}
+}
+
+// DecodeSliceOfStaticObjectValuesContentOnFork is the lazy data reader of DecodeSliceOfStaticObjectValuesOffsetOnFork.
+func DecodeSliceOfStaticObjectValuesContentOnFork[T newableStaticObject[U], U any](dec *Decoder, objects *[]U, maxItems uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, clear out the output
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		*objects = nil
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	DecodeSliceOfStaticObjectValuesContent[T](dec, objects, maxItems)
+}
+
+// DecodeSliceOfStaticObjectsPointerOffsetOnFork parses a dynamic slice of
+// static ssz objects behind a pointer if present in a fork. If not, the pointer
+// is set to nil, otherwise it's allocated even if the list turns out empty.
+func DecodeSliceOfStaticObjectsPointerOffsetOnFork[T newableStaticObject[U], U any](dec *Decoder, objects **[]T, filter ForkFilter) {
+	// If the field is not active in the current fork, clear out the output
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		*objects = nil
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	if *objects == nil {
+		*objects = new([]T)
+	}
+	DecodeSliceOfStaticObjectsOffset(dec, *objects)
+}
+
+// DecodeSliceOfStaticObjectsPointerContentOnFork is the lazy data reader of
+// DecodeSliceOfStaticObjectsPointerOffsetOnFork.
+func DecodeSliceOfStaticObjectsPointerContentOnFork[T newableStaticObject[U], U any](dec *Decoder, objects **[]T, maxItems uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, clear out the output
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		*objects = nil
 		return
 	}
 	// Otherwise fall back to the standard decoder
-	DecodeSliceOfStaticObjectsOffset(dec, objects)
+	if *objects == nil {
+		*objects = new([]T)
+	}
+	DecodeSliceOfStaticObjectsContent(dec, *objects, maxItems)
 }
 
-// DecodeSliceOfStaticObjectsContent is the lazy data reader of DecodeSliceOfStaticObjectsOffset.
-func DecodeSliceOfStaticObjectsContent[T newableStaticObject[U], U any](dec *Decoder, objects *[]T, maxItems uint64) {
+// DecodeStreamSliceOfStaticObjects is a streaming variant of the lazy data reader
+// DecodeSliceOfStaticObjectsContent. Instead of materializing the entire slice,
+// it calls the callback for every item as soon as it is parsed, allowing lists
+// with millions of items (e.g. validator registries) to be processed in constant
+// memory. The offset should be parsed via DecodeSliceOfStaticObjectsOffset.
+//
+// The same object is reused for all the items, so the callback must copy it if
+// it needs to be retained. Any error returned by the callback aborts decoding.
+func DecodeStreamSliceOfStaticObjects[T newableStaticObject[U], U any](dec *Decoder, maxItems uint64, fn func(i int, obj T) error) {
 	if dec.err != nil {
 		return
 	}
 	// Compute the length of the encoded objects based on the seen offsets
 	size := dec.retrieveSize()
 	if size == 0 {
-		// Empty slice, remove anything extra
-		if *objects == nil {
-			*objects = make([]T, 0) // Don't leave nil, init to empty
-		} else {
-			*objects = (*objects)[:0]
-		}
 		return
 	}
 	// Compute the number of items based on the item size of the type
@@ -1080,38 +2163,26 @@ func DecodeSliceOfStaticObjectsContent[T newableStaticObject[U], U any](dec *Dec
 		dec.err = fmt.Errorf("%w: decoded %d, max %d", ErrMaxItemsExceeded, itemCount, maxItems)
 		return
 	}
-	// Expand the slice if needed and decode the objects
-	if uint32(cap(*objects)) < itemCount {
-		*objects = make([]T, itemCount)
-	} else {
-		*objects = (*objects)[:itemCount]
-	}
 	// Descend into a new data slot to track/verify a new sub-length
 	dec.descendIntoSlot(size)
 	defer dec.ascendFromSlot()
 
+	if !chargeItems[U](dec, 1) {
+		return
+	}
+	obj := T(new(U))
 	for i := uint32(0); i < itemCount; i++ {
-		if (*objects)[i] == nil {
-			(*objects)[i] = new(U)
+		dec.decodeObject(obj)
+		if dec.err == nil {
+			dec.err = fn(int(i), obj)
 		}
-		(*objects)[i].DefineSSZ(dec.codec)
 		if dec.err != nil {
+			dec.annotateError("[" + strconv.Itoa(int(i)) + "]")
 			return
 		}
 	}
 }
 
-// DecodeSliceOfStaticObjectsContentOnFork is the lazy data reader of DecodeSliceOfStaticObjectsOffsetOnFork.
-func DecodeSliceOfStaticObjectsContentOnFork[T newableStaticObject[U], U any](dec *Decoder, objects *[]T, maxItems uint64, filter ForkFilter) {
-	// If the field is not active in the current fork, clear out the output
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
-		*objects = nil
-		return
-	}
-	// Otherwise fall back to the standard decoder
-	DecodeSliceOfStaticObjectsContent(dec, objects, maxItems)
-}
-
 // DecodeSliceOfDynamicObjectsOffset parses a dynamic slice of dynamic ssz objects.
 func DecodeSliceOfDynamicObjectsOffset[T newableDynamicObject[U], U any](dec *Decoder, objects *[]T) {
 	dec.decodeOffset(false)
@@ -1121,7 +2192,8 @@ func DecodeSliceOfDynamicObjectsOffset[T newableDynamicObject[U], U any](dec *De
 // objects if present in a fork.
 func DecodeSliceOfDynamicObjectsOffsetOnFork[T newableDynamicObject[U], U any](dec *Decoder, objects *[]T, filter ForkFilter) {
 	// If the field is not active in the current fork, skip parsing the offset
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
 		return
 	}
 	// Otherwise fall back to the standard decoder
@@ -1174,24 +2246,33 @@ func DecodeSliceOfDynamicObjectsContent[T newableDynamicObject[U], U any](dec *D
 		dec.err = fmt.Errorf("%w: decoded %d, max %d", ErrMaxItemsExceeded, items, maxItems)
 		return
 	}
+	dec.decodeOffsetTable(items)
+	if dec.err != nil {
+		return
+	}
 	// Expand the blob slice if needed
 	if uint32(cap(*objects)) < items {
+		if !chargeItems[T](dec, uint64(items)) {
+			return
+		}
 		*objects = make([]T, items)
 	} else {
 		*objects = (*objects)[:items]
 	}
-	for i := uint32(1); i < items; i++ {
-		DecodeDynamicObjectOffset(dec, &(*objects)[i])
-	}
 	for i := uint32(0); i < items; i++ {
 		DecodeDynamicObjectContent(dec, &(*objects)[i])
+		if dec.err != nil {
+			dec.annotateError("[" + strconv.Itoa(int(i)) + "]")
+			return
+		}
 	}
 }
 
 // DecodeSliceOfDynamicObjectsContentOnFork is the lazy data reader of DecodeSliceOfDynamicObjectsOffsetOnFork.
 func DecodeSliceOfDynamicObjectsContentOnFork[T newableDynamicObject[U], U any](dec *Decoder, objects *[]T, maxItems uint64, filter ForkFilter) {
 	// If the field is not active in the current fork, clear out the output
-	if dec.codec.fork < filter.Added || (filter.Removed > ForkUnknown && dec.codec.fork >= filter.Removed) {
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
 		*objects = nil
 		return
 	}
@@ -1199,6 +2280,389 @@ func DecodeSliceOfDynamicObjectsContentOnFork[T newableDynamicObject[U], U any](
 	DecodeSliceOfDynamicObjectsContent(dec, objects, maxItems)
 }
 
+// DecodeSliceOfDynamicObjectsPointerOffsetOnFork parses a dynamic slice of
+// dynamic ssz objects behind a pointer if present in a fork. If not, the
+// pointer is set to nil, otherwise it's allocated even if the list turns out
+// empty.
+func DecodeSliceOfDynamicObjectsPointerOffsetOnFork[T newableDynamicObject[U], U any](dec *Decoder, objects **[]T, filter ForkFilter) {
+	// If the field is not active in the current fork, clear out the output
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		*objects = nil
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	if *objects == nil {
+		*objects = new([]T)
+	}
+	DecodeSliceOfDynamicObjectsOffset(dec, *objects)
+}
+
+// DecodeSliceOfDynamicObjectsPointerContentOnFork is the lazy data reader of
+// DecodeSliceOfDynamicObjectsPointerOffsetOnFork.
+func DecodeSliceOfDynamicObjectsPointerContentOnFork[T newableDynamicObject[U], U any](dec *Decoder, objects **[]T, maxItems uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, clear out the output
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		*objects = nil
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	if *objects == nil {
+		*objects = new([]T)
+	}
+	DecodeSliceOfDynamicObjectsContent(dec, *objects, maxItems)
+}
+
+// checkBitvector verifies that the high (unused) bits of a decoded bitvector are
+// all zero.
+func (dec *Decoder) checkBitvector(bitvector []byte, size uint64) {
+	// TODO(karalabe): This can probably be done more optimally...
+	for i := size; i < uint64(len(bitvector)<<3); i++ {
+		if bitvector[i>>3]&(1<<(i&0x7)) > 0 {
+			dec.err = fmt.Errorf("%w: bit %d set, size %d bits", ErrJunkInBitvector, i+1, size)
+			return
+		}
+	}
+}
+
+// charge accounts for n freshly allocated bytes against the decoding budget, if
+// one was configured, failing the decoding if it is exceeded.
+func (dec *Decoder) charge(n uint64) bool {
+	if dec.budget == 0 {
+		return true
+	}
+	if dec.spent += n; dec.spent > dec.budget {
+		dec.err = fmt.Errorf("%w: allocated %d, budget %d", ErrDecodeBudgetExceeded, dec.spent, dec.budget)
+		return false
+	}
+	return true
+}
+
+// chargeItems accounts for n freshly allocated items of type T against the
+// decoding budget, failing the decoding if it is exceeded.
+func chargeItems[T any](dec *Decoder, n uint64) bool {
+	var item T
+	return dec.charge(n * uint64(unsafe.Sizeof(item)))
+}
+
+// preallocSize returns the capacity to allocate for a dynamic field needing to
+// hold size items, adding the configured headroom (capped at the field's limit)
+// so that decoding larger values into the same object later avoids regrowing.
+func (dec *Decoder) preallocSize(size uint64, limit uint64) uint64 {
+	if dec.prealloc <= 1 {
+		return size
+	}
+	return max(size, min(uint64(float64(size)*dec.prealloc), limit))
+}
+
+// allocBytes allocates a byte slice of the requested length via the custom
+// allocator if one was configured, or via make otherwise.
+func (dec *Decoder) allocBytes(n int) []byte {
+	if dec.alloc == nil || n == 0 {
+		return make([]byte, n)
+	}
+	return dec.alloc(n)[:n]
+}
+
+// allocArrays allocates a slice of byte (or bit) arrays of the requested length
+// via the custom allocator if one was configured, or via make otherwise. Byte
+// arrays contain no pointers and have no alignment requirements, so the
+// allocation can be safely reinterpreted.
+func allocArrays[T commonBytesLengths | commonBitsLengths](dec *Decoder, n int) []T {
+	if dec.alloc == nil || n == 0 {
+		return make([]T, n)
+	}
+	var item T
+	blob := dec.allocBytes(n * len(item))
+	return unsafe.Slice((*T)(unsafe.Pointer(&blob[0])), n)
+}
+
+// setReader sets the input stream to decode a message of the given size from,
+// wrapping it into a read-ahead buffer if enabled. A nil reader releases all the
+// references to the previous stream.
+func (dec *Decoder) setReader(r io.Reader, size uint32) {
+	if r == nil {
+		dec.inReader, dec.inLimit.R = nil, nil
+		if dec.inAhead != nil {
+			dec.inAhead.Reset(nil)
+		}
+		return
+	}
+	// Random access streams do their own read-ahead, wrapping them would prevent
+	// seeking past the skipped fields
+	if stream, ok := r.(*readerAtStream); ok {
+		dec.inReader = stream
+		return
+	}
+	ahead := min(size, StreamReadAhead)
+	if ahead == 0 {
+		dec.inReader = r
+		return
+	}
+	// Limit the stream to the message so the read-ahead can't overconsume, and
+	// reuse any previous buffer if it's large enough
+	dec.inLimit.R, dec.inLimit.N = r, int64(size)
+	if dec.inAhead == nil || dec.inAhead.Size() < int(ahead) {
+		dec.inAhead = bufio.NewReaderSize(&dec.inLimit, int(ahead))
+	} else {
+		dec.inAhead.Reset(&dec.inLimit)
+	}
+	dec.inReader = dec.inAhead
+}
+
+// nextField marks the start of decoding a new field within the current object.
+// The counter is frozen after a failure so the erroring field can be reported.
+//
+// The return value is whether the field needs to be decoded, or whether it was
+// skipped over by a field mask.
+func (dec *Decoder) nextField() bool {
+	if dec.err != nil {
+		return true
+	}
+	dec.field++
+	dec.stats.field()
+	if dec.trace != nil {
+		dec.trace.field(dec)
+	}
+	if dec.mask != nil && dec.object == dec.mask.obj {
+		return dec.maskField()
+	}
+	return true
+}
+
+// maskField seeks past the data of the current field of the masked object if it
+// is not selected by the mask, returning whether the field needs to be decoded.
+func (dec *Decoder) maskField() bool {
+	if dec.field > len(dec.mask.skips) {
+		return true
+	}
+	var size uint32
+	switch skip := dec.mask.skips[dec.field-1]; skip {
+	case maskDecode:
+		return true
+	case maskContent:
+		size = dec.retrieveSize()
+	default:
+		size = uint32(skip)
+	}
+	if dec.inReader != nil {
+		dec.err = dec.skipReader(size)
+		dec.inRead += size
+		return false
+	}
+	if uint32(len(dec.inBuffer)) < size {
+		dec.err = io.ErrUnexpectedEOF
+		return false
+	}
+	dec.inBuffer = dec.inBuffer[size:]
+	return false
+}
+
+// skipReader seeks past the given number of bytes in the input stream, without
+// reading them if the stream supports random access.
+func (dec *Decoder) skipReader(size uint32) error {
+	if stream, ok := dec.inReader.(*readerAtStream); ok {
+		return stream.skip(int64(size))
+	}
+	n, err := io.CopyN(io.Discard, dec.inReader, int64(size))
+	if n < int64(size) && err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// decodeObject runs the field definitions of an ssz object, annotating any error
+// with the name of the field that failed (or its index if the names are unknown).
+func (dec *Decoder) decodeObject(obj Object) {
+	field, object := dec.field, dec.object
+	dec.field, dec.object = 0, obj
+
+	if dec.trace != nil {
+		dec.trace.enter(dec, obj)
+	}
+	var dynamic bool
+	if statsEnabled {
+		_, dynamic = obj.(DynamicObject)
+	}
+	if dynamic {
+		dec.stats.enter()
+	}
+	obj.DefineSSZ(dec.codec)
+	if dynamic {
+		dec.stats.leave()
+	}
+	if dec.trace != nil {
+		dec.trace.leave(dec)
+	}
+	if dec.err != nil && dec.field > 0 {
+		dec.annotateError(fieldName(obj, dec.field-1))
+	}
+	dec.field, dec.object = field, object
+}
+
+// fieldName retrieves the name of a field of an object for error reporting,
+// falling back to its definition index if the names are unknown.
+func fieldName(obj Object, field int) string {
+	if named, ok := obj.(NamedObject); ok {
+		if names := named.NamesSSZ(); field < len(names) {
+			return names[field]
+		}
+	}
+	return "#" + strconv.Itoa(field)
+}
+
+// skipField records a field skipped due to its fork filter being inactive, so
+// that leftover data in the object's slot can be attributed to it.
+func (dec *Decoder) skipField(filter ForkFilter) {
+	if dec.skip.obj == nil && dec.object != nil {
+		dec.skip = skippedField{obj: dec.object, field: dec.field - 1, filter: filter}
+	}
+}
+
+// slotMismatch creates the error for an object's slot not being consumed fully,
+// attributing it to a field skipped by a fork filter if there were any.
+func (dec *Decoder) slotMismatch(read uint32) error {
+	if dec.skip.obj != nil && read < dec.length {
+		return fmt.Errorf("%w: %w: %T.%s (%s) skipped in fork %s, data size %d, object consumed %d",
+			ErrObjectSlotSizeMismatch, ErrFieldNotInFork, dec.skip.obj, fieldName(dec.skip.obj, dec.skip.field), dec.skip.filter, forkName(dec.codec.fork), dec.length, read)
+	}
+	return fmt.Errorf("%w: data size %d, object consumed %d", ErrObjectSlotSizeMismatch, dec.length, read)
+}
+
+// decodeNested parses a standalone object from a data slot of the given size,
+// suspending the dynamic offset tracking of the outer object for the duration.
+func (dec *Decoder) decodeNested(obj Object, size uint32) {
+	if dec.err != nil {
+		return
+	}
+	// The nested object has its own dynamic area, stash away any offsets of the
+	// outer object that are still waiting to be consumed
+	offset, offsets := dec.offset, dec.offsets
+	dec.offsets = nil
+
+	dec.descendIntoSlot(size)
+	if dyn, ok := obj.(DynamicObject); ok {
+		dec.startDynamics(dyn.SizeSSZ(dec.sizer, true))
+		dec.decodeObject(obj)
+		dec.flushDynamics()
+	} else {
+		dec.decodeObject(obj)
+	}
+	dec.ascendFromSlot()
+
+	dec.offset, dec.offsets = offset, offsets
+}
+
+// annotateError prepends a path segment (field name or slice index) to the path
+// of the current decoding error, wrapping it into a DecodeError if needed.
+func (dec *Decoder) annotateError(segment string) {
+	if err, ok := dec.err.(*DecodeError); ok {
+		if strings.HasPrefix(err.Path, "[") {
+			err.Path = segment + err.Path
+		} else {
+			err.Path = segment + "." + err.Path
+		}
+		return
+	}
+	dec.err = &DecodeError{Path: segment, Err: dec.err}
+}
+
+// objectName returns the name of an ssz object's type for error annotations.
+func objectName(obj Object) string {
+	typ := reflect.TypeOf(obj)
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ.Name()
+}
+
+// DecodeMapOfStaticEntriesOffset parses a map encoded as a dynamic slice of static
+// ssz key/value containers.
+func DecodeMapOfStaticEntriesOffset[T newableStaticMapEntry[K, V, U], U any, K comparable, V any](dec *Decoder, m *map[K]V) {
+	dec.decodeOffset(false)
+}
+
+// DecodeMapOfStaticEntriesOffsetOnFork parses a map encoded as a dynamic slice of
+// static ssz key/value containers if present in a fork.
+func DecodeMapOfStaticEntriesOffsetOnFork[T newableStaticMapEntry[K, V, U], U any, K comparable, V any](dec *Decoder, m *map[K]V, filter ForkFilter) {
+	// If the field is not active in the current fork, skip parsing the offset
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	DecodeMapOfStaticEntriesOffset[T](dec, m)
+}
+
+// DecodeMapOfStaticEntriesContent is the lazy data reader of DecodeMapOfStaticEntriesOffset.
+func DecodeMapOfStaticEntriesContent[T newableStaticMapEntry[K, V, U], U any, K comparable, V any](dec *Decoder, m *map[K]V, maxItems uint64) {
+	if dec.err != nil {
+		return
+	}
+	var entries []T
+	DecodeSliceOfStaticObjectsContent(dec, &entries, maxItems)
+	if dec.err != nil {
+		return
+	}
+	*m, dec.err = entriesToMap(entries)
+}
+
+// DecodeMapOfStaticEntriesContentOnFork is the lazy data reader of DecodeMapOfStaticEntriesOffsetOnFork.
+func DecodeMapOfStaticEntriesContentOnFork[T newableStaticMapEntry[K, V, U], U any, K comparable, V any](dec *Decoder, m *map[K]V, maxItems uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, clear out the output
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		*m = nil
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	DecodeMapOfStaticEntriesContent[T](dec, m, maxItems)
+}
+
+// DecodeMapOfDynamicEntriesOffset parses a map encoded as a dynamic slice of dynamic
+// ssz key/value containers.
+func DecodeMapOfDynamicEntriesOffset[T newableDynamicMapEntry[K, V, U], U any, K comparable, V any](dec *Decoder, m *map[K]V) {
+	dec.decodeOffset(false)
+}
+
+// DecodeMapOfDynamicEntriesOffsetOnFork parses a map encoded as a dynamic slice of
+// dynamic ssz key/value containers if present in a fork.
+func DecodeMapOfDynamicEntriesOffsetOnFork[T newableDynamicMapEntry[K, V, U], U any, K comparable, V any](dec *Decoder, m *map[K]V, filter ForkFilter) {
+	// If the field is not active in the current fork, skip parsing the offset
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	DecodeMapOfDynamicEntriesOffset[T](dec, m)
+}
+
+// DecodeMapOfDynamicEntriesContent is the lazy data reader of DecodeMapOfDynamicEntriesOffset.
+func DecodeMapOfDynamicEntriesContent[T newableDynamicMapEntry[K, V, U], U any, K comparable, V any](dec *Decoder, m *map[K]V, maxItems uint64) {
+	if dec.err != nil {
+		return
+	}
+	var entries []T
+	DecodeSliceOfDynamicObjectsContent(dec, &entries, maxItems)
+	if dec.err != nil {
+		return
+	}
+	*m, dec.err = entriesToMap(entries)
+}
+
+// DecodeMapOfDynamicEntriesContentOnFork is the lazy data reader of DecodeMapOfDynamicEntriesOffsetOnFork.
+func DecodeMapOfDynamicEntriesContentOnFork[T newableDynamicMapEntry[K, V, U], U any, K comparable, V any](dec *Decoder, m *map[K]V, maxItems uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, clear out the output
+	if !filter.Active(dec.codec.fork) {
+		dec.skipField(filter)
+		*m = nil
+		return
+	}
+	// Otherwise fall back to the standard decoder
+	DecodeMapOfDynamicEntriesContent[T](dec, m, maxItems)
+}
+
 // decodeOffset decodes the next uint32 as an offset and validates it.
 func (dec *Decoder) decodeOffset(list bool) {
 	if dec.err != nil {
@@ -1223,32 +2687,105 @@ func (dec *Decoder) decodeOffset(list bool) {
 		dec.err = fmt.Errorf("%w: decoded %d, message length %d", ErrOffsetBeyondCapacity, offset, dec.length)
 		return
 	}
-	if dec.offsets == nil && !list && dec.offset != offset {
-		dec.err = fmt.Errorf("%w: decoded %d, type expects %d", ErrFirstOffsetMismatch, offset, dec.offset)
-		return
+	// The first offset needs to point exactly after the static data, otherwise
+	// there would be a gap in the dynamic region. Note, the offsets slice gets
+	// reused across objects, so check its length, not whether it's allocated.
+	if len(dec.offsets) == 0 && !list && dec.offset != offset {
+		// If the top level object has a larger fixed region than known (produced
+		// by a newer fork), the unknown fields can be skipped if requested
+		if !dec.skipUnknown || len(dec.lengths) != 1 || offset < dec.offset {
+			dec.err = fmt.Errorf("%w: decoded %d, type expects %d", ErrFirstOffsetMismatch, offset, dec.offset)
+			return
+		}
+		dec.unknownAt, dec.unknownGap = dec.offset, offset-dec.offset
 	}
-	if dec.offsets != nil && dec.offset > offset {
+	if len(dec.offsets) > 0 && dec.offset > offset {
 		dec.err = fmt.Errorf("%w: decoded %d, previous was %d", ErrBadOffsetProgression, offset, dec.offset)
 		return
 	}
 	dec.offset = offset
 	dec.offsets = append(dec.offsets, offset)
+	dec.stats.offsets(1)
+
+	if dec.trace != nil && !list {
+		dec.trace.offset(offset)
+	}
+}
+
+// decodeOffsetTable parses the offsets of the items of a list of dynamic items,
+// after the first one (doubling as the item counter) was already consumed.
+//
+// In buffered mode, the entire table is pre-scanned and validated in one go,
+// before any item (or the list itself) is allocated, computing the item sizes
+// directly instead of queuing up the offsets for retrieveSize to convert.
+func (dec *Decoder) decodeOffsetTable(items uint32) {
+	if dec.err != nil || items == 0 {
+		return
+	}
+	if dec.inReader != nil {
+		for i := uint32(1); i < items; i++ {
+			dec.decodeOffset(false)
+		}
+		return
+	}
+	table := 4 * (items - 1)
+	if uint32(len(dec.inBuffer)) < table {
+		dec.err = io.ErrUnexpectedEOF
+		return
+	}
+	// Compute the sizes in reverse order, same as retrieveSize would
+	dec.growSizes(int(items))
+
+	prev := dec.offset
+	for i := uint32(1); i < items; i++ {
+		offset := binary.LittleEndian.Uint32(dec.inBuffer[4*(i-1):])
+		if offset > dec.length {
+			dec.err = fmt.Errorf("%w: decoded %d, message length %d", ErrOffsetBeyondCapacity, offset, dec.length)
+			return
+		}
+		if offset < prev {
+			dec.err = fmt.Errorf("%w: decoded %d, previous was %d", ErrBadOffsetProgression, offset, prev)
+			return
+		}
+		dec.sizes[items-i] = offset - prev
+		prev = offset
+	}
+	dec.sizes[0] = dec.length - prev
+	dec.inBuffer = dec.inBuffer[table:]
+	dec.stats.offsets(int(items - 1))
+
+	// Drop the queued up counter offset, the sizes are already available
+	dec.offset = prev
+	dec.offsets = dec.offsets[:0]
+}
+
+// growSizes resizes the computed sizes slice to the requested number of items,
+// reusing its previous allocation if large enough.
+func (dec *Decoder) growSizes(items int) {
+	if cap(dec.sizes) < items {
+		dec.sizes = dec.sizes[:cap(dec.sizes)]
+		dec.sizes = append(dec.sizes, make([]uint32, items-len(dec.sizes))...)
+	} else {
+		dec.sizes = dec.sizes[:items]
+	}
 }
 
 // retrieveSize retrieves the length of the next dynamic item based on the seen
 // and cached offsets.
 func (dec *Decoder) retrieveSize() uint32 {
+	// If there are unknown fixed fields in the top level object, they sit right
+	// before the dynamic data, skip them when reaching the first dynamic item
+	if dec.unknownGap > 0 {
+		dec.skipUnknownFields(dec.unknownAt, dec.unknownGap)
+		dec.unknownAt, dec.unknownGap = 0, 0
+	}
 	// If sizes aren't yet available, pre-compute them all. The reason we use a
 	// reverse order is to permit popping them off without thrashing the slice.
 	if len(dec.sizes) == 0 {
 		// Expand the sizes slice to required capacity
 		items := len(dec.offsets)
-		if cap(dec.sizes) < items {
-			dec.sizes = dec.sizes[:cap(dec.sizes)]
-			dec.sizes = append(dec.sizes, make([]uint32, items-len(dec.sizes))...)
-		} else {
-			dec.sizes = dec.sizes[:items]
-		}
+		dec.growSizes(items)
+
 		// Compute all the sizes we'll need in reverse order (so we can pop them
 		// off like a stack without ruining the buffer pointer)
 		for i := 0; i < items; i++ {
@@ -1264,9 +2801,58 @@ func (dec *Decoder) retrieveSize() uint32 {
 	// Retrieve the next item's size and pop it off the size stack
 	size := dec.sizes[len(dec.sizes)-1]
 	dec.sizes = dec.sizes[:len(dec.sizes)-1]
+	dec.stats.contents(1)
 	return size
 }
 
+// skipUnknownTrailer skips any data left over after the fields of the top level
+// object, if unknown fields are tolerated.
+func (dec *Decoder) skipUnknownTrailer() {
+	if !dec.skipUnknown || dec.err != nil {
+		return
+	}
+	if dec.inReader != nil {
+		if dec.inRead < dec.length {
+			dec.skipUnknownFields(dec.inRead, dec.length-dec.inRead)
+		}
+	} else if len(dec.inBuffer) > 0 {
+		dec.skipUnknownFields(dec.length-uint32(len(dec.inBuffer)), uint32(len(dec.inBuffer)))
+	}
+}
+
+// skipUnknownFields seeks past a region of unknown fields at the given offset in
+// the top level object, reporting its content if requested.
+func (dec *Decoder) skipUnknownFields(offset uint32, size uint32) {
+	if dec.err != nil {
+		return
+	}
+	if dec.inReader != nil {
+		if dec.onUnknown == nil {
+			if _, dec.err = io.CopyN(io.Discard, dec.inReader, int64(size)); dec.err != nil {
+				return
+			}
+			dec.inRead += size
+			return
+		}
+		blob := make([]byte, size)
+		if _, dec.err = io.ReadFull(dec.inReader, blob); dec.err != nil {
+			return
+		}
+		dec.inRead += size
+		dec.onUnknown(offset, blob)
+		return
+	}
+	if uint32(len(dec.inBuffer)) < size {
+		dec.err = io.ErrUnexpectedEOF
+		return
+	}
+	blob := dec.inBuffer[:size:size]
+	dec.inBuffer = dec.inBuffer[size:]
+	if dec.onUnknown != nil {
+		dec.onUnknown(offset, blob)
+	}
+}
+
 // descendIntoSlot starts the decoding of a data slot with a new length. For the
 // static objects, the length is used to enforce that all data is consumed. For
 // the dynamic objects, the length is used to decode the last dynamic item.
@@ -1274,6 +2860,9 @@ func (dec *Decoder) descendIntoSlot(length uint32) {
 	dec.lengths = append(dec.lengths, dec.length)
 	dec.length = length
 
+	dec.skips = append(dec.skips, dec.skip)
+	dec.skip = skippedField{}
+
 	if dec.inReader != nil {
 		dec.inReads = append(dec.inReads, dec.inRead)
 		dec.inRead = 0
@@ -1300,7 +2889,7 @@ func (dec *Decoder) ascendFromSlot() {
 	if dec.inReader != nil {
 		if dec.inRead != dec.length {
 			if dec.err == nil {
-				dec.err = fmt.Errorf("%w: data size %d, object consumed %d", ErrObjectSlotSizeMismatch, dec.length, dec.inRead)
+				dec.err = dec.slotMismatch(dec.inRead)
 			}
 		}
 		dec.inRead += dec.inReads[len(dec.inReads)-1] // track the sub-reads, don't discard!
@@ -1314,7 +2903,7 @@ func (dec *Decoder) ascendFromSlot() {
 		}
 		if read != dec.length {
 			if dec.err == nil {
-				dec.err = fmt.Errorf("%w: data size %d, object consumed %d", ErrObjectSlotSizeMismatch, dec.length, read)
+				dec.err = dec.slotMismatch(read)
 			}
 		}
 		dec.inBufPtr = dec.inBufPtrs[len(dec.inBufPtrs)-1]
@@ -1323,6 +2912,9 @@ func (dec *Decoder) ascendFromSlot() {
 
 	dec.length = dec.lengths[len(dec.lengths)-1]
 	dec.lengths = dec.lengths[:len(dec.lengths)-1]
+
+	dec.skip = dec.skips[len(dec.skips)-1]
+	dec.skips = dec.skips[:len(dec.skips)-1]
 }
 
 // startDynamics marks the item being decoded as a dynamic type, setting the starting
@@ -0,0 +1,144 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"bytes"
+	"reflect"
+	"strconv"
+)
+
+// FieldDiff is a field whose encoding differs between two objects.
+type FieldDiff struct {
+	Path string // Path of the field from the root object (e.g. Body.Deposits[2].Data.Amount)
+	A    []byte // Encoding of the field in the first object (nil if missing)
+	B    []byte // Encoding of the field in the second object (nil if missing)
+}
+
+// Diff compares the ssz encodings of two objects in a fork field-by-field, and
+// reports the innermost fields that differ, meant for debugging mismatches such
+// as re-encoded streams not matching the original without eyeballing hex dumps.
+//
+// Nested objects and list items are descended into, so only the leaf fields with
+// differing content are reported; list items missing from one side are reported
+// with a nil encoding on that side. Offsets are not reported, as they differ
+// whenever the content before them does. If the objects are of different types,
+// a single difference with an empty path is reported for the entire encodings.
+//
+// The layouts are collected the same way as for Dump, so they always match what
+// the codec does. If either encoding cannot be traced, the objects are compared
+// as a whole.
+func Diff(a, b Object, fork Fork) []FieldDiff {
+	var (
+		ta    = diffTrace(a, fork)
+		tb    = diffTrace(b, fork)
+		diffs []FieldDiff
+	)
+	diffObjects(&diffs, "", ta, ta.root, tb, tb.root)
+	return diffs
+}
+
+// diffTrace encodes an object and collects its field layout by decoding it into a
+// fresh instance. Failures are not reported, only the traced layout is missing.
+func diffTrace(obj Object, fork Fork) *dumpTracer {
+	blob := make([]byte, SizeOnFork(obj, fork))
+	if err := EncodeToBytesOnFork(blob, obj, fork); err != nil {
+		return &dumpTracer{blob: blob}
+	}
+	trace := &dumpTracer{blob: blob}
+
+	fresh := newObjectOf(obj)
+	if err := decodeFromBytes(blob, fresh, fork, LittleEndian, nil, trace, nil); err != nil {
+		return &dumpTracer{blob: blob}
+	}
+	return trace
+}
+
+// diffObjects compares two traced objects field-by-field, appending the leaf
+// fields with differing encodings to diffs.
+func diffObjects(diffs *[]FieldDiff, path string, ta *dumpTracer, na *dumpNode, tb *dumpTracer, nb *dumpNode) {
+	// If the layouts cannot be matched up, compare the objects as a whole
+	if na == nil || nb == nil || na.typ != nb.typ || len(na.fields) != len(nb.fields) {
+		a, b := diffSpan(ta, na), diffSpan(tb, nb)
+		if !bytes.Equal(a, b) {
+			*diffs = append(*diffs, FieldDiff{Path: path, A: a, B: b})
+		}
+		return
+	}
+	for i := range na.fields {
+		fa, fb := na.fields[i], nb.fields[i]
+		if fa.isOffset || fb.isOffset {
+			continue
+		}
+		a, b := ta.blob[fa.start:fa.end], tb.blob[fb.start:fb.end]
+		if bytes.Equal(a, b) {
+			continue
+		}
+		name := fa.name
+		if path != "" {
+			name = path + "." + name
+		}
+		// Descend into nested objects to find the innermost differences
+		reported := len(*diffs)
+
+		switch {
+		case len(fa.objects) == 0 && len(fb.objects) == 0:
+			// Basic field, reported as a whole below
+
+		case !diffIsList(na.typ, fa.name) && len(fa.objects) == 1 && len(fb.objects) == 1:
+			diffObjects(diffs, name, ta, fa.objects[0], tb, fb.objects[0])
+
+		default:
+			for j := 0; j < max(len(fa.objects), len(fb.objects)); j++ {
+				item := name + "[" + strconv.Itoa(j) + "]"
+				switch {
+				case j >= len(fa.objects):
+					*diffs = append(*diffs, FieldDiff{Path: item, B: diffSpan(tb, fb.objects[j])})
+				case j >= len(fb.objects):
+					*diffs = append(*diffs, FieldDiff{Path: item, A: diffSpan(ta, fa.objects[j])})
+				default:
+					diffObjects(diffs, item, ta, fa.objects[j], tb, fb.objects[j])
+				}
+			}
+		}
+		// If nothing more specific was found, report the field as a whole
+		if len(*diffs) == reported {
+			*diffs = append(*diffs, FieldDiff{Path: name, A: a, B: b})
+		}
+	}
+}
+
+// diffSpan returns the encoding of a traced object, or the entire encoding if
+// the object could not be traced.
+func diffSpan(trace *dumpTracer, node *dumpNode) []byte {
+	if node == nil {
+		return trace.blob
+	}
+	return trace.blob[node.start:node.end]
+}
+
+// diffIsList reports whether a field of an object type is a list or vector, as
+// opposed to a single nested object. This cannot be deduced from the layout if
+// a list contains only one item.
+func diffIsList(typ reflect.Type, field string) bool {
+	if typ == nil {
+		return false
+	}
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return false
+	}
+	f, ok := typ.FieldByName(field)
+	if !ok {
+		return false
+	}
+	kind := f.Type
+	for kind.Kind() == reflect.Pointer {
+		kind = kind.Elem()
+	}
+	return kind.Kind() == reflect.Slice || kind.Kind() == reflect.Array
+}
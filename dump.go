@@ -0,0 +1,191 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Dump renders the ssz encoding of an object field-by-field in a human readable
+// form, meant for debugging consensus mismatches. Every field is printed with its
+// byte range and hex content, offsets are printed with the position they point
+// to, and the start of each dynamic region is marked. Nested objects and list
+// items are expanded recursively.
+//
+// The layout is collected by running the object's own field definitions while
+// decoding its encoding into a fresh instance, so it always matches what the
+// codec does. Any encoding or decoding failure is reported at the end of the
+// output, after whatever layout could be collected.
+func Dump(obj Object, fork Fork) string {
+	var out strings.Builder
+
+	blob := make([]byte, SizeOnFork(obj, fork))
+	if err := EncodeToBytesOnFork(blob, obj, fork); err != nil {
+		fmt.Fprintf(&out, "%s: %d bytes\nerror: %v\n", objectName(obj), len(blob), err)
+		return out.String()
+	}
+	trace := &dumpTracer{blob: blob}
+
+	fresh := newObjectOf(obj)
+	err := decodeFromBytes(blob, fresh, fork, LittleEndian, nil, trace, nil)
+
+	if trace.root != nil {
+		trace.render(&out, trace.root, trace.root.name, "")
+	}
+	if err != nil {
+		fmt.Fprintf(&out, "error: %v\n", err)
+	}
+	return out.String()
+}
+
+// dumpNode is a single object or field in the layout collected for Dump. Objects
+// have fields, fields may have nested objects (a single one or list items).
+type dumpNode struct {
+	name  string // Field name, or type name for objects
+	start int    // Starting position of the node within the blob
+	end   int    // Ending position of the node within the blob
+
+	offset   int  // Absolute position pointed to by an offset field
+	isOffset bool // Whether the field is the offset of a dynamic field
+	dynamic  int  // Absolute start of an object's dynamic region (0 if unknown)
+
+	fields    []*dumpNode  // Fields of an object, in definition order
+	objects   []*dumpNode  // Objects nested within a field (list items or single)
+	namedFrom Object       // Object to retrieve field names from after decoding
+	typ       reflect.Type // Go type of an object, to resolve its fields' kinds
+}
+
+// dumpTracer collects the field layout of an object while it's being decoded.
+type dumpTracer struct {
+	blob  []byte      // Encoding being decoded, to convert slices into positions
+	root  *dumpNode   // Top level object being decoded
+	stack []*dumpNode // Objects currently being decoded, innermost last
+}
+
+// position returns the current read position of the decoder within the blob.
+func (t *dumpTracer) position(dec *Decoder) int {
+	return len(t.blob) - len(dec.inBuffer)
+}
+
+// enter starts tracking a new (nested) object.
+func (t *dumpTracer) enter(dec *Decoder, obj Object) {
+	node := &dumpNode{name: objectName(obj), start: t.position(dec), namedFrom: obj, typ: reflect.TypeOf(obj)}
+	if len(t.stack) == 0 {
+		t.root = node
+	} else if parent := t.stack[len(t.stack)-1]; len(parent.fields) > 0 {
+		field := parent.fields[len(parent.fields)-1]
+		field.objects = append(field.objects, node)
+	}
+	t.stack = append(t.stack, node)
+}
+
+// leave finishes tracking the innermost object, closing its last field and
+// resolving the field names.
+func (t *dumpTracer) leave(dec *Decoder) {
+	node := t.stack[len(t.stack)-1]
+	t.stack = t.stack[:len(t.stack)-1]
+
+	node.end = t.position(dec)
+	if n := len(node.fields); n > 0 && node.fields[n-1].end < 0 {
+		node.fields[n-1].end = node.end
+	}
+	var names []string
+	if named, ok := node.namedFrom.(NamedObject); ok {
+		names = named.NamesSSZ()
+	}
+	for i, field := range node.fields {
+		if i < len(names) {
+			field.name = names[i]
+		} else {
+			field.name = "#" + strconv.Itoa(i)
+		}
+	}
+	node.namedFrom = nil
+}
+
+// field starts tracking a new field in the innermost object, closing the one
+// before it.
+func (t *dumpTracer) field(dec *Decoder) {
+	if len(t.stack) == 0 {
+		return
+	}
+	node := t.stack[len(t.stack)-1]
+	pos := t.position(dec)
+
+	if n := len(node.fields); n > 0 && node.fields[n-1].end < 0 {
+		node.fields[n-1].end = pos
+	}
+	node.fields = append(node.fields, &dumpNode{start: pos, end: -1})
+}
+
+// offset marks the field currently being decoded as an offset into the dynamic
+// region of its object.
+func (t *dumpTracer) offset(offset uint32) {
+	if len(t.stack) == 0 {
+		return
+	}
+	node := t.stack[len(t.stack)-1]
+	if len(node.fields) == 0 {
+		return
+	}
+	// Offsets within the dynamic region belong to the content of a field (e.g.
+	// items in a list), only those in the static region are field offsets
+	field := node.fields[len(node.fields)-1]
+	if node.dynamic > 0 && field.start >= node.dynamic {
+		return
+	}
+	field.isOffset, field.offset = true, node.start+int(offset)
+	if node.dynamic == 0 {
+		node.dynamic = field.offset
+	}
+}
+
+// render writes the collected layout of an object into the output.
+func (t *dumpTracer) render(out *strings.Builder, node *dumpNode, label string, indent string) {
+	fmt.Fprintf(out, "%s%s: %d bytes\n", indent, label, node.end-node.start)
+
+	// Find the field to mark the start of the dynamic region at (if any). Empty
+	// fields (zero length or inactive in the fork) at the boundary are ambiguous,
+	// so prefer marking before the first field with actual content.
+	mark := -1
+	if node.dynamic > 0 {
+		for i, field := range node.fields {
+			if field.isOffset {
+				mark = i + 1
+				continue
+			}
+			if field.start >= node.dynamic && field.end > field.start {
+				mark = i
+				break
+			}
+		}
+	}
+	indent += "  "
+	for i, field := range node.fields {
+		if i == mark {
+			fmt.Fprintf(out, "%s-- dynamic region %#06x-%#06x --\n", indent, node.dynamic, node.end)
+		}
+		switch {
+		case field.isOffset:
+			fmt.Fprintf(out, "%s%#06x-%#06x %s: offset -> %#06x\n", indent, field.start, field.end, field.name, field.offset)
+		case field.start == field.end:
+			fmt.Fprintf(out, "%s%#06x-%#06x %s: -\n", indent, field.start, field.end, field.name)
+		case len(field.objects) == 1:
+			fmt.Fprintf(out, "%s%#06x-%#06x %s:\n", indent, field.start, field.end, field.name)
+			t.render(out, field.objects[0], field.objects[0].name, indent+"  ")
+		case len(field.objects) > 1:
+			fmt.Fprintf(out, "%s%#06x-%#06x %s: %d items\n", indent, field.start, field.end, field.name, len(field.objects))
+			for i, item := range field.objects {
+				t.render(out, item, "["+strconv.Itoa(i)+"] "+item.name, indent+"  ")
+			}
+		default:
+			fmt.Fprintf(out, "%s%#06x-%#06x %s: 0x%s\n", indent, field.start, field.end, field.name, hex.EncodeToString(t.blob[field.start:field.end]))
+		}
+	}
+}
@@ -6,9 +6,12 @@ package ssz
 
 import (
 	"encoding/binary"
+	"fmt"
 	"io"
 	"math/big"
 	"reflect"
+	"strconv"
+	"strings"
 	"unsafe"
 
 	"github.com/holiman/uint256"
@@ -46,7 +49,9 @@ var (
 //
 //  5. The encoder does not enforce defined size limits on the dynamic fields.
 //     If the caller provided bad data to encode, it is a programming error and
-//     a runtime error will not fix anything.
+//     a runtime error will not fix anything. If the data originates from some
+//     untrusted or unvalidated source, the checked encoding mode can be used to
+//     fail fast instead of emitting a payload remote peers will reject.
 //
 // Internally there are a few implementation details that maintainers need to be
 // aware of when modifying the code:
@@ -78,7 +83,12 @@ type Encoder struct {
 	buf    [32]byte    // Integer conversion buffer
 	bufInt uint256.Int // Big.Int conversion buffer (not pointer, alloc free)
 
-	offset uint32 // Offset tracker for dynamic fields
+	offset  uint32 // Offset tracker for dynamic fields
+	checked bool   // Whether to validate the size limits of dynamic fields
+	field   int    // Number of fields started in the current object (frozen after a failure)
+
+	stats Stats         // Structural counters of the encoding (sszdebug builds only)
+	trace *activeTracer // Tracer of the top level field positions (nil = not tracing)
 }
 
 // EncodeBool serializes a boolean.
@@ -107,7 +117,7 @@ func EncodeBool[T ~bool](enc *Encoder, v T) {
 // Note, a nil pointer is serialized as false.
 func EncodeBoolPointerOnFork[T ~bool](enc *Encoder, v *T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
+	if !filter.Active(enc.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard encoder
@@ -137,7 +147,7 @@ func EncodeUint8[T ~uint8](enc *Encoder, n T) {
 // Note, a nil pointer is serialized as zero.
 func EncodeUint8PointerOnFork[T ~uint8](enc *Encoder, n *T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
+	if !filter.Active(enc.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard encoder
@@ -154,10 +164,10 @@ func EncodeUint16[T ~uint16](enc *Encoder, n T) {
 		if enc.err != nil {
 			return
 		}
-		binary.LittleEndian.PutUint16(enc.buf[:2], (uint16)(n))
+		enc.codec.order.putUint16(enc.buf[:2], (uint16)(n))
 		_, enc.err = enc.outWriter.Write(enc.buf[:2])
 	} else {
-		binary.LittleEndian.PutUint16(enc.outBuffer, (uint16)(n))
+		enc.codec.order.putUint16(enc.outBuffer, (uint16)(n))
 		enc.outBuffer = enc.outBuffer[2:]
 	}
 }
@@ -167,7 +177,7 @@ func EncodeUint16[T ~uint16](enc *Encoder, n T) {
 // Note, a nil pointer is serialized as zero.
 func EncodeUint16PointerOnFork[T ~uint16](enc *Encoder, n *T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
+	if !filter.Active(enc.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard encoder
@@ -184,10 +194,10 @@ func EncodeUint32[T ~uint32](enc *Encoder, n T) {
 		if enc.err != nil {
 			return
 		}
-		binary.LittleEndian.PutUint32(enc.buf[:4], (uint32)(n))
+		enc.codec.order.putUint32(enc.buf[:4], (uint32)(n))
 		_, enc.err = enc.outWriter.Write(enc.buf[:4])
 	} else {
-		binary.LittleEndian.PutUint32(enc.outBuffer, (uint32)(n))
+		enc.codec.order.putUint32(enc.outBuffer, (uint32)(n))
 		enc.outBuffer = enc.outBuffer[4:]
 	}
 }
@@ -197,7 +207,7 @@ func EncodeUint32[T ~uint32](enc *Encoder, n T) {
 // Note, a nil pointer is serialized as zero.
 func EncodeUint32PointerOnFork[T ~uint32](enc *Encoder, n *T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
+	if !filter.Active(enc.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard encoder
@@ -214,10 +224,10 @@ func EncodeUint64[T ~uint64](enc *Encoder, n T) {
 		if enc.err != nil {
 			return
 		}
-		binary.LittleEndian.PutUint64(enc.buf[:8], (uint64)(n))
+		enc.codec.order.putUint64(enc.buf[:8], (uint64)(n))
 		_, enc.err = enc.outWriter.Write(enc.buf[:8])
 	} else {
-		binary.LittleEndian.PutUint64(enc.outBuffer, (uint64)(n))
+		enc.codec.order.putUint64(enc.outBuffer, (uint64)(n))
 		enc.outBuffer = enc.outBuffer[8:]
 	}
 }
@@ -227,7 +237,7 @@ func EncodeUint64[T ~uint64](enc *Encoder, n T) {
 // Note, a nil pointer is serialized as zero.
 func EncodeUint64PointerOnFork[T ~uint64](enc *Encoder, n *T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
+	if !filter.Active(enc.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard encoder
@@ -247,14 +257,14 @@ func EncodeUint256(enc *Encoder, n *uint256.Int) {
 			return
 		}
 		if n != nil {
-			n.MarshalSSZInto(enc.buf[:32])
+			enc.codec.order.putUint256(enc.buf[:32], n)
 			_, enc.err = enc.outWriter.Write(enc.buf[:32])
 		} else {
 			_, enc.err = enc.outWriter.Write(uint256Zero)
 		}
 	} else {
 		if n != nil {
-			n.MarshalSSZInto(enc.outBuffer)
+			enc.codec.order.putUint256(enc.outBuffer, n)
 		} else {
 			copy(enc.outBuffer, uint256Zero)
 		}
@@ -267,7 +277,7 @@ func EncodeUint256(enc *Encoder, n *uint256.Int) {
 // Note, a nil pointer is serialized as zero.
 func EncodeUint256OnFork(enc *Encoder, n *uint256.Int, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
+	if !filter.Active(enc.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard encoder
@@ -285,7 +295,7 @@ func EncodeUint256BigInt(enc *Encoder, n *big.Int) {
 		}
 		if n != nil {
 			enc.bufInt.SetFromBig(n)
-			enc.bufInt.MarshalSSZInto(enc.buf[:32])
+			enc.codec.order.putUint256(enc.buf[:32], &enc.bufInt)
 			_, enc.err = enc.outWriter.Write(enc.buf[:32])
 		} else {
 			_, enc.err = enc.outWriter.Write(uint256Zero)
@@ -293,7 +303,7 @@ func EncodeUint256BigInt(enc *Encoder, n *big.Int) {
 	} else {
 		if n != nil {
 			enc.bufInt.SetFromBig(n)
-			enc.bufInt.MarshalSSZInto(enc.outBuffer)
+			enc.codec.order.putUint256(enc.outBuffer, &enc.bufInt)
 		} else {
 			copy(enc.outBuffer, uint256Zero)
 		}
@@ -308,7 +318,7 @@ func EncodeUint256BigInt(enc *Encoder, n *big.Int) {
 // Note, an overflow will be silently dropped.
 func EncodeUint256BigIntOnFork(enc *Encoder, n *big.Int, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
+	if !filter.Active(enc.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard encoder
@@ -341,7 +351,7 @@ func EncodeStaticBytes[T commonBytesLengths](enc *Encoder, blob *T) {
 // Note, a nil pointer is serialized as a zero-value blob.
 func EncodeStaticBytesPointerOnFork[T commonBytesLengths](enc *Encoder, blob *T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
+	if !filter.Active(enc.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard encoder
@@ -384,13 +394,14 @@ func EncodeDynamicBytesOffset(enc *Encoder, blob []byte) {
 		enc.outBuffer = enc.outBuffer[4:]
 	}
 	enc.offset += uint32(len(blob))
+	enc.stats.offsets(1)
 }
 
 // EncodeDynamicBytesOffsetOnFork serializes a dynamic binary blob if present in
 // a fork.
 func EncodeDynamicBytesOffsetOnFork(enc *Encoder, blob []byte, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
+	if !filter.Active(enc.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard encoder
@@ -399,6 +410,7 @@ func EncodeDynamicBytesOffsetOnFork(enc *Encoder, blob []byte, filter ForkFilter
 
 // EncodeDynamicBytesContent is the lazy data writer for EncodeDynamicBytesOffset.
 func EncodeDynamicBytesContent(enc *Encoder, blob []byte) {
+	enc.stats.contents(1)
 	if enc.outWriter != nil {
 		if enc.err != nil {
 			return
@@ -413,13 +425,64 @@ func EncodeDynamicBytesContent(enc *Encoder, blob []byte) {
 // EncodeDynamicBytesContentOnFork is the lazy data writer for EncodeDynamicBytesOffsetOnFork.
 func EncodeDynamicBytesContentOnFork(enc *Encoder, blob []byte, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
+	if !filter.Active(enc.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard encoder
 	EncodeDynamicBytesContent(enc, blob)
 }
 
+// EncodeStringOffset serializes a string as a dynamic binary blob.
+func EncodeStringOffset[T ~string](enc *Encoder, str T) {
+	if enc.outWriter != nil {
+		if enc.err != nil {
+			return
+		}
+		binary.LittleEndian.PutUint32(enc.buf[:4], enc.offset)
+		_, enc.err = enc.outWriter.Write(enc.buf[:4])
+	} else {
+		binary.LittleEndian.PutUint32(enc.outBuffer, enc.offset)
+		enc.outBuffer = enc.outBuffer[4:]
+	}
+	enc.offset += uint32(len(str))
+	enc.stats.offsets(1)
+}
+
+// EncodeStringOffsetOnFork serializes a string as a dynamic binary blob if
+// present in a fork.
+func EncodeStringOffsetOnFork[T ~string](enc *Encoder, str T, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(enc.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	EncodeStringOffset(enc, str)
+}
+
+// EncodeStringContent is the lazy data writer for EncodeStringOffset.
+func EncodeStringContent[T ~string](enc *Encoder, str T) {
+	enc.stats.contents(1)
+	if enc.outWriter != nil {
+		if enc.err != nil {
+			return
+		}
+		_, enc.err = io.WriteString(enc.outWriter, string(str))
+	} else {
+		copy(enc.outBuffer, str)
+		enc.outBuffer = enc.outBuffer[len(str):]
+	}
+}
+
+// EncodeStringContentOnFork is the lazy data writer for EncodeStringOffsetOnFork.
+func EncodeStringContentOnFork[T ~string](enc *Encoder, str T, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(enc.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	EncodeStringContent(enc, str)
+}
+
 // EncodeStaticObject serializes a static ssz object.
 //
 // Note, nil will be encoded as a zero-value initialized object.
@@ -432,7 +495,7 @@ func EncodeStaticObject[T newableStaticObject[U], U any](enc *Encoder, obj T) {
 		// but it should not happen in production, only during tests mostly.
 		obj = zeroValueStatic[T, U]()
 	}
-	obj.DefineSSZ(enc.codec)
+	enc.encodeObject(obj)
 }
 
 // EncodeStaticObjectOnFork serializes a static ssz object is present in a fork.
@@ -440,7 +503,7 @@ func EncodeStaticObject[T newableStaticObject[U], U any](enc *Encoder, obj T) {
 // Note, nil will be encoded as a zero-value initialized object.
 func EncodeStaticObjectOnFork[T newableStaticObject[U], U any](enc *Encoder, obj T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
+	if !filter.Active(enc.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard encoder
@@ -466,7 +529,8 @@ func EncodeDynamicObjectOffset[T newableDynamicObject[U], U any](enc *Encoder, o
 	if obj == nil {
 		obj = zeroValueDynamic[T, U]()
 	}
-	enc.offset += obj.SizeSSZ(enc.sizer, false)
+	enc.offset += enc.sizer.sizeDynamic(obj)
+	enc.stats.offsets(1)
 }
 
 // EncodeDynamicObjectOffsetOnFork serializes a dynamic ssz object if present in
@@ -475,7 +539,7 @@ func EncodeDynamicObjectOffset[T newableDynamicObject[U], U any](enc *Encoder, o
 // Note, nil will be encoded as a zero-value initialized object.
 func EncodeDynamicObjectOffsetOnFork[T newableDynamicObject[U], U any](enc *Encoder, obj T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
+	if !filter.Active(enc.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard encoder
@@ -486,6 +550,7 @@ func EncodeDynamicObjectOffsetOnFork[T newableDynamicObject[U], U any](enc *Enco
 //
 // Note, nil will be encoded as a zero-value initialized object.
 func EncodeDynamicObjectContent[T newableDynamicObject[U], U any](enc *Encoder, obj T) {
+	enc.stats.contents(1)
 	if enc.err != nil {
 		return
 	}
@@ -495,7 +560,9 @@ func EncodeDynamicObjectContent[T newableDynamicObject[U], U any](enc *Encoder,
 		obj = zeroValueDynamic[T, U]()
 	}
 	enc.offsetDynamics(obj.SizeSSZ(enc.sizer, true))
-	obj.DefineSSZ(enc.codec)
+	enc.stats.enter()
+	enc.encodeObject(obj)
+	enc.stats.leave()
 }
 
 // EncodeDynamicObjectContentOnFork is the lazy data writer for EncodeDynamicObjectOffsetOnFork.
@@ -503,13 +570,110 @@ func EncodeDynamicObjectContent[T newableDynamicObject[U], U any](enc *Encoder,
 // Note, nil will be encoded as a zero-value initialized object.
 func EncodeDynamicObjectContentOnFork[T newableDynamicObject[U], U any](enc *Encoder, obj T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
+	if !filter.Active(enc.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard encoder
 	EncodeDynamicObjectContent(enc, obj)
 }
 
+// EncodeGenericStaticObject serializes a static ssz object passed as a type
+// parameter of a generic container.
+//
+// Note, nil will be encoded as a zero-value initialized object.
+func EncodeGenericStaticObject[T StaticObject](enc *Encoder, obj T) {
+	if enc.err != nil {
+		return
+	}
+	if isNilGeneric(obj) {
+		// If the object is nil, pull up it's zero value. This will be very slow,
+		// but it should not happen in production, only during tests mostly.
+		obj = zeroValueGeneric[T]()
+	}
+	enc.encodeObject(obj)
+}
+
+// EncodeGenericStaticObjectOnFork serializes a static ssz object passed as a type
+// parameter of a generic container if present in a fork.
+//
+// Note, nil will be encoded as a zero-value initialized object.
+func EncodeGenericStaticObjectOnFork[T StaticObject](enc *Encoder, obj T, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(enc.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	EncodeGenericStaticObject(enc, obj)
+}
+
+// EncodeGenericDynamicObjectOffset serializes a dynamic ssz object passed as a
+// type parameter of a generic container.
+//
+// Note, nil will be encoded as a zero-value initialized object.
+func EncodeGenericDynamicObjectOffset[T DynamicObject](enc *Encoder, obj T) {
+	if enc.outWriter != nil {
+		if enc.err != nil {
+			return
+		}
+		binary.LittleEndian.PutUint32(enc.buf[:4], enc.offset)
+		_, enc.err = enc.outWriter.Write(enc.buf[:4])
+	} else {
+		binary.LittleEndian.PutUint32(enc.outBuffer, enc.offset)
+		enc.outBuffer = enc.outBuffer[4:]
+	}
+	// If the object is nil, pull up it's zero value. This will be very slow, but
+	// it should not happen in production, only during tests mostly.
+	if isNilGeneric(obj) {
+		obj = zeroValueGeneric[T]()
+	}
+	enc.offset += enc.sizer.sizeDynamic(obj)
+	enc.stats.offsets(1)
+}
+
+// EncodeGenericDynamicObjectOffsetOnFork serializes a dynamic ssz object passed
+// as a type parameter of a generic container if present in a fork.
+//
+// Note, nil will be encoded as a zero-value initialized object.
+func EncodeGenericDynamicObjectOffsetOnFork[T DynamicObject](enc *Encoder, obj T, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(enc.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	EncodeGenericDynamicObjectOffset(enc, obj)
+}
+
+// EncodeGenericDynamicObjectContent is the lazy data writer for EncodeGenericDynamicObjectOffset.
+//
+// Note, nil will be encoded as a zero-value initialized object.
+func EncodeGenericDynamicObjectContent[T DynamicObject](enc *Encoder, obj T) {
+	enc.stats.contents(1)
+	if enc.err != nil {
+		return
+	}
+	// If the object is nil, pull up it's zero value. This will be very slow, but
+	// it should not happen in production, only during tests mostly.
+	if isNilGeneric(obj) {
+		obj = zeroValueGeneric[T]()
+	}
+	enc.offsetDynamics(obj.SizeSSZ(enc.sizer, true))
+	enc.stats.enter()
+	enc.encodeObject(obj)
+	enc.stats.leave()
+}
+
+// EncodeGenericDynamicObjectContentOnFork is the lazy data writer for EncodeGenericDynamicObjectOffsetOnFork.
+//
+// Note, nil will be encoded as a zero-value initialized object.
+func EncodeGenericDynamicObjectContentOnFork[T DynamicObject](enc *Encoder, obj T, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(enc.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	EncodeGenericDynamicObjectContent(enc, obj)
+}
+
 // EncodeArrayOfBits serializes a static array of (packed) bits.
 func EncodeArrayOfBits[T commonBitsLengths](enc *Encoder, bits *T) {
 	if enc.outWriter != nil {
@@ -533,7 +697,7 @@ func EncodeArrayOfBits[T commonBitsLengths](enc *Encoder, bits *T) {
 // Note, a nil pointer is serialized as a zero-value bit array.
 func EncodeArrayOfBitsPointerOnFork[T commonBitsLengths](enc *Encoder, bits *T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
+	if !filter.Active(enc.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard encoder
@@ -544,6 +708,43 @@ func EncodeArrayOfBitsPointerOnFork[T commonBitsLengths](enc *Encoder, bits *T,
 	EncodeArrayOfBits(enc, bits)
 }
 
+// EncodeUnsafeArrayOfBits serializes a static array of static arrays of (packed)
+// bits.
+func EncodeUnsafeArrayOfBits[T commonBitsLengths](enc *Encoder, bits []T) {
+	for i := 0; i < len(bits); i++ { // don't range loop, T is an array, copy is expensive
+		EncodeArrayOfBits(enc, &bits[i])
+	}
+}
+
+// EncodeCheckedArrayOfBits serializes a static array of static arrays of (packed)
+// bits.
+func EncodeCheckedArrayOfBits[T commonBitsLengths](enc *Encoder, bits []T, items uint64) {
+	// If the bit arrays are nil, write a batch of zeroes and exit
+	if bits == nil {
+		enc.encodeZeroes(int(items) * reflect.TypeFor[T]().Len())
+		return
+	}
+	EncodeUnsafeArrayOfBits(enc, bits)
+}
+
+// EncodeCheckedBits serializes a static (packed) bit slice, such as one of
+// go-bitfield's bitvectors.
+//
+// Note, a nil bit slice is serialized as a zero-value bitvector.
+func EncodeCheckedBits[T ~[]byte](enc *Encoder, bits T, size uint64) {
+	EncodeCheckedStaticBytes(enc, bits, (size+7)>>3)
+}
+
+// EncodeUnsafeArrayOfCheckedBits serializes a static array of (packed) bit slices,
+// such as go-bitfield's bitvectors.
+//
+// Note, a nil bit slice is serialized as a zero-value bitvector.
+func EncodeUnsafeArrayOfCheckedBits[T ~[]byte](enc *Encoder, bits []T, size uint64) {
+	for _, bitvector := range bits {
+		EncodeCheckedStaticBytes(enc, bitvector, (size+7)>>3)
+	}
+}
+
 // EncodeSliceOfBitsOffset serializes a dynamic slice of (packed) bits.
 //
 // Note, a nil slice of bits is serialized as an empty bit list.
@@ -563,6 +764,7 @@ func EncodeSliceOfBitsOffset(enc *Encoder, bits bitfield.Bitlist) {
 	} else {
 		enc.offset += uint32(len(bitlistZero))
 	}
+	enc.stats.offsets(1)
 }
 
 // EncodeSliceOfBitsOffsetOnFork serializes a dynamic slice of (packed) bits if
@@ -571,7 +773,7 @@ func EncodeSliceOfBitsOffset(enc *Encoder, bits bitfield.Bitlist) {
 // Note, a nil slice of bits is serialized as an empty bit list.
 func EncodeSliceOfBitsOffsetOnFork(enc *Encoder, bits bitfield.Bitlist, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
+	if !filter.Active(enc.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard encoder
@@ -582,6 +784,7 @@ func EncodeSliceOfBitsOffsetOnFork(enc *Encoder, bits bitfield.Bitlist, filter F
 //
 // Note, a nil slice of bits is serialized as an empty bit list.
 func EncodeSliceOfBitsContent(enc *Encoder, bits bitfield.Bitlist) {
+	enc.stats.contents(1)
 	if enc.outWriter != nil {
 		if enc.err != nil {
 			return
@@ -607,7 +810,7 @@ func EncodeSliceOfBitsContent(enc *Encoder, bits bitfield.Bitlist) {
 // Note, a nil slice of bits is serialized as an empty bit list.
 func EncodeSliceOfBitsContentOnFork(enc *Encoder, bits bitfield.Bitlist, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
+	if !filter.Active(enc.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard encoder
@@ -631,12 +834,12 @@ func EncodeArrayOfUint64s[T commonUint64sLengths](enc *Encoder, ns *T) {
 			if enc.err != nil {
 				return
 			}
-			binary.LittleEndian.PutUint64(enc.buf[:8], n)
+			enc.codec.order.putUint64(enc.buf[:8], n)
 			_, enc.err = enc.outWriter.Write(enc.buf[:8])
 		}
 	} else {
 		for _, n := range nums {
-			binary.LittleEndian.PutUint64(enc.outBuffer, n)
+			enc.codec.order.putUint64(enc.outBuffer, n)
 			enc.outBuffer = enc.outBuffer[8:]
 		}
 	}
@@ -648,7 +851,7 @@ func EncodeArrayOfUint64s[T commonUint64sLengths](enc *Encoder, ns *T) {
 // Note, a nil pointer is serialized as a uint64 array filled with zeroes.
 func EncodeArrayOfUint64sPointerOnFork[T commonUint64sLengths](enc *Encoder, ns *T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
+	if !filter.Active(enc.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard encoder
@@ -659,132 +862,144 @@ func EncodeArrayOfUint64sPointerOnFork[T commonUint64sLengths](enc *Encoder, ns
 	EncodeArrayOfUint64s(enc, ns)
 }
 
-// EncodeSliceOfUint64sOffset serializes a dynamic slice of uint64s.
-func EncodeSliceOfUint64sOffset[T ~uint64](enc *Encoder, ns []T) {
-	// Nope, dive into actual encoding
-	if enc.outWriter != nil {
-		if enc.err != nil {
-			return
-		}
-		binary.LittleEndian.PutUint32(enc.buf[:4], enc.offset)
-		_, enc.err = enc.outWriter.Write(enc.buf[:4])
-	} else {
-		binary.LittleEndian.PutUint32(enc.outBuffer, enc.offset)
-		enc.outBuffer = enc.outBuffer[4:]
-	}
-	if items := len(ns); items > 0 {
-		enc.offset += uint32(items * 8)
-	}
-}
-
-// EncodeSliceOfUint64sOffsetOnFork serializes a dynamic slice of uint64s if
-// present in a fork.
-func EncodeSliceOfUint64sOffsetOnFork[T ~uint64](enc *Encoder, ns []T, filter ForkFilter) {
-	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
+// EncodeCheckedStaticUint64s serializes a static array of uint64s stored in a
+// plain slice.
+//
+// Note, nil is serialized as a uint64 array filled with zeroes.
+func EncodeCheckedStaticUint64s[T ~uint64](enc *Encoder, ns []T, size uint64) {
+	// If the slice is nil, write a batch of zeroes and exit
+	if ns == nil {
+		enc.encodeZeroes(int(size) * 8)
 		return
 	}
-	// Otherwise fall back to the standard encoder
-	EncodeSliceOfUint64sOffset(enc, ns)
-}
-
-// EncodeSliceOfUint64sContent is the lazy data writer for EncodeSliceOfUint64sOffset.
-func EncodeSliceOfUint64sContent[T ~uint64](enc *Encoder, ns []T) {
+	// Internally this method is essentially calling EncodeUint64 on all numbers
+	// in a loop. Practically, we've inlined that call to make things a *lot* faster.
 	if enc.outWriter != nil {
 		for _, n := range ns {
 			if enc.err != nil {
 				return
 			}
-			binary.LittleEndian.PutUint64(enc.buf[:8], (uint64)(n))
+			enc.codec.order.putUint64(enc.buf[:8], uint64(n))
 			_, enc.err = enc.outWriter.Write(enc.buf[:8])
 		}
 	} else {
 		for _, n := range ns {
-			binary.LittleEndian.PutUint64(enc.outBuffer, (uint64)(n))
+			enc.codec.order.putUint64(enc.outBuffer, uint64(n))
 			enc.outBuffer = enc.outBuffer[8:]
 		}
 	}
 }
 
-// EncodeSliceOfUint64sContentOnFork is the lazy data writer for EncodeSliceOfUint64sOffsetOnFork.
-func EncodeSliceOfUint64sContentOnFork[T ~uint64](enc *Encoder, ns []T, filter ForkFilter) {
-	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
-		return
-	}
-	// Otherwise fall back to the standard encoder
-	EncodeSliceOfUint64sContent(enc, ns)
-}
-
-// EncodeArrayOfStaticBytes serializes a static array of static binary
-// blobs.
+// EncodeArrayOfUint16s serializes a static array of uint16s.
 //
-// The reason the blobs is passed by pointer and not by value is to prevent it
-// from escaping to the heap (and incurring an allocation) when passing it to
-// the output stream.
-func EncodeArrayOfStaticBytes[T commonBytesArrayLengths[U], U commonBytesLengths](enc *Encoder, blobs *T) {
-	// The code below should have used `(*blobs)[:]`, alas Go's generics compiler
+// The reason the ns is passed by pointer and not by value is to prevent it from
+// escaping to the heap (and incurring an allocation) when passing it to the
+// output stream.
+func EncodeArrayOfUint16s[T commonUint16sLengths](enc *Encoder, ns *T) {
+	// The code below should have used `*blob[:]`, alas Go's generics compiler
 	// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-	EncodeUnsafeArrayOfStaticBytes(enc, unsafe.Slice(&(*blobs)[0], len(*blobs)))
-}
+	nums := unsafe.Slice(&(*ns)[0], len(*ns))
 
-// EncodeUnsafeArrayOfStaticBytes serializes a static array of static binary
-// blobs.
-func EncodeUnsafeArrayOfStaticBytes[T commonBytesLengths](enc *Encoder, blobs []T) {
-	// Internally this method is essentially calling EncodeStaticBytes on all
-	// the blobs in a loop. Practically, we've inlined that call to make things
-	// a *lot* faster.
 	if enc.outWriter != nil {
-		for i := 0; i < len(blobs); i++ { // don't range loop, T might be an array, copy is expensive
+		for _, n := range nums {
 			if enc.err != nil {
 				return
 			}
-			// The code below should have used `blobs[i][:]`, alas Go's generics compiler
-			// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-			_, enc.err = enc.outWriter.Write(unsafe.Slice(&blobs[i][0], len(blobs[i])))
+			enc.codec.order.putUint16(enc.buf[:2], n)
+			_, enc.err = enc.outWriter.Write(enc.buf[:2])
 		}
 	} else {
-		for i := 0; i < len(blobs); i++ { // don't range loop, T might be an array, copy is expensive
-			// The code below should have used `blobs[i][:]`, alas Go's generics compiler
-			// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-			copy(enc.outBuffer, unsafe.Slice(&blobs[i][0], len(blobs[i])))
-			enc.outBuffer = enc.outBuffer[len(blobs[i]):]
+		for _, n := range nums {
+			enc.codec.order.putUint16(enc.outBuffer, n)
+			enc.outBuffer = enc.outBuffer[2:]
 		}
 	}
 }
 
-// EncodeCheckedArrayOfStaticBytes serializes a static array of static binary
-// blobs.
-func EncodeCheckedArrayOfStaticBytes[T commonBytesLengths](enc *Encoder, blobs []T, size uint64) {
-	// If the blobs are nil, write a batch of zeroes and exit
-	if blobs == nil {
-		enc.encodeZeroes(int(size) * reflect.TypeFor[T]().Len())
+// EncodeArrayOfUint16sPointerOnFork serializes a static array of uint16s if
+// present in a fork.
+//
+// Note, a nil pointer is serialized as a uint16 array filled with zeroes.
+func EncodeArrayOfUint16sPointerOnFork[T commonUint16sLengths](enc *Encoder, ns *T, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(enc.codec.fork) {
 		return
 	}
-	// Internally this method is essentially calling EncodeStaticBytes on all
-	// the blobs in a loop. Practically, we've inlined that call to make things
-	// a *lot* faster.
+	// Otherwise fall back to the standard encoder
+	if ns == nil {
+		enc.encodeZeroes(reflect.TypeFor[T]().Len() * 2)
+		return
+	}
+	EncodeArrayOfUint16s(enc, ns)
+}
+
+// EncodeArrayOfUint32s serializes a static array of uint32s.
+//
+// The reason the ns is passed by pointer and not by value is to prevent it from
+// escaping to the heap (and incurring an allocation) when passing it to the
+// output stream.
+func EncodeArrayOfUint32s[T commonUint32sLengths](enc *Encoder, ns *T) {
+	// The code below should have used `*blob[:]`, alas Go's generics compiler
+	// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
+	nums := unsafe.Slice(&(*ns)[0], len(*ns))
+
 	if enc.outWriter != nil {
-		for i := 0; i < len(blobs); i++ { // don't range loop, T might be an array, copy is expensive
+		for _, n := range nums {
 			if enc.err != nil {
 				return
 			}
-			// The code below should have used `blobs[i][:]`, alas Go's generics compiler
-			// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-			_, enc.err = enc.outWriter.Write(unsafe.Slice(&blobs[i][0], len(blobs[i])))
+			enc.codec.order.putUint32(enc.buf[:4], n)
+			_, enc.err = enc.outWriter.Write(enc.buf[:4])
 		}
 	} else {
-		for i := 0; i < len(blobs); i++ { // don't range loop, T might be an array, copy is expensive
-			// The code below should have used `blobs[i][:]`, alas Go's generics compiler
-			// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-			copy(enc.outBuffer, unsafe.Slice(&blobs[i][0], len(blobs[i])))
-			enc.outBuffer = enc.outBuffer[len(blobs[i]):]
+		for _, n := range nums {
+			enc.codec.order.putUint32(enc.outBuffer, n)
+			enc.outBuffer = enc.outBuffer[4:]
 		}
 	}
 }
 
-// EncodeSliceOfStaticBytesOffset serializes a dynamic slice of static binary blobs.
-func EncodeSliceOfStaticBytesOffset[T commonBytesLengths](enc *Encoder, blobs []T) {
+// EncodeArrayOfUint32sPointerOnFork serializes a static array of uint32s if
+// present in a fork.
+//
+// Note, a nil pointer is serialized as a uint32 array filled with zeroes.
+func EncodeArrayOfUint32sPointerOnFork[T commonUint32sLengths](enc *Encoder, ns *T, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(enc.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	if ns == nil {
+		enc.encodeZeroes(reflect.TypeFor[T]().Len() * 4)
+		return
+	}
+	EncodeArrayOfUint32s(enc, ns)
+}
+
+// EncodeArrayOfArrayOfUint16s serializes a static array of static arrays of uint16s.
+func EncodeArrayOfArrayOfUint16s[T commonUint16sLengths](enc *Encoder, ns []T) {
+	for i := 0; i < len(ns); i++ { // don't range loop, T is an array, copy is expensive
+		EncodeArrayOfUint16s(enc, &ns[i])
+	}
+}
+
+// EncodeArrayOfArrayOfUint32s serializes a static array of static arrays of uint32s.
+func EncodeArrayOfArrayOfUint32s[T commonUint32sLengths](enc *Encoder, ns []T) {
+	for i := 0; i < len(ns); i++ { // don't range loop, T is an array, copy is expensive
+		EncodeArrayOfUint32s(enc, &ns[i])
+	}
+}
+
+// EncodeArrayOfArrayOfUint64s serializes a static array of static arrays of uint64s.
+func EncodeArrayOfArrayOfUint64s[T commonUint64sLengths](enc *Encoder, ns []T) {
+	for i := 0; i < len(ns); i++ { // don't range loop, T is an array, copy is expensive
+		EncodeArrayOfUint64s(enc, &ns[i])
+	}
+}
+
+// EncodeSliceOfUint16sOffset serializes a dynamic slice of uint16s.
+func EncodeSliceOfUint16sOffset[T ~uint16](enc *Encoder, ns []T) {
+	// Nope, dive into actual encoding
 	if enc.outWriter != nil {
 		if enc.err != nil {
 			return
@@ -795,23 +1010,295 @@ func EncodeSliceOfStaticBytesOffset[T commonBytesLengths](enc *Encoder, blobs []
 		binary.LittleEndian.PutUint32(enc.outBuffer, enc.offset)
 		enc.outBuffer = enc.outBuffer[4:]
 	}
-	if items := len(blobs); items > 0 {
-		enc.offset += uint32(items * len(blobs[0]))
+	if items := len(ns); items > 0 {
+		enc.offset += uint32(items * 2)
 	}
+	enc.stats.offsets(1)
 }
 
-// EncodeSliceOfStaticBytesOffsetOnFork serializes a dynamic slice of static binary blobs.
-func EncodeSliceOfStaticBytesOffsetOnFork[T commonBytesLengths](enc *Encoder, blobs []T, filter ForkFilter) {
+// EncodeSliceOfUint16sOffsetOnFork serializes a dynamic slice of uint16s if
+// present in a fork.
+func EncodeSliceOfUint16sOffsetOnFork[T ~uint16](enc *Encoder, ns []T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
+	if !filter.Active(enc.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard encoder
-	EncodeSliceOfStaticBytesOffset(enc, blobs)
+	EncodeSliceOfUint16sOffset(enc, ns)
 }
 
-// EncodeSliceOfStaticBytesContent is the lazy data writer for EncodeSliceOfStaticBytesOffset.
-func EncodeSliceOfStaticBytesContent[T commonBytesLengths](enc *Encoder, blobs []T) {
+// EncodeSliceOfUint16sContent is the lazy data writer for EncodeSliceOfUint16sOffset.
+func EncodeSliceOfUint16sContent[T ~uint16](enc *Encoder, ns []T) {
+	enc.stats.contents(1)
+	if enc.outWriter != nil {
+		for _, n := range ns {
+			if enc.err != nil {
+				return
+			}
+			enc.codec.order.putUint16(enc.buf[:2], (uint16)(n))
+			_, enc.err = enc.outWriter.Write(enc.buf[:2])
+		}
+	} else {
+		for _, n := range ns {
+			enc.codec.order.putUint16(enc.outBuffer, (uint16)(n))
+			enc.outBuffer = enc.outBuffer[2:]
+		}
+	}
+}
+
+// EncodeSliceOfUint16sContentOnFork is the lazy data writer for EncodeSliceOfUint16sOffsetOnFork.
+func EncodeSliceOfUint16sContentOnFork[T ~uint16](enc *Encoder, ns []T, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(enc.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	EncodeSliceOfUint16sContent(enc, ns)
+}
+
+// EncodeSliceOfUint32sOffset serializes a dynamic slice of uint32s.
+func EncodeSliceOfUint32sOffset[T ~uint32](enc *Encoder, ns []T) {
+	// Nope, dive into actual encoding
+	if enc.outWriter != nil {
+		if enc.err != nil {
+			return
+		}
+		binary.LittleEndian.PutUint32(enc.buf[:4], enc.offset)
+		_, enc.err = enc.outWriter.Write(enc.buf[:4])
+	} else {
+		binary.LittleEndian.PutUint32(enc.outBuffer, enc.offset)
+		enc.outBuffer = enc.outBuffer[4:]
+	}
+	if items := len(ns); items > 0 {
+		enc.offset += uint32(items * 4)
+	}
+	enc.stats.offsets(1)
+}
+
+// EncodeSliceOfUint32sOffsetOnFork serializes a dynamic slice of uint32s if
+// present in a fork.
+func EncodeSliceOfUint32sOffsetOnFork[T ~uint32](enc *Encoder, ns []T, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(enc.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	EncodeSliceOfUint32sOffset(enc, ns)
+}
+
+// EncodeSliceOfUint32sContent is the lazy data writer for EncodeSliceOfUint32sOffset.
+func EncodeSliceOfUint32sContent[T ~uint32](enc *Encoder, ns []T) {
+	enc.stats.contents(1)
+	if enc.outWriter != nil {
+		for _, n := range ns {
+			if enc.err != nil {
+				return
+			}
+			enc.codec.order.putUint32(enc.buf[:4], (uint32)(n))
+			_, enc.err = enc.outWriter.Write(enc.buf[:4])
+		}
+	} else {
+		for _, n := range ns {
+			enc.codec.order.putUint32(enc.outBuffer, (uint32)(n))
+			enc.outBuffer = enc.outBuffer[4:]
+		}
+	}
+}
+
+// EncodeSliceOfUint32sContentOnFork is the lazy data writer for EncodeSliceOfUint32sOffsetOnFork.
+func EncodeSliceOfUint32sContentOnFork[T ~uint32](enc *Encoder, ns []T, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(enc.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	EncodeSliceOfUint32sContent(enc, ns)
+}
+
+// EncodeSliceOfUint64sOffset serializes a dynamic slice of uint64s.
+func EncodeSliceOfUint64sOffset[T ~uint64](enc *Encoder, ns []T) {
+	// Nope, dive into actual encoding
+	if enc.outWriter != nil {
+		if enc.err != nil {
+			return
+		}
+		binary.LittleEndian.PutUint32(enc.buf[:4], enc.offset)
+		_, enc.err = enc.outWriter.Write(enc.buf[:4])
+	} else {
+		binary.LittleEndian.PutUint32(enc.outBuffer, enc.offset)
+		enc.outBuffer = enc.outBuffer[4:]
+	}
+	if items := len(ns); items > 0 {
+		enc.offset += uint32(items * 8)
+	}
+	enc.stats.offsets(1)
+}
+
+// EncodeSliceOfUint64sOffsetOnFork serializes a dynamic slice of uint64s if
+// present in a fork.
+func EncodeSliceOfUint64sOffsetOnFork[T ~uint64](enc *Encoder, ns []T, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(enc.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	EncodeSliceOfUint64sOffset(enc, ns)
+}
+
+// EncodeSliceOfUint64sContent is the lazy data writer for EncodeSliceOfUint64sOffset.
+func EncodeSliceOfUint64sContent[T ~uint64](enc *Encoder, ns []T) {
+	enc.stats.contents(1)
+	if enc.outWriter != nil {
+		for _, n := range ns {
+			if enc.err != nil {
+				return
+			}
+			enc.codec.order.putUint64(enc.buf[:8], (uint64)(n))
+			_, enc.err = enc.outWriter.Write(enc.buf[:8])
+		}
+	} else {
+		for _, n := range ns {
+			enc.codec.order.putUint64(enc.outBuffer, (uint64)(n))
+			enc.outBuffer = enc.outBuffer[8:]
+		}
+	}
+}
+
+// EncodeSliceOfUint64sContentOnFork is the lazy data writer for EncodeSliceOfUint64sOffsetOnFork.
+func EncodeSliceOfUint64sContentOnFork[T ~uint64](enc *Encoder, ns []T, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(enc.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	EncodeSliceOfUint64sContent(enc, ns)
+}
+
+// EncodeSliceOfUint64sPointerOffsetOnFork serializes a dynamic slice of uint64s
+// behind a pointer if present in a fork. A nil pointer is encoded as an empty
+// list.
+func EncodeSliceOfUint64sPointerOffsetOnFork[T ~uint64](enc *Encoder, ns *[]T, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(enc.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	if ns == nil {
+		EncodeSliceOfUint64sOffset[T](enc, nil)
+		return
+	}
+	EncodeSliceOfUint64sOffset(enc, *ns)
+}
+
+// EncodeSliceOfUint64sPointerContentOnFork is the lazy data writer for
+// EncodeSliceOfUint64sPointerOffsetOnFork.
+func EncodeSliceOfUint64sPointerContentOnFork[T ~uint64](enc *Encoder, ns *[]T, filter ForkFilter) {
+	// If the field is not active in the current fork, or is nil, early return
+	if !filter.Active(enc.codec.fork) || ns == nil {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	EncodeSliceOfUint64sContent(enc, *ns)
+}
+
+// EncodeArrayOfStaticBytes serializes a static array of static binary
+// blobs.
+//
+// The reason the blobs is passed by pointer and not by value is to prevent it
+// from escaping to the heap (and incurring an allocation) when passing it to
+// the output stream.
+func EncodeArrayOfStaticBytes[T commonBytesArrayLengths[U], U commonBytesLengths](enc *Encoder, blobs *T) {
+	// The code below should have used `(*blobs)[:]`, alas Go's generics compiler
+	// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
+	EncodeUnsafeArrayOfStaticBytes(enc, unsafe.Slice(&(*blobs)[0], len(*blobs)))
+}
+
+// EncodeUnsafeArrayOfStaticBytes serializes a static array of static binary
+// blobs.
+func EncodeUnsafeArrayOfStaticBytes[T commonBytesLengths](enc *Encoder, blobs []T) {
+	// Internally this method is essentially calling EncodeStaticBytes on all
+	// the blobs in a loop. Practically, we've inlined that call to make things
+	// a *lot* faster.
+	if enc.outWriter != nil {
+		for i := 0; i < len(blobs); i++ { // don't range loop, T might be an array, copy is expensive
+			if enc.err != nil {
+				return
+			}
+			// The code below should have used `blobs[i][:]`, alas Go's generics compiler
+			// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
+			_, enc.err = enc.outWriter.Write(unsafe.Slice(&blobs[i][0], len(blobs[i])))
+		}
+	} else {
+		for i := 0; i < len(blobs); i++ { // don't range loop, T might be an array, copy is expensive
+			// The code below should have used `blobs[i][:]`, alas Go's generics compiler
+			// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
+			copy(enc.outBuffer, unsafe.Slice(&blobs[i][0], len(blobs[i])))
+			enc.outBuffer = enc.outBuffer[len(blobs[i]):]
+		}
+	}
+}
+
+// EncodeCheckedArrayOfStaticBytes serializes a static array of static binary
+// blobs.
+func EncodeCheckedArrayOfStaticBytes[T commonBytesLengths](enc *Encoder, blobs []T, size uint64) {
+	// If the blobs are nil, write a batch of zeroes and exit
+	if blobs == nil {
+		enc.encodeZeroes(int(size) * reflect.TypeFor[T]().Len())
+		return
+	}
+	// Internally this method is essentially calling EncodeStaticBytes on all
+	// the blobs in a loop. Practically, we've inlined that call to make things
+	// a *lot* faster.
+	if enc.outWriter != nil {
+		for i := 0; i < len(blobs); i++ { // don't range loop, T might be an array, copy is expensive
+			if enc.err != nil {
+				return
+			}
+			// The code below should have used `blobs[i][:]`, alas Go's generics compiler
+			// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
+			_, enc.err = enc.outWriter.Write(unsafe.Slice(&blobs[i][0], len(blobs[i])))
+		}
+	} else {
+		for i := 0; i < len(blobs); i++ { // don't range loop, T might be an array, copy is expensive
+			// The code below should have used `blobs[i][:]`, alas Go's generics compiler
+			// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
+			copy(enc.outBuffer, unsafe.Slice(&blobs[i][0], len(blobs[i])))
+			enc.outBuffer = enc.outBuffer[len(blobs[i]):]
+		}
+	}
+}
+
+// EncodeSliceOfStaticBytesOffset serializes a dynamic slice of static binary blobs.
+func EncodeSliceOfStaticBytesOffset[T commonBytesLengths](enc *Encoder, blobs []T) {
+	if enc.outWriter != nil {
+		if enc.err != nil {
+			return
+		}
+		binary.LittleEndian.PutUint32(enc.buf[:4], enc.offset)
+		_, enc.err = enc.outWriter.Write(enc.buf[:4])
+	} else {
+		binary.LittleEndian.PutUint32(enc.outBuffer, enc.offset)
+		enc.outBuffer = enc.outBuffer[4:]
+	}
+	if items := len(blobs); items > 0 {
+		enc.offset += uint32(items * len(blobs[0]))
+	}
+	enc.stats.offsets(1)
+}
+
+// EncodeSliceOfStaticBytesOffsetOnFork serializes a dynamic slice of static binary blobs.
+func EncodeSliceOfStaticBytesOffsetOnFork[T commonBytesLengths](enc *Encoder, blobs []T, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(enc.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	EncodeSliceOfStaticBytesOffset(enc, blobs)
+}
+
+// EncodeSliceOfStaticBytesContent is the lazy data writer for EncodeSliceOfStaticBytesOffset.
+func EncodeSliceOfStaticBytesContent[T commonBytesLengths](enc *Encoder, blobs []T) {
+	enc.stats.contents(1)
 	// Internally this method is essentially calling EncodeStaticBytes on all
 	// the blobs in a loop. Practically, we've inlined that call to make things
 	// a *lot* faster.
@@ -837,13 +1324,142 @@ func EncodeSliceOfStaticBytesContent[T commonBytesLengths](enc *Encoder, blobs [
 // EncodeSliceOfStaticBytesContentOnFork is the lazy data writer for EncodeSliceOfStaticBytesOffsetOnFork.
 func EncodeSliceOfStaticBytesContentOnFork[T commonBytesLengths](enc *Encoder, blobs []T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
+	if !filter.Active(enc.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard encoder
 	EncodeSliceOfStaticBytesContent(enc, blobs)
 }
 
+// EncodeSliceOfStaticBytesPointerOffsetOnFork serializes a dynamic slice of
+// static binary blobs behind a pointer if present in a fork. A nil pointer is
+// encoded as an empty list.
+func EncodeSliceOfStaticBytesPointerOffsetOnFork[T commonBytesLengths](enc *Encoder, blobs *[]T, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(enc.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	if blobs == nil {
+		EncodeSliceOfStaticBytesOffset[T](enc, nil)
+		return
+	}
+	EncodeSliceOfStaticBytesOffset(enc, *blobs)
+}
+
+// EncodeSliceOfStaticBytesPointerContentOnFork is the lazy data writer for
+// EncodeSliceOfStaticBytesPointerOffsetOnFork.
+func EncodeSliceOfStaticBytesPointerContentOnFork[T commonBytesLengths](enc *Encoder, blobs *[]T, filter ForkFilter) {
+	// If the field is not active in the current fork, or is nil, early return
+	if !filter.Active(enc.codec.fork) || blobs == nil {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	EncodeSliceOfStaticBytesContent(enc, *blobs)
+}
+
+// EncodeCheckedArrayOfDynamicBytesOffset serializes a static array of dynamic
+// binary blobs.
+func EncodeCheckedArrayOfDynamicBytesOffset(enc *Encoder, blobs [][]byte, size uint64) {
+	if enc.outWriter != nil {
+		if enc.err != nil {
+			return
+		}
+		binary.LittleEndian.PutUint32(enc.buf[:4], enc.offset)
+		_, enc.err = enc.outWriter.Write(enc.buf[:4])
+	} else {
+		binary.LittleEndian.PutUint32(enc.outBuffer, enc.offset)
+		enc.outBuffer = enc.outBuffer[4:]
+	}
+	enc.offset += uint32(4 * size)
+	for i := 0; i < len(blobs) && i < int(size); i++ {
+		enc.offset += uint32(len(blobs[i]))
+	}
+	enc.stats.offsets(1)
+}
+
+// EncodeCheckedArrayOfDynamicBytesOffsetOnFork serializes a static array of
+// dynamic binary blobs if present in a fork.
+func EncodeCheckedArrayOfDynamicBytesOffsetOnFork(enc *Encoder, blobs [][]byte, size uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(enc.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	EncodeCheckedArrayOfDynamicBytesOffset(enc, blobs, size)
+}
+
+// EncodeCheckedArrayOfDynamicBytesContent is the lazy data writer for EncodeCheckedArrayOfDynamicBytesOffset.
+func EncodeCheckedArrayOfDynamicBytesContent(enc *Encoder, blobs [][]byte, size uint64) {
+	// If the array is nil, encode all the items as empty blobs; if it's of any
+	// other length than expected, reject it.
+	if blobs != nil && uint64(len(blobs)) != size {
+		if enc.err == nil {
+			enc.err = fmt.Errorf("%w: encoding %d, type expects %d", ErrArrayLengthMismatch, len(blobs), size)
+		}
+		return
+	}
+	enc.stats.contents(1 + int(size))
+	enc.offsetDynamics(uint32(4 * size))
+
+	// Inline:
+	//
+	//	for _, blob := range blobs {
+	//		EncodeDynamicBytesOffset(enc, blob)
+	//	}
+	if enc.outWriter != nil {
+		for i := uint64(0); i < size; i++ {
+			if enc.err != nil {
+				return
+			}
+			binary.LittleEndian.PutUint32(enc.buf[:4], enc.offset)
+			_, enc.err = enc.outWriter.Write(enc.buf[:4])
+
+			if i < uint64(len(blobs)) {
+				enc.offset += uint32(len(blobs[i]))
+			}
+		}
+	} else {
+		for i := uint64(0); i < size; i++ {
+			binary.LittleEndian.PutUint32(enc.outBuffer, enc.offset)
+			enc.outBuffer = enc.outBuffer[4:]
+
+			if i < uint64(len(blobs)) {
+				enc.offset += uint32(len(blobs[i]))
+			}
+		}
+	}
+	enc.stats.offsets(int(size))
+	// Inline:
+	//
+	// 	for _, blob := range blobs {
+	//		EncodeDynamicBytesContent(enc, blob)
+	//	}
+	if enc.outWriter != nil {
+		for _, blob := range blobs {
+			if enc.err != nil {
+				return
+			}
+			_, enc.err = enc.outWriter.Write(blob)
+		}
+	} else {
+		for _, blob := range blobs {
+			copy(enc.outBuffer, blob)
+			enc.outBuffer = enc.outBuffer[len(blob):]
+		}
+	}
+}
+
+// EncodeCheckedArrayOfDynamicBytesContentOnFork is the lazy data writer for EncodeCheckedArrayOfDynamicBytesOffsetOnFork.
+func EncodeCheckedArrayOfDynamicBytesContentOnFork(enc *Encoder, blobs [][]byte, size uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(enc.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	EncodeCheckedArrayOfDynamicBytesContent(enc, blobs, size)
+}
+
 // EncodeSliceOfDynamicBytesOffset serializes a dynamic slice of dynamic binary
 // blobs.
 func EncodeSliceOfDynamicBytesOffset(enc *Encoder, blobs [][]byte) {
@@ -860,13 +1476,14 @@ func EncodeSliceOfDynamicBytesOffset(enc *Encoder, blobs [][]byte) {
 	for _, blob := range blobs {
 		enc.offset += uint32(4 + len(blob))
 	}
+	enc.stats.offsets(1)
 }
 
 // EncodeSliceOfDynamicBytesOffsetOnFork serializes a dynamic slice of dynamic
 // binary blob if present in a fork.
 func EncodeSliceOfDynamicBytesOffsetOnFork(enc *Encoder, blobs [][]byte, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
+	if !filter.Active(enc.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard encoder
@@ -875,6 +1492,7 @@ func EncodeSliceOfDynamicBytesOffsetOnFork(enc *Encoder, blobs [][]byte, filter
 
 // EncodeSliceOfDynamicBytesContent is the lazy data writer for EncodeSliceOfDynamicBytesOffset.
 func EncodeSliceOfDynamicBytesContent(enc *Encoder, blobs [][]byte) {
+	enc.stats.contents(1 + len(blobs))
 	// Nope, dive into actual encoding
 	enc.offsetDynamics(uint32(4 * len(blobs)))
 
@@ -901,6 +1519,7 @@ func EncodeSliceOfDynamicBytesContent(enc *Encoder, blobs [][]byte) {
 			enc.offset += uint32(len(blob))
 		}
 	}
+	enc.stats.offsets(len(blobs))
 	// Inline:
 	//
 	// 	for _, blob := range blobs {
@@ -924,7 +1543,7 @@ func EncodeSliceOfDynamicBytesContent(enc *Encoder, blobs [][]byte) {
 // EncodeSliceOfDynamicBytesContentOnFork is the lazy data writer for EncodeSliceOfDynamicBytesOffsetOnFork.
 func EncodeSliceOfDynamicBytesContentOnFork(enc *Encoder, blobs [][]byte, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
+	if !filter.Active(enc.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard encoder
@@ -946,13 +1565,14 @@ func EncodeSliceOfStaticObjectsOffset[T StaticObject](enc *Encoder, objects []T)
 	if items := len(objects); items > 0 {
 		enc.offset += uint32(items) * objects[0].SizeSSZ(enc.sizer)
 	}
+	enc.stats.offsets(1)
 }
 
 // EncodeSliceOfStaticObjectsOffsetOnFork serializes a dynamic slice of static ssz
 // objects if present in a fork.
 func EncodeSliceOfStaticObjectsOffsetOnFork[T StaticObject](enc *Encoder, objects []T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
+	if !filter.Active(enc.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard encoder
@@ -961,24 +1581,109 @@ func EncodeSliceOfStaticObjectsOffsetOnFork[T StaticObject](enc *Encoder, object
 
 // EncodeSliceOfStaticObjectsContent is the lazy data writer for EncodeSliceOfStaticObjectsOffset.
 func EncodeSliceOfStaticObjectsContent[T StaticObject](enc *Encoder, objects []T) {
-	for _, obj := range objects {
+	enc.stats.contents(1)
+	for i, obj := range objects {
 		if enc.err != nil {
 			return
 		}
-		obj.DefineSSZ(enc.codec)
+		enc.encodeObject(obj)
+		if enc.err != nil {
+			enc.annotateError("[" + strconv.Itoa(i) + "]")
+		}
 	}
 }
 
 // EncodeSliceOfStaticObjectsContentOnFork is the lazy data writer for EncodeSliceOfStaticObjectsOffsetOnFork.
 func EncodeSliceOfStaticObjectsContentOnFork[T StaticObject](enc *Encoder, objects []T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
+	if !filter.Active(enc.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard encoder
 	EncodeSliceOfStaticObjectsContent(enc, objects)
 }
 
+// EncodeSliceOfStaticObjectValuesOffset serializes a dynamic slice of static ssz
+// objects stored by value.
+func EncodeSliceOfStaticObjectValuesOffset[T newableStaticObject[U], U any](enc *Encoder, objects []U) {
+	if enc.outWriter != nil {
+		if enc.err != nil {
+			return
+		}
+		binary.LittleEndian.PutUint32(enc.buf[:4], enc.offset)
+		_, enc.err = enc.outWriter.Write(enc.buf[:4])
+	} else {
+		binary.LittleEndian.PutUint32(enc.outBuffer, enc.offset)
+		enc.outBuffer = enc.outBuffer[4:]
+	}
+	if items := len(objects); items > 0 {
+		enc.offset += uint32(items) * T(&objects[0]).SizeSSZ(enc.sizer)
+	}
+	enc.stats.offsets(1)
+}
+
+// EncodeSliceOfStaticObjectValuesOffsetOnFork serializes a dynamic slice of static
+// ssz objects stored by value if present in a fork.
+func EncodeSliceOfStaticObjectValuesOffsetOnFork[T newableStaticObject[U], U any](enc *Encoder, objects []U, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(enc.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	EncodeSliceOfStaticObjectValuesOffset[T](enc, objects)
+}
+
+// EncodeSliceOfStaticObjectValuesContent is the lazy data writer for EncodeSliceOfStaticObjectValuesOffset.
+func EncodeSliceOfStaticObjectValuesContent[T newableStaticObject[U], U any](enc *Encoder, objects []U) {
+	enc.stats.contents(1)
+	for i := range objects {
+		if enc.err != nil {
+			return
+		}
+		enc.encodeObject(T(&objects[i]))
+		if enc.err != nil {
+			enc.annotateError("[" + strconv.Itoa(i) + "]")
+		}
+	}
+}
+
+// EncodeSliceOfStaticObjectValuesContentOnFork is the lazy data writer for EncodeSliceOfStaticObjectValuesOffsetOnFork.
+func EncodeSliceOfStaticObjectValuesContentOnFork[T newableStaticObject[U], U any](enc *Encoder, objects []U, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(enc.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	EncodeSliceOfStaticObjectValuesContent[T](enc, objects)
+}
+
+// EncodeSliceOfStaticObjectsPointerOffsetOnFork serializes a dynamic slice of
+// static ssz objects behind a pointer if present in a fork. A nil pointer is
+// encoded as an empty list.
+func EncodeSliceOfStaticObjectsPointerOffsetOnFork[T StaticObject](enc *Encoder, objects *[]T, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(enc.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	if objects == nil {
+		EncodeSliceOfStaticObjectsOffset[T](enc, nil)
+		return
+	}
+	EncodeSliceOfStaticObjectsOffset(enc, *objects)
+}
+
+// EncodeSliceOfStaticObjectsPointerContentOnFork is the lazy data writer for
+// EncodeSliceOfStaticObjectsPointerOffsetOnFork.
+func EncodeSliceOfStaticObjectsPointerContentOnFork[T StaticObject](enc *Encoder, objects *[]T, filter ForkFilter) {
+	// If the field is not active in the current fork, or is nil, early return
+	if !filter.Active(enc.codec.fork) || objects == nil {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	EncodeSliceOfStaticObjectsContent(enc, *objects)
+}
+
 // EncodeSliceOfDynamicObjectsOffset serializes a dynamic slice of dynamic ssz
 // objects.
 func EncodeSliceOfDynamicObjectsOffset[T DynamicObject](enc *Encoder, objects []T) {
@@ -993,15 +1698,16 @@ func EncodeSliceOfDynamicObjectsOffset[T DynamicObject](enc *Encoder, objects []
 		enc.outBuffer = enc.outBuffer[4:]
 	}
 	for _, obj := range objects {
-		enc.offset += 4 + obj.SizeSSZ(enc.sizer, false)
+		enc.offset += 4 + enc.sizer.sizeDynamic(obj)
 	}
+	enc.stats.offsets(1)
 }
 
 // EncodeSliceOfDynamicObjectsOffsetOnFork serializes a dynamic slice of dynamic
 // ssz objects if present in a fork.
 func EncodeSliceOfDynamicObjectsOffsetOnFork[T DynamicObject](enc *Encoder, objects []T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
+	if !filter.Active(enc.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard encoder
@@ -1010,6 +1716,7 @@ func EncodeSliceOfDynamicObjectsOffsetOnFork[T DynamicObject](enc *Encoder, obje
 
 // EncodeSliceOfDynamicObjectsContent is the lazy data writer for EncodeSliceOfDynamicObjectsOffset.
 func EncodeSliceOfDynamicObjectsContent[T DynamicObject](enc *Encoder, objects []T) {
+	enc.stats.contents(1 + len(objects))
 	enc.offsetDynamics(uint32(4 * len(objects)))
 
 	// Inline:
@@ -1025,46 +1732,208 @@ func EncodeSliceOfDynamicObjectsContent[T DynamicObject](enc *Encoder, objects [
 			binary.LittleEndian.PutUint32(enc.buf[:4], enc.offset)
 			_, enc.err = enc.outWriter.Write(enc.buf[:4])
 
-			enc.offset += obj.SizeSSZ(enc.sizer, false)
+			enc.offset += enc.sizer.sizeDynamic(obj)
 		}
 	} else {
 		for _, obj := range objects {
 			binary.LittleEndian.PutUint32(enc.outBuffer, enc.offset)
 			enc.outBuffer = enc.outBuffer[4:]
 
-			enc.offset += obj.SizeSSZ(enc.sizer, false)
+			enc.offset += enc.sizer.sizeDynamic(obj)
 		}
 	}
+	enc.stats.offsets(len(objects))
 	// Inline:
 	//
 	// 	for _, obj := range objects {
 	//		EncodeDynamicObjectContent(enc, obj)
 	//	}
-	for _, obj := range objects {
+	for i, obj := range objects {
 		if enc.err != nil {
 			return
 		}
 		enc.offsetDynamics(obj.SizeSSZ(enc.sizer, true))
-		obj.DefineSSZ(enc.codec)
+		enc.stats.enter()
+		enc.encodeObject(obj)
+		enc.stats.leave()
+		if enc.err != nil {
+			enc.annotateError("[" + strconv.Itoa(i) + "]")
+		}
 	}
 }
 
 // EncodeSliceOfDynamicObjectsContentOnFork is the lazy data writer for EncodeSliceOfDynamicObjectsOffsetOnFork.
 func EncodeSliceOfDynamicObjectsContentOnFork[T DynamicObject](enc *Encoder, objects []T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if enc.codec.fork < filter.Added || (filter.Removed > ForkUnknown && enc.codec.fork >= filter.Removed) {
+	if !filter.Active(enc.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard encoder
 	EncodeSliceOfDynamicObjectsContent(enc, objects)
 }
 
+// EncodeSliceOfDynamicObjectsPointerOffsetOnFork serializes a dynamic slice of
+// dynamic ssz objects behind a pointer if present in a fork. A nil pointer is
+// encoded as an empty list.
+func EncodeSliceOfDynamicObjectsPointerOffsetOnFork[T DynamicObject](enc *Encoder, objects *[]T, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(enc.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	if objects == nil {
+		EncodeSliceOfDynamicObjectsOffset[T](enc, nil)
+		return
+	}
+	EncodeSliceOfDynamicObjectsOffset(enc, *objects)
+}
+
+// EncodeSliceOfDynamicObjectsPointerContentOnFork is the lazy data writer for
+// EncodeSliceOfDynamicObjectsPointerOffsetOnFork.
+func EncodeSliceOfDynamicObjectsPointerContentOnFork[T DynamicObject](enc *Encoder, objects *[]T, filter ForkFilter) {
+	// If the field is not active in the current fork, or is nil, early return
+	if !filter.Active(enc.codec.fork) || objects == nil {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	EncodeSliceOfDynamicObjectsContent(enc, *objects)
+}
+
+// EncodeMapOfStaticEntriesOffset serializes a map as a dynamic slice of static ssz
+// key/value containers, sorted by key.
+func EncodeMapOfStaticEntriesOffset[T newableStaticMapEntry[K, V, U], U any, K comparable, V any](enc *Encoder, m map[K]V) {
+	EncodeSliceOfStaticObjectsOffset(enc, mapToEntries[T](enc.codec, m))
+}
+
+// EncodeMapOfStaticEntriesOffsetOnFork serializes a map as a dynamic slice of static
+// ssz key/value containers, sorted by key, if present in a fork.
+func EncodeMapOfStaticEntriesOffsetOnFork[T newableStaticMapEntry[K, V, U], U any, K comparable, V any](enc *Encoder, m map[K]V, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(enc.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	EncodeMapOfStaticEntriesOffset[T](enc, m)
+}
+
+// EncodeMapOfStaticEntriesContent is the lazy data writer for EncodeMapOfStaticEntriesOffset.
+func EncodeMapOfStaticEntriesContent[T newableStaticMapEntry[K, V, U], U any, K comparable, V any](enc *Encoder, m map[K]V) {
+	EncodeSliceOfStaticObjectsContent(enc, mapToEntries[T](enc.codec, m))
+}
+
+// EncodeMapOfStaticEntriesContentOnFork is the lazy data writer for EncodeMapOfStaticEntriesOffsetOnFork.
+func EncodeMapOfStaticEntriesContentOnFork[T newableStaticMapEntry[K, V, U], U any, K comparable, V any](enc *Encoder, m map[K]V, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(enc.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	EncodeMapOfStaticEntriesContent[T](enc, m)
+}
+
+// EncodeMapOfDynamicEntriesOffset serializes a map as a dynamic slice of dynamic ssz
+// key/value containers, sorted by key.
+func EncodeMapOfDynamicEntriesOffset[T newableDynamicMapEntry[K, V, U], U any, K comparable, V any](enc *Encoder, m map[K]V) {
+	EncodeSliceOfDynamicObjectsOffset(enc, mapToEntries[T](enc.codec, m))
+}
+
+// EncodeMapOfDynamicEntriesOffsetOnFork serializes a map as a dynamic slice of dynamic
+// ssz key/value containers, sorted by key, if present in a fork.
+func EncodeMapOfDynamicEntriesOffsetOnFork[T newableDynamicMapEntry[K, V, U], U any, K comparable, V any](enc *Encoder, m map[K]V, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(enc.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	EncodeMapOfDynamicEntriesOffset[T](enc, m)
+}
+
+// EncodeMapOfDynamicEntriesContent is the lazy data writer for EncodeMapOfDynamicEntriesOffset.
+func EncodeMapOfDynamicEntriesContent[T newableDynamicMapEntry[K, V, U], U any, K comparable, V any](enc *Encoder, m map[K]V) {
+	EncodeSliceOfDynamicObjectsContent(enc, mapToEntries[T](enc.codec, m))
+}
+
+// EncodeMapOfDynamicEntriesContentOnFork is the lazy data writer for EncodeMapOfDynamicEntriesOffsetOnFork.
+func EncodeMapOfDynamicEntriesContentOnFork[T newableDynamicMapEntry[K, V, U], U any, K comparable, V any](enc *Encoder, m map[K]V, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(enc.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard encoder
+	EncodeMapOfDynamicEntriesContent[T](enc, m)
+}
+
 // offsetDynamics marks the item being encoded as a dynamic type, setting the starting
 // offset for the dynamic fields.
 func (enc *Encoder) offsetDynamics(offset uint32) {
 	enc.offset = offset
 }
 
+// encodeNested serializes a standalone object, suspending the dynamic offset
+// tracking of the outer object for the duration.
+func (enc *Encoder) encodeNested(obj Object) {
+	if enc.err != nil {
+		return
+	}
+	offset := enc.offset
+	dyn, ok := obj.(DynamicObject)
+	if ok {
+		enc.offsetDynamics(dyn.SizeSSZ(enc.sizer, true))
+		enc.stats.enter()
+	}
+	enc.encodeObject(obj)
+	if ok {
+		enc.stats.leave()
+	}
+	enc.offset = offset
+}
+
+// nextField marks the start of encoding a new field within the current object.
+// The counter is frozen after a failure so the erroring field can be reported.
+func (enc *Encoder) nextField() {
+	if enc.err != nil {
+		return
+	}
+	enc.field++
+	enc.stats.field()
+	if enc.trace != nil {
+		enc.trace.field(enc)
+	}
+}
+
+// encodeObject runs the field definitions of an ssz object, annotating any error
+// with the name of the field that failed (or its index if the names are unknown).
+func (enc *Encoder) encodeObject(obj Object) {
+	field := enc.field
+	enc.field = 0
+
+	if enc.trace != nil {
+		enc.trace.depth++
+	}
+	obj.DefineSSZ(enc.codec)
+	if enc.trace != nil {
+		enc.trace.depth--
+	}
+	if enc.err != nil && enc.field > 0 {
+		enc.annotateError(fieldName(obj, enc.field-1))
+	}
+	enc.field = field
+}
+
+// annotateError prepends a path segment (field name or slice index) to the path
+// of the current encoding error, wrapping it into an EncodeError if needed.
+func (enc *Encoder) annotateError(segment string) {
+	if err, ok := enc.err.(*EncodeError); ok {
+		if strings.HasPrefix(err.Path, "[") {
+			err.Path = segment + err.Path
+		} else {
+			err.Path = segment + "." + err.Path
+		}
+		return
+	}
+	enc.err = &EncodeError{Path: segment, Err: enc.err}
+}
+
 // encodeZeroes is a helper to append a bunch of zero values to the output stream.
 // This method is mainly used for encoding uninitialized fields without allocating
 // them beforehand.
@@ -1094,3 +1963,43 @@ func (enc *Encoder) encodeZeroes(size int) {
 		}
 	}
 }
+
+// checkItems is a helper to validate the number of items in a dynamic list when
+// running in checked mode.
+func (enc *Encoder) checkItems(items int, maxItems uint64) {
+	if enc.err == nil && uint64(items) > maxItems {
+		enc.err = fmt.Errorf("%w: encoding %d, max %d", ErrMaxItemsExceeded, items, maxItems)
+	}
+}
+
+// checkBytes is a helper to validate the size of a dynamic blob when running in
+// checked mode.
+func (enc *Encoder) checkBytes(size int, maxSize uint64) {
+	if enc.err == nil && uint64(size) > maxSize {
+		enc.err = fmt.Errorf("%w: encoding %d, max %d", ErrMaxLengthExceeded, size, maxSize)
+	}
+}
+
+// checkBlobs is a helper to validate the size of a batch of dynamic blobs when
+// running in checked mode.
+func (enc *Encoder) checkBlobs(blobs [][]byte, maxSize uint64) {
+	for _, blob := range blobs {
+		enc.checkBytes(len(blob), maxSize)
+	}
+}
+
+// checkUint256 is a helper to validate the value of a uint256 when running in
+// checked mode.
+func (enc *Encoder) checkUint256(n *uint256.Int, max *uint256.Int) {
+	if enc.err == nil && n != nil && n.Gt(max) {
+		enc.err = fmt.Errorf("%w: encoding %s, max %s", ErrMaxValueExceeded, n.Dec(), max.Dec())
+	}
+}
+
+// checkUint256BigInt is a helper to validate the value of a big.Int encoded as a
+// uint256 when running in checked mode.
+func (enc *Encoder) checkUint256BigInt(n *big.Int, max *uint256.Int) {
+	if enc.err == nil && n != nil && n.Cmp(max.ToBig()) > 0 {
+		enc.err = fmt.Errorf("%w: encoding %s, max %s", ErrMaxValueExceeded, n, max.Dec())
+	}
+}
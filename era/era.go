@@ -0,0 +1,85 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package era implements reading and writing era archive files: e2store files
+// holding the snappy compressed ssz blocks of a chain segment, the state at its
+// end, and slot indices to locate them.
+//
+// The layout of an era file is:
+//
+//	Version | block* | state | slot-index(block)? | slot-index(state)
+package era
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// EntryType is the 2 byte type tag of an e2store entry.
+type EntryType [2]byte
+
+var (
+	// TypeEmpty is an entry without semantic meaning, skipped by readers.
+	TypeEmpty = EntryType{0x00, 0x00}
+
+	// TypeVersion is the entry with which every e2store file starts.
+	TypeVersion = EntryType{0x65, 0x32}
+
+	// TypeCompressedSignedBeaconBlock is an entry holding a snappy framed ssz
+	// encoded signed beacon block.
+	TypeCompressedSignedBeaconBlock = EntryType{0x01, 0x00}
+
+	// TypeCompressedBeaconState is an entry holding a snappy framed ssz encoded
+	// beacon state.
+	TypeCompressedBeaconState = EntryType{0x02, 0x00}
+
+	// TypeSlotIndex is an entry holding the offsets of the entries of a range of
+	// slots, relative to the start of the index entry.
+	TypeSlotIndex = EntryType{0x69, 0x32}
+)
+
+// headerSize is the size of an e2store entry header: 2 bytes of type, 4 bytes
+// of little endian data length and 2 reserved zero bytes.
+const headerSize = 8
+
+// ErrInvalidFormat is returned when an era file does not conform to the e2store
+// or era format.
+var ErrInvalidFormat = errors.New("era: invalid file format")
+
+// ErrEmptySlot is returned when attempting to read the block of a slot in which
+// no block was proposed.
+var ErrEmptySlot = errors.New("era: no block in slot")
+
+// ErrSlotOutOfRange is returned when attempting to read the block of a slot not
+// covered by the era file.
+var ErrSlotOutOfRange = errors.New("era: slot out of range")
+
+// writeHeader writes the header of an e2store entry with the given data length.
+func writeHeader(w io.Writer, typ EntryType, length int) error {
+	if uint64(length) > math.MaxUint32 {
+		return fmt.Errorf("era: entry too large: %d bytes", length)
+	}
+	var header [headerSize]byte
+	copy(header[:2], typ[:])
+	binary.LittleEndian.PutUint32(header[2:6], uint32(length))
+
+	_, err := w.Write(header[:])
+	return err
+}
+
+// readHeader reads the header of an e2store entry at the given offset, returning
+// its type and data length.
+func readHeader(r io.ReaderAt, offset int64) (EntryType, uint32, error) {
+	var header [headerSize]byte
+	if _, err := r.ReadAt(header[:], offset); err != nil {
+		return EntryType{}, 0, err
+	}
+	if header[6] != 0 || header[7] != 0 {
+		return EntryType{}, 0, fmt.Errorf("%w: non-zero reserved header bytes at offset %d", ErrInvalidFormat, offset)
+	}
+	return EntryType{header[0], header[1]}, binary.LittleEndian.Uint32(header[2:6]), nil
+}
@@ -0,0 +1,224 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package era
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/karalabe/ssz"
+)
+
+// Reader is an era file reader, locating the entries via the slot indices and
+// decompressing them into objects via the streaming ssz decoder.
+type Reader struct {
+	r io.ReaderAt // Underlying era file
+
+	start  uint64  // First slot covered by the block index
+	blocks []int64 // Offsets of the blocks since start (0 = empty slot)
+	state  int64   // Offset of the state entry
+	slot   uint64  // Slot of the state entry
+}
+
+// NewReader opens an era file of the given size, loading its slot indices.
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	// Sanity check that the file is an e2store one
+	typ, length, err := readHeader(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	if typ != TypeVersion || length != 0 {
+		return nil, fmt.Errorf("%w: missing version entry", ErrInvalidFormat)
+	}
+	// Load the state index from the end of the file, and the block index right
+	// before that (unless the era contains no blocks at all)
+	reader := &Reader{r: r}
+
+	offset, err := findIndex(r, size)
+	if err != nil {
+		return nil, err
+	}
+	slot, states, err := readIndex(r, offset)
+	if err != nil {
+		return nil, err
+	}
+	if len(states) != 1 || states[0] == 0 {
+		return nil, fmt.Errorf("%w: invalid state index", ErrInvalidFormat)
+	}
+	reader.slot, reader.state = slot, states[0]
+
+	offset, err = findIndex(r, offset)
+	switch {
+	case errors.Is(err, errNoSlotIndex):
+		// No block index, genesis era with only a state
+		reader.start = reader.slot
+	case err != nil:
+		return nil, err
+	default:
+		if reader.start, reader.blocks, err = readIndex(r, offset); err != nil {
+			return nil, err
+		}
+		if reader.start+uint64(len(reader.blocks)) != reader.slot {
+			return nil, fmt.Errorf("%w: block index [%d, %d) not ending at state slot %d", ErrInvalidFormat, reader.start, reader.start+uint64(len(reader.blocks)), reader.slot)
+		}
+	}
+	return reader, nil
+}
+
+// StartSlot returns the first slot covered by the era's blocks.
+func (r *Reader) StartSlot() uint64 {
+	return r.start
+}
+
+// StateSlot returns the slot of the era's state, which is also the first slot
+// not covered by the era's blocks.
+func (r *Reader) StateSlot() uint64 {
+	return r.slot
+}
+
+// ReadBlock decompresses and decodes the signed beacon block proposed in the
+// given slot, according to the rules of the given fork.
+func (r *Reader) ReadBlock(slot uint64, block ssz.Object, fork ssz.Fork) error {
+	if slot < r.start || slot >= r.start+uint64(len(r.blocks)) {
+		return fmt.Errorf("%w: slot %d, era [%d, %d)", ErrSlotOutOfRange, slot, r.start, r.start+uint64(len(r.blocks)))
+	}
+	offset := r.blocks[slot-r.start]
+	if offset == 0 {
+		return fmt.Errorf("%w: slot %d", ErrEmptySlot, slot)
+	}
+	return r.readEntry(offset, TypeCompressedSignedBeaconBlock, block, fork)
+}
+
+// ReadState decompresses and decodes the era's beacon state, according to the
+// rules of the given fork.
+func (r *Reader) ReadState(state ssz.Object, fork ssz.Fork) error {
+	return r.readEntry(r.state, TypeCompressedBeaconState, state, fork)
+}
+
+// readEntry streams the snappy framed ssz data of an entry into an object.
+func (r *Reader) readEntry(offset int64, want EntryType, obj ssz.Object, fork ssz.Fork) error {
+	typ, length, err := readHeader(r.r, offset)
+	if err != nil {
+		return err
+	}
+	if typ != want {
+		return fmt.Errorf("%w: entry type %x at offset %d, want %x", ErrInvalidFormat, typ, offset, want)
+	}
+	data := io.NewSectionReader(r.r, offset+headerSize, int64(length))
+
+	size, err := framedSize(data, int64(length))
+	if err != nil {
+		return err
+	}
+	return ssz.DecodeFromSnappyStreamOnFork(data, obj, size, fork)
+}
+
+// errNoSlotIndex is returned when no slot index entry ends at the offset being
+// searched. It is a format error, but the missing block index of genesis eras
+// needs to be told apart from other failures.
+var errNoSlotIndex = fmt.Errorf("%w: no slot index", ErrInvalidFormat)
+
+// findIndex locates the slot index entry ending at the given offset, using its
+// trailing count field to compute where it starts. Data not shaped like a slot
+// index is reported as errNoSlotIndex, read failures are returned as is.
+func findIndex(r io.ReaderAt, end int64) (int64, error) {
+	if end < 2*headerSize+16 {
+		return 0, fmt.Errorf("%w before offset %d", errNoSlotIndex, end)
+	}
+	var count [8]byte
+	if _, err := r.ReadAt(count[:], end-8); err != nil {
+		return 0, err
+	}
+	n := binary.LittleEndian.Uint64(count[:])
+	if n > uint64(end-2*headerSize-16)/8 {
+		return 0, fmt.Errorf("%w before offset %d", errNoSlotIndex, end)
+	}
+	offset := end - headerSize - 16 - 8*int64(n)
+
+	typ, length, err := readHeader(r, offset)
+	if errors.Is(err, ErrInvalidFormat) {
+		return 0, fmt.Errorf("%w before offset %d", errNoSlotIndex, end)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if typ != TypeSlotIndex || int64(length) != 16+8*int64(n) {
+		return 0, fmt.Errorf("%w before offset %d", errNoSlotIndex, end)
+	}
+	return offset, nil
+}
+
+// readIndex reads a slot index entry, returning the first slot it covers and
+// the absolute offsets of the entries of the slots (0 for empty ones).
+func readIndex(r io.ReaderAt, offset int64) (uint64, []int64, error) {
+	_, length, err := readHeader(r, offset)
+	if err != nil {
+		return 0, nil, err
+	}
+	data := make([]byte, length)
+	if _, err := r.ReadAt(data, offset+headerSize); err != nil {
+		return 0, nil, err
+	}
+	offsets := make([]int64, (len(data)-16)/8)
+	for i := range offsets {
+		if rel := int64(binary.LittleEndian.Uint64(data[8+8*i:])); rel != 0 {
+			offsets[i] = offset + rel
+		}
+	}
+	return binary.LittleEndian.Uint64(data), offsets, nil
+}
+
+// framedSize computes the uncompressed size of a snappy framed stream, without
+// decompressing it, by summing up the lengths declared by its chunks.
+func framedSize(r io.ReaderAt, length int64) (uint32, error) {
+	var (
+		pos  int64
+		size uint64
+		head [4 + 4 + binary.MaxVarintLen32]byte
+	)
+	for pos < length {
+		if _, err := r.ReadAt(head[:4], pos); err != nil {
+			return 0, err
+		}
+		kind, n := head[0], int64(head[1])|int64(head[2])<<8|int64(head[3])<<16
+
+		switch {
+		case kind == 0x00:
+			// Compressed chunk: checksum, followed by a snappy block prefixed
+			// with its decoded length
+			chunk := head[4:min(int64(len(head)), 4+n)]
+			if _, err := r.ReadAt(chunk, pos+4); err != nil {
+				return 0, err
+			}
+			if len(chunk) < 4 {
+				return 0, fmt.Errorf("%w: corrupt snappy chunk", ErrInvalidFormat)
+			}
+			decoded, k := binary.Uvarint(chunk[4:])
+			if k <= 0 {
+				return 0, fmt.Errorf("%w: corrupt snappy chunk", ErrInvalidFormat)
+			}
+			size += decoded
+
+		case kind == 0x01:
+			// Uncompressed chunk: checksum, followed by the raw data
+			if n < 4 {
+				return 0, fmt.Errorf("%w: corrupt snappy chunk", ErrInvalidFormat)
+			}
+			size += uint64(n - 4)
+
+		case kind < 0x80:
+			return 0, fmt.Errorf("%w: unsupported snappy chunk type %#x", ErrInvalidFormat, kind)
+		}
+		// Stream identifiers, padding and skippable chunks carry no data
+		pos += 4 + n
+	}
+	if size > math.MaxUint32 {
+		return 0, fmt.Errorf("%w: entry too large: %d bytes", ErrInvalidFormat, size)
+	}
+	return uint32(size), nil
+}
@@ -0,0 +1,126 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package era
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/karalabe/ssz"
+)
+
+// Writer is an era file writer, compressing the blocks and the state into the
+// archive via the streaming ssz encoder.
+//
+// Blocks need to be written in increasing slot order (skipping empty slots),
+// followed by the state at the end of the era, after which the writer must be
+// closed to append the slot indices.
+type Writer struct {
+	w      io.Writer    // Underlying output stream
+	offset int64        // Number of bytes written into the stream so far
+	buf    bytes.Buffer // Scratch buffer to compress entries into
+
+	start  uint64  // First slot covered by the block index
+	blocks []int64 // Offsets of the blocks written since start (0 = empty slot)
+	state  int64   // Offset of the state entry (0 = not yet written)
+	slot   uint64  // Slot of the state entry
+}
+
+// NewWriter creates an era file writer, whose block index will start at the
+// given slot. The e2store version entry is written out immediately.
+func NewWriter(w io.Writer, startSlot uint64) (*Writer, error) {
+	writer := &Writer{w: w, start: startSlot}
+	if err := writer.writeEntry(TypeVersion, nil); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+// WriteBlock compresses and writes a signed beacon block proposed in the given
+// slot, encoded according to the rules of the given fork.
+func (w *Writer) WriteBlock(slot uint64, block ssz.Object, fork ssz.Fork) error {
+	if w.state != 0 {
+		return errors.New("era: block written after state")
+	}
+	if next := w.start + uint64(len(w.blocks)); slot < next {
+		return fmt.Errorf("era: block slot %d out of order, next available %d", slot, next)
+	}
+	w.buf.Reset()
+	if err := ssz.EncodeToSnappyStreamOnFork(&w.buf, block, fork); err != nil {
+		return err
+	}
+	for w.start+uint64(len(w.blocks)) < slot {
+		w.blocks = append(w.blocks, 0)
+	}
+	w.blocks = append(w.blocks, w.offset)
+	return w.writeEntry(TypeCompressedSignedBeaconBlock, w.buf.Bytes())
+}
+
+// WriteState compresses and writes the beacon state at the given slot, encoded
+// according to the rules of the given fork. The block index will cover all the
+// slots from the starting one up to (excluding) the state's slot.
+func (w *Writer) WriteState(slot uint64, state ssz.Object, fork ssz.Fork) error {
+	if w.state != 0 {
+		return errors.New("era: state already written")
+	}
+	if next := w.start + uint64(len(w.blocks)); slot < next {
+		return fmt.Errorf("era: state slot %d out of order, next available %d", slot, next)
+	}
+	w.buf.Reset()
+	if err := ssz.EncodeToSnappyStreamOnFork(&w.buf, state, fork); err != nil {
+		return err
+	}
+	w.state, w.slot = w.offset, slot
+	return w.writeEntry(TypeCompressedBeaconState, w.buf.Bytes())
+}
+
+// Close writes out the block index (unless the era covers no slots, such as the
+// genesis one) and the state index, finishing the era file. The underlying
+// writer is not closed.
+func (w *Writer) Close() error {
+	if w.state == 0 {
+		return errors.New("era: no state written")
+	}
+	if w.slot > w.start {
+		offsets := make([]int64, w.slot-w.start)
+		for i, offset := range w.blocks {
+			if offset != 0 {
+				offsets[i] = offset - w.offset
+			}
+		}
+		if err := w.writeIndex(w.start, offsets); err != nil {
+			return err
+		}
+	}
+	return w.writeIndex(w.slot, []int64{w.state - w.offset})
+}
+
+// writeIndex writes a slot index entry with the given (already relative) offsets.
+func (w *Writer) writeIndex(start uint64, offsets []int64) error {
+	data := make([]byte, 8*len(offsets)+16)
+
+	binary.LittleEndian.PutUint64(data, start)
+	for i, offset := range offsets {
+		binary.LittleEndian.PutUint64(data[8+8*i:], uint64(offset))
+	}
+	binary.LittleEndian.PutUint64(data[len(data)-8:], uint64(len(offsets)))
+
+	return w.writeEntry(TypeSlotIndex, data)
+}
+
+// writeEntry writes an e2store entry into the underlying stream.
+func (w *Writer) writeEntry(typ EntryType, data []byte) error {
+	if err := writeHeader(w.w, typ, len(data)); err != nil {
+		return err
+	}
+	if _, err := w.w.Write(data); err != nil {
+		return err
+	}
+	w.offset += int64(headerSize + len(data))
+	return nil
+}
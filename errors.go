@@ -4,7 +4,10 @@
 
 package ssz
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // ErrBufferTooSmall is returned from encoding if the provided output byte buffer
 // is too small to hold the encoding of the object.
@@ -27,6 +30,10 @@ var ErrOffsetBeyondCapacity = errors.New("ssz: offset beyond capacity")
 // is larger than permitted.
 var ErrMaxLengthExceeded = errors.New("ssz: maximum item size exceeded")
 
+// ErrMaxValueExceeded is returned when a numeric value is larger than the maximum
+// permitted for its field (e.g. declared via the ssz-maxvalue tag).
+var ErrMaxValueExceeded = errors.New("ssz: maximum value exceeded")
+
 // ErrMaxItemsExceeded is returned when the number of items in a dynamic list
 // type is later than permitted.
 var ErrMaxItemsExceeded = errors.New("ssz: maximum item count exceeded")
@@ -51,6 +58,16 @@ var ErrDynamicStaticsIndivisible = errors.New("ssz: list of fixed objects not di
 // ssz stream contains more data than the object cares to consume.
 var ErrObjectSlotSizeMismatch = errors.New("ssz: object didn't consume all designated data")
 
+// ErrFieldNotInFork is returned (alongside ErrObjectSlotSizeMismatch) from
+// decoding if an object's slot contained more data than consumed, and some of
+// its fields were skipped due to not being present in the fork being decoded.
+// Most probably the data was encoded in a different fork.
+var ErrFieldNotInFork = errors.New("ssz: field not in fork")
+
+// ErrObjectNotInFork is returned from encoding, decoding and hashing if an object
+// restricted to certain forks (via DefineObjectFork) is used in a different fork.
+var ErrObjectNotInFork = errors.New("ssz: object not in fork")
+
 // ErrInvalidBoolean is returned from decoding if a boolean slot contains some
 // other byte than 0x00 or 0x01.
 var ErrInvalidBoolean = errors.New("ssz: invalid boolean")
@@ -62,3 +79,120 @@ var ErrJunkInBitvector = errors.New("ssz: junk in bitvector unused bits")
 // ErrJunkInBitlist is returned from decoding if the high (unused) bits of a
 // bitlist contains junk, instead of being all 0.
 var ErrJunkInBitlist = errors.New("ssz: junk in bitlist unused bits")
+
+// ErrUnorderedMapKeys is returned from decoding if the entries of a map are not
+// strictly ordered by their keys (either unsorted or containing duplicates).
+var ErrUnorderedMapKeys = errors.New("ssz: map keys not strictly increasing")
+
+// ErrInvalidUTF8 is returned from decoding if a string field required to be UTF-8
+// contains invalid byte sequences.
+var ErrInvalidUTF8 = errors.New("ssz: invalid UTF-8 string")
+
+// ErrUnsortedItems is returned from decoding if the items of a list required to
+// be sorted are not strictly increasing (either unsorted or containing duplicates).
+var ErrUnsortedItems = errors.New("ssz: list items not strictly increasing")
+
+// ErrNonCanonicalEncoding is returned from validation if re-encoding a decoded
+// object does not result in the exact same bytes as the original input.
+var ErrNonCanonicalEncoding = errors.New("ssz: non-canonical encoding")
+
+// ErrMaxFrameSizeExceeded is returned from reading a size-prefixed frame if the
+// advertised size is larger than permitted.
+var ErrMaxFrameSizeExceeded = errors.New("ssz: maximum frame size exceeded")
+
+// ErrMaxObjectSizeExceeded is returned from checked decoding if the encoded size
+// of an object is larger than the maximum registered for its type and fork.
+var ErrMaxObjectSizeExceeded = errors.New("ssz: maximum object size exceeded")
+
+// ErrMaxSizeNotRegistered is returned from checked decoding if no maximum size
+// was registered for the object's type (neither for the fork, nor a default).
+var ErrMaxSizeNotRegistered = errors.New("ssz: maximum size not registered")
+
+// ErrInvalidSchema is returned from schema based hashing if the dynamic schema
+// description is malformed or contains unsupported field encodings.
+var ErrInvalidSchema = errors.New("ssz: invalid schema")
+
+// ErrUnbalancedHashing is returned from HashRoot if an object's DefineSSZ method
+// left hashing layers or chunk groups open, meaning the computed root is invalid.
+var ErrUnbalancedHashing = errors.New("ssz: unbalanced hashing")
+
+// ErrUnknownField is returned from masked decoding if the field mask references
+// a field the object does not have (or the object has no field names at all).
+var ErrUnknownField = errors.New("ssz: unknown field")
+
+// ErrReadOnlyObject is returned from decoding if an object restricted to encoding
+// and hashing (via DefineReadOnly, e.g. types generated with value receivers) is
+// decoded into.
+var ErrReadOnlyObject = errors.New("ssz: read-only object")
+
+// ErrActiveFieldsMismatch is returned from decoding if the active fields of the
+// payload (passed via DecodeOptions.ActiveFields) do not match the fields of the
+// object's type present in the fork being decoded.
+var ErrActiveFieldsMismatch = errors.New("ssz: active fields mismatch")
+
+// DecodeError is returned from decoding to annotate a failure with the path of
+// the field it happened in (e.g. BeaconBlockBody.Attestations[3].AggregationBits).
+// Field names are only available for types implementing NamedObject, otherwise
+// the path will contain the field's definition index (e.g. #3).
+//
+// The underlying error can still be checked against via errors.Is and errors.As.
+type DecodeError struct {
+	Path string // Path of the field that failed to decode
+	Err  error  // Underlying error that caused the failure
+}
+
+// Error implements the error interface.
+func (err *DecodeError) Error() string {
+	return fmt.Sprintf("ssz: decoding %s: %v", err.Path, err.Err)
+}
+
+// Unwrap returns the underlying error that caused the decoding failure.
+func (err *DecodeError) Unwrap() error {
+	return err.Err
+}
+
+// EncodeError is returned from encoding to annotate a failure (e.g. a failed write
+// into the output stream) with the path of the field it happened in. Similarly to
+// DecodeError, field names are only available for types implementing NamedObject,
+// otherwise the path will contain the field's definition index (e.g. #3).
+//
+// The underlying error can still be checked against via errors.Is and errors.As.
+type EncodeError struct {
+	Path string // Path of the field that failed to encode
+	Err  error  // Underlying error that caused the failure
+}
+
+// Error implements the error interface.
+func (err *EncodeError) Error() string {
+	return fmt.Sprintf("ssz: encoding %s: %v", err.Path, err.Err)
+}
+
+// Unwrap returns the underlying error that caused the encoding failure.
+func (err *EncodeError) Unwrap() error {
+	return err.Err
+}
+
+// ErrDecodeBudgetExceeded is returned from decoding if the memory allocated for
+// the decoded fields exceeds the budget configured in the decoding options.
+var ErrDecodeBudgetExceeded = errors.New("ssz: decode budget exceeded")
+
+// ErrNotUpgradable is returned from transcoding if the object does not implement
+// UpgradableObject, so it cannot be converted between the layouts of forks.
+var ErrNotUpgradable = errors.New("ssz: object not upgradable")
+
+// ErrHasherBackendFailed is returned from hashing if a custom hasher backend
+// failed to hash some chunks.
+var ErrHasherBackendFailed = errors.New("ssz: hasher backend failed")
+
+// ErrUnknownType is returned from registry based decoding and validation if no type
+// was registered with the requested name.
+var ErrUnknownType = errors.New("ssz: unknown type")
+
+// ErrArrayLengthMismatch is returned from encoding and hashing if a static array
+// stored in a plain slice holds a different number of items than its declared
+// size (nil slices are treated as arrays of empty items).
+var ErrArrayLengthMismatch = errors.New("ssz: static array length mismatch")
+
+// ErrNotSelfDelimiting is returned from decoding with trailing data tolerated if
+// the object is dynamic, as its last field would swallow any trailing data.
+var ErrNotSelfDelimiting = errors.New("ssz: dynamic object not self-delimiting")
@@ -0,0 +1,149 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/karalabe/ssz/internal/extension"
+)
+
+// init wires up the internals needed by the codecext package to implement third
+// party primitives, without exporting them from this package.
+func init() {
+	extension.EncoderField = func(enc any) {
+		enc.(*Encoder).nextField()
+	}
+	extension.EncoderChecked = func(enc any) bool {
+		return enc.(*Encoder).checked
+	}
+	extension.EncoderWrite = func(enc any, blob []byte) {
+		enc.(*Encoder).writeBytes(blob)
+	}
+	extension.EncoderOffset = func(enc any, size uint32) {
+		enc.(*Encoder).writeOffset(size)
+	}
+	extension.EncoderFail = func(enc any, err error) {
+		if e := enc.(*Encoder); e.err == nil {
+			e.err = err
+		}
+	}
+	extension.EncoderErr = func(enc any) error {
+		return enc.(*Encoder).err
+	}
+
+	extension.DecoderField = func(dec any) bool {
+		return dec.(*Decoder).nextField()
+	}
+	extension.DecoderRead = func(dec any, blob []byte) {
+		dec.(*Decoder).readBytes(blob)
+	}
+	extension.DecoderOffset = func(dec any) {
+		dec.(*Decoder).decodeOffset(false)
+	}
+	extension.DecoderSize = func(dec any) uint32 {
+		if d := dec.(*Decoder); d.err == nil {
+			return d.retrieveSize()
+		}
+		return 0
+	}
+	extension.DecoderDescend = func(dec any, length uint32) {
+		dec.(*Decoder).descendIntoSlot(length)
+	}
+	extension.DecoderAscend = func(dec any) {
+		dec.(*Decoder).ascendFromSlot()
+	}
+	extension.DecoderCharge = func(dec any, n uint64) bool {
+		return dec.(*Decoder).charge(n)
+	}
+	extension.DecoderFail = func(dec any, err error) {
+		if d := dec.(*Decoder); d.err == nil {
+			d.err = err
+		}
+	}
+	extension.DecoderErr = func(dec any) error {
+		return dec.(*Decoder).err
+	}
+
+	extension.HasherField = func(h any) bool {
+		// Third party primitives cannot be described by a schema, fail recording
+		if has := h.(*Hasher); has.schema != nil {
+			if has.schema.err == nil {
+				has.schema.err = fmt.Errorf("%w: field %d is a third party primitive", ErrInvalidSchema, len(has.schema.fields))
+			}
+			return false
+		}
+		return true
+	}
+	extension.HasherChunk = func(h any, chunk [32]byte) {
+		h.(*Hasher).insertChunk(chunk, 0)
+	}
+	extension.HasherBlob = func(h any, blob []byte) {
+		h.(*Hasher).insertBlobChunks(blob)
+	}
+	extension.HasherDescend = func(h any, mixin bool) {
+		if mixin {
+			h.(*Hasher).descendMixinLayer()
+		} else {
+			h.(*Hasher).descendLayer()
+		}
+	}
+	extension.HasherAscend = func(h any, capacity uint64) {
+		h.(*Hasher).ascendLayer(capacity)
+	}
+	extension.HasherMixin = func(h any, size, capacity uint64) {
+		h.(*Hasher).ascendMixinLayer(size, capacity)
+	}
+}
+
+// writeBytes writes a raw blob into the output of the encoder.
+func (enc *Encoder) writeBytes(blob []byte) {
+	if enc.outWriter != nil {
+		if enc.err != nil {
+			return
+		}
+		_, enc.err = enc.outWriter.Write(blob)
+	} else {
+		copy(enc.outBuffer, blob)
+		enc.outBuffer = enc.outBuffer[len(blob):]
+	}
+}
+
+// writeOffset writes the offset of the next dynamic item into the output of the
+// encoder, reserving the given number of bytes for its content.
+func (enc *Encoder) writeOffset(size uint32) {
+	if enc.outWriter != nil {
+		if enc.err != nil {
+			return
+		}
+		binary.LittleEndian.PutUint32(enc.buf[:4], enc.offset)
+		_, enc.err = enc.outWriter.Write(enc.buf[:4])
+	} else {
+		binary.LittleEndian.PutUint32(enc.outBuffer, enc.offset)
+		enc.outBuffer = enc.outBuffer[4:]
+	}
+	enc.offset += size
+	enc.stats.offsets(1)
+}
+
+// readBytes fills a raw blob from the input of the decoder.
+func (dec *Decoder) readBytes(blob []byte) {
+	if dec.err != nil {
+		return
+	}
+	if dec.inReader != nil {
+		_, dec.err = io.ReadFull(dec.inReader, blob)
+		dec.inRead += uint32(len(blob))
+	} else {
+		if len(dec.inBuffer) < len(blob) {
+			dec.err = io.ErrUnexpectedEOF
+			return
+		}
+		copy(blob, dec.inBuffer)
+		dec.inBuffer = dec.inBuffer[len(blob):]
+	}
+}
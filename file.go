@@ -0,0 +1,92 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import "os"
+
+// EncodeToFile serializes a non-monolithic object into a file, memory mapping it
+// to avoid any intermediate copies. If the type contains fork-specific rules, use
+// EncodeToFileOnFork.
+func EncodeToFile(path string, obj Object) error {
+	return EncodeToFileOnFork(path, obj, ForkUnknown)
+}
+
+// EncodeToFileOnFork serializes a monolithic object into a file, memory mapping it
+// to avoid any intermediate copies. If the type does not contain fork-specific
+// rules, you can also use EncodeToFile.
+//
+// The file is created if it does not exist, or truncated if it does. The object is
+// encoded directly into the mapped pages via the buffered fast path, so it's the
+// preferred way to persist large objects (e.g. beacon state snapshots). Platforms
+// without memory mapping support fall back to writing out a temporary buffer.
+func EncodeToFileOnFork(path string, obj Object, fork Fork) error {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	size := int(SizeOnFork(obj, fork))
+	if err := f.Truncate(int64(size)); err != nil {
+		f.Close()
+		return err
+	}
+	if size == 0 {
+		return f.Close()
+	}
+	mapping, err := mapFile(f, size, true)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	if err := EncodeToBytesOnFork(mapping.data, obj, fork); err != nil {
+		mapping.unmap()
+		f.Close()
+		return err
+	}
+	if err := mapping.unmap(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// DecodeFromFile parses a non-monolithic object from a file, memory mapping it
+// to avoid any intermediate copies. If the type contains fork-specific rules, use
+// DecodeFromFileOnFork.
+func DecodeFromFile(path string, obj Object) error {
+	return DecodeFromFileOnFork(path, obj, ForkUnknown)
+}
+
+// DecodeFromFileOnFork parses a monolithic object from a file, memory mapping it
+// to avoid any intermediate copies. If the type does not contain fork-specific
+// rules, you can also use DecodeFromFile.
+//
+// The entire file is expected to be the encoded object. It is decoded directly
+// out of the mapped pages via the buffered fast path, and the decoded object does
+// not reference the mapping afterwards. Platforms without memory mapping support
+// fall back to reading the file into a temporary buffer.
+func DecodeFromFileOnFork(path string, obj Object, fork Fork) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() == 0 {
+		return decodeFromBytes(nil, obj, fork, LittleEndian, nil, nil, nil)
+	}
+	mapping, err := mapFile(f, int(info.Size()), false)
+	if err != nil {
+		return err
+	}
+	if err := decodeFromBytes(mapping.data, obj, fork, LittleEndian, nil, nil, nil); err != nil {
+		mapping.unmap()
+		return err
+	}
+	return mapping.unmap()
+}
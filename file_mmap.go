@@ -0,0 +1,37 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package ssz
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileMapping is a memory mapped region of a file.
+type fileMapping struct {
+	data []byte
+}
+
+// mapFile memory maps the first size bytes of a file, either read-only or shared
+// writable, so that writes end up in the file.
+func mapFile(f *os.File, size int, writable bool) (*fileMapping, error) {
+	prot := syscall.PROT_READ
+	if writable {
+		prot |= syscall.PROT_WRITE
+	}
+	data, err := syscall.Mmap(int(f.Fd()), 0, size, prot, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, &os.PathError{Op: "mmap", Path: f.Name(), Err: err}
+	}
+	return &fileMapping{data: data}, nil
+}
+
+// unmap releases the memory mapped region, after which the data must not be
+// accessed anymore.
+func (m *fileMapping) unmap() error {
+	return syscall.Munmap(m.data)
+}
@@ -0,0 +1,42 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package ssz
+
+import (
+	"io"
+	"os"
+)
+
+// fileMapping is an in-memory copy of a region of a file, emulating a memory
+// mapping on platforms that don't support it.
+type fileMapping struct {
+	data []byte
+	file *os.File // File to write the data back into on unmap (nil = read-only)
+}
+
+// mapFile reads the first size bytes of a file into memory, or allocates an empty
+// buffer to be written out on unmap if writable.
+func mapFile(f *os.File, size int, writable bool) (*fileMapping, error) {
+	data := make([]byte, size)
+	if writable {
+		return &fileMapping{data: data, file: f}, nil
+	}
+	if _, err := io.ReadFull(f, data); err != nil {
+		return nil, err
+	}
+	return &fileMapping{data: data}, nil
+}
+
+// unmap writes the data back into the file if writable, after which the data
+// must not be accessed anymore.
+func (m *fileMapping) unmap() error {
+	if m.file == nil {
+		return nil
+	}
+	_, err := m.file.WriteAt(m.data, 0)
+	return err
+}
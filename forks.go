@@ -4,14 +4,29 @@
 
 package ssz
 
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
 // Fork is an enum with all the hard forks that Ethereum mainnet went through,
 // which can be used to multiplex monolith types that can encode/decode across
 // a range of forks, not just for one specific.
 //
 // These enums are only meaningful in relation to one another, but are completely
 // meaningless numbers otherwise. Do not persist them across code versions.
+//
+// Custom forks can be appended after the built-in ones, before ForkFuture (e.g.
+// `const ForkMyChain = ssz.ForkElectra + 1`), and made known to the library via
+// RegisterFork.
 type Fork int
 
+// maxCustomForks is the number of fork values reserved after the last built-in
+// fork for custom forks appended by downstream chains.
+const maxCustomForks = 16
+
 const (
 	ForkUnknown Fork = iota // Placeholder if forks haven't been specified (must be index 0)
 
@@ -36,7 +51,7 @@ const (
 	ForkDencun         // https://ethereum.org/en/history/#dencun
 	ForkPectra         // https://ethereum.org/en/history/#pectra
 
-	ForkFuture // Use this for specifying future features (must be last index, no gaps)
+	ForkFuture = ForkPectra + maxCustomForks + 1 // Use this for specifying future features (must be last index)
 
 	ForkMerge    = ForkParis    // Common alias for Paris
 	ForkShanghai = ForkShapella // EL alias for Shapella
@@ -48,7 +63,8 @@ const (
 )
 
 // ForkMapping maps fork names to fork values. This is used internally by the
-// ssz codec generator to convert tags to values.
+// ssz codec generator to convert tags to values. Custom forks can be added to it
+// via RegisterFork.
 var ForkMapping = map[string]Fork{
 	"unknown":        ForkUnknown,
 	"frontier":       ForkFrontier,
@@ -81,9 +97,134 @@ var ForkMapping = map[string]Fork{
 	"future":         ForkFuture,
 }
 
+// RegisterFork adds a custom fork to the fork mapping, so that it can be looked
+// up by name. It is meant to be called at init time (it is not thread safe) by
+// chains that need extra forks after the built-in ones. The fork is returned to
+// allow declaring and registering it in one go.
+//
+// The method panics if the name is already registered, or if the fork value is
+// not between the last built-in fork and ForkFuture (exclusive).
+func RegisterFork(name string, fork Fork) Fork {
+	if _, ok := ForkMapping[name]; ok {
+		panic(fmt.Sprintf("fork %q already registered", name))
+	}
+	if fork <= ForkPectra || fork >= ForkFuture {
+		panic(fmt.Sprintf("fork %q value %d out of range (%d, %d)", name, fork, ForkPectra, ForkFuture))
+	}
+	ForkMapping[name] = fork
+	return fork
+}
+
+// forkName retrieves the registered names of a fork (joined if it has aliases),
+// or its numeric value if it was never registered.
+func forkName(fork Fork) string {
+	var names []string
+	for name, known := range ForkMapping {
+		if known == fork {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return strconv.Itoa(int(fork))
+	}
+	sort.Strings(names)
+	return strings.Join(names, "/")
+}
+
+// ForkAfter returns the first registered fork (built-in or custom) after the one
+// given, or ForkFuture if there are none.
+func ForkAfter(fork Fork) Fork {
+	next := ForkFuture
+	for _, known := range ForkMapping {
+		if known > fork && known < next {
+			next = known
+		}
+	}
+	return next
+}
+
+// ForkBefore returns the last registered fork (built-in or custom) before the one
+// given, or ForkUnknown if there are none.
+func ForkBefore(fork Fork) Fork {
+	prev := ForkUnknown
+	for _, known := range ForkMapping {
+		if known < fork && known > prev {
+			prev = known
+		}
+	}
+	return prev
+}
+
 // ForkFilter can be used by the XXXOnFork methods inside monolithic types to
 // define certain fields appearing only in certain forks.
 type ForkFilter struct {
 	Added   Fork
 	Removed Fork
+
+	// Ranges can be used to define fields that are present in multiple disjoint
+	// fork ranges (e.g. removed and later re-introduced). If set, Added/Removed
+	// are ignored. It is a pointer to keep filters comparable, and the sets are
+	// meant to be declared once as package level variables.
+	Ranges *ForkFilterSet
+}
+
+// Active reports whether a field guarded by the filter is present in a fork.
+func (filter ForkFilter) Active(fork Fork) bool {
+	if filter.Ranges != nil {
+		return filter.Ranges.Active(fork)
+	}
+	return fork >= filter.Added && (filter.Removed == ForkUnknown || fork < filter.Removed)
+}
+
+// String implements fmt.Stringer, describing the forks the filter is active in.
+func (filter ForkFilter) String() string {
+	if filter.Ranges != nil {
+		ranges := make([]string, len(*filter.Ranges))
+		for i, r := range *filter.Ranges {
+			ranges[i] = r.String()
+		}
+		return strings.Join(ranges, " or ")
+	}
+	if filter.Removed == ForkUnknown {
+		return "added in " + forkName(filter.Added)
+	}
+	return "added in " + forkName(filter.Added) + ", removed in " + forkName(filter.Removed)
+}
+
+// ForkFilterSet is a union of fork ranges, used to define fields that are present
+// in multiple disjoint fork ranges. It can be passed to the XXXOnFork methods by
+// wrapping it into a ForkFilter{Ranges: &set}.
+type ForkFilterSet []ForkFilter
+
+// Active reports whether a fork is within any of the ranges of the set.
+func (set ForkFilterSet) Active(fork Fork) bool {
+	for _, filter := range set {
+		if filter.Active(fork) {
+			return true
+		}
+	}
+	return false
+}
+
+// ForkLimit can be used by the LimitOnFork method inside monolithic types to
+// define list limits that change from a certain fork onward.
+type ForkLimit struct {
+	Fork  Fork   // Fork from which the limit is active
+	Limit uint64 // Limit to enforce from the fork onward
+}
+
+// LimitOnFork resolves a list limit that changes across forks, based on the fork
+// the codec is currently operating on. The limit in effect will be the override
+// of the latest fork already activated, or the base limit if none are active.
+func LimitOnFork(c *Codec, base uint64, overrides ...ForkLimit) uint64 {
+	var (
+		limit  = base
+		active = Fork(-1)
+	)
+	for _, override := range overrides {
+		if override.Fork <= c.fork && override.Fork > active {
+			limit, active = override.Limit, override.Fork
+		}
+	}
+	return limit
 }
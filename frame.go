@@ -0,0 +1,116 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// FrameFormat defines how the size of an ssz object is prefixed and how its data
+// is encoded when writing it into (or reading it from) a network stream.
+type FrameFormat int
+
+const (
+	// FrameUvarint prefixes the ssz payload with its size as an unsigned varint.
+	FrameUvarint FrameFormat = iota
+
+	// FrameUint32 prefixes the ssz payload with its size as a little endian uint32.
+	FrameUint32
+
+	// FrameSnappy prefixes the ssz payload with its (uncompressed) size as an
+	// unsigned varint and compresses the payload with snappy's framing format.
+	// This is the ssz_snappy encoding used by libp2p req/resp.
+	FrameSnappy
+)
+
+// WriteFrame serializes a non-monolithic object into a data stream, prefixed by
+// its size. If the type contains fork-specific rules, use WriteFrameOnFork.
+func WriteFrame(w io.Writer, obj Object, format FrameFormat) error {
+	return WriteFrameOnFork(w, obj, format, ForkUnknown)
+}
+
+// WriteFrameOnFork serializes a monolithic object into a data stream, prefixed by
+// its size. If the type does not contain fork-specific rules, you can also use
+// WriteFrame.
+func WriteFrameOnFork(w io.Writer, obj Object, format FrameFormat, fork Fork) error {
+	var (
+		buf  [binary.MaxVarintLen64]byte
+		size = SizeOnFork(obj, fork)
+	)
+	switch format {
+	case FrameUvarint, FrameSnappy:
+		if _, err := w.Write(buf[:binary.PutUvarint(buf[:], uint64(size))]); err != nil {
+			return err
+		}
+	case FrameUint32:
+		binary.LittleEndian.PutUint32(buf[:4], size)
+		if _, err := w.Write(buf[:4]); err != nil {
+			return err
+		}
+	default:
+		panic(fmt.Sprintf("unsupported frame format: %d", format))
+	}
+	if format == FrameSnappy {
+		return EncodeToSnappyStreamOnFork(w, obj, fork)
+	}
+	return EncodeToStreamOnFork(w, obj, fork)
+}
+
+// ReadFrame parses a size-prefixed non-monolithic object out of a data stream,
+// rejecting it if it's larger than maxSize. If the type contains fork-specific
+// rules, use ReadFrameOnFork.
+func ReadFrame(r io.Reader, obj Object, maxSize uint32, format FrameFormat) error {
+	return ReadFrameOnFork(r, obj, maxSize, format, ForkUnknown)
+}
+
+// ReadFrameOnFork parses a size-prefixed monolithic object out of a data stream,
+// rejecting it if it's larger than maxSize. If the type does not contain fork-
+// specific rules, you can also use ReadFrame.
+func ReadFrameOnFork(r io.Reader, obj Object, maxSize uint32, format FrameFormat, fork Fork) error {
+	var size uint64
+	switch format {
+	case FrameUvarint, FrameSnappy:
+		br, ok := r.(io.ByteReader)
+		if !ok {
+			br = &frameByteReader{r: r}
+		}
+		var err error
+		if size, err = binary.ReadUvarint(br); err != nil {
+			return err
+		}
+	case FrameUint32:
+		var buf [4]byte
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return err
+		}
+		size = uint64(binary.LittleEndian.Uint32(buf[:]))
+	default:
+		panic(fmt.Sprintf("unsupported frame format: %d", format))
+	}
+	if size > uint64(maxSize) {
+		return fmt.Errorf("%w: decoded %d, max %d", ErrMaxFrameSizeExceeded, size, maxSize)
+	}
+	if format == FrameSnappy {
+		return DecodeFromSnappyStreamOnFork(r, obj, uint32(size), fork)
+	}
+	return DecodeFromStreamOnFork(r, obj, uint32(size), fork)
+}
+
+// frameByteReader is a tiny wrapper around an io.Reader to read the size prefix
+// byte-by-byte, without consuming anything from the stream beyond it.
+type frameByteReader struct {
+	r   io.Reader
+	buf [1]byte
+}
+
+// ReadByte implements io.ByteReader.
+func (br *frameByteReader) ReadByte() (byte, error) {
+	if _, err := io.ReadFull(br.r, br.buf[:]); err != nil {
+		return 0, err
+	}
+	return br.buf[0], nil
+}
@@ -0,0 +1,308 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package gen
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/constant"
+	"go/types"
+	"math/bits"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// schemaType is the machine readable description of a resolved ssz container.
+type schemaType struct {
+	Name   string         `json:"name"`
+	Static bool           `json:"static"`
+	Forks  []string       `json:"forks,omitempty"` // Forks at which the field layout changes
+	Fields []*schemaField `json:"fields"`
+}
+
+// schemaField is the machine readable description of a single container field.
+type schemaField struct {
+	Name     string           `json:"name"`
+	Type     string           `json:"type"`                 // Go type of the field
+	Encoding string           `json:"encoding"`             // ssz codec method family used for the field
+	Static   bool             `json:"static"`               // Whether the field is fixed size
+	Size     int              `json:"size,omitempty"`       // Encoded size of static fields, if known
+	Sizes    []int            `json:"sizes,omitempty"`      // Static item sizes for the different dimensions
+	Limits   []int            `json:"limits,omitempty"`     // Maximum item counts for dynamic dimensions
+	Forks    []schemaLimit    `json:"forkLimits,omitempty"` // Fork specific overrides of the limit
+	Switches []schemaSwitch   `json:"forkTypes,omitempty"`  // Fork specific alternative encodings
+	Sorted   bool             `json:"sorted,omitempty"`     // Whether list items must be strictly increasing
+	Added    string           `json:"added,omitempty"`      // Fork the field was added in
+	Removed  string           `json:"removed,omitempty"`    // Fork the field was removed in
+	Ranges   []schemaRange    `json:"ranges,omitempty"`     // Disjoint fork ranges the field is present in
+	GIndex   uint64           `json:"gindex,omitempty"`     // Generalized index (fork independent types)
+	GIndices []schemaForkGIdx `json:"gindices,omitempty"`   // Generalized indices (fork dependent types)
+	Schema   *schemaType      `json:"schema,omitempty"`     // Schema of the nested object (or list item) type
+
+	ranges []forkRange // Parsed fork constraint for computing the layouts
+}
+
+// schemaRange is a half-open range of forks [added, removed) a field is present in.
+type schemaRange struct {
+	Added   string `json:"added,omitempty"`
+	Removed string `json:"removed,omitempty"`
+}
+
+// schemaLimit is a fork specific limit override of a dynamic field.
+type schemaLimit struct {
+	Fork  string `json:"fork"`
+	Limit int    `json:"limit"`
+}
+
+// schemaSwitch is an alternative encoding a field switches to from a specific fork
+// onward.
+type schemaSwitch struct {
+	Fork     string `json:"fork"`
+	Encoding string `json:"encoding"`
+	Size     int    `json:"size,omitempty"`
+	Sizes    []int  `json:"sizes,omitempty"`
+	Limits   []int  `json:"limits,omitempty"`
+}
+
+// schemaForkGIdx is the generalized index of a field from a specific fork onward.
+type schemaForkGIdx struct {
+	Fork   string `json:"fork"`
+	GIndex uint64 `json:"gindex"`
+}
+
+// Describe resolves the ssz schema of the requested types of a package (all the
+// structs if none are specified), returning it as JSON. Only the directory and
+// leniency of the options are used.
+func Describe(pkgPath string, typeNames []string, opts *Options) ([]byte, error) {
+	if opts == nil {
+		opts = new(Options)
+	}
+	genLock.Lock()
+	defer genLock.Unlock()
+
+	library, target, containers, err := load(pkgPath, typeNames, opts)
+	if err != nil {
+		return nil, err
+	}
+	schema := make([]*schemaType, 0, len(containers))
+	for _, typ := range containers {
+		desc, err := describe(library, target, typ)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe %s: %v", typ.named.Obj().Name(), err)
+		}
+		schema = append(schema, desc)
+	}
+	blob, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(blob, '\n'), nil
+}
+
+// describe converts a resolved ssz container into its schema description.
+func describe(library *types.Package, target *types.Package, typ *sszContainer) (*schemaType, error) {
+	schema := &schemaType{
+		Name:   typ.named.Obj().Name(),
+		Static: typ.static,
+	}
+	var (
+		qualifier = types.RelativeTo(target)
+		entries   = typ.entries
+	)
+	for i, name := range typ.fields {
+		field := &schemaField{
+			Name: name,
+			Type: types.TypeString(typ.types[i], qualifier),
+		}
+		field.Static = isStaticOpset(typ.opsets[i])
+		field.Encoding, field.Size, field.Sizes, field.Limits = describeOpset(typ.opsets[i])
+		if op, ok := typ.opsets[i].(*opsetDynamic); ok {
+			field.Sorted = strings.HasPrefix(op.defineContent, "DefineSorted")
+			for _, override := range op.overrides {
+				field.Forks = append(field.Forks, schemaLimit{Fork: override.fork, Limit: override.limit})
+			}
+		}
+		for _, alt := range typ.forkOpsets[i] {
+			sw := schemaSwitch{Fork: alt.fork}
+			sw.Encoding, sw.Size, sw.Sizes, sw.Limits = describeOpset(alt.opset)
+			field.Switches = append(field.Switches, sw)
+		}
+		var err error
+		if underlyingMap(typ.types[i]) != nil {
+			field.Schema, err = describe(library, target, entries[0])
+			entries = entries[1:]
+		} else if strings.Contains(field.Encoding, "Object") {
+			field.Schema, err = describeNested(library, target, typ.types[i])
+		}
+		if err != nil {
+			return nil, err
+		}
+		field.ranges = parseForkRanges(typ.forks[i])
+		switch len(field.ranges) {
+		case 0:
+		case 1:
+			field.Added, field.Removed = field.ranges[0].added, field.ranges[0].removed
+		default:
+			for _, r := range field.ranges {
+				field.Ranges = append(field.Ranges, schemaRange{Added: r.added, Removed: r.removed})
+			}
+		}
+		schema.Fields = append(schema.Fields, field)
+	}
+	// Compute the generalized indices of the fields. If there are no fork rules,
+	// that's a single layout, otherwise one per distinct fork boundary.
+	var forks []string
+	for _, field := range schema.Fields {
+		for _, r := range field.ranges {
+			for _, fork := range []string{r.added, r.removed} {
+				if fork != "" && !slices.Contains(forks, fork) {
+					forks = append(forks, fork)
+				}
+			}
+		}
+	}
+	if len(forks) == 0 {
+		for i, gindex := range describeGIndices(len(schema.Fields)) {
+			schema.Fields[i].GIndex = gindex
+		}
+		return schema, nil
+	}
+	values := map[string]int64{"Unknown": 0}
+	for _, fork := range forks {
+		value, err := forkValue(library, target, fork)
+		if err != nil {
+			return nil, err
+		}
+		values[fork] = value
+	}
+	sort.SliceStable(forks, func(i, j int) bool {
+		return values[forks[i]] < values[forks[j]]
+	})
+	if forks[0] != "Unknown" {
+		forks = append([]string{"Unknown"}, forks...)
+	}
+	schema.Forks = forks
+
+	for _, fork := range schema.Forks {
+		var active []*schemaField
+		for _, field := range schema.Fields {
+			if field.ranges == nil {
+				active = append(active, field)
+				continue
+			}
+			for _, r := range field.ranges {
+				if r.added != "" && values[fork] < values[r.added] {
+					continue
+				}
+				if r.removed != "" && values[fork] >= values[r.removed] {
+					continue
+				}
+				active = append(active, field)
+				break
+			}
+		}
+		for i, gindex := range describeGIndices(len(active)) {
+			active[i].GIndices = append(active[i].GIndices, schemaForkGIdx{Fork: fork, GIndex: gindex})
+		}
+	}
+	return schema, nil
+}
+
+// describeOpset converts the opset of a field into its encoding family and its
+// static size, item sizes and limits.
+func describeOpset(op opset) (encoding string, size int, sizes []int, limits []int) {
+	switch op := op.(type) {
+	case *opsetStatic:
+		if len(op.bytes) > 0 {
+			size = staticSize(op.bytes)
+		}
+		if len(op.bytes) > 1 {
+			sizes = op.bytes
+		}
+		return describeEncoding(op.define), size, sizes, nil
+	case *opsetDynamic:
+		return describeEncoding(op.defineOffset), 0, op.sizes, op.limits
+	}
+	return "", 0, nil, nil
+}
+
+// describeNested resolves the schema of the object type embedded into a field,
+// either directly or as the items of a list.
+func describeNested(library *types.Package, target *types.Package, typ types.Type) (*schemaType, error) {
+	for {
+		switch t := types.Unalias(typ).(type) {
+		case *types.Pointer:
+			typ = t.Elem()
+			continue
+		case *types.Slice:
+			typ = t.Elem()
+			continue
+		case *types.Array:
+			typ = t.Elem()
+			continue
+		case *types.Named:
+			str, ok := t.Underlying().(*types.Struct)
+			if !ok {
+				typ = t.Underlying()
+				continue
+			}
+			container, err := newParseContext(library).makeContainer(t, str)
+			if err != nil {
+				return nil, nil
+			}
+			return describe(library, target, container)
+		}
+		return nil, nil
+	}
+}
+
+// describeEncoding converts an ssz codec method name into the encoding family of
+// the field (e.g. DefineSliceOfUint64sOffset -> SliceOfUint64s).
+func describeEncoding(method string) string {
+	if idx := strings.IndexAny(method, "[("); idx >= 0 {
+		method = method[:idx]
+	}
+	method = strings.TrimPrefix(method, "Define")
+	method = strings.TrimPrefix(method, "Generic")
+	method = strings.TrimSuffix(method, "Offset")
+	method = strings.TrimSuffix(method, "Pointer")
+	method = strings.TrimSuffix(method, "Max")
+	method = strings.Replace(method, "ObjectValues", "Objects", 1)
+	return method
+}
+
+// describeGIndices computes the generalized indices of the fields in a container
+// with the given number of fields.
+func describeGIndices(fields int) []uint64 {
+	var depth int
+	if fields > 1 {
+		depth = bits.Len(uint(fields - 1))
+	}
+	gindices := make([]uint64, fields)
+	for i := range gindices {
+		gindices[i] = 1<<depth + uint64(i)
+	}
+	return gindices
+}
+
+// forkValue retrieves the ordinal of a fork from the ssz library's constants, or
+// from the target package's for custom forks. The fork names are validated when
+// parsing the tags, but an older ssz library might not declare all of them.
+func forkValue(library *types.Package, target *types.Package, fork string) (int64, error) {
+	scope := library.Scope()
+	if forkCustom[fork] {
+		scope = target.Scope()
+	}
+	obj, ok := scope.Lookup("Fork" + fork).(*types.Const)
+	if !ok {
+		return 0, fmt.Errorf("unknown fork: %s", fork)
+	}
+	val, ok := constant.Int64Val(obj.Val())
+	if !ok {
+		return 0, fmt.Errorf("fork %s is not an integer constant: %v", fork, obj.Val())
+	}
+	return val, nil
+}
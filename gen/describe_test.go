@@ -0,0 +1,131 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package gen
+
+import (
+	"encoding/json"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"reflect"
+	"testing"
+)
+
+// describeTestSource is a package with fork dependent fields, limits and nested
+// objects, exercising all the layout computations of the schema description.
+const describeTestSource = `package desctest
+
+import "github.com/karalabe/ssz"
+
+type Inner struct {
+	A uint64
+}
+
+func (obj *Inner) SizeSSZ(sizer *ssz.Sizer) uint32 { return 8 }
+func (obj *Inner) DefineSSZ(codec *ssz.Codec)      { ssz.DefineUint64(codec, &obj.A) }
+
+type Outer struct {
+	Slot  uint64
+	Extra *uint64  ` + "`ssz-fork:\"deneb\"`" + `
+	Items []*Inner ` + "`ssz-max:\"16\" ssz-max-fork:\"deneb=8\"`" + `
+	Blob  []byte   ` + "`ssz-max:\"32\"`" + `
+	Gone  *uint32  ` + "`ssz-fork:\"!capella\"`" + `
+}
+`
+
+// Tests that the schema description of a package contains the resolved field
+// encodings, fork rules and per-fork generalized indices.
+func TestDescribe(t *testing.T) {
+	dir := writeTestPackage(t, "desctest", describeTestSource)
+
+	blob, err := Describe(".", []string{"Inner", "Outer"}, &Options{Dir: dir})
+	if err != nil {
+		t.Fatalf("failed to describe package: %v", err)
+	}
+	var schema []*schemaType
+	if err := json.Unmarshal(blob, &schema); err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	if len(schema) != 2 || schema[0].Name != "Inner" || schema[1].Name != "Outer" {
+		t.Fatalf("described types mismatch: %s", blob)
+	}
+	if inner := schema[0]; !inner.Static || len(inner.Fields) != 1 || inner.Fields[0].GIndex != 1 || inner.Forks != nil {
+		t.Errorf("inner schema mismatch: %+v", inner)
+	}
+	outer := schema[1]
+	if outer.Static {
+		t.Errorf("outer schema reported static")
+	}
+	// Fork boundaries must be sorted by the fork ordinals, not the field order
+	if want := []string{"Unknown", "Capella", "Deneb"}; !reflect.DeepEqual(outer.Forks, want) {
+		t.Errorf("fork boundaries mismatch: have %v, want %v", outer.Forks, want)
+	}
+	fields := make(map[string]*schemaField)
+	for _, field := range outer.Fields {
+		fields[field.Name] = field
+	}
+	if field := fields["Extra"]; field.Added != "Deneb" || field.Removed != "" {
+		t.Errorf("added field fork rule mismatch: %+v", field)
+	}
+	if field := fields["Gone"]; field.Added != "" || field.Removed != "Capella" {
+		t.Errorf("removed field fork rule mismatch: %+v", field)
+	}
+	items := fields["Items"]
+	if items.Encoding != "SliceOfStaticObjects" || !reflect.DeepEqual(items.Limits, []int{16}) {
+		t.Errorf("list encoding mismatch: %+v", items)
+	}
+	if want := []schemaLimit{{Fork: "Deneb", Limit: 8}}; !reflect.DeepEqual(items.Forks, want) {
+		t.Errorf("fork limits mismatch: have %v, want %v", items.Forks, want)
+	}
+	if items.Schema == nil || items.Schema.Name != "Inner" {
+		t.Errorf("nested schema mismatch: %+v", items.Schema)
+	}
+	// The generalized indices must follow the active fields in every fork
+	for name, want := range map[string][]schemaForkGIdx{
+		"Slot":  {{"Unknown", 4}, {"Capella", 4}, {"Deneb", 4}},
+		"Extra": {{"Deneb", 5}},
+		"Items": {{"Unknown", 5}, {"Capella", 5}, {"Deneb", 6}},
+		"Blob":  {{"Unknown", 6}, {"Capella", 6}, {"Deneb", 7}},
+		"Gone":  {{"Unknown", 7}},
+	} {
+		if have := fields[name].GIndices; !reflect.DeepEqual(have, want) {
+			t.Errorf("field %s: gindices mismatch: have %v, want %v", name, have, want)
+		}
+	}
+}
+
+// Tests that fork ordinals are resolved from the library or the custom forks of
+// the target package, and that missing forks are reported as errors instead of
+// crashing the generator.
+func TestForkValue(t *testing.T) {
+	library := types.NewPackage("github.com/karalabe/ssz", "ssz")
+	library.Scope().Insert(types.NewConst(token.NoPos, library, "ForkDeneb", types.Typ[types.Uint16], constant.MakeInt64(90)))
+	library.Scope().Insert(types.NewVar(token.NoPos, library, "ForkBogus", types.Typ[types.Uint16]))
+
+	target := types.NewPackage("example.com/chain", "chain")
+	target.Scope().Insert(types.NewConst(token.NoPos, target, "ForkMyChain", types.Typ[types.Uint16], constant.MakeInt64(91)))
+
+	forkCustom["MyChain"] = true
+	defer delete(forkCustom, "MyChain")
+
+	for _, tt := range []struct {
+		fork  string
+		value int64
+		fail  bool
+	}{
+		{fork: "Deneb", value: 90},
+		{fork: "MyChain", value: 91},
+		{fork: "Electra", fail: true}, // not declared by the library
+		{fork: "Bogus", fail: true},   // not a constant
+	} {
+		value, err := forkValue(library, target, tt.fork)
+		switch {
+		case tt.fail && err == nil:
+			t.Errorf("fork %s: resolved to %d, want error", tt.fork, value)
+		case !tt.fail && (err != nil || value != tt.value):
+			t.Errorf("fork %s: value mismatch: have %d/%v, want %d", tt.fork, value, err, tt.value)
+		}
+	}
+}
@@ -0,0 +1,637 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/types"
+	"io"
+)
+
+// Extra methods that the code generator can emit on top of the ssz ones.
+const (
+	extraClone   = "clone"
+	extraEqual   = "equal"
+	extraCache   = "cache"
+	extraGetters = "getters"
+	extraReset   = "reset"
+	extraUpgrade = "upgrade"
+)
+
+// generateClone creates a deep copy method for the type, only retaining the fields
+// that are part of the ssz schema.
+func generateClone(ctx *genContext, typ *sszContainer) ([]byte, error) {
+	var (
+		b    bytes.Buffer
+		name = typ.typeName()
+	)
+	fmt.Fprint(&b, "// Clone creates a deep copy of the object, retaining only the ssz fields.\n")
+	fmt.Fprintf(&b, "func (obj *%s) Clone() *%s {\n", name, name)
+	fmt.Fprint(&b, "	if obj == nil {\n")
+	fmt.Fprint(&b, "		return nil\n")
+	fmt.Fprint(&b, "	}\n")
+	fmt.Fprintf(&b, "	clone := new(%s)\n", name)
+	for i, field := range typ.fields {
+		if err := generateCloneField(&b, ctx, "clone."+field, "obj."+field, typ.types[i], 0); err != nil {
+			return nil, fmt.Errorf("failed to clone field %s.%s: %v", name, field, err)
+		}
+	}
+	fmt.Fprint(&b, "	return clone\n")
+	fmt.Fprint(&b, "}\n")
+	return b.Bytes(), nil
+}
+
+// generateCloneField emits the code to deep copy a single value from src to dst.
+func generateCloneField(w io.Writer, ctx *genContext, dst string, src string, typ types.Type, depth int) error {
+	if isValueType(typ) {
+		fmt.Fprintf(w, "%s = %s\n", dst, src)
+		return nil
+	}
+	switch t := typ.Underlying().(type) {
+	case *types.Slice:
+		if isValueType(t.Elem()) {
+			fmt.Fprintf(w, "%s = append(%s[:0:0], %s...)\n", dst, src, src)
+			return nil
+		}
+		idx := loopVariable(depth)
+
+		fmt.Fprintf(w, "if %s != nil {\n", src)
+		fmt.Fprintf(w, "%s = make(%s, len(%s))\n", dst, ctx.typeString(typ), src)
+		fmt.Fprintf(w, "for %s := range %s {\n", idx, src)
+		if err := generateCloneField(w, ctx, dst+"["+idx+"]", src+"["+idx+"]", t.Elem(), depth+1); err != nil {
+			return err
+		}
+		fmt.Fprint(w, "}\n")
+		fmt.Fprint(w, "}\n")
+		return nil
+
+	case *types.Array:
+		idx := loopVariable(depth)
+
+		fmt.Fprintf(w, "for %s := range %s {\n", idx, src)
+		if err := generateCloneField(w, ctx, dst+"["+idx+"]", src+"["+idx+"]", t.Elem(), depth+1); err != nil {
+			return err
+		}
+		fmt.Fprint(w, "}\n")
+		return nil
+
+	case *types.Map:
+		key := loopVariable(depth)
+
+		fmt.Fprintf(w, "if %s != nil {\n", src)
+		fmt.Fprintf(w, "%s = make(%s, len(%s))\n", dst, ctx.typeString(typ), src)
+		fmt.Fprintf(w, "for %s := range %s {\n", key, src)
+		if err := generateCloneField(w, ctx, dst+"["+key+"]", src+"["+key+"]", t.Elem(), depth+1); err != nil {
+			return err
+		}
+		fmt.Fprint(w, "}\n")
+		fmt.Fprint(w, "}\n")
+		return nil
+
+	case *types.Struct:
+		// Objects stored by value are cloned via their pointer receivers
+		fmt.Fprintf(w, "%s = *%s.Clone()\n", dst, src)
+		return nil
+
+	case *types.Pointer:
+		// Slices behind pointers need to retain the difference between nil and
+		// empty, so allocate the pointer and clone the contents
+		if _, ok := types.Unalias(t.Elem()).(*types.Slice); ok {
+			fmt.Fprintf(w, "if %s != nil {\n", src)
+			fmt.Fprintf(w, "%s = new(%s)\n", dst, ctx.typeString(t.Elem()))
+			if err := generateCloneField(w, ctx, "(*"+dst+")", "(*"+src+")", t.Elem(), depth); err != nil {
+				return err
+			}
+			fmt.Fprint(w, "}\n")
+			return nil
+		}
+		// Objects handle nil receivers themselves, everything else needs to be
+		// explicitly checked before copying
+		if !isUint256(t.Elem()) && !isBigInt(t.Elem()) && !isValueType(t.Elem()) {
+			fmt.Fprintf(w, "%s = %s.Clone()\n", dst, src)
+			return nil
+		}
+		fmt.Fprintf(w, "if %s != nil {\n", src)
+		if isUint256(t.Elem()) || isBigInt(t.Elem()) {
+			fmt.Fprintf(w, "%s = new(%s).Set(%s)\n", dst, ctx.typeString(t.Elem()), src)
+		} else {
+			fmt.Fprintf(w, "%s = new(%s)\n", dst, ctx.typeString(t.Elem()))
+			fmt.Fprintf(w, "*%s = *%s\n", dst, src)
+		}
+		fmt.Fprint(w, "}\n")
+		return nil
+	}
+	return fmt.Errorf("unsupported type %s", typ.String())
+}
+
+// generateEqual creates an equality check for the type, only comparing the fields
+// that are part of the ssz schema and treating nil and zero values as equal.
+func generateEqual(ctx *genContext, typ *sszContainer) ([]byte, error) {
+	var (
+		b    bytes.Buffer
+		name = typ.typeName()
+	)
+	fmt.Fprint(&b, "// EqualSSZ checks whether two objects are equal in their ssz representation. Nil\n")
+	fmt.Fprint(&b, "// and zero values are considered equal, as they would encode the same.\n")
+	fmt.Fprintf(&b, "func (obj *%s) EqualSSZ(other *%s) bool {\n", name, name)
+	fmt.Fprint(&b, "	if obj == nil {\n")
+	fmt.Fprintf(&b, "		obj = new(%s)\n", name)
+	fmt.Fprint(&b, "	}\n")
+	fmt.Fprint(&b, "	if other == nil {\n")
+	fmt.Fprintf(&b, "		other = new(%s)\n", name)
+	fmt.Fprint(&b, "	}\n")
+	for i, field := range typ.fields {
+		if err := generateEqualField(&b, ctx, "obj."+field, "other."+field, typ.types[i], 0); err != nil {
+			return nil, fmt.Errorf("failed to compare field %s.%s: %v", name, field, err)
+		}
+	}
+	fmt.Fprint(&b, "	return true\n")
+	fmt.Fprint(&b, "}\n")
+	return b.Bytes(), nil
+}
+
+// generateEqualField emits the code to compare two values, returning false from
+// the generated method on a mismatch.
+func generateEqualField(w io.Writer, ctx *genContext, a string, b string, typ types.Type, depth int) error {
+	if isValueType(typ) {
+		fmt.Fprintf(w, "if %s != %s {\n", a, b)
+		fmt.Fprint(w, "return false\n")
+		fmt.Fprint(w, "}\n")
+		return nil
+	}
+	switch t := typ.Underlying().(type) {
+	case *types.Slice:
+		if basic, ok := t.Elem().Underlying().(*types.Basic); ok && basic.Kind() == types.Byte {
+			ctx.addImport("bytes", "")
+
+			fmt.Fprintf(w, "if !bytes.Equal(%s, %s) {\n", a, b)
+			fmt.Fprint(w, "return false\n")
+			fmt.Fprint(w, "}\n")
+			return nil
+		}
+		idx := loopVariable(depth)
+
+		fmt.Fprintf(w, "if len(%s) != len(%s) {\n", a, b)
+		fmt.Fprint(w, "return false\n")
+		fmt.Fprint(w, "}\n")
+		fmt.Fprintf(w, "for %s := range %s {\n", idx, a)
+		if err := generateEqualField(w, ctx, a+"["+idx+"]", b+"["+idx+"]", t.Elem(), depth+1); err != nil {
+			return err
+		}
+		fmt.Fprint(w, "}\n")
+		return nil
+
+	case *types.Array:
+		idx := loopVariable(depth)
+
+		fmt.Fprintf(w, "for %s := range %s {\n", idx, a)
+		if err := generateEqualField(w, ctx, a+"["+idx+"]", b+"["+idx+"]", t.Elem(), depth+1); err != nil {
+			return err
+		}
+		fmt.Fprint(w, "}\n")
+		return nil
+
+	case *types.Map:
+		key := loopVariable(depth)
+
+		fmt.Fprintf(w, "if len(%s) != len(%s) {\n", a, b)
+		fmt.Fprint(w, "return false\n")
+		fmt.Fprint(w, "}\n")
+		fmt.Fprintf(w, "for %s := range %s {\n", key, a)
+		fmt.Fprintf(w, "if _, ok := %s[%s]; !ok {\n", b, key)
+		fmt.Fprint(w, "return false\n")
+		fmt.Fprint(w, "}\n")
+		if err := generateEqualField(w, ctx, a+"["+key+"]", b+"["+key+"]", t.Elem(), depth+1); err != nil {
+			return err
+		}
+		fmt.Fprint(w, "}\n")
+		return nil
+
+	case *types.Struct:
+		// Objects stored by value are compared via their pointer receivers
+		fmt.Fprintf(w, "if !%s.EqualSSZ(&%s) {\n", a, b)
+		fmt.Fprint(w, "return false\n")
+		fmt.Fprint(w, "}\n")
+		return nil
+
+	case *types.Pointer:
+		// Objects handle nil receivers themselves, everything else is substituted
+		// with a zero value before comparing
+		_, slice := types.Unalias(t.Elem()).(*types.Slice)
+		if !slice && !isUint256(t.Elem()) && !isBigInt(t.Elem()) && !isValueType(t.Elem()) {
+			fmt.Fprintf(w, "if !%s.EqualSSZ(%s) {\n", a, b)
+			fmt.Fprint(w, "return false\n")
+			fmt.Fprint(w, "}\n")
+			return nil
+		}
+		elem := ctx.typeString(t.Elem())
+
+		fmt.Fprint(w, "{\n")
+		fmt.Fprintf(w, "a, b := %s, %s\n", a, b)
+		fmt.Fprint(w, "if a == nil {\n")
+		fmt.Fprintf(w, "a = new(%s)\n", elem)
+		fmt.Fprint(w, "}\n")
+		fmt.Fprint(w, "if b == nil {\n")
+		fmt.Fprintf(w, "b = new(%s)\n", elem)
+		fmt.Fprint(w, "}\n")
+		if slice {
+			if err := generateEqualField(w, ctx, "(*a)", "(*b)", t.Elem(), depth); err != nil {
+				return err
+			}
+			fmt.Fprint(w, "}\n")
+			return nil
+		}
+		switch {
+		case isUint256(t.Elem()):
+			fmt.Fprint(w, "if !a.Eq(b) {\n")
+		case isBigInt(t.Elem()):
+			fmt.Fprint(w, "if a.Cmp(b) != 0 {\n")
+		default:
+			fmt.Fprint(w, "if *a != *b {\n")
+		}
+		fmt.Fprint(w, "return false\n")
+		fmt.Fprint(w, "}\n")
+		fmt.Fprint(w, "}\n")
+		return nil
+	}
+	return fmt.Errorf("unsupported type %s", typ.String())
+}
+
+// generateReset creates a method clearing all the fields that are part of the ssz
+// schema, retaining the allocated memory for reuse by subsequent decodes.
+func generateReset(ctx *genContext, typ *sszContainer) ([]byte, error) {
+	var (
+		b    bytes.Buffer
+		name = typ.typeName()
+	)
+	fmt.Fprint(&b, "// ResetSSZ clears all the ssz fields of the object, retaining the capacity of any\n")
+	fmt.Fprint(&b, "// slices, so that pooled objects don't leak data across decodes.\n")
+	fmt.Fprintf(&b, "func (obj *%s) ResetSSZ() {\n", name)
+	fmt.Fprint(&b, "	if obj == nil {\n")
+	fmt.Fprint(&b, "		return\n")
+	fmt.Fprint(&b, "	}\n")
+	for i, field := range typ.fields {
+		// Pointers of fields not present in all forks are dropped, otherwise they
+		// would be left allocated when decoding a fork without them
+		if _, ok := types.Unalias(typ.types[i]).(*types.Pointer); ok && !forkAlways(typ.forks[i]) {
+			fmt.Fprintf(&b, "	obj.%s = nil\n", field)
+			continue
+		}
+		if err := generateResetField(&b, ctx, "obj."+field, typ.types[i], 0); err != nil {
+			return nil, fmt.Errorf("failed to reset field %s.%s: %v", name, field, err)
+		}
+	}
+	if typ.cache != "" {
+		fmt.Fprintf(&b, "	obj.%s.Invalidate()\n", typ.cache)
+	}
+	fmt.Fprint(&b, "}\n")
+	return b.Bytes(), nil
+}
+
+// generateResetField emits the code to clear a single value in place.
+func generateResetField(w io.Writer, ctx *genContext, dst string, typ types.Type, depth int) error {
+	if isValueType(typ) {
+		fmt.Fprintf(w, "%s = %s\n", dst, zeroValue(ctx, typ))
+		return nil
+	}
+	switch t := typ.Underlying().(type) {
+	case *types.Slice:
+		// Items past the length are reused by the decoder when reslicing, so any
+		// referenced memory needs to be cleared up to the capacity
+		if !isValueType(t.Elem()) {
+			idx := loopVariable(depth)
+
+			fmt.Fprintf(w, "%s = %s[:cap(%s)]\n", dst, dst, dst)
+			fmt.Fprintf(w, "for %s := range %s {\n", idx, dst)
+			if err := generateResetField(w, ctx, dst+"["+idx+"]", t.Elem(), depth+1); err != nil {
+				return err
+			}
+			fmt.Fprint(w, "}\n")
+		}
+		fmt.Fprintf(w, "%s = %s[:0]\n", dst, dst)
+		return nil
+
+	case *types.Array:
+		idx := loopVariable(depth)
+
+		fmt.Fprintf(w, "for %s := range %s {\n", idx, dst)
+		if err := generateResetField(w, ctx, dst+"["+idx+"]", t.Elem(), depth+1); err != nil {
+			return err
+		}
+		fmt.Fprint(w, "}\n")
+		return nil
+
+	case *types.Map:
+		fmt.Fprintf(w, "clear(%s)\n", dst)
+		return nil
+
+	case *types.Struct:
+		// Objects stored by value are reset via their pointer receivers
+		if err := checkNestedExtra(ctx, typ, "ResetSSZ", extraReset); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s.ResetSSZ()\n", dst)
+		return nil
+
+	case *types.Pointer:
+		// Objects handle nil receivers themselves, everything else needs to be
+		// explicitly checked before clearing
+		_, slice := types.Unalias(t.Elem()).(*types.Slice)
+		if !slice && !isUint256(t.Elem()) && !isBigInt(t.Elem()) && !isValueType(t.Elem()) {
+			if err := checkNestedExtra(ctx, t.Elem(), "ResetSSZ", extraReset); err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "%s.ResetSSZ()\n", dst)
+			return nil
+		}
+		fmt.Fprintf(w, "if %s != nil {\n", dst)
+		switch {
+		case slice:
+			if err := generateResetField(w, ctx, "(*"+dst+")", t.Elem(), depth); err != nil {
+				return err
+			}
+		case isUint256(t.Elem()):
+			fmt.Fprintf(w, "%s.Clear()\n", dst)
+		case isBigInt(t.Elem()):
+			fmt.Fprintf(w, "%s.SetUint64(0)\n", dst)
+		default:
+			fmt.Fprintf(w, "*%s = %s\n", dst, zeroValue(ctx, t.Elem()))
+		}
+		fmt.Fprint(w, "}\n")
+		return nil
+
+	case *types.Interface:
+		// Type parameters can only be cleared by replacing them wholesale
+		if _, ok := typ.(*types.TypeParam); ok {
+			fmt.Fprintf(w, "%s = %s\n", dst, zeroValue(ctx, typ))
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported type %s", typ.String())
+}
+
+// generateUpgrade creates a method converting the object between the layouts of
+// two forks, zero initializing the fields added and clearing the fields removed
+// in between, recursing into any nested objects.
+func generateUpgrade(ctx *genContext, typ *sszContainer) ([]byte, error) {
+	var (
+		b    bytes.Buffer
+		body bytes.Buffer
+		name = typ.typeName()
+	)
+	ctx.addImport(sszPkgPath, "")
+
+	// Fields switching their encoding across forks would need their values
+	// converted between the layouts, which cannot be done generically
+	for i, field := range typ.fields {
+		if len(typ.forkOpsets[i]) > 0 {
+			return nil, fmt.Errorf("failed to upgrade field %s.%s: fork specific encodings (ssz-fork-type) are not supported", name, field)
+		}
+	}
+	for i := 0; i < len(typ.fields); {
+		// Group consecutive fields with the same fork constraint together
+		if typ.forks[i] == "" {
+			i++
+			continue
+		}
+		j := i + 1
+		for j < len(typ.fields) && typ.forks[j] == typ.forks[i] {
+			j++
+		}
+		// Pointers are allocated when the fields are added, everything else is
+		// reset to its zero value both when added and when removed
+		var pointers bool
+		for k := i; k < j; k++ {
+			if _, ok := types.Unalias(typ.types[k]).(*types.Pointer); ok {
+				pointers = true
+			}
+		}
+		fmt.Fprintf(&body, "	if was, now := %s, %s; ", forkCondition(typ.forks[i], "from"), forkCondition(typ.forks[i], "to"))
+		if !pointers {
+			fmt.Fprint(&body, "was != now {\n")
+			for k := i; k < j; k++ {
+				fmt.Fprintf(&body, "		obj.%s = %s\n", typ.fields[k], zeroValue(ctx, typ.types[k]))
+			}
+		} else {
+			fmt.Fprint(&body, "was && !now {\n")
+			for k := i; k < j; k++ {
+				fmt.Fprintf(&body, "		obj.%s = %s\n", typ.fields[k], zeroValue(ctx, typ.types[k]))
+			}
+			fmt.Fprint(&body, "	} else if !was && now {\n")
+			for k := i; k < j; k++ {
+				if ptr, ok := types.Unalias(typ.types[k]).(*types.Pointer); ok {
+					fmt.Fprintf(&body, "		obj.%s = new(%s)\n", typ.fields[k], ctx.typeString(ptr.Elem()))
+				} else {
+					fmt.Fprintf(&body, "		obj.%s = %s\n", typ.fields[k], zeroValue(ctx, typ.types[k]))
+				}
+			}
+		}
+		fmt.Fprint(&body, "	}\n")
+		i = j
+	}
+	for i, field := range typ.fields {
+		if err := generateUpgradeField(&body, ctx, "obj."+field, typ.types[i], 0); err != nil {
+			return nil, fmt.Errorf("failed to upgrade field %s.%s: %v", name, field, err)
+		}
+	}
+	fmt.Fprint(&b, "// UpgradeSSZ converts the object from the layout of one fork to another, zero\n")
+	fmt.Fprint(&b, "// initializing the fields added and clearing the fields removed in between.\n")
+	if body.Len() == 0 {
+		fmt.Fprintf(&b, "func (obj *%s) UpgradeSSZ(from ssz.Fork, to ssz.Fork) {}\n", name)
+		return b.Bytes(), nil
+	}
+	fmt.Fprintf(&b, "func (obj *%s) UpgradeSSZ(from ssz.Fork, to ssz.Fork) {\n", name)
+	fmt.Fprint(&b, "	if obj == nil {\n")
+	fmt.Fprint(&b, "		return\n")
+	fmt.Fprint(&b, "	}\n")
+	b.Write(body.Bytes())
+	fmt.Fprint(&b, "}\n")
+	return b.Bytes(), nil
+}
+
+// generateUpgradeField emits the code to upgrade any objects nested in a value.
+func generateUpgradeField(w io.Writer, ctx *genContext, dst string, typ types.Type, depth int) error {
+	if !hasNestedObjects(typ) {
+		return nil
+	}
+	switch t := typ.Underlying().(type) {
+	case *types.Slice:
+		idx := loopVariable(depth)
+
+		fmt.Fprintf(w, "for %s := range %s {\n", idx, dst)
+		if err := generateUpgradeField(w, ctx, dst+"["+idx+"]", t.Elem(), depth+1); err != nil {
+			return err
+		}
+		fmt.Fprint(w, "}\n")
+		return nil
+
+	case *types.Array:
+		idx := loopVariable(depth)
+
+		fmt.Fprintf(w, "for %s := range %s {\n", idx, dst)
+		if err := generateUpgradeField(w, ctx, dst+"["+idx+"]", t.Elem(), depth+1); err != nil {
+			return err
+		}
+		fmt.Fprint(w, "}\n")
+		return nil
+
+	case *types.Map:
+		// Map values are not addressable, only objects behind pointers can be
+		// upgraded in place
+		if err := checkNestedExtra(ctx, types.Unalias(t.Elem()).(*types.Pointer).Elem(), "UpgradeSSZ", extraUpgrade); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "for _, v := range %s {\n", dst)
+		fmt.Fprint(w, "v.UpgradeSSZ(from, to)\n")
+		fmt.Fprint(w, "}\n")
+		return nil
+
+	case *types.Pointer:
+		if _, ok := types.Unalias(t.Elem()).(*types.Slice); ok {
+			fmt.Fprintf(w, "if %s != nil {\n", dst)
+			if err := generateUpgradeField(w, ctx, "(*"+dst+")", t.Elem(), depth); err != nil {
+				return err
+			}
+			fmt.Fprint(w, "}\n")
+			return nil
+		}
+	}
+	// Objects handle nil receivers themselves
+	if ptr, ok := types.Unalias(typ).(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	if err := checkNestedExtra(ctx, typ, "UpgradeSSZ", extraUpgrade); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "%s.UpgradeSSZ(from, to)\n", dst)
+	return nil
+}
+
+// hasNestedObjects checks whether 'typ' is or contains ssz objects that need to be
+// recursed into (e.g. when upgrading across forks).
+func hasNestedObjects(typ types.Type) bool {
+	switch t := typ.Underlying().(type) {
+	case *types.Struct:
+		return !isBigInt(typ)
+	case *types.Slice:
+		return hasNestedObjects(t.Elem())
+	case *types.Array:
+		return hasNestedObjects(t.Elem())
+	case *types.Map:
+		_, ok := types.Unalias(t.Elem()).(*types.Pointer)
+		return ok && hasNestedObjects(t.Elem())
+	case *types.Pointer:
+		return hasNestedObjects(t.Elem())
+	}
+	return false
+}
+
+// checkNestedExtra ensures that a nested object type has an extra method called
+// by the code generated for the outer type, either hand-written or generated in
+// an earlier run, or being generated in this run. Otherwise the generated code
+// would not compile.
+func checkNestedExtra(ctx *genContext, typ types.Type, method string, extra string) error {
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok || ctx.targets[named.Origin()] {
+		return nil
+	}
+	if types.NewMethodSet(types.NewPointer(named)).Lookup(named.Obj().Pkg(), method) != nil {
+		return nil
+	}
+	return fmt.Errorf("nested type %s has no %s method, generate it with the %s extra too", named.Obj().Name(), method, extra)
+}
+
+// isValueType checks whether 'typ' does not reference any memory, meaning that
+// it can be copied by assignment and compared via the equality operator.
+func isValueType(typ types.Type) bool {
+	switch t := typ.Underlying().(type) {
+	case *types.Basic:
+		return true
+	case *types.Array:
+		return isValueType(t.Elem())
+	}
+	return false
+}
+
+// loopVariable returns the name of the index variable to use for iterating at
+// a given nesting depth.
+func loopVariable(depth int) string {
+	return string(rune('i' + depth))
+}
+
+// generateCache creates the cached hashing method for the type, along with setters
+// for all the ssz fields that invalidate the cached root on modification.
+func generateCache(ctx *genContext, typ *sszContainer) ([]byte, error) {
+	var (
+		b    bytes.Buffer
+		name = typ.typeName()
+	)
+	if typ.cache == "" {
+		return nil, fmt.Errorf("failed to generate cache for %s: no embedded ssz.RootCache field", name)
+	}
+	ctx.addImport(sszPkgPath, "")
+
+	fmt.Fprint(&b, "// HashTreeRootCached returns the merkle root of the object in the given fork,\n")
+	fmt.Fprint(&b, "// only rehashing it if it was modified (via the setters) since the last call.\n")
+	fmt.Fprintf(&b, "func (obj *%s) HashTreeRootCached(fork ssz.Fork) [32]byte {\n", name)
+	fmt.Fprintf(&b, "	return ssz.HashSequentialCached(obj, &obj.%s, fork)\n", typ.cache)
+	fmt.Fprint(&b, "}\n")
+
+	for i, field := range typ.fields {
+		fmt.Fprintf(&b, "\n// Set%s sets the %s field, invalidating the cached merkle root.\n", field, field)
+		fmt.Fprintf(&b, "func (obj *%s) Set%s(v %s) {\n", name, field, ctx.typeString(typ.types[i]))
+		fmt.Fprintf(&b, "	obj.%s = v\n", field)
+		fmt.Fprintf(&b, "	obj.%s.Invalidate()\n", typ.cache)
+		fmt.Fprint(&b, "}\n")
+	}
+	return b.Bytes(), nil
+}
+
+// generateGetters creates protobuf style accessors for all the ssz fields, which
+// are safe to call on a nil object, returning the zero value of the field.
+func generateGetters(ctx *genContext, typ *sszContainer) ([]byte, error) {
+	var (
+		b    bytes.Buffer
+		name = typ.typeName()
+	)
+	for i, field := range typ.fields {
+		if i > 0 {
+			fmt.Fprint(&b, "\n")
+		}
+		fmt.Fprintf(&b, "// Get%s returns the %s field, or its zero value if the object is nil.\n", field, field)
+		fmt.Fprintf(&b, "func (obj *%s) Get%s() %s {\n", name, field, ctx.typeString(typ.types[i]))
+		fmt.Fprint(&b, "	if obj != nil {\n")
+		fmt.Fprintf(&b, "		return obj.%s\n", field)
+		fmt.Fprint(&b, "	}\n")
+		fmt.Fprintf(&b, "	return %s\n", zeroValue(ctx, typ.types[i]))
+		fmt.Fprint(&b, "}\n")
+	}
+	return b.Bytes(), nil
+}
+
+// zeroValue returns the literal expression of a type's zero value.
+func zeroValue(ctx *genContext, typ types.Type) string {
+	switch t := typ.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case t.Info()&types.IsBoolean != 0:
+			return "false"
+		case t.Info()&types.IsString != 0:
+			return `""`
+		default:
+			return "0"
+		}
+	case *types.Array, *types.Struct:
+		return ctx.typeString(typ) + "{}"
+	case *types.Interface:
+		// Type parameters have an interface as their underlying type, but
+		// they can't be compared to nil, so need the generic zero value
+		if _, ok := typ.(*types.TypeParam); ok {
+			return "*new(" + ctx.typeString(typ) + ")"
+		}
+	}
+	return "nil"
+}
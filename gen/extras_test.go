@@ -0,0 +1,65 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package gen
+
+import (
+	"strings"
+	"testing"
+)
+
+// nestedExtrasTestSource is a package with an outer type referencing a nested one
+// both directly and in a list, to check that the extras recursing into nested
+// objects require them to have the same extras.
+const nestedExtrasTestSource = `package nestedtest
+
+import "github.com/karalabe/ssz"
+
+type Inner struct {
+	A uint64
+}
+
+func (obj *Inner) SizeSSZ(sizer *ssz.Sizer) uint32 { return 8 }
+func (obj *Inner) DefineSSZ(codec *ssz.Codec)      { ssz.DefineUint64(codec, &obj.A) }
+
+type Outer struct {
+	Ptr  *Inner
+	List []*Inner ` + "`ssz-max:\"4\"`" + `
+}
+`
+
+// Tests that generating an extra method that calls into nested objects requires
+// them to be generated with the same extra.
+func TestGenerateNestedExtras(t *testing.T) {
+	dir := writeTestPackage(t, "nestedtest", nestedExtrasTestSource)
+
+	for _, extra := range []string{extraReset, extraUpgrade} {
+		opts := &Options{Dir: dir, Extras: []string{extra}}
+		if _, _, err := Generate(".", []string{"Outer"}, opts); err == nil || !strings.Contains(err.Error(), "nested type Inner") {
+			t.Errorf("%s: missing nested method error mismatch: have %v", extra, err)
+		}
+		if _, _, err := Generate(".", []string{"Outer", "Inner"}, opts); err != nil {
+			t.Errorf("%s: failed to generate with nested type: %v", extra, err)
+		}
+	}
+}
+
+// Tests that upgrades are rejected for fields switching their encoding across
+// forks, as their values cannot be converted generically.
+func TestGenerateUpgradeForkTypes(t *testing.T) {
+	dir := writeTestPackage(t, "forktypetest", `package forktypetest
+
+type Switching struct {
+	A uint64
+	B []byte `+"`ssz-size:\"4\" ssz-fork-type:\"electra:CheckedStaticBytes(8)\"`"+`
+}
+`)
+	opts := &Options{Dir: dir, Extras: []string{extraUpgrade}}
+	if _, _, err := Generate(".", nil, opts); err == nil || !strings.Contains(err.Error(), "ssz-fork-type") {
+		t.Errorf("fork specific encoding upgrade error mismatch: have %v", err)
+	}
+	if _, _, err := Generate(".", nil, &Options{Dir: dir}); err != nil {
+		t.Errorf("failed to generate without upgrade: %v", err)
+	}
+}
@@ -0,0 +1,235 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package gen
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// forkMapping maps fork names to fork values. This is used internally by the
+// ssz codec generator to convert tags to values.
+var forkMapping = map[string]string{
+	"unknown":        "Unknown",
+	"frontier":       "Frontier",
+	"homestead":      "Homestead",
+	"dao":            "DAO",
+	"tangerine":      "Tangerine",
+	"spurious":       "Spurious",
+	"byzantium":      "Byzantium",
+	"constantinople": "Constantinople",
+	"istanbul":       "Istanbul",
+	"muir":           "Muir",
+	"phase0":         "Phase0",
+	"berlin":         "Berlin",
+	"london":         "London",
+	"altair":         "Altair",
+	"arrow":          "Arrow",
+	"gray":           "Gray",
+	"bellatrix":      "Bellatrix",
+	"paris":          "Paris",
+	"merge":          "Merge",
+	"shapella":       "Shapella",
+	"shanghai":       "Shanghai",
+	"capella":        "Capella",
+	"dencun":         "Dencun",
+	"cancun":         "Cancun",
+	"deneb":          "Deneb",
+	"pectra":         "Pectra",
+	"prague":         "Prague",
+	"electra":        "Electra",
+	"future":         "Future",
+}
+
+// forkCustom tracks the fork enum names that were declared as custom forks in the
+// target package, rather than being built into the ssz library.
+var forkCustom = make(map[string]bool)
+
+// registerForks extends the fork mapping with any custom forks declared in the
+// target package as constants of type ssz.Fork, named ForkXyz (e.g. `const
+// ForkMyChain = ssz.ForkElectra + 1`). The tag name is the lowercase suffix.
+//
+// Any custom forks registered for a previously processed package are dropped.
+func registerForks(library *types.Package, target *types.Package) {
+	for enum := range forkCustom {
+		delete(forkMapping, strings.ToLower(enum))
+		delete(forkCustom, enum)
+	}
+	fork := library.Scope().Lookup("Fork").Type()
+	for _, name := range target.Scope().Names() {
+		obj, ok := target.Scope().Lookup(name).(*types.Const)
+		if !ok || !strings.HasPrefix(name, "Fork") || len(name) == len("Fork") || !types.Identical(obj.Type(), fork) {
+			continue
+		}
+		tag := strings.ToLower(name[len("Fork"):])
+		if _, ok := forkMapping[tag]; ok {
+			continue
+		}
+		forkMapping[tag] = name[len("Fork"):]
+		forkCustom[name[len("Fork"):]] = true
+	}
+}
+
+// forkIdent generates the Go identifier of a fork enum name, referencing either
+// the ssz library or the custom forks of the target package.
+func forkIdent(enum string) string {
+	if forkCustom[enum] {
+		return "Fork" + enum
+	}
+	return "ssz.Fork" + enum
+}
+
+// forkRange is a half-open range of forks [added, removed) in which a field is
+// present. An empty bound means the range is unbounded in that direction.
+type forkRange struct {
+	added   string // fork enum name (without the Fork prefix)
+	removed string // fork enum name (without the Fork prefix)
+}
+
+// parseForkRanges converts the fork constraint of a field into a list of ranges.
+// The constraint may be a single fork the field was added in ("X"), a single fork
+// the field was removed in ("!X"), or a list of ranges ("A-B,C").
+func parseForkRanges(fork string) []forkRange {
+	switch {
+	case fork == "":
+		return nil
+	case fork[0] == '!':
+		return []forkRange{{removed: fork[1:]}}
+	}
+	var ranges []forkRange
+	for _, part := range strings.Split(fork, ",") {
+		bounds := strings.Split(part, "-")
+		if len(bounds) == 1 {
+			ranges = append(ranges, forkRange{added: bounds[0]})
+		} else {
+			ranges = append(ranges, forkRange{added: bounds[0], removed: bounds[1]})
+		}
+	}
+	return ranges
+}
+
+// forkAlways reports whether a fork constraint covers all the forks, meaning the
+// field is present in every fork (e.g. "unknown" used to allow a nil pointer).
+func forkAlways(fork string) bool {
+	ranges := parseForkRanges(fork)
+	if len(ranges) != 1 {
+		return len(ranges) == 0
+	}
+	return ranges[0].removed == "" && (ranges[0].added == "" || ranges[0].added == "Unknown")
+}
+
+// forkCondition generates a Go expression checking whether the fork in expr is
+// within the fork constraint of a field.
+func forkCondition(fork string, expr string) string {
+	ranges := parseForkRanges(fork)
+
+	var conds []string
+	for _, r := range ranges {
+		var checks []string
+		if r.added != "" {
+			checks = append(checks, fmt.Sprintf("%s >= %s", expr, forkIdent(r.added)))
+		}
+		if r.removed != "" {
+			checks = append(checks, fmt.Sprintf("%s < %s", expr, forkIdent(r.removed)))
+		}
+		cond := strings.Join(checks, " && ")
+		if len(ranges) > 1 && len(checks) > 1 {
+			cond = "(" + cond + ")"
+		}
+		conds = append(conds, cond)
+	}
+	return strings.Join(conds, " || ")
+}
+
+// forkActive reports whether a fork (by its numeric value) is within the fork
+// constraint of a field.
+func forkActive(library *types.Package, target *types.Package, fork string, value int64) (bool, error) {
+	for _, r := range parseForkRanges(fork) {
+		if r.added != "" {
+			added, err := forkValue(library, target, r.added)
+			if err != nil {
+				return false, err
+			}
+			if value < added {
+				continue
+			}
+		}
+		if r.removed != "" {
+			removed, err := forkValue(library, target, r.removed)
+			if err != nil {
+				return false, err
+			}
+			if value >= removed {
+				continue
+			}
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// forkSetOwner is the name of the type currently being generated, used to scope
+// the package level ssz.ForkFilterSet variables it needs.
+var forkSetOwner string
+
+// forkSets collects the ssz.ForkFilterSet variables (name to literal) needed by
+// the fork filters of the type currently being generated.
+var forkSets = make(map[string]string)
+
+// forkFilter generates a Go ssz.ForkFilter literal for the fork constraint of a
+// field, falling back to a reference to a package level ssz.ForkFilterSet for
+// multiple disjoint ranges (collected into forkSets).
+func forkFilter(fork string) string {
+	ranges := parseForkRanges(fork)
+
+	var (
+		filters = make([]string, len(ranges))
+		names   = make([]string, len(ranges))
+	)
+	for i, r := range ranges {
+		var fields []string
+		if r.added != "" {
+			fields = append(fields, "Added: "+forkIdent(r.added))
+			names[i] = r.added
+		}
+		if r.removed != "" {
+			fields = append(fields, "Removed: "+forkIdent(r.removed))
+			names[i] += "To" + r.removed
+		}
+		filters[i] = "ssz.ForkFilter{" + strings.Join(fields, ", ") + "}"
+	}
+	if len(filters) == 1 {
+		return filters[0]
+	}
+	name := "forkRanges" + forkSetOwner + strings.Join(names, "Or")
+	forkSets[name] = "ssz.ForkFilterSet{" + strings.Join(filters, ", ") + "}"
+
+	return "ssz.ForkFilter{Ranges: &" + name + "}"
+}
+
+// generateForkSets generates the package level ssz.ForkFilterSet variables the
+// fork filters of a type reference, so the filters stay comparable and calls do
+// not allocate a new set every time.
+func generateForkSets() []byte {
+	if len(forkSets) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(forkSets))
+	for name := range forkSets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Fork ranges of the fields present in multiple disjoint fork ranges.\n")
+	fmt.Fprintf(&b, "var (\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "	%s = %s\n", name, forkSets[name])
+	}
+	fmt.Fprintf(&b, ")\n")
+	return []byte(b.String())
+}
@@ -0,0 +1,863 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/types"
+	"html/template"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	offsetBytes = 4
+	sszPkgPath  = "github.com/karalabe/ssz"
+)
+
+type genContext struct {
+	pkg     *types.Package
+	imports map[string]string
+	extras  map[string]bool
+	library *types.Package        // ssz library to resolve static size tables with (nil = resolve on init)
+	values  map[*types.Named]bool // types to generate value receivers for (read-only)
+	targets map[*types.Named]bool // types being generated in this run
+}
+
+func newGenContext(pkg *types.Package, extras []string) *genContext {
+	ctx := &genContext{
+		pkg:     pkg,
+		imports: make(map[string]string),
+		extras:  make(map[string]bool),
+		values:  make(map[*types.Named]bool),
+		targets: make(map[*types.Named]bool),
+	}
+	for _, extra := range extras {
+		ctx.extras[extra] = true
+	}
+	return ctx
+}
+
+func (ctx *genContext) addImport(path string, alias string) error {
+	if path == ctx.pkg.Path() {
+		return nil
+	}
+	if n, ok := ctx.imports[path]; ok && n != alias {
+		return fmt.Errorf("conflict import %s(alias: %s-%s)", path, n, alias)
+	}
+	ctx.imports[path] = alias
+	return nil
+}
+
+// receiver returns the receiver type of the ssz methods of a container, which
+// is a pointer, unless value receivers were requested for it.
+func (ctx *genContext) receiver(typ *sszContainer) string {
+	if ctx.values[typ.named] {
+		return typ.typeName()
+	}
+	return "*" + typ.typeName()
+}
+
+// zeroObject returns the expression of a zero object of a container type, usable
+// to call its ssz methods on: a nil pointer for pointer receivers, or an empty
+// struct for value receivers (either generated now or already existing).
+func (ctx *genContext) zeroObject(typ types.Type) string {
+	if named, ok := types.Unalias(typ).(*types.Named); ok {
+		if ctx.values[named] || types.NewMethodSet(named).Lookup(named.Obj().Pkg(), "SizeSSZ") != nil {
+			return ctx.typeString(typ) + "{}"
+		}
+	}
+	return "(*" + ctx.typeString(typ) + ")(nil)"
+}
+
+// typeString returns the textual representation of a type as usable from within
+// the generated package, importing any foreign packages it references.
+func (ctx *genContext) typeString(typ types.Type) string {
+	return types.TypeString(typ, func(pkg *types.Package) string {
+		if pkg.Path() == ctx.pkg.Path() {
+			return ""
+		}
+		if alias, ok := ctx.imports[pkg.Path()]; ok && alias != "" {
+			return alias
+		}
+		ctx.addImport(pkg.Path(), "")
+		return pkg.Name()
+	})
+}
+
+func (ctx *genContext) header() []byte {
+	var paths sort.StringSlice
+	for path := range ctx.imports {
+		paths = append(paths, path)
+	}
+	sort.Sort(paths)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "package %s\n", ctx.pkg.Name())
+	if len(paths) == 0 {
+		return b.Bytes()
+	}
+	if len(paths) == 1 {
+		alias := ctx.imports[paths[0]]
+		if alias == "" {
+			fmt.Fprintf(&b, "import \"%s\"\n", paths[0])
+		} else {
+			fmt.Fprintf(&b, "import %s \"%s\"\n", alias, paths[0])
+		}
+		return b.Bytes()
+	}
+	fmt.Fprintf(&b, "import (\n")
+	for _, path := range paths {
+		alias := ctx.imports[path]
+		if alias == "" {
+			fmt.Fprintf(&b, "\"%s\"\n", path)
+		} else {
+			fmt.Fprintf(&b, "%s \"%s\"\n", alias, path)
+		}
+	}
+	fmt.Fprintf(&b, ")\n")
+	return b.Bytes()
+}
+
+func generate(ctx *genContext, typ *sszContainer) ([]byte, error) {
+	fns := []func(ctx *genContext, typ *sszContainer) ([]byte, error){
+		generateSizeSSZ,
+		generateDefineSSZ,
+		generateNamesSSZ,
+	}
+	if ctx.extras[extraClone] {
+		fns = append(fns, generateClone)
+	}
+	if ctx.extras[extraEqual] {
+		fns = append(fns, generateEqual)
+	}
+	if ctx.extras[extraCache] {
+		fns = append(fns, generateCache)
+	}
+	if ctx.extras[extraGetters] {
+		fns = append(fns, generateGetters)
+	}
+	if ctx.extras[extraReset] {
+		fns = append(fns, generateReset)
+	}
+	if ctx.extras[extraUpgrade] {
+		fns = append(fns, generateUpgrade)
+	}
+	// Collect the fork range sets referenced by the type to declare them once
+	forkSetOwner = typ.named.Obj().Name()
+	clear(forkSets)
+
+	var codes [][]byte
+	for _, fn := range fns {
+		code, err := fn(ctx, typ)
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+	for _, entry := range typ.entries {
+		code, err := generateMapEntry(ctx, entry)
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+	if sets := generateForkSets(); sets != nil {
+		codes = append(codes, sets)
+	}
+	//fmt.Println(string(bytes.Join(codes, []byte("\n"))))
+	return bytes.Join(codes, []byte("\n")), nil
+}
+
+// staticSize computes the total byte size of a static field from its dimensions.
+func staticSize(bytes []int) int {
+	size := 1
+	for _, dim := range bytes {
+		size *= dim
+	}
+	return size
+}
+
+// staticSizeExpr formats the byte size of a static field as a product of its
+// dimensions (e.g. 4*8192*8), leaving the multiplication to the compiler. Any
+// dimensions declared via named constants are emitted by name.
+func staticSizeExpr(bytes []int, names []string) string {
+	dims := make([]string, len(bytes))
+	for i, dim := range bytes {
+		if i < len(names) && names[i] != "" {
+			dims[i] = names[i]
+		} else {
+			dims[i] = strconv.Itoa(dim)
+		}
+	}
+	return strings.Join(dims, "*")
+}
+
+// generateStaticSizeAccumulator is a helper to iterate over all the fields and
+// accumulate the static sizes into a `size` variable based on fork constraints.
+func generateStaticSizeAccumulator(w io.Writer, ctx *genContext, typ *sszContainer) {
+	// Fields switching their static sizes across forks can't be summed up in a
+	// single expression, they need a size switch of their own
+	switched := func(i int) bool {
+		base, ok := typ.opsets[i].(*opsetStatic)
+		if !ok {
+			return false
+		}
+		for _, alt := range typ.forkOpsets[i] {
+			if staticSize(alt.opset.(*opsetStatic).bytes) != staticSize(base.bytes) {
+				return true
+			}
+		}
+		return false
+	}
+	plain := func(i int) bool {
+		return typ.forks[i] == "" && !switched(i)
+	}
+	for i := range typ.opsets {
+		switch {
+		case plain(i) && i == 0:
+			fmt.Fprintf(w, "	size = ")
+		case plain(i) && plain(i-1):
+			fmt.Fprintf(w, " + ")
+		case plain(i) && !plain(i-1):
+			fmt.Fprintf(w, "\n	size += ")
+		case !plain(i) && i > 0 && (typ.forks[i-1] != typ.forks[i] || switched(i)):
+			fmt.Fprintf(w, "\n")
+		}
+		if switched(i) {
+			calls := []string{"size += " + staticSizeExpr(typ.opsets[i].(*opsetStatic).bytes, typ.opsets[i].(*opsetStatic).names)}
+			for _, alt := range typ.forkOpsets[i] {
+				calls = append(calls, "size += "+staticSizeExpr(alt.opset.(*opsetStatic).bytes, alt.opset.(*opsetStatic).names))
+			}
+			fmt.Fprint(w, strings.TrimSuffix(generateForkSwitch("	", "sizer.Fork()", typ.forkOpsets[i], calls), "\n"))
+			continue
+		}
+		if typ.forks[i] != "" {
+			if i == 0 || typ.forks[i] != typ.forks[i-1] {
+				fmt.Fprintf(w, "	if %s {\n", forkCondition(typ.forks[i], "sizer.Fork()"))
+				fmt.Fprintf(w, "		size += ")
+			} else {
+				fmt.Fprintf(w, " + ")
+			}
+		}
+		switch t := typ.opsets[i].(type) {
+		case *opsetStatic:
+			if t.bytes != nil {
+				fmt.Fprint(w, staticSizeExpr(t.bytes, t.names))
+			} else {
+				// Type parameters are sized via their zero value, which is a nil
+				// pointer, same as for concrete object types
+				if param, ok := typ.types[i].(*types.TypeParam); ok {
+					fmt.Fprintf(w, "(*new(%s)).SizeSSZ(sizer)", param.Obj().Name())
+				} else {
+					elem := types.Unalias(typ.types[i]).(*types.Pointer).Elem()
+					fmt.Fprintf(w, "%s.SizeSSZ(sizer)", ctx.zeroObject(elem))
+				}
+			}
+		case *opsetDynamic:
+			fmt.Fprintf(w, "%d", offsetBytes)
+		}
+		if typ.forks[i] != "" && (i == len(typ.forks)-1 || typ.forks[i] != typ.forks[i+1]) {
+			fmt.Fprintf(w, "\n	}")
+		}
+	}
+	fmt.Fprintf(w, "	\n")
+}
+
+// generateDynamicSizeAccumulator is a helper to iterate over all the dynamic fields
+// and accumulate their sizes into a `size` variable based on fork constraints.
+func generateDynamicSizeAccumulator(w io.Writer, typ *sszContainer) {
+	var (
+		dynFields []string
+		dynOpsets []opset
+		dynForks  []string
+		dynAlts   [][]forkOpset
+	)
+	for i := 0; i < len(typ.fields); i++ {
+		if _, ok := (typ.opsets[i]).(*opsetDynamic); ok {
+			dynFields = append(dynFields, typ.fields[i])
+			dynOpsets = append(dynOpsets, typ.opsets[i])
+			dynForks = append(dynForks, typ.forks[i])
+			dynAlts = append(dynAlts, typ.forkOpsets[i])
+		}
+	}
+	for i := range dynFields {
+		if dynForks[i] != "" && (i == 0 || dynForks[i] != dynForks[i-1]) {
+			fmt.Fprintf(w, "	if %s {\n", forkCondition(dynForks[i], "sizer.Fork()"))
+		}
+		calls := []string{"size += ssz." + generateCall(dynOpsets[i].(*opsetDynamic).size, "", "sizer", "obj."+dynFields[i], nil, nil, dynOpsets[i].(*opsetDynamic).limits...)}
+		for _, alt := range dynAlts[i] {
+			calls = append(calls, "size += ssz."+generateCall(alt.opset.(*opsetDynamic).size, "", "sizer", "obj."+dynFields[i], nil, nil, alt.opset.(*opsetDynamic).limits...))
+		}
+		fmt.Fprint(w, generateForkSwitch("	", "sizer.Fork()", dynAlts[i], calls))
+
+		if dynForks[i] != "" && (i == len(dynForks)-1 || dynForks[i] != dynForks[i+1]) {
+			fmt.Fprintf(w, "	}\n")
+		}
+	}
+	if dynForks[len(dynForks)-1] == "" {
+		fmt.Fprintf(w, "\n")
+	}
+}
+
+func generateSizeSSZ(ctx *genContext, typ *sszContainer) ([]byte, error) {
+	var b bytes.Buffer
+
+	// If requested, resolve the static sizes at generation time, replacing the
+	// package init computation with per-fork constants
+	if ctx.library != nil && typ.named.TypeParams().Len() == 0 {
+		if steps := resolveStaticSizeSteps(ctx, typ); steps != nil {
+			if typ.static {
+				fmt.Fprint(&b, "// SizeSSZ returns the total size of the static ssz object.\n")
+				if len(steps) == 1 {
+					fmt.Fprintf(&b, "func (obj %s) SizeSSZ(sizer *ssz.Sizer) uint32 {\n", ctx.receiver(typ))
+					fmt.Fprintf(&b, "	return %d\n}\n", steps[0].size)
+					return b.Bytes(), nil
+				}
+				fmt.Fprintf(&b, "func (obj %s) SizeSSZ(sizer *ssz.Sizer) (size uint32) {\n", ctx.receiver(typ))
+				generateStaticSizeSteps(&b, steps)
+				fmt.Fprintf(&b, "	return size\n}\n")
+				return b.Bytes(), nil
+			}
+			fmt.Fprintf(&b, "// SizeSSZ returns either the static size of the object if fixed == true, or\n// the total size otherwise.\n")
+			fmt.Fprintf(&b, "func (obj %s) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {\n", ctx.receiver(typ))
+			generateStaticSizeSteps(&b, steps)
+			fmt.Fprintf(&b, "	if (fixed) {\n")
+			fmt.Fprintf(&b, "		return size\n")
+			fmt.Fprintf(&b, "	}\n")
+			generateDynamicSizeAccumulator(&b, typ)
+			fmt.Fprintf(&b, "	return size\n")
+			fmt.Fprintf(&b, "}\n")
+			return b.Bytes(), nil
+		}
+	}
+	// Generate the code itself
+	if typ.static {
+		// Iterate through the fields to see if the size can be computed compile
+		// time or if runtime resolutions are needed.
+		var (
+			runtime  bool
+			monolith bool
+		)
+		for i := range typ.opsets {
+			if typ.opsets[i].(*opsetStatic).bytes == nil {
+				runtime = true
+			}
+			if typ.forks[i] != "" || len(typ.forkOpsets[i]) > 0 {
+				monolith = true
+			}
+		}
+		// If some types require runtime size determination, generate a helper
+		// variable to run it on package init. Generic types cannot have package
+		// level variables, so they always need to compute it on the fly.
+		if runtime && typ.named.TypeParams().Len() == 0 {
+			fmt.Fprintf(&b, "// Cached static size computed on package init.\n")
+			fmt.Fprintf(&b, "var staticSizeCache%s = ssz.PrecomputeStaticSizeCache(%s)\n\n", typ.named.Obj().Name(), ctx.zeroObject(typ.named))
+
+			fmt.Fprintf(&b, "// SizeSSZ returns the total size of the static ssz object.\n")
+			fmt.Fprintf(&b, "func (obj %s) SizeSSZ(sizer *ssz.Sizer) (size uint32) {\n", ctx.receiver(typ))
+			fmt.Fprintf(&b, "	if fork := int(sizer.Fork()); fork < len(staticSizeCache%s) {\n", typ.named.Obj().Name())
+			fmt.Fprintf(&b, "		return staticSizeCache%s[fork]\n", typ.named.Obj().Name())
+			fmt.Fprintf(&b, "	}\n")
+
+			generateStaticSizeAccumulator(&b, ctx, typ)
+			fmt.Fprintf(&b, "	return size\n}\n")
+		} else {
+			fmt.Fprint(&b, "// SizeSSZ returns the total size of the static ssz object.\n")
+			if monolith || runtime {
+				fmt.Fprintf(&b, "func (obj %s) SizeSSZ(sizer *ssz.Sizer) (size uint32) {\n", ctx.receiver(typ))
+				generateStaticSizeAccumulator(&b, ctx, typ)
+				fmt.Fprintf(&b, "	return size\n}\n")
+			} else {
+				fmt.Fprintf(&b, "func (obj %s) SizeSSZ(sizer *ssz.Sizer) uint32 {\n", ctx.receiver(typ))
+				fmt.Fprintf(&b, "	return ")
+				for i := range typ.opsets {
+					fmt.Fprint(&b, staticSizeExpr(typ.opsets[i].(*opsetStatic).bytes, typ.opsets[i].(*opsetStatic).names))
+					if i < len(typ.opsets)-1 {
+						fmt.Fprint(&b, " + ")
+					}
+				}
+				fmt.Fprintf(&b, "\n}\n")
+			}
+		}
+	} else {
+		// Iterate through the fields to see if the static size can be computed
+		// compile time or if runtime resolutions are needed even for statics.
+		var runtime bool
+		for i := range typ.opsets {
+			if typ, ok := typ.opsets[i].(*opsetStatic); ok {
+				if typ.bytes == nil {
+					runtime = true
+				}
+			}
+		}
+		// If some types require runtime size determination, generate a helper
+		// variable to run it on package init (unless generic, see above)
+		if runtime && typ.named.TypeParams().Len() == 0 {
+			fmt.Fprintf(&b, "// Cached static size computed on package init.\n")
+			fmt.Fprintf(&b, "var staticSizeCache%s = ssz.PrecomputeStaticSizeCache(%s)\n\n", typ.named.Obj().Name(), ctx.zeroObject(typ.named))
+
+			fmt.Fprintf(&b, "// SizeSSZ returns either the static size of the object if fixed == true, or\n// the total size otherwise.\n")
+			fmt.Fprintf(&b, "func (obj %s) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {\n", ctx.receiver(typ))
+			fmt.Fprintf(&b, "	// Load static size if already precomputed, calculate otherwise\n")
+			fmt.Fprintf(&b, "	if fork := int(sizer.Fork()); fork < len(staticSizeCache%s) {\n", typ.named.Obj().Name())
+			fmt.Fprintf(&b, "		size = staticSizeCache%s[fork]\n", typ.named.Obj().Name())
+			fmt.Fprintf(&b, "	} else {\n")
+			generateStaticSizeAccumulator(&b, ctx, typ)
+			fmt.Fprintf(&b, "	}\n")
+			fmt.Fprintf(&b, "	// Either return the static size or accumulate the dynamic too\n")
+			fmt.Fprintf(&b, "	if (fixed) {\n")
+			fmt.Fprintf(&b, "		return size\n")
+			fmt.Fprintf(&b, "	}\n")
+			generateDynamicSizeAccumulator(&b, typ)
+			fmt.Fprintf(&b, "	return size\n")
+			fmt.Fprintf(&b, "}\n")
+		} else {
+			fmt.Fprintf(&b, "\n\n// SizeSSZ returns either the static size of the object if fixed == true, or\n// the total size otherwise.\n")
+			fmt.Fprintf(&b, "func (obj %s) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {\n", ctx.receiver(typ))
+			generateStaticSizeAccumulator(&b, ctx, typ)
+			fmt.Fprintf(&b, "	if (fixed) {\n")
+			fmt.Fprintf(&b, "		return size\n")
+			fmt.Fprintf(&b, "	}\n")
+
+			generateDynamicSizeAccumulator(&b, typ)
+			fmt.Fprintf(&b, "	return size\n")
+			fmt.Fprintf(&b, "}\n")
+		}
+	}
+	return b.Bytes(), nil
+}
+
+// staticSizeStep is the static size of a container from a fork onward.
+type staticSizeStep struct {
+	fork string // fork enum name the size takes effect from ("" for the first step)
+	size uint32 // static size of the container from the fork onward
+}
+
+// resolveStaticSizeSteps computes the static size of a container in every fork
+// known to the library, collapsed into the forks where the size changes. If the
+// size cannot be resolved at generation time (e.g. it contains objects that are
+// not declared as structs, or sizes declared via named constants), nil is returned.
+//
+// Nested objects are sized by their struct declarations, so they are expected to
+// be generated by sszgen too (or to follow the same layout if hand-written).
+func resolveStaticSizeSteps(ctx *genContext, typ *sszContainer) []staticSizeStep {
+	future, err := forkValue(ctx.library, ctx.pkg, "Future")
+	if err != nil {
+		return nil
+	}
+	var (
+		names = make(map[int64]string)
+		steps []staticSizeStep
+	)
+	// Fork constraints can only reference forks up to the future one, so sizing
+	// it covers all the forks after too
+	for fork := int64(0); fork <= future; fork++ {
+		size, ok := resolveStaticSize(ctx, typ, fork, names)
+		if !ok {
+			return nil
+		}
+		if len(steps) == 0 {
+			steps = append(steps, staticSizeStep{size: uint32(size)})
+		} else if steps[len(steps)-1].size != uint32(size) {
+			steps = append(steps, staticSizeStep{fork: names[fork], size: uint32(size)})
+		}
+	}
+	return steps
+}
+
+// resolveStaticSize computes the static size of a container in a single fork,
+// collecting the names of all the forks referenced by the fork constraints.
+func resolveStaticSize(ctx *genContext, typ *sszContainer, fork int64, names map[int64]string) (int, bool) {
+	var size int
+	for i := range typ.opsets {
+		// Skip any fields not present in the fork, picking the active encoding of
+		// the ones switching across forks
+		if typ.forks[i] != "" {
+			for _, r := range parseForkRanges(typ.forks[i]) {
+				for _, name := range []string{r.added, r.removed} {
+					if name != "" {
+						value, err := forkValue(ctx.library, ctx.pkg, name)
+						if err != nil {
+							return 0, false
+						}
+						names[value] = name
+					}
+				}
+			}
+			active, err := forkActive(ctx.library, ctx.pkg, typ.forks[i], fork)
+			if err != nil {
+				return 0, false
+			}
+			if !active {
+				continue
+			}
+		}
+		op := typ.opsets[i]
+		for _, alt := range typ.forkOpsets[i] {
+			value, err := forkValue(ctx.library, ctx.pkg, alt.fork)
+			if err != nil {
+				return 0, false
+			}
+			if names[value] = alt.fork; value <= fork {
+				op = alt.opset
+			}
+		}
+		switch op := op.(type) {
+		case *opsetDynamic:
+			size += offsetBytes
+		case *opsetStatic:
+			// Sizes declared via named constants may differ across builds (e.g.
+			// consensus presets selected by build tags), leave them to runtime
+			if slices.ContainsFunc(op.names, func(name string) bool { return name != "" }) {
+				return 0, false
+			}
+			if op.bytes != nil {
+				size += staticSize(op.bytes)
+				continue
+			}
+			// Nested static object, resolve it from its struct declaration
+			ptr, ok := types.Unalias(typ.types[i]).(*types.Pointer)
+			if !ok {
+				return 0, false
+			}
+			named, ok := types.Unalias(ptr.Elem()).(*types.Named)
+			if !ok || named.TypeParams().Len() > 0 {
+				return 0, false
+			}
+			str, ok := named.Underlying().(*types.Struct)
+			if !ok {
+				return 0, false
+			}
+			nested, err := newParseContext(ctx.library).makeContainer(named, str)
+			if err != nil || !nested.static {
+				return 0, false
+			}
+			inner, ok := resolveStaticSize(ctx, nested, fork, names)
+			if !ok {
+				return 0, false
+			}
+			size += inner
+		}
+	}
+	return size, true
+}
+
+// generateStaticSizeSteps emits the assignment of the static size of a container
+// into a `size` variable, switching on the fork where it changes.
+func generateStaticSizeSteps(w io.Writer, steps []staticSizeStep) {
+	if len(steps) == 1 {
+		fmt.Fprintf(w, "	size = %d\n", steps[0].size)
+		return
+	}
+	for i := len(steps) - 1; i > 0; i-- {
+		if i == len(steps)-1 {
+			fmt.Fprintf(w, "	if fork := sizer.Fork(); fork >= %s {\n", forkIdent(steps[i].fork))
+		} else {
+			fmt.Fprintf(w, "	} else if fork >= %s {\n", forkIdent(steps[i].fork))
+		}
+		fmt.Fprintf(w, "		size = %d\n", steps[i].size)
+	}
+	fmt.Fprintf(w, "	} else {\n")
+	fmt.Fprintf(w, "		size = %d\n", steps[0].size)
+	fmt.Fprintf(w, "	}\n")
+}
+
+func generateDefineSSZ(ctx *genContext, typ *sszContainer) ([]byte, error) {
+	var b bytes.Buffer
+
+	// Add a needed import of the ssz encoder
+	ctx.addImport(sszPkgPath, "")
+
+	// Iterate through the fields names to compute some comment formatting mods
+	var (
+		maxFieldLength = 0
+		maxBytes       = 1
+	)
+	for i, field := range typ.fields {
+		maxFieldLength = max(maxFieldLength, len(field))
+
+		opsets := []opset{typ.opsets[i]}
+		for _, alt := range typ.forkOpsets[i] {
+			opsets = append(opsets, alt.opset)
+		}
+		for _, op := range opsets {
+			switch opset := op.(type) {
+			case *opsetStatic:
+				if len(opset.bytes) > 0 {
+					maxBytes = max(maxBytes, staticSize(opset.bytes))
+				}
+			case *opsetDynamic:
+				maxBytes = max(maxBytes, offsetBytes) // offset size
+			}
+		}
+	}
+	var (
+		indexRule = fmt.Sprintf("%%%dd", int(math.Ceil(math.Log10(float64(len(typ.fields))))))
+		nameRule  = fmt.Sprintf("%%%ds", maxFieldLength)
+		sizeRule  = fmt.Sprintf("%d", int(math.Ceil(math.Log10(float64(maxBytes)))))
+	)
+	// Generate the code itself
+	fmt.Fprint(&b, "// DefineSSZ defines how an object is encoded/decoded.\n")
+	fmt.Fprintf(&b, "func (obj %s) DefineSSZ(codec *ssz.Codec) {\n", ctx.receiver(typ))
+	if ctx.values[typ.named] {
+		fmt.Fprint(&b, "	// Refuse decoding into a copy of the object, the data would be lost\n")
+		fmt.Fprint(&b, "	if !ssz.DefineReadOnly(codec) {\n")
+		fmt.Fprint(&b, "		return\n")
+		fmt.Fprint(&b, "	}\n\n")
+	}
+	if typ.fork != "" {
+		fmt.Fprint(&b, "	// Refuse operating on the object outside of the forks it exists in\n")
+		fmt.Fprintf(&b, "	if !ssz.DefineObjectFork(codec, %s) {\n", forkFilter(typ.fork))
+		fmt.Fprint(&b, "		return\n")
+		fmt.Fprint(&b, "	}\n\n")
+	}
+	if !typ.static {
+		fmt.Fprint(&b, "	// Define the static data (fields and dynamic offsets)\n")
+	}
+	for i := 0; i < len(typ.fields); i++ {
+		field := typ.fields[i]
+
+		opsets := []opset{typ.opsets[i]}
+		for _, alt := range typ.forkOpsets[i] {
+			opsets = append(opsets, alt.opset)
+		}
+		calls := make([]string, len(opsets))
+		for j, op := range opsets {
+			switch opset := op.(type) {
+			case *opsetStatic:
+				call := generateCall(opset.define, typ.forks[i], "codec", "obj."+field, nil, opset.names, opset.bytes...)
+				if strings.Contains(call, "uint256.Int{") {
+					ctx.addImport("github.com/holiman/uint256", "")
+				}
+				switch len(opset.bytes) {
+				case 0:
+					var name string
+					if param, ok := typ.types[i].(*types.TypeParam); ok {
+						name = param.Obj().Name()
+					} else {
+						name = types.Unalias(types.Unalias(typ.types[i]).(*types.Pointer).Elem()).(*types.Named).Obj().Name()
+					}
+					calls[j] = fmt.Sprintf("ssz.%s // Field  ("+indexRule+") - "+nameRule+" - %"+sizeRule+"s bytes (%s)", call, i, field, "?", name)
+				default:
+					calls[j] = fmt.Sprintf("ssz.%s // Field  ("+indexRule+") - "+nameRule+" - %"+sizeRule+"d bytes", call, i, field, staticSize(opset.bytes))
+				}
+			case *opsetDynamic:
+				call := generateCall(opset.defineOffset, typ.forks[i], "codec", "obj."+field, opset.overrides, opset.names, opset.limits...)
+				calls[j] = fmt.Sprintf("ssz.%s // Offset ("+indexRule+") - "+nameRule+" - %"+sizeRule+"d bytes", call, i, field, offsetBytes)
+			}
+		}
+		fmt.Fprint(&b, generateForkSwitch("	", "codec.Fork()", typ.forkOpsets[i], calls))
+	}
+	if !typ.static {
+		fmt.Fprint(&b, "\n	// Define the dynamic data (fields)\n")
+		var (
+			dynIndices []int
+			dynFields  []string
+			dynOpsets  []opset
+			dynForks   []string
+		)
+		for i := 0; i < len(typ.fields); i++ {
+			if _, ok := (typ.opsets[i]).(*opsetDynamic); ok {
+				dynIndices = append(dynIndices, i)
+				dynFields = append(dynFields, typ.fields[i])
+				dynOpsets = append(dynOpsets, typ.opsets[i])
+				dynForks = append(dynForks, typ.forks[i])
+			}
+		}
+		for i := 0; i < len(dynFields); i++ {
+			opsets := []opset{dynOpsets[i]}
+			for _, alt := range typ.forkOpsets[dynIndices[i]] {
+				opsets = append(opsets, alt.opset)
+			}
+			calls := make([]string, len(opsets))
+			for j, op := range opsets {
+				opset := op.(*opsetDynamic)
+
+				call := generateCall(opset.defineContent, dynForks[i], "codec", "obj."+dynFields[i], opset.overrides, opset.names, opset.limits...)
+				calls[j] = fmt.Sprintf("ssz.%s // Field  ("+indexRule+") - "+nameRule+" - ? bytes", call, dynIndices[i], dynFields[i])
+			}
+			fmt.Fprint(&b, generateForkSwitch("	", "codec.Fork()", typ.forkOpsets[dynIndices[i]], calls))
+		}
+	}
+	fmt.Fprint(&b, "}\n")
+	return b.Bytes(), nil
+}
+
+func generateNamesSSZ(ctx *genContext, typ *sszContainer) ([]byte, error) {
+	var b bytes.Buffer
+
+	// Collect the field names in the order DefineSSZ defines them: all fields
+	// first (static data and dynamic offsets), then the dynamic data again.
+	var names []string
+	for _, field := range typ.fields {
+		names = append(names, fmt.Sprintf("%q", field))
+	}
+	for i, field := range typ.fields {
+		if _, ok := (typ.opsets[i]).(*opsetDynamic); ok {
+			names = append(names, fmt.Sprintf("%q", field))
+		}
+	}
+	// Generate the code itself
+	fmt.Fprint(&b, "// NamesSSZ returns the field names in the order of their definitions.\n")
+	fmt.Fprintf(&b, "func (obj %s) NamesSSZ() []string {\n", ctx.receiver(typ))
+	fmt.Fprintf(&b, "	return []string{%s}\n", strings.Join(names, ", "))
+	fmt.Fprint(&b, "}\n")
+	return b.Bytes(), nil
+}
+
+// generateMapEntry creates the synthesized key/value container of a map field,
+// along with its ssz and ssz.MapEntry methods.
+func generateMapEntry(ctx *genContext, typ *sszContainer) ([]byte, error) {
+	var (
+		b     bytes.Buffer
+		name  = typ.named.Obj().Name()
+		key   = typ.fields[0]
+		ktype = ctx.typeString(typ.types[0])
+		vtype = ctx.typeString(typ.types[1])
+	)
+	// Declare the container type itself, the user never sees it
+	fmt.Fprintf(&b, "// %s is the ssz key/value container of a map entry.\n", name)
+	fmt.Fprintf(&b, "type %s struct {\n", name)
+	fmt.Fprintf(&b, "	%s %s\n", key, ktype)
+	fmt.Fprintf(&b, "	Value %s\n", vtype)
+	fmt.Fprint(&b, "}\n\n")
+
+	// Generate the methods to convert between the map and the entries
+	fmt.Fprint(&b, "// EntrySSZ returns the key and value stored in the container.\n")
+	fmt.Fprintf(&b, "func (obj *%s) EntrySSZ() (%s, %s) {\n", name, ktype, vtype)
+	fmt.Fprintf(&b, "	return obj.%s, obj.Value\n", key)
+	fmt.Fprint(&b, "}\n\n")
+
+	fmt.Fprint(&b, "// SetEntrySSZ sets the key and value stored in the container.\n")
+	fmt.Fprintf(&b, "func (obj *%s) SetEntrySSZ(key %s, value %s) {\n", name, ktype, vtype)
+	fmt.Fprintf(&b, "	obj.%s, obj.Value = key, value\n", key)
+	fmt.Fprint(&b, "}\n\n")
+
+	fmt.Fprint(&b, "// CompareKeySSZ compares the key stored in the container to another one.\n")
+	fmt.Fprintf(&b, "func (obj *%s) CompareKeySSZ(key %s) int {\n", name, ktype)
+	switch t := typ.types[0].Underlying().(type) {
+	case *types.Basic:
+		if t.Info()&types.IsOrdered == 0 {
+			return nil, fmt.Errorf("unsupported map key type %s", typ.types[0])
+		}
+		ctx.addImport("cmp", "")
+		fmt.Fprintf(&b, "	return cmp.Compare(obj.%s, key)\n", key)
+	case *types.Array:
+		if basic, ok := t.Elem().Underlying().(*types.Basic); !ok || basic.Kind() != types.Uint8 {
+			return nil, fmt.Errorf("unsupported map key type %s", typ.types[0])
+		}
+		ctx.addImport("bytes", "")
+		fmt.Fprintf(&b, "	return bytes.Compare(obj.%s[:], key[:])\n", key)
+	default:
+		return nil, fmt.Errorf("unsupported map key type %s", typ.types[0])
+	}
+	fmt.Fprint(&b, "}\n")
+
+	// Generate the standard ssz methods of the container
+	codes := [][]byte{b.Bytes()}
+	for _, fn := range []func(ctx *genContext, typ *sszContainer) ([]byte, error){
+		generateSizeSSZ,
+		generateDefineSSZ,
+		generateNamesSSZ,
+	} {
+		code, err := fn(ctx, typ)
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+	for _, entry := range typ.entries {
+		code, err := generateMapEntry(ctx, entry)
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+	return bytes.Join(codes, []byte("\n")), nil
+}
+
+// generateForkSwitch generates the statements selecting between the variants of
+// a field switching encodings across forks: calls[0] for the base encoding and
+// the rest for the alternatives, newest fork checked first. If all the variants
+// are identical, a single unconditional statement is generated.
+func generateForkSwitch(indent string, expr string, alts []forkOpset, calls []string) string {
+	identical := true
+	for _, call := range calls[1:] {
+		identical = identical && call == calls[0]
+	}
+	if identical {
+		return indent + calls[0] + "\n"
+	}
+	var b strings.Builder
+	for i := len(alts) - 1; i >= 0; i-- {
+		if i == len(alts)-1 {
+			fmt.Fprintf(&b, "%sif %s >= %s {\n", indent, expr, forkIdent(alts[i].fork))
+		} else {
+			fmt.Fprintf(&b, "%s} else if %s >= %s {\n", indent, expr, forkIdent(alts[i].fork))
+		}
+		fmt.Fprintf(&b, "%s	%s\n", indent, calls[i+1])
+	}
+	fmt.Fprintf(&b, "%s} else {\n%s	%s\n%s}\n", indent, indent, calls[0], indent)
+	return b.String()
+}
+
+// generateCall parses a Go template and fills it with the provided data. This
+// could be done more optimally, but we really don't care for a code generator.
+//
+// Limits declared via named constants are emitted by name instead of by value.
+func generateCall(tmpl string, fork string, recv string, field string, overrides []forkLimit, names []string, limits ...int) string {
+	// If a fork filter was specified, inject it into the call template. This is
+	// done before filling the template to avoid mutating any injected calls.
+	if fork != "" {
+		// Mutate the call to the fork variant (ahead of any type parameters)
+		idx := strings.IndexAny(tmpl, "[(")
+		tmpl = tmpl[:idx] + "OnFork" + tmpl[idx:]
+
+		// Inject a fork filter as the last parameter
+		tmpl = strings.ReplaceAll(tmpl, ")", ","+forkFilter(fork)+")")
+	}
+	// Generate the call with all the data filled in
+	t, err := template.New("").Parse(tmpl)
+	if err != nil {
+		panic(err)
+	}
+	d := map[string]interface{}{
+		"Sizer": recv,
+		"Codec": recv,
+		"Field": field,
+	}
+	limit := func(i int) string {
+		if i < len(names) && names[i] != "" {
+			return names[i]
+		}
+		return strconv.Itoa(limits[i])
+	}
+	if len(limits) > 0 {
+		d["MaxSize"] = limit(len(limits) - 1)
+
+		// If the limit changes across forks, resolve it runtime
+		if len(overrides) > 0 {
+			expr := fmt.Sprintf("ssz.LimitOnFork(%s, %s", recv, limit(len(limits)-1))
+			for _, override := range overrides {
+				expr += fmt.Sprintf(", ssz.ForkLimit{Fork: %s, Limit: %d}", forkIdent(override.fork), override.limit)
+			}
+			d["MaxSize"] = expr + ")"
+		}
+	}
+	if len(limits) > 1 {
+		d["MaxItems"] = limit(len(limits) - 2)
+	}
+	buf := new(bytes.Buffer)
+	if err := t.Execute(buf, d); err != nil {
+		panic(err)
+	}
+	return string(buf.Bytes())
+}
@@ -0,0 +1,227 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package gen is the ssz codec generator behind the sszgen command, exposed as a
+// library so build systems and code generation pipelines can invoke it without
+// exec'ing a binary and parsing its output.
+package gen
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Options are the optional knobs of the generator. The zero value (or a nil
+// pointer) generates the plain ssz methods into the current directory's package.
+type Options struct {
+	Dir    string   // Directory to resolve the package path in (default is the current one)
+	Extras []string // Extra methods to generate beside the ssz ones (clone, equal, cache, getters, reset, upgrade)
+	Tests  bool     // Whether to generate round-trip tests and fuzz targets too
+
+	Lenient        bool // Whether to ignore unknown ssz struct tags instead of rejecting them
+	SizeTable      bool // Whether to resolve the per-fork static sizes at generation time
+	ValueReceivers bool // Whether to generate the ssz methods on value receivers (encode-only)
+}
+
+// genLock serializes the generator runs, since the fork tables are extended with
+// the custom forks of the package being processed.
+var genLock sync.Mutex
+
+// Generate generates the ssz methods for the requested types of a package (all
+// the structs if none are specified), returning the formatted source code of the
+// generated file and, if requested, that of its accompanying test file.
+//
+// The package path may be anything the go tool accepts (e.g. an import path or a
+// ./relative directory), resolved in the directory set by the options.
+func Generate(pkgPath string, typeNames []string, opts *Options) (code []byte, tests []byte, err error) {
+	if opts == nil {
+		opts = new(Options)
+	}
+	// Make sure all the requested extra methods are known
+	for _, extra := range opts.Extras {
+		if extra != extraClone && extra != extraEqual && extra != extraCache && extra != extraGetters && extra != extraReset && extra != extraUpgrade {
+			return nil, nil, fmt.Errorf("unknown extra method: %s", extra)
+		}
+	}
+	// Value receivers cannot be decoded into, so round-trip tests make no sense
+	if opts.ValueReceivers && opts.Tests {
+		return nil, nil, fmt.Errorf("value receiver types cannot be decoded, round-trip tests unavailable")
+	}
+	genLock.Lock()
+	defer genLock.Unlock()
+
+	library, target, types, err := load(pkgPath, typeNames, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		ctx    = newGenContext(target, opts.Extras)
+		chunks [][]byte
+	)
+	if opts.SizeTable {
+		ctx.library = library
+	}
+	for _, typ := range types {
+		ctx.targets[typ.named] = true
+	}
+	if opts.ValueReceivers {
+		for _, typ := range types {
+			ctx.values[typ.named] = true
+		}
+	}
+	for _, typ := range types {
+		ret, err := generate(ctx, typ)
+		if err != nil {
+			return nil, nil, err
+		}
+		chunks = append(chunks, ret)
+	}
+	code = bytes.Join(chunks, []byte("\n\n"))
+
+	// Add package and imports definition and format code
+	code = append(ctx.header(), code...)
+	code, err = format.Source(code)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Add build comments and the source stamp.
+	// This is done here to avoid processing these lines with gofmt.
+	header := []byte("// Code generated by github.com/karalabe/ssz. DO NOT EDIT.\n")
+	code = append(fmt.Appendf(header[:len(header):len(header)], "%s%s\n\n", stampDirective, stamp(types, opts)), code...)
+	header = append(header, '\n')
+
+	if !opts.Tests {
+		return code, nil, nil
+	}
+	tests, err = generateTests(target, types)
+	if err != nil {
+		return nil, nil, err
+	}
+	tests, err = format.Source(tests)
+	if err != nil {
+		return nil, nil, err
+	}
+	return code, append(header[:len(header):len(header)], tests...), nil
+}
+
+// stampDirective is the comment line prefix in the generated code holding the
+// hash of the source types it was generated from.
+const stampDirective = "//ssz:stamp "
+
+// Check reports whether previously generated code is up to date with the current
+// declarations of the requested types and the generator options, without doing
+// the generation itself. The arguments must match those used for generating.
+//
+// The declarations of the requested types and of all the types referenced by
+// their fields are tracked (their static sizes are baked into the generated code),
+// but changes to the values of constants referenced from struct tags, to custom
+// hand-written ssz methods, or to the generator itself, are not detected.
+func Check(pkgPath string, typeNames []string, opts *Options, code []byte) (bool, error) {
+	if opts == nil {
+		opts = new(Options)
+	}
+	genLock.Lock()
+	defer genLock.Unlock()
+
+	_, _, types, err := load(pkgPath, typeNames, opts)
+	if err != nil {
+		return false, err
+	}
+	for _, line := range bytes.Split(code, []byte("\n")) {
+		if have, ok := bytes.CutPrefix(line, []byte(stampDirective)); ok {
+			return string(bytes.TrimSpace(have)) == stamp(types, opts), nil
+		}
+	}
+	return false, nil
+}
+
+// stamp computes the hash of the source declarations of the types (along with the
+// types they reference) and the options that influence the code generated.
+func stamp(types []*sszContainer, opts *Options) string {
+	hasher := sha256.New()
+	fmt.Fprintf(hasher, "extras=%v tests=%v lenient=%v sizetable=%v values=%v\n",
+		opts.Extras, opts.Tests, opts.Lenient, opts.SizeTable, opts.ValueReceivers)
+	for _, typ := range types {
+		fmt.Fprintf(hasher, "%s %q\n%s\n", typ.typeName(), typ.fork, typ.source)
+		for _, ref := range typ.refs {
+			fmt.Fprintf(hasher, "ref %s\n", ref)
+		}
+	}
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+// load parses the requested types of the input package in the context of the ssz
+// library, returning both packages and the resolved containers.
+func load(pkgPath string, typeNames []string, opts *Options) (*types.Package, *types.Package, []*sszContainer, error) {
+	// Load the ssz library package and the target package to generate into
+	pcfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedSyntax | packages.NeedTypes | packages.NeedImports | packages.NeedDeps,
+		Dir:  opts.Dir,
+	}
+	ps, err := packages.Load(pcfg, sszPkgPath, pkgPath)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(ps) == 0 {
+		return nil, nil, nil, fmt.Errorf("no Go package found for %s", pkgPath)
+	}
+	if len(ps) != 2 {
+		return nil, nil, nil, fmt.Errorf("at most one package can be processed at the same time")
+	}
+	// Pick out the library package for interfaces and the target package for types
+	var (
+		library *types.Package
+		target  *types.Package
+		fileset *token.FileSet
+		syntax  []*ast.File
+	)
+	for _, p := range ps {
+		if len(p.Errors) > 0 {
+			errs := make([]error, len(p.Errors))
+			for i, err := range p.Errors {
+				errs[i] = err
+			}
+			return nil, nil, nil, fmt.Errorf("package %s has errors: %w", p.PkgPath, errors.Join(errs...))
+		}
+		if p.PkgPath == sszPkgPath {
+			library = p.Types
+		} else {
+			target, fileset, syntax = p.Types, p.Fset, p.Syntax
+		}
+	}
+	// Parse the package in the context of the ssz library, including any custom
+	// forks declared by the target package
+	registerForks(library, target)
+	parser := newParseContext(library)
+	parser.lenient = opts.Lenient
+
+	containers, err := parser.parsePackage(target, typeNames)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	forks, err := parseTypeForks(syntax)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	sources, err := parseTypeSources(fileset, syntax)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	for _, container := range containers {
+		container.fork = forks[container.named.Obj().Name()]
+		container.source = sources[container.named.Obj().Name()]
+		container.refs = parser.parseTypeRefs(target, container.named, sources)
+	}
+	return library, target, containers, nil
+}
@@ -0,0 +1,177 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestPackage creates a throwaway package folder within the module, so that the
+// sources written into it can import the library. It is deleted after the test.
+func newTestPackage(t *testing.T, name string) string {
+	t.Helper()
+
+	if err := os.MkdirAll("testdata", 0o755); err != nil {
+		t.Fatalf("failed to create testdata folder: %v", err)
+	}
+	dir, err := os.MkdirTemp("testdata", name)
+	if err != nil {
+		t.Fatalf("failed to create test package: %v", err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+		os.Remove("testdata") // only if empty
+	})
+	return dir
+}
+
+// writeTestPackage creates a throwaway package with a single source file.
+func writeTestPackage(t *testing.T, name string, src string) string {
+	t.Helper()
+
+	dir := newTestPackage(t, name)
+	if err := os.WriteFile(filepath.Join(dir, "types.go"), []byte(src), 0o644); err != nil {
+		t.Fatalf("failed to write test package: %v", err)
+	}
+	return dir
+}
+
+// stampTestSource is a package with a hand-written nested type, the static size
+// of which is baked into the code generated for the outer type.
+const stampTestSource = `package stamptest
+
+import "github.com/karalabe/ssz"
+
+type Inner struct {
+	A uint64
+	%s
+}
+
+func (obj *Inner) SizeSSZ(sizer *ssz.Sizer) uint32 { return %d }
+func (obj *Inner) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &obj.A)
+	%s
+}
+
+type Outer struct {
+	Inner *Inner
+	B     uint64
+}
+`
+
+// Tests that generated code is considered stale if the declaration of a type it
+// references changes, not only if its own declaration does.
+func TestCheckReferencedTypes(t *testing.T) {
+	dir := newTestPackage(t, "stamptest")
+
+	write := func(field string, size int, define string) {
+		src := []byte(fmt.Sprintf(stampTestSource, field, size, define))
+		if err := os.WriteFile(filepath.Join(dir, "types.go"), src, 0o644); err != nil {
+			t.Fatalf("failed to write test package: %v", err)
+		}
+	}
+	write("", 8, "")
+
+	opts := &Options{Dir: dir}
+	code, _, err := Generate(".", []string{"Outer"}, opts)
+	if err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+	if fresh, err := Check(".", []string{"Outer"}, opts, code); err != nil || !fresh {
+		t.Fatalf("fresh code reported stale: fresh %v, err %v", fresh, err)
+	}
+	// Grow the referenced type and ensure the generated code becomes stale
+	write("C uint32", 12, "ssz.DefineUint32(codec, &obj.C)")
+	if fresh, err := Check(".", []string{"Outer"}, opts, code); err != nil || fresh {
+		t.Fatalf("stale code reported fresh: fresh %v, err %v", fresh, err)
+	}
+	// Regenerating must make it fresh again
+	if code, _, err = Generate(".", []string{"Outer"}, opts); err != nil {
+		t.Fatalf("failed to regenerate code: %v", err)
+	}
+	if fresh, err := Check(".", []string{"Outer"}, opts, code); err != nil || !fresh {
+		t.Fatalf("regenerated code reported stale: fresh %v, err %v", fresh, err)
+	}
+}
+
+// generateTestSource is a package with a few plain types to run the generator's
+// library entry point on.
+const generateTestSource = `package gentest
+
+type Pair struct {
+	A uint64
+	B []byte ` + "`ssz-max:\"32\"`" + `
+}
+
+type Single struct {
+	C uint32
+}
+`
+
+// Tests that the library entry point of the generator produces the requested
+// methods and tests, and rejects invalid options and types.
+func TestGenerate(t *testing.T) {
+	dir := writeTestPackage(t, "gentest", generateTestSource)
+
+	// Generating without types and options should cover all the structs
+	code, tests, err := Generate(".", nil, &Options{Dir: dir})
+	if err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+	if !bytes.HasPrefix(code, []byte("// Code generated by github.com/karalabe/ssz. DO NOT EDIT.\n"+stampDirective)) {
+		t.Errorf("generated code header missing:\n%s", code)
+	}
+	for _, want := range []string{"package gentest", "func (obj *Pair) SizeSSZ(", "func (obj *Pair) DefineSSZ(", "func (obj *Single) SizeSSZ(", "func (obj *Single) DefineSSZ("} {
+		if !bytes.Contains(code, []byte(want)) {
+			t.Errorf("generated code missing %q", want)
+		}
+	}
+	if tests != nil {
+		t.Errorf("tests generated without being requested")
+	}
+	if fresh, err := Check(".", nil, &Options{Dir: dir}, code); err != nil || !fresh {
+		t.Errorf("fresh code reported stale: fresh %v, err %v", fresh, err)
+	}
+	// Generating selected types with extras and tests should only cover those
+	opts := &Options{Dir: dir, Extras: []string{"clone", "equal"}, Tests: true}
+	if code, tests, err = Generate(".", []string{"Pair"}, opts); err != nil {
+		t.Fatalf("failed to generate code with extras: %v", err)
+	}
+	for _, want := range []string{"func (obj *Pair) SizeSSZ(", "func (obj *Pair) Clone(", "func (obj *Pair) EqualSSZ("} {
+		if !bytes.Contains(code, []byte(want)) {
+			t.Errorf("generated code missing %q", want)
+		}
+	}
+	if bytes.Contains(code, []byte("Single")) {
+		t.Errorf("generated code contains unrequested type")
+	}
+	for _, want := range []string{"package gentest", "func TestSSZRoundTripPair(", "func FuzzSSZPair("} {
+		if !bytes.Contains(tests, []byte(want)) {
+			t.Errorf("generated tests missing %q", want)
+		}
+	}
+	if bytes.Contains(tests, []byte("Single")) {
+		t.Errorf("generated tests contain unrequested type")
+	}
+	// Invalid options and unknown types should be rejected
+	for i, tt := range []struct {
+		types []string
+		opts  *Options
+		err   string
+	}{
+		{nil, &Options{Dir: dir, Extras: []string{"unknown"}}, "unknown extra method"},
+		{nil, &Options{Dir: dir, ValueReceivers: true, Tests: true}, "round-trip tests unavailable"},
+		{[]string{"Missing"}, &Options{Dir: dir}, "Missing"},
+	} {
+		if _, _, err := Generate(".", tt.types, tt.opts); err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("test %d: error mismatch: have %v, want %q", i, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,1104 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package gen
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/holiman/uint256"
+)
+
+// opset is a group of methods that define how different pieces of an ssz codec
+// operates on a given type. It may be static or dynamic.
+type opset interface{}
+
+// opsetStatic is a group of methods that define how different pieces of an ssz
+// codec operates on a given static type. Ideally these would be some go/types
+// function values, but alas too much pain, especially with generics.
+type opsetStatic struct {
+	define string   // DefineXYZ method for the ssz.Codec
+	encode string   // EncodeXYZ method for the ssz.Encoder
+	decode string   // DecodeXYZ method for the ssz.Decoder
+	bytes  []int    // Number of bytes in the ssz encoding (nil == unknown)
+	names  []string // Constant names of the byte dimensions, if tagged with them
+}
+
+// opsetDynamic is a group of methods that define how different pieces of an ssz
+// codec operates on a given dynamic type. Ideally these would be some go/types
+// function values, but alas too much pain, especially with generics.
+type opsetDynamic struct {
+	size          string      // SizeXYZ method for the ssz.Sizer
+	defineOffset  string      // DefineXYZOffset method for the ssz.Codec
+	defineContent string      // DefineXYZContent method for the ssz.Codec
+	encodeOffset  string      // EncodeXYZOffset method for the ssz.Encoder
+	encodeContent string      // EncodeXYZContent method for the ssz.Encoder
+	decodeOffset  string      // DecodeXYZOffset method for the ssz.Decoder
+	decodeContent string      // DecodeXYZContent method for the ssz.Decoder
+	sizes         []int       // Static item sizes for different dimensions
+	limits        []int       // Maximum dynamic item sizes for different dimensions
+	overrides     []forkLimit // Fork specific overrides of the (1D) limit
+	names         []string    // Constant names of the limits, if tagged with them
+}
+
+// forkOpset is an alternative opset of a field, taking effect from a specific fork
+// onward (ssz-fork-type tag).
+type forkOpset struct {
+	fork  string // fork enum name (without the Fork prefix)
+	opset opset  // opset to use from the fork onward
+}
+
+// isStaticOpset reports whether an opset defines a static field.
+func isStaticOpset(op opset) bool {
+	_, ok := op.(*opsetStatic)
+	return ok
+}
+
+// resolveForkTypeOpset resolves an alternative encoding of a field. The dimensions
+// of the encoding are interpreted as limits or as sizes (of bytes or bits), the
+// first one resolving to the requested encoding family being used.
+func (p *parseContext) resolveForkTypeOpset(typ types.Type, alt forkType) (opset, error) {
+	candidates := []*sizeTag{
+		{limit: alt.dims, limitNames: alt.names},
+		{size: alt.dims, sizeNames: alt.names},
+		{size: alt.dims, sizeNames: alt.names, bits: true},
+	}
+	for _, tags := range candidates {
+		op, err := p.resolveOpset(typ, tags, false)
+		if err != nil {
+			continue
+		}
+		switch op := op.(type) {
+		case *opsetStatic:
+			if describeEncoding(op.define) == alt.encoding {
+				if !tags.bits {
+					op.names = constNames(op.bytes, tags.size, tags.sizeNames)
+				}
+				return op, nil
+			}
+		case *opsetDynamic:
+			if describeEncoding(op.defineOffset) == alt.encoding {
+				op.names = constNames(op.limits, tags.limit, tags.limitNames)
+				return op, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("type %s cannot be encoded as %s%v", typ, alt.encoding, alt.dims)
+}
+
+// resolveBasicOpset retrieves the opset required to handle a basic struct
+// field. Yes, we could maybe have some of these be "computed" instead of hard
+// coded, but it makes things brittle for corner-cases.
+func (p *parseContext) resolveBasicOpset(typ *types.Basic, tags *sizeTag, pointer bool) (opset, error) {
+	// Strings are dynamic lists of bytes, handle them before the static checks
+	if typ.Kind() == types.String {
+		return p.resolveStringOpset(tags, pointer)
+	}
+	// Sanity check a few tag constraints relevant for all basic types
+	if tags != nil {
+		if tags.limit != nil {
+			return nil, fmt.Errorf("basic type cannot have ssz-max tag")
+		}
+		if len(tags.size) != 1 {
+			return nil, fmt.Errorf("basic type requires 1D ssz-size tag: have %v", tags.size)
+		}
+	}
+	// Return the type-specific opsets
+	switch typ.Kind() {
+	case types.Bool:
+		if tags != nil && tags.size[0] != 1 {
+			return nil, fmt.Errorf("boolean basic type requires ssz-size=1: have %d", tags.size[0])
+		}
+		if !pointer {
+			return &opsetStatic{
+				"DefineBool({{.Codec}}, &{{.Field}})",
+				"EncodeBool({{.Codec}}, &{{.Field}})",
+				"DecodeBool({{.Codec}}, &{{.Field}})",
+				[]int{1}, nil,
+			}, nil
+		} else {
+			return &opsetStatic{
+				"DefineBoolPointer({{.Codec}}, &{{.Field}})",
+				"EncodeBoolPointer({{.Codec}}, &{{.Field}})",
+				"DecodeBoolPointer({{.Codec}}, &{{.Field}})",
+				[]int{1}, nil,
+			}, nil
+		}
+	case types.Uint8:
+		if tags != nil && tags.size[0] != 1 {
+			return nil, fmt.Errorf("byte basic type requires ssz-size=1: have %d", tags.size[0])
+		}
+		if !pointer {
+			return &opsetStatic{
+				"DefineUint8({{.Codec}}, &{{.Field}})",
+				"EncodeUint8({{.Codec}}, &{{.Field}})",
+				"DecodeUint8({{.Codec}}, &{{.Field}})",
+				[]int{1}, nil,
+			}, nil
+		} else {
+			return &opsetStatic{
+				"DefineUint8Pointer({{.Codec}}, &{{.Field}})",
+				"EncodeUint8Pointer({{.Codec}}, &{{.Field}})",
+				"DecodeUint8Pointer({{.Codec}}, &{{.Field}})",
+				[]int{1}, nil,
+			}, nil
+		}
+	case types.Uint16:
+		if tags != nil && tags.size[0] != 2 {
+			return nil, fmt.Errorf("uint16 basic type requires ssz-size=2: have %d", tags.size[0])
+		}
+		if !pointer {
+			return &opsetStatic{
+				"DefineUint16({{.Codec}}, &{{.Field}})",
+				"EncodeUint16({{.Codec}}, &{{.Field}})",
+				"DecodeUint16({{.Codec}}, &{{.Field}})",
+				[]int{2}, nil,
+			}, nil
+		} else {
+			return &opsetStatic{
+				"DefineUint16Pointer({{.Codec}}, &{{.Field}})",
+				"EncodeUint16Pointer({{.Codec}}, &{{.Field}})",
+				"DecodeUint16Pointer({{.Codec}}, &{{.Field}})",
+				[]int{2}, nil,
+			}, nil
+		}
+	case types.Uint32:
+		if tags != nil && tags.size[0] != 4 {
+			return nil, fmt.Errorf("uint32 basic type requires ssz-size=4: have %d", tags.size[0])
+		}
+		if !pointer {
+			return &opsetStatic{
+				"DefineUint32({{.Codec}}, &{{.Field}})",
+				"EncodeUint32({{.Codec}}, &{{.Field}})",
+				"DecodeUint32({{.Codec}}, &{{.Field}})",
+				[]int{4}, nil,
+			}, nil
+		} else {
+			return &opsetStatic{
+				"DefineUint32Pointer({{.Codec}}, &{{.Field}})",
+				"EncodeUint32Pointer({{.Codec}}, &{{.Field}})",
+				"DecodeUint32Pointer({{.Codec}}, &{{.Field}})",
+				[]int{4}, nil,
+			}, nil
+		}
+	case types.Uint64:
+		if tags != nil && tags.size[0] != 8 {
+			return nil, fmt.Errorf("uint64 basic type requires ssz-size=8: have %d", tags.size[0])
+		}
+		if !pointer {
+			return &opsetStatic{
+				"DefineUint64({{.Codec}}, &{{.Field}})",
+				"EncodeUint64({{.Codec}}, &{{.Field}})",
+				"DecodeUint64({{.Codec}}, &{{.Field}})",
+				[]int{8}, nil,
+			}, nil
+		} else {
+			return &opsetStatic{
+				"DefineUint64Pointer({{.Codec}}, &{{.Field}})",
+				"EncodeUint64Pointer({{.Codec}}, &{{.Field}})",
+				"DecodeUint64Pointer({{.Codec}}, &{{.Field}})",
+				[]int{8}, nil,
+			}, nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported basic type: %s", typ)
+	}
+}
+
+// resolveStringOpset retrieves the opset required to handle a string field, which
+// is encoded as a dynamic list of bytes, optionally validated as UTF-8.
+func (p *parseContext) resolveStringOpset(tags *sizeTag, pointer bool) (opset, error) {
+	if pointer {
+		return nil, fmt.Errorf("pointer to string type not supported")
+	}
+	if tags == nil || tags.limit == nil {
+		return nil, fmt.Errorf("string type requires ssz-max tag")
+	}
+	if len(tags.size) > 0 {
+		return nil, fmt.Errorf("string type cannot have ssz-size tag")
+	}
+	if len(tags.limit) != 1 {
+		return nil, fmt.Errorf("string type tag conflict: needs [N] tag, has %v", tags.limit)
+	}
+	content := "String"
+	if tags.utf8 {
+		content = "UTF8String"
+	}
+	return &opsetDynamic{
+		"SizeString({{.Sizer}}, {{.Field}})",
+		"DefineStringOffset({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+		"Define" + content + "Content({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+		"EncodeStringOffset({{.Codec}}, &{{.Field}})",
+		"EncodeStringContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+		"DecodeStringOffset({{.Codec}}, &{{.Field}})",
+		"Decode" + content + "Content({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+		nil, tags.limit, nil, nil,
+	}, nil
+}
+
+func (p *parseContext) resolveBitlistOpset(tags *sizeTag) (opset, error) {
+	if tags == nil || tags.limit == nil {
+		return nil, fmt.Errorf("slice of bits type requires ssz-max tag")
+	}
+	if len(tags.size) > 0 {
+		return nil, fmt.Errorf("slice of bits type cannot have ssz-size tag")
+	}
+	if len(tags.limit) != 1 {
+		return nil, fmt.Errorf("slice of bits tag conflict: field supports [N] bits, tag wants %v bits", tags.limit)
+	}
+	return &opsetDynamic{
+		"SizeSliceOfBits({{.Sizer}}, {{.Field}})",
+		"DefineSliceOfBitsOffset({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+		"DefineSliceOfBitsContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+		"EncodeSliceOfBitsOffset({{.Codec}}, &{{.Field}})",
+		"EncodeSliceOfBitsContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+		"DecodeSliceOfBitsOffset({{.Codec}}, &{{.Field}})",
+		"DecodeSliceOfBitsContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+		nil, []int{tags.limit[0]}, nil, nil, // limit in bits, not bytes
+	}, nil
+}
+
+// resolveBitvectorOpset retrieves the opset required to handle one of go-bitfield's
+// fixed size bitvectors, the bit size of which is derived from the type itself.
+func (p *parseContext) resolveBitvectorOpset(bits int, tags *sizeTag, pointer bool) (opset, error) {
+	if pointer {
+		return nil, fmt.Errorf("pointer to bitvector type not supported")
+	}
+	if tags != nil {
+		if tags.limit != nil {
+			return nil, fmt.Errorf("bitvector type cannot have ssz-max tag")
+		}
+		if len(tags.size) != 1 || tags.size[0] != bits {
+			return nil, fmt.Errorf("bitvector tag conflict: field is %d bits, tag wants %v bits", bits, tags.size)
+		}
+	}
+	return &opsetStatic{
+		fmt.Sprintf("DefineCheckedBits({{.Codec}}, &{{.Field}}, %d)", bits), // inject bit-size directly
+		fmt.Sprintf("EncodeCheckedBits({{.Codec}}, &{{.Field}}, %d)", bits), // inject bit-size directly
+		fmt.Sprintf("DecodeCheckedBits({{.Codec}}, &{{.Field}}, %d)", bits), // inject bit-size directly
+		[]int{(bits + 7) / 8}, nil,
+	}, nil
+}
+
+func (p *parseContext) resolveArrayOpset(typ types.Type, size int, tags *sizeTag, pointer bool) (opset, error) {
+	switch typ := types.Unalias(typ).(type) {
+	case *types.Basic:
+		// Sanity check a few tag constraints relevant for all arrays of basic types
+		if tags != nil {
+			if tags.limit != nil {
+				return nil, fmt.Errorf("array of basic type cannot have ssz-max tag")
+			}
+		}
+		switch typ.Kind() {
+		case types.Byte:
+			// If the byte array is a packet bitvector, handle is explicitly
+			if tags != nil && tags.bits {
+				if len(tags.size) != 1 || tags.size[0] < (size-1)*8+1 || tags.size[0] > size*8 {
+					return nil, fmt.Errorf("array of bits tag conflict: field supports %d-%d bits, tag wants %v bits", (size-1)*8+1, size*8, tags.size)
+				}
+				if !pointer {
+					return &opsetStatic{
+						fmt.Sprintf("DefineArrayOfBits({{.Codec}}, &{{.Field}}, %d)", tags.size[0]), // inject bit-size directly
+						fmt.Sprintf("EncodeArrayOfBits({{.Codec}}, &{{.Field}}, %d)", tags.size[0]), // inject bit-size directly
+						fmt.Sprintf("DecodeArrayOfBits({{.Codec}}, &{{.Field}}, %d)", tags.size[0]), // inject bit-size directly
+						[]int{size}, nil,
+					}, nil
+				} else {
+					return &opsetStatic{
+						fmt.Sprintf("DefineArrayOfBitsPointer({{.Codec}}, &{{.Field}}, %d)", tags.size[0]), // inject bit-size directly
+						fmt.Sprintf("EncodeArrayOfBitsPointer({{.Codec}}, &{{.Field}}, %d)", tags.size[0]), // inject bit-size directly
+						fmt.Sprintf("DecodeArrayOfBitsPointer({{.Codec}}, &{{.Field}}, %d)", tags.size[0]), // inject bit-size directly
+						[]int{size}, nil,
+					}, nil
+				}
+			}
+			// Not a bitvector, interpret as plain byte array
+			if tags != nil {
+				if (len(tags.size) != 1 && len(tags.size) != 2) ||
+					(len(tags.size) == 1 && tags.size[0] != size) ||
+					(len(tags.size) == 2 && (tags.size[0] != size || tags.size[1] != 1)) {
+					return nil, fmt.Errorf("array of byte basic type tag conflict: field is %d bytes, tag wants %v bytes", size, tags.size)
+				}
+			}
+			if !pointer {
+				return &opsetStatic{
+					"DefineStaticBytes({{.Codec}}, &{{.Field}})",
+					"EncodeStaticBytes({{.Codec}}, &{{.Field}})",
+					"DecodeStaticBytes({{.Codec}}, &{{.Field}})",
+					[]int{size}, nil,
+				}, nil
+			} else {
+				return &opsetStatic{
+					"DefineStaticBytesPointer({{.Codec}}, &{{.Field}})",
+					"EncodeStaticBytesPointer({{.Codec}}, &{{.Field}})",
+					"DecodeStaticBytesPointer({{.Codec}}, &{{.Field}})",
+					[]int{size}, nil,
+				}, nil
+
+			}
+		case types.Uint64:
+			if tags != nil {
+				if (len(tags.size) != 1 && len(tags.size) != 2) ||
+					(len(tags.size) == 1 && tags.size[0] != size) ||
+					(len(tags.size) == 2 && (tags.size[0] != size || tags.size[1] != 8)) {
+					return nil, fmt.Errorf("array of byte basic type tag conflict: field is %d bytes, tag wants %v bytes", size, tags.size)
+				}
+			}
+			if !pointer {
+				return &opsetStatic{
+					"DefineArrayOfUint64s({{.Codec}}, &{{.Field}})",
+					"EncodeArrayOfUint64s({{.Codec}}, &{{.Field}})",
+					"DecodeArrayOfUint64s({{.Codec}}, &{{.Field}})",
+					[]int{size, 8}, nil,
+				}, nil
+			} else {
+				return &opsetStatic{
+					"DefineArrayOfUint64sPointer({{.Codec}}, &{{.Field}})",
+					"EncodeArrayOfUint64sPointer({{.Codec}}, &{{.Field}})",
+					"DecodeArrayOfUint64sPointer({{.Codec}}, &{{.Field}})",
+					[]int{size, 8}, nil,
+				}, nil
+			}
+		case types.Uint16:
+			if tags != nil {
+				if (len(tags.size) != 1 && len(tags.size) != 2) ||
+					(len(tags.size) == 1 && tags.size[0] != size) ||
+					(len(tags.size) == 2 && (tags.size[0] != size || tags.size[1] != 2)) {
+					return nil, fmt.Errorf("array of uint16 basic type tag conflict: field is %d items, tag wants %v", size, tags.size)
+				}
+			}
+			if !pointer {
+				return &opsetStatic{
+					"DefineArrayOfUint16s({{.Codec}}, &{{.Field}})",
+					"EncodeArrayOfUint16s({{.Codec}}, &{{.Field}})",
+					"DecodeArrayOfUint16s({{.Codec}}, &{{.Field}})",
+					[]int{size, 2}, nil,
+				}, nil
+			} else {
+				return &opsetStatic{
+					"DefineArrayOfUint16sPointer({{.Codec}}, &{{.Field}})",
+					"EncodeArrayOfUint16sPointer({{.Codec}}, &{{.Field}})",
+					"DecodeArrayOfUint16sPointer({{.Codec}}, &{{.Field}})",
+					[]int{size, 2}, nil,
+				}, nil
+			}
+		case types.Uint32:
+			if tags != nil {
+				if (len(tags.size) != 1 && len(tags.size) != 2) ||
+					(len(tags.size) == 1 && tags.size[0] != size) ||
+					(len(tags.size) == 2 && (tags.size[0] != size || tags.size[1] != 4)) {
+					return nil, fmt.Errorf("array of uint32 basic type tag conflict: field is %d items, tag wants %v", size, tags.size)
+				}
+			}
+			if !pointer {
+				return &opsetStatic{
+					"DefineArrayOfUint32s({{.Codec}}, &{{.Field}})",
+					"EncodeArrayOfUint32s({{.Codec}}, &{{.Field}})",
+					"DecodeArrayOfUint32s({{.Codec}}, &{{.Field}})",
+					[]int{size, 4}, nil,
+				}, nil
+			} else {
+				return &opsetStatic{
+					"DefineArrayOfUint32sPointer({{.Codec}}, &{{.Field}})",
+					"EncodeArrayOfUint32sPointer({{.Codec}}, &{{.Field}})",
+					"DecodeArrayOfUint32sPointer({{.Codec}}, &{{.Field}})",
+					[]int{size, 4}, nil,
+				}, nil
+			}
+		default:
+			return nil, fmt.Errorf("unsupported array item basic type: %s", typ)
+		}
+	case *types.Array:
+		return p.resolveArrayOfArrayOpset(typ.Elem(), size, int(typ.Len()), tags)
+
+	case *types.Slice:
+		return p.resolveArrayOfSliceOpset(typ.Elem(), size, tags, pointer)
+
+	case *types.Named:
+		// Arrays of go-bitfield bitvectors carry their bit size in the item type
+		if bits := bitvectorBits(typ); bits > 0 {
+			if tags == nil {
+				tags = &sizeTag{size: []int{size, bits}, bits: true}
+			}
+			if tags.bits && (len(tags.size) != 2 || tags.size[1] != bits) {
+				return nil, fmt.Errorf("array of bitvectors tag conflict: items are %d bits, tag wants %v", bits, tags.size)
+			}
+		}
+		return p.resolveArrayOpset(typ.Underlying(), size, tags, pointer)
+
+	default:
+		return nil, fmt.Errorf("unsupported array item type: %s", typ)
+	}
+}
+
+func (p *parseContext) resolveArrayOfArrayOpset(typ types.Type, outerSize, innerSize int, tags *sizeTag) (opset, error) {
+	switch typ := types.Unalias(typ).(type) {
+	case *types.Basic:
+		// Sanity check a few tag constraints relevant for all arrays of basic types
+		if tags != nil {
+			if tags.limit != nil {
+				return nil, fmt.Errorf("array of array of basic type cannot have ssz-max tag")
+			}
+		}
+		switch typ.Kind() {
+		case types.Byte:
+			// If the inner byte arrays are packed bitvectors, handle them explicitly
+			if tags != nil && tags.bits {
+				if len(tags.size) != 2 || tags.size[0] != outerSize || tags.size[1] < (innerSize-1)*8+1 || tags.size[1] > innerSize*8 {
+					return nil, fmt.Errorf("array of array of bits tag conflict: field supports [%d, %d-%d] bits, tag wants %v bits", outerSize, (innerSize-1)*8+1, innerSize*8, tags.size)
+				}
+				return &opsetStatic{
+					fmt.Sprintf("DefineUnsafeArrayOfBits({{.Codec}}, {{.Field}}[:], %d)", tags.size[1]), // inject bit-size directly
+					"EncodeUnsafeArrayOfBits({{.Codec}}, {{.Field}}[:])",
+					fmt.Sprintf("DecodeUnsafeArrayOfBits({{.Codec}}, {{.Field}}[:], %d)", tags.size[1]), // inject bit-size directly
+					[]int{outerSize, innerSize}, nil,
+				}, nil
+			}
+			// Not a bitvector array, interpret as plain array of byte arrays
+			if tags != nil {
+				if (len(tags.size) != 2 && len(tags.size) != 3) ||
+					(len(tags.size) == 2 && (tags.size[0] != outerSize || tags.size[1] != innerSize)) ||
+					(len(tags.size) == 3 && (tags.size[0] != outerSize || tags.size[1] != innerSize || tags.size[2] != 1)) {
+					return nil, fmt.Errorf("array of array of byte basic type tag conflict: field is [%d, %d] bytes, tag wants %v bytes", outerSize, innerSize, tags.size)
+				}
+			}
+			return &opsetStatic{
+				"DefineUnsafeArrayOfStaticBytes({{.Codec}}, {{.Field}}[:])",
+				"EncodeUnsafeArrayOfStaticBytes({{.Codec}}, {{.Field}}[:])",
+				"DecodeUnsafeArrayOfStaticBytes({{.Codec}}, {{.Field}}[:])",
+				[]int{outerSize, innerSize}, nil,
+			}, nil
+		case types.Uint16:
+			if tags != nil {
+				if (len(tags.size) != 2 && len(tags.size) != 3) ||
+					(len(tags.size) == 2 && (tags.size[0] != outerSize || tags.size[1] != innerSize)) ||
+					(len(tags.size) == 3 && (tags.size[0] != outerSize || tags.size[1] != innerSize || tags.size[2] != 2)) {
+					return nil, fmt.Errorf("array of array of uint16 basic type tag conflict: field is [%d, %d] items, tag wants %v", outerSize, innerSize, tags.size)
+				}
+			}
+			return &opsetStatic{
+				"DefineArrayOfArrayOfUint16s({{.Codec}}, {{.Field}}[:])",
+				"EncodeArrayOfArrayOfUint16s({{.Codec}}, {{.Field}}[:])",
+				"DecodeArrayOfArrayOfUint16s({{.Codec}}, {{.Field}}[:])",
+				[]int{outerSize, innerSize, 2}, nil,
+			}, nil
+		case types.Uint32:
+			if tags != nil {
+				if (len(tags.size) != 2 && len(tags.size) != 3) ||
+					(len(tags.size) == 2 && (tags.size[0] != outerSize || tags.size[1] != innerSize)) ||
+					(len(tags.size) == 3 && (tags.size[0] != outerSize || tags.size[1] != innerSize || tags.size[2] != 4)) {
+					return nil, fmt.Errorf("array of array of uint32 basic type tag conflict: field is [%d, %d] items, tag wants %v", outerSize, innerSize, tags.size)
+				}
+			}
+			return &opsetStatic{
+				"DefineArrayOfArrayOfUint32s({{.Codec}}, {{.Field}}[:])",
+				"EncodeArrayOfArrayOfUint32s({{.Codec}}, {{.Field}}[:])",
+				"DecodeArrayOfArrayOfUint32s({{.Codec}}, {{.Field}}[:])",
+				[]int{outerSize, innerSize, 4}, nil,
+			}, nil
+		case types.Uint64:
+			if tags != nil {
+				if (len(tags.size) != 2 && len(tags.size) != 3) ||
+					(len(tags.size) == 2 && (tags.size[0] != outerSize || tags.size[1] != innerSize)) ||
+					(len(tags.size) == 3 && (tags.size[0] != outerSize || tags.size[1] != innerSize || tags.size[2] != 8)) {
+					return nil, fmt.Errorf("array of array of uint64 basic type tag conflict: field is [%d, %d] items, tag wants %v", outerSize, innerSize, tags.size)
+				}
+			}
+			return &opsetStatic{
+				"DefineArrayOfArrayOfUint64s({{.Codec}}, {{.Field}}[:])",
+				"EncodeArrayOfArrayOfUint64s({{.Codec}}, {{.Field}}[:])",
+				"DecodeArrayOfArrayOfUint64s({{.Codec}}, {{.Field}}[:])",
+				[]int{outerSize, innerSize, 8}, nil,
+			}, nil
+		default:
+			return nil, fmt.Errorf("unsupported array-of-array item basic type: %s", typ)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported array-of-array item type: %s", typ)
+	}
+}
+
+// resolveArrayOfSliceOpset retrieves the opset required to handle an array of
+// byte slices, which is only supported for packed bitvectors (e.g. an array of
+// go-bitfield bitvectors).
+func (p *parseContext) resolveArrayOfSliceOpset(typ types.Type, size int, tags *sizeTag, pointer bool) (opset, error) {
+	if basic, ok := types.Unalias(typ).(*types.Basic); !ok || basic.Kind() != types.Byte {
+		return nil, fmt.Errorf("unsupported array-of-slice item type: %s", typ)
+	}
+	if pointer {
+		return nil, fmt.Errorf("pointer to array of bits slices not supported")
+	}
+	if tags == nil || !tags.bits {
+		return nil, fmt.Errorf("array of byte slices requires ssz:\"bits\" tag")
+	}
+	if tags.limit != nil {
+		return nil, fmt.Errorf("array of bits slices cannot have ssz-max tag")
+	}
+	if len(tags.size) != 2 || tags.size[0] != size || tags.size[1] <= 0 {
+		return nil, fmt.Errorf("array of bits slices tag conflict: field is %d items, tag wants %v", size, tags.size)
+	}
+	return &opsetStatic{
+		fmt.Sprintf("DefineUnsafeArrayOfCheckedBits({{.Codec}}, {{.Field}}[:], %d)", tags.size[1]), // inject bit-size directly
+		fmt.Sprintf("EncodeUnsafeArrayOfCheckedBits({{.Codec}}, {{.Field}}[:], %d)", tags.size[1]), // inject bit-size directly
+		fmt.Sprintf("DecodeUnsafeArrayOfCheckedBits({{.Codec}}, {{.Field}}[:], %d)", tags.size[1]), // inject bit-size directly
+		[]int{size, (tags.size[1] + 7) / 8}, nil,
+	}, nil
+}
+
+func (p *parseContext) resolveSliceOpset(typ types.Type, tags *sizeTag) (opset, error) {
+	// Sanity check a few tag constraints relevant for all slice types
+	if tags == nil {
+		return nil, fmt.Errorf("slice type requires ssz tags")
+	}
+	switch typ := types.Unalias(typ).(type) {
+	case *types.Basic:
+		switch typ.Kind() {
+		case types.Byte:
+			// Slice of bytes. If we have ssz-size, it's a static slice
+			if len(tags.size) > 0 {
+				if (len(tags.size) != 1 && len(tags.size) != 2) ||
+					(len(tags.size) == 2 && tags.size[1] != 1) {
+					return nil, fmt.Errorf("static slice of byte basic type tag conflict: needs [N] or [N, 1] tag, has %v", tags.size)
+				}
+				if len(tags.limit) > 0 {
+					return nil, fmt.Errorf("static slice of byte basic type cannot have ssz-max tag")
+				}
+				return &opsetStatic{
+					"DefineCheckedStaticBytes({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+					"EncodeCheckedStaticBytes({{.Codec}}, &{{.Field}})",
+					"DecodeCheckedStaticBytes({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+					[]int{tags.size[0]}, nil,
+				}, nil
+			}
+			// Not a static slice of bytes, we need to pull ssz-max for the limits
+			if tags.limit == nil {
+				return nil, fmt.Errorf("dynamic slice of byte basic type requires ssz-max tag")
+			}
+			if len(tags.limit) != 1 {
+				return nil, fmt.Errorf("dynamic slice of byte basic type tag conflict: needs [N] tag, has %v", tags.limit)
+			}
+			return &opsetDynamic{
+				"SizeDynamicBytes({{.Sizer}}, {{.Field}})",
+				"DefineDynamicBytesOffset({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				"DefineDynamicBytesContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				"EncodeDynamicBytesOffset({{.Codec}}, &{{.Field}})",
+				"EncodeDynamicBytesContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				"DecodeDynamicBytesOffset({{.Codec}}, &{{.Field}})",
+				"DecodeDynamicBytesContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				[]int{0}, tags.limit, nil, nil,
+			}, nil
+
+		case types.Uint16:
+			// Slice of uint16s, only dynamic lists are supported
+			if len(tags.size) > 0 {
+				return nil, fmt.Errorf("static slice of uint16 basic type not supported, use an array")
+			}
+			if tags.limit == nil {
+				return nil, fmt.Errorf("dynamic slice of uint16 basic type requires ssz-max tag")
+			}
+			if len(tags.limit) != 1 {
+				return nil, fmt.Errorf("dynamic slice of uint16 basic type tag conflict: needs [N] tag, has %v", tags.limit)
+			}
+			return &opsetDynamic{
+				"SizeSliceOfUint16s({{.Sizer}}, {{.Field}})",
+				"DefineSliceOfUint16sOffset({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				"DefineSliceOfUint16sContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				"EncodeSliceOfUint16sOffset({{.Codec}}, &{{.Field}})",
+				"EncodeSliceOfUint16sContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				"DecodeSliceOfUint16sOffset({{.Codec}}, &{{.Field}})",
+				"DecodeSliceOfUint16sContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				nil, tags.limit, nil, nil,
+			}, nil
+
+		case types.Uint32:
+			// Slice of uint32s, only dynamic lists are supported
+			if len(tags.size) > 0 {
+				return nil, fmt.Errorf("static slice of uint32 basic type not supported, use an array")
+			}
+			if tags.limit == nil {
+				return nil, fmt.Errorf("dynamic slice of uint32 basic type requires ssz-max tag")
+			}
+			if len(tags.limit) != 1 {
+				return nil, fmt.Errorf("dynamic slice of uint32 basic type tag conflict: needs [N] tag, has %v", tags.limit)
+			}
+			return &opsetDynamic{
+				"SizeSliceOfUint32s({{.Sizer}}, {{.Field}})",
+				"DefineSliceOfUint32sOffset({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				"DefineSliceOfUint32sContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				"EncodeSliceOfUint32sOffset({{.Codec}}, &{{.Field}})",
+				"EncodeSliceOfUint32sContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				"DecodeSliceOfUint32sOffset({{.Codec}}, &{{.Field}})",
+				"DecodeSliceOfUint32sContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				nil, tags.limit, nil, nil,
+			}, nil
+
+		case types.Uint64:
+			// Slice of uint64s. If we have ssz-size, it's a static slice
+			if len(tags.size) > 0 {
+				if (len(tags.size) != 1 && len(tags.size) != 2) ||
+					(len(tags.size) == 2 && tags.size[1] != 8) {
+					return nil, fmt.Errorf("static slice of uint64 basic type tag conflict: needs [N] or [N, 8] tag, has %v", tags.size)
+				}
+				if len(tags.limit) > 0 {
+					return nil, fmt.Errorf("static slice of uint64 basic type cannot have ssz-max tag")
+				}
+				return &opsetStatic{
+					"DefineCheckedStaticUint64s({{.Codec}}, &{{.Field}}, {{.MaxItems}})",
+					"EncodeCheckedStaticUint64s({{.Codec}}, &{{.Field}}, {{.MaxItems}})",
+					"DecodeCheckedStaticUint64s({{.Codec}}, &{{.Field}}, {{.MaxItems}})",
+					[]int{tags.size[0], 8}, nil,
+				}, nil
+			}
+			// Not a static slice of bytes, we need to pull ssz-max for the limits
+			if tags.limit == nil {
+				return nil, fmt.Errorf("dynamic slice of uint64 basic type requires ssz-max tag")
+			}
+			if len(tags.limit) != 1 {
+				return nil, fmt.Errorf("dynamic slice of uint64 basic type tag conflict: needs [N] tag, has %v", tags.limit)
+			}
+			content := "SliceOfUint64s"
+			if tags.sorted {
+				content = "SortedSliceOfUint64s"
+			}
+			return &opsetDynamic{
+				"SizeSliceOfUint64s({{.Sizer}}, {{.Field}})",
+				"DefineSliceOfUint64sOffset({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				"Define" + content + "Content({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				"EncodeSliceOfUint64sOffset({{.Codec}}, &{{.Field}})",
+				"EncodeSliceOfUint64sContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				"DecodeSliceOfUint64sOffset({{.Codec}}, &{{.Field}})",
+				"Decode" + content + "Content({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				nil, tags.limit, nil, nil,
+			}, nil
+
+		default:
+			return nil, fmt.Errorf("unsupported slice item basic type: %s", typ)
+		}
+	case *types.Pointer:
+		if types.Implements(typ, p.staticObjectIface) {
+			if len(tags.size) > 0 {
+				return nil, fmt.Errorf("static slice of static objects not yet implemented")
+			}
+			if len(tags.limit) != 1 {
+				return nil, fmt.Errorf("dynamic slice of static objects type tag conflict: needs [N] tag, has %v", tags.limit)
+			}
+			return &opsetDynamic{
+				"SizeSliceOfStaticObjects({{.Sizer}}, {{.Field}})",
+				"DefineSliceOfStaticObjectsOffset({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				"DefineSliceOfStaticObjectsContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				"EncodeSliceOfStaticObjectsOffset({{.Codec}}, &{{.Field}})",
+				"EncodeSliceOfStaticObjectsContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				"DecodeSliceOfStaticObjectsOffset({{.Codec}}, &{{.Field}})",
+				"DecodeSliceOfStaticObjectsContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				nil, tags.limit, nil, nil,
+			}, nil
+		}
+		if types.Implements(typ, p.dynamicObjectIface) {
+			if len(tags.size) > 0 {
+				return nil, fmt.Errorf("static slice of dynamic objects not yet implemented")
+			}
+			if len(tags.limit) != 1 {
+				return nil, fmt.Errorf("dynamic slice of dynamic objects type tag conflict: needs [N] tag, has %v", tags.limit)
+			}
+			return &opsetDynamic{
+				"SizeSliceOfDynamicObjects({{.Sizer}}, {{.Field}})",
+				"DefineSliceOfDynamicObjectsOffset({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				"DefineSliceOfDynamicObjectsContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				"EncodeSliceOfDynamicObjectsOffset({{.Codec}}, &{{.Field}})",
+				"EncodeSliceOfDynamicObjectsContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				"DecodeSliceOfDynamicObjectsOffset({{.Codec}}, &{{.Field}})",
+				"DecodeSliceOfDynamicObjectsContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				nil, tags.limit, nil, nil,
+			}, nil
+
+		}
+		return nil, fmt.Errorf("unsupported pointer slice item type %s", typ.String())
+
+	case *types.Array:
+		return p.resolveSliceOfArrayOpset(typ.Elem(), int(typ.Len()), tags)
+
+	case *types.Slice:
+		return p.resolveSliceOfSliceOpset(typ.Elem(), tags)
+
+	case *types.Named:
+		// Objects stored by value have their ssz methods on the pointer receiver
+		if _, ok := typ.Underlying().(*types.Struct); ok {
+			ptr := types.NewPointer(typ)
+			if types.Implements(ptr, p.staticObjectIface) {
+				if len(tags.size) > 0 {
+					return nil, fmt.Errorf("static slice of static objects not yet implemented")
+				}
+				if len(tags.limit) != 1 {
+					return nil, fmt.Errorf("dynamic slice of static objects type tag conflict: needs [N] tag, has %v", tags.limit)
+				}
+				return &opsetDynamic{
+					"SizeSliceOfStaticObjectValues({{.Sizer}}, {{.Field}})",
+					"DefineSliceOfStaticObjectValuesOffset({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+					"DefineSliceOfStaticObjectValuesContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+					"EncodeSliceOfStaticObjectValuesOffset({{.Codec}}, &{{.Field}})",
+					"EncodeSliceOfStaticObjectValuesContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+					"DecodeSliceOfStaticObjectValuesOffset({{.Codec}}, &{{.Field}})",
+					"DecodeSliceOfStaticObjectValuesContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+					nil, tags.limit, nil, nil,
+				}, nil
+			}
+			if types.Implements(ptr, p.dynamicObjectIface) {
+				return nil, fmt.Errorf("dynamic slice of dynamic objects stored by value not supported: %s", typ)
+			}
+		}
+		return p.resolveSliceOpset(typ.Underlying(), tags)
+
+	default:
+		return nil, fmt.Errorf("unsupported slice item type: %s", typ)
+	}
+}
+
+func (p *parseContext) resolveSliceOfArrayOpset(typ types.Type, innerSize int, tags *sizeTag) (opset, error) {
+	switch typ := types.Unalias(typ).(type) {
+	case *types.Basic:
+		switch typ.Kind() {
+		case types.Byte:
+			// If the inner byte arrays are packed bitvectors, handle them explicitly
+			if tags.bits {
+				if len(tags.size) != 2 || tags.size[1] < (innerSize-1)*8+1 || tags.size[1] > innerSize*8 {
+					return nil, fmt.Errorf("static slice of array of bits tag conflict: field supports [N, %d-%d] bits, tag wants %v bits", (innerSize-1)*8+1, innerSize*8, tags.size)
+				}
+				if len(tags.limit) > 0 {
+					return nil, fmt.Errorf("static slice of array of bits cannot have ssz-max tag")
+				}
+				return &opsetStatic{
+					fmt.Sprintf("DefineCheckedArrayOfBits({{.Codec}}, &{{.Field}}, {{.MaxItems}}, %d)", tags.size[1]), // inject bit-size directly
+					"EncodeCheckedArrayOfBits({{.Codec}}, {{.Field}}, {{.MaxItems}})",
+					fmt.Sprintf("DecodeCheckedArrayOfBits({{.Codec}}, &{{.Field}}, {{.MaxItems}}, %d)", tags.size[1]), // inject bit-size directly
+					[]int{tags.size[0], innerSize}, nil,
+				}, nil
+			}
+			// Slice of array of bytes. If we have ssz-size, it's a static slice.
+			if len(tags.size) > 0 {
+				if (len(tags.size) != 1 && len(tags.size) != 2) ||
+					(len(tags.size) == 2 && tags.size[1] != innerSize) {
+					return nil, fmt.Errorf("static slice of array of byte basic type tag conflict: needs [N] or [N, %d] tag, has %v", innerSize, tags.size)
+				}
+				if len(tags.limit) > 0 {
+					return nil, fmt.Errorf("static slice of array of byte basic type cannot have ssz-max tag")
+				}
+				return &opsetStatic{
+					"DefineCheckedArrayOfStaticBytes({{.Codec}}, &{{.Field}}, {{.MaxItems}})",
+					"EncodeCheckedArrayOfStaticBytes({{.Codec}}, &{{.Field}})",
+					"DecodeCheckedArrayOfStaticBytes({{.Codec}}, &{{.Field}}, {{.MaxItems}})",
+					[]int{tags.size[0], innerSize}, nil,
+				}, nil
+			}
+			// Not a static slice of array of bytes, we need to pull ssz-max for the limits
+			if tags.limit == nil {
+				return nil, fmt.Errorf("dynamic slice of array of byte basic type requires ssz-max tag")
+			}
+			if len(tags.limit) != 1 {
+				return nil, fmt.Errorf("dynamic slice of array of byte basic type tag conflict: needs [N] tag, has %v", tags.limit)
+			}
+			return &opsetDynamic{
+				"SizeSliceOfStaticBytes({{.Sizer}}, {{.Field}})",
+				"DefineSliceOfStaticBytesOffset({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				"DefineSliceOfStaticBytesContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				"EncodeSliceOfStaticBytesOffset({{.Codec}}, &{{.Field}})",
+				"EncodeSliceOfStaticBytesContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				"DecodeSliceOfStaticBytesOffset({{.Codec}}, &{{.Field}})",
+				"DecodeSliceOfStaticBytesContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+				[]int{0, innerSize}, tags.limit, nil, nil,
+			}, nil
+		default:
+			return nil, fmt.Errorf("unsupported array-of-array item basic type: %s", typ)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported array-of-array item type: %s", typ)
+	}
+}
+
+func (p *parseContext) resolveSliceOfSliceOpset(typ types.Type, tags *sizeTag) (*opsetDynamic, error) {
+	switch typ := types.Unalias(typ).(type) {
+	case *types.Basic:
+		switch typ.Kind() {
+		case types.Byte:
+			// Slice of slice of bytes. At this point we have 2D possibilities of
+			// ssz-size and ssz-max combinations, each resulting in a different
+			// call that we have to make. Reject any conflicts in the tags, after
+			// which assemble the required combo.
+			switch {
+			case len(tags.size) > 0 && len(tags.limit) == 0:
+				return nil, fmt.Errorf("static slice of static slice of bytes not implemented yet")
+
+			case len(tags.size) > 0 && len(tags.limit) > 0:
+				if len(tags.size) != 2 || tags.size[0] == 0 || tags.size[1] != 0 {
+					return nil, fmt.Errorf("static slice of dynamic slice of byte basic type tag conflict: needs [N, ?] ssz-size tag, has %v", tags.size)
+				}
+				if len(tags.limit) != 2 || tags.limit[0] != 0 || tags.limit[1] == 0 {
+					return nil, fmt.Errorf("static slice of dynamic slice of byte basic type tag conflict: needs [?, M] ssz-max tag, has %v", tags.limit)
+				}
+				return &opsetDynamic{
+					"SizeCheckedArrayOfDynamicBytes({{.Sizer}}, {{.Field}}, {{.MaxItems}})",
+					"DefineCheckedArrayOfDynamicBytesOffset({{.Codec}}, &{{.Field}}, {{.MaxItems}}, {{.MaxSize}})",
+					"DefineCheckedArrayOfDynamicBytesContent({{.Codec}}, &{{.Field}}, {{.MaxItems}}, {{.MaxSize}})",
+					"EncodeCheckedArrayOfDynamicBytesOffset({{.Codec}}, &{{.Field}}, {{.MaxItems}})",
+					"EncodeCheckedArrayOfDynamicBytesContent({{.Codec}}, &{{.Field}}, {{.MaxItems}})",
+					"DecodeCheckedArrayOfDynamicBytesOffset({{.Codec}}, &{{.Field}})",
+					"DecodeCheckedArrayOfDynamicBytesContent({{.Codec}}, &{{.Field}}, {{.MaxItems}}, {{.MaxSize}})",
+					[]int{tags.size[0], 0}, []int{tags.size[0], tags.limit[1]}, nil, nil,
+				}, nil
+
+			case len(tags.size) == 0 && len(tags.limit) > 0:
+				if len(tags.limit) != 2 {
+					return nil, fmt.Errorf("dynamic slice of dynamic slice of byte basic type tag conflict: needs [N, M] ssz-max tag, has %v", tags.limit)
+				}
+				return &opsetDynamic{
+					"SizeSliceOfDynamicBytes({{.Sizer}}, {{.Field}})",
+					"DefineSliceOfDynamicBytesOffset({{.Codec}}, &{{.Field}}, {{.MaxItems}}, {{.MaxSize}})",
+					"DefineSliceOfDynamicBytesContent({{.Codec}}, &{{.Field}}, {{.MaxItems}}, {{.MaxSize}})",
+					"EncodeSliceOfDynamicBytesOffset({{.Codec}}, &{{.Field}})",
+					"EncodeSliceOfDynamicBytesContent({{.Codec}}, &{{.Field}}, {{.MaxItems}}, {{.MaxSize}})",
+					"DecodeSliceOfDynamicBytesOffset({{.Codec}}, &{{.Field}})",
+					"DecodeSliceOfDynamicBytesContent({{.Codec}}, &{{.Field}}, {{.MaxItems}}, {{.MaxSize}})",
+					nil, tags.limit, nil, nil,
+				}, nil
+
+			default:
+				return nil, fmt.Errorf("not implemented yet")
+			}
+		default:
+			return nil, fmt.Errorf("unsupported slice-of-slice item basic type: %s", typ)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported slice-of-slice item type: %s", typ)
+	}
+}
+
+// uint256Literal formats a uint256 as a Go composite literal, omitting the zero
+// high limbs, so it can be passed to the codec without allocating.
+func uint256Literal(n *uint256.Int) string {
+	limbs := n[:]
+	for len(limbs) > 0 && limbs[len(limbs)-1] == 0 {
+		limbs = limbs[:len(limbs)-1]
+	}
+	words := make([]string, len(limbs))
+	for i, limb := range limbs {
+		words[i] = fmt.Sprint(limb)
+	}
+	return "uint256.Int{" + strings.Join(words, ", ") + "}"
+}
+
+// resolveSlicePointerOpset retrieves the opset required to handle a pointer to a
+// dynamic slice. Only the list types that can be added in later forks of the
+// consensus types are supported.
+func (p *parseContext) resolveSlicePointerOpset(typ types.Type, tags *sizeTag) (opset, error) {
+	if tags != nil && tags.sorted {
+		return nil, fmt.Errorf("pointer to sorted slice not supported")
+	}
+	op, err := p.resolveSliceOpset(typ, tags)
+	if err != nil {
+		return nil, err
+	}
+	dyn, ok := op.(*opsetDynamic)
+	if !ok {
+		return nil, fmt.Errorf("unsupported pointer to static slice: %s", typ)
+	}
+	switch kind := strings.TrimSuffix(strings.SplitN(dyn.defineOffset, "(", 2)[0], "Offset"); kind {
+	case "DefineSliceOfUint64s", "DefineSliceOfStaticBytes", "DefineSliceOfStaticObjects", "DefineSliceOfDynamicObjects":
+	default:
+		return nil, fmt.Errorf("unsupported pointer to slice item type: %s", typ)
+	}
+	pointerify := func(call string, suffix string) string {
+		return strings.Replace(call, suffix+"(", "Pointer"+suffix+"(", 1)
+	}
+	return &opsetDynamic{
+		size:          strings.Replace(dyn.size, "(", "Pointer(", 1),
+		defineOffset:  pointerify(dyn.defineOffset, "Offset"),
+		defineContent: pointerify(dyn.defineContent, "Content"),
+		encodeOffset:  pointerify(dyn.encodeOffset, "Offset"),
+		encodeContent: pointerify(dyn.encodeContent, "Content"),
+		decodeOffset:  pointerify(dyn.decodeOffset, "Offset"),
+		decodeContent: pointerify(dyn.decodeContent, "Content"),
+		sizes:         dyn.sizes,
+		limits:        dyn.limits,
+	}, nil
+}
+
+func (p *parseContext) resolvePointerOpset(typ *types.Pointer, tags *sizeTag) (opset, error) {
+	if isUint256(typ.Elem()) {
+		if tags != nil {
+			if tags.limit != nil {
+				return nil, fmt.Errorf("uint256 basic type cannot have ssz-max tag")
+			}
+			if tags.size != nil && (len(tags.size) != 1 || tags.size[0] != 32) {
+				return nil, fmt.Errorf("uint256 basic type tag conflict: field is [32] bytes, tag wants %v", tags.size)
+			}
+			if tags.maxValue != nil {
+				max := uint256Literal(tags.maxValue)
+				return &opsetStatic{
+					"DefineUint256Max({{.Codec}}, &{{.Field}}, " + max + ")",
+					"EncodeUint256({{.Codec}}, &{{.Field}})",
+					"DecodeUint256Max({{.Codec}}, &{{.Field}}, " + max + ")",
+					[]int{32}, nil,
+				}, nil
+			}
+		}
+		return &opsetStatic{
+			"DefineUint256({{.Codec}}, &{{.Field}})",
+			"EncodeUint256({{.Codec}}, &{{.Field}})",
+			"DecodeUint256({{.Codec}}, &{{.Field}})",
+			[]int{32}, nil,
+		}, nil
+	}
+	if isBigInt(typ.Elem()) {
+		if tags != nil {
+			if tags.limit != nil {
+				return nil, fmt.Errorf("big.Int (uint256) basic type cannot have ssz-max tag")
+			}
+			if tags.size != nil && (len(tags.size) != 1 || tags.size[0] != 32) {
+				return nil, fmt.Errorf("big.Int (uint256) basic type tag conflict: field is [32] bytes, tag wants %v", tags.size)
+			}
+			if tags.maxValue != nil {
+				max := uint256Literal(tags.maxValue)
+				return &opsetStatic{
+					"DefineUint256BigIntMax({{.Codec}}, &{{.Field}}, " + max + ")",
+					"EncodeUint256BigInt({{.Codec}}, &{{.Field}})",
+					"DecodeUint256BigIntMax({{.Codec}}, &{{.Field}}, " + max + ")",
+					[]int{32}, nil,
+				}, nil
+			}
+		}
+		return &opsetStatic{
+			"DefineUint256BigInt({{.Codec}}, &{{.Field}})",
+			"EncodeUint256BigInt({{.Codec}}, &{{.Field}})",
+			"DecodeUint256BigInt({{.Codec}}, &{{.Field}})",
+			[]int{32}, nil,
+		}, nil
+	}
+	if types.Implements(typ, p.staticObjectIface) {
+		if tags != nil {
+			return nil, fmt.Errorf("static object type cannot have any ssz tags")
+		}
+		return &opsetStatic{
+			"DefineStaticObject({{.Codec}}, &{{.Field}})",
+			"EncodeStaticObject({{.Codec}}, &{{.Field}})",
+			"DecodeStaticObject({{.Codec}}, &{{.Field}})",
+			nil, nil,
+		}, nil
+	}
+	if types.Implements(typ, p.dynamicObjectIface) {
+		if tags != nil {
+			return nil, fmt.Errorf("dynamic object type cannot have any ssz tags")
+		}
+		return &opsetDynamic{
+			"SizeDynamicObject({{.Sizer}}, {{.Field}})",
+			"DefineDynamicObjectOffset({{.Codec}}, &{{.Field}})",
+			"DefineDynamicObjectContent({{.Codec}}, &{{.Field}})",
+			"EncodeDynamicObjectOffset({{.Codec}}, &{{.Field}})",
+			"EncodeDynamicObjectContent({{.Codec}}, &{{.Field}})",
+			"DecodeDynamicObjectOffset({{.Codec}}, &{{.Field}})",
+			"DecodeDynamicObjectContent({{.Codec}}, &{{.Field}})",
+			nil, nil, nil, nil,
+		}, nil
+	}
+	named, ok := types.Unalias(typ.Elem()).(*types.Named)
+	if !ok {
+		return nil, fmt.Errorf("unsupported pointer type %s", typ.String())
+	}
+	return p.resolveOpset(named.Underlying(), tags, true)
+}
+
+// resolveTypeParamOpset retrieves the opset required to handle a field typed as
+// a type parameter of a generic container, which needs to be constrained to an
+// ssz object type (the instantiations need to be struct pointers).
+func (p *parseContext) resolveTypeParamOpset(typ *types.TypeParam, tags *sizeTag) (opset, error) {
+	if tags != nil {
+		return nil, fmt.Errorf("type parameter %s cannot have any ssz tags", typ)
+	}
+	if types.Implements(typ, p.staticObjectIface) {
+		return &opsetStatic{
+			"DefineGenericStaticObject({{.Codec}}, &{{.Field}})",
+			"EncodeGenericStaticObject({{.Codec}}, &{{.Field}})",
+			"DecodeGenericStaticObject({{.Codec}}, &{{.Field}})",
+			nil, nil,
+		}, nil
+	}
+	if types.Implements(typ, p.dynamicObjectIface) {
+		return &opsetDynamic{
+			"SizeGenericDynamicObject({{.Sizer}}, {{.Field}})",
+			"DefineGenericDynamicObjectOffset({{.Codec}}, &{{.Field}})",
+			"DefineGenericDynamicObjectContent({{.Codec}}, &{{.Field}})",
+			"EncodeGenericDynamicObjectOffset({{.Codec}}, &{{.Field}})",
+			"EncodeGenericDynamicObjectContent({{.Codec}}, &{{.Field}})",
+			"DecodeGenericDynamicObjectOffset({{.Codec}}, &{{.Field}})",
+			"DecodeGenericDynamicObjectContent({{.Codec}}, &{{.Field}})",
+			nil, nil, nil, nil,
+		}, nil
+	}
+	return nil, fmt.Errorf("type parameter %s constraint %s implements neither ssz.StaticObject nor ssz.DynamicObject", typ, typ.Constraint())
+}
+
+// resolveMapOpset retrieves the opset required to handle a map field, encoded as
+// a dynamic list of key/value containers sorted by key. The container type is not
+// declared by the user, rather synthesized here and generated alongside the owner.
+func (p *parseContext) resolveMapOpset(owner *types.Named, field string, typ *types.Map, tags *sizeTag) (opset, *sszContainer, error) {
+	// Sanity check a few tag constraints relevant for all map types
+	if tags == nil || (tags.mapKey == "" && !tags.mapSorted) {
+		return nil, nil, fmt.Errorf("map type requires %s or %s tag", sszMapKeyTagIdent, sszMapSortedTagIdent)
+	}
+	if len(tags.limit) == 0 || tags.limit[0] == 0 {
+		return nil, nil, fmt.Errorf("map type requires ssz-max tag for the number of entries")
+	}
+	if len(tags.size) > 0 && tags.size[0] != 0 {
+		return nil, nil, fmt.Errorf("map type cannot have a static size: have %v", tags.size)
+	}
+	// Construct the tags of the value field from the remaining dimensions
+	var valueTags []string
+	if tags.bits {
+		valueTags = append(valueTags, `ssz:"bits"`)
+	}
+	if len(tags.size) > 1 {
+		valueTags = append(valueTags, fmt.Sprintf(`%s:"%s"`, sszSizeTagIdent, joinNamedDims(tags.size[1:], tags.sizeNames[1:])))
+	}
+	if len(tags.limit) > 1 {
+		valueTags = append(valueTags, fmt.Sprintf(`%s:"%s"`, sszMaxTagIdent, joinNamedDims(tags.limit[1:], tags.limitNames[1:])))
+	}
+	// Synthesize the key/value container and resolve it as any other struct
+	keyName := tags.mapKey
+	if keyName == "" {
+		keyName = "Key"
+	}
+	pkg := owner.Obj().Pkg()
+
+	fields := []*types.Var{
+		types.NewField(token.NoPos, pkg, keyName, typ.Key(), false),
+		types.NewField(token.NoPos, pkg, "Value", typ.Elem(), false),
+	}
+	str := types.NewStruct(fields, []string{"", strings.Join(valueTags, " ")})
+
+	name := types.NewTypeName(token.NoPos, pkg, lowerFirst(owner.Obj().Name())+field+"Entry", nil)
+	named := types.NewNamed(name, str, nil)
+
+	entry, err := p.makeContainer(named, str)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, ok := entry.opsets[0].(*opsetStatic); !ok {
+		return nil, nil, fmt.Errorf("map key type must be static: have %s", typ.Key())
+	}
+	kind := "Dynamic"
+	if entry.static {
+		kind = "Static"
+	}
+	return &opsetDynamic{
+		fmt.Sprintf("SizeMapOf%sEntries[*%s]({{.Sizer}}, {{.Field}})", kind, name.Name()),
+		fmt.Sprintf("DefineMapOf%sEntriesOffset[*%s]({{.Codec}}, &{{.Field}}, {{.MaxSize}})", kind, name.Name()),
+		fmt.Sprintf("DefineMapOf%sEntriesContent[*%s]({{.Codec}}, &{{.Field}}, {{.MaxSize}})", kind, name.Name()),
+		fmt.Sprintf("EncodeMapOf%sEntriesOffset[*%s]({{.Codec}}, &{{.Field}})", kind, name.Name()),
+		fmt.Sprintf("EncodeMapOf%sEntriesContent[*%s]({{.Codec}}, &{{.Field}}, {{.MaxSize}})", kind, name.Name()),
+		fmt.Sprintf("DecodeMapOf%sEntriesOffset[*%s]({{.Codec}}, &{{.Field}})", kind, name.Name()),
+		fmt.Sprintf("DecodeMapOf%sEntriesContent[*%s]({{.Codec}}, &{{.Field}}, {{.MaxSize}})", kind, name.Name()),
+		nil, []int{tags.limit[0]}, nil, nil,
+	}, entry, nil
+}
@@ -0,0 +1,230 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// parseContext contains some helpers for interpreting generated types.
+type parseContext struct {
+	library            *types.Package
+	staticObjectIface  *types.Interface
+	dynamicObjectIface *types.Interface
+	lenient            bool // whether to ignore unknown ssz struct tags
+}
+
+// newParseContext loads a few ssz library interfaces for the generator.
+func newParseContext(library *types.Package) *parseContext {
+	var (
+		static  = library.Scope().Lookup("StaticObject").Type().Underlying()
+		dynamic = library.Scope().Lookup("DynamicObject").Type().Underlying()
+	)
+	return &parseContext{
+		library:            library,
+		staticObjectIface:  static.(*types.Interface),
+		dynamicObjectIface: dynamic.(*types.Interface),
+	}
+}
+
+// parsePackage retrieves the specified named-types from the target package and
+// creates ssz containers out of them.
+func (p *parseContext) parsePackage(target *types.Package, names []string) ([]*sszContainer, error) {
+	// If no types were requested, parse all of them
+	if len(names) == 0 {
+		names = target.Scope().Names()
+	}
+	var containers []*sszContainer
+	for _, name := range names {
+		// Generic types may be requested with their type parameters listed (e.g.
+		// Signed[T]), in which case make sure they match the declaration
+		name, params, generic := strings.Cut(name, "[")
+
+		named, str, err := p.lookupStruct(target.Scope(), name)
+		if err != nil {
+			return nil, err
+		}
+		if generic {
+			want := strings.Join(typeParamNames(named), ", ")
+			have := strings.Join(strings.Fields(strings.ReplaceAll(strings.TrimSuffix(params, "]"), ",", " ")), ", ")
+			if have != want {
+				return nil, fmt.Errorf("type parameters mismatch for %s: have [%s], want [%s]", name, have, want)
+			}
+		}
+		typ, err := p.makeContainer(named, str)
+		if err != nil {
+			return nil, err
+		}
+		containers = append(containers, typ)
+	}
+	return containers, nil
+}
+
+// sszForkDirective is the doc comment directive restricting an entire type to
+// certain forks (e.g. `//ssz:fork deneb` for types that don't exist before).
+const sszForkDirective = "//ssz:fork "
+
+// parseTypeForks collects the fork constraints of the types declared with a fork
+// directive in their doc comments, keyed by type name. The constraints follow
+// the same syntax as the ssz-fork field tags.
+func parseTypeForks(files []*ast.File) (map[string]string, error) {
+	forks := make(map[string]string)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				spec := spec.(*ast.TypeSpec)
+
+				// Single type declarations have the docs on the declaration
+				doc := spec.Doc
+				if doc == nil && len(gen.Specs) == 1 {
+					doc = gen.Doc
+				}
+				if doc == nil {
+					continue
+				}
+				for _, comment := range doc.List {
+					constraint, ok := strings.CutPrefix(comment.Text, sszForkDirective)
+					if !ok {
+						continue
+					}
+					if _, ok := forks[spec.Name.Name]; ok {
+						return nil, fmt.Errorf("duplicate fork directive on type %s", spec.Name.Name)
+					}
+					fork, err := parseForkConstraint(strings.TrimSpace(constraint))
+					if err != nil {
+						return nil, fmt.Errorf("failed to parse fork directive of type %s: %v", spec.Name.Name, err)
+					}
+					forks[spec.Name.Name] = fork
+				}
+			}
+		}
+	}
+	return forks, nil
+}
+
+// parseTypeSources collects the canonically formatted declarations of the types,
+// keyed by type name, to detect changes in them without regenerating the code.
+// Comments are not part of the output, so editing them does not count as change.
+func parseTypeSources(fset *token.FileSet, files []*ast.File) (map[string][]byte, error) {
+	sources := make(map[string][]byte)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				spec := spec.(*ast.TypeSpec)
+
+				// Detach the field comments while formatting, restoring them after
+				var fields []*ast.Field
+				ast.Inspect(spec, func(n ast.Node) bool {
+					if field, ok := n.(*ast.Field); ok {
+						fields = append(fields, field)
+					}
+					return true
+				})
+				docs := make([]*ast.CommentGroup, 2*len(fields))
+				for i, field := range fields {
+					docs[2*i], docs[2*i+1] = field.Doc, field.Comment
+					field.Doc, field.Comment = nil, nil
+				}
+				var buf bytes.Buffer
+				err := format.Node(&buf, fset, spec)
+				for i, field := range fields {
+					field.Doc, field.Comment = docs[2*i], docs[2*i+1]
+				}
+				if err != nil {
+					return nil, fmt.Errorf("failed to format declaration of type %s: %v", spec.Name.Name, err)
+				}
+				sources[spec.Name.Name] = buf.Bytes()
+			}
+		}
+	}
+	return sources, nil
+}
+
+// parseTypeRefs collects the declarations of the types referenced by the fields
+// of a container (transitively), since their static sizes are baked into the code
+// generated for it. Types of the target package are represented by their source
+// declaration, ssz types of other packages by their type definition.
+func (p *parseContext) parseTypeRefs(target *types.Package, named *types.Named, sources map[string][]byte) [][]byte {
+	var (
+		refs  [][]byte
+		seen  = map[*types.Named]bool{named.Origin(): true}
+		visit func(typ types.Type)
+	)
+	visit = func(typ types.Type) {
+		switch typ := types.Unalias(typ).(type) {
+		case *types.Pointer:
+			visit(typ.Elem())
+		case *types.Slice:
+			visit(typ.Elem())
+		case *types.Array:
+			visit(typ.Elem())
+		case *types.Map:
+			visit(typ.Key())
+			visit(typ.Elem())
+		case *types.Struct:
+			for i := 0; i < typ.NumFields(); i++ {
+				visit(typ.Field(i).Type())
+			}
+		case *types.Named:
+			origin := typ.Origin()
+			if seen[origin] {
+				return
+			}
+			seen[origin] = true
+
+			name := origin.Obj().Name()
+			switch {
+			case origin.Obj().Pkg() == target:
+				refs = append(refs, append([]byte(name+"\n"), sources[name]...))
+			case types.Implements(types.NewPointer(typ), p.staticObjectIface) || types.Implements(types.NewPointer(typ), p.dynamicObjectIface):
+				refs = append(refs, []byte(types.TypeString(origin, nil)+" "+types.TypeString(origin.Underlying(), nil)))
+			default:
+				return
+			}
+			for i := 0; i < typ.TypeArgs().Len(); i++ {
+				visit(typ.TypeArgs().At(i))
+			}
+			visit(origin.Underlying())
+		}
+	}
+	visit(named.Underlying())
+	return refs
+}
+
+// lookupStruct is a small helper to check that a type name is indeed a struct
+// that we can convert into an ssz type.
+func (p *parseContext) lookupStruct(scope *types.Scope, name string) (*types.Named, *types.Struct, error) {
+	obj := scope.Lookup(name)
+	if obj == nil {
+		return nil, nil, fmt.Errorf("identifier not found: %s", name)
+	}
+	typ, ok := obj.(*types.TypeName)
+	if !ok {
+		return nil, nil, fmt.Errorf("identifier not a type: %s", name)
+	}
+	dec, ok := typ.Type().(*types.Named)
+	if !ok {
+		return nil, nil, fmt.Errorf("identifier not a named type: %s", name)
+	}
+	str, ok := dec.Underlying().(*types.Struct)
+	if !ok {
+		return nil, nil, fmt.Errorf("identifier not a named struct: %s", name)
+	}
+	return dec, str, nil
+}
@@ -0,0 +1,353 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package gen
+
+import (
+	"fmt"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"github.com/holiman/uint256"
+)
+
+const (
+	sszTagIdent     = "ssz"
+	sszSizeTagIdent = "ssz-size"
+	sszMaxTagIdent  = "ssz-max"
+	sszForkTagIdent = "ssz-fork"
+
+	sszMaxForkTagIdent   = "ssz-max-fork"
+	sszMapKeyTagIdent    = "ssz-map-key"
+	sszMapSortedTagIdent = "ssz-map-sorted"
+	sszMaxValueTagIdent  = "ssz-maxvalue"
+	sszForkTypeTagIdent  = "ssz-fork-type"
+	sszSortedTagIdent    = "ssz-sorted"
+)
+
+// sizeTag describes the restriction for types.
+type sizeTag struct {
+	bits       bool         // whether the sizes are bits instead of bytes
+	utf8       bool         // whether strings need to be validated as UTF-8
+	size       []int        // 0 means the size for that dimension is undefined
+	sizeNames  []string     // constant names the sizes were declared with ("" for literals)
+	limit      []int        // 0 means the limit for that dimension is undefined
+	limitNames []string     // constant names the limits were declared with ("" for literals)
+	overrides  []forkLimit  // fork specific overrides for the limit
+	mapKey     string       // name of the key field in a map's entry container
+	mapSorted  bool         // whether a map was opted into sorted list encoding
+	maxValue   *uint256.Int // maximum value permitted for a uint256 field
+	forkTypes  []forkType   // fork specific alternative encodings of the field
+	sorted     bool         // whether list items need to be validated as strictly increasing
+}
+
+// forkType is an alternative encoding of a field that takes effect from a specific
+// fork onward (e.g. electra:SliceOfBits(131072)).
+type forkType struct {
+	fork     string   // fork enum name (without the Fork prefix)
+	encoding string   // ssz encoding family to use from the fork onward
+	dims     []int    // sizes or limits of the encoding (0 == undefined)
+	names    []string // constant names the dimensions were declared with
+}
+
+// forkLimit is a limit override that takes effect from a specific fork onward.
+type forkLimit struct {
+	fork  string // fork enum name (without the Fork prefix)
+	limit int    // limit to use from the fork onward
+}
+
+// parseTags parses the ssz struct tags of a field. Sizes and limits may reference
+// named integer constants declared in the given package scope instead of literals.
+//
+// Unknown ssz tags (and values of the plain ssz tag) are rejected to catch typos,
+// unless lenient parsing is requested, in which case they are ignored.
+func parseTags(input string, scope *types.Scope, lenient bool) (bool, *sizeTag, string, error) {
+	if len(input) == 0 {
+		return false, nil, "", nil
+	}
+	var (
+		ignore bool
+		tags   sizeTag
+		fork   string
+
+		setTag = func(v int, ident string, name string) {
+			if ident == sszMaxTagIdent {
+				tags.limit = append(tags.limit, v)
+				tags.limitNames = append(tags.limitNames, name)
+			} else {
+				tags.size = append(tags.size, v)
+				tags.sizeNames = append(tags.sizeNames, name)
+			}
+		}
+	)
+	for _, tag := range strings.Fields(input) {
+		ident, remain, ok := strings.Cut(tag, ":")
+		if !ok {
+			return false, nil, "", fmt.Errorf("invalid tag %s", tag)
+		}
+		remain = strings.Trim(remain, "\"")
+		switch ident {
+		case sszTagIdent:
+			if remain == "-" {
+				ignore = true
+			} else if remain == "bits" {
+				tags.bits = true
+			} else if remain == "utf8" {
+				tags.utf8 = true
+			} else if !lenient {
+				return false, nil, "", fmt.Errorf("unknown value in tag %s", tag)
+			}
+		case sszMaxTagIdent, sszSizeTagIdent:
+			parts := strings.Split(remain, ",")
+			for _, p := range parts {
+				if p == "?" {
+					setTag(0, ident, "")
+					continue
+				}
+				if token.IsIdentifier(p) {
+					num, err := resolveConstant(scope, p, ident == sszMaxTagIdent)
+					if err != nil {
+						return false, nil, "", fmt.Errorf("invalid dimension in tag %s: %v", tag, err)
+					}
+					setTag(num, ident, p)
+					continue
+				}
+				num, err := strconv.ParseInt(p, 10, 64)
+				if err != nil {
+					return false, nil, "", err
+				}
+				setTag(int(num), ident, "")
+			}
+		case sszForkTagIdent:
+			parsed, err := parseForkConstraint(remain)
+			if err != nil {
+				return ignore, nil, "", fmt.Errorf("%v in tag %s", err, tag)
+			}
+			fork = parsed
+		case sszMapKeyTagIdent:
+			if remain == "" {
+				return ignore, nil, "", fmt.Errorf("empty map key name in tag %s", tag)
+			}
+			tags.mapKey = remain
+		case sszMapSortedTagIdent:
+			sorted, err := strconv.ParseBool(remain)
+			if err != nil {
+				return ignore, nil, "", fmt.Errorf("invalid map sorting flag in tag %s", tag)
+			}
+			tags.mapSorted = sorted
+		case sszMaxValueTagIdent:
+			max, err := uint256.FromDecimal(remain)
+			if err != nil {
+				return ignore, nil, "", fmt.Errorf("invalid maximum value in tag %s: %v", tag, err)
+			}
+			tags.maxValue = max
+		case sszForkTypeTagIdent:
+			types, err := parseForkTypes(remain, scope)
+			if err != nil {
+				return ignore, nil, "", fmt.Errorf("%v in tag %s", err, tag)
+			}
+			tags.forkTypes = append(tags.forkTypes, types...)
+		case sszSortedTagIdent:
+			if remain != "asc" {
+				return ignore, nil, "", fmt.Errorf("invalid sort order in tag %s (only asc supported)", tag)
+			}
+			tags.sorted = true
+		case sszMaxForkTagIdent:
+			for _, override := range strings.Split(remain, ",") {
+				parts := strings.Split(override, "=")
+				if len(parts) != 2 {
+					return ignore, nil, "", fmt.Errorf("invalid fork limit %s in tag %s", override, tag)
+				}
+				enum, ok := forkMapping[parts[0]]
+				if !ok {
+					return ignore, nil, "", fmt.Errorf("invalid fork %s in tag %s", parts[0], tag)
+				}
+				num, err := strconv.ParseInt(parts[1], 10, 64)
+				if err != nil {
+					return ignore, nil, "", err
+				}
+				tags.overrides = append(tags.overrides, forkLimit{fork: enum, limit: int(num)})
+			}
+		default:
+			if !lenient && strings.HasPrefix(ident, sszTagIdent+"-") {
+				return ignore, nil, "", fmt.Errorf("unknown tag %s", tag)
+			}
+		}
+	}
+	if tags.overrides != nil && len(tags.limit) != 1 {
+		return ignore, nil, "", fmt.Errorf("%s tag requires a 1D %s tag, has %v", sszMaxForkTagIdent, sszMaxTagIdent, tags.limit)
+	}
+	if tags.forkTypes != nil && fork != "" {
+		return ignore, nil, "", fmt.Errorf("%s tag cannot be combined with %s tag", sszForkTypeTagIdent, sszForkTagIdent)
+	}
+	if tags.size == nil && tags.limit == nil && tags.mapKey == "" && !tags.mapSorted && tags.maxValue == nil && tags.forkTypes == nil && !tags.sorted {
+		return ignore, nil, fork, nil
+	}
+	return ignore, &tags, fork, nil
+}
+
+// validateTags checks that the ssz tags of a field are all meaningful for the
+// opset it was resolved to, rejecting the ones that would be silently ignored
+// otherwise.
+func validateTags(typ types.Type, tags *sizeTag, op opset) error {
+	if tags == nil {
+		return nil
+	}
+	if tags.maxValue != nil && !isUint256Pointer(typ) {
+		return fmt.Errorf("%s tag requires a uint256 type", sszMaxValueTagIdent)
+	}
+	if underlyingMap(typ) != nil {
+		return nil // map tags are forwarded to the keys and values
+	}
+	if tags.mapKey != "" || tags.mapSorted {
+		return fmt.Errorf("non-map type cannot have %s or %s tag", sszMapKeyTagIdent, sszMapSortedTagIdent)
+	}
+	var encoding string
+	switch op := op.(type) {
+	case *opsetStatic:
+		if tags.overrides != nil {
+			return fmt.Errorf("static type cannot have %s tag", sszMaxForkTagIdent)
+		}
+		encoding = describeEncoding(op.define)
+	case *opsetDynamic:
+		encoding = describeEncoding(op.defineOffset)
+	}
+	if tags.bits && !strings.Contains(encoding, "Bits") {
+		return fmt.Errorf("ssz:\"bits\" tag not supported by %s encoding", encoding)
+	}
+	if tags.utf8 && !strings.Contains(encoding, "String") {
+		return fmt.Errorf("ssz:\"utf8\" tag not supported by %s encoding", encoding)
+	}
+	if tags.sorted && encoding != "SliceOfUint64s" {
+		return fmt.Errorf("%s tag not supported by %s encoding", sszSortedTagIdent, encoding)
+	}
+	return nil
+}
+
+// parseForkTypes parses the alternative encodings of a field, each in the form of
+// fork:Encoding(dims), separated by commas. The dimensions follow the syntax of
+// the ssz-size and ssz-max tags.
+func parseForkTypes(input string, scope *types.Scope) ([]forkType, error) {
+	var parsed []forkType
+	for input != "" {
+		fork, rest, ok := strings.Cut(input, ":")
+		if !ok {
+			return nil, fmt.Errorf("missing fork in alternative encoding %s", input)
+		}
+		enum, ok := forkMapping[fork]
+		if !ok {
+			return nil, fmt.Errorf("invalid fork %s", fork)
+		}
+		encoding, rest, ok := strings.Cut(rest, "(")
+		if !ok || !token.IsIdentifier(encoding) {
+			return nil, fmt.Errorf("invalid alternative encoding %s", rest)
+		}
+		args, rest, ok := strings.Cut(rest, ")")
+		if !ok {
+			return nil, fmt.Errorf("unterminated alternative encoding %s(%s", encoding, args)
+		}
+		typ := forkType{fork: enum, encoding: encoding}
+		for _, arg := range strings.Split(args, ",") {
+			switch {
+			case arg == "?":
+				typ.dims, typ.names = append(typ.dims, 0), append(typ.names, "")
+			case token.IsIdentifier(arg):
+				num, err := resolveConstant(scope, arg, false)
+				if err != nil {
+					return nil, fmt.Errorf("invalid dimension of %s: %v", encoding, err)
+				}
+				typ.dims, typ.names = append(typ.dims, num), append(typ.names, arg)
+			default:
+				num, err := strconv.ParseInt(arg, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid dimension of %s: %v", encoding, err)
+				}
+				typ.dims, typ.names = append(typ.dims, int(num)), append(typ.names, "")
+			}
+		}
+		if len(parsed) > 0 && parsed[len(parsed)-1].fork == enum {
+			return nil, fmt.Errorf("duplicate alternative encoding for fork %s", fork)
+		}
+		parsed = append(parsed, typ)
+
+		if input = strings.TrimPrefix(rest, ","); len(input) == len(rest) && rest != "" {
+			return nil, fmt.Errorf("invalid alternative encoding separator %s", rest)
+		}
+	}
+	return parsed, nil
+}
+
+// resolveConstant looks up a named integer constant in the package scope to use
+// as a size or limit, returning its value.
+//
+// The constant is emitted by name into the generated code, where sizes are used
+// both as uint32 (static sizes) and uint64 (codec arguments) and limits only as
+// uint64. Constants that would not compile in all their uses are rejected.
+func resolveConstant(scope *types.Scope, name string, limit bool) (int, error) {
+	if scope == nil {
+		return 0, fmt.Errorf("constant %s not resolvable", name)
+	}
+	obj, ok := scope.Lookup(name).(*types.Const)
+	if !ok {
+		return 0, fmt.Errorf("%s is not a constant in package scope", name)
+	}
+	if basic, ok := obj.Type().(*types.Basic); !ok || basic.Info()&types.IsUntyped == 0 {
+		if !limit {
+			return 0, fmt.Errorf("constant %s has type %s, sizes must be untyped constants", name, obj.Type())
+		}
+		if !ok || basic.Kind() != types.Uint64 {
+			return 0, fmt.Errorf("constant %s has type %s, limits must be untyped or uint64 constants", name, obj.Type())
+		}
+	}
+	if obj.Val().Kind() != constant.Int {
+		return 0, fmt.Errorf("constant %s is not an integer: %v", name, obj.Val())
+	}
+	num, exact := constant.Int64Val(obj.Val())
+	if !exact || num <= 0 {
+		return 0, fmt.Errorf("constant %s is not a positive dimension: %v", name, obj.Val())
+	}
+	return int(num), nil
+}
+
+// parseForkConstraint converts the textual fork constraint of a field or type into
+// enum names: a single fork the field was added in ("x"), a single fork it was
+// removed in ("!x"), or a list of ranges ("a-b,c").
+func parseForkConstraint(constraint string) (string, error) {
+	// If the constraint has multiple (or bounded) fork ranges, parse them all
+	// into their enum names
+	if strings.ContainsAny(constraint, ",-") {
+		var ranges []string
+		for _, part := range strings.Split(constraint, ",") {
+			bounds := strings.Split(part, "-")
+			if len(bounds) > 2 {
+				return "", fmt.Errorf("invalid fork range %s", part)
+			}
+			for i, bound := range bounds {
+				enum, ok := forkMapping[bound]
+				if !ok {
+					return "", fmt.Errorf("invalid fork %s", bound)
+				}
+				bounds[i] = enum
+			}
+			ranges = append(ranges, strings.Join(bounds, "-"))
+		}
+		return strings.Join(ranges, ","), nil
+	}
+	var negate bool
+	if strings.HasPrefix(constraint, "!") {
+		negate = true
+		constraint = constraint[1:]
+	}
+	enum, ok := forkMapping[constraint]
+	if !ok {
+		return "", fmt.Errorf("invalid fork %s", constraint)
+	}
+	if negate {
+		return "!" + enum, nil
+	}
+	return enum, nil
+}
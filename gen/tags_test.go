@@ -0,0 +1,115 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package gen
+
+import (
+	"fmt"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+)
+
+// Tests that only constants which compile in all places they are emitted into
+// can be referenced by the size and limit tags.
+func TestResolveConstant(t *testing.T) {
+	pkg := types.NewPackage("example.com/consts", "consts")
+	for name, typ := range map[string]types.Type{
+		"Untyped": types.Typ[types.UntypedInt],
+		"Uint64":  types.Typ[types.Uint64],
+		"Int":     types.Typ[types.Int],
+		"Named":   types.NewNamed(types.NewTypeName(token.NoPos, pkg, "Slot", nil), types.Typ[types.Uint64], nil),
+	} {
+		pkg.Scope().Insert(types.NewConst(token.NoPos, pkg, name, typ, constant.MakeInt64(16)))
+	}
+	for _, tt := range []struct {
+		name  string
+		limit bool
+		fail  bool
+	}{
+		{"Untyped", false, false},
+		{"Untyped", true, false},
+		{"Uint64", false, true},
+		{"Uint64", true, false},
+		{"Int", false, true},
+		{"Int", true, true},
+		{"Named", true, true},
+		{"Missing", true, true},
+	} {
+		num, err := resolveConstant(pkg.Scope(), tt.name, tt.limit)
+		if tt.fail {
+			if err == nil {
+				t.Errorf("%s (limit %v): constant accepted", tt.name, tt.limit)
+			}
+			continue
+		}
+		if err != nil || num != 16 {
+			t.Errorf("%s (limit %v): resolution mismatch: have %d/%v, want 16", tt.name, tt.limit, num, err)
+		}
+	}
+	// Ensure the restrictions apply to the parsed tags too
+	if _, _, _, err := parseTags(`ssz-max:"Int"`, pkg.Scope(), false); err == nil {
+		t.Errorf("typed int limit accepted")
+	}
+	if _, _, _, err := parseTags(`ssz-size:"Uint64"`, pkg.Scope(), false); err == nil {
+		t.Errorf("typed uint64 size accepted")
+	}
+	if _, tags, _, err := parseTags(`ssz-max:"Uint64"`, pkg.Scope(), false); err != nil || tags.limit[0] != 16 || tags.limitNames[0] != "Uint64" {
+		t.Errorf("typed uint64 limit rejected: %v", err)
+	}
+}
+
+// Tests that unknown struct tags are rejected, unless lenient parsing is enabled,
+// in which case they are ignored.
+func TestParseUnknownTags(t *testing.T) {
+	scope := types.NewPackage("example.com/tags", "tags").Scope()
+	for _, tag := range []string{
+		`ssz-limit:"8"`,
+		`ssz:"bytes"`,
+		`ssz-size:"8" ssz-foo:"bar"`,
+	} {
+		if _, _, _, err := parseTags(tag, scope, false); err == nil {
+			t.Errorf("%s: unknown tag accepted", tag)
+		}
+		if _, _, _, err := parseTags(tag, scope, true); err != nil {
+			t.Errorf("%s: unknown tag rejected in lenient mode: %v", tag, err)
+		}
+	}
+	// Non-ssz tags are never of concern
+	if _, _, _, err := parseTags(`json:"a" ssz-size:"8"`, scope, false); err != nil {
+		t.Errorf("foreign tag rejected: %v", err)
+	}
+}
+
+// Tests that tags conflicting with each other or with the type of the field they
+// are attached to are rejected when generating code, irrespective of leniency.
+func TestValidateTags(t *testing.T) {
+	cases := []struct {
+		field string
+		err   string // expected error fragment
+	}{
+		{field: "A []byte `ssz-max:\"8\" ssz:\"bits\"`", err: "tag not supported by DynamicBytes"},
+		{field: "A []byte `ssz-max:\"8\" ssz:\"utf8\"`", err: "tag not supported by DynamicBytes"},
+		{field: "A string `ssz-max:\"8\" ssz-sorted:\"asc\"`", err: "ssz-sorted tag not supported"},
+		{field: "A []byte `ssz-max:\"8\" ssz-maxvalue:\"8\"`", err: "requires a uint256 type"},
+		{field: "A []uint64 `ssz-max:\"8\" ssz-map-sorted:\"true\"`", err: "non-map type cannot have"},
+		{field: "A [8]byte `ssz-max-fork:\"deneb=4\"`", err: "requires a 1D ssz-max tag"},
+		{field: "A *[]byte `ssz-max:\"8\" ssz-fork:\"deneb\" ssz-fork-type:\"electra:DynamicBytes(16)\"`", err: "cannot be combined"},
+	}
+	src := "package tagtest\n\n"
+	for i, tt := range cases {
+		src += fmt.Sprintf("type T%d struct {\n\t%s\n}\n\n", i, tt.field)
+	}
+	dir := writeTestPackage(t, "tagtest", src)
+
+	for i, tt := range cases {
+		// Leniency only concerns unknown tags, toggle it to ensure it's ignored
+		opts := &Options{Dir: dir, Lenient: i%2 == 1}
+		if _, _, err := Generate(".", []string{fmt.Sprintf("T%d", i)}, opts); err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s (lenient %v): error mismatch: have %v, want %q", tt.field, opts.Lenient, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/types"
+	"text/template"
+)
+
+// testsTemplate is the round-trip test and fuzz target generated for each type,
+// mirroring the checks done by the library's own consensus test suite.
+//
+// Everything is inlined per type, so that multiple generated test files can live
+// in the same package without clashing on shared helpers.
+var testsTemplate = template.Must(template.New("tests").Parse(`
+// TestSSZRoundTrip{{.}} checks that the zero value of {{.}} survives an
+// encode/decode round-trip, with consistent sizes and hashes.
+func TestSSZRoundTrip{{.}}(t *testing.T) {
+	obj := new({{.}})
+
+	blob := make([]byte, ssz.SizeOnFork(obj, ssz.ForkFuture))
+	if err := ssz.EncodeToBytesOnFork(blob, obj, ssz.ForkFuture); err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+	dec := new({{.}})
+	if err := ssz.ValidateOnFork(blob, dec, ssz.ForkFuture); err != nil {
+		t.Fatalf("failed to round-trip: %v", err)
+	}
+	if have, want := ssz.HashSequentialOnFork(dec, ssz.ForkFuture), ssz.HashSequentialOnFork(obj, ssz.ForkFuture); have != want {
+		t.Fatalf("decoded hash mismatch: have %x, want %x", have, want)
+	}
+	if have, want := ssz.HashConcurrentOnFork(dec, ssz.ForkFuture), ssz.HashSequentialOnFork(dec, ssz.ForkFuture); have != want {
+		t.Fatalf("sequential/concurrent hash mismatch: concurrent %x, sequential %x", have, want)
+	}
+}
+
+// FuzzSSZ{{.}} checks that any input decoding successfully into a {{.}}
+// re-encodes into the exact same bytes, with consistent sizes and hashes.
+func FuzzSSZ{{.}}(f *testing.F) {
+	seed := new({{.}})
+
+	blob := make([]byte, ssz.SizeOnFork(seed, ssz.ForkFuture))
+	if err := ssz.EncodeToBytesOnFork(blob, seed, ssz.ForkFuture); err != nil {
+		f.Fatalf("failed to encode seed: %v", err)
+	}
+	f.Add(blob)
+
+	f.Fuzz(func(t *testing.T, inSSZ []byte) {
+		// Decode from a buffer, if invalid, the stream decoder must fail too
+		obj := new({{.}})
+		if err := ssz.DecodeFromBytesOnFork(inSSZ, obj, ssz.ForkFuture); err != nil {
+			if err := ssz.DecodeFromStreamOnFork(bytes.NewReader(inSSZ), new({{.}}), uint32(len(inSSZ)), ssz.ForkFuture); err == nil {
+				t.Fatalf("stream decoder accepted invalid input")
+			}
+			return
+		}
+		// Valid input, make sure it round-trips through both encoders
+		if size := ssz.SizeOnFork(obj, ssz.ForkFuture); size != uint32(len(inSSZ)) {
+			t.Fatalf("reported/decoded size mismatch: reported %v, decoded %v", size, len(inSSZ))
+		}
+		bin := make([]byte, len(inSSZ))
+		if err := ssz.EncodeToBytesOnFork(bin, obj, ssz.ForkFuture); err != nil {
+			t.Fatalf("failed to re-encode buffer: %v", err)
+		}
+		if !bytes.Equal(bin, inSSZ) {
+			t.Fatalf("re-encoded buffer mismatch: have %x, want %x", bin, inSSZ)
+		}
+		stream := new(bytes.Buffer)
+		if err := ssz.EncodeToStreamOnFork(stream, obj, ssz.ForkFuture); err != nil {
+			t.Fatalf("failed to re-encode stream: %v", err)
+		}
+		if !bytes.Equal(stream.Bytes(), inSSZ) {
+			t.Fatalf("re-encoded stream mismatch: have %x, want %x", stream.Bytes(), inSSZ)
+		}
+		// Decoding from a stream must result in the same object
+		dec := new({{.}})
+		if err := ssz.DecodeFromStreamOnFork(bytes.NewReader(inSSZ), dec, uint32(len(inSSZ)), ssz.ForkFuture); err != nil {
+			t.Fatalf("failed to decode stream: %v", err)
+		}
+		hash1 := ssz.HashSequentialOnFork(obj, ssz.ForkFuture)
+		hash2 := ssz.HashConcurrentOnFork(obj, ssz.ForkFuture)
+		hash3 := ssz.HashSequentialOnFork(dec, ssz.ForkFuture)
+		if hash1 != hash2 || hash1 != hash3 {
+			t.Fatalf("hash mismatch: sequential %x, concurrent %x, stream %x", hash1, hash2, hash3)
+		}
+	})
+}
+`))
+
+// generateTests creates a round-trip property test and a fuzz target for each of
+// the types, so that downstream packages get coverage without copying harnesses.
+func generateTests(pkg *types.Package, typs []*sszContainer) ([]byte, error) {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "package %s\n\n", pkg.Name())
+	fmt.Fprintf(&b, "import (\n\t\"bytes\"\n\t\"testing\"\n\n\t%q\n)\n", sszPkgPath)
+
+	for _, typ := range typs {
+		if typ.named.TypeParams().Len() > 0 {
+			return nil, fmt.Errorf("cannot generate tests for generic type %s", typ.typeName())
+		}
+		if err := testsTemplate.Execute(&b, typ.named.Obj().Name()); err != nil {
+			return nil, err
+		}
+	}
+	return b.Bytes(), nil
+}
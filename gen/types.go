@@ -0,0 +1,305 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package gen
+
+import (
+	"fmt"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+type sszContainer struct {
+	*types.Struct
+	named  *types.Named
+	static bool
+	fields []string     // Name of the struct field
+	types  []types.Type // Type of the struct field
+	opsets []opset      // Opset for the struct field
+	forks  []string     // Fork constraint for the struct field
+	fork   string       // Fork constraint for the entire type (//ssz:fork directive)
+	source []byte       // Formatted declaration of the type, for staleness stamping
+	refs   [][]byte     // Declarations of the types referenced by the fields, for staleness stamping
+
+	forkOpsets [][]forkOpset // Alternative opsets of the struct field in later forks
+
+	entries []*sszContainer // Synthesized key/value containers for map fields
+	cache   string          // Name of the embedded ssz.RootCache field, if any
+}
+
+// typeName returns the name of the container type as usable in method receivers,
+// including the type parameters of generic containers (e.g. Signed[T]).
+func (typ *sszContainer) typeName() string {
+	params := typeParamNames(typ.named)
+	if len(params) == 0 {
+		return typ.named.Obj().Name()
+	}
+	return typ.named.Obj().Name() + "[" + strings.Join(params, ", ") + "]"
+}
+
+// typeParamNames returns the names of the type parameters of a generic type, or
+// nil if the type is not generic.
+func typeParamNames(named *types.Named) []string {
+	var names []string
+	for i := 0; i < named.TypeParams().Len(); i++ {
+		names = append(names, named.TypeParams().At(i).Obj().Name())
+	}
+	return names
+}
+
+// makeContainer iterates over the fields of the struct and attempt to match each
+// field with an opset for encoding/decoding ssz.
+func (p *parseContext) makeContainer(named *types.Named, typ *types.Struct) (*sszContainer, error) {
+	var (
+		static = true
+		fields []string
+		types  []types.Type
+		opsets []opset
+		forks  []string
+
+		forkOpsets [][]forkOpset
+
+		entries []*sszContainer
+		cache   string
+	)
+	// Iterate over all the fields of the struct
+	for i := 0; i < typ.NumFields(); i++ {
+		// Skip root caches, private fields, and skip ignored ssz fields
+		f := typ.Field(i)
+		if isRootCache(f.Type()) {
+			if cache != "" {
+				return nil, fmt.Errorf("failed to validate field %s.%s: duplicate root cache, previous %s", named.Obj().Name(), f.Name(), cache)
+			}
+			cache = f.Name()
+			continue
+		}
+		if !f.Exported() {
+			continue
+		}
+		ignore, tags, fork, err := parseTags(typ.Tag(i), named.Obj().Pkg().Scope(), p.lenient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse field %s.%s tags: %v", named.Obj().Name(), f.Name(), err)
+		}
+		if ignore {
+			continue
+		}
+		if isSlicePointer(f.Type()) && fork == "" {
+			return nil, fmt.Errorf("failed to validate field %s.%s: pointer to slice requires %s tag", named.Obj().Name(), f.Name(), sszForkTagIdent)
+		}
+		// Required field found, validate type with tag content
+		var opset opset
+		if m := underlyingMap(f.Type()); m != nil {
+			var entry *sszContainer
+			if opset, entry, err = p.resolveMapOpset(named, f.Name(), m, tags); err != nil {
+				return nil, fmt.Errorf("failed to validate field %s.%s: %v", named.Obj().Name(), f.Name(), err)
+			}
+			entries = append(entries, entry)
+		} else {
+			if opset, err = p.resolveOpset(f.Type(), tags, false); err != nil {
+				return nil, fmt.Errorf("failed to validate field %s.%s: %v", named.Obj().Name(), f.Name(), err)
+			}
+		}
+		if err := validateTags(f.Type(), tags, opset); err != nil {
+			return nil, fmt.Errorf("failed to validate field %s.%s: %v", named.Obj().Name(), f.Name(), err)
+		}
+		if dyn, ok := (opset).(*opsetDynamic); ok {
+			static = false
+			if tags != nil {
+				dyn.overrides = tags.overrides
+				dyn.names = constNames(dyn.limits, tags.limit, tags.limitNames)
+			}
+		} else if st, ok := (opset).(*opsetStatic); ok && tags != nil && !tags.bits {
+			st.names = constNames(st.bytes, tags.size, tags.sizeNames)
+		}
+		// If the field switches encodings in later forks, resolve the alternatives
+		var alts []forkOpset
+		if tags != nil {
+			var prev int64
+			for _, alt := range tags.forkTypes {
+				value, err := forkValue(p.library, named.Obj().Pkg(), alt.fork)
+				if err != nil {
+					return nil, fmt.Errorf("failed to validate field %s.%s: %v", named.Obj().Name(), f.Name(), err)
+				}
+				if len(alts) > 0 && value <= prev {
+					return nil, fmt.Errorf("failed to validate field %s.%s: %s forks not in increasing order", named.Obj().Name(), f.Name(), sszForkTypeTagIdent)
+				}
+				prev = value
+
+				op, err := p.resolveForkTypeOpset(f.Type(), alt)
+				if err != nil {
+					return nil, fmt.Errorf("failed to validate field %s.%s: %v", named.Obj().Name(), f.Name(), err)
+				}
+				if _, dynamic := op.(*opsetDynamic); dynamic != !isStaticOpset(opset) {
+					return nil, fmt.Errorf("failed to validate field %s.%s: %s cannot switch between static and dynamic encodings", named.Obj().Name(), f.Name(), sszForkTypeTagIdent)
+				}
+				alts = append(alts, forkOpset{fork: alt.fork, opset: op})
+			}
+		}
+		fields = append(fields, f.Name())
+		types = append(types, f.Type())
+		opsets = append(opsets, opset)
+		forks = append(forks, fork)
+		forkOpsets = append(forkOpsets, alts)
+	}
+	return &sszContainer{
+		Struct: typ,
+		named:  named,
+		static: static,
+		fields: fields,
+		types:  types,
+		opsets: opsets,
+		forks:  forks,
+
+		forkOpsets: forkOpsets,
+
+		entries: entries,
+		cache:   cache,
+	}, nil
+}
+
+// constNames maps the constant names the dimensions were tagged with onto the
+// dimensions of a resolved opset. Opsets might only use some of the tagged ones,
+// so a name is only kept if the dimension at its position was left untouched.
+func constNames(dims []int, tagged []int, tagNames []string) []string {
+	var names []string
+	for i, dim := range dims {
+		if i < len(tagged) && tagNames[i] != "" && tagged[i] == dim {
+			if names == nil {
+				names = make([]string, len(dims))
+			}
+			names[i] = tagNames[i]
+		}
+	}
+	return names
+}
+
+// underlyingMap returns the map type underlying a field type, or nil if it is
+// not a map.
+func underlyingMap(typ types.Type) *types.Map {
+	m, _ := typ.Underlying().(*types.Map)
+	return m
+}
+
+// resolveOpset compares the type of the field to the provided tags and returns
+// whether there's a collision between them, or if more tags are needed to fully
+// derive the size. If the type/tags are in sync and well-defined, an opset will
+// be returned that the generator can use to create the code.
+func (p *parseContext) resolveOpset(typ types.Type, tags *sizeTag, pointer bool) (opset, error) {
+	switch t := types.Unalias(typ).(type) {
+	case *types.Named:
+		if isBitlist(typ) {
+			return p.resolveBitlistOpset(tags)
+		}
+		if bits := bitvectorBits(typ); bits > 0 && (tags == nil || tags.bits) {
+			return p.resolveBitvectorOpset(bits, tags, pointer)
+		}
+		return p.resolveOpset(t.Underlying(), tags, pointer)
+
+	case *types.Basic:
+		return p.resolveBasicOpset(t, tags, pointer)
+
+	case *types.TypeParam:
+		return p.resolveTypeParamOpset(t, tags)
+
+	case *types.Array:
+		return p.resolveArrayOpset(t.Elem(), int(t.Len()), tags, pointer)
+
+	case *types.Slice:
+		return p.resolveSliceOpset(t.Elem(), tags)
+
+	case *types.Pointer:
+		switch tt := types.Unalias(t.Elem()).(type) {
+		case *types.Basic:
+			return p.resolveBasicOpset(tt, tags, true)
+
+		case *types.Array:
+			return p.resolveArrayOpset(tt.Elem(), int(tt.Len()), tags, true)
+
+		case *types.Slice:
+			return p.resolveSlicePointerOpset(tt.Elem(), tags)
+		}
+		return p.resolvePointerOpset(t, tags)
+	}
+	return nil, fmt.Errorf("unsupported type %s", typ.String())
+}
+
+// isSlicePointer checks whether 'typ' is a pointer to a slice, used by monolith
+// types to differentiate lists missing from a fork from empty ones.
+func isSlicePointer(typ types.Type) bool {
+	ptr, ok := types.Unalias(typ).(*types.Pointer)
+	if !ok {
+		return false
+	}
+	_, ok = types.Unalias(ptr.Elem()).(*types.Slice)
+	return ok
+}
+
+// isUint256Pointer checks whether 'typ' is a pointer to a uint256, either as a
+// "github.com/holiman/uint256".Int or as a "math/big".Int.
+func isUint256Pointer(typ types.Type) bool {
+	ptr, ok := types.Unalias(typ).(*types.Pointer)
+	if !ok {
+		return false
+	}
+	return isUint256(ptr.Elem()) || isBigInt(ptr.Elem())
+}
+
+// isBigInt checks whether 'typ' is "math/big".Int.
+func isBigInt(typ types.Type) bool {
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok {
+		return false
+	}
+	name := named.Obj()
+	return name.Pkg().Path() == "math/big" && name.Name() == "Int"
+}
+
+// isUint256 checks whether 'typ' is "github.com/holiman/uint256".Int.
+func isUint256(typ types.Type) bool {
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok {
+		return false
+	}
+	name := named.Obj()
+	return name.Pkg().Path() == "github.com/holiman/uint256" && name.Name() == "Int"
+}
+
+// isRootCache checks whether 'typ' is "github.com/karalabe/ssz".RootCache.
+func isRootCache(typ types.Type) bool {
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok {
+		return false
+	}
+	name := named.Obj()
+	return name.Pkg().Path() == sszPkgPath && name.Name() == "RootCache"
+}
+
+// isBitlist checks whether 'typ' is "github.com/prysmaticlabs/go-bitfield".Bitlist.
+func isBitlist(typ types.Type) bool {
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok {
+		return false
+	}
+	name := named.Obj()
+	return name.Pkg().Path() == "github.com/prysmaticlabs/go-bitfield" && name.Name() == "Bitlist"
+}
+
+// bitvectorBits returns the number of bits in 'typ' if it is one of the fixed
+// size "github.com/prysmaticlabs/go-bitfield".BitvectorN types, or 0 otherwise.
+func bitvectorBits(typ types.Type) int {
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok {
+		return 0
+	}
+	name := named.Obj()
+	if name.Pkg().Path() != "github.com/prysmaticlabs/go-bitfield" || !strings.HasPrefix(name.Name(), "Bitvector") {
+		return 0
+	}
+	bits, err := strconv.Atoi(strings.TrimPrefix(name.Name(), "Bitvector"))
+	if err != nil {
+		return 0
+	}
+	return bits
+}
@@ -0,0 +1,53 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package gen
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+func pkgName(pkgPath string) string {
+	index := strings.LastIndex(pkgPath, "/")
+	if index == -1 {
+		return pkgPath // universal package
+	}
+	return pkgPath[index+1:]
+}
+
+// lowerFirst converts the first character of a name to lowercase, making it an
+// unexported identifier.
+func lowerFirst(name string) string {
+	r, n := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(r)) + name[n:]
+}
+
+// joinDims formats a list of size dimensions back into ssz tag content, using
+// '?' for the undefined ones.
+func joinDims(dims []int) string {
+	parts := make([]string, len(dims))
+	for i, dim := range dims {
+		if dim == 0 {
+			parts[i] = "?"
+		} else {
+			parts[i] = strconv.Itoa(dim)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// joinNamedDims is the same as joinDims, but retains the constant names any of
+// the dimensions were declared with.
+func joinNamedDims(dims []int, names []string) string {
+	parts := strings.Split(joinDims(dims), ",")
+	for i, name := range names {
+		if name != "" {
+			parts[i] = name
+		}
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,435 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package gen
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/types"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/snappy"
+	"github.com/karalabe/ssz"
+)
+
+// vectorsMaxItems caps the number of items (or bytes) generated for the dynamic
+// fields, keeping the vectors small even if the limits are huge.
+const vectorsMaxItems = 16
+
+// vectorsCase is the JSON metadata stored alongside each generated encoding.
+type vectorsCase struct {
+	Type string `json:"type"`
+	Fork string `json:"fork,omitempty"`
+	Size int    `json:"size"`
+	Root string `json:"root"`
+}
+
+// VectorsOptions are the knobs of the test vector generator.
+type VectorsOptions struct {
+	Runs int    // Number of test vectors to generate per type
+	Fork string // Fork to generate the test vectors in (monolithic types)
+	Seed int64  // Seed of the random generator, for reproducibility
+}
+
+// Vectors generates randomized valid encodings of the requested types of a package
+// along with their expected merkle roots into an output directory, for differential
+// testing against other ssz implementations. Only the directory and leniency of
+// the generator options are used.
+//
+// The encodings are generated from the resolved ssz schema of the types, so any
+// value constraint not part of the schema (e.g. ssz-maxvalue) is not honored.
+func Vectors(pkgPath string, typeNames []string, outdir string, opts *Options, vopts *VectorsOptions) error {
+	if opts == nil {
+		opts = new(Options)
+	}
+	if vopts == nil {
+		vopts = &VectorsOptions{Runs: 16, Seed: 1}
+	}
+	if len(typeNames) == 0 {
+		return errors.New("test vector generation requires the types to be specified")
+	}
+	fork := ssz.ForkUnknown
+	if vopts.Fork != "" {
+		var ok bool
+		if fork, ok = ssz.ForkMapping[strings.ToLower(vopts.Fork)]; !ok {
+			return fmt.Errorf("unknown fork: %s", vopts.Fork)
+		}
+	}
+	genLock.Lock()
+	defer genLock.Unlock()
+
+	library, target, containers, err := load(pkgPath, typeNames, opts)
+	if err != nil {
+		return err
+	}
+	rng := rand.New(rand.NewSource(vopts.Seed))
+	for _, typ := range containers {
+		if typ.named.TypeParams().Len() > 0 {
+			return fmt.Errorf("cannot generate test vectors for generic type %s", typ.typeName())
+		}
+		name := typ.named.Obj().Name()
+
+		desc, err := describe(library, target, typ)
+		if err != nil {
+			return fmt.Errorf("failed to describe %s: %v", name, err)
+		}
+		schema, err := vectorsSchema(library, target, desc, fork)
+		if err != nil {
+			return fmt.Errorf("failed to convert schema of %s: %v", name, err)
+		}
+		dir := filepath.Join(outdir, name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		for i := 0; i < vopts.Runs; i++ {
+			blob, err := vectorsObject(rng, schema, fork)
+			if err != nil {
+				return fmt.Errorf("failed to generate %s vector: %v", name, err)
+			}
+			// Hashing validates the encoding against the schema too, so an error
+			// would mean a generator bug, not a bad input
+			root, err := ssz.HashRootOfSchemaOnFork(blob, schema, fork)
+			if err != nil {
+				return fmt.Errorf("generated invalid %s vector: %v", name, err)
+			}
+			meta, err := json.MarshalIndent(&vectorsCase{Type: name, Fork: vopts.Fork, Size: len(blob), Root: fmt.Sprintf("%#x", root)}, "", "  ")
+			if err != nil {
+				return err
+			}
+			base := filepath.Join(dir, fmt.Sprintf("case_%d", i))
+			if err := os.WriteFile(base+".ssz_snappy", snappy.Encode(nil, blob), 0644); err != nil {
+				return err
+			}
+			if err := os.WriteFile(base+".json", append(meta, '\n'), 0644); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// vectorsSchema converts the description of a type into the library's dynamic
+// schema, resolving any fork specific limits in the requested fork.
+func vectorsSchema(library *types.Package, target *types.Package, desc *schemaType, fork ssz.Fork) (*ssz.Schema, error) {
+	if err := vectorsResolveLimits(library, target, desc, fork); err != nil {
+		return nil, err
+	}
+	blob, err := json.Marshal(desc)
+	if err != nil {
+		return nil, err
+	}
+	schema := new(ssz.Schema)
+	if err := json.Unmarshal(blob, schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// vectorsResolveLimits replaces the limits (and encodings) of the fields having
+// fork specific overrides with the ones active in the requested fork. Same as
+// with ssz.LimitOnFork, the latest fork already activated wins, irrespective of
+// the order the overrides were listed in.
+func vectorsResolveLimits(library *types.Package, target *types.Package, desc *schemaType, fork ssz.Fork) error {
+	for _, field := range desc.Fields {
+		active := int64(-1)
+		for _, override := range field.Forks {
+			value, err := forkValue(library, target, override.Fork)
+			if err != nil {
+				return fmt.Errorf("field %s: %v", field.Name, err)
+			}
+			if value <= int64(fork) && value > active {
+				field.Limits[len(field.Limits)-1], active = override.Limit, value
+			}
+		}
+		active = -1
+		for _, sw := range field.Switches {
+			value, err := forkValue(library, target, sw.Fork)
+			if err != nil {
+				return fmt.Errorf("field %s: %v", field.Name, err)
+			}
+			if value <= int64(fork) && value > active {
+				field.Encoding, field.Size, field.Sizes, field.Limits = sw.Encoding, sw.Size, sw.Sizes, sw.Limits
+				active = value
+			}
+		}
+		if field.Schema != nil {
+			if err := vectorsResolveLimits(library, target, field.Schema, fork); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// vectorsActive reports whether a field of a schema is present in a fork.
+func vectorsActive(field *ssz.SchemaField, fork ssz.Fork) (bool, error) {
+	lookup := func(name string) (ssz.Fork, error) {
+		if name == "" {
+			return ssz.ForkUnknown, nil
+		}
+		fork, ok := ssz.ForkMapping[strings.ToLower(name)]
+		if !ok {
+			return ssz.ForkUnknown, fmt.Errorf("field %s: unknown fork %s", field.Name, name)
+		}
+		return fork, nil
+	}
+	ranges := field.Ranges
+	if len(ranges) == 0 {
+		ranges = []ssz.SchemaRange{{Added: field.Added, Removed: field.Removed}}
+	}
+	for _, r := range ranges {
+		added, err := lookup(r.Added)
+		if err != nil {
+			return false, err
+		}
+		removed, err := lookup(r.Removed)
+		if err != nil {
+			return false, err
+		}
+		if (ssz.ForkFilter{Added: added, Removed: removed}).Active(fork) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// vectorsDynamic reports whether a field is encoded via an offset.
+func vectorsDynamic(field *ssz.SchemaField) bool {
+	switch field.Encoding {
+	case "DynamicObject", "DynamicBytes", "String", "UTF8String", "SliceOfBits", "SliceOfUint16s", "SliceOfUint32s",
+		"SliceOfUint64s", "SliceOfStaticBytes", "SliceOfDynamicBytes", "CheckedArrayOfDynamicBytes",
+		"SliceOfStaticObjects", "SliceOfDynamicObjects", "MapOfStaticEntries", "MapOfDynamicEntries":
+		return true
+	}
+	return false
+}
+
+// vectorsObject generates a random valid encoding of a container.
+func vectorsObject(rng *rand.Rand, schema *ssz.Schema, fork ssz.Fork) ([]byte, error) {
+	var (
+		fixed   [][]byte // Static fields, or placeholders for the offsets
+		dynamic [][]byte // Contents of the dynamic fields
+		offsets []int    // Indices of the offsets within the static fields
+		size    int      // Total size of the static part
+	)
+	for _, field := range schema.Fields {
+		active, err := vectorsActive(field, fork)
+		if err != nil {
+			return nil, err
+		}
+		if !active {
+			continue
+		}
+		blob, err := vectorsField(rng, field, fork)
+		if err != nil {
+			return nil, err
+		}
+		if vectorsDynamic(field) {
+			offsets = append(offsets, len(fixed))
+			fixed = append(fixed, make([]byte, 4))
+			dynamic = append(dynamic, blob)
+			size += 4
+		} else {
+			fixed = append(fixed, blob)
+			size += len(blob)
+		}
+	}
+	for i, idx := range offsets {
+		binary.LittleEndian.PutUint32(fixed[idx], uint32(size))
+		size += len(dynamic[i])
+	}
+	blob := make([]byte, 0, size)
+	for _, part := range fixed {
+		blob = append(blob, part...)
+	}
+	for _, part := range dynamic {
+		blob = append(blob, part...)
+	}
+	return blob, nil
+}
+
+// vectorsField generates a random valid encoding of a single field.
+func vectorsField(rng *rand.Rand, field *ssz.SchemaField, fork ssz.Fork) ([]byte, error) {
+	// Cap the dynamic dimensions, with the items counts drawn randomly
+	limit := func(dim int) (int, error) {
+		if len(field.Limits) <= dim || field.Limits[dim] <= 0 {
+			return 0, fmt.Errorf("field %s: missing limit for dimension %d", field.Name, dim)
+		}
+		return min(field.Limits[dim], vectorsMaxItems), nil
+	}
+	items := func() (int, error) {
+		n, err := limit(0)
+		if err != nil {
+			return 0, err
+		}
+		return rng.Intn(n + 1), nil
+	}
+	switch field.Encoding {
+	case "Bool":
+		return []byte{byte(rng.Intn(2))}, nil
+
+	case "Uint8", "Uint16", "Uint32", "Uint64", "Uint256", "Uint256BigInt", "StaticBytes", "CheckedStaticBytes",
+		"ArrayOfUint16s", "ArrayOfUint32s", "ArrayOfUint64s", "UnsafeArrayOfStaticBytes", "ArrayOfStaticBytes",
+		"CheckedArrayOfStaticBytes", "ArrayOfArrayOfUint16s", "ArrayOfArrayOfUint32s", "ArrayOfArrayOfUint64s",
+		"CheckedStaticUint64s":
+		return vectorsBytes(rng, field.Size), nil
+
+	case "ArrayOfBits", "CheckedBits", "UnsafeArrayOfBits", "CheckedArrayOfBits", "UnsafeArrayOfCheckedBits":
+		// The exact bit size is not part of the schema, but the first bit of the
+		// last byte is always within the bitvector, so clear everything above it
+		item := field.Size
+		if len(field.Sizes) == 2 {
+			item = field.Sizes[1]
+		}
+		if item <= 0 || field.Size%item != 0 {
+			return nil, fmt.Errorf("field %s: invalid bitvector size %d/%v", field.Name, field.Size, field.Sizes)
+		}
+		blob := vectorsBytes(rng, field.Size)
+		for i := item - 1; i < len(blob); i += item {
+			blob[i] &= 0x01
+		}
+		return blob, nil
+
+	case "StaticObject", "DynamicObject":
+		if field.Schema == nil {
+			return nil, fmt.Errorf("field %s: missing nested schema", field.Name)
+		}
+		return vectorsObject(rng, field.Schema, fork)
+
+	case "DynamicBytes":
+		n, err := items()
+		if err != nil {
+			return nil, err
+		}
+		return vectorsBytes(rng, n), nil
+
+	case "String", "UTF8String":
+		n, err := items()
+		if err != nil {
+			return nil, err
+		}
+		blob := make([]byte, n)
+		for i := range blob {
+			blob[i] = byte('a' + rng.Intn(26))
+		}
+		return blob, nil
+
+	case "SliceOfBits":
+		n, err := items()
+		if err != nil {
+			return nil, err
+		}
+		// Pick a bit count within the limit and terminate it with the length bit
+		bits := rng.Intn(min(field.Limits[0], n*8) + 1)
+		blob := vectorsBytes(rng, bits/8+1)
+		blob[len(blob)-1] &= byte(1<<(bits%8)) - 1
+		blob[len(blob)-1] |= byte(1 << (bits % 8))
+		return blob, nil
+
+	case "SliceOfUint16s", "SliceOfUint32s", "SliceOfUint64s":
+		n, err := items()
+		if err != nil {
+			return nil, err
+		}
+		size := map[string]int{"SliceOfUint16s": 2, "SliceOfUint32s": 4, "SliceOfUint64s": 8}[field.Encoding]
+		if !field.Sorted {
+			return vectorsBytes(rng, n*size), nil
+		}
+		// Sorted lists need strictly increasing items
+		blob := make([]byte, n*8)
+		for i, item := 0, uint64(0); i < n; i++ {
+			item += 1 + uint64(rng.Intn(1024))
+			binary.LittleEndian.PutUint64(blob[i*8:], item)
+		}
+		return blob, nil
+
+	case "SliceOfStaticBytes":
+		n, err := items()
+		if err != nil {
+			return nil, err
+		}
+		if len(field.Sizes) != 2 || field.Sizes[1] <= 0 {
+			return nil, fmt.Errorf("field %s: invalid list item size %v", field.Name, field.Sizes)
+		}
+		return vectorsBytes(rng, n*field.Sizes[1]), nil
+
+	case "SliceOfDynamicBytes", "CheckedArrayOfDynamicBytes":
+		n, err := items()
+		if err != nil {
+			return nil, err
+		}
+		if field.Encoding == "CheckedArrayOfDynamicBytes" {
+			n = field.Limits[0] // vector, all items are present
+		}
+		maxSize, err := limit(1)
+		if err != nil {
+			return nil, err
+		}
+		blobs := make([][]byte, n)
+		for i := range blobs {
+			blobs[i] = vectorsBytes(rng, rng.Intn(maxSize+1))
+		}
+		return vectorsList(blobs, true), nil
+
+	case "SliceOfStaticObjects", "SliceOfDynamicObjects", "MapOfStaticEntries", "MapOfDynamicEntries":
+		n, err := items()
+		if err != nil {
+			return nil, err
+		}
+		// Maps need unique (and possibly sorted) keys, which random entries can't
+		// guarantee, so only ever generate a single entry for them
+		if strings.HasPrefix(field.Encoding, "Map") {
+			n = min(n, 1)
+		}
+		if field.Schema == nil {
+			return nil, fmt.Errorf("field %s: missing nested schema", field.Name)
+		}
+		blobs := make([][]byte, n)
+		for i := range blobs {
+			if blobs[i], err = vectorsObject(rng, field.Schema, fork); err != nil {
+				return nil, err
+			}
+		}
+		dynamic := false
+		for _, item := range field.Schema.Fields {
+			if active, _ := vectorsActive(item, fork); active && vectorsDynamic(item) {
+				dynamic = true
+			}
+		}
+		return vectorsList(blobs, dynamic), nil
+
+	default:
+		return nil, fmt.Errorf("field %s: unsupported encoding %s", field.Name, field.Encoding)
+	}
+}
+
+// vectorsList concatenates the encodings of list items, prefixing them with the
+// offsets if the items are dynamic.
+func vectorsList(items [][]byte, dynamic bool) []byte {
+	var blob []byte
+	if dynamic {
+		offset := 4 * len(items)
+		for _, item := range items {
+			blob = binary.LittleEndian.AppendUint32(blob, uint32(offset))
+			offset += len(item)
+		}
+	}
+	for _, item := range items {
+		blob = append(blob, item...)
+	}
+	return blob
+}
+
+// vectorsBytes generates a random byte blob of the given size.
+func vectorsBytes(rng *rand.Rand, size int) []byte {
+	blob := make([]byte, size)
+	rng.Read(blob)
+	return blob
+}
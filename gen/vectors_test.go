@@ -0,0 +1,167 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package gen
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that the generated test vectors decode into the Go types they were made
+// from, re-encode into the same bytes and hash into the recorded roots; and that
+// they are reproducible from the seed.
+func TestVectorsRoundtrip(t *testing.T) {
+	opts := &Options{Dir: "../tests/testtypes/consensus-spec-tests"}
+
+	for _, tt := range []struct {
+		fork  string
+		types map[string]func() ssz.Object
+	}{
+		{
+			types: map[string]func() ssz.Object{
+				"BeaconBlockHeader":    func() ssz.Object { return new(types.BeaconBlockHeader) },
+				"ExecutionPayload":     func() ssz.Object { return new(types.ExecutionPayload) },
+				"PackedListsVariation": func() ssz.Object { return new(types.PackedListsVariation) },
+			},
+		},
+		{
+			fork: "deneb",
+			types: map[string]func() ssz.Object{
+				"ForkLimitsVariation": func() ssz.Object { return new(types.ForkLimitsVariation) },
+				"ForkTypesMonolith":   func() ssz.Object { return new(types.ForkTypesMonolith) },
+			},
+		},
+		{
+			fork: "electra",
+			types: map[string]func() ssz.Object{
+				"AttestationMonolith": func() ssz.Object { return new(types.AttestationMonolith) },
+				"ForkLimitsVariation": func() ssz.Object { return new(types.ForkLimitsVariation) },
+				"ForkTypesMonolith":   func() ssz.Object { return new(types.ForkTypesMonolith) },
+			},
+		},
+	} {
+		fork := ssz.ForkMapping[tt.fork]
+
+		var names []string
+		for name := range tt.types {
+			names = append(names, name)
+		}
+		vopts := &VectorsOptions{Runs: 8, Fork: tt.fork, Seed: 1}
+
+		dir := t.TempDir()
+		if err := Vectors(".", names, dir, opts, vopts); err != nil {
+			t.Fatalf("fork %q: failed to generate vectors: %v", tt.fork, err)
+		}
+		for name, alloc := range tt.types {
+			for i := 0; i < vopts.Runs; i++ {
+				base := filepath.Join(dir, name, fmt.Sprintf("case_%d", i))
+
+				meta, err := os.ReadFile(base + ".json")
+				if err != nil {
+					t.Fatalf("fork %q: %s #%d: failed to read metadata: %v", tt.fork, name, i, err)
+				}
+				var want vectorsCase
+				if err := json.Unmarshal(meta, &want); err != nil {
+					t.Fatalf("fork %q: %s #%d: failed to parse metadata: %v", tt.fork, name, i, err)
+				}
+				compressed, err := os.ReadFile(base + ".ssz_snappy")
+				if err != nil {
+					t.Fatalf("fork %q: %s #%d: failed to read encoding: %v", tt.fork, name, i, err)
+				}
+				blob, err := snappy.Decode(nil, compressed)
+				if err != nil {
+					t.Fatalf("fork %q: %s #%d: failed to decompress encoding: %v", tt.fork, name, i, err)
+				}
+				if len(blob) != want.Size {
+					t.Errorf("fork %q: %s #%d: size mismatch: have %d, want %d", tt.fork, name, i, len(blob), want.Size)
+				}
+				obj := alloc()
+				if err := ssz.DecodeFromBytesOnFork(blob, obj, fork); err != nil {
+					t.Errorf("fork %q: %s #%d: failed to decode vector: %v", tt.fork, name, i, err)
+					continue
+				}
+				enc := make([]byte, ssz.SizeOnFork(obj, fork))
+				if err := ssz.EncodeToBytesOnFork(enc, obj, fork); err != nil {
+					t.Errorf("fork %q: %s #%d: failed to re-encode vector: %v", tt.fork, name, i, err)
+					continue
+				}
+				if !bytes.Equal(enc, blob) {
+					t.Errorf("fork %q: %s #%d: re-encoding mismatch: have %x, want %x", tt.fork, name, i, enc, blob)
+				}
+				if root := fmt.Sprintf("%#x", ssz.HashSequentialOnFork(obj, fork)); root != want.Root {
+					t.Errorf("fork %q: %s #%d: root mismatch: have %s, want %s", tt.fork, name, i, root, want.Root)
+				}
+			}
+		}
+		// Regenerating from the same seed must yield the exact same vectors
+		redir := t.TempDir()
+		if err := Vectors(".", names, redir, opts, vopts); err != nil {
+			t.Fatalf("fork %q: failed to regenerate vectors: %v", tt.fork, err)
+		}
+		for _, name := range names {
+			for i := 0; i < vopts.Runs; i++ {
+				for _, ext := range []string{".json", ".ssz_snappy"} {
+					file := filepath.Join(name, fmt.Sprintf("case_%d", i)+ext)
+
+					have, _ := os.ReadFile(filepath.Join(redir, file))
+					want, _ := os.ReadFile(filepath.Join(dir, file))
+					if !bytes.Equal(have, want) {
+						t.Errorf("fork %q: %s: regenerated vector mismatch", tt.fork, file)
+					}
+				}
+			}
+		}
+	}
+}
+
+// Tests that fork specific limit overrides resolve to the latest activated fork,
+// irrespective of the order they were listed in.
+func TestVectorsForkLimits(t *testing.T) {
+	dir := writeTestPackage(t, "vectest", `package vectest
+
+type Limits struct {
+	List []uint64 `+"`ssz-max:\"16\" ssz-max-fork:\"electra=2,deneb=8\"`"+`
+}
+`)
+	for fork, limit := range map[string]int{"capella": 16, "deneb": 8, "electra": 2} {
+		out := t.TempDir()
+		vopts := &VectorsOptions{Runs: 32, Fork: fork, Seed: 1}
+		if err := Vectors(".", []string{"Limits"}, out, &Options{Dir: dir}, vopts); err != nil {
+			t.Fatalf("fork %s: failed to generate vectors: %v", fork, err)
+		}
+		longest := 0
+		for i := 0; i < vopts.Runs; i++ {
+			compressed, err := os.ReadFile(filepath.Join(out, "Limits", fmt.Sprintf("case_%d.ssz_snappy", i)))
+			if err != nil {
+				t.Fatalf("fork %s: failed to read vector: %v", fork, err)
+			}
+			blob, err := snappy.Decode(nil, compressed)
+			if err != nil || len(blob) < 4 || binary.LittleEndian.Uint32(blob) != 4 {
+				t.Fatalf("fork %s: invalid vector %x: %v", fork, blob, err)
+			}
+			longest = max(longest, (len(blob)-4)/8)
+		}
+		if longest > limit {
+			t.Errorf("fork %s: list exceeds limit: have %d items, max %d", fork, longest, limit)
+		}
+		if longest < min(limit, 2) {
+			t.Errorf("fork %s: list limit not exercised: longest %d items, limit %d", fork, longest, limit)
+		}
+	}
+	// Unknown forks must be rejected instead of generating anything
+	if err := Vectors(".", []string{"Limits"}, t.TempDir(), &Options{Dir: dir}, &VectorsOptions{Runs: 1, Fork: "nosuchfork"}); err == nil || !strings.Contains(err.Error(), "unknown fork") {
+		t.Errorf("unknown fork error mismatch: have %v", err)
+	}
+}
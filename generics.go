@@ -4,6 +4,14 @@
 
 package ssz
 
+// newableObject is a generic type whose purpose is to enforce that the ssz.Object
+// is specifically implemented on a struct pointer. That is needed to allow to
+// instantiate new structs via `new` when parsing.
+type newableObject[U any] interface {
+	Object
+	*U
+}
+
 // newableStaticObject is a generic type whose purpose is to enforce that the
 // ssz.StaticObject is specifically implemented on a struct pointer. That is
 // needed to allow to instantiate new structs via `new` when parsing.
@@ -20,6 +28,32 @@ type newableDynamicObject[U any] interface {
 	*U
 }
 
+// newableMapEntry is a generic type whose purpose is to enforce that the key/value
+// container of a map is implemented on a struct pointer. That is needed to allow
+// to instantiate new entries when converting.
+type newableMapEntry[K comparable, V any, U any] interface {
+	MapEntry[K, V]
+	*U
+}
+
+// newableStaticMapEntry is a generic type whose purpose is to enforce that the
+// key/value container of a map is a ssz.StaticObject implemented on a struct
+// pointer. That is needed to allow to instantiate new entries when converting.
+type newableStaticMapEntry[K comparable, V any, U any] interface {
+	StaticObject
+	MapEntry[K, V]
+	*U
+}
+
+// newableDynamicMapEntry is a generic type whose purpose is to enforce that the
+// key/value container of a map is a ssz.DynamicObject implemented on a struct
+// pointer. That is needed to allow to instantiate new entries when converting.
+type newableDynamicMapEntry[K comparable, V any, U any] interface {
+	DynamicObject
+	MapEntry[K, V]
+	*U
+}
+
 // commonBytesLengths is a generic type whose purpose is to permit that fixed-
 // sized binary blobs can be passed to different methods. Although a slice of
 // the array would work for simple cases, there are scenarios when a new array
@@ -44,8 +78,24 @@ type commonBytesLengths interface {
 // generics compiler that it cannot represent arrays of arbitrary sizes with
 // one shorthand notation.
 type commonUint64sLengths interface {
-	// slashing
-	~[8192]uint64
+	// slashing (mainnet) | slashing (minimal preset)
+	~[8192]uint64 | ~[64]uint64
+}
+
+// commonUint16sLengths is a generic type whose purpose is to permit that fixed-
+// sized uint16 arrays can be passed to different methods. See commonUint64sLengths
+// for the details.
+type commonUint16sLengths interface {
+	// counters
+	~[16]uint16 | ~[64]uint16
+}
+
+// commonUint32sLengths is a generic type whose purpose is to permit that fixed-
+// sized uint32 arrays can be passed to different methods. See commonUint64sLengths
+// for the details.
+type commonUint32sLengths interface {
+	// shard counters
+	~[16]uint32 | ~[64]uint32
 }
 
 // commonBitsLengths is a generic type whose purpose is to permit that fixed-
@@ -58,8 +108,8 @@ type commonUint64sLengths interface {
 // generics compiler that it cannot represent arrays of arbitrary sizes with
 // one shorthand notation.
 type commonBitsLengths interface {
-	// justification
-	~[1]byte
+	// justification | sync committee (minimal) | bitvector64 | bitvector128 | sync committee
+	~[1]byte | ~[4]byte | ~[8]byte | ~[16]byte | ~[64]byte
 }
 
 // commonBytesArrayLengths is a generic type whose purpose is to permit that
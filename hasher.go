@@ -7,10 +7,12 @@ package ssz
 import (
 	"crypto/sha256"
 	"encoding/binary"
+	"fmt"
 	"math/big"
 	bitops "math/bits"
 	"reflect"
 	"runtime"
+	"sync"
 	"unsafe"
 
 	"github.com/holiman/uint256"
@@ -22,10 +24,55 @@ import (
 // hasherBatch is the number of chunks to batch up before calling the hasher.
 const hasherBatch = 8 // *MUST* be power of 2
 
+// hasherBulkDepth is the depth of the sub-tries hashed in one go when inserting
+// long runs of consecutive chunks (e.g. lists of roots), bypassing the per chunk
+// accounting of the accumulators.
+const hasherBulkDepth = 8
+
+// hasherTriesBatch is the number of top level leaf chunks of multiple objects to
+// accumulate before merkleizing all their tries together in one batch.
+const hasherTriesBatch = 16384
+
+// hostLittleEndian is whether the in-memory layout of the integers matches the
+// SSZ one, permitting packed integer lists to be hashed without copying.
+var hostLittleEndian = func() bool {
+	n := uint16(1)
+	return *(*byte)(unsafe.Pointer(&n)) == 1
+}()
+
 // concurrencyThreshold is the data size above which a new sub-hasher is spun up
 // for each dynamic field instead of hashing sequentially.
 const concurrencyThreshold = 65536
 
+const (
+	// BytesPerChunk is the size of the leaf chunks of an SSZ merkle trie, which
+	// basic values are packed into (BYTES_PER_CHUNK in the specs).
+	BytesPerChunk = 32
+
+	// OffsetSize is the size of the little-endian offsets pointing to the dynamic
+	// fields of a container, or the items of a list (BYTES_PER_LENGTH_OFFSET in
+	// the specs).
+	OffsetSize = 4
+)
+
+// ChunkCountOfList returns the number of leaf chunks the merkle trie of a list
+// (or vector) of at most maxItems items of the given byte size is built from,
+// before being padded to a power of two. For lists of composite items, each of
+// which is merkleized into its own root, use BytesPerChunk as the item size.
+func ChunkCountOfList(maxItems uint64, itemSize uint64) uint64 {
+	return (maxItems*itemSize + BytesPerChunk - 1) / BytesPerChunk
+}
+
+// NextPowerOfTwo returns the smallest power of two greater than or equal to n,
+// which is the number of leaves a merkle trie of n chunks gets padded to. Zero
+// is rounded up to one, matching the single zero chunk of an empty trie.
+func NextPowerOfTwo(n uint64) uint64 {
+	if n <= 1 {
+		return 1
+	}
+	return 1 << bitops.Len64(n-1)
+}
+
 // Some helpers to avoid occasional allocations
 var (
 	hasherZeroChunk = [32]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
@@ -46,13 +93,92 @@ func init() {
 	}
 }
 
+// HasherBackend is the inner hash function used to merkleize ssz objects. It can
+// be used to retain the ssz tree structure, but swap out the default sha256 for
+// something else (e.g. zk friendly hashes).
+//
+// Backends must be comparable, since zero sub-trie hashes are cached per backend.
+// They can be selected per call (e.g. HashRootWithBackend) or per Profile.
+type HasherBackend interface {
+	// HashChunks hashes consecutive pairs of chunks into the digests. The number
+	// of chunks is always twice the number of digests, and the two slices might
+	// overlap, with the digests overwriting the chunks in place.
+	//
+	// A returned error aborts the hashing: the HashRoot family of methods return
+	// it wrapped into ErrHasherBackendFailed, the others panic.
+	HashChunks(digests [][32]byte, chunks [][32]byte) error
+}
+
+// SHA256Backend is the default hasher backend, using the vectorized sha256 from
+// gohashtree. Hashing with it is equivalent to not setting a custom backend.
+var SHA256Backend HasherBackend = sha256Backend{}
+
+// sha256Backend is the default gohashtree sha256 hasher backend.
+type sha256Backend struct{}
+
+// HashChunks implements HasherBackend.
+func (sha256Backend) HashChunks(digests [][32]byte, chunks [][32]byte) error {
+	gohashtree.HashChunks(digests, chunks)
+	return nil
+}
+
+// backendZeroCaches contains the pre-computed zero sub-trie hashes for custom
+// hasher backends, keyed by the backend itself.
+var backendZeroCaches = new(sync.Map)
+
+// backendZeroes retrieves (or computes on the fly) the table of zero sub-trie
+// hashes for a custom hasher backend.
+func backendZeroes(backend HasherBackend) (*[65][32]byte, error) {
+	if zeroes, ok := backendZeroCaches.Load(backend); ok {
+		return zeroes.(*[65][32]byte), nil
+	}
+	zeroes := new([65][32]byte)
+	for i := 0; i < len(zeroes)-1; i++ {
+		var digest [1][32]byte
+		if err := backend.HashChunks(digest[:], [][32]byte{zeroes[i], zeroes[i]}); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrHasherBackendFailed, err)
+		}
+		zeroes[i+1] = digest[0]
+	}
+	backendZeroCaches.Store(backend, zeroes)
+	return zeroes, nil
+}
+
+// setBackend configures a custom hash backend for the hasher, resolving the
+// default sha256 one to the native fast path. If the backend fails to hash the
+// zero sub-tries, the failure is recorded and surfaced at the end of hashing.
+func (h *Hasher) setBackend(backend HasherBackend) {
+	if backend == nil || backend == SHA256Backend {
+		h.backend, h.zeroes = nil, nil
+		return
+	}
+	zeroes, err := backendZeroes(backend)
+	if err != nil {
+		h.fail(err)
+		zeroes = new([65][32]byte)
+	}
+	h.backend, h.zeroes = backend, zeroes
+}
+
 // Hasher is an SSZ Merkle Hash Root computer.
 type Hasher struct {
-	threads bool // Whether threaded hashing is allowed or not
+	threads bool            // Whether threaded hashing is allowed or not
+	done    <-chan struct{} // Cancellation signal aborting threaded hashing (nil = never)
+
+	backend HasherBackend // Custom inner hash function (nil = gohashtree sha256)
+	zeroes  *[65][32]byte // Zero sub-trie hashes matching the hash backend
+
+	prover *hasherProver   // Merkle proof collector for a single path (nil = off)
+	schema *schemaRecorder // Schema collector recording fields instead of hashing (nil = off)
+
+	collect bool       // Whether to collect the roots of the top level fields
+	flat    bool       // Whether to only collect the top level roots, without hashing them
+	fields  [][32]byte // Roots of the top level fields collected while hashing
 
 	chunks [][32]byte   // Scratch space for in-progress hashing chunks
 	groups []groupStats // Hashing progress tracking for the chunk groups
 	layer  int          // Layer depth being hasher now
+	broken error        // First accounting failure found while hashing (if any)
 
 	codec *Codec // Self-referencing to pass DefineSSZ calls through (API trick)
 	sizer *Sizer // Self-referencing to pass SizeSSZ call through (API trick)
@@ -68,8 +194,17 @@ type groupStats struct {
 	chunks int // Number of chunks in this group
 }
 
+// String implements fmt.Stringer, used for diagnosing unbalanced hashing.
+func (g groupStats) String() string {
+	return fmt.Sprintf("{layer %d, depth %d, %d chunks}", g.layer, g.depth, g.chunks)
+}
+
 // HashBool hashes a boolean.
 func HashBool[T ~bool](h *Hasher, v T) {
+	if h.schema != nil {
+		h.schema.field("Bool", 1, nil)
+		return
+	}
 	if !v {
 		h.insertChunk(hasherBoolFalse, 0)
 	} else {
@@ -82,7 +217,7 @@ func HashBool[T ~bool](h *Hasher, v T) {
 // Note, a nil pointer is hashed as zero.
 func HashBoolPointerOnFork[T ~bool](h *Hasher, v *T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if h.codec.fork < filter.Added || (filter.Removed > ForkUnknown && h.codec.fork >= filter.Removed) {
+	if !filter.Active(h.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard hasher
@@ -95,6 +230,10 @@ func HashBoolPointerOnFork[T ~bool](h *Hasher, v *T, filter ForkFilter) {
 
 // HashUint8 hashes a uint8.
 func HashUint8[T ~uint8](h *Hasher, n T) {
+	if h.schema != nil {
+		h.schema.field("Uint8", 1, nil)
+		return
+	}
 	var buffer [32]byte
 	buffer[0] = uint8(n)
 	h.insertChunk(buffer, 0)
@@ -105,7 +244,7 @@ func HashUint8[T ~uint8](h *Hasher, n T) {
 // Note, a nil pointer is hashed as zero.
 func HashUint8PointerOnFork[T ~uint8](h *Hasher, n *T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if h.codec.fork < filter.Added || (filter.Removed > ForkUnknown && h.codec.fork >= filter.Removed) {
+	if !filter.Active(h.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard hasher
@@ -118,8 +257,12 @@ func HashUint8PointerOnFork[T ~uint8](h *Hasher, n *T, filter ForkFilter) {
 
 // HashUint16 hashes a uint16.
 func HashUint16[T ~uint16](h *Hasher, n T) {
+	if h.schema != nil {
+		h.schema.field("Uint16", 2, nil)
+		return
+	}
 	var buffer [32]byte
-	binary.LittleEndian.PutUint16(buffer[:], uint16(n))
+	h.codec.order.putUint16(buffer[:], uint16(n))
 	h.insertChunk(buffer, 0)
 }
 
@@ -128,7 +271,7 @@ func HashUint16[T ~uint16](h *Hasher, n T) {
 // Note, a nil pointer is hashed as zero.
 func HashUint16PointerOnFork[T ~uint16](h *Hasher, n *T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if h.codec.fork < filter.Added || (filter.Removed > ForkUnknown && h.codec.fork >= filter.Removed) {
+	if !filter.Active(h.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard hasher
@@ -141,8 +284,12 @@ func HashUint16PointerOnFork[T ~uint16](h *Hasher, n *T, filter ForkFilter) {
 
 // HashUint32 hashes a uint32.
 func HashUint32[T ~uint32](h *Hasher, n T) {
+	if h.schema != nil {
+		h.schema.field("Uint32", 4, nil)
+		return
+	}
 	var buffer [32]byte
-	binary.LittleEndian.PutUint32(buffer[:], uint32(n))
+	h.codec.order.putUint32(buffer[:], uint32(n))
 	h.insertChunk(buffer, 0)
 }
 
@@ -151,7 +298,7 @@ func HashUint32[T ~uint32](h *Hasher, n T) {
 // Note, a nil pointer is hashed as zero.
 func HashUint32PointerOnFork[T ~uint32](h *Hasher, n *T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if h.codec.fork < filter.Added || (filter.Removed > ForkUnknown && h.codec.fork >= filter.Removed) {
+	if !filter.Active(h.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard hasher
@@ -164,8 +311,12 @@ func HashUint32PointerOnFork[T ~uint32](h *Hasher, n *T, filter ForkFilter) {
 
 // HashUint64 hashes a uint64.
 func HashUint64[T ~uint64](h *Hasher, n T) {
+	if h.schema != nil {
+		h.schema.field("Uint64", 8, nil)
+		return
+	}
 	var buffer [32]byte
-	binary.LittleEndian.PutUint64(buffer[:], uint64(n))
+	h.codec.order.putUint64(buffer[:], uint64(n))
 	h.insertChunk(buffer, 0)
 }
 
@@ -174,7 +325,7 @@ func HashUint64[T ~uint64](h *Hasher, n T) {
 // Note, a nil pointer is hashed as zero.
 func HashUint64PointerOnFork[T ~uint64](h *Hasher, n *T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if h.codec.fork < filter.Added || (filter.Removed > ForkUnknown && h.codec.fork >= filter.Removed) {
+	if !filter.Active(h.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard hasher
@@ -189,9 +340,13 @@ func HashUint64PointerOnFork[T ~uint64](h *Hasher, n *T, filter ForkFilter) {
 //
 // Note, a nil pointer is hashed as zero.
 func HashUint256(h *Hasher, n *uint256.Int) {
+	if h.schema != nil {
+		h.schema.field("Uint256", 32, nil)
+		return
+	}
 	var buffer [32]byte
 	if n != nil {
-		n.MarshalSSZInto(buffer[:])
+		h.codec.order.putUint256(buffer[:], n)
 	}
 	h.insertChunk(buffer, 0)
 }
@@ -201,7 +356,7 @@ func HashUint256(h *Hasher, n *uint256.Int) {
 // Note, a nil pointer is hashed as zero.
 func HashUint256OnFork(h *Hasher, n *uint256.Int, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if h.codec.fork < filter.Added || (filter.Removed > ForkUnknown && h.codec.fork >= filter.Removed) {
+	if !filter.Active(h.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard hasher
@@ -213,11 +368,15 @@ func HashUint256OnFork(h *Hasher, n *uint256.Int, filter ForkFilter) {
 // Note, a nil pointer is hashed as zero.
 // Note, an overflow will be silently dropped.
 func HashUint256BigInt(h *Hasher, n *big.Int) {
+	if h.schema != nil {
+		h.schema.field("Uint256BigInt", 32, nil)
+		return
+	}
 	var buffer [32]byte
 	if n != nil {
 		var bufint uint256.Int // No pointer, alloc free
 		bufint.SetFromBig(n)
-		bufint.MarshalSSZInto(buffer[:])
+		h.codec.order.putUint256(buffer[:], &bufint)
 	}
 	h.insertChunk(buffer, 0)
 }
@@ -228,7 +387,7 @@ func HashUint256BigInt(h *Hasher, n *big.Int) {
 // Note, an overflow will be silently dropped.
 func HashUint256BigIntOnFork(h *Hasher, n *big.Int, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if h.codec.fork < filter.Added || (filter.Removed > ForkUnknown && h.codec.fork >= filter.Removed) {
+	if !filter.Active(h.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard hasher
@@ -240,6 +399,10 @@ func HashUint256BigIntOnFork(h *Hasher, n *big.Int, filter ForkFilter) {
 // The blob is passed by pointer to avoid high stack copy costs and a potential
 // escape to the heap.
 func HashStaticBytes[T commonBytesLengths](h *Hasher, blob *T) {
+	if h.schema != nil {
+		h.schema.field("StaticBytes", reflect.TypeFor[T]().Len(), nil)
+		return
+	}
 	// The code below should have used `blob[:]`, alas Go's generics compiler
 	// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
 	h.hashBytes(unsafe.Slice(&(*blob)[0], len(*blob)))
@@ -250,11 +413,12 @@ func HashStaticBytes[T commonBytesLengths](h *Hasher, blob *T) {
 // Note, a nil pointer is hashed as an empty binary blob.
 func HashStaticBytesPointerOnFork[T commonBytesLengths](h *Hasher, blob *T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if h.codec.fork < filter.Added || (filter.Removed > ForkUnknown && h.codec.fork >= filter.Removed) {
+	if !filter.Active(h.codec.fork) {
 		return
 	}
-	// Otherwise fall back to the standard hasher
-	if blob == nil {
+	// Otherwise fall back to the standard hasher (which records nil fields too
+	// when deriving schemas)
+	if blob == nil && h.schema == nil {
 		// Go generics cannot do len(T{}), so we either allocate and bear the GC
 		// costs, or we use reflect. Both is kind of crappy.
 		//
@@ -267,34 +431,73 @@ func HashStaticBytesPointerOnFork[T commonBytesLengths](h *Hasher, blob *T, filt
 
 // HashCheckedStaticBytes hashes a static binary blob.
 func HashCheckedStaticBytes(h *Hasher, blob []byte) {
+	if h.schema != nil {
+		h.schema.field("CheckedStaticBytes", len(blob), nil)
+		return
+	}
 	h.hashBytes(blob)
 }
 
 // HashDynamicBytes hashes a dynamic binary blob.
 func HashDynamicBytes(h *Hasher, blob []byte, maxSize uint64) {
+	if h.schema != nil {
+		h.schema.field("DynamicBytes", 0, nil, maxSize)
+		return
+	}
+	h.checkBytes(len(blob), maxSize)
 	h.descendMixinLayer()
 	h.insertBlobChunks(blob)
-	h.ascendMixinLayer(uint64(len(blob)), (maxSize+31)/32)
+	h.ascendMixinLayer(uint64(len(blob)), ChunkCountOfList(maxSize, 1))
 }
 
 // HashDynamicBytesOnFork hashes a dynamic binary blob if present in a fork.
 func HashDynamicBytesOnFork(h *Hasher, blob []byte, maxSize uint64, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if h.codec.fork < filter.Added || (filter.Removed > ForkUnknown && h.codec.fork >= filter.Removed) {
+	if !filter.Active(h.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard hasher
 	HashDynamicBytes(h, blob, maxSize)
 }
 
+// HashString hashes a string as a dynamic binary blob.
+func HashString[T ~string](h *Hasher, str T, maxSize uint64) {
+	if h.schema != nil {
+		h.schema.field("String", 0, nil, maxSize)
+		return
+	}
+	h.checkBytes(len(str), maxSize)
+	h.descendMixinLayer()
+	h.insertStringChunks(string(str))
+	h.ascendMixinLayer(uint64(len(str)), ChunkCountOfList(maxSize, 1))
+}
+
+// HashStringOnFork hashes a string as a dynamic binary blob if present in a fork.
+func HashStringOnFork[T ~string](h *Hasher, str T, maxSize uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(h.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard hasher
+	HashString(h, str, maxSize)
+}
+
 // HashStaticObject hashes a static ssz object.
 func HashStaticObject[T newableStaticObject[U], U any](h *Hasher, obj T) {
-	h.descendLayer()
+	if h.schema != nil {
+		h.schema.object("StaticObject", T(new(U)))
+		return
+	}
 	if obj == nil {
-		// If the object is nil, pull up it's zero value. This will be very slow,
-		// but it should not happen in production, only during tests mostly.
-		obj = zeroValueStatic[T, U]()
+		// If the object is nil, pull up it's zero root. This will be slow on the
+		// first hit, but cached afterwards for the specific type and fork.
+		h.insertZeroRoot(zeroRootStatic[T, U](h.codec.fork, h.backend))
+		return
+	}
+	if h.insertRootedObject(obj) {
+		return
 	}
+	h.descendLayer()
 	obj.DefineSSZ(h.codec)
 	h.ascendLayer(0)
 }
@@ -302,7 +505,7 @@ func HashStaticObject[T newableStaticObject[U], U any](h *Hasher, obj T) {
 // HashStaticObjectOnFork hashes a static ssz object if present in a fork.
 func HashStaticObjectOnFork[T newableStaticObject[U], U any](h *Hasher, obj T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if h.codec.fork < filter.Added || (filter.Removed > ForkUnknown && h.codec.fork >= filter.Removed) {
+	if !filter.Active(h.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard hasher
@@ -311,12 +514,20 @@ func HashStaticObjectOnFork[T newableStaticObject[U], U any](h *Hasher, obj T, f
 
 // HashDynamicObject hashes a dynamic ssz object.
 func HashDynamicObject[T newableDynamicObject[U], U any](h *Hasher, obj T) {
-	h.descendLayer()
+	if h.schema != nil {
+		h.schema.object("DynamicObject", T(new(U)))
+		return
+	}
 	if obj == nil {
-		// If the object is nil, pull up it's zero value. This will be very slow,
-		// but it should not happen in production, only during tests mostly.
-		obj = zeroValueDynamic[T, U]()
+		// If the object is nil, pull up it's zero root. This will be slow on the
+		// first hit, but cached afterwards for the specific type and fork.
+		h.insertZeroRoot(zeroRootDynamic[T, U](h.codec.fork, h.backend))
+		return
+	}
+	if h.insertRootedObject(obj) {
+		return
 	}
+	h.descendLayer()
 	obj.DefineSSZ(h.codec)
 	h.ascendLayer(0)
 }
@@ -324,15 +535,83 @@ func HashDynamicObject[T newableDynamicObject[U], U any](h *Hasher, obj T) {
 // HashDynamicObjectOnFork hashes a dynamic ssz object if present in a fork.
 func HashDynamicObjectOnFork[T newableDynamicObject[U], U any](h *Hasher, obj T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if h.codec.fork < filter.Added || (filter.Removed > ForkUnknown && h.codec.fork >= filter.Removed) {
+	if !filter.Active(h.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard hasher
 	HashDynamicObject(h, obj)
 }
 
+// HashGenericStaticObject hashes a static ssz object passed as a type parameter
+// of a generic container.
+func HashGenericStaticObject[T StaticObject](h *Hasher, obj T) {
+	if h.schema != nil {
+		h.schema.object("StaticObject", newGeneric[T]())
+		return
+	}
+	if isNilGeneric(obj) {
+		// If the object is nil, pull up it's zero root. This will be slow on the
+		// first hit, but cached afterwards for the specific type and fork.
+		h.insertZeroRoot(zeroRootGeneric[T](h.codec.fork, h.backend))
+		return
+	}
+	if h.insertRootedObject(obj) {
+		return
+	}
+	h.descendLayer()
+	obj.DefineSSZ(h.codec)
+	h.ascendLayer(0)
+}
+
+// HashGenericStaticObjectOnFork hashes a static ssz object passed as a type
+// parameter of a generic container if present in a fork.
+func HashGenericStaticObjectOnFork[T StaticObject](h *Hasher, obj T, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(h.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard hasher
+	HashGenericStaticObject(h, obj)
+}
+
+// HashGenericDynamicObject hashes a dynamic ssz object passed as a type parameter
+// of a generic container.
+func HashGenericDynamicObject[T DynamicObject](h *Hasher, obj T) {
+	if h.schema != nil {
+		h.schema.object("DynamicObject", newGeneric[T]())
+		return
+	}
+	if isNilGeneric(obj) {
+		// If the object is nil, pull up it's zero root. This will be slow on the
+		// first hit, but cached afterwards for the specific type and fork.
+		h.insertZeroRoot(zeroRootGeneric[T](h.codec.fork, h.backend))
+		return
+	}
+	if h.insertRootedObject(obj) {
+		return
+	}
+	h.descendLayer()
+	obj.DefineSSZ(h.codec)
+	h.ascendLayer(0)
+}
+
+// HashGenericDynamicObjectOnFork hashes a dynamic ssz object passed as a type
+// parameter of a generic container if present in a fork.
+func HashGenericDynamicObjectOnFork[T DynamicObject](h *Hasher, obj T, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(h.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard hasher
+	HashGenericDynamicObject(h, obj)
+}
+
 // HashArrayOfBits hashes a static array of (packed) bits.
 func HashArrayOfBits[T commonBitsLengths](h *Hasher, bits *T) {
+	if h.schema != nil {
+		h.schema.field("ArrayOfBits", reflect.TypeFor[T]().Len(), nil)
+		return
+	}
 	// The code below should have used `*bits[:]`, alas Go's generics compiler
 	// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
 	h.hashBytes(unsafe.Slice(&(*bits)[0], len(*bits)))
@@ -342,11 +621,12 @@ func HashArrayOfBits[T commonBitsLengths](h *Hasher, bits *T) {
 // in a fork.
 func HashArrayOfBitsPointerOnFork[T commonBitsLengths](h *Hasher, bits *T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if h.codec.fork < filter.Added || (filter.Removed > ForkUnknown && h.codec.fork >= filter.Removed) {
+	if !filter.Active(h.codec.fork) {
 		return
 	}
-	// Otherwise fall back to the standard hasher
-	if bits == nil {
+	// Otherwise fall back to the standard hasher (which records nil fields too
+	// when deriving schemas)
+	if bits == nil && h.schema == nil {
 		// Go generics cannot do len(T{}), so we either allocate and bear the GC
 		// costs, or we use reflect. Both is kind of crappy.
 		//
@@ -357,10 +637,88 @@ func HashArrayOfBitsPointerOnFork[T commonBitsLengths](h *Hasher, bits *T, filte
 	HashArrayOfBits(h, bits)
 }
 
+// HashUnsafeArrayOfBits hashes a static array of static arrays of (packed) bits.
+func HashUnsafeArrayOfBits[T commonBitsLengths](h *Hasher, bits []T) {
+	if h.schema != nil {
+		size := reflect.TypeFor[T]().Len()
+		h.schema.field("UnsafeArrayOfBits", len(bits)*size, []int{len(bits), size})
+		return
+	}
+	h.descendLayer()
+	for i := 0; i < len(bits); i++ {
+		// The code below should have used `bits[i][:]`, alas Go's generics compiler
+		// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
+		h.hashBytes(unsafe.Slice(&bits[i][0], len(bits[i])))
+	}
+	h.ascendLayer(0)
+}
+
+// HashCheckedArrayOfBits hashes a static array of static arrays of (packed) bits.
+//
+// Note, a nil slice is hashed as an array of zero-value bit arrays.
+func HashCheckedArrayOfBits[T commonBitsLengths](h *Hasher, bits []T, items uint64) {
+	size := reflect.TypeFor[T]().Len()
+	if h.schema != nil {
+		h.schema.field("CheckedArrayOfBits", int(items)*size, []int{int(items), size})
+		return
+	}
+	if bits == nil {
+		h.descendLayer()
+		for i := uint64(0); i < items; i++ {
+			h.hashBytesEmpty(size)
+		}
+		h.ascendLayer(0)
+		return
+	}
+	HashUnsafeArrayOfBits(h, bits)
+}
+
+// HashCheckedBits hashes a static (packed) bit slice, such as one of go-bitfield's
+// bitvectors.
+//
+// Note, a nil bit slice is hashed as a zero-value bitvector.
+func HashCheckedBits[T ~[]byte](h *Hasher, bits T, size uint64) {
+	blobSize := int((size + 7) >> 3)
+	if h.schema != nil {
+		h.schema.field("CheckedBits", blobSize, nil)
+		return
+	}
+	if bits == nil {
+		h.hashBytesEmpty(blobSize)
+		return
+	}
+	h.hashBytes(bits)
+}
+
+// HashUnsafeArrayOfCheckedBits hashes a static array of (packed) bit slices, such
+// as go-bitfield's bitvectors.
+//
+// Note, a nil bit slice is hashed as a zero-value bitvector.
+func HashUnsafeArrayOfCheckedBits[T ~[]byte](h *Hasher, bits []T, size uint64) {
+	blobSize := int((size + 7) >> 3)
+	if h.schema != nil {
+		h.schema.field("UnsafeArrayOfCheckedBits", len(bits)*blobSize, []int{len(bits), blobSize})
+		return
+	}
+	h.descendLayer()
+	for _, bitvector := range bits {
+		if bitvector == nil {
+			h.hashBytesEmpty(blobSize)
+		} else {
+			h.hashBytes(bitvector)
+		}
+	}
+	h.ascendLayer(0)
+}
+
 // HashSliceOfBits hashes a dynamic slice of (packed) bits.
 //
 // Note, a nil slice of bits is serialized as an empty bit list.
 func HashSliceOfBits(h *Hasher, bits bitfield.Bitlist, maxBits uint64) {
+	if h.schema != nil {
+		h.schema.field("SliceOfBits", 0, nil, maxBits)
+		return
+	}
 	// If the slice of bits is nil (i.e. uninitialized), hash it as empty
 	if bits == nil {
 		HashSliceOfBits(h, bitlistZero, maxBits)
@@ -371,6 +729,9 @@ func HashSliceOfBits(h *Hasher, bits bitfield.Bitlist, maxBits uint64) {
 		msb  = uint8(bitops.Len8(bits[len(bits)-1])) - 1
 		size = uint64((len(bits)-1)<<3 + int(msb))
 	)
+	if h.broken == nil && size > maxBits {
+		h.broken = fmt.Errorf("%w: hashing %d bits, max %d", ErrMaxLengthExceeded, size, maxBits)
+	}
 	h.bitbuf = append(h.bitbuf[:0], bits...)
 	h.bitbuf[len(h.bitbuf)-1] &^= uint8(1 << msb)
 
@@ -399,7 +760,7 @@ func HashSliceOfBits(h *Hasher, bits bitfield.Bitlist, maxBits uint64) {
 // Note, a nil slice of bits is serialized as an empty bit list.
 func HashSliceOfBitsOnFork(h *Hasher, bits bitfield.Bitlist, maxBits uint64, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if h.codec.fork < filter.Added || (filter.Removed > ForkUnknown && h.codec.fork >= filter.Removed) {
+	if !filter.Active(h.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard hasher
@@ -412,28 +773,15 @@ func HashSliceOfBitsOnFork(h *Hasher, bits bitfield.Bitlist, maxBits uint64, fil
 // escaping to the heap (and incurring an allocation) when passing it to the
 // hasher.
 func HashArrayOfUint64s[T commonUint64sLengths](h *Hasher, ns *T) {
+	if h.schema != nil {
+		h.schema.field("ArrayOfUint64s", reflect.TypeFor[T]().Len()*8, nil)
+		return
+	}
 	// The code below should have used `*blob[:]`, alas Go's generics compiler
 	// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
 	nums := unsafe.Slice(&(*ns)[0], len(*ns))
 	h.descendLayer()
-
-	var buffer [32]byte
-	for len(nums) > 4 {
-		binary.LittleEndian.PutUint64(buffer[:], nums[0])
-		binary.LittleEndian.PutUint64(buffer[8:], nums[1])
-		binary.LittleEndian.PutUint64(buffer[16:], nums[2])
-		binary.LittleEndian.PutUint64(buffer[24:], nums[3])
-
-		h.insertChunk(buffer, 0)
-		nums = nums[4:]
-	}
-	if len(nums) > 0 {
-		buffer = [32]byte{}
-		for i := 0; i < len(nums); i++ {
-			binary.LittleEndian.PutUint64(buffer[i<<3:], nums[i])
-		}
-		h.insertChunk(buffer, 0)
-	}
+	hashUint64sItems(h, nums)
 	h.ascendLayer(0)
 }
 
@@ -441,11 +789,12 @@ func HashArrayOfUint64s[T commonUint64sLengths](h *Hasher, ns *T) {
 // in a fork.
 func HashArrayOfUint64sPointerOnFork[T commonUint64sLengths](h *Hasher, ns *T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if h.codec.fork < filter.Added || (filter.Removed > ForkUnknown && h.codec.fork >= filter.Removed) {
+	if !filter.Active(h.codec.fork) {
 		return
 	}
-	// Otherwise fall back to the standard hasher
-	if ns == nil {
+	// Otherwise fall back to the standard hasher (which records nil fields too
+	// when deriving schemas)
+	if ns == nil && h.schema == nil {
 		h.descendLayer()
 		h.insertBlobChunksEmpty(reflect.TypeFor[T]().Len() * 8)
 		h.ascendLayer(0)
@@ -454,112 +803,456 @@ func HashArrayOfUint64sPointerOnFork[T commonUint64sLengths](h *Hasher, ns *T, f
 	HashArrayOfUint64s(h, ns)
 }
 
-// HashSliceOfUint64s hashes a dynamic slice of uint64s.
-func HashSliceOfUint64s[T ~uint64](h *Hasher, ns []T, maxItems uint64) {
-	h.descendMixinLayer()
-	nums := ns
-
-	var buffer [32]byte
-	for len(nums) > 4 {
-		binary.LittleEndian.PutUint64(buffer[:], uint64(nums[0]))
-		binary.LittleEndian.PutUint64(buffer[8:], uint64(nums[1]))
-		binary.LittleEndian.PutUint64(buffer[16:], uint64(nums[2]))
-		binary.LittleEndian.PutUint64(buffer[24:], uint64(nums[3]))
+// HashCheckedStaticUint64s hashes a static array of uint64s stored in a plain
+// slice.
+func HashCheckedStaticUint64s[T ~uint64](h *Hasher, ns []T, size uint64) {
+	if h.schema != nil {
+		h.schema.field("CheckedStaticUint64s", int(size)*8, nil)
+		return
+	}
+	h.descendLayer()
+	if ns == nil {
+		h.insertBlobChunksEmpty(int(size) * 8)
+	} else {
+		hashUint64sItems(h, ns)
+	}
+	h.ascendLayer(0)
+}
 
-		h.insertChunk(buffer, 0)
-		nums = nums[4:]
+// HashArrayOfUint16s hashes a static array of uint16s.
+//
+// The reason the ns is passed by pointer and not by value is to prevent it from
+// escaping to the heap (and incurring an allocation) when passing it to the
+// hasher.
+func HashArrayOfUint16s[T commonUint16sLengths](h *Hasher, ns *T) {
+	if h.schema != nil {
+		h.schema.field("ArrayOfUint16s", reflect.TypeFor[T]().Len()*2, nil)
+		return
 	}
-	if len(nums) > 0 {
+	// The code below should have used `*blob[:]`, alas Go's generics compiler
+	// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
+	nums := unsafe.Slice(&(*ns)[0], len(*ns))
+	h.descendLayer()
+
+	var buffer [32]byte
+	for len(nums) > 0 {
 		buffer = [32]byte{}
-		for i := 0; i < len(nums); i++ {
-			binary.LittleEndian.PutUint64(buffer[i<<3:], uint64(nums[i]))
+		for i := 0; i < 16 && i < len(nums); i++ {
+			h.codec.order.putUint16(buffer[i<<1:], nums[i])
 		}
 		h.insertChunk(buffer, 0)
+		nums = nums[min(16, len(nums)):]
 	}
-	h.ascendMixinLayer(uint64(len(ns)), (maxItems*8+31)/32)
+	h.ascendLayer(0)
 }
 
-// HashSliceOfUint64sOnFork hashes a dynamic slice of uint64s if present in a fork.
-func HashSliceOfUint64sOnFork[T ~uint64](h *Hasher, ns []T, maxItems uint64, filter ForkFilter) {
+// HashArrayOfUint16sPointerOnFork hashes a static array of uint16s if present
+// in a fork.
+func HashArrayOfUint16sPointerOnFork[T commonUint16sLengths](h *Hasher, ns *T, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if h.codec.fork < filter.Added || (filter.Removed > ForkUnknown && h.codec.fork >= filter.Removed) {
+	if !filter.Active(h.codec.fork) {
 		return
 	}
-	// Otherwise fall back to the standard hasher
-	HashSliceOfUint64s(h, ns, maxItems)
+	// Otherwise fall back to the standard hasher (which records nil fields too
+	// when deriving schemas)
+	if ns == nil && h.schema == nil {
+		h.descendLayer()
+		h.insertBlobChunksEmpty(reflect.TypeFor[T]().Len() * 2)
+		h.ascendLayer(0)
+		return
+	}
+	HashArrayOfUint16s(h, ns)
 }
 
-// HashArrayOfStaticBytes hashes a static array of static binary blobs.
+// HashArrayOfUint32s hashes a static array of uint32s.
 //
-// The reason the blobs is passed by pointer and not by value is to prevent it
-// from escaping to the heap (and incurring an allocation) when passing it to
-// the output stream.
-func HashArrayOfStaticBytes[T commonBytesArrayLengths[U], U commonBytesLengths](h *Hasher, blobs *T) {
-	// The code below should have used `(*blobs)[:]`, alas Go's generics compiler
+// The reason the ns is passed by pointer and not by value is to prevent it from
+// escaping to the heap (and incurring an allocation) when passing it to the
+// hasher.
+func HashArrayOfUint32s[T commonUint32sLengths](h *Hasher, ns *T) {
+	if h.schema != nil {
+		h.schema.field("ArrayOfUint32s", reflect.TypeFor[T]().Len()*4, nil)
+		return
+	}
+	// The code below should have used `*blob[:]`, alas Go's generics compiler
 	// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-	HashUnsafeArrayOfStaticBytes(h, unsafe.Slice(&(*blobs)[0], len(*blobs)))
-}
-
-// HashUnsafeArrayOfStaticBytes hashes a static array of static binary blobs.
-func HashUnsafeArrayOfStaticBytes[T commonBytesLengths](h *Hasher, blobs []T) {
+	nums := unsafe.Slice(&(*ns)[0], len(*ns))
 	h.descendLayer()
-	for i := 0; i < len(blobs); i++ {
-		// The code below should have used `blobs[i][:]`, alas Go's generics compiler
-		// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-		h.hashBytes(unsafe.Slice(&blobs[i][0], len(blobs[i])))
+
+	var buffer [32]byte
+	for len(nums) > 0 {
+		buffer = [32]byte{}
+		for i := 0; i < 8 && i < len(nums); i++ {
+			h.codec.order.putUint32(buffer[i<<2:], nums[i])
+		}
+		h.insertChunk(buffer, 0)
+		nums = nums[min(8, len(nums)):]
 	}
 	h.ascendLayer(0)
 }
 
-// HashCheckedArrayOfStaticBytes hashes a static array of static binary blobs.
-func HashCheckedArrayOfStaticBytes[T commonBytesLengths](h *Hasher, blobs []T) {
-	h.descendLayer()
-	for i := 0; i < len(blobs); i++ {
-		// The code below should have used `blobs[i][:]`, alas Go's generics compiler
-		// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-		h.hashBytes(unsafe.Slice(&blobs[i][0], len(blobs[i])))
+// HashArrayOfUint32sPointerOnFork hashes a static array of uint32s if present
+// in a fork.
+func HashArrayOfUint32sPointerOnFork[T commonUint32sLengths](h *Hasher, ns *T, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(h.codec.fork) {
+		return
 	}
-	h.ascendLayer(0)
+	// Otherwise fall back to the standard hasher (which records nil fields too
+	// when deriving schemas)
+	if ns == nil && h.schema == nil {
+		h.descendLayer()
+		h.insertBlobChunksEmpty(reflect.TypeFor[T]().Len() * 4)
+		h.ascendLayer(0)
+		return
+	}
+	HashArrayOfUint32s(h, ns)
+}
+
+// HashArrayOfArrayOfUint16s hashes a static array of static arrays of uint16s. Each
+// inner array is packed and merkleized on its own, the outer array's trie being
+// built from their roots.
+func HashArrayOfArrayOfUint16s[T commonUint16sLengths](h *Hasher, ns []T) {
+	if h.schema != nil {
+		inner := reflect.TypeFor[T]().Len()
+		h.schema.field("ArrayOfArrayOfUint16s", len(ns)*inner*2, []int{len(ns), inner, 2})
+		return
+	}
+	h.descendLayer()
+	for i := 0; i < len(ns); i++ {
+		HashArrayOfUint16s(h, &ns[i])
+	}
+	h.ascendLayer(0)
+}
+
+// HashArrayOfArrayOfUint32s hashes a static array of static arrays of uint32s. Each
+// inner array is packed and merkleized on its own, the outer array's trie being
+// built from their roots.
+func HashArrayOfArrayOfUint32s[T commonUint32sLengths](h *Hasher, ns []T) {
+	if h.schema != nil {
+		inner := reflect.TypeFor[T]().Len()
+		h.schema.field("ArrayOfArrayOfUint32s", len(ns)*inner*4, []int{len(ns), inner, 4})
+		return
+	}
+	h.descendLayer()
+	for i := 0; i < len(ns); i++ {
+		HashArrayOfUint32s(h, &ns[i])
+	}
+	h.ascendLayer(0)
+}
+
+// HashArrayOfArrayOfUint64s hashes a static array of static arrays of uint64s. Each
+// inner array is packed and merkleized on its own, the outer array's trie being
+// built from their roots.
+func HashArrayOfArrayOfUint64s[T commonUint64sLengths](h *Hasher, ns []T) {
+	if h.schema != nil {
+		inner := reflect.TypeFor[T]().Len()
+		h.schema.field("ArrayOfArrayOfUint64s", len(ns)*inner*8, []int{len(ns), inner, 8})
+		return
+	}
+	h.descendLayer()
+	for i := 0; i < len(ns); i++ {
+		HashArrayOfUint64s(h, &ns[i])
+	}
+	h.ascendLayer(0)
+}
+
+// HashSliceOfUint16s hashes a dynamic slice of uint16s, packing 16 items
+// into each chunk.
+func HashSliceOfUint16s[T ~uint16](h *Hasher, ns []T, maxItems uint64) {
+	if h.schema != nil {
+		h.schema.field("SliceOfUint16s", 0, nil, maxItems)
+		return
+	}
+	h.checkItems(len(ns), maxItems)
+	h.descendMixinLayer()
+	nums := ns
+
+	var buffer [32]byte
+	for len(nums) > 0 {
+		buffer = [32]byte{}
+		for i := 0; i < 16 && i < len(nums); i++ {
+			h.codec.order.putUint16(buffer[i<<1:], uint16(nums[i]))
+		}
+		h.insertChunk(buffer, 0)
+		nums = nums[min(16, len(nums)):]
+	}
+	h.ascendMixinLayer(uint64(len(ns)), ChunkCountOfList(maxItems, 2))
+}
+
+// HashSliceOfUint16sOnFork hashes a dynamic slice of uint16s if present in a fork.
+func HashSliceOfUint16sOnFork[T ~uint16](h *Hasher, ns []T, maxItems uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(h.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard hasher
+	HashSliceOfUint16s(h, ns, maxItems)
+}
+
+// HashSliceOfUint32s hashes a dynamic slice of uint32s, packing 8 items
+// into each chunk.
+func HashSliceOfUint32s[T ~uint32](h *Hasher, ns []T, maxItems uint64) {
+	if h.schema != nil {
+		h.schema.field("SliceOfUint32s", 0, nil, maxItems)
+		return
+	}
+	h.checkItems(len(ns), maxItems)
+	h.descendMixinLayer()
+	nums := ns
+
+	var buffer [32]byte
+	for len(nums) > 0 {
+		buffer = [32]byte{}
+		for i := 0; i < 8 && i < len(nums); i++ {
+			h.codec.order.putUint32(buffer[i<<2:], uint32(nums[i]))
+		}
+		h.insertChunk(buffer, 0)
+		nums = nums[min(8, len(nums)):]
+	}
+	h.ascendMixinLayer(uint64(len(ns)), ChunkCountOfList(maxItems, 4))
+}
+
+// HashSliceOfUint32sOnFork hashes a dynamic slice of uint32s if present in a fork.
+func HashSliceOfUint32sOnFork[T ~uint32](h *Hasher, ns []T, maxItems uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(h.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard hasher
+	HashSliceOfUint32s(h, ns, maxItems)
+}
+
+// HashSliceOfUint64s hashes a dynamic slice of uint64s.
+func HashSliceOfUint64s[T ~uint64](h *Hasher, ns []T, maxItems uint64) {
+	if h.schema != nil {
+		h.schema.field("SliceOfUint64s", 0, nil, maxItems)
+		return
+	}
+	h.checkItems(len(ns), maxItems)
+	h.descendMixinLayer()
+	hashUint64sItems(h, ns)
+	h.ascendMixinLayer(uint64(len(ns)), ChunkCountOfList(maxItems, 8))
+}
+
+// hashUint64sItems packs the items of a static array or dynamic slice of uint64s
+// into chunks and hashes them into the current layer. If the serialized layout
+// matches the in-memory one (little-endian host and profile), the full chunks are
+// reinterpreted directly from the backing array and inserted in bulk.
+func hashUint64sItems[T ~uint64](h *Hasher, nums []T) {
+	if hostLittleEndian && h.codec.order == LittleEndian && len(nums) >= 4 {
+		chunks := len(nums) / 4
+		h.insertChunksBulk(unsafe.Slice((*[32]byte)(unsafe.Pointer(&nums[0])), chunks))
+		nums = nums[chunks*4:]
+	}
+	var buffer [32]byte
+	for len(nums) > 4 {
+		h.codec.order.putUint64(buffer[:], uint64(nums[0]))
+		h.codec.order.putUint64(buffer[8:], uint64(nums[1]))
+		h.codec.order.putUint64(buffer[16:], uint64(nums[2]))
+		h.codec.order.putUint64(buffer[24:], uint64(nums[3]))
+
+		h.insertChunk(buffer, 0)
+		nums = nums[4:]
+	}
+	if len(nums) > 0 {
+		buffer = [32]byte{}
+		for i := 0; i < len(nums); i++ {
+			h.codec.order.putUint64(buffer[i<<3:], uint64(nums[i]))
+		}
+		h.insertChunk(buffer, 0)
+	}
+}
+
+// HashSliceOfUint64sOnFork hashes a dynamic slice of uint64s if present in a fork.
+func HashSliceOfUint64sOnFork[T ~uint64](h *Hasher, ns []T, maxItems uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(h.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard hasher
+	HashSliceOfUint64s(h, ns, maxItems)
+}
+
+// HashSliceOfUint64sPointerOnFork hashes a dynamic slice of uint64s behind a
+// pointer if present in a fork. A nil pointer is hashed as an empty list.
+func HashSliceOfUint64sPointerOnFork[T ~uint64](h *Hasher, ns *[]T, maxItems uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(h.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard hasher
+	if ns == nil {
+		HashSliceOfUint64s[T](h, nil, maxItems)
+		return
+	}
+	HashSliceOfUint64s(h, *ns, maxItems)
+}
+
+// HashArrayOfStaticBytes hashes a static array of static binary blobs.
+//
+// The reason the blobs is passed by pointer and not by value is to prevent it
+// from escaping to the heap (and incurring an allocation) when passing it to
+// the output stream.
+func HashArrayOfStaticBytes[T commonBytesArrayLengths[U], U commonBytesLengths](h *Hasher, blobs *T) {
+	// The code below should have used `(*blobs)[:]`, alas Go's generics compiler
+	// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
+	HashUnsafeArrayOfStaticBytes(h, unsafe.Slice(&(*blobs)[0], len(*blobs)))
+}
+
+// HashUnsafeArrayOfStaticBytes hashes a static array of static binary blobs.
+func HashUnsafeArrayOfStaticBytes[T commonBytesLengths](h *Hasher, blobs []T) {
+	if h.schema != nil {
+		size := reflect.TypeFor[T]().Len()
+		h.schema.field("UnsafeArrayOfStaticBytes", len(blobs)*size, []int{len(blobs), size})
+		return
+	}
+	h.descendLayer()
+	hashStaticBytesItems(h, blobs)
+	h.ascendLayer(0)
+}
+
+// HashCheckedArrayOfStaticBytes hashes a static array of static binary blobs.
+func HashCheckedArrayOfStaticBytes[T commonBytesLengths](h *Hasher, blobs []T) {
+	if h.schema != nil {
+		size := reflect.TypeFor[T]().Len()
+		h.schema.field("CheckedArrayOfStaticBytes", len(blobs)*size, []int{len(blobs), size})
+		return
+	}
+	h.descendLayer()
+	hashStaticBytesItems(h, blobs)
+	h.ascendLayer(0)
 }
 
 // HashSliceOfStaticBytes hashes a dynamic slice of static binary blobs.
 func HashSliceOfStaticBytes[T commonBytesLengths](h *Hasher, blobs []T, maxItems uint64) {
+	if h.schema != nil {
+		h.schema.field("SliceOfStaticBytes", 0, []int{0, reflect.TypeFor[T]().Len()}, maxItems)
+		return
+	}
+	h.checkItems(len(blobs), maxItems)
 	h.descendMixinLayer()
+	hashStaticBytesItems(h, blobs)
+	h.ascendMixinLayer(uint64(len(blobs)), maxItems)
+}
+
+// hashStaticBytesItems hashes the items of a static array or dynamic slice of
+// static binary blobs into the current layer. Blobs of exactly one chunk (i.e.
+// roots) are inserted in bulk, everything else one by one.
+func hashStaticBytesItems[T commonBytesLengths](h *Hasher, blobs []T) {
+	if len(blobs) > 0 && unsafe.Sizeof(blobs[0]) == 32 {
+		h.insertChunksBulk(unsafe.Slice((*[32]byte)(unsafe.Pointer(&blobs[0])), len(blobs)))
+		return
+	}
 	for i := 0; i < len(blobs); i++ {
 		// The code below should have used `blobs[i][:]`, alas Go's generics compiler
 		// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
 		h.hashBytes(unsafe.Slice(&blobs[i][0], len(blobs[i])))
 	}
-	h.ascendMixinLayer(uint64(len(blobs)), maxItems)
 }
 
 // HashSliceOfStaticBytesOnFork hashes a dynamic slice of static binary blobs if
 // present in a fork.
 func HashSliceOfStaticBytesOnFork[T commonBytesLengths](h *Hasher, blobs []T, maxItems uint64, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if h.codec.fork < filter.Added || (filter.Removed > ForkUnknown && h.codec.fork >= filter.Removed) {
+	if !filter.Active(h.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard hasher
 	HashSliceOfStaticBytes(h, blobs, maxItems)
 }
 
+// HashSliceOfStaticBytesPointerOnFork hashes a dynamic slice of static binary
+// blobs behind a pointer if present in a fork. A nil pointer is hashed as an
+// empty list.
+func HashSliceOfStaticBytesPointerOnFork[T commonBytesLengths](h *Hasher, blobs *[]T, maxItems uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(h.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard hasher
+	if blobs == nil {
+		HashSliceOfStaticBytes[T](h, nil, maxItems)
+		return
+	}
+	HashSliceOfStaticBytes(h, *blobs, maxItems)
+}
+
+// HashCheckedArrayOfDynamicBytes hashes a static array of dynamic binary blobs.
+func HashCheckedArrayOfDynamicBytes(h *Hasher, blobs [][]byte, size uint64, maxSize uint64) {
+	if h.schema != nil {
+		h.schema.field("CheckedArrayOfDynamicBytes", 0, nil, size, maxSize)
+		return
+	}
+	if blobs != nil && uint64(len(blobs)) != size && h.broken == nil {
+		h.broken = fmt.Errorf("%w: hashing %d, type expects %d", ErrArrayLengthMismatch, len(blobs), size)
+	}
+	h.checkBlobs(blobs, maxSize)
+	h.descendLayer()
+	for i := uint64(0); i < size; i++ {
+		// Missing items (nil array) are hashed as empty blobs
+		var blob []byte
+		if i < uint64(len(blobs)) {
+			blob = blobs[i]
+		}
+		h.descendMixinLayer()
+		h.insertBlobChunks(blob)
+		h.ascendMixinLayer(uint64(len(blob)), ChunkCountOfList(maxSize, 1))
+	}
+	h.ascendLayer(0)
+}
+
+// HashCheckedArrayOfDynamicBytesOnFork hashes a static array of dynamic binary
+// blobs if present in a fork.
+func HashCheckedArrayOfDynamicBytesOnFork(h *Hasher, blobs [][]byte, size uint64, maxSize uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(h.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard hasher
+	HashCheckedArrayOfDynamicBytes(h, blobs, size, maxSize)
+}
+
 // HashSliceOfDynamicBytes hashes a dynamic slice of dynamic binary blobs.
 func HashSliceOfDynamicBytes(h *Hasher, blobs [][]byte, maxItems uint64, maxSize uint64) {
+	if h.schema != nil {
+		h.schema.field("SliceOfDynamicBytes", 0, nil, maxItems, maxSize)
+		return
+	}
+	h.checkItems(len(blobs), maxItems)
+	h.checkBlobs(blobs, maxSize)
 	h.descendMixinLayer()
-	for _, blob := range blobs {
-		h.descendMixinLayer()
-		h.insertBlobChunks(blob)
-		h.ascendMixinLayer(uint64(len(blob)), (maxSize+31)/32)
+	defer h.ascendMixinLayer(uint64(len(blobs)), maxItems)
+
+	// If threading is disabled, or hashing too little data, do it sequentially.
+	// The blobs are sized one by one, but only until the threshold is reached.
+	var size int
+	if h.threads {
+		for i := 0; i < len(blobs) && size < concurrencyThreshold; i++ {
+			size += len(blobs[i])
+		}
 	}
-	h.ascendMixinLayer(uint64(len(blobs)), maxItems)
+	if size < concurrencyThreshold {
+		for _, blob := range blobs {
+			h.descendMixinLayer()
+			h.insertBlobChunks(blob)
+			h.ascendMixinLayer(uint64(len(blob)), ChunkCountOfList(maxSize, 1))
+		}
+		return
+	}
+	// Otherwise split the slice up and hash the blobs concurrently
+	h.hashConcurrently(len(blobs), func(sub *Hasher, i int) {
+		sub.descendMixinLayer()
+		sub.insertBlobChunks(blobs[i])
+		sub.ascendMixinLayer(uint64(len(blobs[i])), ChunkCountOfList(maxSize, 1))
+	})
 }
 
 // HashSliceOfDynamicBytesOnFork hashes a dynamic slice of dynamic binary blobs
 // if present in a fork.
 func HashSliceOfDynamicBytesOnFork(h *Hasher, blobs [][]byte, maxItems uint64, maxSize uint64, filter ForkFilter) {
 	// If the field is not active in the current fork, early return
-	if h.codec.fork < filter.Added || (filter.Removed > ForkUnknown && h.codec.fork >= filter.Removed) {
+	if !filter.Active(h.codec.fork) {
 		return
 	}
 	// Otherwise fall back to the standard hasher
@@ -568,20 +1261,183 @@ func HashSliceOfDynamicBytesOnFork(h *Hasher, blobs [][]byte, maxItems uint64, m
 
 // HashSliceOfStaticObjects hashes a dynamic slice of static ssz objects.
 func HashSliceOfStaticObjects[T StaticObject](h *Hasher, objects []T, maxItems uint64) {
+	if h.schema != nil {
+		h.schema.object("SliceOfStaticObjects", newGeneric[T](), maxItems)
+		return
+	}
+	h.checkItems(len(objects), maxItems)
 	h.descendMixinLayer()
 	defer h.ascendMixinLayer(uint64(len(objects)), maxItems)
 
 	// If threading is disabled, or hashing nothing, do it sequentially
 	if !h.threads || len(objects) == 0 || len(objects)*int(SizeOnFork(objects[0], h.codec.fork)) < concurrencyThreshold {
 		for _, obj := range objects {
+			if h.insertRootedObject(obj) {
+				continue
+			}
+			h.descendLayer()
+			obj.DefineSSZ(h.codec)
+			h.ascendLayer(0)
+		}
+		return
+	}
+	// Otherwise split the slice up and hash the objects concurrently
+	h.hashConcurrently(len(objects), func(sub *Hasher, i int) {
+		if sub.insertRootedObject(objects[i]) {
+			return
+		}
+		sub.descendLayer()
+		objects[i].DefineSSZ(sub.codec)
+		sub.ascendLayer(0)
+	})
+}
+
+// HashSliceOfStaticObjectsOnFork hashes a dynamic slice of static ssz objects
+// if present in a fork.
+func HashSliceOfStaticObjectsOnFork[T StaticObject](h *Hasher, objects []T, maxItems uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(h.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard hasher
+	HashSliceOfStaticObjects(h, objects, maxItems)
+}
+
+// HashSliceOfStaticObjectValues hashes a dynamic slice of static ssz objects
+// stored by value.
+func HashSliceOfStaticObjectValues[T newableStaticObject[U], U any](h *Hasher, objects []U, maxItems uint64) {
+	if h.schema != nil {
+		h.schema.object("SliceOfStaticObjects", T(new(U)), maxItems)
+		return
+	}
+	h.checkItems(len(objects), maxItems)
+	h.descendMixinLayer()
+	defer h.ascendMixinLayer(uint64(len(objects)), maxItems)
+
+	// If threading is disabled, or hashing nothing, do it sequentially
+	if !h.threads || len(objects) == 0 || len(objects)*int(SizeOnFork(T(&objects[0]), h.codec.fork)) < concurrencyThreshold {
+		for i := range objects {
+			obj := T(&objects[i])
+			if h.insertRootedObject(obj) {
+				continue
+			}
+			h.descendLayer()
+			obj.DefineSSZ(h.codec)
+			h.ascendLayer(0)
+		}
+		return
+	}
+	// Otherwise split the slice up and hash the objects concurrently
+	h.hashConcurrently(len(objects), func(sub *Hasher, i int) {
+		obj := T(&objects[i])
+		if sub.insertRootedObject(obj) {
+			return
+		}
+		sub.descendLayer()
+		obj.DefineSSZ(sub.codec)
+		sub.ascendLayer(0)
+	})
+}
+
+// HashSliceOfStaticObjectValuesOnFork hashes a dynamic slice of static ssz objects
+// stored by value if present in a fork.
+func HashSliceOfStaticObjectValuesOnFork[T newableStaticObject[U], U any](h *Hasher, objects []U, maxItems uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(h.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard hasher
+	HashSliceOfStaticObjectValues[T](h, objects, maxItems)
+}
+
+// HashSliceOfStaticObjectsPointerOnFork hashes a dynamic slice of static ssz
+// objects behind a pointer if present in a fork. A nil pointer is hashed as an
+// empty list.
+func HashSliceOfStaticObjectsPointerOnFork[T StaticObject](h *Hasher, objects *[]T, maxItems uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(h.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard hasher
+	if objects == nil {
+		HashSliceOfStaticObjects[T](h, nil, maxItems)
+		return
+	}
+	HashSliceOfStaticObjects(h, *objects, maxItems)
+}
+
+// HashSliceOfDynamicObjects hashes a dynamic slice of dynamic ssz objects.
+func HashSliceOfDynamicObjects[T DynamicObject](h *Hasher, objects []T, maxItems uint64) {
+	if h.schema != nil {
+		h.schema.object("SliceOfDynamicObjects", newGeneric[T](), maxItems)
+		return
+	}
+	h.checkItems(len(objects), maxItems)
+	h.descendMixinLayer()
+	defer h.ascendMixinLayer(uint64(len(objects)), maxItems)
+
+	// If threading is disabled, or hashing too little data, do it sequentially.
+	// The objects are sized one by one, but only until the threshold is reached.
+	var size int
+	if h.threads {
+		for i := 0; i < len(objects) && size < concurrencyThreshold; i++ {
+			size += int(objects[i].SizeSSZ(h.sizer, false))
+		}
+	}
+	if size < concurrencyThreshold {
+		for _, obj := range objects {
+			if h.insertRootedObject(obj) {
+				continue
+			}
 			h.descendLayer()
 			obj.DefineSSZ(h.codec)
 			h.ascendLayer(0)
 		}
 		return
 	}
-	// Split the slice into equal chunks and hash the objects concurrently. The
-	// splits will in theory be objects // threads. In practice, we need powers
+	// Otherwise split the slice up and hash the objects concurrently
+	h.hashConcurrently(len(objects), func(sub *Hasher, i int) {
+		if sub.insertRootedObject(objects[i]) {
+			return
+		}
+		sub.descendLayer()
+		objects[i].DefineSSZ(sub.codec)
+		sub.ascendLayer(0)
+	})
+}
+
+// HashSliceOfDynamicObjectsOnFork hashes a dynamic slice of dynamic ssz objects
+// if present in a fork.
+func HashSliceOfDynamicObjectsOnFork[T DynamicObject](h *Hasher, objects []T, maxItems uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(h.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard hasher
+	HashSliceOfDynamicObjects(h, objects, maxItems)
+}
+
+// HashSliceOfDynamicObjectsPointerOnFork hashes a dynamic slice of dynamic ssz
+// objects behind a pointer if present in a fork. A nil pointer is hashed as an
+// empty list.
+func HashSliceOfDynamicObjectsPointerOnFork[T DynamicObject](h *Hasher, objects *[]T, maxItems uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(h.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard hasher
+	if objects == nil {
+		HashSliceOfDynamicObjects[T](h, nil, maxItems)
+		return
+	}
+	HashSliceOfDynamicObjects(h, *objects, maxItems)
+}
+
+// hashConcurrently splits the items of a list into equal chunks and hashes them
+// concurrently, each item via the given callback on a sub-hasher, inserting the
+// resulting sub-trie roots into the current layer.
+func (h *Hasher) hashConcurrently(items int, hash func(sub *Hasher, i int)) {
+	// The splits will in theory be items // threads. In practice, we need powers
 	// of 2, otherwise child hashers wouldn't be able to collapse their tasks
 	// into a single sub-root. Going for the biggest power of two that can be
 	// served by exactly N threads is a problem, because we can end up with N/2-1
@@ -591,11 +1447,11 @@ func HashSliceOfStaticObjects[T StaticObject](h *Hasher, objects []T, maxItems u
 	workers.SetLimit(runtime.NumCPU())
 
 	var (
-		splits  = min(4*runtime.NumCPU(), len(objects))
-		subtask = max(1<<bitops.Len(uint(len(objects)/splits)), 1)
+		splits  = min(4*runtime.NumCPU(), items)
+		subtask = max(1<<bitops.Len(uint(items/splits)), 1)
 
-		resultChunks = make([][32]byte, (len(objects)+subtask-1)/subtask)
-		resultDepths = make([]int, (len(objects)+subtask-1)/subtask)
+		resultChunks = make([][32]byte, (items+subtask-1)/subtask)
+		resultDepths = make([]int, (items+subtask-1)/subtask)
 	)
 	for i := 0; i < len(resultChunks); i++ {
 		worker := i // Take care, closure
@@ -604,58 +1460,78 @@ func HashSliceOfStaticObjects[T StaticObject](h *Hasher, objects []T, maxItems u
 			codec := hasherPool.Get().(*Codec)
 			defer hasherPool.Put(codec)
 			defer codec.has.Reset()
-			codec.has.threads = true
-
-			for i := worker * subtask; i < (worker+1)*subtask && i < len(objects); i++ {
-				codec.has.descendLayer()
-				objects[i].DefineSSZ(codec)
-				codec.has.ascendLayer(0)
+			codec.has.threads, codec.has.done = true, h.done
+			codec.has.backend, codec.has.zeroes = h.backend, h.zeroes
+			codec.fork, codec.order = h.codec.fork, h.codec.order
+			defer func() { codec.order = LittleEndian }()
+
+			for i := worker * subtask; i < (worker+1)*subtask && i < items; i++ {
+				// If hashing was cancelled, abandon the subtask. The result will
+				// be garbage, but the caller discards it anyway.
+				select {
+				case <-h.done:
+					return nil
+				default:
+				}
+				hash(codec.has, i)
 			}
 			codec.has.balanceLayer()
 
 			resultChunks[worker] = codec.has.chunks[0]
 			resultDepths[worker] = codec.has.groups[0].depth
-			return nil
+			return codec.has.broken
 		})
 	}
-	// Wait for all the hashers to finish and aggregate the results
-	workers.Wait()
+	// Wait for all the hashers to finish and aggregate the results, carrying over
+	// any accounting failure of the sub-hashers
+	if err := workers.Wait(); err != nil && h.broken == nil {
+		h.broken = err
+	}
 	for i := 0; i < len(resultChunks); i++ {
 		h.insertChunk(resultChunks[i], resultDepths[i])
 	}
 }
 
-// HashSliceOfStaticObjectsOnFork hashes a dynamic slice of static ssz objects
-// if present in a fork.
-func HashSliceOfStaticObjectsOnFork[T StaticObject](h *Hasher, objects []T, maxItems uint64, filter ForkFilter) {
-	// If the field is not active in the current fork, early return
-	if h.codec.fork < filter.Added || (filter.Removed > ForkUnknown && h.codec.fork >= filter.Removed) {
-		return
+// insertRootedObject checks whether the object has a precomputed merkle root,
+// and if so, inserts it directly as a chunk instead of hashing its fields.
+func (h *Hasher) insertRootedObject(obj Object) bool {
+	// Cached roots are computed with the default hash function and byte order,
+	// so they cannot be used if an alternative backend or profile was configured.
+	// Similarly, they cannot be used if a proof is being collected as it might go
+	// through them.
+	if h.backend != nil || h.codec.order != LittleEndian || h.prover != nil {
+		return false
 	}
-	// Otherwise fall back to the standard hasher
-	HashSliceOfStaticObjects(h, objects, maxItems)
+	rooted, ok := obj.(RootedObject)
+	if !ok {
+		return false
+	}
+	h.insertChunk(rooted.HashTreeRootSSZ(), 0)
+	return true
 }
 
-// HashSliceOfDynamicObjects hashes a dynamic slice of dynamic ssz objects.
-func HashSliceOfDynamicObjects[T DynamicObject](h *Hasher, objects []T, maxItems uint64) {
-	h.descendMixinLayer()
-	for _, obj := range objects {
-		h.descendLayer()
-		obj.DefineSSZ(h.codec)
-		h.ascendLayer(0)
+// checkItems is a helper to detect dynamic lists longer than their capacity, which
+// would otherwise be silently merkleized into a non-spec root.
+func (h *Hasher) checkItems(items int, maxItems uint64) {
+	if h.broken == nil && uint64(items) > maxItems {
+		h.broken = fmt.Errorf("%w: hashing %d, max %d", ErrMaxItemsExceeded, items, maxItems)
 	}
-	h.ascendMixinLayer(uint64(len(objects)), maxItems)
 }
 
-// HashSliceOfDynamicObjectsOnFork hashes a dynamic slice of dynamic ssz objects
-// if present in a fork.
-func HashSliceOfDynamicObjectsOnFork[T DynamicObject](h *Hasher, objects []T, maxItems uint64, filter ForkFilter) {
-	// If the field is not active in the current fork, early return
-	if h.codec.fork < filter.Added || (filter.Removed > ForkUnknown && h.codec.fork >= filter.Removed) {
-		return
+// checkBytes is a helper to detect dynamic blobs longer than their capacity, which
+// would otherwise be silently merkleized into a non-spec root.
+func (h *Hasher) checkBytes(size int, maxSize uint64) {
+	if h.broken == nil && uint64(size) > maxSize {
+		h.broken = fmt.Errorf("%w: hashing %d, max %d", ErrMaxLengthExceeded, size, maxSize)
+	}
+}
+
+// checkBlobs is a helper to detect a batch of dynamic blobs with any of them being
+// longer than their capacity.
+func (h *Hasher) checkBlobs(blobs [][]byte, maxSize uint64) {
+	for _, blob := range blobs {
+		h.checkBytes(len(blob), maxSize)
 	}
-	// Otherwise fall back to the standard hasher
-	HashSliceOfDynamicObjects(h, objects, maxItems)
 }
 
 // hashBytes either appends the blob to the hasher's scratch space if it's small
@@ -693,6 +1569,16 @@ func (h *Hasher) hashBytesEmpty(size int) {
 func (h *Hasher) insertChunk(chunk [32]byte, depth int) {
 	// Insert the chunk into the accumulator
 	h.chunks = append(h.chunks, chunk)
+	if h.prover != nil {
+		h.prover.track(depth, h.chunks[len(h.chunks)-1:])
+	}
+	// Every top level field contributes exactly one leaf to the outermost layer
+	if (h.collect || h.flat) && h.layer == 1 && depth == 0 {
+		h.fields = append(h.fields, chunk)
+		if h.flat {
+			return // merkleized by the caller across multiple objects
+		}
+	}
 
 	// If the depth tracker is at the leaf level, bump the leaf count
 	groups := len(h.groups)
@@ -718,7 +1604,10 @@ func (h *Hasher) insertChunk(chunk [32]byte, depth int) {
 		// them one by one, so can't all of a sudden overshoot. Hash the next batch
 		// of chunks and update the trackers.
 		chunks := len(h.chunks)
-		gohashtree.HashChunks(h.chunks[chunks-hasherBatch:], h.chunks[chunks-hasherBatch:])
+		h.hashChunks(h.chunks[chunks-hasherBatch:], h.chunks[chunks-hasherBatch:])
+		if h.prover != nil {
+			h.prover.track(group.depth+1, h.chunks[chunks-hasherBatch:chunks-hasherBatch/2])
+		}
 		h.chunks = h.chunks[:chunks-hasherBatch/2]
 
 		group.depth++
@@ -746,6 +1635,39 @@ func (h *Hasher) insertChunk(chunk [32]byte, depth int) {
 	}
 }
 
+// insertChunksBulk adds a run of consecutive chunks to the accumulators. If the
+// current layer is still empty, full sub-tries are hashed directly from the input
+// in large batches and only their roots are inserted, the leftovers one by one.
+func (h *Hasher) insertChunksBulk(chunks [][32]byte) {
+	// Bulk hashing needs the sub-tries aligned to the start of the layer and the
+	// intermediate nodes are not tracked, so it cannot be used for proofs
+	groups := len(h.groups)
+	if h.prover == nil && (groups == 0 || h.groups[groups-1].layer != h.layer) {
+		var scratch [1 << (hasherBulkDepth - 1)][32]byte
+		for len(chunks) >= 1<<hasherBulkDepth {
+			h.hashChunks(scratch[:], chunks[:1<<hasherBulkDepth])
+			for n := len(scratch); n > 1; n >>= 1 {
+				h.hashChunks(scratch[:n/2], scratch[:n])
+			}
+			h.insertChunk(scratch[0], hasherBulkDepth)
+			chunks = chunks[1<<hasherBulkDepth:]
+		}
+	}
+	for i := range chunks {
+		h.insertChunk(chunks[i], 0)
+	}
+}
+
+// hashTries merkleizes consecutive, zero padded tries of the given (power of 2)
+// width in place, hashing each level of all of them in a single batch. The roots
+// end up in the first len(leaves)/width slots.
+func (h *Hasher) hashTries(leaves [][32]byte, width int) {
+	for n := len(leaves); width > 1; width >>= 1 {
+		h.hashChunks(leaves[:n/2], leaves[:n])
+		n >>= 1
+	}
+}
+
 // insertBlobChunks splits up the blob into 32 byte chunks and adds them to the
 // accumulators, collapsing matching pairs.
 func (h *Hasher) insertBlobChunks(blob []byte) {
@@ -762,6 +1684,22 @@ func (h *Hasher) insertBlobChunks(blob []byte) {
 	}
 }
 
+// insertStringChunks is analogous to insertBlobChunks, but operates on a string
+// to avoid having to convert (copy) it into a byte slice first.
+func (h *Hasher) insertStringChunks(str string) {
+	var buffer [32]byte
+	for len(str) >= 32 {
+		copy(buffer[:], str)
+		h.insertChunk(buffer, 0)
+		str = str[32:]
+	}
+	if len(str) > 0 {
+		buffer = [32]byte{}
+		copy(buffer[:], str)
+		h.insertChunk(buffer, 0)
+	}
+}
+
 // insertBlobChunksEmpty is analogous to insertBlobChunks, but where the input
 // is all zeroes, so it's passed by length, not by content. This allows hashing
 // zero pointers without allocating them first.
@@ -772,22 +1710,72 @@ func (h *Hasher) insertBlobChunksEmpty(size int) {
 	}
 }
 
+// HashMapOfStaticEntries hashes a map as a dynamic slice of static ssz key/value
+// containers, sorted by key.
+func HashMapOfStaticEntries[T newableStaticMapEntry[K, V, U], U any, K comparable, V any](h *Hasher, m map[K]V, maxItems uint64) {
+	HashSliceOfStaticObjects(h, mapToEntries[T](h.codec, m), maxItems)
+}
+
+// HashMapOfStaticEntriesOnFork hashes a map as a dynamic slice of static ssz
+// key/value containers, sorted by key, if present in a fork.
+func HashMapOfStaticEntriesOnFork[T newableStaticMapEntry[K, V, U], U any, K comparable, V any](h *Hasher, m map[K]V, maxItems uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(h.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard hasher
+	HashMapOfStaticEntries[T](h, m, maxItems)
+}
+
+// HashMapOfDynamicEntries hashes a map as a dynamic slice of dynamic ssz key/value
+// containers, sorted by key.
+func HashMapOfDynamicEntries[T newableDynamicMapEntry[K, V, U], U any, K comparable, V any](h *Hasher, m map[K]V, maxItems uint64) {
+	HashSliceOfDynamicObjects(h, mapToEntries[T](h.codec, m), maxItems)
+}
+
+// HashMapOfDynamicEntriesOnFork hashes a map as a dynamic slice of dynamic ssz
+// key/value containers, sorted by key, if present in a fork.
+func HashMapOfDynamicEntriesOnFork[T newableDynamicMapEntry[K, V, U], U any, K comparable, V any](h *Hasher, m map[K]V, maxItems uint64, filter ForkFilter) {
+	// If the field is not active in the current fork, early return
+	if !filter.Active(h.codec.fork) {
+		return
+	}
+	// Otherwise fall back to the standard hasher
+	HashMapOfDynamicEntries[T](h, m, maxItems)
+}
+
 // descendLayer starts a new hashing layer, acting as a barrier to prevent the
 // chunks from being collapsed into previous pending ones.
 func (h *Hasher) descendLayer() {
 	h.layer++
+	if h.prover != nil {
+		h.prover.descend()
+	}
 }
 
 // descendMixinLayer is similar to descendLayer, but actually descends two at the
 // same time, using the outer for mixing in a list length during ascent.
 func (h *Hasher) descendMixinLayer() {
 	h.layer += 2
+	if h.prover != nil {
+		h.prover.descend()
+		h.prover.descend()
+	}
 }
 
 // ascendLayer terminates a hashing layer, moving the result up one level and
 // collapsing anything unblocked. The capacity param controls how many chunks
 // a dynamic list is expected to be composed of at maximum (0 == only balance).
 func (h *Hasher) ascendLayer(capacity uint64) {
+	// If nothing was hashed in this layer (e.g. a DefineSSZ without fields), the
+	// accounting is broken. Record the failure and substitute a zero chunk to be
+	// able to keep going until the error can be reported.
+	if groups := len(h.groups); groups == 0 || h.groups[groups-1].layer != h.layer {
+		if h.broken == nil {
+			h.broken = fmt.Errorf("%w: no chunks hashed in layer %d, pending groups %v", ErrUnbalancedHashing, h.layer, h.groups)
+		}
+		h.insertChunk([32]byte{}, 0)
+	}
 	// Before even considering extending the layer to capacity, balance any
 	// partial sub-tries to their completion.
 	h.balanceLayer()
@@ -804,14 +1792,23 @@ func (h *Hasher) ascendLayer(capacity uint64) {
 			break
 		}
 		// Last group requires expansion, hash in a new empty sibling trie
-		h.chunks = append(h.chunks, hasherZeroCache[group.depth])
+		h.chunks = append(h.chunks, h.zeroHash(group.depth))
 
 		chunks := len(h.chunks)
-		gohashtree.HashChunks(h.chunks[chunks-2:], h.chunks[chunks-2:])
+		if h.prover != nil {
+			h.prover.track(group.depth, h.chunks[chunks-1:])
+		}
+		h.hashChunks(h.chunks[chunks-2:], h.chunks[chunks-2:])
+		if h.prover != nil {
+			h.prover.track(group.depth+1, h.chunks[chunks-2:chunks-1])
+		}
 		h.chunks = h.chunks[:chunks-1]
 
 		h.groups[groups-1].depth++
 	}
+	if h.prover != nil {
+		h.prover.ascend(h, h.groups[len(h.groups)-1].depth)
+	}
 	// Ascend from the previous hashing layer
 	h.layer--
 
@@ -847,11 +1844,18 @@ func (h *Hasher) balanceLayer() {
 		// the previous one and then see.
 		if group.chunks&0x1 == 1 {
 			// Group unbalanced, expand with a zero sub-trie
-			h.chunks = append(h.chunks, hasherZeroCache[group.depth])
+			h.chunks = append(h.chunks, h.zeroHash(group.depth))
 			group.chunks++
+
+			if h.prover != nil {
+				h.prover.track(group.depth, h.chunks[len(h.chunks)-1:])
+			}
 		}
 		chunks := len(h.chunks)
-		gohashtree.HashChunks(h.chunks[chunks-int(group.chunks):], h.chunks[chunks-int(group.chunks):])
+		h.hashChunks(h.chunks[chunks-int(group.chunks):], h.chunks[chunks-int(group.chunks):])
+		if h.prover != nil {
+			h.prover.track(group.depth+1, h.chunks[chunks-int(group.chunks):chunks-int(group.chunks)>>1])
+		}
 		h.chunks = h.chunks[:chunks-int(group.chunks)>>1]
 
 		group.depth++
@@ -892,9 +1896,80 @@ func (h *Hasher) ascendMixinLayer(size uint64, chunks uint64) {
 	h.ascendLayer(0) // length mixin
 }
 
+// hashChunks hashes consecutive pairs of chunks into the digests, either via the
+// default gohashtree sha256 implementation, or the custom hash backend.
+func (h *Hasher) hashChunks(digests [][32]byte, chunks [][32]byte) {
+	if h.backend == nil {
+		gohashtree.HashChunks(digests, chunks)
+		return
+	}
+	if err := h.backend.HashChunks(digests[:len(chunks)/2], chunks); err != nil {
+		h.fail(fmt.Errorf("%w: %w", ErrHasherBackendFailed, err))
+	}
+}
+
+// fail records the first failure encountered while hashing, which aborts the
+// hashing with an error (or panic) when the root is retrieved.
+func (h *Hasher) fail(err error) {
+	if h.broken == nil {
+		h.broken = err
+	}
+}
+
+// insertZeroRoot inserts the merkle root of a nil object's zero value, recording
+// the failure if it could not be computed (e.g. broken custom backend).
+func (h *Hasher) insertZeroRoot(root [32]byte, err error) {
+	if err != nil {
+		h.fail(err)
+	}
+	h.insertChunk(root, 0)
+}
+
+// zeroHash retrieves the hash of an all-zero sub-trie of the given depth, using
+// the hash backend of the hasher.
+func (h *Hasher) zeroHash(depth int) [32]byte {
+	if h.zeroes == nil {
+		return hasherZeroCache[depth]
+	}
+	return h.zeroes[depth]
+}
+
+// checkBalanced verifies that the hasher is at the expected layer and that all
+// the pending chunk groups belong to it, returning an error describing what was
+// left open otherwise (e.g. by a DefineSSZ with mismatched hashing calls).
+func (h *Hasher) checkBalanced(layer int) error {
+	if h.broken != nil {
+		return h.broken
+	}
+	if h.layer != layer {
+		return fmt.Errorf("%w: at layer %d instead of %d, pending groups %v", ErrUnbalancedHashing, h.layer, layer, h.groups)
+	}
+	for _, group := range h.groups {
+		if group.layer != layer {
+			return fmt.Errorf("%w: group %v left open in layer %d, pending groups %v", ErrUnbalancedHashing, group, layer, h.groups)
+		}
+	}
+	if layer == 0 && len(h.chunks) != 1 {
+		return fmt.Errorf("%w: %d chunks left unmerged, pending groups %v", ErrUnbalancedHashing, len(h.chunks), h.groups)
+	}
+	return nil
+}
+
 // Reset resets the Hasher obj
 func (h *Hasher) Reset() {
 	h.chunks = h.chunks[:0]
 	h.groups = h.groups[:0]
+	h.layer = 0
+	h.broken = nil
 	h.threads = false
+	h.done = nil
+	h.backend = nil
+	h.zeroes = nil
+	h.prover = nil
+	h.collect = false
+	h.flat = false
+	h.fields = nil
+	if h.codec != nil {
+		h.codec.releaseEntries()
+	}
 }
@@ -0,0 +1,38 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package extension is the bridge through which the public codecext package can
+// reach into the internals of the ssz package, without the latter having to
+// export them in its own API.
+//
+// The hooks are wired up by the ssz package on initialization. The encoders,
+// decoders and hashers are passed as *ssz.Encoder, *ssz.Decoder and *ssz.Hasher
+// respectively, typed as any to avoid an import cycle.
+package extension
+
+var (
+	EncoderField   func(enc any)              // Accounts for a new field being encoded
+	EncoderChecked func(enc any) bool         // Whether size limits need validating
+	EncoderWrite   func(enc any, blob []byte) // Writes raw data into the output
+	EncoderOffset  func(enc any, size uint32) // Writes an offset for dynamic data
+	EncoderFail    func(enc any, err error)   // Fails the encoding, unless already failed
+	EncoderErr     func(enc any) error        // Retrieves the encoding failure, if any
+
+	DecoderField   func(dec any) bool           // Accounts for a new field being decoded
+	DecoderRead    func(dec any, blob []byte)   // Reads raw data from the input
+	DecoderOffset  func(dec any)                // Reads an offset for dynamic data
+	DecoderSize    func(dec any) uint32         // Retrieves the size of the next dynamic data
+	DecoderDescend func(dec any, length uint32) // Starts decoding a sub-slot of the given length
+	DecoderAscend  func(dec any)                // Finishes decoding a sub-slot
+	DecoderCharge  func(dec any, n uint64) bool // Charges an allocation against the budget
+	DecoderFail    func(dec any, err error)     // Fails the decoding, unless already failed
+	DecoderErr     func(dec any) error          // Retrieves the decoding failure, if any
+
+	HasherField   func(h any) bool                   // Accounts for a new field being hashed
+	HasherChunk   func(h any, chunk [32]byte)        // Inserts a leaf chunk into the current layer
+	HasherBlob    func(h any, blob []byte)           // Inserts a blob as leaf chunks into the current layer
+	HasherDescend func(h any, mixin bool)            // Starts a new (optionally length mixed) layer
+	HasherAscend  func(h any, capacity uint64)       // Terminates a plain layer
+	HasherMixin   func(h any, size, capacity uint64) // Terminates a length mixed layer
+)
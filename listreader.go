@@ -0,0 +1,151 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ListReader iterates over the items of an ssz encoded list of objects (e.g. a
+// dump of all the blocks of an epoch) read from a stream, decoding them one at a
+// time instead of loading the entire list into memory.
+//
+// The stream is expected to contain the encoding of a list of the object type,
+// without any limit: static objects concatenated back to back, dynamic objects
+// preceded by a table of their offsets.
+type ListReader[T newableObject[U], U any] struct {
+	r    *bufio.Reader // Buffered input stream to read the items from
+	fork Fork          // Fork to decode the items in
+
+	static bool   // Whether the items are static objects
+	size   uint32 // Size of the static items (static lists only)
+
+	started bool     // Whether the offsets table was already read (dynamic lists only)
+	sizes   []uint32 // Sizes of the remaining items, but the last (dynamic lists only)
+	last    bool     // Whether the last item (sized to the end) is pending (dynamic lists only)
+
+	err error // Sticky failure (or io.EOF) terminating the iteration
+}
+
+// NewListReader creates an iterator over the items of an ssz encoded list of
+// objects read from a stream. If the type does not contain fork-specific rules,
+// use ForkUnknown.
+func NewListReader[T newableObject[U], U any](r io.Reader, fork Fork) *ListReader[T, U] {
+	lr := &ListReader[T, U]{
+		fork: fork,
+	}
+	if ahead := int(StreamReadAhead); ahead > 0 {
+		lr.r = bufio.NewReaderSize(r, ahead)
+	} else {
+		lr.r = bufio.NewReaderSize(r, 16) // bufio minimum, needed for peeking
+	}
+	if obj, ok := any(T(new(U))).(StaticObject); ok {
+		lr.static = true
+		lr.size = SizeOnFork(obj, fork)
+	}
+	return lr
+}
+
+// Next decodes the next item from the list, returning io.EOF once all items were
+// consumed. Each item is decoded into a freshly allocated object, so it may be
+// retained by the caller. Any failure terminates the iteration.
+func (lr *ListReader[T, U]) Next() (T, error) {
+	if lr.err != nil {
+		return nil, lr.err
+	}
+	obj, err := lr.next()
+	if err != nil {
+		lr.err = err
+		return nil, err
+	}
+	return obj, nil
+}
+
+// next is the internal implementation of Next, without the sticky failures.
+func (lr *ListReader[T, U]) next() (T, error) {
+	// Static items are simply concatenated, decode until the stream runs dry
+	if lr.static {
+		if _, err := lr.r.Peek(1); err != nil {
+			return nil, err
+		}
+		obj := T(new(U))
+		if err := DecodeFromStreamOnFork(lr.r, obj, lr.size, lr.fork); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	}
+	// Dynamic items are preceded by their offsets, read them on the first call
+	if !lr.started {
+		lr.started = true
+		if err := lr.readOffsets(); err != nil {
+			return nil, err
+		}
+	}
+	obj := T(new(U))
+	switch {
+	case len(lr.sizes) > 0:
+		size := lr.sizes[0]
+		lr.sizes = lr.sizes[1:]
+		if err := DecodeFromStreamOnFork(lr.r, obj, size, lr.fork); err != nil {
+			return nil, err
+		}
+	case lr.last:
+		// The last item extends until the end of the stream, so its size is not
+		// known upfront, it needs to be read wholesale
+		lr.last = false
+		blob, err := io.ReadAll(lr.r)
+		if err != nil {
+			return nil, err
+		}
+		if err := DecodeFromBytesOnFork(blob, obj, lr.fork); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, io.EOF
+	}
+	return obj, nil
+}
+
+// readOffsets reads and validates the offsets table of a list of dynamic items,
+// converting them into item sizes.
+func (lr *ListReader[T, U]) readOffsets() error {
+	var buf [4]byte
+	if _, err := io.ReadFull(lr.r, buf[:]); err != nil {
+		if errors.Is(err, io.EOF) {
+			return io.EOF // empty list
+		}
+		return err
+	}
+	first := binary.LittleEndian.Uint32(buf[:])
+	if first == 0 {
+		return ErrZeroCounterOffset
+	}
+	if first&3 != 0 {
+		return fmt.Errorf("%w: %d bytes", ErrBadCounterOffset, first)
+	}
+	// Read the remainder of the offsets one by one, so a bogus counter cannot
+	// trigger an allocation beyond what the stream actually contains
+	prev := first
+	for i := uint32(1); i < first>>2; i++ {
+		if _, err := io.ReadFull(lr.r, buf[:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				return io.ErrUnexpectedEOF
+			}
+			return err
+		}
+		offset := binary.LittleEndian.Uint32(buf[:])
+		if offset < prev {
+			return fmt.Errorf("%w: decoded %d, previous was %d", ErrBadOffsetProgression, offset, prev)
+		}
+		lr.sizes = append(lr.sizes, offset-prev)
+		prev = offset
+	}
+	lr.last = true
+	return nil
+}
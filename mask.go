@@ -0,0 +1,150 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// FieldMask is a set of top level field names to decode from an object, with all
+// the other fields being seeked past without decoding them.
+type FieldMask []string
+
+// Special skip sizes in a field masker for fields that need to be decoded and for
+// the content of dynamic fields whose size is only known from the offsets.
+const (
+	maskDecode  = -1
+	maskContent = -2
+)
+
+// fieldMasker is the plan of a masked decoding: how many bytes to seek past for
+// each field definition of the top level object.
+type fieldMasker struct {
+	obj   Object // Top level object whose fields are masked
+	skips []int  // Bytes to skip per field definition (or maskDecode/maskContent)
+}
+
+// fieldLayout is the position of a single field definition within an object's
+// encoding, independent of the contents of the object.
+type fieldLayout struct {
+	name    string // Name of the field the definition belongs to
+	size    int    // Encoded size of the definition if static
+	offset  bool   // Whether the definition is the offset of a dynamic field
+	content bool   // Whether the definition is the content of a dynamic field
+}
+
+// fieldLayoutKey is the key of the field layout cache.
+type fieldLayoutKey struct {
+	typ  reflect.Type
+	fork Fork
+}
+
+// fieldLayoutCache is a cache of the field layouts of types per fork.
+var fieldLayoutCache sync.Map // map[fieldLayoutKey][]fieldLayout
+
+// DecodeFields parses the fields selected by a mask of a non-monolithic object
+// from a byte buffer. If the type contains fork-specific rules, use
+// DecodeFieldsOnFork.
+func DecodeFields(blob []byte, obj Object, mask FieldMask) error {
+	return DecodeFieldsOnFork(blob, obj, ForkUnknown, mask)
+}
+
+// DecodeFieldsOnFork parses the fields selected by a mask of a monolithic object
+// from a byte buffer. The fields not in the mask are seeked past using offset
+// arithmetic without decoding them, leaving them untouched in obj (i.e. zero if
+// a fresh object is used). This permits serving only a few fields out of large
+// objects (e.g. validators and balances from a beacon state) cheaply.
+//
+// Only the top level fields of the object can be masked, and the object needs
+// to implement NamedObject so they can be referenced. The offsets of all the
+// dynamic fields are still validated.
+func DecodeFieldsOnFork(blob []byte, obj Object, fork Fork, mask FieldMask) error {
+	masker, err := newFieldMasker(obj, fork, mask)
+	if err != nil {
+		return err
+	}
+	return decodeFromBytes(blob, obj, fork, LittleEndian, nil, nil, masker)
+}
+
+// newFieldMasker creates the plan of a masked decoding of an object's top level
+// fields in a fork, failing if the mask references fields unknown to the type.
+func newFieldMasker(obj Object, fork Fork, mask FieldMask) (*fieldMasker, error) {
+	layout, err := fieldLayoutOf(obj, fork)
+	if err != nil {
+		return nil, err
+	}
+	selected := make(map[string]bool, len(mask))
+	for _, name := range mask {
+		selected[name] = true
+	}
+	var (
+		masker = &fieldMasker{obj: obj, skips: make([]int, len(layout))}
+		known  = make(map[string]bool, len(layout))
+	)
+	for i, field := range layout {
+		known[field.name] = true
+
+		switch {
+		case selected[field.name]:
+			masker.skips[i] = maskDecode
+		case field.offset:
+			masker.skips[i] = maskDecode // needed for the content sizes
+		case field.content:
+			masker.skips[i] = maskContent
+		default:
+			masker.skips[i] = field.size
+		}
+	}
+	for _, name := range mask {
+		if !known[name] {
+			return nil, fmt.Errorf("%w: %T has no field %s", ErrUnknownField, obj, name)
+		}
+	}
+	return masker, nil
+}
+
+// fieldLayoutOf retrieves the layout of the top level field definitions of an
+// object's type in a fork, computing it by tracing the decoding of an empty
+// instance if not yet cached.
+func fieldLayoutOf(obj Object, fork Fork) ([]fieldLayout, error) {
+	if _, ok := obj.(NamedObject); !ok {
+		return nil, fmt.Errorf("%w: %T has no field names", ErrUnknownField, obj)
+	}
+	key := fieldLayoutKey{typ: reflect.TypeOf(obj), fork: fork}
+	if layout, ok := fieldLayoutCache.Load(key); ok {
+		return layout.([]fieldLayout), nil
+	}
+	// Static fields have the same size irrespective of their contents, dynamic
+	// ones are only an offset, so an empty instance has the same layout as any
+	fresh := newObjectOf(obj)
+
+	blob := make([]byte, SizeOnFork(fresh, fork))
+	if err := EncodeToBytesOnFork(blob, fresh, fork); err != nil {
+		return nil, err
+	}
+	trace := &dumpTracer{blob: blob}
+	if err := decodeFromBytes(blob, newObjectOf(obj), fork, LittleEndian, nil, trace, nil); err != nil {
+		return nil, err
+	}
+	var (
+		layout  = make([]fieldLayout, len(trace.root.fields))
+		offsets = make(map[string]bool)
+	)
+	for i, field := range trace.root.fields {
+		layout[i] = fieldLayout{
+			name:    field.name,
+			size:    field.end - field.start,
+			offset:  field.isOffset,
+			content: offsets[field.name],
+		}
+		if field.isOffset {
+			offsets[field.name] = true
+		}
+	}
+	fieldLayoutCache.Store(key, layout)
+	return layout, nil
+}
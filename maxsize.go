@@ -0,0 +1,153 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+
+	"github.com/golang/snappy"
+)
+
+// maxSizeKey is the key of the registered maximum object sizes.
+type maxSizeKey struct {
+	typ  reflect.Type
+	fork Fork
+}
+
+// maxSizes is the table of maximum encoded sizes registered per type and fork.
+var maxSizes sync.Map // map[maxSizeKey]uint64
+
+// RegisterMaxSize sets the maximum encoded size accepted by the checked decoders
+// for objects of type T in a fork (e.g. the gossip limits of the consensus specs).
+// This allows keeping the DoS protection constants next to the types instead of
+// scattering them across the code receiving data from the network.
+//
+// A size registered for ForkUnknown acts as the default for any fork without a
+// specific one (and is the one to use for non-monolithic types).
+func RegisterMaxSize[T Object](fork Fork, bytes uint64) {
+	maxSizes.Store(maxSizeKey{typ: reflect.TypeFor[T](), fork: fork}, bytes)
+}
+
+// UnregisterMaxSize removes the maximum encoded size registered for objects of
+// type T in a fork, if any.
+func UnregisterMaxSize[T Object](fork Fork) {
+	maxSizes.Delete(maxSizeKey{typ: reflect.TypeFor[T](), fork: fork})
+}
+
+// MaxSize retrieves the maximum encoded size registered for objects of type T in
+// a fork, falling back to the default one registered for ForkUnknown.
+func MaxSize[T Object](fork Fork) (uint64, bool) {
+	return maxSizeOf(reflect.TypeFor[T](), fork)
+}
+
+// maxSizeOf retrieves the maximum encoded size registered for a type in a fork,
+// falling back to the default one registered for ForkUnknown.
+func maxSizeOf(typ reflect.Type, fork Fork) (uint64, bool) {
+	if size, ok := maxSizes.Load(maxSizeKey{typ: typ, fork: fork}); ok {
+		return size.(uint64), true
+	}
+	if size, ok := maxSizes.Load(maxSizeKey{typ: typ, fork: ForkUnknown}); ok {
+		return size.(uint64), true
+	}
+	return 0, false
+}
+
+// checkMaxSize verifies that an encoded size is within the maximum registered
+// for the type of an object in a fork.
+func checkMaxSize(obj Object, size uint64, fork Fork) error {
+	limit, ok := maxSizeOf(reflect.TypeOf(obj), fork)
+	if !ok {
+		return fmt.Errorf("%w: %T, fork %s", ErrMaxSizeNotRegistered, obj, forkName(fork))
+	}
+	if size > limit {
+		return fmt.Errorf("%w: %T: size %d, max %d", ErrMaxObjectSizeExceeded, obj, size, limit)
+	}
+	return nil
+}
+
+// DecodeFromBytesChecked parses a non-monolithic object from a byte buffer,
+// rejecting it if it's larger than the maximum size registered for its type. If
+// the type contains fork-specific rules, use DecodeFromBytesCheckedOnFork.
+func DecodeFromBytesChecked(blob []byte, obj Object) error {
+	return DecodeFromBytesCheckedOnFork(blob, obj, ForkUnknown)
+}
+
+// DecodeFromBytesCheckedOnFork parses a monolithic object from a byte buffer,
+// rejecting it if it's larger than the maximum size registered for its type and
+// fork. If the type does not contain fork-specific rules, you can also use
+// DecodeFromBytesChecked.
+func DecodeFromBytesCheckedOnFork(blob []byte, obj Object, fork Fork) error {
+	if err := checkMaxSize(obj, uint64(len(blob)), fork); err != nil {
+		return err
+	}
+	return DecodeFromBytesOnFork(blob, obj, fork)
+}
+
+// DecodeFromStreamChecked parses a non-monolithic object with the given size out
+// of a stream, rejecting it before reading anything if it's larger than the
+// maximum size registered for its type. If the type contains fork-specific rules,
+// use DecodeFromStreamCheckedOnFork.
+func DecodeFromStreamChecked(r io.Reader, obj Object, size uint32) error {
+	return DecodeFromStreamCheckedOnFork(r, obj, size, ForkUnknown)
+}
+
+// DecodeFromStreamCheckedOnFork parses a monolithic object with the given size
+// out of a stream, rejecting it before reading anything if it's larger than the
+// maximum size registered for its type and fork. If the type does not contain
+// fork-specific rules, you can also use DecodeFromStreamChecked.
+func DecodeFromStreamCheckedOnFork(r io.Reader, obj Object, size uint32, fork Fork) error {
+	if err := checkMaxSize(obj, uint64(size), fork); err != nil {
+		return err
+	}
+	return DecodeFromStreamOnFork(r, obj, size, fork)
+}
+
+// DecodeFromSnappyStreamChecked parses a non-monolithic object with the given
+// (not compressed) size out of a snappy framed stream, rejecting it before
+// decompressing anything if it's larger than the maximum size registered for its
+// type. If the type contains fork-specific rules, use
+// DecodeFromSnappyStreamCheckedOnFork.
+func DecodeFromSnappyStreamChecked(r io.Reader, obj Object, size uint32) error {
+	return DecodeFromSnappyStreamCheckedOnFork(r, obj, size, ForkUnknown)
+}
+
+// DecodeFromSnappyStreamCheckedOnFork parses a monolithic object with the given
+// (not compressed) size out of a snappy framed stream, rejecting it before
+// decompressing anything if it's larger than the maximum size registered for its
+// type and fork. If the type does not contain fork-specific rules, you can also
+// use DecodeFromSnappyStreamChecked.
+func DecodeFromSnappyStreamCheckedOnFork(r io.Reader, obj Object, size uint32, fork Fork) error {
+	if err := checkMaxSize(obj, uint64(size), fork); err != nil {
+		return err
+	}
+	return DecodeFromSnappyStreamOnFork(r, obj, size, fork)
+}
+
+// DecodeFromSnappyBytesChecked parses a non-monolithic object from a snappy block
+// compressed byte buffer, rejecting it before inflating it if the decompressed
+// length is larger than the maximum size registered for its type. If the type
+// contains fork-specific rules, use DecodeFromSnappyBytesCheckedOnFork.
+func DecodeFromSnappyBytesChecked(blob []byte, obj Object) error {
+	return DecodeFromSnappyBytesCheckedOnFork(blob, obj, ForkUnknown)
+}
+
+// DecodeFromSnappyBytesCheckedOnFork parses a monolithic object from a snappy
+// block compressed byte buffer, rejecting it before inflating it if the
+// decompressed length is larger than the maximum size registered for its type
+// and fork. If the type does not contain fork-specific rules, you can also use
+// DecodeFromSnappyBytesChecked.
+func DecodeFromSnappyBytesCheckedOnFork(blob []byte, obj Object, fork Fork) error {
+	size, err := snappy.DecodedLen(blob)
+	if err != nil {
+		return err
+	}
+	if err := checkMaxSize(obj, uint64(size), fork); err != nil {
+		return err
+	}
+	return DecodeFromSnappyBytesOnFork(blob, obj, fork)
+}
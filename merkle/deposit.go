@@ -0,0 +1,383 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package merkle implements the EIP-4881 incremental deposit merkle tree.
+package merkle
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/karalabe/ssz"
+	"github.com/prysmaticlabs/gohashtree"
+)
+
+// DepositContractDepth is the depth of the deposit contract's merkle tree, not
+// counting the length mixin.
+const DepositContractDepth = 32
+
+// ErrTreeFull is returned when attempting to push a leaf into a deposit tree
+// that has no more free slots.
+var ErrTreeFull = errors.New("merkle: deposit tree full")
+
+// ErrFinalizedLeaf is returned when attempting to push a leaf into, or generate
+// a proof from a finalized subtree.
+var ErrFinalizedLeaf = errors.New("merkle: leaf already finalized")
+
+// ErrLeafNotFound is returned when attempting to generate a proof for a leaf
+// that does not exist in the deposit tree.
+var ErrLeafNotFound = errors.New("merkle: leaf not found")
+
+// ErrFinalizeBeyondCount is returned when attempting to finalize more deposits
+// than were pushed into the deposit tree.
+var ErrFinalizeBeyondCount = errors.New("merkle: finalizing beyond deposit count")
+
+// ErrNotFinalized is returned when attempting to take a snapshot of a deposit
+// tree that has never been finalized.
+var ErrNotFinalized = errors.New("merkle: deposit tree not finalized")
+
+// ErrInvalidSnapshot is returned when attempting to restore a deposit tree from
+// a snapshot, which is inconsistent with itself.
+var ErrInvalidSnapshot = errors.New("merkle: invalid deposit tree snapshot")
+
+// DepositTreeSnapshot is the minimal representation of a finalized deposit tree
+// as defined by EIP-4881. It is an ssz object itself, so it can be persisted or
+// transmitted via the usual encoders.
+type DepositTreeSnapshot struct {
+	Finalized            [][32]byte // Roots of the finalized subtrees, left to right
+	DepositRoot          [32]byte   // Root of the deposit tree (with the length mixin)
+	DepositCount         uint64     // Number of deposits in the finalized subtrees
+	ExecutionBlockHash   [32]byte   // Hash of the execution block of the last finalized deposit
+	ExecutionBlockHeight uint64     // Height of the execution block of the last finalized deposit
+}
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (s *DepositTreeSnapshot) SizeSSZ(sizer *ssz.Sizer, fixed bool) uint32 {
+	size := uint32(4 + 32 + 8 + 32 + 8)
+	if fixed {
+		return size
+	}
+	size += ssz.SizeSliceOfStaticBytes(sizer, s.Finalized)
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (s *DepositTreeSnapshot) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineSliceOfStaticBytesOffset(codec, &s.Finalized, DepositContractDepth)
+	ssz.DefineStaticBytes(codec, &s.DepositRoot)
+	ssz.DefineUint64(codec, &s.DepositCount)
+	ssz.DefineStaticBytes(codec, &s.ExecutionBlockHash)
+	ssz.DefineUint64(codec, &s.ExecutionBlockHeight)
+
+	ssz.DefineSliceOfStaticBytesContent(codec, &s.Finalized, DepositContractDepth)
+}
+
+// DepositTree is an incremental merkle tree of deposits, which can be pruned of
+// finalized subtrees, only retaining what is needed to continue extending it.
+type DepositTree struct {
+	tree  node   // Root node of the deposit tree (without length mixin)
+	count uint64 // Number of deposits pushed into the tree
+
+	finalized       bool     // Whether the tree was ever finalized
+	finalizedHash   [32]byte // Hash of the execution block of the last finalized deposit
+	finalizedHeight uint64   // Height of the execution block of the last finalized deposit
+}
+
+// NewDepositTree creates an empty deposit tree.
+func NewDepositTree() *DepositTree {
+	return &DepositTree{
+		tree: &zeroNode{depth: DepositContractDepth},
+	}
+}
+
+// NewDepositTreeFromSnapshot recreates a finalized deposit tree from a snapshot.
+func NewDepositTreeFromSnapshot(snapshot *DepositTreeSnapshot) (*DepositTree, error) {
+	tree, err := nodeFromSnapshot(snapshot.Finalized, snapshot.DepositCount, DepositContractDepth)
+	if err != nil {
+		return nil, err
+	}
+	t := &DepositTree{
+		tree:            tree,
+		count:           snapshot.DepositCount,
+		finalized:       true,
+		finalizedHash:   snapshot.ExecutionBlockHash,
+		finalizedHeight: snapshot.ExecutionBlockHeight,
+	}
+	if t.Root() != snapshot.DepositRoot {
+		return nil, ErrInvalidSnapshot
+	}
+	return t, nil
+}
+
+// Count returns the number of deposits pushed into the tree.
+func (t *DepositTree) Count() uint64 {
+	return t.count
+}
+
+// Root returns the deposit root, which is the root of the merkle tree with the
+// number of deposits mixed in.
+func (t *DepositTree) Root() [32]byte {
+	return mixinLength(t.tree.root(), t.count)
+}
+
+// PushLeaf appends a new deposit leaf into the tree.
+func (t *DepositTree) PushLeaf(leaf [32]byte) error {
+	if t.tree.full() {
+		return ErrTreeFull
+	}
+	tree, err := t.tree.push(leaf, DepositContractDepth)
+	if err != nil {
+		return err
+	}
+	t.tree = tree
+	t.count++
+	return nil
+}
+
+// Finalize prunes the first count deposits from the tree, replacing them with
+// the roots of their subtrees. The execution block containing the last of the
+// finalized deposits is tracked to be included in snapshots.
+func (t *DepositTree) Finalize(count uint64, blockHash [32]byte, blockHeight uint64) error {
+	// Finalizing unknown deposits would collapse partially filled subtrees
+	if count > t.count {
+		return fmt.Errorf("%w: finalizing %d, have %d", ErrFinalizeBeyondCount, count, t.count)
+	}
+	t.finalized = true
+	t.finalizedHash = blockHash
+	t.finalizedHeight = blockHeight
+
+	t.tree = t.tree.finalize(count, DepositContractDepth)
+	return nil
+}
+
+// Proof generates the merkle proof of a deposit leaf (including the length
+// mixin), returning the leaf and the sibling hashes from the bottom up.
+func (t *DepositTree) Proof(index uint64) ([32]byte, [][32]byte, error) {
+	if index >= t.count {
+		return [32]byte{}, nil, ErrLeafNotFound
+	}
+	leaf, proof, err := t.tree.proof(index, DepositContractDepth)
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+	var length [32]byte
+	binary.LittleEndian.PutUint64(length[:], t.count)
+
+	return leaf, append(proof, length), nil
+}
+
+// Snapshot creates the minimal representation of the finalized deposit tree,
+// from which it can be restored later.
+func (t *DepositTree) Snapshot() (*DepositTreeSnapshot, error) {
+	if !t.finalized {
+		return nil, ErrNotFinalized
+	}
+	var finalized [][32]byte
+	count := t.tree.finalized(&finalized)
+
+	// The deposit root needs to be the one at the finalized deposit count, so
+	// reconstruct the pruned tree to calculate it
+	tree, err := nodeFromSnapshot(finalized, count, DepositContractDepth)
+	if err != nil {
+		return nil, err
+	}
+	return &DepositTreeSnapshot{
+		Finalized:            finalized,
+		DepositRoot:          mixinLength(tree.root(), count),
+		DepositCount:         count,
+		ExecutionBlockHash:   t.finalizedHash,
+		ExecutionBlockHeight: t.finalizedHeight,
+	}, nil
+}
+
+// node is a single node of the deposit tree, which may be a partially filled
+// subtree, a full subtree, a finalized subtree or an empty one.
+type node interface {
+	root() [32]byte                                              // Merkle root of the subtree
+	full() bool                                                  // Whether there are free slots in the subtree
+	push(leaf [32]byte, depth int) (node, error)                 // Inserts a new leaf into the subtree
+	finalize(count uint64, depth int) node                       // Prunes the first count leaves of the subtree
+	finalized(roots *[][32]byte) uint64                          // Collects the finalized roots and leaf count
+	proof(index uint64, depth int) ([32]byte, [][32]byte, error) // Generates the proof of a leaf
+}
+
+// finalizedNode is a full subtree which was pruned, only retaining its root.
+type finalizedNode struct {
+	count uint64   // Number of leaves in the pruned subtree
+	hash  [32]byte // Merkle root of the pruned subtree
+}
+
+func (n *finalizedNode) root() [32]byte { return n.hash }
+func (n *finalizedNode) full() bool     { return true }
+
+func (n *finalizedNode) push(leaf [32]byte, depth int) (node, error) {
+	return nil, ErrFinalizedLeaf
+}
+
+func (n *finalizedNode) finalize(count uint64, depth int) node {
+	return n
+}
+
+func (n *finalizedNode) finalized(roots *[][32]byte) uint64 {
+	*roots = append(*roots, n.hash)
+	return n.count
+}
+
+func (n *finalizedNode) proof(index uint64, depth int) ([32]byte, [][32]byte, error) {
+	return [32]byte{}, nil, ErrFinalizedLeaf
+}
+
+// leafNode is a single deposit leaf in the tree.
+type leafNode struct {
+	hash [32]byte
+}
+
+func (n *leafNode) root() [32]byte { return n.hash }
+func (n *leafNode) full() bool     { return true }
+
+func (n *leafNode) push(leaf [32]byte, depth int) (node, error) {
+	return nil, ErrTreeFull
+}
+
+func (n *leafNode) finalize(count uint64, depth int) node {
+	return &finalizedNode{count: 1, hash: n.hash}
+}
+
+func (n *leafNode) finalized(roots *[][32]byte) uint64 {
+	return 0
+}
+
+func (n *leafNode) proof(index uint64, depth int) ([32]byte, [][32]byte, error) {
+	return n.hash, nil, nil
+}
+
+// innerNode is a non-empty subtree with two children.
+type innerNode struct {
+	left  node
+	right node
+}
+
+func (n *innerNode) root() [32]byte { return hashPair(n.left.root(), n.right.root()) }
+func (n *innerNode) full() bool     { return n.right.full() }
+
+func (n *innerNode) push(leaf [32]byte, depth int) (node, error) {
+	var err error
+	if !n.left.full() {
+		n.left, err = n.left.push(leaf, depth-1)
+	} else {
+		n.right, err = n.right.push(leaf, depth-1)
+	}
+	return n, err
+}
+
+func (n *innerNode) finalize(count uint64, depth int) node {
+	leaves := uint64(1) << depth
+	if leaves <= count {
+		return &finalizedNode{count: leaves, hash: n.root()}
+	}
+	n.left = n.left.finalize(count, depth-1)
+	if count > leaves/2 {
+		n.right = n.right.finalize(count-leaves/2, depth-1)
+	}
+	return n
+}
+
+func (n *innerNode) finalized(roots *[][32]byte) uint64 {
+	return n.left.finalized(roots) + n.right.finalized(roots)
+}
+
+func (n *innerNode) proof(index uint64, depth int) ([32]byte, [][32]byte, error) {
+	if (index>>(depth-1))&1 == 1 {
+		leaf, proof, err := n.right.proof(index, depth-1)
+		if err != nil {
+			return [32]byte{}, nil, err
+		}
+		return leaf, append(proof, n.left.root()), nil
+	}
+	leaf, proof, err := n.left.proof(index, depth-1)
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+	return leaf, append(proof, n.right.root()), nil
+}
+
+// zeroNode is an empty subtree of a given depth.
+type zeroNode struct {
+	depth int
+}
+
+func (n *zeroNode) root() [32]byte { return ssz.ZeroHash(n.depth) }
+func (n *zeroNode) full() bool     { return false }
+
+func (n *zeroNode) push(leaf [32]byte, depth int) (node, error) {
+	return newNode(leaf, depth), nil
+}
+
+func (n *zeroNode) finalize(count uint64, depth int) node {
+	return n
+}
+
+func (n *zeroNode) finalized(roots *[][32]byte) uint64 {
+	return 0
+}
+
+func (n *zeroNode) proof(index uint64, depth int) ([32]byte, [][32]byte, error) {
+	return [32]byte{}, nil, ErrLeafNotFound
+}
+
+// newNode creates a subtree of the given depth with a single leaf in it.
+func newNode(leaf [32]byte, depth int) node {
+	if depth == 0 {
+		return &leafNode{hash: leaf}
+	}
+	return &innerNode{
+		left:  newNode(leaf, depth-1),
+		right: &zeroNode{depth: depth - 1},
+	}
+}
+
+// nodeFromSnapshot recreates a subtree of the given depth from the roots of its
+// finalized subtrees and the number of leaves they cover.
+func nodeFromSnapshot(roots [][32]byte, count uint64, depth int) (node, error) {
+	if len(roots) == 0 || count == 0 {
+		return &zeroNode{depth: depth}, nil
+	}
+	if count == uint64(1)<<depth {
+		return &finalizedNode{count: count, hash: roots[0]}, nil
+	}
+	if depth == 0 || count > uint64(1)<<depth {
+		return nil, ErrInvalidSnapshot
+	}
+	half := uint64(1) << (depth - 1)
+	if count <= half {
+		left, err := nodeFromSnapshot(roots, count, depth-1)
+		if err != nil {
+			return nil, err
+		}
+		return &innerNode{left: left, right: &zeroNode{depth: depth - 1}}, nil
+	}
+	right, err := nodeFromSnapshot(roots[1:], count-half, depth-1)
+	if err != nil {
+		return nil, err
+	}
+	return &innerNode{left: &finalizedNode{count: half, hash: roots[0]}, right: right}, nil
+}
+
+// mixinLength computes the root of a list from the root of its items and their
+// count.
+func mixinLength(root [32]byte, count uint64) [32]byte {
+	var length [32]byte
+	binary.LittleEndian.PutUint64(length[:], count)
+
+	return hashPair(root, length)
+}
+
+// hashPair computes the merkle parent of two sibling hashes.
+func hashPair(left [32]byte, right [32]byte) [32]byte {
+	var digest [1][32]byte
+	gohashtree.HashChunks(digest[:], [][32]byte{left, right})
+	return digest[0]
+}
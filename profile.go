@@ -0,0 +1,220 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/holiman/uint256"
+)
+
+// ByteOrder is the byte order in which the basic integer types are serialized.
+type ByteOrder uint8
+
+const (
+	// LittleEndian is the byte order mandated by the SSZ specs.
+	LittleEndian ByteOrder = iota
+
+	// BigEndian is an alternative byte order used by some SSZ-derived protocols.
+	BigEndian
+)
+
+// Profile is a codec configuration for SSZ-derived protocols that deviate from
+// the standard encoding in some way. The zero value is the standard SSZ profile,
+// for which the package level functions can be used directly.
+//
+// The profile only changes the byte order of the basic integer types (uint16 to
+// uint256, including the ones packed in arrays and lists). Offsets and the list
+// length mixins used in merkleization remain little-endian, as they are part of
+// the SSZ framing, not of the data.
+//
+// A profile may also swap out the sha256 used for merkleization for a custom hash
+// backend, retaining the SSZ tree structure. Its hashing methods panic if the
+// backend fails, use HashRoot to get the failure as an error instead.
+//
+// Objects caching their own roots via ssz.RootedObject are rehashed when using
+// a non-standard profile, as their roots were computed with the standard one.
+//
+// Lastly, a profile may request the structural counters of its encodings and
+// decodings (sszdebug builds only). The destination is overwritten by each one,
+// so a profile collecting stats must not be used concurrently.
+type Profile struct {
+	Order   ByteOrder     // Byte order of the basic integer types
+	Backend HasherBackend // Hash function for merkleization (nil = sha256)
+	Stats   *Stats        // Destination for the stats of each encode/decode (nil = none)
+}
+
+// BigEndianProfile is an SSZ profile serializing basic integers as big-endian.
+var BigEndianProfile = Profile{Order: BigEndian}
+
+// EncodeToStream serializes a non-monolithic object into a data stream using
+// the profile. If the type contains fork-specific rules, use EncodeToStreamOnFork.
+func (p Profile) EncodeToStream(w io.Writer, obj Object) error {
+	return p.EncodeToStreamOnFork(w, obj, ForkUnknown)
+}
+
+// EncodeToStreamOnFork serializes a monolithic object into a data stream using
+// the profile.
+func (p Profile) EncodeToStreamOnFork(w io.Writer, obj Object, fork Fork) error {
+	return encodeToStream(w, obj, fork, p.Order, p.Stats)
+}
+
+// EncodeToBytes serializes a non-monolithic object into a byte buffer using the
+// profile. If the type contains fork-specific rules, use EncodeToBytesOnFork.
+func (p Profile) EncodeToBytes(buf []byte, obj Object) error {
+	return p.EncodeToBytesOnFork(buf, obj, ForkUnknown)
+}
+
+// EncodeToBytesOnFork serializes a monolithic object into a byte buffer using
+// the profile.
+func (p Profile) EncodeToBytesOnFork(buf []byte, obj Object, fork Fork) error {
+	return encodeToBytes(buf, obj, fork, p.Order, false, p.Stats, nil)
+}
+
+// DecodeFromStream parses a non-monolithic object with the given size out of a
+// stream using the profile. If the type contains fork-specific rules, use
+// DecodeFromStreamOnFork.
+func (p Profile) DecodeFromStream(r io.Reader, obj Object, size uint32) error {
+	return p.DecodeFromStreamOnFork(r, obj, size, ForkUnknown)
+}
+
+// DecodeFromStreamOnFork parses a monolithic object with the given size out of
+// a stream using the profile.
+func (p Profile) DecodeFromStreamOnFork(r io.Reader, obj Object, size uint32, fork Fork) error {
+	return decodeFromStream(r, obj, size, fork, p.Order, p.decodeOptions(), nil)
+}
+
+// DecodeFromBytes parses a non-monolithic object with the given data from a
+// byte buffer using the profile. If the type contains fork-specific rules, use
+// DecodeFromBytesOnFork.
+func (p Profile) DecodeFromBytes(blob []byte, obj Object) error {
+	return p.DecodeFromBytesOnFork(blob, obj, ForkUnknown)
+}
+
+// DecodeFromBytesOnFork parses a monolithic object with the given data from a
+// byte buffer using the profile.
+func (p Profile) DecodeFromBytesOnFork(blob []byte, obj Object, fork Fork) error {
+	return decodeFromBytes(blob, obj, fork, p.Order, p.decodeOptions(), nil, nil)
+}
+
+// HashSequential computes the merkle root of a non-monolithic object on a single
+// thread using the profile. If the type contains fork-specific rules, use
+// HashSequentialOnFork.
+func (p Profile) HashSequential(obj Object) [32]byte {
+	return p.HashSequentialOnFork(obj, ForkUnknown)
+}
+
+// HashSequentialOnFork computes the merkle root of a monolithic object on a
+// single thread using the profile.
+func (p Profile) HashSequentialOnFork(obj Object, fork Fork) [32]byte {
+	return hashSequential(obj, fork, p.Order, p.Backend)
+}
+
+// HashConcurrent computes the merkle root of a non-monolithic object on
+// potentially multiple concurrent threads using the profile. If the type
+// contains fork-specific rules, use HashConcurrentOnFork.
+func (p Profile) HashConcurrent(obj Object) [32]byte {
+	return p.HashConcurrentOnFork(obj, ForkUnknown)
+}
+
+// HashConcurrentOnFork computes the merkle root of a monolithic object on
+// potentially multiple concurrent threads using the profile.
+func (p Profile) HashConcurrentOnFork(obj Object, fork Fork) [32]byte {
+	return hashConcurrent(obj, fork, p.Order, p.Backend)
+}
+
+// HashRoot computes the merkle root of a non-monolithic object on a single thread
+// using the profile, similarly to HashSequential. Instead of panicking, it returns
+// an error if the hashing failed (e.g. the profile's backend). If the type contains
+// fork-specific rules, use HashRootOnFork.
+func (p Profile) HashRoot(obj Object) ([32]byte, error) {
+	return p.HashRootOnFork(obj, ForkUnknown)
+}
+
+// HashRootOnFork computes the merkle root of a monolithic object on a single
+// thread using the profile, similarly to HashSequentialOnFork. Instead of
+// panicking, it returns an error if the hashing failed (e.g. the profile's
+// backend).
+func (p Profile) HashRootOnFork(obj Object, fork Fork) ([32]byte, error) {
+	return hashRoot(obj, fork, p.Order, p.Backend)
+}
+
+// decodeOptions returns the decoder options needed to honor the profile, if any.
+func (p Profile) decodeOptions() *DecodeOptions {
+	if p.Stats == nil {
+		return nil
+	}
+	return &DecodeOptions{Stats: p.Stats}
+}
+
+// putUint16 serializes a uint16 into the buffer in the given byte order.
+func (o ByteOrder) putUint16(b []byte, n uint16) {
+	if o == BigEndian {
+		binary.BigEndian.PutUint16(b, n)
+		return
+	}
+	binary.LittleEndian.PutUint16(b, n)
+}
+
+// putUint32 serializes a uint32 into the buffer in the given byte order.
+func (o ByteOrder) putUint32(b []byte, n uint32) {
+	if o == BigEndian {
+		binary.BigEndian.PutUint32(b, n)
+		return
+	}
+	binary.LittleEndian.PutUint32(b, n)
+}
+
+// putUint64 serializes a uint64 into the buffer in the given byte order.
+func (o ByteOrder) putUint64(b []byte, n uint64) {
+	if o == BigEndian {
+		binary.BigEndian.PutUint64(b, n)
+		return
+	}
+	binary.LittleEndian.PutUint64(b, n)
+}
+
+// putUint256 serializes a uint256 into the buffer in the given byte order.
+func (o ByteOrder) putUint256(b []byte, n *uint256.Int) {
+	if o == BigEndian {
+		n.WriteToSlice(b[:32])
+		return
+	}
+	n.MarshalSSZInto(b)
+}
+
+// uint16 parses a uint16 from the buffer in the given byte order.
+func (o ByteOrder) uint16(b []byte) uint16 {
+	if o == BigEndian {
+		return binary.BigEndian.Uint16(b)
+	}
+	return binary.LittleEndian.Uint16(b)
+}
+
+// uint32 parses a uint32 from the buffer in the given byte order.
+func (o ByteOrder) uint32(b []byte) uint32 {
+	if o == BigEndian {
+		return binary.BigEndian.Uint32(b)
+	}
+	return binary.LittleEndian.Uint32(b)
+}
+
+// uint64 parses a uint64 from the buffer in the given byte order.
+func (o ByteOrder) uint64(b []byte) uint64 {
+	if o == BigEndian {
+		return binary.BigEndian.Uint64(b)
+	}
+	return binary.LittleEndian.Uint64(b)
+}
+
+// uint256 parses a uint256 from the buffer in the given byte order.
+func (o ByteOrder) uint256(b []byte, n *uint256.Int) {
+	if o == BigEndian {
+		n.SetBytes32(b[:32])
+		return
+	}
+	n.UnmarshalSSZ(b[:32])
+}
@@ -0,0 +1,179 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"fmt"
+	bitops "math/bits"
+)
+
+// nopBackend is a hasher backend that does not hash anything. It is used to walk
+// an object's merkle tree structure without paying for the actual hashing.
+type nopBackend struct{}
+
+// HashChunks implements HasherBackend.
+func (nopBackend) HashChunks(digests [][32]byte, chunks [][32]byte) error {
+	return nil
+}
+
+// hasherProver tracks the nodes along a single generalized index path while the
+// hasher is running, collecting the sibling hashes needed for a merkle proof.
+//
+// The hasher only learns the depth of a (sub-)trie when it is done hashing it, so
+// proving is done in two passes: a shape pass that records the depth of all the
+// tries without hashing anything, and a proving pass that uses those depths to
+// know which nodes are on the path to the requested generalized index.
+type hasherProver struct {
+	shape  bool  // Whether this is the shape pass (record depths only)
+	depths []int // Depths of all the tries, indexed by their descent order
+	seq    int   // Descent order of the next trie
+
+	path uint64 // Generalized index being proven
+	bits int    // Number of path bits below the root (depth of the target node)
+
+	stack  []proverLayer // Trie tracking state, one item per hasher layer
+	branch [][32]byte    // Sibling hashes collected, from the bottom up
+	filled []bool        // Flags whether a sibling was already collected
+	found  bool          // Whether the trie containing the target was reached
+}
+
+// proverLayer is the proof tracking state of a single hashing layer (trie).
+type proverLayer struct {
+	seq    int  // Descent order of the trie
+	onPath bool // Whether the trie is on the path to the target
+
+	depth  int    // Depth of the trie, from its root to its leaves
+	remain int    // Number of path bits remaining from this trie's root
+	final  bool   // Whether the target node is within this trie
+	level  int    // Depth (from the leaves) of the target node (or leaf) in this trie
+	target uint64 // Index of the target node (or leaf) at the above level
+
+	created [65]uint64 // Number of nodes created so far at each level
+}
+
+// newHasherProver creates a prover for the given generalized index, starting in
+// shape recording mode.
+func newHasherProver(gindex uint64) *hasherProver {
+	bits := bitops.Len64(gindex) - 1
+	return &hasherProver{
+		shape:  true,
+		path:   gindex,
+		bits:   bits,
+		branch: make([][32]byte, bits),
+		filled: make([]bool, bits),
+	}
+}
+
+// descend starts tracking a new trie, deciding whether it is on the path to the
+// target node or not.
+func (p *hasherProver) descend() {
+	layer := proverLayer{seq: p.seq}
+	p.seq++
+
+	if p.shape {
+		p.depths = append(p.depths, 0)
+		p.stack = append(p.stack, layer)
+		return
+	}
+	// Tries are on path either if they are the root, or if they are exactly at
+	// the leaf position (in their parent) the path passes through
+	switch {
+	case len(p.stack) == 0:
+		layer.onPath, layer.remain = true, p.bits
+
+	default:
+		parent := &p.stack[len(p.stack)-1]
+		if parent.onPath && !parent.final && parent.created[0] == parent.target {
+			layer.onPath, layer.remain = true, parent.remain-parent.depth
+		}
+	}
+	if layer.onPath {
+		layer.depth = p.depths[layer.seq]
+		bits := p.path & (1<<layer.remain - 1)
+
+		if layer.remain <= layer.depth {
+			layer.final = true
+			layer.level = layer.depth - layer.remain
+			layer.target = bits
+			p.found = true
+		} else {
+			layer.target = bits >> (layer.remain - layer.depth)
+		}
+	}
+	p.stack = append(p.stack, layer)
+}
+
+// ascend stops tracking the current trie, filling in any missing siblings with
+// zero hashes if the target was within a virtual (never created) zero sub-trie.
+func (p *hasherProver) ascend(h *Hasher, depth int) {
+	layer := &p.stack[len(p.stack)-1]
+	if p.shape {
+		p.depths[layer.seq] = depth
+	} else if layer.final {
+		for level := layer.level; level < layer.depth; level++ {
+			if index := layer.remain - layer.depth + level; !p.filled[index] {
+				p.branch[index], p.filled[index] = h.zeroHash(level), true
+			}
+		}
+	}
+	p.stack = p.stack[:len(p.stack)-1]
+}
+
+// track is called whenever new nodes are created at a given level of the trie
+// currently being hashed, collecting any that are siblings of the target path.
+func (p *hasherProver) track(level int, nodes [][32]byte) {
+	// Skip tracking during shape recording, or for the final root of the object
+	if p.shape || len(p.stack) == 0 {
+		return
+	}
+	layer := &p.stack[len(p.stack)-1]
+	if !layer.onPath || level < layer.level || level >= layer.depth {
+		layer.created[level] += uint64(len(nodes))
+		return
+	}
+	sibling := (layer.target >> (level - layer.level)) ^ 1
+	for _, node := range nodes {
+		if layer.created[level] == sibling {
+			index := layer.remain - layer.depth + level
+			p.branch[index], p.filled[index] = node, true
+		}
+		layer.created[level]++
+	}
+}
+
+// VerifyProof checks a merkle proof of a leaf at a generalized index against a
+// root, implementing is_valid_merkle_branch from the consensus specs. The branch
+// contains the sibling hashes from the bottom up (as HashSequentialWithProof
+// returns them), and its length must match the depth of the generalized index.
+func VerifyProof(root [32]byte, leaf [32]byte, branch [][32]byte, gindex uint64) bool {
+	ok, _ := VerifyProofWithBackend(root, leaf, branch, gindex, SHA256Backend) // sha256 cannot fail
+	return ok
+}
+
+// VerifyProofWithBackend checks a merkle proof similarly to VerifyProof, but uses
+// a custom hash backend for the inner hashing. If the backend fails, the error is
+// returned wrapped into ErrHasherBackendFailed.
+func VerifyProofWithBackend(root [32]byte, leaf [32]byte, branch [][32]byte, gindex uint64, backend HasherBackend) (bool, error) {
+	if gindex == 0 || len(branch) != bitops.Len64(gindex)-1 {
+		return false, nil
+	}
+	var (
+		chunks [2][32]byte
+		digest [1][32]byte
+	)
+	for _, sibling := range branch {
+		if gindex&1 == 1 {
+			chunks[0], chunks[1] = sibling, leaf
+		} else {
+			chunks[0], chunks[1] = leaf, sibling
+		}
+		if err := backend.HashChunks(digest[:], chunks[:]); err != nil {
+			return false, fmt.Errorf("%w: %w", ErrHasherBackendFailed, err)
+		}
+		leaf = digest[0]
+		gindex >>= 1
+	}
+	return leaf == root, nil
+}
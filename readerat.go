@@ -0,0 +1,128 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"fmt"
+	"io"
+	"math"
+)
+
+// DecodeFromReaderAt parses a non-monolithic object with the given size out of
+// a random access source. If the type contains fork-specific rules, use
+// DecodeFromReaderAtOnFork.
+func DecodeFromReaderAt(r io.ReaderAt, size int64, obj Object) error {
+	return DecodeFromReaderAtOnFork(r, size, obj, ForkUnknown)
+}
+
+// DecodeFromReaderAtOnFork parses a monolithic object with the given size out of
+// a random access source (e.g. a file or a blob in remote storage). If the type
+// does not contain fork-specific rules, you can also use DecodeFromReaderAt.
+//
+// The source is read via the streaming decoder, but the read-ahead is done with
+// positioned reads that never cross the size of the message. On its own, this is
+// equivalent to DecodeFromStreamOnFork; its use is in combination with field
+// masks, see DecodeFieldsFromReaderAtOnFork.
+func DecodeFromReaderAtOnFork(r io.ReaderAt, size int64, obj Object, fork Fork) error {
+	if size < 0 {
+		return fmt.Errorf("ssz: negative object size %d", size)
+	}
+	if size > math.MaxUint32 {
+		return fmt.Errorf("ssz: object size %d exceeds 32 bit offsets", size)
+	}
+	return decodeFromStream(newReaderAtStream(r, size), obj, uint32(size), fork, LittleEndian, nil, nil)
+}
+
+// DecodeFieldsFromReaderAt parses the fields selected by a mask of a non-monolithic
+// object out of a random access source. If the type contains fork-specific rules,
+// use DecodeFieldsFromReaderAtOnFork.
+func DecodeFieldsFromReaderAt(r io.ReaderAt, size int64, obj Object, mask FieldMask) error {
+	return DecodeFieldsFromReaderAtOnFork(r, size, obj, ForkUnknown, mask)
+}
+
+// DecodeFieldsFromReaderAtOnFork parses the fields selected by a mask of a
+// monolithic object out of a random access source. The fields not in the mask
+// are seeked past without reading them, so decoding a few fields out of a large
+// object (e.g. a beacon state on disk or in S3) only fetches the static section
+// and the byte ranges of the selected dynamic fields (plus at most one read-ahead
+// window of StreamReadAhead bytes per seek).
+//
+// The same restrictions apply as for DecodeFieldsOnFork.
+func DecodeFieldsFromReaderAtOnFork(r io.ReaderAt, size int64, obj Object, fork Fork, mask FieldMask) error {
+	if size < 0 {
+		return fmt.Errorf("ssz: negative object size %d", size)
+	}
+	if size > math.MaxUint32 {
+		return fmt.Errorf("ssz: object size %d exceeds 32 bit offsets", size)
+	}
+	masker, err := newFieldMasker(obj, fork, mask)
+	if err != nil {
+		return err
+	}
+	return decodeFromStream(newReaderAtStream(r, size), obj, uint32(size), fork, LittleEndian, nil, masker)
+}
+
+// readerAtStream is a read-ahead buffered stream over a random access source,
+// which can seek forward without reading the skipped bytes.
+type readerAtStream struct {
+	src io.ReaderAt // Random access source to read from
+	pos int64       // Position of the next byte to read
+	end int64       // Position past the last byte of the message
+
+	buf    []byte // Read-ahead window of the source
+	bufPos int64  // Position of the first byte of the window
+	bufLen int    // Number of valid bytes in the window
+}
+
+// newReaderAtStream creates a stream over the first size bytes of a source.
+func newReaderAtStream(r io.ReaderAt, size int64) *readerAtStream {
+	return &readerAtStream{
+		src: r,
+		end: size,
+		buf: make([]byte, min(size, int64(StreamReadAhead))),
+	}
+}
+
+// Read implements io.Reader, serving the data from the read-ahead window, or
+// refilling it from the current position if exhausted. Reads larger than the
+// window go directly to the source.
+func (s *readerAtStream) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if s.pos >= s.end {
+		return 0, io.EOF
+	}
+	if s.pos < s.bufPos || s.pos >= s.bufPos+int64(s.bufLen) {
+		want := min(int64(len(p)), s.end-s.pos)
+		if want >= int64(len(s.buf)) {
+			n, err := s.src.ReadAt(p[:want], s.pos)
+			s.pos += int64(n)
+			if int64(n) == want {
+				err = nil
+			}
+			return n, err
+		}
+		n, err := s.src.ReadAt(s.buf[:min(int64(len(s.buf)), s.end-s.pos)], s.pos)
+		s.bufPos, s.bufLen = s.pos, n
+		if n == 0 {
+			return 0, err
+		}
+	}
+	n := copy(p, s.buf[s.pos-s.bufPos:s.bufLen])
+	s.pos += int64(n)
+	return n, nil
+}
+
+// skip seeks forward in the stream without reading the skipped bytes from the
+// source (unless they are already in the read-ahead window).
+func (s *readerAtStream) skip(n int64) error {
+	if s.end-s.pos < n {
+		s.pos = s.end
+		return io.ErrUnexpectedEOF
+	}
+	s.pos += n
+	return nil
+}
@@ -0,0 +1,419 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package reflectcodec
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/bits"
+	"reflect"
+	"unicode/utf8"
+
+	"github.com/holiman/uint256"
+	"github.com/karalabe/ssz"
+)
+
+// value dereferences a (possibly nil) pointer for reading, returning the zero
+// value of the pointed type for nil pointers.
+func value(v reflect.Value) reflect.Value {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Zero(v.Type().Elem())
+		}
+		return v.Elem()
+	}
+	return v
+}
+
+// settable dereferences a (possibly nil) pointer for writing, allocating a new
+// item for nil pointers.
+func settable(v reflect.Value) reflect.Value {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return v.Elem()
+	}
+	return v
+}
+
+// sizeOf computes the encoded size of a value in a fork.
+func sizeOf(v reflect.Value, t *sszType, fork ssz.Fork) int {
+	if t.static && t.kind != kindContainer {
+		return t.size
+	}
+	v = value(v)
+
+	switch t.kind {
+	case kindBitlist:
+		if v.Len() == 0 {
+			return 1 // Nil bitlists are encoded as empty ones
+		}
+		return v.Len()
+
+	case kindBytes, kindString:
+		return v.Len()
+
+	case kindVector, kindList:
+		var size int
+		if t.elem.static {
+			return v.Len() * t.elem.fixedSize(fork)
+		}
+		for i := 0; i < v.Len(); i++ {
+			size += 4 + sizeOf(v.Index(i), t.elem, fork)
+		}
+		if t.kind == kindVector {
+			size += 4 * (t.items - v.Len()) // Missing items are empty
+		}
+		return size
+
+	case kindContainer:
+		var size int
+		for _, field := range t.fields {
+			if !field.filter.Active(fork) {
+				continue
+			}
+			size += field.typ.fixedSize(fork)
+			if !field.typ.static {
+				size += sizeOf(v.Field(field.index), field.typ, fork)
+			}
+		}
+		return size
+	}
+	panic(fmt.Sprintf("unknown kind %d", t.kind))
+}
+
+// encode appends the ssz encoding of a value in a fork to the buffer.
+func encode(buf []byte, v reflect.Value, t *sszType, fork ssz.Fork) ([]byte, error) {
+	v = value(v)
+
+	switch t.kind {
+	case kindBool:
+		if v.Bool() {
+			return append(buf, 1), nil
+		}
+		return append(buf, 0), nil
+
+	case kindUint:
+		switch t.size {
+		case 1:
+			return append(buf, uint8(v.Uint())), nil
+		case 2:
+			return binary.LittleEndian.AppendUint16(buf, uint16(v.Uint())), nil
+		case 4:
+			return binary.LittleEndian.AppendUint32(buf, uint32(v.Uint())), nil
+		default:
+			return binary.LittleEndian.AppendUint64(buf, v.Uint()), nil
+		}
+
+	case kindUint256:
+		n := v.Interface().(uint256.Int)
+		for _, limb := range n {
+			buf = binary.LittleEndian.AppendUint64(buf, limb)
+		}
+		return buf, nil
+
+	case kindBitlist:
+		if v.Len() == 0 {
+			return append(buf, 0x01), nil // Nil bitlists are encoded as empty ones
+		}
+		return append(buf, v.Bytes()...), nil
+
+	case kindBytes, kindBits:
+		if t.static && v.Kind() == reflect.Slice && v.Len() != t.size {
+			if v.Len() != 0 {
+				return nil, fmt.Errorf("%w: encoding %d bytes, want %d", ssz.ErrObjectSlotSizeMismatch, v.Len(), t.size)
+			}
+			return append(buf, make([]byte, t.size)...), nil // Missing blobs are zero
+		}
+		for i := 0; i < v.Len(); i++ {
+			buf = append(buf, uint8(v.Index(i).Uint()))
+		}
+		return buf, nil
+
+	case kindString:
+		return append(buf, v.String()...), nil
+
+	case kindVector, kindList:
+		items := make([]reflect.Value, v.Len())
+		for i := range items {
+			items[i] = v.Index(i)
+		}
+		if t.kind == kindVector && len(items) != t.items {
+			if len(items) > t.items || (t.elem.static && len(items) != 0) {
+				return nil, fmt.Errorf("%w: encoding %d items, want %d", ssz.ErrObjectSlotSizeMismatch, len(items), t.items)
+			}
+			for len(items) < t.items {
+				items = append(items, reflect.Zero(t.elem.typ)) // Missing items are empty
+			}
+		}
+		return encodeSequence(buf, items, t.elem, fork)
+
+	case kindContainer:
+		var (
+			fields []reflect.Value
+			types  []*sszType
+		)
+		for _, field := range t.fields {
+			if field.filter.Active(fork) {
+				fields = append(fields, v.Field(field.index))
+				types = append(types, field.typ)
+			}
+		}
+		return encodeHeterogeneous(buf, fields, types, fork)
+	}
+	panic(fmt.Sprintf("unknown kind %d", t.kind))
+}
+
+// encodeSequence appends the ssz encoding of a list of same typed items.
+func encodeSequence(buf []byte, items []reflect.Value, t *sszType, fork ssz.Fork) ([]byte, error) {
+	types := make([]*sszType, len(items))
+	for i := range types {
+		types[i] = t
+	}
+	return encodeHeterogeneous(buf, items, types, fork)
+}
+
+// encodeHeterogeneous appends the ssz encoding of a list of arbitrarily typed
+// items, placing static items inline and dynamic ones after the fixed area.
+func encodeHeterogeneous(buf []byte, items []reflect.Value, types []*sszType, fork ssz.Fork) ([]byte, error) {
+	var fixed int
+	for _, t := range types {
+		fixed += t.fixedSize(fork)
+	}
+	var (
+		err    error
+		offset = fixed
+	)
+	for i, t := range types {
+		if t.static {
+			if buf, err = encode(buf, items[i], t, fork); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(offset))
+		offset += sizeOf(items[i], t, fork)
+	}
+	for i, t := range types {
+		if !t.static {
+			if buf, err = encode(buf, items[i], t, fork); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return buf, nil
+}
+
+// decode parses the ssz encoding of a value in a fork from a blob containing
+// exactly the value's encoding.
+func decode(blob []byte, v reflect.Value, t *sszType, fork ssz.Fork) error {
+	if t.static && t.kind != kindContainer && len(blob) != t.size {
+		if len(blob) < t.size {
+			return io.ErrUnexpectedEOF
+		}
+		return fmt.Errorf("%w: have %d, want %d", ssz.ErrObjectSlotSizeMismatch, len(blob), t.size)
+	}
+	v = settable(v)
+
+	switch t.kind {
+	case kindBool:
+		if blob[0] > 1 {
+			return fmt.Errorf("%w: found %#x", ssz.ErrInvalidBoolean, blob[0])
+		}
+		v.SetBool(blob[0] == 1)
+
+	case kindUint:
+		switch t.size {
+		case 1:
+			v.SetUint(uint64(blob[0]))
+		case 2:
+			v.SetUint(uint64(binary.LittleEndian.Uint16(blob)))
+		case 4:
+			v.SetUint(uint64(binary.LittleEndian.Uint32(blob)))
+		default:
+			v.SetUint(binary.LittleEndian.Uint64(blob))
+		}
+
+	case kindUint256:
+		var n uint256.Int
+		for i := range n {
+			n[i] = binary.LittleEndian.Uint64(blob[i*8:])
+		}
+		v.Set(reflect.ValueOf(n))
+
+	case kindBits:
+		for i := t.bits; i < t.size*8; i++ {
+			if blob[i>>3]&(1<<(i&7)) != 0 {
+				return fmt.Errorf("%w: bit %d set, size %d bits", ssz.ErrJunkInBitvector, i+1, t.bits)
+			}
+		}
+		reflect.Copy(v, reflect.ValueOf(blob))
+
+	case kindBitlist:
+		if len(blob) == 0 {
+			return fmt.Errorf("%w: length bit missing", ssz.ErrJunkInBitlist)
+		}
+		if blob[len(blob)-1] == 0 {
+			return fmt.Errorf("%w: high byte unset", ssz.ErrJunkInBitlist)
+		}
+		if size := uint64(len(blob)-1)*8 + uint64(7-bits.LeadingZeros8(blob[len(blob)-1])); size > t.limitOnFork(fork) {
+			return fmt.Errorf("%w: decoded %d bits, max %d bits", ssz.ErrMaxItemsExceeded, size, t.limitOnFork(fork))
+		}
+		v.SetBytes(append(make([]byte, 0, len(blob)), blob...))
+
+	case kindBytes:
+		if !t.static && uint64(len(blob)) > t.limitOnFork(fork) {
+			return fmt.Errorf("%w: decoded %d, max %d", ssz.ErrMaxLengthExceeded, len(blob), t.limitOnFork(fork))
+		}
+		if v.Kind() == reflect.Slice {
+			v.Set(reflect.MakeSlice(v.Type(), len(blob), len(blob)))
+		}
+		reflect.Copy(v, reflect.ValueOf(blob))
+
+	case kindString:
+		if uint64(len(blob)) > t.limitOnFork(fork) {
+			return fmt.Errorf("%w: decoded %d, max %d", ssz.ErrMaxLengthExceeded, len(blob), t.limitOnFork(fork))
+		}
+		if t.utf8 && !utf8.Valid(blob) {
+			return ssz.ErrInvalidUTF8
+		}
+		v.SetString(string(blob))
+
+	case kindVector, kindList:
+		var (
+			items [][]byte
+			err   error
+		)
+		if t.elem.static {
+			size := t.elem.fixedSize(fork)
+			if len(blob)%size != 0 {
+				return fmt.Errorf("%w: length %d, item size %d", ssz.ErrDynamicStaticsIndivisible, len(blob), size)
+			}
+			for ; len(blob) > 0; blob = blob[size:] {
+				items = append(items, blob[:size])
+			}
+		} else if items, err = splitOffsets(blob); err != nil {
+			return err
+		}
+		switch {
+		case t.kind == kindList && uint64(len(items)) > t.limitOnFork(fork):
+			return fmt.Errorf("%w: decoded %d, max %d", ssz.ErrMaxItemsExceeded, len(items), t.limitOnFork(fork))
+		case t.kind == kindVector && len(items) != t.items:
+			return fmt.Errorf("%w: decoded %d, want %d", ssz.ErrObjectSlotSizeMismatch, len(items), t.items)
+		}
+		if v.Kind() == reflect.Slice {
+			v.Set(reflect.MakeSlice(v.Type(), len(items), len(items)))
+		}
+		for i, item := range items {
+			if err := decode(item, v.Index(i), t.elem, fork); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+			if t.sorted && i > 0 && v.Index(i).Uint() <= v.Index(i-1).Uint() {
+				return fmt.Errorf("%w: item %d is %d, previous %d", ssz.ErrUnsortedItems, i, v.Index(i).Uint(), v.Index(i-1).Uint())
+			}
+		}
+
+	case kindContainer:
+		return decodeContainer(blob, v, t, fork)
+
+	default:
+		panic(fmt.Sprintf("unknown kind %d", t.kind))
+	}
+	return nil
+}
+
+// decodeContainer parses the ssz encoding of a struct in a fork.
+func decodeContainer(blob []byte, v reflect.Value, t *sszType, fork ssz.Fork) error {
+	var (
+		fields []*sszField
+		slots  [][]byte
+		dyns   []int // Indices of the dynamic fields within the slots
+		offset int
+	)
+	for _, field := range t.fields {
+		if !field.filter.Active(fork) {
+			// Field not present in the current fork, clear out the output
+			f := v.Field(field.index)
+			f.Set(reflect.Zero(f.Type()))
+			continue
+		}
+		size := field.typ.fixedSize(fork)
+		if offset+size > len(blob) {
+			return io.ErrUnexpectedEOF
+		}
+		if !field.typ.static {
+			dyns = append(dyns, len(slots))
+		}
+		fields = append(fields, field)
+		slots = append(slots, blob[offset:offset+size])
+		offset += size
+	}
+	if len(dyns) == 0 && offset != len(blob) {
+		return fmt.Errorf("%w: have %d, want %d", ssz.ErrObjectSlotSizeMismatch, len(blob), offset)
+	}
+	// Resolve the dynamic fields' content from their offsets
+	starts := make([]int, len(dyns)+1)
+	for i, idx := range dyns {
+		start := int(binary.LittleEndian.Uint32(slots[idx]))
+		switch {
+		case i == 0 && start != offset:
+			return fmt.Errorf("%s: %w: have %d, want %d", fields[idx].name, ssz.ErrFirstOffsetMismatch, start, offset)
+		case i > 0 && start < starts[i-1]:
+			return fmt.Errorf("%s: %w: have %d, previous %d", fields[idx].name, ssz.ErrBadOffsetProgression, start, starts[i-1])
+		case start > len(blob):
+			return fmt.Errorf("%s: %w: offset %d, capacity %d", fields[idx].name, ssz.ErrOffsetBeyondCapacity, start, len(blob))
+		}
+		starts[i] = start
+	}
+	starts[len(dyns)] = len(blob)
+	for i, idx := range dyns {
+		slots[idx] = blob[starts[i]:starts[i+1]]
+	}
+	// Decode all the fields from their slots
+	for i, field := range fields {
+		if err := decode(slots[i], v.Field(field.index), field.typ, fork); err != nil {
+			return fmt.Errorf("%s: %w", field.name, err)
+		}
+	}
+	return nil
+}
+
+// splitOffsets splits the encoding of a list of dynamic items into the items'
+// individual encodings, based on the offsets prefixing the list.
+func splitOffsets(blob []byte) ([][]byte, error) {
+	if len(blob) == 0 {
+		return nil, nil
+	}
+	if len(blob) < 4 {
+		return nil, ssz.ErrShortCounterOffset
+	}
+	first := binary.LittleEndian.Uint32(blob)
+	switch {
+	case first == 0:
+		return nil, ssz.ErrZeroCounterOffset
+	case first&3 != 0:
+		return nil, fmt.Errorf("%w: %d bytes", ssz.ErrBadCounterOffset, first)
+	case int(first) > len(blob):
+		return nil, fmt.Errorf("%w: offset %d, capacity %d", ssz.ErrOffsetBeyondCapacity, first, len(blob))
+	}
+	items := make([][]byte, first>>2)
+	for i := range items {
+		start := int(binary.LittleEndian.Uint32(blob[i<<2:]))
+		end := len(blob)
+		if i+1 < len(items) {
+			end = int(binary.LittleEndian.Uint32(blob[(i+1)<<2:]))
+		}
+		switch {
+		case end < start:
+			return nil, fmt.Errorf("%w: offset %d, previous %d", ssz.ErrBadOffsetProgression, end, start)
+		case end > len(blob):
+			return nil, fmt.Errorf("%w: offset %d, capacity %d", ssz.ErrOffsetBeyondCapacity, end, len(blob))
+		}
+		items[i] = blob[start:end]
+	}
+	return items, nil
+}
@@ -0,0 +1,229 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package reflectcodec implements a reflection based ssz codec, which encodes,
+// decodes and hashes arbitrary structs annotated with the same ssz tags as the
+// code generator uses, without having to run the generator first.
+//
+// It is meant for prototyping and tests: it is an order of magnitude slower than
+// the generated code and allocates liberally. Production code should still use
+// sszgen and the ssz package directly. Map fields are not supported.
+package reflectcodec
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+
+	"github.com/karalabe/ssz"
+)
+
+// ErrUnsupportedType is returned if a Go type cannot be mapped to an ssz type,
+// either because it is not supported, or because its tags are inconsistent.
+var ErrUnsupportedType = errors.New("reflectcodec: unsupported type")
+
+// constants are the named constants that struct tags may reference in place of
+// literal sizes and limits. Reflection cannot see Go constants, so they need to
+// be registered explicitly via RegisterConstant.
+var constants sync.Map // map[string]int
+
+// RegisterConstant makes a named constant known to the codec, so that ssz-size
+// and ssz-max tags referencing it by name (as supported by the code generator)
+// can be resolved. It needs to be called before any type using it is resolved.
+func RegisterConstant(name string, value int) {
+	if value <= 0 {
+		panic(fmt.Sprintf("constant %q value %d not positive", name, value))
+	}
+	if _, loaded := constants.LoadOrStore(name, value); loaded {
+		panic(fmt.Sprintf("constant %q already registered", name))
+	}
+}
+
+// Size retrieves the size of a non-monolithic object, independent if it is
+// static or dynamic. If the type contains fork-specific rules, use SizeOnFork.
+func Size(obj any) (uint32, error) {
+	return SizeOnFork(obj, ssz.ForkUnknown)
+}
+
+// SizeOnFork retrieves the size of a monolithic object, independent if it is
+// static or dynamic.
+func SizeOnFork(obj any, fork ssz.Fork) (uint32, error) {
+	v, t, err := resolve(obj)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(sizeOf(v, t, fork)), nil
+}
+
+// EncodeToBytes serializes a non-monolithic object into a byte buffer. If the
+// type contains fork-specific rules, use EncodeToBytesOnFork.
+//
+// Don't use this method if you want to then write the buffer into a stream via
+// some writer, as that would double the memory use for the temporary buffer.
+// For that use case, use ssz.EncodeToStream on the generated types instead.
+func EncodeToBytes(buf []byte, obj any) error {
+	return EncodeToBytesOnFork(buf, obj, ssz.ForkUnknown)
+}
+
+// EncodeToBytesOnFork serializes a monolithic object into a byte buffer.
+func EncodeToBytesOnFork(buf []byte, obj any, fork ssz.Fork) error {
+	v, t, err := resolve(obj)
+	if err != nil {
+		return err
+	}
+	if size := sizeOf(v, t, fork); size > len(buf) {
+		return fmt.Errorf("%w: buffer %d bytes, object %d bytes", ssz.ErrBufferTooSmall, len(buf), size)
+	}
+	_, err = encode(buf[:0], v, t, fork)
+	return err
+}
+
+// DecodeFromBytes parses a non-monolithic object with the given data from a
+// byte buffer. If the type contains fork-specific rules, use DecodeFromBytesOnFork.
+func DecodeFromBytes(blob []byte, obj any) error {
+	return DecodeFromBytesOnFork(blob, obj, ssz.ForkUnknown)
+}
+
+// DecodeFromBytesOnFork parses a monolithic object with the given data from a
+// byte buffer.
+func DecodeFromBytesOnFork(blob []byte, obj any, fork ssz.Fork) error {
+	v, t, err := resolve(obj)
+	if err != nil {
+		return err
+	}
+	if len(blob) == 0 {
+		return io.ErrUnexpectedEOF
+	}
+	if err := decode(blob, v, t, fork); err != nil {
+		return fmt.Errorf("%s: %w", t.name, err)
+	}
+	return nil
+}
+
+// HashSequential computes the merkle root of a non-monolithic object on a single
+// thread. If the type contains fork-specific rules, use HashSequentialOnFork.
+func HashSequential(obj any) ([32]byte, error) {
+	return HashSequentialOnFork(obj, ssz.ForkUnknown)
+}
+
+// HashSequentialOnFork computes the merkle root of a monolithic object on a
+// single thread.
+//
+// The object is hashed by serializing it, and then merkleizing the encoding via
+// ssz.HashRootOfSchema with the schema derived from the Go type in that fork.
+func HashSequentialOnFork(obj any, fork ssz.Fork) ([32]byte, error) {
+	v, t, err := resolve(obj)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	blob, err := encode(make([]byte, 0, sizeOf(v, t, fork)), v, t, fork)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return ssz.HashRootOfSchema(blob, schemaOf(t, fork))
+}
+
+// resolve validates that the object is a non-nil pointer to a struct, and then
+// retrieves its ssz layout.
+func resolve(obj any) (reflect.Value, *sszType, error) {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, nil, fmt.Errorf("%w: %T is not a non-nil struct pointer", ErrUnsupportedType, obj)
+	}
+	t, err := resolveContainer(v.Elem().Type())
+	if err != nil {
+		return reflect.Value{}, nil, err
+	}
+	return v.Elem(), t, nil
+}
+
+// schemaKey is the cache key of a container's schema in a specific fork.
+type schemaKey struct {
+	typ  *sszType
+	fork ssz.Fork
+}
+
+// schemaCache is the cache of already derived container schemas.
+var schemaCache sync.Map // map[schemaKey]*ssz.Schema
+
+// schemaOf derives the hashing schema of a container in a specific fork. Since
+// the fork is already known, inactive fields are dropped and fork specific limits
+// are resolved instead of being described in the schema.
+func schemaOf(t *sszType, fork ssz.Fork) *ssz.Schema {
+	key := schemaKey{typ: t, fork: fork}
+	if cached, ok := schemaCache.Load(key); ok {
+		return cached.(*ssz.Schema)
+	}
+	schema := &ssz.Schema{Name: t.name}
+	for _, field := range t.fields {
+		if field.filter.Active(fork) {
+			schema.Fields = append(schema.Fields, schemaFieldOf(field.name, field.typ, fork))
+		}
+	}
+	cached, _ := schemaCache.LoadOrStore(key, schema)
+	return cached.(*ssz.Schema)
+}
+
+// schemaFieldOf derives the hashing schema of a single field in a specific fork.
+func schemaFieldOf(name string, t *sszType, fork ssz.Fork) *ssz.SchemaField {
+	field := &ssz.SchemaField{Name: name}
+
+	switch t.kind {
+	case kindBool:
+		field.Encoding, field.Size = "Bool", 1
+	case kindUint:
+		field.Encoding, field.Size = fmt.Sprintf("Uint%d", t.size*8), t.size
+	case kindUint256:
+		field.Encoding, field.Size = "Uint256", 32
+	case kindBits:
+		field.Encoding, field.Size = "ArrayOfBits", t.size
+	case kindBitlist:
+		field.Encoding, field.Limits = "SliceOfBits", []int{int(t.limitOnFork(fork))}
+	case kindString:
+		field.Encoding, field.Limits = "String", []int{int(t.limitOnFork(fork))}
+
+	case kindBytes:
+		if t.static {
+			field.Encoding, field.Size = "StaticBytes", t.size
+		} else {
+			field.Encoding, field.Limits = "DynamicBytes", []int{int(t.limitOnFork(fork))}
+		}
+
+	case kindVector:
+		switch {
+		case t.elem.kind == kindUint:
+			field.Encoding, field.Size = fmt.Sprintf("ArrayOfUint%ds", t.elem.size*8), t.size
+		case t.elem.static:
+			field.Encoding, field.Size, field.Sizes = "ArrayOfStaticBytes", t.size, []int{t.items, t.elem.size}
+		default:
+			field.Encoding, field.Limits = "CheckedArrayOfDynamicBytes", []int{t.items, int(t.elem.limit)}
+		}
+
+	case kindList:
+		limit := int(t.limitOnFork(fork))
+		switch {
+		case t.elem.kind == kindUint:
+			field.Encoding, field.Limits = fmt.Sprintf("SliceOfUint%ds", t.elem.size*8), []int{limit}
+		case t.elem.kind == kindBytes && t.elem.static:
+			field.Encoding, field.Sizes, field.Limits = "SliceOfStaticBytes", []int{0, t.elem.size}, []int{limit}
+		case t.elem.kind == kindBytes:
+			field.Encoding, field.Limits = "SliceOfDynamicBytes", []int{limit, int(t.elem.limit)}
+		case t.elem.static:
+			field.Encoding, field.Limits, field.Schema = "SliceOfStaticObjects", []int{limit}, schemaOf(t.elem, fork)
+		default:
+			field.Encoding, field.Limits, field.Schema = "SliceOfDynamicObjects", []int{limit}, schemaOf(t.elem, fork)
+		}
+
+	case kindContainer:
+		if t.static {
+			field.Encoding = "StaticObject"
+		} else {
+			field.Encoding = "DynamicObject"
+		}
+		field.Schema = schemaOf(t, fork)
+	}
+	return field
+}
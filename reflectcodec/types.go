@@ -0,0 +1,410 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package reflectcodec
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/holiman/uint256"
+	"github.com/karalabe/ssz"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+// kind is the ssz encoding family of a resolved Go type.
+type kind int
+
+const (
+	kindBool      kind = iota // bool
+	kindUint                  // uint8, uint16, uint32, uint64
+	kindUint256               // uint256.Int
+	kindBytes                 // [N]byte, []byte with ssz-size or ssz-max
+	kindBits                  // [N]byte with ssz:"bits"
+	kindBitlist               // bitfield.Bitlist
+	kindString                // string
+	kindVector                // [N]T, [][M]byte or [][]byte with ssz-size
+	kindList                  // []T with ssz-max
+	kindContainer             // struct
+)
+
+// sszType is the resolved ssz layout of a Go type, along with any constraints
+// derived from the struct tags of the field it was embedded in.
+type sszType struct {
+	kind   kind
+	size   int          // Encoded size of static types (containers are fork dependent)
+	bits   int          // Bit size of bitvectors
+	items  int          // Item count of vectors
+	limit  uint64       // Maximum item count of lists (bits for bitlists, bytes for blobs)
+	forks  []forkLimit  // Fork specific overrides of the limit
+	utf8   bool         // Whether strings need to be validated as UTF-8
+	sorted bool         // Whether list items need to be validated as strictly increasing
+	elem   *sszType     // Item type of vectors and lists
+	name   string       // Name of container types
+	fields []*sszField  // Fields of container types
+	static bool         // Whether the type is fixed size
+	typ    reflect.Type // Go type (without pointers) the layout was resolved from
+}
+
+// sszField is a single ssz field of a container.
+type sszField struct {
+	name   string         // Name of the Go struct field
+	index  int            // Index of the Go struct field
+	typ    *sszType       // Layout of the field
+	filter ssz.ForkFilter // Forks the field is present in
+}
+
+// forkLimit is a limit override that takes effect from a specific fork onward.
+type forkLimit struct {
+	fork  ssz.Fork
+	limit uint64
+}
+
+// sizeTag contains the parsed ssz tags of a struct field.
+type sizeTag struct {
+	bits   bool        // Whether the sizes are bits instead of bytes
+	utf8   bool        // Whether strings need to be validated as UTF-8
+	sorted bool        // Whether list items need to be validated as strictly increasing
+	size   []int       // 0 means the size for that dimension is undefined
+	limit  []int       // 0 means the limit for that dimension is undefined
+	forks  []forkLimit // Fork specific overrides for the limit
+	filter ssz.ForkFilter
+}
+
+var (
+	uint256Type   = reflect.TypeOf(uint256.Int{})
+	bitlistType   = reflect.TypeOf(bitfield.Bitlist{})
+	rootCacheType = reflect.TypeOf(ssz.RootCache{})
+)
+
+// typeCache is the cache of already resolved container types.
+var typeCache sync.Map // map[reflect.Type]*sszType
+
+// resolveContainer retrieves the ssz layout of a struct type, caching it for
+// later invocations.
+func resolveContainer(typ reflect.Type) (*sszType, error) {
+	if cached, ok := typeCache.Load(typ); ok {
+		return cached.(*sszType), nil
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %v is not a struct", ErrUnsupportedType, typ)
+	}
+	t := &sszType{
+		kind:   kindContainer,
+		name:   typ.Name(),
+		static: true,
+		typ:    typ,
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if !f.IsExported() || f.Type == rootCacheType {
+			continue
+		}
+		ignore, tags, err := parseTags(f.Tag)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse field %s.%s tags: %v", typ.Name(), f.Name, err)
+		}
+		if ignore {
+			continue
+		}
+		ft, err := resolveType(f.Type, tags.size, tags.limit, tags)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve field %s.%s: %w", typ.Name(), f.Name, err)
+		}
+		if len(tags.forks) > 0 {
+			if ft.static || ft.kind == kindContainer || ft.kind == kindVector {
+				return nil, fmt.Errorf("%w: field %s.%s: only lists can have ssz-max-fork tag", ErrUnsupportedType, typ.Name(), f.Name)
+			}
+			ft.forks = tags.forks
+		}
+		t.static = t.static && ft.static
+		t.fields = append(t.fields, &sszField{
+			name:   f.Name,
+			index:  i,
+			typ:    ft,
+			filter: tags.filter,
+		})
+	}
+	if len(t.fields) == 0 {
+		return nil, fmt.Errorf("%w: %v has no ssz fields", ErrUnsupportedType, typ)
+	}
+	cached, _ := typeCache.LoadOrStore(typ, t)
+	return cached.(*sszType), nil
+}
+
+// resolveType resolves the ssz layout of a Go type, consuming the size and limit
+// tags of the outermost dimension and passing the rest to the item types.
+func resolveType(typ reflect.Type, sizes []int, limits []int, tags *sizeTag) (*sszType, error) {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	var size, limit int
+	if len(sizes) > 0 {
+		size = sizes[0]
+	}
+	if len(limits) > 0 {
+		limit = limits[0]
+	}
+	switch {
+	case typ == uint256Type:
+		return &sszType{kind: kindUint256, size: 32, static: true, typ: typ}, nil
+
+	case typ == bitlistType:
+		if limit == 0 {
+			return nil, fmt.Errorf("%w: bitlist requires ssz-max tag", ErrUnsupportedType)
+		}
+		return &sszType{kind: kindBitlist, limit: uint64(limit), typ: typ}, nil
+	}
+	switch typ.Kind() {
+	case reflect.Bool:
+		return &sszType{kind: kindBool, size: 1, static: true, typ: typ}, nil
+
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &sszType{kind: kindUint, size: int(typ.Size()), static: true, typ: typ}, nil
+
+	case reflect.String:
+		if limit == 0 {
+			return nil, fmt.Errorf("%w: string requires ssz-max tag", ErrUnsupportedType)
+		}
+		return &sszType{kind: kindString, limit: uint64(limit), utf8: tags.utf8, typ: typ}, nil
+
+	case reflect.Array:
+		n := typ.Len()
+		if size != 0 && size != n && !(tags.bits && len(sizes) == 1) {
+			return nil, fmt.Errorf("%w: array of %d items, tag wants %d", ErrUnsupportedType, n, size)
+		}
+		switch elem := typ.Elem(); elem.Kind() {
+		case reflect.Uint8:
+			if tags.bits && len(sizes) == 1 {
+				if size < (n-1)*8+1 || size > n*8 {
+					return nil, fmt.Errorf("%w: array of bits supports %d-%d bits, tag wants %d", ErrUnsupportedType, (n-1)*8+1, n*8, size)
+				}
+				return &sszType{kind: kindBits, size: n, bits: size, static: true, typ: typ}, nil
+			}
+			return &sszType{kind: kindBytes, size: n, items: n, static: true, typ: typ}, nil
+
+		case reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			item := &sszType{kind: kindUint, size: int(elem.Size()), static: true, typ: elem}
+			return &sszType{kind: kindVector, size: n * item.size, items: n, elem: item, static: true, typ: typ}, nil
+
+		case reflect.Array:
+			item, err := resolveType(elem, tail(sizes), tail(limits), tags)
+			if err != nil {
+				return nil, err
+			}
+			if item.kind != kindBytes {
+				return nil, fmt.Errorf("%w: array of %v", ErrUnsupportedType, elem)
+			}
+			return &sszType{kind: kindVector, size: n * item.size, items: n, elem: item, static: true, typ: typ}, nil
+		}
+		return nil, fmt.Errorf("%w: array of %v", ErrUnsupportedType, typ.Elem())
+
+	case reflect.Slice:
+		switch elem := typ.Elem(); elem.Kind() {
+		case reflect.Uint8:
+			if size > 0 {
+				return &sszType{kind: kindBytes, size: size, items: size, static: true, typ: typ}, nil
+			}
+			if limit == 0 {
+				return nil, fmt.Errorf("%w: dynamic byte slice requires ssz-max tag", ErrUnsupportedType)
+			}
+			return &sszType{kind: kindBytes, limit: uint64(limit), typ: typ}, nil
+
+		case reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if size > 0 || limit == 0 {
+				return nil, fmt.Errorf("%w: slice of %v requires ssz-max tag", ErrUnsupportedType, elem)
+			}
+			item := &sszType{kind: kindUint, size: int(elem.Size()), static: true, typ: elem}
+			return &sszType{kind: kindList, limit: uint64(limit), sorted: tags.sorted, elem: item, typ: typ}, nil
+
+		case reflect.Array, reflect.Slice:
+			item, err := resolveType(elem, tail(sizes), tail(limits), tags)
+			if err != nil {
+				return nil, err
+			}
+			if item.kind != kindBytes {
+				return nil, fmt.Errorf("%w: slice of %v", ErrUnsupportedType, elem)
+			}
+			if size > 0 {
+				t := &sszType{kind: kindVector, items: size, elem: item, static: item.static, typ: typ}
+				if t.static {
+					t.size = size * item.size
+				}
+				return t, nil
+			}
+			if limit == 0 {
+				return nil, fmt.Errorf("%w: slice of %v requires ssz-max tag", ErrUnsupportedType, elem)
+			}
+			return &sszType{kind: kindList, limit: uint64(limit), elem: item, typ: typ}, nil
+
+		case reflect.Struct, reflect.Pointer:
+			if limit == 0 {
+				return nil, fmt.Errorf("%w: slice of %v requires ssz-max tag", ErrUnsupportedType, elem)
+			}
+			if elem.Kind() == reflect.Pointer {
+				elem = elem.Elem()
+			}
+			item, err := resolveContainer(elem)
+			if err != nil {
+				return nil, err
+			}
+			return &sszType{kind: kindList, limit: uint64(limit), elem: item, typ: typ}, nil
+		}
+		return nil, fmt.Errorf("%w: slice of %v", ErrUnsupportedType, typ.Elem())
+
+	case reflect.Struct:
+		return resolveContainer(typ)
+	}
+	return nil, fmt.Errorf("%w: %v", ErrUnsupportedType, typ)
+}
+
+// tail drops the outermost dimension of a size or limit tag.
+func tail(dims []int) []int {
+	if len(dims) == 0 {
+		return nil
+	}
+	return dims[1:]
+}
+
+// parseTags parses the ssz related struct tags of a field, following the same
+// rules as the code generator.
+func parseTags(tag reflect.StructTag) (bool, *sizeTag, error) {
+	tags := new(sizeTag)
+	switch tag.Get("ssz") {
+	case "-":
+		return true, nil, nil
+	case "bits":
+		tags.bits = true
+	case "utf8":
+		tags.utf8 = true
+	}
+	for ident, dims := range map[string]*[]int{"ssz-size": &tags.size, "ssz-max": &tags.limit} {
+		value, ok := tag.Lookup(ident)
+		if !ok {
+			continue
+		}
+		for _, part := range strings.Split(value, ",") {
+			if part == "?" {
+				*dims = append(*dims, 0)
+				continue
+			}
+			if value, ok := constants.Load(part); ok {
+				*dims = append(*dims, value.(int))
+				continue
+			}
+			num, err := strconv.ParseInt(part, 10, 64)
+			if err != nil {
+				return false, nil, err
+			}
+			*dims = append(*dims, int(num))
+		}
+	}
+	if value, ok := tag.Lookup("ssz-sorted"); ok {
+		if value != "asc" {
+			return false, nil, fmt.Errorf("invalid sort order %s (only asc supported)", value)
+		}
+		tags.sorted = true
+	}
+	if value, ok := tag.Lookup("ssz-fork"); ok {
+		filter, err := parseForkFilter(value)
+		if err != nil {
+			return false, nil, err
+		}
+		tags.filter = filter
+	}
+	if value, ok := tag.Lookup("ssz-max-fork"); ok {
+		if len(tags.limit) != 1 {
+			return false, nil, fmt.Errorf("ssz-max-fork tag requires a 1D ssz-max tag, has %v", tags.limit)
+		}
+		for _, override := range strings.Split(value, ",") {
+			parts := strings.Split(override, "=")
+			if len(parts) != 2 {
+				return false, nil, fmt.Errorf("invalid fork limit %s", override)
+			}
+			fork, ok := ssz.ForkMapping[parts[0]]
+			if !ok {
+				return false, nil, fmt.Errorf("invalid fork %s", parts[0])
+			}
+			num, err := strconv.ParseUint(parts[1], 10, 64)
+			if err != nil {
+				return false, nil, err
+			}
+			tags.forks = append(tags.forks, forkLimit{fork: fork, limit: num})
+		}
+	}
+	return false, tags, nil
+}
+
+// parseForkFilter converts an ssz-fork tag into a fork filter: either a single
+// fork the field was added ("x") or removed ("!x") in, or a list of half-open
+// fork ranges ("a-b,c").
+func parseForkFilter(value string) (ssz.ForkFilter, error) {
+	if strings.ContainsAny(value, ",-") {
+		var set ssz.ForkFilterSet
+		for _, part := range strings.Split(value, ",") {
+			bounds := strings.Split(part, "-")
+			if len(bounds) > 2 {
+				return ssz.ForkFilter{}, fmt.Errorf("invalid fork range %s", part)
+			}
+			var filter ssz.ForkFilter
+			for i, bound := range bounds {
+				fork, ok := ssz.ForkMapping[bound]
+				if !ok {
+					return ssz.ForkFilter{}, fmt.Errorf("invalid fork %s", bound)
+				}
+				if i == 0 {
+					filter.Added = fork
+				} else {
+					filter.Removed = fork
+				}
+			}
+			set = append(set, filter)
+		}
+		return ssz.ForkFilter{Ranges: &set}, nil
+	}
+	fork, ok := ssz.ForkMapping[strings.TrimPrefix(value, "!")]
+	if !ok {
+		return ssz.ForkFilter{}, fmt.Errorf("invalid fork tag %s", value)
+	}
+	if strings.HasPrefix(value, "!") {
+		return ssz.ForkFilter{Removed: fork}, nil
+	}
+	return ssz.ForkFilter{Added: fork}, nil
+}
+
+// limitOnFork returns the maximum item count of a list in a specific fork, being
+// the override of the latest fork already activated, or the base limit if none.
+func (t *sszType) limitOnFork(fork ssz.Fork) uint64 {
+	var (
+		limit  = t.limit
+		active = ssz.Fork(-1)
+	)
+	for _, override := range t.forks {
+		if override.fork <= fork && override.fork > active {
+			limit, active = override.limit, override.fork
+		}
+	}
+	return limit
+}
+
+// fixedSize returns the size of the static part of a type's encoding in a fork,
+// which is the full encoding for static types, or the offset for dynamic ones.
+func (t *sszType) fixedSize(fork ssz.Fork) int {
+	if !t.static {
+		return 4
+	}
+	if t.kind != kindContainer {
+		return t.size
+	}
+	var size int
+	for _, field := range t.fields {
+		if field.filter.Active(fork) {
+			size += field.typ.fixedSize(fork)
+		}
+	}
+	return size
+}
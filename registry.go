@@ -0,0 +1,90 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"fmt"
+	"sync"
+)
+
+// typeFactories is the table of object constructors registered per type name.
+var typeFactories sync.Map // map[string]func() Object
+
+// RegisterType associates a type name (e.g. a discriminator received alongside
+// the data over RPC) with a constructor of fresh instances of the type, allowing
+// objects to be decoded by name without a switch over all the supported types.
+//
+// Registering a name again replaces the previous constructor.
+func RegisterType(name string, factory func() Object) {
+	typeFactories.Store(name, factory)
+}
+
+// UnregisterType removes the constructor registered for a type name, if any.
+func UnregisterType(name string) {
+	typeFactories.Delete(name)
+}
+
+// LookupType retrieves the constructor registered for a type name.
+func LookupType(name string) (func() Object, bool) {
+	factory, ok := typeFactories.Load(name)
+	if !ok {
+		return nil, false
+	}
+	return factory.(func() Object), true
+}
+
+// DecodeAny parses a non-monolithic object of a registered type from a byte
+// buffer. If the type contains fork-specific rules, use DecodeAnyOnFork.
+func DecodeAny(blob []byte, typeName string) (Object, error) {
+	return DecodeAnyOnFork(blob, typeName, ForkUnknown)
+}
+
+// DecodeAnyOnFork parses a monolithic object of a registered type from a byte
+// buffer, into a fresh instance created by the type's registered constructor.
+//
+// If the type does not contain fork-specific rules, you can also use DecodeAny.
+func DecodeAnyOnFork(blob []byte, typeName string, fork Fork) (Object, error) {
+	factory, ok := LookupType(typeName)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, typeName)
+	}
+	obj := factory()
+	if err := DecodeFromBytesOnFork(blob, obj, fork); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// SchemaOfType derives the schema of a registered type in a fork, as exported by
+// `sszgen describe` (but only with the fields active in the fork), e.g. to hand
+// it to the other side of the wire along with the type's name.
+func SchemaOfType(typeName string, fork Fork) (*Schema, error) {
+	factory, ok := LookupType(typeName)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, typeName)
+	}
+	return schemaOf(factory(), fork)
+}
+
+// ValidateAny checks that a byte buffer is a valid encoding of a non-monolithic
+// object of a registered type, without decoding it. If the type contains fork-
+// specific rules, use ValidateAnyOnFork.
+func ValidateAny(blob []byte, typeName string) ([32]byte, error) {
+	return ValidateAnyOnFork(blob, typeName, ForkUnknown)
+}
+
+// ValidateAnyOnFork checks that a byte buffer is a valid encoding of a monolithic
+// object of a registered type, without decoding it. The blob is validated against
+// the schema of the type (sizes, offsets and limits) while it is merkleized, and
+// its root is returned, similarly to HashRootFromBytes.
+//
+// If the type does not contain fork-specific rules, you can also use ValidateAny.
+func ValidateAnyOnFork(blob []byte, typeName string, fork Fork) ([32]byte, error) {
+	schema, err := SchemaOfType(typeName, fork)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return HashRootOfSchemaOnFork(blob, schema, fork)
+}
@@ -0,0 +1,50 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+// RootCache is a merkle root cache meant to be embedded into an ssz type, which
+// allows hot paths hashing the same unchanged objects over and over again (e.g.
+// attestations) to skip all but the first hashing.
+//
+// The cache does not detect modifications by itself. Instead, it tracks a
+// generation counter that needs to be bumped via Invalidate on every change to
+// the object. The code generator can emit setters doing exactly that (see the
+// cache extra of sszgen), in which case all modifications should go through the
+// setters. Changes to nested objects are not tracked; invalidate the cache of
+// the outer object after modifying an inner one.
+//
+// The cache is not safe for concurrent use, and the code generator will skip it
+// when encoding, decoding or hashing the object it is embedded in.
+type RootCache struct {
+	gen    uint64   // Generation of the object, bumped on every modification
+	hashed uint64   // Generation of the object when the root was cached
+	fork   Fork     // Fork the cached root was computed in
+	root   [32]byte // Cached merkle root of the object
+	valid  bool     // Whether the cache was populated at all
+}
+
+// Invalidate bumps the generation of the object, discarding any cached root.
+func (c *RootCache) Invalidate() {
+	c.gen++
+}
+
+// Generation returns the number of times the object was modified since the cache
+// was created.
+func (c *RootCache) Generation() uint64 {
+	return c.gen
+}
+
+// HashSequentialCached returns the cached merkle root of an object if it was not
+// modified since it was last hashed in the same fork, or computes the root via
+// HashSequentialOnFork and caches it otherwise.
+func HashSequentialCached(obj Object, cache *RootCache, fork Fork) [32]byte {
+	if cache.valid && cache.hashed == cache.gen && cache.fork == fork {
+		return cache.root
+	}
+	cache.root = HashSequentialOnFork(obj, fork)
+	cache.hashed, cache.fork, cache.valid = cache.gen, fork, true
+
+	return cache.root
+}
@@ -0,0 +1,107 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ForkSchedule contains the epochs at which the forks of a consensus chain were
+// (or will be) activated, needed to derive the fork to decode historical objects
+// with.
+type ForkSchedule struct {
+	Epochs map[Fork]uint64 // Activation epoch of each scheduled fork
+}
+
+// LoadForkSchedule parses the fork schedule out of a standard consensus config
+// YAML (e.g. mainnet's config.yaml), collecting the XXX_FORK_EPOCH fields. Forks
+// are looked up by name in ForkMapping (so custom forks need to be registered
+// beforehand); unknown ones are ignored. Phase0 is always scheduled at genesis.
+//
+// Fields with non-scalar values (e.g. the BLOB_SCHEDULE list) are skipped.
+func LoadForkSchedule(r io.Reader) (ForkSchedule, error) {
+	var config yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&config); err != nil && err != io.EOF {
+		return ForkSchedule{}, fmt.Errorf("failed to parse config: %w", err)
+	}
+	schedule := ForkSchedule{Epochs: map[Fork]uint64{ForkPhase0: 0}}
+	if len(config.Content) == 0 {
+		return schedule, nil
+	}
+	fields := config.Content[0]
+	if fields.Kind != yaml.MappingNode {
+		return ForkSchedule{}, fmt.Errorf("failed to parse config: top level is not a mapping")
+	}
+	for i := 0; i+1 < len(fields.Content); i += 2 {
+		key, value := fields.Content[i], fields.Content[i+1]
+		if key.Kind != yaml.ScalarNode || value.Kind != yaml.ScalarNode {
+			continue
+		}
+		name, ok := strings.CutSuffix(key.Value, "_FORK_EPOCH")
+		if !ok {
+			continue
+		}
+		fork, ok := ForkMapping[strings.ToLower(name)]
+		if !ok {
+			continue
+		}
+		epoch, err := strconv.ParseUint(value.Value, 0, 64)
+		if err != nil {
+			return ForkSchedule{}, fmt.Errorf("invalid %s: %w", key.Value, err)
+		}
+		schedule.Epochs[fork] = epoch
+	}
+	return schedule, nil
+}
+
+// ForkAtEpoch returns the fork active at the given epoch, i.e. the latest fork
+// of the schedule activated at or before it. If multiple forks activate in the
+// same epoch (e.g. on devnets), the latest of them is returned. If no fork is
+// active yet, ForkUnknown is returned.
+func ForkAtEpoch(schedule ForkSchedule, epoch uint64) Fork {
+	active := ForkUnknown
+	for fork, activation := range schedule.Epochs {
+		if activation <= epoch && fork > active {
+			active = fork
+		}
+	}
+	return active
+}
+
+// forkData is the consensus spec's ForkData container, mixing a fork version
+// with the chain's genesis validators root to derive chain specific domains.
+type forkData struct {
+	CurrentVersion        [4]byte
+	GenesisValidatorsRoot [32]byte
+}
+
+func (f *forkData) SizeSSZ(sizer *Sizer) uint32 { return 4 + 32 }
+func (f *forkData) DefineSSZ(codec *Codec) {
+	DefineStaticBytes(codec, &f.CurrentVersion)        // Field (0) - CurrentVersion        -  4 bytes
+	DefineStaticBytes(codec, &f.GenesisValidatorsRoot) // Field (1) - GenesisValidatorsRoot - 32 bytes
+}
+
+// ComputeForkDataRoot computes the merkle root of the ForkData container, used
+// for separating signature domains across forks and chains.
+func ComputeForkDataRoot(currentVersion [4]byte, genesisValidatorsRoot [32]byte) [32]byte {
+	return HashSequential(&forkData{
+		CurrentVersion:        currentVersion,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	})
+}
+
+// ComputeForkDigest computes the 4-byte fork digest of a fork version on a chain,
+// used for gossip topic names and ENR records to separate the p2p networks.
+func ComputeForkDigest(currentVersion [4]byte, genesisValidatorsRoot [32]byte) [4]byte {
+	var digest [4]byte
+	root := ComputeForkDataRoot(currentVersion, genesisValidatorsRoot)
+	copy(digest[:], root[:4])
+	return digest
+}
@@ -0,0 +1,624 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+// Schema is a dynamic description of an ssz container, which can be used to
+// merkleize serialized objects without having a Go type for them (e.g. proxies
+// and relays). Its JSON layout matches the output of `sszgen describe`.
+type Schema struct {
+	Name   string         `json:"name"`
+	Fields []*SchemaField `json:"fields"`
+}
+
+// SchemaField is the dynamic description of a single field of an ssz container.
+type SchemaField struct {
+	Name     string        `json:"name"`
+	Encoding string        `json:"encoding"`          // ssz codec method family used for the field (e.g. SliceOfUint64s)
+	Size     int           `json:"size,omitempty"`    // Encoded size of static fields
+	Sizes    []int         `json:"sizes,omitempty"`   // Static item sizes for the different dimensions
+	Limits   []int         `json:"limits,omitempty"`  // Maximum item counts for the dynamic dimensions
+	Sorted   bool          `json:"sorted,omitempty"`  // Whether list items must be strictly increasing
+	Added    string        `json:"added,omitempty"`   // Fork the field was added in
+	Removed  string        `json:"removed,omitempty"` // Fork the field was removed in
+	Ranges   []SchemaRange `json:"ranges,omitempty"`  // Disjoint fork ranges the field is present in
+	Schema   *Schema       `json:"schema,omitempty"`  // Schema of the nested object (or list item) type
+
+	ForkLimits []SchemaLimit  `json:"forkLimits,omitempty"` // Fork specific overrides of the limit
+	ForkTypes  []SchemaSwitch `json:"forkTypes,omitempty"`  // Fork specific alternative encodings
+}
+
+// SchemaRange is a half-open range of forks [added, removed) a field is present in.
+type SchemaRange struct {
+	Added   string `json:"added,omitempty"`
+	Removed string `json:"removed,omitempty"`
+}
+
+// SchemaLimit is a fork specific override of the limit of a dynamic field, taking
+// effect from the given fork onward.
+type SchemaLimit struct {
+	Fork  string `json:"fork"`
+	Limit int    `json:"limit"`
+}
+
+// SchemaSwitch is an alternative encoding a field switches to from a specific fork
+// onward.
+type SchemaSwitch struct {
+	Fork     string `json:"fork"`
+	Encoding string `json:"encoding"`
+	Size     int    `json:"size,omitempty"`
+	Sizes    []int  `json:"sizes,omitempty"`
+	Limits   []int  `json:"limits,omitempty"`
+}
+
+// HashRootOfSchema computes the merkle root of a serialized non-monolithic object
+// described by a dynamic schema. If the type contains fork-specific rules, use
+// HashRootOfSchemaOnFork.
+func HashRootOfSchema(blob []byte, schema *Schema) ([32]byte, error) {
+	return HashRootOfSchemaOnFork(blob, schema, ForkUnknown)
+}
+
+// HashRootOfSchemaOnFork computes the merkle root of a serialized monolithic
+// object described by a dynamic schema, in the context of the given fork. The
+// blob is validated against the schema (sizes, offsets and limits) while it is
+// being hashed.
+func HashRootOfSchemaOnFork(blob []byte, schema *Schema, fork Fork) ([32]byte, error) {
+	codec := hasherPool.Get().(*Codec)
+	defer hasherPool.Put(codec)
+	defer codec.has.Reset()
+
+	codec.fork = fork
+	if err := codec.has.hashSchemaObject(blob, schema); err != nil {
+		return [32]byte{}, err
+	}
+	return codec.has.chunks[0], nil
+}
+
+// schemaCache is a cache of the schemas derived from types per fork.
+var schemaCache sync.Map // map[fieldLayoutKey]*Schema
+
+// HashRootFromBytes computes the merkle root of a serialized object directly from
+// its encoding, without decoding it into a Go object first. The type (and fork)
+// of obj is only used to derive the schema locating the chunks within the blob,
+// its contents are ignored. The blob is validated against the schema (sizes,
+// offsets and limits) while it is being hashed.
+//
+// The schema is derived by running the field definitions of a fresh instance of
+// the type through a recording hasher, and is cached per type and fork. Custom
+// hashers (DefineHasher) are supported as long as they only use the Hash methods
+// and don't depend on the contents of the fresh instance (e.g. checked sizes).
+func HashRootFromBytes(blob []byte, obj Object, fork Fork) ([32]byte, error) {
+	schema, err := schemaOf(obj, fork)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return HashRootOfSchemaOnFork(blob, schema, fork)
+}
+
+// schemaRecorder collects the fields of an object's type while its definitions
+// are run through a hasher, deriving its schema instead of its merkle root. As
+// the fork filters are evaluated by the hasher, only the fields active in the
+// fork are recorded, with any fork specific limits already resolved.
+type schemaRecorder struct {
+	fork   Fork           // Fork the schema is being derived for
+	fields []*SchemaField // Fields recorded so far, in definition order
+	err    error          // First failure encountered while recording (if any)
+}
+
+// schemaOf retrieves the schema of an object's type in a fork, deriving it from
+// the field definitions of a fresh instance if not yet cached.
+func schemaOf(obj Object, fork Fork) (*Schema, error) {
+	key := fieldLayoutKey{typ: reflect.TypeOf(obj), fork: fork}
+	if schema, ok := schemaCache.Load(key); ok {
+		return schema.(*Schema), nil
+	}
+	fresh := newObjectOf(obj)
+
+	recorder := &schemaRecorder{fork: fork}
+	codec := &Codec{fork: fork, has: &Hasher{schema: recorder}}
+	codec.has.codec = codec
+	codec.has.sizer = &Sizer{codec: codec}
+
+	fresh.DefineSSZ(codec)
+	if codec.has.broken != nil {
+		return nil, codec.has.broken
+	}
+	if recorder.err != nil {
+		return nil, recorder.err
+	}
+	// Name the fields after the active, non-content definitions of the layout,
+	// falling back to their indices if the type has no field names
+	var names []string
+	if layout, err := fieldLayoutOf(fresh, fork); err == nil {
+		for _, field := range layout {
+			if !field.content && field.size > 0 {
+				names = append(names, field.name)
+			}
+		}
+	}
+	for i, field := range recorder.fields {
+		if len(names) == len(recorder.fields) {
+			field.Name = names[i]
+		} else {
+			field.Name = "#" + strconv.Itoa(i)
+		}
+	}
+	schema := &Schema{Name: objectName(fresh), Fields: recorder.fields}
+	schemaCache.Store(key, schema)
+	return schema, nil
+}
+
+// field records a field definition without nested objects.
+func (r *schemaRecorder) field(encoding string, size int, sizes []int, limits ...uint64) *SchemaField {
+	field := &SchemaField{Encoding: encoding, Size: size, Sizes: sizes}
+	for _, limit := range limits {
+		if limit > math.MaxInt {
+			if r.err == nil {
+				r.err = fmt.Errorf("%w: %s field limit %d overflows int", ErrInvalidSchema, encoding, limit)
+			}
+			return field
+		}
+		field.Limits = append(field.Limits, int(limit))
+	}
+	r.fields = append(r.fields, field)
+	return field
+}
+
+// object records a field definition of a nested object, or a list of them, the
+// schema of the object type being derived recursively.
+func (r *schemaRecorder) object(encoding string, obj Object, limits ...uint64) {
+	schema, err := schemaOf(obj, r.fork)
+	if err != nil {
+		if r.err == nil {
+			r.err = err
+		}
+		return
+	}
+	r.field(encoding, 0, nil, limits...).Schema = schema
+}
+
+// lookupFork resolves a fork name referenced by the field's constraints.
+func (field *SchemaField) lookupFork(name string) (Fork, error) {
+	if name == "" {
+		return ForkUnknown, nil
+	}
+	fork, ok := ForkMapping[strings.ToLower(name)]
+	if !ok {
+		return ForkUnknown, fmt.Errorf("%w: field %s: unknown fork %s", ErrInvalidSchema, field.Name, name)
+	}
+	return fork, nil
+}
+
+// filter converts the fork constraints of the field into a fork filter.
+func (field *SchemaField) filter() (ForkFilter, error) {
+	lookup := field.lookupFork
+
+	var (
+		filter ForkFilter
+		err    error
+	)
+	if filter.Added, err = lookup(field.Added); err != nil {
+		return filter, err
+	}
+	if filter.Removed, err = lookup(field.Removed); err != nil {
+		return filter, err
+	}
+	if len(field.Ranges) == 0 {
+		return filter, nil
+	}
+	set := make(ForkFilterSet, 0, len(field.Ranges))
+	for _, r := range field.Ranges {
+		var sub ForkFilter
+		if sub.Added, err = lookup(r.Added); err != nil {
+			return filter, err
+		}
+		if sub.Removed, err = lookup(r.Removed); err != nil {
+			return filter, err
+		}
+		set = append(set, sub)
+	}
+	filter.Ranges = &set
+	return filter, nil
+}
+
+// onFork resolves the fork specific encodings and limits of the field, returning
+// a copy with the ones in effect in the given fork applied. Similarly to the codec
+// (LimitOnFork), the overrides of the latest fork already activated win.
+func (field *SchemaField) onFork(fork Fork) (*SchemaField, error) {
+	if len(field.ForkTypes) == 0 && len(field.ForkLimits) == 0 {
+		return field, nil
+	}
+	resolved := *field
+
+	active := Fork(-1)
+	for _, alt := range field.ForkTypes {
+		at, err := field.lookupFork(alt.Fork)
+		if err != nil {
+			return nil, err
+		}
+		if at <= fork && at > active {
+			resolved.Encoding, resolved.Size, resolved.Sizes, resolved.Limits = alt.Encoding, alt.Size, alt.Sizes, alt.Limits
+			active = at
+		}
+	}
+	active = Fork(-1)
+	for _, override := range field.ForkLimits {
+		at, err := field.lookupFork(override.Fork)
+		if err != nil {
+			return nil, err
+		}
+		if at <= fork && at > active {
+			if len(resolved.Limits) == 0 {
+				return nil, fmt.Errorf("%w: field %s: fork limit without base limit", ErrInvalidSchema, field.Name)
+			}
+			resolved.Limits = append([]int{}, resolved.Limits...)
+			resolved.Limits[len(resolved.Limits)-1] = override.Limit
+			active = at
+		}
+	}
+	return &resolved, nil
+}
+
+// staticSize returns the size of the static part of the field's encoding, which
+// is the full encoding for static fields, or the offset for dynamic ones.
+func (field *SchemaField) staticSize() (int, bool, error) {
+	switch field.Encoding {
+	case "StaticObject":
+		if field.Schema == nil {
+			return 0, false, fmt.Errorf("%w: field %s: missing nested schema", ErrInvalidSchema, field.Name)
+		}
+		size, static, err := field.Schema.staticSize()
+		if err != nil {
+			return 0, false, err
+		}
+		if !static {
+			return 0, false, fmt.Errorf("%w: field %s: static object with dynamic schema", ErrInvalidSchema, field.Name)
+		}
+		return size, true, nil
+
+	case "DynamicObject", "DynamicBytes", "String", "UTF8String", "SliceOfBits", "SliceOfUint16s", "SliceOfUint32s",
+		"SliceOfUint64s", "SliceOfStaticBytes", "SliceOfDynamicBytes", "CheckedArrayOfDynamicBytes",
+		"SliceOfStaticObjects", "SliceOfDynamicObjects", "MapOfStaticEntries", "MapOfDynamicEntries":
+		return 4, false, nil
+
+	default:
+		if field.Size <= 0 {
+			return 0, false, fmt.Errorf("%w: field %s: missing static size", ErrInvalidSchema, field.Name)
+		}
+		return field.Size, true, nil
+	}
+}
+
+// staticSize returns the size of the static part of an object's encoding, and
+// whether the object is fully static.
+func (schema *Schema) staticSize() (int, bool, error) {
+	if len(schema.Fields) == 0 {
+		return 0, false, fmt.Errorf("%w: %s: no fields", ErrInvalidSchema, schema.Name)
+	}
+	var (
+		total  int
+		static = true
+	)
+	for _, field := range schema.Fields {
+		size, fixed, err := field.staticSize()
+		if err != nil {
+			return 0, false, err
+		}
+		total += size
+		static = static && fixed
+	}
+	return total, static, nil
+}
+
+// hashSchemaObject hashes a serialized container described by a schema.
+func (h *Hasher) hashSchemaObject(blob []byte, schema *Schema) error {
+	// Split the blob into the fields active in the current fork
+	var (
+		fields []*SchemaField
+		slots  [][]byte
+		dyns   []int // Indices of the dynamic fields within the slots
+		offset int
+	)
+	for _, field := range schema.Fields {
+		filter, err := field.filter()
+		if err != nil {
+			return err
+		}
+		if !filter.Active(h.codec.fork) {
+			continue
+		}
+		if field, err = field.onFork(h.codec.fork); err != nil {
+			return err
+		}
+		size, static, err := field.staticSize()
+		if err != nil {
+			return err
+		}
+		if offset+size > len(blob) {
+			return fmt.Errorf("%w: field %s", io.ErrUnexpectedEOF, field.Name)
+		}
+		if !static {
+			dyns = append(dyns, len(slots))
+		}
+		fields = append(fields, field)
+		slots = append(slots, blob[offset:offset+size])
+		offset += size
+	}
+	if len(fields) == 0 {
+		return fmt.Errorf("%w: %s: no fields in fork", ErrInvalidSchema, schema.Name)
+	}
+	// Resolve the dynamic fields' content from their offsets
+	if len(dyns) == 0 && offset != len(blob) {
+		return fmt.Errorf("%w: %s: consumed %d, have %d", ErrObjectSlotSizeMismatch, schema.Name, offset, len(blob))
+	}
+	starts := make([]int, len(dyns)+1)
+	for i, idx := range dyns {
+		start := int(binary.LittleEndian.Uint32(slots[idx]))
+		switch {
+		case i == 0 && start != offset:
+			return fmt.Errorf("%w: field %s: have %d, want %d", ErrFirstOffsetMismatch, fields[idx].Name, start, offset)
+		case i > 0 && start < starts[i-1]:
+			return fmt.Errorf("%w: field %s: have %d, previous %d", ErrBadOffsetProgression, fields[idx].Name, start, starts[i-1])
+		case start > len(blob):
+			return fmt.Errorf("%w: field %s: offset %d, capacity %d", ErrOffsetBeyondCapacity, fields[idx].Name, start, len(blob))
+		}
+		starts[i] = start
+	}
+	starts[len(dyns)] = len(blob)
+	for i, idx := range dyns {
+		slots[idx] = blob[starts[i]:starts[i+1]]
+	}
+	// Hash all the fields into the container's trie
+	h.descendLayer()
+	for i, field := range fields {
+		if err := h.hashSchemaField(slots[i], field); err != nil {
+			return err
+		}
+	}
+	h.ascendLayer(0)
+	return nil
+}
+
+// hashSchemaField hashes the serialized content of a single field.
+func (h *Hasher) hashSchemaField(blob []byte, field *SchemaField) error {
+	switch field.Encoding {
+	case "Bool", "Uint8", "Uint16", "Uint32", "Uint64", "Uint256", "Uint256BigInt",
+		"StaticBytes", "CheckedStaticBytes", "ArrayOfBits", "CheckedBits", "ArrayOfUint16s",
+		"ArrayOfUint32s", "ArrayOfUint64s", "CheckedStaticUint64s":
+		h.hashBytes(blob)
+
+	case "UnsafeArrayOfStaticBytes", "ArrayOfStaticBytes", "CheckedArrayOfStaticBytes",
+		"UnsafeArrayOfBits", "CheckedArrayOfBits", "UnsafeArrayOfCheckedBits":
+		if len(field.Sizes) != 2 || field.Sizes[1] <= 0 || len(blob)%field.Sizes[1] != 0 {
+			return fmt.Errorf("%w: field %s: invalid array item size %v", ErrInvalidSchema, field.Name, field.Sizes)
+		}
+		h.descendLayer()
+		for ; len(blob) > 0; blob = blob[field.Sizes[1]:] {
+			h.hashBytes(blob[:field.Sizes[1]])
+		}
+		h.ascendLayer(0)
+
+	case "ArrayOfArrayOfUint16s", "ArrayOfArrayOfUint32s", "ArrayOfArrayOfUint64s":
+		if len(field.Sizes) != 3 || field.Sizes[1]*field.Sizes[2] <= 0 || len(blob)%(field.Sizes[1]*field.Sizes[2]) != 0 {
+			return fmt.Errorf("%w: field %s: invalid array item size %v", ErrInvalidSchema, field.Name, field.Sizes)
+		}
+		size := field.Sizes[1] * field.Sizes[2]
+
+		h.descendLayer()
+		for ; len(blob) > 0; blob = blob[size:] {
+			h.hashBytes(blob[:size])
+		}
+		h.ascendLayer(0)
+
+	case "StaticObject", "DynamicObject":
+		if field.Schema == nil {
+			return fmt.Errorf("%w: field %s: missing nested schema", ErrInvalidSchema, field.Name)
+		}
+		return h.hashSchemaObject(blob, field.Schema)
+
+	case "DynamicBytes", "String", "UTF8String":
+		limit, err := field.limit(0)
+		if err != nil {
+			return err
+		}
+		if uint64(len(blob)) > limit {
+			return fmt.Errorf("%w: field %s: decoded %d, max %d", ErrMaxLengthExceeded, field.Name, len(blob), limit)
+		}
+		h.descendMixinLayer()
+		h.insertBlobChunks(blob)
+		h.ascendMixinLayer(uint64(len(blob)), ChunkCountOfList(limit, 1))
+
+	case "SliceOfBits":
+		limit, err := field.limit(0)
+		if err != nil {
+			return err
+		}
+		if len(blob) == 0 || blob[len(blob)-1] == 0 {
+			return fmt.Errorf("%w: field %s", ErrJunkInBitlist, field.Name)
+		}
+		if bits := bitfield.Bitlist(blob).Len(); bits > limit {
+			return fmt.Errorf("%w: field %s: decoded %d bits, max %d bits", ErrMaxItemsExceeded, field.Name, bits, limit)
+		}
+		HashSliceOfBits(h, bitfield.Bitlist(blob), limit)
+
+	case "SliceOfUint16s", "SliceOfUint32s", "SliceOfUint64s":
+		limit, err := field.limit(0)
+		if err != nil {
+			return err
+		}
+		size := map[string]int{"SliceOfUint16s": 2, "SliceOfUint32s": 4, "SliceOfUint64s": 8}[field.Encoding]
+		if len(blob)%size != 0 {
+			return fmt.Errorf("%w: field %s: length %d, item size %d", ErrDynamicStaticsIndivisible, field.Name, len(blob), size)
+		}
+		if items := uint64(len(blob) / size); items > limit {
+			return fmt.Errorf("%w: field %s: decoded %d, max %d", ErrMaxItemsExceeded, field.Name, items, limit)
+		}
+		h.descendMixinLayer()
+		h.insertBlobChunks(blob)
+		h.ascendMixinLayer(uint64(len(blob)/size), ChunkCountOfList(limit, uint64(size)))
+
+	case "SliceOfStaticBytes":
+		limit, err := field.limit(0)
+		if err != nil {
+			return err
+		}
+		if len(field.Sizes) != 2 || field.Sizes[1] <= 0 {
+			return fmt.Errorf("%w: field %s: invalid list item size %v", ErrInvalidSchema, field.Name, field.Sizes)
+		}
+		items, err := schemaStaticItems(blob, field.Sizes[1], limit, field.Name)
+		if err != nil {
+			return err
+		}
+		h.descendMixinLayer()
+		for _, item := range items {
+			h.hashBytes(item)
+		}
+		h.ascendMixinLayer(uint64(len(items)), limit)
+
+	case "SliceOfDynamicBytes", "CheckedArrayOfDynamicBytes":
+		limit, err := field.limit(0)
+		if err != nil {
+			return err
+		}
+		maxSize, err := field.limit(1)
+		if err != nil {
+			return err
+		}
+		items, err := schemaDynamicItems(blob, limit, field.Name)
+		if err != nil {
+			return err
+		}
+		if field.Encoding == "CheckedArrayOfDynamicBytes" {
+			if uint64(len(items)) != limit {
+				return fmt.Errorf("%w: field %s: decoded %d items, want %d", ErrObjectSlotSizeMismatch, field.Name, len(items), limit)
+			}
+			h.descendLayer()
+		} else {
+			h.descendMixinLayer()
+		}
+		for _, item := range items {
+			if uint64(len(item)) > maxSize {
+				return fmt.Errorf("%w: field %s: decoded %d, max %d", ErrMaxLengthExceeded, field.Name, len(item), maxSize)
+			}
+			h.descendMixinLayer()
+			h.insertBlobChunks(item)
+			h.ascendMixinLayer(uint64(len(item)), ChunkCountOfList(maxSize, 1))
+		}
+		if field.Encoding == "CheckedArrayOfDynamicBytes" {
+			h.ascendLayer(0)
+		} else {
+			h.ascendMixinLayer(uint64(len(items)), limit)
+		}
+
+	case "SliceOfStaticObjects", "SliceOfDynamicObjects", "MapOfStaticEntries", "MapOfDynamicEntries":
+		limit, err := field.limit(0)
+		if err != nil {
+			return err
+		}
+		if field.Schema == nil {
+			return fmt.Errorf("%w: field %s: missing nested schema", ErrInvalidSchema, field.Name)
+		}
+		size, static, err := field.Schema.staticSize()
+		if err != nil {
+			return err
+		}
+		var items [][]byte
+		if static {
+			items, err = schemaStaticItems(blob, size, limit, field.Name)
+		} else {
+			items, err = schemaDynamicItems(blob, limit, field.Name)
+		}
+		if err != nil {
+			return err
+		}
+		h.descendMixinLayer()
+		for _, item := range items {
+			if err := h.hashSchemaObject(item, field.Schema); err != nil {
+				return err
+			}
+		}
+		h.ascendMixinLayer(uint64(len(items)), limit)
+
+	default:
+		return fmt.Errorf("%w: field %s: unsupported encoding %s", ErrInvalidSchema, field.Name, field.Encoding)
+	}
+	return nil
+}
+
+// limit retrieves the maximum item count of a dynamic dimension of the field.
+func (field *SchemaField) limit(dim int) (uint64, error) {
+	if len(field.Limits) <= dim || field.Limits[dim] <= 0 {
+		return 0, fmt.Errorf("%w: field %s: missing limit for dimension %d", ErrInvalidSchema, field.Name, dim)
+	}
+	return uint64(field.Limits[dim]), nil
+}
+
+// schemaStaticItems splits a serialized list of static items into the individual
+// item encodings.
+func schemaStaticItems(blob []byte, size int, limit uint64, name string) ([][]byte, error) {
+	if len(blob)%size != 0 {
+		return nil, fmt.Errorf("%w: field %s: length %d, item size %d", ErrDynamicStaticsIndivisible, name, len(blob), size)
+	}
+	if items := uint64(len(blob) / size); items > limit {
+		return nil, fmt.Errorf("%w: field %s: decoded %d, max %d", ErrMaxItemsExceeded, name, items, limit)
+	}
+	items := make([][]byte, 0, len(blob)/size)
+	for ; len(blob) > 0; blob = blob[size:] {
+		items = append(items, blob[:size])
+	}
+	return items, nil
+}
+
+// schemaDynamicItems splits a serialized list of dynamic items into the individual
+// item encodings, based on the offsets prefixing the list.
+func schemaDynamicItems(blob []byte, limit uint64, name string) ([][]byte, error) {
+	if len(blob) == 0 {
+		return nil, nil
+	}
+	if len(blob) < 4 {
+		return nil, fmt.Errorf("%w: field %s", ErrShortCounterOffset, name)
+	}
+	first := binary.LittleEndian.Uint32(blob)
+	switch {
+	case first == 0:
+		return nil, fmt.Errorf("%w: field %s", ErrZeroCounterOffset, name)
+	case first&3 != 0:
+		return nil, fmt.Errorf("%w: field %s: offset %d", ErrBadCounterOffset, name, first)
+	case int(first) > len(blob):
+		return nil, fmt.Errorf("%w: field %s: offset %d, capacity %d", ErrOffsetBeyondCapacity, name, first, len(blob))
+	}
+	count := first >> 2
+	if uint64(count) > limit {
+		return nil, fmt.Errorf("%w: field %s: decoded %d, max %d", ErrMaxItemsExceeded, name, count, limit)
+	}
+	items := make([][]byte, count)
+	for i := uint32(0); i < count; i++ {
+		start := binary.LittleEndian.Uint32(blob[i<<2:])
+		end := uint32(len(blob))
+		if i+1 < count {
+			end = binary.LittleEndian.Uint32(blob[(i+1)<<2:])
+		}
+		switch {
+		case end < start:
+			return nil, fmt.Errorf("%w: field %s: offset %d, previous %d", ErrBadOffsetProgression, name, end, start)
+		case int(end) > len(blob):
+			return nil, fmt.Errorf("%w: field %s: offset %d, capacity %d", ErrOffsetBeyondCapacity, name, end, len(blob))
+		}
+		items[i] = blob[start:end]
+	}
+	return items, nil
+}
@@ -5,12 +5,18 @@
 package ssz
 
 import (
+	"fmt"
+	"reflect"
+
 	"github.com/prysmaticlabs/go-bitfield"
 )
 
 // Sizer is an SSZ static and dynamic size computer.
 type Sizer struct {
 	codec *Codec // Self-referencing to have access to fork contexts
+
+	memoize bool                     // Whether dynamic object sizes are memoized (only while encoding)
+	memo    map[DynamicObject]uint32 // Total sizes of dynamic objects, keyed by pointer
 }
 
 // Fork retrieves the current fork (if any) that the sizer is operating in.
@@ -18,12 +24,128 @@ func (siz *Sizer) Fork() Fork {
 	return siz.codec.fork
 }
 
+// SizeWithFork runs the size computations in fn with the sizer's fork context
+// overridden, restoring it afterwards. It is the SizeSSZ counterpart of fields
+// defined via DefineWithFork.
+func SizeWithFork(siz *Sizer, fork Fork, fn func() uint32) uint32 {
+	prev := siz.codec.fork
+	siz.codec.fork = fork
+	defer func() { siz.codec.fork = prev }()
+
+	return fn()
+}
+
+// SizeOf returns the total serialized size of a static or dynamic object, using
+// the fork context of the sizer. It is meant to be used by hand-written SizeSSZ
+// methods to size nested objects without coupling to the free size functions.
+// Nil objects are sized as their zero values, same as with SizeDynamicObject.
+func (siz *Sizer) SizeOf(obj Object) uint32 {
+	if obj != nil && isNilObject(obj) {
+		// If the object is nil, pull up it's zero value. This will be very slow,
+		// but it should not happen in production, only during tests mostly.
+		obj = zeroValueOf(obj)
+	}
+	switch v := obj.(type) {
+	case StaticObject:
+		return v.SizeSSZ(siz)
+	case DynamicObject:
+		return siz.sizeDynamic(v)
+	default:
+		panic(fmt.Sprintf("unsupported type: %T", obj))
+	}
+}
+
+// sizeDynamic returns the total size of a dynamic object. Whilst encoding, every
+// level of nesting needs the sizes of the dynamic objects below it to compute
+// the offsets, so the sizes are memoized by object pointer to avoid walking the
+// same subtrees over and over again.
+func (siz *Sizer) sizeDynamic(obj DynamicObject) uint32 {
+	if !siz.memoize || reflect.TypeOf(obj).Kind() != reflect.Pointer {
+		return obj.SizeSSZ(siz, false)
+	}
+	if size, ok := siz.memo[obj]; ok {
+		return size
+	}
+	size := obj.SizeSSZ(siz, false)
+	if siz.memo == nil {
+		siz.memo = make(map[DynamicObject]uint32)
+	}
+	siz.memo[obj] = size
+	return size
+}
+
+// startMemo enables memoizing the sizes of dynamic objects. It must only be
+// enabled for the duration of a single encoding, during which the objects being
+// encoded cannot be modified.
+func (siz *Sizer) startMemo() {
+	siz.memoize = true
+}
+
+// stopMemo disables memoizing the sizes of dynamic objects and drops all the
+// sizes (and map entries) cached so far.
+func (siz *Sizer) stopMemo() {
+	siz.memoize = false
+	clear(siz.memo)
+	siz.codec.releaseEntries()
+}
+
+// SizeDynamicBytes is the method variant of the SizeDynamicBytes function.
+func (siz *Sizer) SizeDynamicBytes(blobs []byte) uint32 {
+	return SizeDynamicBytes(siz, blobs)
+}
+
+// SizeString is the method variant of the SizeString function, limited to the
+// builtin string type since methods cannot have type parameters.
+func (siz *Sizer) SizeString(str string) uint32 {
+	return SizeString(siz, str)
+}
+
+// SizeSliceOfBits is the method variant of the SizeSliceOfBits function.
+func (siz *Sizer) SizeSliceOfBits(bits bitfield.Bitlist) uint32 {
+	return SizeSliceOfBits(siz, bits)
+}
+
+// SizeSliceOfUint16s is the method variant of the SizeSliceOfUint16s function,
+// limited to the builtin uint16 type since methods cannot have type parameters.
+func (siz *Sizer) SizeSliceOfUint16s(ns []uint16) uint32 {
+	return SizeSliceOfUint16s(siz, ns)
+}
+
+// SizeSliceOfUint32s is the method variant of the SizeSliceOfUint32s function,
+// limited to the builtin uint32 type since methods cannot have type parameters.
+func (siz *Sizer) SizeSliceOfUint32s(ns []uint32) uint32 {
+	return SizeSliceOfUint32s(siz, ns)
+}
+
+// SizeSliceOfUint64s is the method variant of the SizeSliceOfUint64s function,
+// limited to the builtin uint64 type since methods cannot have type parameters.
+func (siz *Sizer) SizeSliceOfUint64s(ns []uint64) uint32 {
+	return SizeSliceOfUint64s(siz, ns)
+}
+
+// SizeCheckedArrayOfDynamicBytes is the method variant of the function with the
+// same name.
+func (siz *Sizer) SizeCheckedArrayOfDynamicBytes(blobs [][]byte, size uint64) uint32 {
+	return SizeCheckedArrayOfDynamicBytes(siz, blobs, size)
+}
+
+// SizeSliceOfDynamicBytes is the method variant of the SizeSliceOfDynamicBytes
+// function.
+func (siz *Sizer) SizeSliceOfDynamicBytes(blobs [][]byte) uint32 {
+	return SizeSliceOfDynamicBytes(siz, blobs)
+}
+
 // SizeDynamicBytes returns the serialized size of the dynamic part of a dynamic
 // blob.
 func SizeDynamicBytes(siz *Sizer, blobs []byte) uint32 {
 	return uint32(len(blobs))
 }
 
+// SizeString returns the serialized size of the dynamic part of a string.
+func SizeString[T ~string](siz *Sizer, str T) uint32 {
+	return uint32(len(str))
+}
+
 // SizeSliceOfBits returns the serialized size of the dynamic part of a slice of
 // bits.
 //
@@ -35,12 +157,33 @@ func SizeSliceOfBits(siz *Sizer, bits bitfield.Bitlist) uint32 {
 	return uint32(len(bitlistZero))
 }
 
+// SizeSliceOfUint16s returns the serialized size of the dynamic part of a dynamic
+// list of uint16s.
+func SizeSliceOfUint16s[T ~uint16](siz *Sizer, ns []T) uint32 {
+	return uint32(len(ns)) * 2
+}
+
+// SizeSliceOfUint32s returns the serialized size of the dynamic part of a dynamic
+// list of uint32s.
+func SizeSliceOfUint32s[T ~uint32](siz *Sizer, ns []T) uint32 {
+	return uint32(len(ns)) * 4
+}
+
 // SizeSliceOfUint64s returns the serialized size of the dynamic part of a dynamic
 // list of uint64s.
 func SizeSliceOfUint64s[T ~uint64](siz *Sizer, ns []T) uint32 {
 	return uint32(len(ns)) * 8
 }
 
+// SizeSliceOfUint64sPointer returns the serialized size of the dynamic part of
+// a dynamic list of uint64s behind a pointer, treating nil as an empty list.
+func SizeSliceOfUint64sPointer[T ~uint64](siz *Sizer, ns *[]T) uint32 {
+	if ns == nil {
+		return 0
+	}
+	return SizeSliceOfUint64s(siz, *ns)
+}
+
 // SizeDynamicObject returns the serialized size of the dynamic part of a dynamic
 // object.
 func SizeDynamicObject[T newableDynamicObject[U], U any](siz *Sizer, obj T) uint32 {
@@ -49,7 +192,18 @@ func SizeDynamicObject[T newableDynamicObject[U], U any](siz *Sizer, obj T) uint
 		// but it should not happen in production, only during tests mostly.
 		obj = zeroValueDynamic[T, U]()
 	}
-	return obj.SizeSSZ(siz, false)
+	return siz.sizeDynamic(obj)
+}
+
+// SizeGenericDynamicObject returns the serialized size of the dynamic part of a
+// dynamic object passed as a type parameter of a generic container.
+func SizeGenericDynamicObject[T DynamicObject](siz *Sizer, obj T) uint32 {
+	if isNilGeneric(obj) {
+		// If the object is nil, pull up it's zero value. This will be very slow,
+		// but it should not happen in production, only during tests mostly.
+		obj = zeroValueGeneric[T]()
+	}
+	return siz.sizeDynamic(obj)
 }
 
 // SizeSliceOfStaticBytes returns the serialized size of the dynamic part of a dynamic
@@ -61,6 +215,26 @@ func SizeSliceOfStaticBytes[T commonBytesLengths](siz *Sizer, blobs []T) uint32
 	return uint32(len(blobs) * len(blobs[0]))
 }
 
+// SizeSliceOfStaticBytesPointer returns the serialized size of the dynamic part
+// of a dynamic list of static binary blobs behind a pointer, treating nil as an
+// empty list.
+func SizeSliceOfStaticBytesPointer[T commonBytesLengths](siz *Sizer, blobs *[]T) uint32 {
+	if blobs == nil {
+		return 0
+	}
+	return SizeSliceOfStaticBytes(siz, *blobs)
+}
+
+// SizeCheckedArrayOfDynamicBytes returns the serialized size of the dynamic part
+// of a static array of dynamic blobs.
+func SizeCheckedArrayOfDynamicBytes(siz *Sizer, blobs [][]byte, size uint64) uint32 {
+	total := uint32(4 * size) // 4-byte offsets + dynamic data later
+	for i := 0; i < len(blobs) && i < int(size); i++ {
+		total += uint32(len(blobs[i]))
+	}
+	return total
+}
+
 // SizeSliceOfDynamicBytes returns the serialized size of the dynamic part of a dynamic
 // list of dynamic blobs.
 func SizeSliceOfDynamicBytes(siz *Sizer, blobs [][]byte) uint32 {
@@ -80,12 +254,53 @@ func SizeSliceOfStaticObjects[T StaticObject](siz *Sizer, objects []T) uint32 {
 	return uint32(len(objects)) * objects[0].SizeSSZ(siz)
 }
 
+// SizeSliceOfStaticObjectValues returns the serialized size of the dynamic part
+// of a dynamic list of static objects stored by value.
+func SizeSliceOfStaticObjectValues[T newableStaticObject[U], U any](siz *Sizer, objects []U) uint32 {
+	if len(objects) == 0 {
+		return 0
+	}
+	return uint32(len(objects)) * T(&objects[0]).SizeSSZ(siz)
+}
+
+// SizeSliceOfStaticObjectsPointer returns the serialized size of the dynamic
+// part of a dynamic list of static ssz objects behind a pointer, treating nil
+// as an empty list.
+func SizeSliceOfStaticObjectsPointer[T StaticObject](siz *Sizer, objects *[]T) uint32 {
+	if objects == nil {
+		return 0
+	}
+	return SizeSliceOfStaticObjects(siz, *objects)
+}
+
 // SizeSliceOfDynamicObjects returns the serialized size of the dynamic part of
 // a dynamic list of dynamic objects.
 func SizeSliceOfDynamicObjects[T DynamicObject](siz *Sizer, objects []T) uint32 {
 	var size uint32
 	for _, obj := range objects {
-		size += 4 + obj.SizeSSZ(siz, false) // 4-byte offset + dynamic data later
+		size += 4 + siz.sizeDynamic(obj) // 4-byte offset + dynamic data later
 	}
 	return size
 }
+
+// SizeSliceOfDynamicObjectsPointer returns the serialized size of the dynamic
+// part of a dynamic list of dynamic ssz objects behind a pointer, treating nil
+// as an empty list.
+func SizeSliceOfDynamicObjectsPointer[T DynamicObject](siz *Sizer, objects *[]T) uint32 {
+	if objects == nil {
+		return 0
+	}
+	return SizeSliceOfDynamicObjects(siz, *objects)
+}
+
+// SizeMapOfStaticEntries returns the serialized size of the dynamic part of a map
+// encoded as a dynamic list of static key/value containers.
+func SizeMapOfStaticEntries[T newableStaticMapEntry[K, V, U], U any, K comparable, V any](siz *Sizer, m map[K]V) uint32 {
+	return SizeSliceOfStaticObjects(siz, mapToEntries[T](siz.codec, m))
+}
+
+// SizeMapOfDynamicEntries returns the serialized size of the dynamic part of a map
+// encoded as a dynamic list of dynamic key/value containers.
+func SizeMapOfDynamicEntries[T newableDynamicMapEntry[K, V, U], U any, K comparable, V any](siz *Sizer, m map[K]V) uint32 {
+	return SizeSliceOfDynamicObjects(siz, mapToEntries[T](siz.codec, m))
+}
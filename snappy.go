@@ -0,0 +1,169 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/golang/snappy"
+)
+
+// snappyBufferPool is a pool of scratch buffers to hold the uncompressed ssz
+// data for snappy block (de)compression without hitting Go's GC constantly.
+var snappyBufferPool = sync.Pool{
+	New: func() any {
+		return new([]byte)
+	},
+}
+
+// snappyMaxPooledBuffer is the largest scratch buffer returned to the pool, so a
+// single huge message does not pin its memory for the lifetime of the process.
+const snappyMaxPooledBuffer = 4 * 1024 * 1024
+
+// releaseSnappyBuffer returns a scratch buffer to the pool, unless it grew too
+// large to be worth retaining.
+func releaseSnappyBuffer(scratch *[]byte) {
+	if cap(*scratch) <= snappyMaxPooledBuffer {
+		snappyBufferPool.Put(scratch)
+	}
+}
+
+// EncodeToSnappyStream serializes a non-monolithic object into a data stream,
+// compressing it with snappy's framing format. If the type contains fork-specific
+// rules, use EncodeToSnappyStreamOnFork.
+func EncodeToSnappyStream(w io.Writer, obj Object) error {
+	return EncodeToSnappyStreamOnFork(w, obj, ForkUnknown)
+}
+
+// EncodeToSnappyStreamOnFork serializes a monolithic object into a data stream,
+// compressing it with snappy's framing format. If the type does not contain fork-
+// specific rules, you can also use EncodeToSnappyStream.
+//
+// The underlying writer is not closed, but the compressor is flushed.
+func EncodeToSnappyStreamOnFork(w io.Writer, obj Object, fork Fork) error {
+	sw := snappy.NewBufferedWriter(w)
+	if err := EncodeToStreamOnFork(sw, obj, fork); err != nil {
+		return err
+	}
+	return sw.Close() // flushes, doesn't close the underlying writer
+}
+
+// EncodeToSnappyBytes serializes a non-monolithic object into a byte buffer,
+// compressing it with snappy's block format. If the type contains fork-specific
+// rules, use EncodeToSnappyBytesOnFork.
+//
+// The compressed data is written into buf if it is large enough, otherwise a new
+// slice is allocated. The returned slice is the compressed data.
+func EncodeToSnappyBytes(buf []byte, obj Object) ([]byte, error) {
+	return EncodeToSnappyBytesOnFork(buf, obj, ForkUnknown)
+}
+
+// EncodeToSnappyBytesOnFork serializes a monolithic object into a byte buffer,
+// compressing it with snappy's block format. If the type does not contain fork-
+// specific rules, you can also use EncodeToSnappyBytes.
+//
+// The compressed data is written into buf if it is large enough, otherwise a new
+// slice is allocated. The returned slice is the compressed data.
+//
+// Snappy's block format needs the entire input available, so the object is first
+// serialized into a full size (pooled) scratch buffer. Use EncodeToSnappyStream
+// to avoid the intermediate copy.
+func EncodeToSnappyBytesOnFork(buf []byte, obj Object, fork Fork) ([]byte, error) {
+	scratch := snappyBufferPool.Get().(*[]byte)
+	defer releaseSnappyBuffer(scratch)
+
+	size := int(SizeOnFork(obj, fork))
+	if cap(*scratch) < size {
+		*scratch = make([]byte, size)
+	}
+	*scratch = (*scratch)[:size]
+
+	if err := EncodeToBytesOnFork(*scratch, obj, fork); err != nil {
+		return nil, err
+	}
+	return snappy.Encode(buf[:cap(buf)], *scratch), nil
+}
+
+// DecodeFromSnappyStream parses a non-monolithic object with the given (not
+// compressed) size out of a snappy framed stream. If the type contains fork-
+// specific rules, use DecodeFromSnappyStreamOnFork.
+func DecodeFromSnappyStream(r io.Reader, obj Object, size uint32) error {
+	return DecodeFromSnappyStreamOnFork(r, obj, size, ForkUnknown)
+}
+
+// DecodeFromSnappyStreamOnFork parses a monolithic object with the given (not
+// compressed) size out of a snappy framed stream. If the type does not contain
+// fork-specific rules, you can also use DecodeFromSnappyStream.
+//
+// The compressed stream is never read past the longest framing that size bytes
+// could have been encoded into, so a corrupt frame cannot eat into whatever data
+// follows it in the stream.
+func DecodeFromSnappyStreamOnFork(r io.Reader, obj Object, size uint32, fork Fork) error {
+	return DecodeFromStreamOnFork(snappy.NewReader(io.LimitReader(r, snappyFramedMaxLen(size))), obj, size, fork)
+}
+
+// snappyFramedMaxLen returns the maximum number of bytes size bytes of data can
+// take up in snappy's framing format: the stream identifier, followed by chunks
+// of at most 64KiB, each with a header, checksum and worst case block encoding.
+func snappyFramedMaxLen(size uint32) int64 {
+	const (
+		identifierLen  = 10
+		chunkHeaderLen = 8
+		chunkMaxLen    = 65536
+	)
+	chunks := (int64(size) + chunkMaxLen - 1) / chunkMaxLen
+
+	// The chunk payloads sum up to snappy.MaxEncodedLen over the split input
+	return identifierLen + chunks*chunkHeaderLen + chunks*32 + int64(size) + int64(size)/6
+}
+
+// DecodeFromSnappyBytes parses a non-monolithic object from a snappy block
+// compressed byte buffer. If the type contains fork-specific rules, use
+// DecodeFromSnappyBytesOnFork.
+func DecodeFromSnappyBytes(blob []byte, obj Object) error {
+	return DecodeFromSnappyBytesOnFork(blob, obj, ForkUnknown)
+}
+
+// DecodeFromSnappyBytesOnFork parses a monolithic object from a snappy block
+// compressed byte buffer. If the type does not contain fork-specific rules, you
+// can also use DecodeFromSnappyBytes.
+//
+// Snappy's block format can only be decompressed in one go, so the data is first
+// inflated into a full size (pooled) scratch buffer. Use DecodeFromSnappyStream
+// to avoid the intermediate copy.
+//
+// The decompressed length announced by the blob is only sanity checked against
+// what the compressed data could possibly expand to (and the size of static
+// objects). Use DecodeFromSnappyBytesCheckedOnFork to enforce a tighter limit
+// on data received from the network.
+func DecodeFromSnappyBytesOnFork(blob []byte, obj Object, fork Fork) error {
+	size, err := snappy.DecodedLen(blob)
+	if err != nil {
+		return err
+	}
+	// Reject bogus lengths before allocating anything for them. Snappy's densest
+	// element (a 2 byte offset copy) expands 3 bytes into at most 64.
+	if uint64(size)*3 > uint64(len(blob))*64 {
+		return fmt.Errorf("%w: decoded length %d from %d bytes", snappy.ErrCorrupt, size, len(blob))
+	}
+	if _, ok := obj.(StaticObject); ok {
+		if want := SizeOnFork(obj, fork); uint64(size) != uint64(want) {
+			return fmt.Errorf("%w: decompressed %d bytes, static size %d", ErrObjectSlotSizeMismatch, size, want)
+		}
+	}
+	scratch := snappyBufferPool.Get().(*[]byte)
+	defer releaseSnappyBuffer(scratch)
+
+	if cap(*scratch) < size {
+		*scratch = make([]byte, size)
+	}
+	data, err := snappy.Decode((*scratch)[:size], blob)
+	if err != nil {
+		return err
+	}
+	return DecodeFromBytesOnFork(data, obj, fork)
+}
@@ -0,0 +1,192 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package spectest parses the YAML value representation used by the Ethereum
+// consensus spec tests (value.yaml) into ssz types, so test harnesses can check
+// decoded objects against the expected values, not only the merkle roots.
+//
+// The representation maps containers to YAML mappings keyed by the snake_case
+// field names, lists and vectors to sequences, integers to (possibly quoted)
+// decimals and byte blobs (including bitlists and bitvectors) to 0x prefixed
+// hex strings.
+package spectest
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/holiman/uint256"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	uint256Type = reflect.TypeOf(uint256.Int{})
+	bigIntType  = reflect.TypeOf(big.Int{})
+)
+
+// Unmarshal parses the YAML representation of a value into the Go value pointed
+// to by v. Container fields are matched case insensitively, ignoring underscores
+// (e.g. parent_root to ParentRoot), and fields missing from the YAML are left
+// untouched (e.g. the fields of monolithic types not present in a fork). Fields
+// named differently than in the specs can be renamed via a yaml struct tag.
+func Unmarshal(data []byte, v any) error {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Pointer || val.IsNil() {
+		return fmt.Errorf("spectest: non-pointer or nil target %T", v)
+	}
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) != 1 {
+			return fmt.Errorf("spectest: expected a single YAML document, got %d", len(node.Content))
+		}
+		return decode(node.Content[0], val.Elem(), "")
+	}
+	return decode(&node, val.Elem(), "")
+}
+
+// decode parses a YAML node into a settable Go value, using the path for error
+// reporting.
+func decode(node *yaml.Node, v reflect.Value, path string) error {
+	// Allocate pointers and handle the special big number types first
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	switch v.Type() {
+	case uint256Type:
+		n, err := uint256.FromDecimal(node.Value)
+		if err != nil {
+			return fmt.Errorf("spectest: %s: invalid uint256 %q: %v", pathName(path), node.Value, err)
+		}
+		v.Set(reflect.ValueOf(*n))
+		return nil
+
+	case bigIntType:
+		n, ok := new(big.Int).SetString(node.Value, 10)
+		if !ok {
+			return fmt.Errorf("spectest: %s: invalid big integer %q", pathName(path), node.Value)
+		}
+		v.Set(reflect.ValueOf(*n))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(node.Value)
+		if err != nil {
+			return fmt.Errorf("spectest: %s: invalid boolean %q", pathName(path), node.Value)
+		}
+		v.SetBool(b)
+
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(node.Value, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("spectest: %s: invalid uint%d %q", pathName(path), v.Type().Bits(), node.Value)
+		}
+		v.SetUint(n)
+
+	case reflect.String:
+		v.SetString(node.Value)
+
+	case reflect.Array, reflect.Slice:
+		// Byte blobs (bitfields included) are hex strings, anything else a list
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return decodeBytes(node, v, path)
+		}
+		if node.Kind != yaml.SequenceNode {
+			return fmt.Errorf("spectest: %s: expected sequence, got %q", pathName(path), node.Value)
+		}
+		if v.Kind() == reflect.Array {
+			if len(node.Content) != v.Len() {
+				return fmt.Errorf("spectest: %s: vector length mismatch: have %d, want %d", pathName(path), len(node.Content), v.Len())
+			}
+		} else {
+			v.Set(reflect.MakeSlice(v.Type(), len(node.Content), len(node.Content)))
+		}
+		for i, item := range node.Content {
+			if err := decode(item, v.Index(i), path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return fmt.Errorf("spectest: %s: expected mapping, got %q", pathName(path), node.Value)
+		}
+		fields := make(map[string]int)
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() || field.Tag.Get("ssz") == "-" {
+				continue
+			}
+			// Use the YAML name if the Go one deviates from the spec
+			name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+			if name == "" {
+				name = field.Name
+			}
+			fields[normalize(name)] = i
+		}
+		for i := 0; i < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+
+			index, ok := fields[normalize(key)]
+			if !ok {
+				return fmt.Errorf("spectest: %s: unknown field %q in %s", pathName(path), key, v.Type())
+			}
+			name := v.Type().Field(index).Name
+			if path != "" {
+				name = path + "." + name
+			}
+			if err := decode(node.Content[i+1], v.Field(index), name); err != nil {
+				return err
+			}
+		}
+
+	default:
+		return fmt.Errorf("spectest: %s: unsupported type %s", pathName(path), v.Type())
+	}
+	return nil
+}
+
+// decodeBytes parses a 0x prefixed hex string into a byte array or slice.
+func decodeBytes(node *yaml.Node, v reflect.Value, path string) error {
+	if node.Kind != yaml.ScalarNode || !strings.HasPrefix(node.Value, "0x") {
+		return fmt.Errorf("spectest: %s: expected hex string, got %q", pathName(path), node.Value)
+	}
+	blob, err := hex.DecodeString(node.Value[2:])
+	if err != nil {
+		return fmt.Errorf("spectest: %s: invalid hex string %q: %v", pathName(path), node.Value, err)
+	}
+	if v.Kind() == reflect.Array {
+		if len(blob) != v.Len() {
+			return fmt.Errorf("spectest: %s: byte vector length mismatch: have %d, want %d", pathName(path), len(blob), v.Len())
+		}
+		reflect.Copy(v, reflect.ValueOf(blob))
+		return nil
+	}
+	v.SetBytes(blob)
+	return nil
+}
+
+// normalize converts a field name into a canonical form that both the Go and the
+// YAML (snake_case) names map to.
+func normalize(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// pathName returns the path of a value for error reporting, naming the root.
+func pathName(path string) string {
+	if path == "" {
+		return "<root>"
+	}
+	return path
+}
@@ -6,10 +6,15 @@
 package ssz
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"reflect"
+	"runtime"
 	"sync"
 	"unsafe"
+
+	"golang.org/x/sync/errgroup"
 )
 
 // Object defines the methods a type needs to implement to be used as a ssz
@@ -46,6 +51,68 @@ type DynamicObject interface {
 	SizeSSZ(siz *Sizer, fixed bool) uint32
 }
 
+// RootedObject defines an optional method a static or dynamic object can also
+// implement to provide its own merkle root, short-circuiting the hasher so that
+// it does not descend into its fields. This is useful for objects with a cached
+// or externally computed root (e.g. stubbed out bodies with known hashes).
+//
+// Note, the root is only used when the object is embedded into another one (or
+// a list of them). Hashing the object directly will always recompute the root,
+// so it is safe to implement HashTreeRootSSZ via the HashXYZ methods.
+type RootedObject interface {
+	// HashTreeRootSSZ returns the precomputed merkle root of the ssz object.
+	HashTreeRootSSZ() [32]byte
+}
+
+// NamedObject defines an optional method a static or dynamic object can also
+// implement to provide the names of its fields for decoding error reports. The
+// names need to be in the order the fields are defined in DefineSSZ, dynamic
+// fields appearing twice (once for their offsets and once for their contents).
+type NamedObject interface {
+	// NamesSSZ returns the field names in the order of their definitions.
+	NamesSSZ() []string
+}
+
+// EncodeHookedObject defines an optional method a static or dynamic object can
+// also implement to be notified before being encoded as the top level object,
+// allowing it to normalize internal caches or validate its invariants. An error
+// aborts the encoding. Nested objects are not notified.
+type EncodeHookedObject interface {
+	// BeforeSSZEncode is called before the object is serialized.
+	BeforeSSZEncode() error
+}
+
+// DecodeHookedObject defines an optional method a static or dynamic object can
+// also implement to be notified after being successfully decoded as the top
+// level object, allowing it to rebuild internal caches or validate invariants.
+// An error is returned from the decoding. Nested objects are not notified.
+type DecodeHookedObject interface {
+	// AfterSSZDecode is called after the object is deserialized.
+	AfterSSZDecode() error
+}
+
+// UpgradableObject defines an optional method a monolith object can implement to
+// convert itself between the layouts of different forks. These are generated by
+// sszgen via the upgrade extra, but can also be implemented manually.
+type UpgradableObject interface {
+	// UpgradeSSZ converts the object from the layout of one fork to another.
+	UpgradeSSZ(from Fork, to Fork)
+}
+
+// MapEntry defines the methods the key/value containers of a map need to implement
+// to allow encoding the map as an ssz list of entries, sorted by key. These are
+// generated by sszgen for map fields, but can also be implemented manually.
+type MapEntry[K comparable, V any] interface {
+	// EntrySSZ returns the key and value stored in the container.
+	EntrySSZ() (K, V)
+
+	// SetEntrySSZ sets the key and value stored in the container.
+	SetEntrySSZ(key K, value V)
+
+	// CompareKeySSZ compares the key stored in the container to another one.
+	CompareKeySSZ(key K) int
+}
+
 // encoderPool is a pool of SSZ encoders to reuse some tiny internal helpers
 // without hitting Go's GC constantly.
 var encoderPool = sync.Pool{
@@ -57,6 +124,15 @@ var encoderPool = sync.Pool{
 	},
 }
 
+// StreamReadAhead is the maximum number of bytes stream decoding buffers up from
+// the underlying reader ahead of the fields being decoded, to avoid issuing many
+// tiny reads (e.g. on network sockets). The read-ahead is sized to the declared
+// message size if that is smaller, and never reads past the end of the message.
+//
+// Setting it to 0 disables the read-ahead, reading fields from the stream one by
+// one. This is useful if the reader is already buffered or is in memory.
+var StreamReadAhead uint32 = 64 * 1024
+
 // decoderPool is a pool of SSZ decoders to reuse some tiny internal helpers
 // without hitting Go's GC constantly.
 var decoderPool = sync.Pool{
@@ -102,28 +178,100 @@ func EncodeToStream(w io.Writer, obj Object) error {
 // Do not use this method with a bytes.Buffer to write into a []byte slice, as that
 // will do double the byte copying. For that use case, use EncodeToBytesOnFork.
 func EncodeToStreamOnFork(w io.Writer, obj Object, fork Fork) error {
+	return encodeToStream(w, obj, fork, LittleEndian, nil)
+}
+
+// encodeToStream is the internal implementation of EncodeToStreamOnFork, with
+// the byte order of the basic types and the statistics destination configurable.
+func encodeToStream(w io.Writer, obj Object, fork Fork, order ByteOrder, stats *Stats) error {
+	if err := beforeEncode(obj); err != nil {
+		return err
+	}
+	return encodeToStreamUnhooked(w, obj, fork, order, stats)
+}
+
+// encodeToStreamUnhooked is the implementation of encodeToStream, without the
+// pre-encoding hook being invoked.
+func encodeToStreamUnhooked(w io.Writer, obj Object, fork Fork, order ByteOrder, stats *Stats) error {
 	codec := encoderPool.Get().(*Codec)
 	defer encoderPool.Put(codec)
 
-	codec.fork, codec.enc.outWriter = fork, w
+	codec.fork, codec.order, codec.enc.outWriter = fork, order, w
+	codec.enc.sizer.startMemo()
+	defer codec.enc.sizer.stopMemo()
+
 	switch v := obj.(type) {
 	case StaticObject:
-		v.DefineSSZ(codec)
+		codec.enc.encodeObject(v)
 	case DynamicObject:
 		codec.enc.offsetDynamics(v.SizeSSZ(codec.enc.sizer, true))
-		v.DefineSSZ(codec)
+		codec.enc.stats.enter()
+		codec.enc.encodeObject(v)
+		codec.enc.stats.leave()
 	default:
 		panic(fmt.Sprintf("unsupported type: %T", obj))
 	}
+	if codec.enc.err != nil {
+		codec.enc.annotateError(objectName(obj))
+	}
 	// Retrieve any errors, zero out the sink and return
 	err := codec.enc.err
+	codec.enc.stats.publish(stats)
 
 	codec.enc.outWriter = nil
 	codec.enc.err = nil
+	codec.order = LittleEndian
 
 	return err
 }
 
+// beforeEncode invokes the pre-encoding hook of a top level object, if defined.
+func beforeEncode(obj Object) error {
+	if hooked, ok := obj.(EncodeHookedObject); ok {
+		return hooked.BeforeSSZEncode()
+	}
+	return nil
+}
+
+// EncodeToStreamAndHash serializes a non-monolithic object into a data stream,
+// also computing its merkle root. If the type contains fork-specific rules, use
+// EncodeToStreamAndHashOnFork.
+func EncodeToStreamAndHash(w io.Writer, obj Object) ([32]byte, error) {
+	return EncodeToStreamAndHashOnFork(w, obj, ForkUnknown)
+}
+
+// EncodeToStreamAndHashOnFork serializes a monolithic object into a data stream,
+// also computing its merkle root. If the type does not contain fork-specific
+// rules, you can also use EncodeToStreamAndHash.
+//
+// Note, this is not a single pass over the object: it is traversed twice, once
+// by the encoder on a background thread and once by the hasher on the calling
+// one. When both the encoding and the root are needed (e.g. when publishing a
+// block), this takes the encoding off the critical path, but the total work is
+// the same as calling EncodeToStreamOnFork and HashRootOnFork separately (the
+// hashing dominates the cost by far, so a shared traversal would not save much).
+// The object must not be modified until the method returns.
+//
+// The pre-encoding hook of the object (if any) is run before either starts, so
+// the root is computed over the same normalized object that is encoded.
+func EncodeToStreamAndHashOnFork(w io.Writer, obj Object, fork Fork) ([32]byte, error) {
+	if err := beforeEncode(obj); err != nil {
+		return [32]byte{}, err
+	}
+	errc := make(chan error, 1)
+	go func() {
+		errc <- encodeToStreamUnhooked(w, obj, fork, LittleEndian, nil)
+	}()
+	root, err := HashRootOnFork(obj, fork)
+	if encErr := <-errc; encErr != nil {
+		return [32]byte{}, encErr
+	}
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return root, nil
+}
+
 // EncodeToBytes serializes a non-monolithic object into a byte buffer. If the
 // type contains fork-specific rules, use EncodeToBytesOnFork.
 //
@@ -141,28 +289,106 @@ func EncodeToBytes(buf []byte, obj Object) error {
 // some writer, as that would double the memory use for the temporary buffer.
 // For that use case, use EncodeToStreamOnFork.
 func EncodeToBytesOnFork(buf []byte, obj Object, fork Fork) error {
-	// Sanity check that we have enough space to serialize into
-	if size := SizeOnFork(obj, fork); int(size) > len(buf) {
-		return fmt.Errorf("%w: buffer %d bytes, object %d bytes", ErrBufferTooSmall, len(buf), size)
+	return encodeToBytes(buf, obj, fork, LittleEndian, false, nil, nil)
+}
+
+// EncodeValue serializes a non-monolithic object held by value (e.g. a struct
+// stored in an interface) into a newly allocated byte buffer. If the type
+// contains fork-specific rules, use EncodeValueOnFork.
+func EncodeValue(v any) ([]byte, error) {
+	return EncodeValueOnFork(v, ForkUnknown)
+}
+
+// EncodeValueOnFork serializes a monolithic object held by value into a newly
+// allocated byte buffer. If the type does not contain fork-specific rules, you
+// can also use EncodeValue.
+//
+// Generated types implement the ssz methods on pointer receivers, so a value of
+// them (e.g. one retrieved from a cache as an interface) is not an Object. This
+// method copies such values into a new instance and encodes that, leaving the
+// original untouched. Values already implementing Object (e.g. pointers, or types
+// generated with value receivers) are encoded directly.
+func EncodeValueOnFork(v any, fork Fork) ([]byte, error) {
+	obj, ok := v.(Object)
+	if !ok {
+		val := reflect.ValueOf(v)
+		if !val.IsValid() {
+			return nil, fmt.Errorf("ssz: cannot encode nil value")
+		}
+		ptr := reflect.New(val.Type())
+		ptr.Elem().Set(val)
+
+		if obj, ok = ptr.Interface().(Object); !ok {
+			return nil, fmt.Errorf("ssz: %T is not an ssz object", v)
+		}
+	}
+	buf := make([]byte, SizeOnFork(obj, fork))
+	if err := EncodeToBytesOnFork(buf, obj, fork); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// EncodeToBytesChecked serializes a non-monolithic object into a byte buffer,
+// validating the size limits of all dynamic fields. If the type contains fork-
+// specific rules, use EncodeToBytesCheckedOnFork.
+func EncodeToBytesChecked(buf []byte, obj Object) error {
+	return EncodeToBytesCheckedOnFork(buf, obj, ForkUnknown)
+}
+
+// EncodeToBytesCheckedOnFork serializes a monolithic object into a byte buffer,
+// validating the size limits of all dynamic fields (list lengths, blob sizes and
+// bitlist lengths). If any is exceeded, an error is returned instead of silently
+// producing a payload that remote peers would reject.
+func EncodeToBytesCheckedOnFork(buf []byte, obj Object, fork Fork) error {
+	return encodeToBytes(buf, obj, fork, LittleEndian, true, nil, nil)
+}
+
+// encodeToBytes is the internal implementation of EncodeToBytesOnFork, with the
+// byte order of the basic types and the statistics destination configurable, the
+// size limit checks optionally enabled and the field positions optionally traced.
+func encodeToBytes(buf []byte, obj Object, fork Fork, order ByteOrder, checked bool, stats *Stats, trace *activeTracer) error {
+	if err := beforeEncode(obj); err != nil {
+		return err
 	}
 	codec := encoderPool.Get().(*Codec)
 	defer encoderPool.Put(codec)
 
-	codec.fork, codec.enc.outBuffer = fork, buf
+	// Sanity check that we have enough space to serialize into. The sizes of the
+	// dynamic objects are memoized, so the encoding can reuse them for offsets.
+	codec.fork = fork
+	codec.enc.sizer.startMemo()
+	defer codec.enc.sizer.stopMemo()
+
+	if size := codec.enc.sizer.SizeOf(obj); int(size) > len(buf) {
+		return fmt.Errorf("%w: buffer %d bytes, object %d bytes", ErrBufferTooSmall, len(buf), size)
+	}
+	codec.enc.outBuffer, codec.enc.checked = buf, checked
+	codec.enc.trace = trace
+	codec.order = order
 	switch v := obj.(type) {
 	case StaticObject:
-		v.DefineSSZ(codec)
+		codec.enc.encodeObject(v)
 	case DynamicObject:
 		codec.enc.offsetDynamics(v.SizeSSZ(codec.enc.sizer, true))
-		v.DefineSSZ(codec)
+		codec.enc.stats.enter()
+		codec.enc.encodeObject(v)
+		codec.enc.stats.leave()
 	default:
 		panic(fmt.Sprintf("unsupported type: %T", obj))
 	}
+	if codec.enc.err != nil {
+		codec.enc.annotateError(objectName(obj))
+	}
 	// Retrieve any errors, zero out the sink and return
 	err := codec.enc.err
+	codec.enc.stats.publish(stats)
 
 	codec.enc.outBuffer = nil
 	codec.enc.err = nil
+	codec.enc.checked = false
+	codec.enc.trace = nil
+	codec.order = LittleEndian
 
 	return err
 }
@@ -183,34 +409,91 @@ func DecodeFromStream(r io.Reader, obj Object, size uint32) error {
 // Do not use this method with a bytes.Buffer to read from a []byte slice, as that
 // will double the byte copying. For that use case, use DecodeFromBytesOnFork.
 func DecodeFromStreamOnFork(r io.Reader, obj Object, size uint32, fork Fork) error {
+	return decodeFromStream(r, obj, size, fork, LittleEndian, nil, nil)
+}
+
+// DecodeFromStreamWithOptions parses a monolithic object with the given size out
+// of a stream, customizing the decoder's behavior via the given options.
+func DecodeFromStreamWithOptions(r io.Reader, obj Object, size uint32, fork Fork, opts *DecodeOptions) error {
+	return decodeFromStream(r, obj, size, fork, LittleEndian, opts, nil)
+}
+
+// decodeFromStream is the internal implementation of DecodeFromStreamOnFork,
+// with the byte order of the basic types, the decoding options and the masking
+// of the top level fields configurable.
+func decodeFromStream(r io.Reader, obj Object, size uint32, fork Fork, order ByteOrder, opts *DecodeOptions, mask *fieldMasker) error {
+	// Ensure the payload carries the fields the type expects, if known
+	if opts != nil && opts.ActiveFields != nil {
+		if err := checkActiveFields(opts.ActiveFields, obj, fork); err != nil {
+			return err
+		}
+	}
 	// Retrieve a new decoder codec and set its data source
+	var done bool
 	codec := decoderPool.Get().(*Codec)
-	defer decoderPool.Put(codec)
+	defer func() {
+		// Zero out the source and the caller's options, even if decoding panics,
+		// so the allocator and callbacks are never retained by the pool. A codec
+		// abandoned midway has its internal stacks dirty, so don't reuse it.
+		codec.dec.setReader(nil, 0)
+		codec.dec.mask = nil
+		codec.dec.err = nil
+		codec.dec.alloc = nil
+		codec.dec.skipUnknown = false
+		codec.dec.onUnknown = nil
+		codec.dec.unknownAt, codec.dec.unknownGap = 0, 0
+		codec.dec.budget, codec.dec.spent = 0, 0
+		codec.dec.prealloc = 0
+		codec.order = LittleEndian
 
-	codec.fork, codec.dec.inReader = fork, r
+		if done {
+			decoderPool.Put(codec)
+		}
+	}()
+
+	codec.fork, codec.order = fork, order
+	codec.dec.setReader(r, size)
+	codec.dec.mask = mask
+	if opts != nil {
+		codec.dec.alloc = opts.Alloc
+		codec.dec.skipUnknown = opts.SkipUnknownFields
+		codec.dec.onUnknown = opts.UnknownFields
+		codec.dec.budget = opts.Budget
+		codec.dec.prealloc = opts.PreallocFactor
+	}
 
 	// Start a decoding round with length enforcement in place
 	codec.dec.descendIntoSlot(size)
 
 	switch v := obj.(type) {
 	case StaticObject:
-		v.DefineSSZ(codec)
+		codec.dec.decodeObject(v)
 	case DynamicObject:
 		codec.dec.startDynamics(v.SizeSSZ(codec.dec.sizer, true))
-		v.DefineSSZ(codec)
+		codec.dec.decodeObject(v)
 		codec.dec.flushDynamics()
 	default:
 		panic(fmt.Sprintf("unsupported type: %T", obj))
 	}
+	codec.dec.skipUnknownTrailer()
+	if codec.dec.err != nil {
+		codec.dec.annotateError(objectName(obj))
+	}
 	codec.dec.ascendFromSlot()
+	done = true
 
-	// Retrieve any errors, zero out the source and return
+	// Retrieve any errors and return
 	err := codec.dec.err
+	if opts != nil {
+		codec.dec.stats.publish(opts.Stats)
+	} else {
+		codec.dec.stats.publish(nil)
+	}
 
-	codec.dec.inReader = nil
-	codec.dec.err = nil
-
-	return err
+	if err != nil {
+		return err
+	}
+	return afterDecode(obj)
 }
 
 // DecodeFromBytes parses a non-monolithic object from a byte buffer. If the type
@@ -230,41 +513,256 @@ func DecodeFromBytes(blob []byte, obj Object) error {
 // some reader, as that would double the memory use for the temporary buffer. For
 // that use case, use DecodeFromStreamOnFork instead.
 func DecodeFromBytesOnFork(blob []byte, obj Object, fork Fork) error {
+	return decodeFromBytes(blob, obj, fork, LittleEndian, nil, nil, nil)
+}
+
+// DecodeFromBytesTrailing parses a non-monolithic static object from the start of
+// a byte buffer, tolerating any data after it, and returns the number of bytes
+// the object occupied. If the type contains fork-specific rules, use
+// DecodeFromBytesTrailingOnFork.
+func DecodeFromBytesTrailing(blob []byte, obj Object) (int, error) {
+	return DecodeFromBytesTrailingOnFork(blob, obj, ForkUnknown)
+}
+
+// DecodeFromBytesTrailingOnFork parses a monolithic static object from the start
+// of a byte buffer, tolerating any data after it, and returns the number of bytes
+// the object occupied. This allows decoding concatenated objects one by one.
+//
+// Note, only static objects are self-delimiting in SSZ. The last dynamic field
+// of a dynamic object extends until the end of its data, so it would silently
+// swallow any trailing data. Dynamic objects are rejected with ErrNotSelfDelimiting,
+// concatenating those needs external framing (e.g. length prefixes, see ReadFrame).
+func DecodeFromBytesTrailingOnFork(blob []byte, obj Object, fork Fork) (int, error) {
+	if _, ok := obj.(StaticObject); !ok {
+		return 0, fmt.Errorf("%w: %T", ErrNotSelfDelimiting, obj)
+	}
+	size := int(SizeOnFork(obj, fork))
+	if len(blob) < size {
+		return 0, io.ErrUnexpectedEOF
+	}
+	if err := decodeFromBytes(blob[:size], obj, fork, LittleEndian, nil, nil, nil); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+// DecodeOptions customizes the behavior of the decoder beyond the SSZ rules.
+type DecodeOptions struct {
+	// Alloc, if set, is used to allocate the byte slices of the decoded fields
+	// (dynamic bytes, bitlists and slices of static bytes), allowing them to be
+	// drawn from an arena or pool and released wholesale afterwards. It must
+	// return a slice of length n; its contents will be overwritten.
+	//
+	// The decoded objects will reference the returned memory, so it must not be
+	// reused for as long as they are live.
+	Alloc func(n int) []byte
+
+	// SkipUnknownFields makes the decoder tolerate a top level object carrying
+	// more fixed-region data than its type declares in the fork being decoded
+	// (i.e. it was produced by a newer fork appending fields), skipping the
+	// unknown trailing fields instead of rejecting the input. This allows older
+	// tooling to do best-effort inspection of future fork payloads.
+	//
+	// Only the fixed region is understood: if the unknown fields include dynamic
+	// ones, their content is attributed to the last known dynamic field.
+	SkipUnknownFields bool
+
+	// UnknownFields, if set, is called with the offset and raw content of the
+	// unknown fields skipped via SkipUnknownFields. The content must not be
+	// retained after the callback returns.
+	UnknownFields func(offset uint32, blob []byte)
+
+	// Budget, if non-zero, is the maximum number of bytes the decoder may newly
+	// allocate for the decoded fields (byte blobs, bitfields, lists and their
+	// items), across the entire object. Decoding is aborted with an error once
+	// exceeded, protecting against inputs that individually respect each field's
+	// limits, but add up to excessive memory across many fields. Memory reused
+	// from the decoded object is not accounted for.
+	Budget uint64
+
+	// PreallocFactor, if above 1, makes the decoder allocate dynamic byte blobs
+	// (and the item slices of blob lists, but not their packed contents) with a
+	// capacity of the decoded size multiplied by the factor, capped at the field's
+	// maximum size. Decoding larger values into the
+	// same object later (e.g. the ExtraData or Transactions of the next payload)
+	// can then reuse the memory instead of reallocating, trading memory headroom
+	// for fewer allocations. The headroom is accounted for in the Budget.
+	PreallocFactor float64
+
+	// ActiveFields, if set, is the active fields bitvector of the payload (e.g.
+	// the prefix of a StableContainer), with the bits packed as on the wire. It
+	// is checked against the top level fields of the object's type present in
+	// the fork being decoded (see ActiveFields) before decoding, rejecting any
+	// mismatch with ErrActiveFieldsMismatch. The object needs to implement the
+	// NamedObject interface.
+	ActiveFields []byte
+
+	// Stats, if set, receives the structural counters of the decoding, for fuzz
+	// oracles to assert invariants with. They are only collected in sszdebug
+	// builds, see Stats.
+	Stats *Stats
+}
+
+// DecodeFromBytesWithOptions parses a monolithic object from a byte buffer,
+// customizing the decoder's behavior via the given options.
+func DecodeFromBytesWithOptions(blob []byte, obj Object, fork Fork, opts *DecodeOptions) error {
+	return decodeFromBytes(blob, obj, fork, LittleEndian, opts, nil, nil)
+}
+
+// decodeFromBytes is the internal version of DecodeFromBytesOnFork that can also
+// decode in alternative byte orders, with custom options, collect the field
+// layout of the decoded object for Dump and seek past fields not in a mask.
+func decodeFromBytes(blob []byte, obj Object, fork Fork, order ByteOrder, opts *DecodeOptions, trace *dumpTracer, mask *fieldMasker) error {
 	// Reject decoding from an empty slice
 	if len(blob) == 0 {
 		return io.ErrUnexpectedEOF
 	}
+	// Ensure the payload carries the fields the type expects, if known
+	if opts != nil && opts.ActiveFields != nil {
+		if err := checkActiveFields(opts.ActiveFields, obj, fork); err != nil {
+			return err
+		}
+	}
 	// Retrieve a new decoder codec and set its data source
+	var done bool
 	codec := decoderPool.Get().(*Codec)
-	defer decoderPool.Put(codec)
+	defer func() {
+		// Zero out the source and the caller's options, even if decoding panics,
+		// so the allocator and callbacks are never retained by the pool. A codec
+		// abandoned midway has its internal stacks dirty, so don't reuse it.
+		codec.dec.inBufEnd = 0
+		codec.dec.inBuffer = nil
+		codec.dec.err = nil
+		codec.dec.trace = nil
+		codec.dec.mask = nil
+		codec.dec.alloc = nil
+		codec.dec.skipUnknown = false
+		codec.dec.onUnknown = nil
+		codec.dec.unknownAt, codec.dec.unknownGap = 0, 0
+		codec.dec.budget, codec.dec.spent = 0, 0
+		codec.dec.prealloc = 0
+		codec.order = LittleEndian
 
-	codec.fork = fork
+		if done {
+			decoderPool.Put(codec)
+		}
+	}()
+
+	codec.fork, codec.order = fork, order
 	codec.dec.inBuffer = blob
 	codec.dec.inBufEnd = uintptr(unsafe.Pointer(&blob[0])) + uintptr(len(blob))
+	codec.dec.trace = trace
+	codec.dec.mask = mask
+	if opts != nil {
+		codec.dec.alloc = opts.Alloc
+		codec.dec.skipUnknown = opts.SkipUnknownFields
+		codec.dec.onUnknown = opts.UnknownFields
+		codec.dec.budget = opts.Budget
+		codec.dec.prealloc = opts.PreallocFactor
+	}
 
 	// Start a decoding round with length enforcement in place
 	codec.dec.descendIntoSlot(uint32(len(blob)))
 
 	switch v := obj.(type) {
 	case StaticObject:
-		v.DefineSSZ(codec)
+		codec.dec.decodeObject(v)
 	case DynamicObject:
 		codec.dec.startDynamics(v.SizeSSZ(codec.dec.sizer, true))
-		v.DefineSSZ(codec)
+		codec.dec.decodeObject(v)
 		codec.dec.flushDynamics()
 	default:
 		panic(fmt.Sprintf("unsupported type: %T", obj))
 	}
+	codec.dec.skipUnknownTrailer()
+	if codec.dec.err != nil {
+		codec.dec.annotateError(objectName(obj))
+	}
 	codec.dec.ascendFromSlot()
+	done = true
 
-	// Retrieve any errors, zero out the source and return
+	// Retrieve any errors and return
 	err := codec.dec.err
+	if opts != nil {
+		codec.dec.stats.publish(opts.Stats)
+	} else {
+		codec.dec.stats.publish(nil)
+	}
 
-	codec.dec.inBufEnd = 0
-	codec.dec.inBuffer = nil
-	codec.dec.err = nil
+	// Partially decoded objects are incomplete, don't run any decode hooks
+	if err != nil || mask != nil {
+		return err
+	}
+	return afterDecode(obj)
+}
 
-	return err
+// afterDecode invokes the post-decoding hook of a top level object, if defined.
+func afterDecode(obj Object) error {
+	if hooked, ok := obj.(DecodeHookedObject); ok {
+		return hooked.AfterSSZDecode()
+	}
+	return nil
+}
+
+// Validate parses a non-monolithic object from a byte buffer and verifies that
+// re-encoding it results in the exact same bytes (i.e. the input is canonical).
+// If the type contains fork-specific rules, use ValidateOnFork.
+func Validate(blob []byte, obj Object) error {
+	return ValidateOnFork(blob, obj, ForkUnknown)
+}
+
+// ValidateOnFork parses a monolithic object from a byte buffer and verifies that
+// re-encoding it results in the exact same bytes (i.e. the input is canonical).
+// If the type does not contain fork-specific rules, you can also use Validate.
+//
+// The re-encoding is streamed against the original input without allocating a
+// temporary buffer. On mismatch, the returned error contains the offset of the
+// first differing byte.
+func ValidateOnFork(blob []byte, obj Object, fork Fork) error {
+	if err := DecodeFromBytesOnFork(blob, obj, fork); err != nil {
+		return err
+	}
+	if size := SizeOnFork(obj, fork); int(size) != len(blob) {
+		return fmt.Errorf("%w: input %d bytes, re-encoded %d bytes", ErrNonCanonicalEncoding, len(blob), size)
+	}
+	return EncodeToStreamOnFork(&canonicalChecker{blob: blob}, obj, fork)
+}
+
+// CheckOffsets verifies that the offsets within an encoded monolithic object (and
+// all nested dynamic objects and lists) partition the dynamic regions exactly:
+// the first offset points right after the static data, subsequent ones never go
+// backwards and the last item ends exactly at the end of its region. This rules
+// out offset-sharing attacks, where multiple fields would alias the same data.
+//
+// The check is done by decoding into a throwaway instance of obj's type, so obj
+// itself is left untouched, being used only for its type. The same rules are
+// always enforced by the decoder too, since it reads the input sequentially.
+// CheckOffsets is meant as a diagnostic tool for inspecting untrusted blobs.
+//
+// Errors unrelated to offsets (e.g. invalid booleans) abort the check and are
+// returned as is, use errors.Is with the offset errors to tell them apart. Types
+// that cannot be decoded (e.g. generated with value receivers) are rejected with
+// ErrReadOnlyObject.
+func CheckOffsets(blob []byte, obj Object, fork Fork) error {
+	return DecodeFromBytesOnFork(blob, newObjectOf(obj), fork)
+}
+
+// canonicalChecker is an io.Writer that compares the data written into it with
+// a reference blob, failing on the first mismatching byte.
+type canonicalChecker struct {
+	blob []byte // Reference blob to compare the written data against
+	pos  int    // Number of bytes already compared
+}
+
+// Write implements io.Writer, comparing the data against the reference blob.
+func (c *canonicalChecker) Write(p []byte) (int, error) {
+	for i := 0; i < len(p); i++ {
+		if c.pos+i >= len(c.blob) || p[i] != c.blob[c.pos+i] {
+			return i, fmt.Errorf("%w: first difference at byte %d", ErrNonCanonicalEncoding, c.pos+i)
+		}
+	}
+	c.pos += len(p)
+	return len(p), nil
 }
 
 // HashSequential computes the merkle root of a non-monolithic object on a single
@@ -282,20 +780,155 @@ func HashSequential(obj Object) [32]byte {
 //
 // If the type does not contain fork-specific rules, you can also use HashSequential.
 func HashSequentialOnFork(obj Object, fork Fork) [32]byte {
+	return hashSequential(obj, fork, LittleEndian, nil)
+}
+
+// hashSequential is the internal implementation of HashSequentialOnFork, with
+// the byte order of the basic types and the hasher backend configurable.
+func hashSequential(obj Object, fork Fork, order ByteOrder, backend HasherBackend) [32]byte {
+	root, err := hashRoot(obj, fork, order, backend)
+	if err != nil {
+		panic(err)
+	}
+	return root
+}
+
+// HashRoot computes the merkle root of a non-monolithic object on a single thread,
+// similarly to HashSequential. Instead of panicking, it returns an error if the
+// object's DefineSSZ method left the hasher unbalanced (e.g. a buggy hand-written
+// codec), describing the hashing layer and chunk groups left open, or if a dynamic
+// list or blob exceeds its limit.
+//
+// If the type contains fork-specific rules, use HashRootOnFork.
+func HashRoot(obj Object) ([32]byte, error) {
+	return HashRootOnFork(obj, ForkUnknown)
+}
+
+// HashRootOnFork computes the merkle root of a monolithic object on a single
+// thread, similarly to HashSequentialOnFork. Instead of panicking, it returns an
+// error if the object's DefineSSZ method left the hasher unbalanced (e.g. a buggy
+// hand-written codec), describing the hashing layer and chunk groups left open, or
+// if a dynamic list or blob exceeds its limit.
+//
+// If the type does not contain fork-specific rules, you can also use HashRoot.
+func HashRootOnFork(obj Object, fork Fork) ([32]byte, error) {
+	return hashRoot(obj, fork, LittleEndian, nil)
+}
+
+// hashRoot is the internal implementation of HashRootOnFork, with the byte order
+// of the basic types and the hasher backend configurable.
+func hashRoot(obj Object, fork Fork, order ByteOrder, backend HasherBackend) ([32]byte, error) {
 	codec := hasherPool.Get().(*Codec)
 	defer hasherPool.Put(codec)
 	defer codec.has.Reset()
 
-	codec.fork = fork
+	codec.fork, codec.order = fork, order
+	defer func() { codec.order = LittleEndian }()
+	codec.has.setBackend(backend)
 
+	return hashObject(codec, obj)
+}
+
+// hashObject runs a top level object through the hasher of a codec, verifying
+// that its DefineSSZ method left no hashing layers or chunk groups open.
+func hashObject(codec *Codec, obj Object) ([32]byte, error) {
 	codec.has.descendLayer()
 	obj.DefineSSZ(codec)
+	if err := codec.has.checkBalanced(1); err != nil {
+		return [32]byte{}, err
+	}
 	codec.has.ascendLayer(0)
 
-	if len(codec.has.chunks) != 1 {
-		panic(fmt.Sprintf("unfinished hashing: left %v", codec.has.groups))
+	if err := codec.has.checkBalanced(0); err != nil {
+		return [32]byte{}, err
 	}
-	return codec.has.chunks[0]
+	return codec.has.chunks[0], nil
+}
+
+// HashSequentialWithProof computes the merkle root of a non-monolithic object on
+// a single thread, also collecting the merkle proof of the node at the requested
+// generalized index while hashing.
+//
+// If the type contains fork-specific rules, use HashSequentialWithProofOnFork.
+func HashSequentialWithProof(obj Object, gindex uint64) ([32]byte, [][32]byte) {
+	return HashSequentialWithProofOnFork(obj, ForkUnknown, gindex)
+}
+
+// HashSequentialWithProofOnFork computes the merkle root of a monolithic object on
+// a single thread, also collecting the merkle proof of the node at the requested
+// generalized index while hashing. The returned branch contains the sibling hashes
+// along the path from the bottom up, as expected by is_valid_merkle_branch.
+//
+// The object is walked twice, first without hashing, to learn the shape of the
+// trie; but no tree is ever built in memory, making this much cheaper than full
+// tree construction when only a single proof is needed.
+//
+// If the generalized index is not within the object's trie (e.g. it points into
+// a basic leaf, or into a nil object), the returned branch is nil.
+//
+// If the type does not contain fork-specific rules, you can also use
+// HashSequentialWithProof.
+func HashSequentialWithProofOnFork(obj Object, fork Fork, gindex uint64) ([32]byte, [][32]byte) {
+	if gindex == 0 {
+		return HashSequentialOnFork(obj, fork), nil
+	}
+	codec := hasherPool.Get().(*Codec)
+	defer hasherPool.Put(codec)
+	defer codec.has.Reset()
+
+	codec.fork = fork
+
+	// Walk the object without hashing to learn the shape of the trie
+	prover := newHasherProver(gindex)
+
+	codec.has.setBackend(nopBackend{})
+	codec.has.prover = prover
+
+	if _, err := hashObject(codec, obj); err != nil {
+		panic(err)
+	}
+	codec.has.Reset()
+
+	// Hash the object for real, collecting the proof along the way
+	prover.shape, prover.seq = false, 0
+	codec.has.prover = prover
+
+	root, err := hashObject(codec, obj)
+	if err != nil {
+		panic(err)
+	}
+	if !prover.found {
+		return root, nil
+	}
+	return root, prover.branch
+}
+
+// FieldRoots computes the merkle roots of the top level fields of a non-monolithic
+// object, in their definition order.
+//
+// If the type contains fork-specific rules, use FieldRootsOnFork.
+func FieldRoots(obj Object) [][32]byte {
+	return FieldRootsOnFork(obj, ForkUnknown)
+}
+
+// FieldRootsOnFork computes the merkle roots of the top level fields of a monolithic
+// object active in the given fork, in their definition order. These are the leaves
+// the object's root is merkleized from, useful for building proofs against specific
+// fields (e.g. of a beacon state) without collecting them one by one.
+//
+// If the type does not contain fork-specific rules, you can also use FieldRoots.
+func FieldRootsOnFork(obj Object, fork Fork) [][32]byte {
+	codec := hasherPool.Get().(*Codec)
+	defer hasherPool.Put(codec)
+	defer codec.has.Reset()
+
+	codec.fork = fork
+	codec.has.collect = true
+
+	if _, err := hashObject(codec, obj); err != nil {
+		panic(err)
+	}
+	return codec.has.fields
 }
 
 // HashConcurrent computes the merkle root of a non-monolithic object on potentially
@@ -315,22 +948,270 @@ func HashConcurrent(obj Object) [32]byte {
 //
 // If the type does not contain fork-specific rules, you can also use HashConcurrent.
 func HashConcurrentOnFork(obj Object, fork Fork) [32]byte {
+	return hashConcurrent(obj, fork, LittleEndian, nil)
+}
+
+// HashConcurrentCtx computes the merkle root of a non-monolithic object on
+// potentially multiple concurrent threads, aborting if the context is cancelled.
+//
+// If the type contains fork-specific rules, use HashConcurrentOnForkCtx.
+func HashConcurrentCtx(ctx context.Context, obj Object) ([32]byte, error) {
+	return HashConcurrentOnForkCtx(ctx, obj, ForkUnknown)
+}
+
+// HashConcurrentOnForkCtx computes the merkle root of a monolithic object on
+// potentially multiple concurrent threads, aborting if the context is cancelled.
+// This is useful for request-scoped hashing of large objects (e.g. API handlers)
+// to avoid burning CPU after the requester went away.
+//
+// Cancellation is checked by the concurrent workers between the list items they
+// hash, so the call returns shortly after the context is cancelled with ctx.Err().
+// Objects that cannot be hashed (e.g. lists exceeding their limits) are reported
+// as errors instead of panicking as HashConcurrent does.
+//
+// If the type does not contain fork-specific rules, you can also use
+// HashConcurrentCtx.
+func HashConcurrentOnForkCtx(ctx context.Context, obj Object, fork Fork) ([32]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return [32]byte{}, err
+	}
 	codec := hasherPool.Get().(*Codec)
 	defer hasherPool.Put(codec)
 	defer codec.has.Reset()
 
 	codec.fork = fork
 	codec.has.threads = true
+	codec.has.done = ctx.Done()
 
-	codec.has.descendLayer()
-	obj.DefineSSZ(codec)
-	codec.has.ascendLayer(0)
+	root, err := hashObject(codec, obj)
+	if err := ctx.Err(); err != nil {
+		return [32]byte{}, err
+	}
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return root, nil
+}
+
+// hashConcurrent is the internal implementation of HashConcurrentOnFork, with
+// the byte order of the basic types and the hasher backend configurable.
+func hashConcurrent(obj Object, fork Fork, order ByteOrder, backend HasherBackend) [32]byte {
+	codec := hasherPool.Get().(*Codec)
+	defer hasherPool.Put(codec)
+	defer codec.has.Reset()
+
+	codec.fork, codec.order = fork, order
+	defer func() { codec.order = LittleEndian }()
+	codec.has.threads = true
+	codec.has.setBackend(backend)
 
-	if len(codec.has.chunks) != 1 {
-		panic(fmt.Sprintf("unfinished hashing: left %v", codec.has.groups))
+	root, err := hashObject(codec, obj)
+	if err != nil {
+		panic(err)
 	}
 	codec.has.threads = false
-	return codec.has.chunks[0]
+	return root
+}
+
+// HashSequentialWithBackend computes the merkle root of a non-monolithic object
+// on a single thread, using a custom hash function instead of sha256 for the
+// merkleization. It panics if the backend fails, use HashRootWithBackend to get
+// the failure as an error instead.
+//
+// Objects caching their own roots via ssz.RootedObject are rehashed, as there's
+// no way to know which backend their roots were computed with.
+//
+// If the type contains fork-specific rules, use HashSequentialWithBackendOnFork.
+func HashSequentialWithBackend(obj Object, backend HasherBackend) [32]byte {
+	return HashSequentialWithBackendOnFork(obj, ForkUnknown, backend)
+}
+
+// HashSequentialWithBackendOnFork computes the merkle root of a monolithic object
+// on a single thread, using a custom hash function instead of sha256 for the
+// merkleization. It panics if the backend fails, use HashRootWithBackendOnFork
+// to get the failure as an error instead.
+//
+// If the type does not contain fork-specific rules, you can also use
+// HashSequentialWithBackend.
+func HashSequentialWithBackendOnFork(obj Object, fork Fork, backend HasherBackend) [32]byte {
+	return hashSequential(obj, fork, LittleEndian, backend)
+}
+
+// HashConcurrentWithBackend computes the merkle root of a non-monolithic object
+// on potentially multiple concurrent threads (iff some data segments are large
+// enough to be worth it), using a custom hash function instead of sha256 for the
+// merkleization. It panics if the backend fails.
+//
+// If the type contains fork-specific rules, use HashConcurrentWithBackendOnFork.
+func HashConcurrentWithBackend(obj Object, backend HasherBackend) [32]byte {
+	return HashConcurrentWithBackendOnFork(obj, ForkUnknown, backend)
+}
+
+// HashConcurrentWithBackendOnFork computes the merkle root of a monolithic object
+// on potentially multiple concurrent threads (iff some data segments are large
+// enough to be worth it), using a custom hash function instead of sha256 for the
+// merkleization. It panics if the backend fails.
+//
+// If the type does not contain fork-specific rules, you can also use
+// HashConcurrentWithBackend.
+func HashConcurrentWithBackendOnFork(obj Object, fork Fork, backend HasherBackend) [32]byte {
+	return hashConcurrent(obj, fork, LittleEndian, backend)
+}
+
+// HashRootWithBackend computes the merkle root of a non-monolithic object on a
+// single thread using a custom hash backend, similarly to HashSequentialWithBackend.
+// Instead of panicking, it returns an error if the backend fails, wrapped into
+// ErrHasherBackendFailed (or for the same reasons HashRoot does).
+//
+// If the type contains fork-specific rules, use HashRootWithBackendOnFork.
+func HashRootWithBackend(obj Object, backend HasherBackend) ([32]byte, error) {
+	return HashRootWithBackendOnFork(obj, ForkUnknown, backend)
+}
+
+// HashRootWithBackendOnFork computes the merkle root of a monolithic object on a
+// single thread using a custom hash backend, similarly to
+// HashSequentialWithBackendOnFork. Instead of panicking, it returns an error if
+// the backend fails, wrapped into ErrHasherBackendFailed (or for the same reasons
+// HashRootOnFork does).
+//
+// If the type does not contain fork-specific rules, you can also use
+// HashRootWithBackend.
+func HashRootWithBackendOnFork(obj Object, fork Fork, backend HasherBackend) ([32]byte, error) {
+	return hashRoot(obj, fork, LittleEndian, backend)
+}
+
+// HashRoots computes the merkle roots of a batch of objects, potentially on
+// multiple concurrent threads (iff the batch is large enough to be worth it).
+// This is useful for processing large numbers of small objects (e.g. validators).
+//
+// Only the fields of the objects are hashed one object at a time. The top level
+// tries of the objects are merkleized together, hashing each of their levels in
+// large batches across objects, instead of a few chunks at a time per object.
+//
+// Like HashSequential, the method panics if any object cannot be hashed, but the
+// failure is always raised on the calling goroutine, not on a worker thread.
+func HashRoots[T Object](objs []T, fork Fork) [][32]byte {
+	roots := make([][32]byte, len(objs))
+	if len(objs) == 0 {
+		return roots
+	}
+	// Split the objects into contiguous ranges across the available threads, but
+	// avoid spinning up threads if there's too little data to hash
+	var (
+		threads = runtime.NumCPU()
+		batch   = (len(objs) + threads - 1) / threads
+	)
+	if len(objs)*int(SizeOnFork(objs[0], fork)) < concurrencyThreshold {
+		batch = len(objs)
+	}
+	var workers errgroup.Group
+	workers.SetLimit(threads)
+
+	for start := 0; start < len(objs); start += batch {
+		start, end := start, min(start+batch, len(objs)) // Take care, closure
+
+		workers.Go(func() error {
+			codec := hasherPool.Get().(*Codec)
+			defer hasherPool.Put(codec)
+			defer codec.has.Reset()
+
+			codec.fork = fork
+			return hashRoots(codec, objs[start:end], roots[start:end])
+		})
+	}
+	if err := workers.Wait(); err != nil {
+		panic(err)
+	}
+	return roots
+}
+
+// hashRoots computes the merkle roots of a batch of objects on a single thread,
+// collecting the top level leaves of the objects and merkleizing the tries of
+// many of them together. Objects with a different trie width than the first one
+// (e.g. dynamic types behind an interface) are merkleized on their own.
+func hashRoots[T Object](codec *Codec, objs []T, roots [][32]byte) error {
+	var (
+		h      = codec.has
+		width  int        // Leaf count of the top level tries (power of 2)
+		leaves [][32]byte // Padded top level leaves of the pending objects
+		first  int        // Index of the first pending object
+		fields [][32]byte // Reusable buffer for collecting the top level leaves
+	)
+	flush := func(end int) {
+		if len(leaves) == 0 {
+			first = end
+			return
+		}
+		h.hashTries(leaves, width)
+		for i := first; i < end; i++ {
+			roots[i] = leaves[i-first]
+		}
+		leaves, first = leaves[:0], end
+	}
+	for i, obj := range objs {
+		h.Reset()
+		h.flat, h.fields = true, fields[:0]
+
+		h.descendLayer()
+		obj.DefineSSZ(codec)
+		if err := h.checkBalanced(1); err != nil {
+			return err
+		}
+		fields = h.fields
+		if len(fields) == 0 {
+			return fmt.Errorf("%w: no chunks hashed in layer 1", ErrUnbalancedHashing)
+		}
+		// If the object's trie shape differs from the pending ones, merkleize it
+		// separately. Otherwise queue it up and flush if enough was accumulated.
+		size := int(NextPowerOfTwo(uint64(len(fields))))
+		if width == 0 {
+			width = size
+		}
+		if size != width {
+			flush(i)
+			for len(fields) < size {
+				fields = append(fields, hasherZeroChunk)
+			}
+			h.hashTries(fields, size)
+			roots[i], first = fields[0], i+1
+		} else {
+			leaves = append(leaves, fields...)
+			for j := len(fields); j < width; j++ {
+				leaves = append(leaves, hasherZeroChunk)
+			}
+			if len(leaves) >= hasherTriesBatch {
+				flush(i + 1)
+			}
+		}
+		if h.broken != nil {
+			return h.broken
+		}
+	}
+	flush(len(objs))
+	return h.broken
+}
+
+// ZeroHash retrieves the merkle root of an all-zero subtree of the given depth,
+// depth 0 being a single zero chunk. The maximum supported depth is 64.
+func ZeroHash(depth int) [32]byte {
+	return hasherZeroCache[depth]
+}
+
+// ZeroRootOf retrieves the merkle root of the zero value of an object's type on
+// a given fork. The object itself is only used for its type, so it may be nil,
+// or a value of a type generated with value receivers.
+//
+// The roots are cached per type and fork, so only the first call is expensive.
+func ZeroRootOf(obj Object, fork Fork) [32]byte {
+	fresh := newObjectOf(obj)
+	key := zeroRootKey{kind: reflect.TypeOf(fresh).Elem(), fork: fork}
+
+	if root, ok := zeroRootCache.Load(key); ok {
+		return root.([32]byte)
+	}
+	root := HashSequentialOnFork(fresh, fork)
+	zeroRootCache.Store(key, root)
+	return root
 }
 
 // Size retrieves the size of a non-monolithic object, independent if it is static
@@ -345,6 +1226,7 @@ func Size(obj Object) uint32 {
 func SizeOnFork(obj Object, fork Fork) uint32 {
 	sizer := sizerPool.Get().(*Sizer)
 	defer sizerPool.Put(sizer)
+	defer sizer.codec.releaseEntries()
 
 	sizer.codec.fork = fork
 
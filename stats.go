@@ -0,0 +1,71 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+// Stats are structural counters collected while encoding or decoding an object,
+// meant for fuzzing oracles to assert invariants that round-trip equality alone
+// cannot catch (e.g. every offset emitted having its content consumed).
+//
+// The counters are collected per operation into the destination requested via
+// Profile.Stats (encoding and decoding) or DecodeOptions.Stats (decoding). They
+// are only collected if the library is built with the sszdebug build tag (go
+// test -tags sszdebug), otherwise they are always zero and the collection is
+// compiled out.
+type Stats struct {
+	Fields   int // Number of fields defined, across all nested objects
+	Offsets  int // Number of offsets written or read, including list item ones
+	Contents int // Number of dynamic fields and list items written or read
+	MaxDepth int // Deepest nesting of dynamic objects, the top level one being 1
+
+	depth int // Current nesting of dynamic objects
+}
+
+// field counts a newly defined field.
+func (s *Stats) field() {
+	if statsEnabled {
+		s.Fields++
+	}
+}
+
+// offsets counts a number of newly written or read offsets.
+func (s *Stats) offsets(n int) {
+	if statsEnabled {
+		s.Offsets += n
+	}
+}
+
+// contents counts a number of newly written or read dynamic contents.
+func (s *Stats) contents(n int) {
+	if statsEnabled {
+		s.Contents += n
+	}
+}
+
+// enter tracks descending into a dynamic object.
+func (s *Stats) enter() {
+	if statsEnabled {
+		if s.depth++; s.depth > s.MaxDepth {
+			s.MaxDepth = s.depth
+		}
+	}
+}
+
+// leave tracks ascending from a dynamic object.
+func (s *Stats) leave() {
+	if statsEnabled {
+		s.depth--
+	}
+}
+
+// publish stores the collected statistics of the operation into the destination
+// (if any) and resets the counters for the next operation.
+func (s *Stats) publish(dst *Stats) {
+	if statsEnabled {
+		if dst != nil {
+			*dst = *s
+		}
+		*s = Stats{}
+	}
+}
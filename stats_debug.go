@@ -0,0 +1,10 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build sszdebug
+
+package ssz
+
+// statsEnabled is whether the encoders and decoders collect Stats.
+const statsEnabled = true
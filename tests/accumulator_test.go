@@ -0,0 +1,101 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that the incremental list accumulator produces the same roots as hashing
+// the entire list from scratch, at every step and up to the list capacity.
+func TestListAccumulator(t *testing.T) {
+	var (
+		acc   = ssz.NewListAccumulator[*types.Withdrawal](16)
+		roots = ssz.NewListAccumulator[*types.Withdrawal](16)
+		list  = new(testAccumulatedList)
+	)
+	if have, want := testHashPair(acc.Root(), roots.Root()), ssz.HashSequential(list); have != want {
+		t.Fatalf("empty root mismatch: have %x, want %x", have, want)
+	}
+	for i := 0; i < 16; i++ {
+		obj := &types.Withdrawal{Index: uint64(i), Validator: uint64(2 * i), Amount: uint64(3 * i)}
+		if err := acc.Append(obj); err != nil {
+			t.Fatalf("item %d: failed to append: %v", i, err)
+		}
+		root := ssz.HashSequential(obj)
+		if err := roots.AppendRoot(root); err != nil {
+			t.Fatalf("item %d: failed to append root: %v", i, err)
+		}
+		list.Withdrawals = append(list.Withdrawals, obj)
+		list.Roots = append(list.Roots, root)
+
+		want := ssz.HashSequential(list)
+		if have := testHashPair(acc.Root(), roots.Root()); have != want {
+			t.Fatalf("item %d: root mismatch: have %x, want %x", i, have, want)
+		}
+	}
+	if acc.Count() != 16 {
+		t.Fatalf("item count mismatch: have %d, want %d", acc.Count(), 16)
+	}
+	if err := acc.Append(new(types.Withdrawal)); !errors.Is(err, ssz.ErrMaxItemsExceeded) {
+		t.Fatalf("overflow error mismatch: have %v, want %v", err, ssz.ErrMaxItemsExceeded)
+	}
+}
+
+// Tests that accumulators with limits needing a trie of depth 64 (the maximum a
+// uint64 count can address) hash correctly.
+func TestListAccumulatorMaxDepth(t *testing.T) {
+	acc := ssz.NewListAccumulator[*types.Withdrawal](math.MaxUint64)
+
+	var length [32]byte
+	if have, want := acc.Root(), testHashPair(ssz.ZeroHash(64), length); have != want {
+		t.Fatalf("empty root mismatch: have %x, want %x", have, want)
+	}
+	leaf := [32]byte{0x01}
+	if err := acc.AppendRoot(leaf); err != nil {
+		t.Fatalf("failed to append root: %v", err)
+	}
+	node := leaf
+	for depth := 0; depth < 64; depth++ {
+		node = testHashPair(node, ssz.ZeroHash(depth))
+	}
+	length[0] = 1
+	if have, want := acc.Root(), testHashPair(node, length); have != want {
+		t.Fatalf("single item root mismatch: have %x, want %x", have, want)
+	}
+}
+
+// testAccumulatedList is a container with two lists, the roots of which are the
+// leaves of the container trie.
+type testAccumulatedList struct {
+	Withdrawals []*types.Withdrawal
+	Roots       [][32]byte
+}
+
+func (t *testAccumulatedList) SizeSSZ(siz *ssz.Sizer, fixed bool) uint32 {
+	size := uint32(4 + 4)
+	if fixed {
+		return size
+	}
+	return size + ssz.SizeSliceOfStaticObjects(siz, t.Withdrawals) + ssz.SizeSliceOfStaticBytes(siz, t.Roots)
+}
+func (t *testAccumulatedList) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &t.Withdrawals, 16)
+	ssz.DefineSliceOfStaticBytesOffset(codec, &t.Roots, 16)
+
+	ssz.DefineSliceOfStaticObjectsContent(codec, &t.Withdrawals, 16)
+	ssz.DefineSliceOfStaticBytesContent(codec, &t.Roots, 16)
+}
+
+// testHashPair hashes two sibling nodes together.
+func testHashPair(left [32]byte, right [32]byte) [32]byte {
+	return sha256.Sum256(append(left[:], right[:]...))
+}
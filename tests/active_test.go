@@ -0,0 +1,91 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/holiman/uint256"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that the active fields of monolithic types are reported with stable
+// indices across forks, and that decoding payloads with mismatching active
+// fields is rejected.
+func TestActiveFields(t *testing.T) {
+	shanghai, err := ssz.ActiveFields(new(types.ExecutionPayloadMonolith), ssz.ForkShanghai)
+	if err != nil {
+		t.Fatalf("failed to retrieve shanghai active fields: %v", err)
+	}
+	cancun, err := ssz.ActiveFields(new(types.ExecutionPayloadMonolith), ssz.ForkCancun)
+	if err != nil {
+		t.Fatalf("failed to retrieve cancun active fields: %v", err)
+	}
+	// The 17 fields should fit into the smallest bitvector holding them
+	if shanghai.Len() != 32 || cancun.Len() != 32 {
+		t.Fatalf("bitvector size mismatch: have %d/%d, want 32", shanghai.Len(), cancun.Len())
+	}
+	if !shanghai.BitAt(14) || shanghai.BitAt(15) || shanghai.BitAt(16) {
+		t.Errorf("shanghai withdrawals/blob gas presence mismatch: %08b", shanghai.Bytes())
+	}
+	if cancun.Count() != 17 {
+		t.Errorf("cancun active field count mismatch: have %d, want 17", cancun.Count())
+	}
+	// Decoding with the matching active fields should succeed, even if padded to
+	// the capacity of a StableContainer
+	blobGas := uint64(1)
+	obj := &types.ExecutionPayloadMonolith{
+		ExtraData:     []byte{0x01},
+		BaseFeePerGas: uint256.NewInt(7),
+		Withdrawals:   []*types.Withdrawal{{Index: 1}},
+		BlobGasUsed:   &blobGas,
+		ExcessBlobGas: &blobGas,
+	}
+	blob := make([]byte, ssz.SizeOnFork(obj, ssz.ForkCancun))
+	if err := ssz.EncodeToBytesOnFork(blob, obj, ssz.ForkCancun); err != nil {
+		t.Fatalf("failed to encode payload: %v", err)
+	}
+	active := make([]byte, 8)
+	copy(active, cancun.Bytes())
+
+	dec := new(types.ExecutionPayloadMonolith)
+	if err := ssz.DecodeFromBytesWithOptions(blob, dec, ssz.ForkCancun, &ssz.DecodeOptions{ActiveFields: active}); err != nil {
+		t.Fatalf("failed to decode with matching active fields: %v", err)
+	}
+	if !dec.EqualSSZ(obj) {
+		t.Errorf("decoded payload mismatch")
+	}
+	// Missing, extra and unknown fields should all be rejected
+	missing := cancun.Bytes()
+	missing[0] &^= 0x01
+
+	for _, bits := range [][]byte{shanghai.Bytes(), missing, append(cancun.Bytes(), 0x01)} {
+		opts := &ssz.DecodeOptions{ActiveFields: bits}
+		if err := ssz.DecodeFromBytesWithOptions(blob, new(types.ExecutionPayloadMonolith), ssz.ForkCancun, opts); !errors.Is(err, ssz.ErrActiveFieldsMismatch) {
+			t.Errorf("active fields %08b: error mismatch: have %v, want %v", bits, err, ssz.ErrActiveFieldsMismatch)
+		}
+		if err := ssz.DecodeFromStreamWithOptions(bytes.NewReader(blob), new(types.ExecutionPayloadMonolith), uint32(len(blob)), ssz.ForkCancun, opts); !errors.Is(err, ssz.ErrActiveFieldsMismatch) {
+			t.Errorf("active fields %08b: stream error mismatch: have %v, want %v", bits, err, ssz.ErrActiveFieldsMismatch)
+		}
+	}
+}
+
+// Tests that the active fields of read-only types (value receivers, which cannot
+// be decoded into) can be retrieved too.
+func TestActiveFieldsReadOnly(t *testing.T) {
+	for _, obj := range []ssz.Object{types.ReadOnlyVariation{}, &types.ReadOnlyVariation{}} {
+		bits, err := ssz.ActiveFields(obj, ssz.ForkUnknown)
+		if err != nil {
+			t.Fatalf("%T: failed to retrieve active fields: %v", obj, err)
+		}
+		if bits.Len() != 8 || bits.Count() != 3 || !bytes.Equal(bits.Bytes(), []byte{0x07}) {
+			t.Errorf("%T: active fields mismatch: have %08b, want %08b", obj, bits.Bytes(), []byte{0x07})
+		}
+	}
+}
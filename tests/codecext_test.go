@@ -0,0 +1,175 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	"github.com/karalabe/ssz/codecext"
+)
+
+// Tests that a third party primitive built on the codecext package (an optional
+// uint64, wire compatible with a list of at most one uint64) encodes, decodes and
+// hashes the same way as its built-in equivalent.
+func TestCodecExtension(t *testing.T) {
+	value := uint64(0xdeadbeef)
+
+	for _, test := range []struct {
+		obj *testOptionalUint64
+		ref *testOptionalUint64Ref
+	}{
+		{&testOptionalUint64{Slot: 1}, &testOptionalUint64Ref{Slot: 1, Values: []uint64{}}},
+		{&testOptionalUint64{Slot: 2, Value: &value}, &testOptionalUint64Ref{Slot: 2, Values: []uint64{value}}},
+	} {
+		blob := make([]byte, ssz.Size(test.obj))
+		if err := ssz.EncodeToBytes(blob, test.obj); err != nil {
+			t.Fatalf("failed to encode object: %v", err)
+		}
+		want := make([]byte, ssz.Size(test.ref))
+		if err := ssz.EncodeToBytes(want, test.ref); err != nil {
+			t.Fatalf("failed to encode reference: %v", err)
+		}
+		if !bytes.Equal(blob, want) {
+			t.Errorf("encoding mismatch: have %x, want %x", blob, want)
+		}
+		stream := new(bytes.Buffer)
+		if err := ssz.EncodeToStream(stream, test.obj); err != nil {
+			t.Fatalf("failed to stream encode object: %v", err)
+		}
+		if !bytes.Equal(stream.Bytes(), want) {
+			t.Errorf("stream encoding mismatch: have %x, want %x", stream.Bytes(), want)
+		}
+		if have, want := ssz.HashSequential(test.obj), ssz.HashSequential(test.ref); have != want {
+			t.Errorf("hash mismatch: have %x, want %x", have, want)
+		}
+		dec := new(testOptionalUint64)
+		if err := ssz.DecodeFromBytes(blob, dec); err != nil {
+			t.Fatalf("failed to decode object: %v", err)
+		}
+		if dec.Slot != test.obj.Slot || (dec.Value == nil) != (test.obj.Value == nil) || (dec.Value != nil && *dec.Value != *test.obj.Value) {
+			t.Errorf("decoded object mismatch: have %v, want %v", dec, test.obj)
+		}
+		dec = new(testOptionalUint64)
+		if err := ssz.DecodeFromStream(bytes.NewReader(blob), dec, uint32(len(blob))); err != nil {
+			t.Fatalf("failed to stream decode object: %v", err)
+		}
+		if dec.Slot != test.obj.Slot || (dec.Value == nil) != (test.obj.Value == nil) || (dec.Value != nil && *dec.Value != *test.obj.Value) {
+			t.Errorf("stream decoded object mismatch: have %v, want %v", dec, test.obj)
+		}
+	}
+	// Errors raised by the primitive must abort the decoding
+	blob := make([]byte, ssz.Size(&testOptionalUint64Ref{Values: []uint64{1, 2}}))
+	if err := ssz.EncodeToBytes(blob, &testOptionalUint64Ref{Values: []uint64{1, 2}}); err != nil {
+		t.Fatalf("failed to encode reference: %v", err)
+	}
+	if err := ssz.DecodeFromBytes(blob, new(testOptionalUint64)); !errors.Is(err, ssz.ErrMaxItemsExceeded) {
+		t.Errorf("oversized optional error mismatch: have %v, want %v", err, ssz.ErrMaxItemsExceeded)
+	}
+	// Third party primitives cannot be described by a schema
+	if _, err := ssz.HashRootFromBytes(blob, new(testOptionalUint64), ssz.ForkUnknown); !errors.Is(err, ssz.ErrInvalidSchema) {
+		t.Errorf("schema hashing error mismatch: have %v, want %v", err, ssz.ErrInvalidSchema)
+	}
+}
+
+// testOptionalUint64 is a container with an optional uint64 field, implemented
+// via the codecext package as a list of at most one uint64.
+type testOptionalUint64 struct {
+	Slot  uint64
+	Value *uint64
+}
+
+func (t *testOptionalUint64) SizeSSZ(siz *ssz.Sizer, fixed bool) uint32 {
+	if fixed || t.Value == nil {
+		return 8 + 4
+	}
+	return 8 + 4 + 8
+}
+
+func (t *testOptionalUint64) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &t.Slot)
+	defineOptionalUint64Offset(codec, &t.Value)
+	defineOptionalUint64Content(codec, &t.Value)
+}
+
+// defineOptionalUint64Offset defines the offset position of an optional uint64,
+// doing all the hashing of the field.
+func defineOptionalUint64Offset(c *ssz.Codec, n **uint64) {
+	codecext.Define(c,
+		func(enc *ssz.Encoder) {
+			if *n == nil {
+				codecext.WriteOffset(enc, 0)
+			} else {
+				codecext.WriteOffset(enc, 8)
+			}
+		},
+		func(dec *ssz.Decoder) {
+			codecext.ReadOffset(dec)
+		},
+		func(h *ssz.Hasher) {
+			codecext.DescendMixinLayer(h)
+			if *n == nil {
+				codecext.AscendMixinLayer(h, 0, 1)
+				return
+			}
+			var chunk [32]byte
+			binary.LittleEndian.PutUint64(chunk[:], **n)
+			codecext.InsertChunk(h, chunk)
+			codecext.AscendMixinLayer(h, 1, 1)
+		},
+	)
+}
+
+// defineOptionalUint64Content defines the content position of an optional uint64.
+func defineOptionalUint64Content(c *ssz.Codec, n **uint64) {
+	codecext.Define(c,
+		func(enc *ssz.Encoder) {
+			if *n != nil {
+				var buf [8]byte
+				binary.LittleEndian.PutUint64(buf[:], **n)
+				codecext.Write(enc, buf[:])
+			}
+		},
+		func(dec *ssz.Decoder) {
+			switch size := codecext.ReadSize(dec); size {
+			case 0:
+				*n = nil
+			case 8:
+				var buf [8]byte
+				codecext.Read(dec, buf[:])
+				if *n == nil {
+					*n = new(uint64)
+				}
+				**n = binary.LittleEndian.Uint64(buf[:])
+			default:
+				codecext.FailDecode(dec, fmt.Errorf("%w: optional of %d bytes", ssz.ErrMaxItemsExceeded, size))
+			}
+		},
+		nil,
+	)
+}
+
+// testOptionalUint64Ref is the built-in equivalent of testOptionalUint64.
+type testOptionalUint64Ref struct {
+	Slot   uint64
+	Values []uint64
+}
+
+func (t *testOptionalUint64Ref) SizeSSZ(siz *ssz.Sizer, fixed bool) uint32 {
+	if fixed {
+		return 8 + 4
+	}
+	return 8 + 4 + ssz.SizeSliceOfUint64s(siz, t.Values)
+}
+
+func (t *testOptionalUint64Ref) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &t.Slot)
+	ssz.DefineSliceOfUint64sOffset(codec, &t.Values, 1)
+	ssz.DefineSliceOfUint64sContent(codec, &t.Values, 1)
+}
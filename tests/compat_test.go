@@ -0,0 +1,22 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/karalabe/ssz/compat"
+)
+
+// Tests that the encodings of all the primitives supported by the library did
+// not change compared to the golden files.
+func TestCompatFixtures(t *testing.T) {
+	for name, obj := range compat.Fixtures() {
+		t.Run(name, func(t *testing.T) {
+			compat.Check(t, obj, filepath.Join("testdata", "compat", name+".golden"))
+		})
+	}
+}
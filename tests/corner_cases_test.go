@@ -6,11 +6,14 @@ package tests
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"io"
 	"testing"
 
+	"github.com/holiman/uint256"
+
 	"github.com/karalabe/ssz"
 	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
 )
@@ -165,3 +168,87 @@ func (t *testEmptySlicesType) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineSliceOfStaticObjectsContent(codec, &t.D, 16)
 	ssz.DefineSliceOfDynamicObjectsContent(codec, &t.E, 16)
 }
+
+// Tests that offset tables trying to alias or skip data are detected, both in the
+// standalone diagnostic as well as during decoding.
+func TestCheckOffsets(t *testing.T) {
+	obj := &types.ExecutionPayload{
+		ExtraData:     []byte{0x01, 0x02},
+		BaseFeePerGas: new(uint256.Int),
+		Transactions:  [][]byte{{0x03, 0x04}, {0x05}},
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode payload: %v", err)
+	}
+	if err := ssz.CheckOffsets(blob, obj, ssz.ForkUnknown); err != nil {
+		t.Fatalf("valid offsets rejected: %v", err)
+	}
+	// Static data ends at 508: the extra data starts there, followed by the
+	// transactions list at 510 with its own 2 offsets
+	tests := []struct {
+		name   string
+		pos    int
+		offset uint32
+		fail   error
+	}{
+		{"gap before first item", 436, 509, ssz.ErrFirstOffsetMismatch},
+		{"alias static data", 504, 507, ssz.ErrBadOffsetProgression},
+		{"alias offset table", 514, 4, ssz.ErrBadOffsetProgression},
+		{"beyond data region", 504, 1024, ssz.ErrOffsetBeyondCapacity},
+	}
+	for _, tt := range tests {
+		bad := bytes.Clone(blob)
+		binary.LittleEndian.PutUint32(bad[tt.pos:], tt.offset)
+
+		if err := ssz.CheckOffsets(bad, obj, ssz.ForkUnknown); !errors.Is(err, tt.fail) {
+			t.Errorf("%s: check error mismatch: have %v, want %v", tt.name, err, tt.fail)
+		}
+		if err := ssz.DecodeFromBytes(bad, new(types.ExecutionPayload)); !errors.Is(err, tt.fail) {
+			t.Errorf("%s: decode error mismatch: have %v, want %v", tt.name, err, tt.fail)
+		}
+	}
+	// The checked object must not have been touched
+	if !bytes.Equal(obj.ExtraData, []byte{0x01, 0x02}) || len(obj.Transactions) != 2 {
+		t.Errorf("checked object modified")
+	}
+}
+
+// Tests that corrupted offset tables of dynamic lists are rejected before any of
+// the items (or the list itself) are allocated when decoding from a buffer, and
+// that streaming decoding reports the same failures.
+func TestDecodeOffsetTablePrescan(t *testing.T) {
+	obj := &types.ExecutionPayload{
+		BaseFeePerGas: new(uint256.Int),
+		Transactions:  [][]byte{{0x01}, {0x02}, {0x03}, {0x04}, {0x05}},
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode payload: %v", err)
+	}
+	// Static data ends at 508, followed by the transactions list with its offset
+	// table of 5 items, the last offset of which is at 508 + 4*4
+	tests := []struct {
+		name   string
+		offset uint32
+		fail   error
+	}{
+		{"beyond data region", 1024, ssz.ErrOffsetBeyondCapacity},
+		{"regressing offset", 4, ssz.ErrBadOffsetProgression},
+	}
+	for _, tt := range tests {
+		bad := bytes.Clone(blob)
+		binary.LittleEndian.PutUint32(bad[524:], tt.offset)
+
+		dec := new(types.ExecutionPayload)
+		if err := ssz.DecodeFromBytes(bad, dec); !errors.Is(err, tt.fail) {
+			t.Errorf("%s: buffer decode error mismatch: have %v, want %v", tt.name, err, tt.fail)
+		}
+		if dec.Transactions != nil {
+			t.Errorf("%s: transactions allocated before offset table validation: %v", tt.name, dec.Transactions)
+		}
+		if err := ssz.DecodeFromStream(bytes.NewReader(bad), new(types.ExecutionPayload), uint32(len(bad))); !errors.Is(err, tt.fail) {
+			t.Errorf("%s: stream decode error mismatch: have %v, want %v", tt.name, err, tt.fail)
+		}
+	}
+}
@@ -0,0 +1,681 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"unsafe"
+
+	"github.com/golang/snappy"
+	"github.com/holiman/uint256"
+	"github.com/prysmaticlabs/go-bitfield"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that decoding errors are annotated with the path of the failing field.
+func TestDecodeErrorPath(t *testing.T) {
+	obj := &types.BeaconBlockBodyDeneb{
+		Attestations: []*types.Attestation{
+			{AggregationBits: bitfield.NewBitlist(8)},
+			{AggregationBits: bitfield.NewBitlist(8)},
+			{AggregationBits: bitfield.NewBitlist(8)},
+			{AggregationBits: bitfield.Bitlist{0x00}}, // missing length bit
+		},
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	err := ssz.DecodeFromBytes(blob, new(types.BeaconBlockBodyDeneb))
+
+	var derr *ssz.DecodeError
+	if !errors.As(err, &derr) {
+		t.Fatalf("decode error type mismatch: have %T, want %T", err, derr)
+	}
+	if want := "BeaconBlockBodyDeneb.Attestations[3].AggregationBits"; derr.Path != want {
+		t.Errorf("decode error path mismatch: have %s, want %s", derr.Path, want)
+	}
+}
+
+// testStreamedRegistryType is a validator registry that is encoded as a plain
+// list, but decoded by streaming the validators without retaining them.
+type testStreamedRegistryType struct {
+	Slot       uint64
+	Validators []*types.Validator
+
+	balances uint64 // Sum of the effective balances streamed
+	count    int    // Number of validators streamed
+	abort    error  // Error to abort the stream with
+}
+
+func (t *testStreamedRegistryType) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
+	size = 8 + 4
+	if fixed {
+		return size
+	}
+	return size + ssz.SizeSliceOfStaticObjects(sizer, t.Validators)
+}
+func (t *testStreamedRegistryType) DefineSSZ(codec *ssz.Codec) {
+	codec.DefineEncoder(func(enc *ssz.Encoder) {
+		ssz.EncodeUint64(enc, t.Slot)
+		ssz.EncodeSliceOfStaticObjectsOffset(enc, t.Validators)
+		ssz.EncodeSliceOfStaticObjectsContent(enc, t.Validators)
+	})
+	codec.DefineDecoder(func(dec *ssz.Decoder) {
+		ssz.DecodeUint64(dec, &t.Slot)
+		ssz.DecodeSliceOfStaticObjectsOffset(dec, &t.Validators)
+		ssz.DecodeStreamSliceOfStaticObjects(dec, 1099511627776, func(i int, v *types.Validator) error {
+			if i == 2 && t.abort != nil {
+				return t.abort
+			}
+			t.balances += v.EffectiveBalance
+			t.count++
+			return nil
+		})
+	})
+}
+
+// Tests that lists of static objects can be decoded by streaming the items one
+// by one, instead of materializing the entire list.
+func TestDecodeStreamSliceOfStaticObjects(t *testing.T) {
+	obj := &testStreamedRegistryType{Slot: 1}
+	for i := 0; i < 100; i++ {
+		obj.Validators = append(obj.Validators, &types.Validator{EffectiveBalance: uint64(i)})
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode registry: %v", err)
+	}
+	// Stream the registry both from a buffer and from a reader
+	dec := new(testStreamedRegistryType)
+	if err := ssz.DecodeFromBytes(blob, dec); err != nil {
+		t.Fatalf("failed to decode registry from bytes: %v", err)
+	}
+	if dec.Slot != 1 || dec.count != 100 || dec.balances != 4950 || dec.Validators != nil {
+		t.Errorf("bytes stream mismatch: slot %d, count %d, balances %d, materialized %d", dec.Slot, dec.count, dec.balances, len(dec.Validators))
+	}
+	dec = new(testStreamedRegistryType)
+	if err := ssz.DecodeFromStream(bytes.NewReader(blob), dec, uint32(len(blob))); err != nil {
+		t.Fatalf("failed to decode registry from stream: %v", err)
+	}
+	if dec.Slot != 1 || dec.count != 100 || dec.balances != 4950 || dec.Validators != nil {
+		t.Errorf("reader stream mismatch: slot %d, count %d, balances %d, materialized %d", dec.Slot, dec.count, dec.balances, len(dec.Validators))
+	}
+	// Ensure callback errors abort the decoding
+	abort := errors.New("abort")
+
+	dec = &testStreamedRegistryType{abort: abort}
+	if err := ssz.DecodeFromBytes(blob, dec); !errors.Is(err, abort) {
+		t.Errorf("abort error mismatch: have %v, want %v", err, abort)
+	}
+	if dec.count != 2 {
+		t.Errorf("aborted stream count mismatch: have %d, want %d", dec.count, 2)
+	}
+	// Ensure malformed lists are still rejected
+	if err := ssz.DecodeFromBytes(blob[:len(blob)-1], new(testStreamedRegistryType)); !errors.Is(err, ssz.ErrDynamicStaticsIndivisible) {
+		t.Errorf("indivisible error mismatch: have %v, want %v", err, ssz.ErrDynamicStaticsIndivisible)
+	}
+}
+
+// countingReader is an io.Reader tracking the number of reads issued on it.
+type countingReader struct {
+	r     io.Reader
+	reads int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	r.reads++
+	return r.r.Read(p)
+}
+
+// Tests that stream decoding reads ahead from the underlying stream, but never
+// past the end of the message being decoded.
+func TestDecodeStreamReadAhead(t *testing.T) {
+	obj := &types.ExecutionPayloadCapella{
+		ExtraData:     []byte{1, 2, 3},
+		BaseFeePerGas: uint256.NewInt(8),
+		Transactions:  [][]byte{{9}, {}, bytes.Repeat([]byte{10}, 100)},
+		Withdrawals:   make([]*types.Withdrawal, types.MaxWithdrawalsPerPayload),
+	}
+	for i := range obj.Withdrawals {
+		obj.Withdrawals[i] = &types.Withdrawal{Index: uint64(i)}
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	trailer := []byte{0xde, 0xad, 0xbe, 0xef}
+
+	for _, ahead := range []uint32{0, 16, 64 * 1024} {
+		func() {
+			defer func(old uint32) { ssz.StreamReadAhead = old }(ssz.StreamReadAhead)
+			ssz.StreamReadAhead = ahead
+
+			r := &countingReader{r: bytes.NewReader(append(blob[:len(blob):len(blob)], trailer...))}
+			dec := new(types.ExecutionPayloadCapella)
+			if err := ssz.DecodeFromStream(r, dec, uint32(len(blob))); err != nil {
+				t.Fatalf("read-ahead %d: failed to decode object: %v", ahead, err)
+			}
+			have := make([]byte, len(blob))
+			ssz.EncodeToBytes(have, dec)
+			if !bytes.Equal(have, blob) {
+				t.Errorf("read-ahead %d: re-encoding mismatch: have %x, want %x", ahead, have, blob)
+			}
+			if rest, _ := io.ReadAll(r); !bytes.Equal(rest, trailer) {
+				t.Errorf("read-ahead %d: trailer mismatch: have %x, want %x", ahead, rest, trailer)
+			}
+			if ahead == 64*1024 && r.reads > 3 {
+				t.Errorf("read-ahead %d: too many reads: have %d, want at most %d", ahead, r.reads, 3)
+			}
+		}()
+	}
+	// Ensure truncated streams are still rejected
+	if err := ssz.DecodeFromStream(bytes.NewReader(blob[:len(blob)-1]), new(types.ExecutionPayloadCapella), uint32(len(blob))); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("truncated stream error mismatch: have %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
+// Tests that decoding with a custom allocator draws the byte slices of the
+// decoded fields from it, without altering the decoded contents.
+func TestDecodeAlloc(t *testing.T) {
+	obj := &types.ExecutionPayloadCapella{
+		ExtraData:     []byte{1, 2, 3},
+		BaseFeePerGas: uint256.NewInt(4),
+		Transactions:  [][]byte{{5}, {}, bytes.Repeat([]byte{6}, 100)},
+		Withdrawals:   []*types.Withdrawal{{Index: 7}},
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	// Create a simple bump allocator and decode the object with it
+	var (
+		arena = make([]byte, 1024)
+		used  int
+		calls int
+	)
+	opts := &ssz.DecodeOptions{
+		Alloc: func(n int) []byte {
+			calls++
+			blob := arena[used : used+n]
+			used += n
+			return blob
+		},
+	}
+	dec := new(types.ExecutionPayloadCapella)
+	if err := ssz.DecodeFromBytesWithOptions(blob, dec, ssz.ForkUnknown, opts); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	// The transactions are laid out in a single flat arena, so one allocation is
+	// needed for the extra data and one for all the transactions
+	if calls != 2 || used != 3+1+100 {
+		t.Errorf("allocation mismatch: have %d calls for %d bytes, want %d calls for %d bytes", calls, used, 2, 104)
+	}
+	if &dec.ExtraData[0] != &arena[0] || &dec.Transactions[0][0] != &arena[3] || &dec.Transactions[2][0] != &arena[4] {
+		t.Errorf("decoded fields not allocated from the arena")
+	}
+	reenc := make([]byte, ssz.Size(dec))
+	if err := ssz.EncodeToBytes(reenc, dec); err != nil {
+		t.Fatalf("failed to re-encode object: %v", err)
+	}
+	if !bytes.Equal(blob, reenc) {
+		t.Errorf("re-encoded object mismatch")
+	}
+	// Ensure streaming decoding also uses the allocator
+	used, calls = 0, 0
+	dec = new(types.ExecutionPayloadCapella)
+	if err := ssz.DecodeFromStreamWithOptions(bytes.NewReader(blob), dec, uint32(len(blob)), ssz.ForkUnknown, opts); err != nil {
+		t.Fatalf("failed to stream decode object: %v", err)
+	}
+	if calls != 2 || &dec.Transactions[2][0] != &arena[4] {
+		t.Errorf("stream decoded fields not allocated from the arena")
+	}
+}
+
+// Tests that a custom allocator does not outlive a decoding that panicked, as
+// the pooled decoder would otherwise keep using it for unrelated callers.
+func TestDecodeAllocPanic(t *testing.T) {
+	obj := &types.ExecutionPayloadCapella{ExtraData: []byte{1, 2, 3}, BaseFeePerGas: new(uint256.Int)}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	var calls int
+	opts := &ssz.DecodeOptions{
+		Alloc: func(n int) []byte {
+			calls++
+			panic("allocator failure")
+		},
+	}
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatalf("allocator panic not propagated")
+			}
+		}()
+		ssz.DecodeFromBytesWithOptions(blob, new(types.ExecutionPayloadCapella), ssz.ForkUnknown, opts)
+	}()
+	if err := ssz.DecodeFromBytes(blob, new(types.ExecutionPayloadCapella)); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	if err := ssz.DecodeFromStream(bytes.NewReader(blob), new(types.ExecutionPayloadCapella), uint32(len(blob))); err != nil {
+		t.Fatalf("failed to stream decode object: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("allocator calls mismatch: have %d, want %d", calls, 1)
+	}
+}
+
+// Tests that static objects can be decoded one by one from concatenated data,
+// while dynamic ones are rejected as they are not self-delimiting.
+func TestDecodeTrailing(t *testing.T) {
+	var blob []byte
+	for i := 0; i < 3; i++ {
+		obj := &types.Withdrawal{Index: uint64(i), Validator: uint64(i + 1), Amount: uint64(i + 2)}
+
+		enc := make([]byte, ssz.Size(obj))
+		if err := ssz.EncodeToBytes(enc, obj); err != nil {
+			t.Fatalf("failed to encode withdrawal %d: %v", i, err)
+		}
+		blob = append(blob, enc...)
+	}
+	blob = append(blob, 0xde, 0xad)
+
+	for i := 0; i < 3; i++ {
+		obj := new(types.Withdrawal)
+		n, err := ssz.DecodeFromBytesTrailing(blob, obj)
+		if err != nil {
+			t.Fatalf("failed to decode withdrawal %d: %v", i, err)
+		}
+		if n != 44 {
+			t.Errorf("withdrawal %d: consumed mismatch: have %d, want %d", i, n, 44)
+		}
+		if obj.Index != uint64(i) || obj.Amount != uint64(i+2) {
+			t.Errorf("withdrawal %d: decoded content mismatch: %+v", i, obj)
+		}
+		blob = blob[n:]
+	}
+	if _, err := ssz.DecodeFromBytesTrailing(blob, new(types.Withdrawal)); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("leftover decoding error mismatch: have %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+	// Dynamic objects would extend until the end of the data, so they are rejected
+	obj := &types.IndexedAttestation{AttestationIndices: []uint64{1, 2}, Data: new(types.AttestationData)}
+
+	enc := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(enc, obj); err != nil {
+		t.Fatalf("failed to encode attestation: %v", err)
+	}
+	if _, err := ssz.DecodeFromBytesTrailing(append(enc, make([]byte, 8)...), new(types.IndexedAttestation)); !errors.Is(err, ssz.ErrNotSelfDelimiting) {
+		t.Errorf("dynamic decoding error mismatch: have %v, want %v", err, ssz.ErrNotSelfDelimiting)
+	}
+}
+
+// Tests that the checked decoders enforce the maximum sizes registered for the
+// types, falling back to the default ones and rejecting unregistered types.
+func TestCheckedDecode(t *testing.T) {
+	ssz.RegisterMaxSize[*types.ExecutionPayloadMonolith](ssz.ForkUnknown, 1024)
+	ssz.RegisterMaxSize[*types.ExecutionPayloadMonolith](ssz.ForkDeneb, 2048)
+	ssz.RegisterMaxSize[*types.Withdrawal](ssz.ForkUnknown, 44)
+	t.Cleanup(func() {
+		ssz.UnregisterMaxSize[*types.ExecutionPayloadMonolith](ssz.ForkUnknown)
+		ssz.UnregisterMaxSize[*types.ExecutionPayloadMonolith](ssz.ForkDeneb)
+		ssz.UnregisterMaxSize[*types.Withdrawal](ssz.ForkUnknown)
+	})
+	obj := &types.ExecutionPayloadMonolith{BaseFeePerGas: new(uint256.Int), Transactions: [][]byte{make([]byte, 1000)}}
+	for _, tt := range []struct {
+		fork ssz.Fork
+		err  error
+	}{
+		{ssz.ForkCapella, ssz.ErrMaxObjectSizeExceeded}, // default limit
+		{ssz.ForkDeneb, nil},                            // fork specific limit
+	} {
+		blob := make([]byte, ssz.SizeOnFork(obj, tt.fork))
+		if err := ssz.EncodeToBytesOnFork(blob, obj, tt.fork); err != nil {
+			t.Fatalf("fork %v: failed to encode object: %v", tt.fork, err)
+		}
+		if err := ssz.DecodeFromBytesCheckedOnFork(blob, new(types.ExecutionPayloadMonolith), tt.fork); !errors.Is(err, tt.err) {
+			t.Errorf("fork %v: bytes error mismatch: have %v, want %v", tt.fork, err, tt.err)
+		}
+		if err := ssz.DecodeFromStreamCheckedOnFork(bytes.NewReader(blob), new(types.ExecutionPayloadMonolith), uint32(len(blob)), tt.fork); !errors.Is(err, tt.err) {
+			t.Errorf("fork %v: stream error mismatch: have %v, want %v", tt.fork, err, tt.err)
+		}
+		if err := ssz.DecodeFromSnappyBytesCheckedOnFork(snappy.Encode(nil, blob), new(types.ExecutionPayloadMonolith), tt.fork); !errors.Is(err, tt.err) {
+			t.Errorf("fork %v: snappy error mismatch: have %v, want %v", tt.fork, err, tt.err)
+		}
+		var framed bytes.Buffer
+		if err := ssz.EncodeToSnappyStreamOnFork(&framed, obj, tt.fork); err != nil {
+			t.Fatalf("fork %v: failed to snappy encode object: %v", tt.fork, err)
+		}
+		if err := ssz.DecodeFromSnappyStreamCheckedOnFork(&framed, new(types.ExecutionPayloadMonolith), uint32(len(blob)), tt.fork); !errors.Is(err, tt.err) {
+			t.Errorf("fork %v: snappy stream error mismatch: have %v, want %v", tt.fork, err, tt.err)
+		}
+	}
+	if limit, ok := ssz.MaxSize[*types.ExecutionPayloadMonolith](ssz.ForkElectra); !ok || limit != 1024 {
+		t.Errorf("default limit mismatch: have %d (%v), want %d", limit, ok, 1024)
+	}
+	// Non-monolithic types use the default limit via the fork agnostic variants
+	withdrawal := &types.Withdrawal{Index: 1}
+	blob := make([]byte, ssz.Size(withdrawal))
+	if err := ssz.EncodeToBytes(blob, withdrawal); err != nil {
+		t.Fatalf("failed to encode withdrawal: %v", err)
+	}
+	var framed bytes.Buffer
+	if err := ssz.EncodeToSnappyStream(&framed, withdrawal); err != nil {
+		t.Fatalf("failed to snappy encode withdrawal: %v", err)
+	}
+	for name, decode := range map[string]func() error{
+		"bytes": func() error { return ssz.DecodeFromBytesChecked(blob, new(types.Withdrawal)) },
+		"stream": func() error {
+			return ssz.DecodeFromStreamChecked(bytes.NewReader(blob), new(types.Withdrawal), uint32(len(blob)))
+		},
+		"snappy": func() error { return ssz.DecodeFromSnappyBytesChecked(snappy.Encode(nil, blob), new(types.Withdrawal)) },
+		"snappy stream": func() error {
+			return ssz.DecodeFromSnappyStreamChecked(&framed, new(types.Withdrawal), uint32(len(blob)))
+		},
+	} {
+		if err := decode(); err != nil {
+			t.Errorf("%s: failed to decode withdrawal: %v", name, err)
+		}
+	}
+	// Unregistered types should be rejected
+	ssz.UnregisterMaxSize[*types.Withdrawal](ssz.ForkUnknown)
+	if err := ssz.DecodeFromBytesChecked(blob, new(types.Withdrawal)); !errors.Is(err, ssz.ErrMaxSizeNotRegistered) {
+		t.Errorf("unregistered error mismatch: have %v, want %v", err, ssz.ErrMaxSizeNotRegistered)
+	}
+}
+
+// Tests that payloads produced by a newer fork can be decoded by an older fork
+// if unknown trailing fields are tolerated, and that they get reported.
+func TestDecodeSkipUnknownFields(t *testing.T) {
+	blobGasUsed, excessBlobGas := uint64(1), uint64(2)
+	obj := &types.ExecutionPayloadHeaderMonolith{
+		ExtraData:      []byte{0xde, 0xad, 0xbe, 0xef},
+		BlockNumber:    3,
+		WithdrawalRoot: &[32]byte{0x04},
+		BlobGasUsed:    &blobGasUsed,
+		ExcessBlobGas:  &excessBlobGas,
+	}
+	blob := make([]byte, ssz.SizeOnFork(obj, ssz.ForkCancun))
+	if err := ssz.EncodeToBytesOnFork(blob, obj, ssz.ForkCancun); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	// Strict decoding must reject the fields unknown in the older fork
+	if err := ssz.DecodeFromBytesOnFork(blob, new(types.ExecutionPayloadHeaderMonolith), ssz.ForkShanghai); !errors.Is(err, ssz.ErrFirstOffsetMismatch) {
+		t.Fatalf("strict decode error mismatch: have %v, want %v", err, ssz.ErrFirstOffsetMismatch)
+	}
+	// Tolerant decoding must skip and report the unknown fields
+	var (
+		offsets []uint32
+		unknown [][]byte
+	)
+	opts := &ssz.DecodeOptions{
+		SkipUnknownFields: true,
+		UnknownFields: func(offset uint32, blob []byte) {
+			offsets = append(offsets, offset)
+			unknown = append(unknown, append([]byte{}, blob...))
+		},
+	}
+	known := ssz.SizeOnFork(obj, ssz.ForkShanghai) - uint32(len(obj.ExtraData))
+
+	dec := new(types.ExecutionPayloadHeaderMonolith)
+	if err := ssz.DecodeFromBytesWithOptions(blob, dec, ssz.ForkShanghai, opts); err != nil {
+		t.Fatalf("failed to decode with unknown fields: %v", err)
+	}
+	if !bytes.Equal(dec.ExtraData, obj.ExtraData) || dec.BlockNumber != obj.BlockNumber || *dec.WithdrawalRoot != *obj.WithdrawalRoot {
+		t.Errorf("decoded object mismatch: have %+v, want %+v", dec, obj)
+	}
+	if dec.BlobGasUsed != nil || dec.ExcessBlobGas != nil {
+		t.Errorf("unknown fields decoded: %v, %v", dec.BlobGasUsed, dec.ExcessBlobGas)
+	}
+	if len(offsets) != 1 || offsets[0] != known || !bytes.Equal(unknown[0], blob[known:known+16]) {
+		t.Errorf("unknown fields mismatch: have %v %x, want [%d] %x", offsets, unknown, known, blob[known:known+16])
+	}
+	// Streaming decoding must behave the same way
+	offsets, unknown = nil, nil
+
+	dec = new(types.ExecutionPayloadHeaderMonolith)
+	if err := ssz.DecodeFromStreamWithOptions(bytes.NewReader(blob), dec, uint32(len(blob)), ssz.ForkShanghai, opts); err != nil {
+		t.Fatalf("failed to stream decode with unknown fields: %v", err)
+	}
+	if !bytes.Equal(dec.ExtraData, obj.ExtraData) || len(offsets) != 1 || offsets[0] != known {
+		t.Errorf("stream decode mismatch: have %x, unknown at %v", dec.ExtraData, offsets)
+	}
+	// Static objects have their unknown fields trailing after the known ones
+	static := append(make([]byte, 40), 0xff, 0xff)
+	static[0] = 0x05
+
+	offsets, unknown = nil, nil
+	checkpoint := new(types.Checkpoint)
+	if err := ssz.DecodeFromBytes(static, checkpoint); !errors.Is(err, ssz.ErrObjectSlotSizeMismatch) {
+		t.Fatalf("strict decode error mismatch: have %v, want %v", err, ssz.ErrObjectSlotSizeMismatch)
+	}
+	if err := ssz.DecodeFromBytesWithOptions(static, checkpoint, ssz.ForkUnknown, opts); err != nil {
+		t.Fatalf("failed to decode with unknown fields: %v", err)
+	}
+	if checkpoint.Epoch != 5 || len(offsets) != 1 || offsets[0] != 40 || !bytes.Equal(unknown[0], []byte{0xff, 0xff}) {
+		t.Errorf("static decode mismatch: epoch %d, unknown %v %x", checkpoint.Epoch, offsets, unknown)
+	}
+	// Skipping without reporting must also work
+	if err := ssz.DecodeFromStreamWithOptions(bytes.NewReader(static), checkpoint, uint32(len(static)), ssz.ForkUnknown, &ssz.DecodeOptions{SkipUnknownFields: true}); err != nil {
+		t.Fatalf("failed to stream decode without reporting: %v", err)
+	}
+}
+
+// Tests that decoding aborts once the memory allocated for the decoded fields
+// exceeds the configured budget, but not when reusing the decoded object.
+func TestDecodeBudget(t *testing.T) {
+	obj := &types.ExecutionPayload{
+		ExtraData:     []byte{0x01},
+		BaseFeePerGas: new(uint256.Int),
+		Transactions:  [][]byte{make([]byte, 1000), make([]byte, 1000), make([]byte, 1000)},
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	// Decoding into a fresh object must enforce the budget
+	opts := &ssz.DecodeOptions{Budget: 2048}
+	if err := ssz.DecodeFromBytesWithOptions(blob, new(types.ExecutionPayload), ssz.ForkUnknown, opts); !errors.Is(err, ssz.ErrDecodeBudgetExceeded) {
+		t.Errorf("budget error mismatch: have %v, want %v", err, ssz.ErrDecodeBudgetExceeded)
+	}
+	if err := ssz.DecodeFromStreamWithOptions(bytes.NewReader(blob), new(types.ExecutionPayload), uint32(len(blob)), ssz.ForkUnknown, opts); !errors.Is(err, ssz.ErrDecodeBudgetExceeded) {
+		t.Errorf("stream budget error mismatch: have %v, want %v", err, ssz.ErrDecodeBudgetExceeded)
+	}
+	dec := new(types.ExecutionPayload)
+	if err := ssz.DecodeFromBytesWithOptions(blob, dec, ssz.ForkUnknown, &ssz.DecodeOptions{Budget: 4096}); err != nil {
+		t.Fatalf("failed to decode within budget: %v", err)
+	}
+	// Decoding into a previously decoded object reuses its memory
+	if err := ssz.DecodeFromBytesWithOptions(blob, dec, ssz.ForkUnknown, &ssz.DecodeOptions{Budget: 1}); err != nil {
+		t.Errorf("failed to redecode within budget: %v", err)
+	}
+	// The budget must not leak into subsequent decodes without one
+	if err := ssz.DecodeFromBytes(blob, new(types.ExecutionPayload)); err != nil {
+		t.Errorf("failed to decode without budget: %v", err)
+	}
+}
+
+// Tests that the decode budget accounts for the objects allocated for the items
+// of dynamic lists too, not just the slices holding them.
+func TestDecodeBudgetObjects(t *testing.T) {
+	obj := &types.BeaconBlockBody{Eth1Data: new(types.Eth1Data)}
+	for i := 0; i < 128; i++ {
+		obj.Attestations = append(obj.Attestations, &types.Attestation{AggregationBits: bitfield.Bitlist{0x01}, Data: new(types.AttestationData)})
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	// The item pointers and bitlists fit into the budget, the objects do not
+	opts := &ssz.DecodeOptions{Budget: 4096}
+	if err := ssz.DecodeFromBytesWithOptions(blob, new(types.BeaconBlockBody), ssz.ForkUnknown, opts); !errors.Is(err, ssz.ErrDecodeBudgetExceeded) {
+		t.Errorf("budget error mismatch: have %v, want %v", err, ssz.ErrDecodeBudgetExceeded)
+	}
+	if err := ssz.DecodeFromStreamWithOptions(bytes.NewReader(blob), new(types.BeaconBlockBody), uint32(len(blob)), ssz.ForkUnknown, opts); !errors.Is(err, ssz.ErrDecodeBudgetExceeded) {
+		t.Errorf("stream budget error mismatch: have %v, want %v", err, ssz.ErrDecodeBudgetExceeded)
+	}
+	dec := new(types.BeaconBlockBody)
+	if err := ssz.DecodeFromBytesWithOptions(blob, dec, ssz.ForkUnknown, &ssz.DecodeOptions{Budget: 64 * 1024}); err != nil {
+		t.Fatalf("failed to decode within budget: %v", err)
+	}
+	// Decoding into the previously decoded object reuses the items
+	if err := ssz.DecodeFromBytesWithOptions(blob, dec, ssz.ForkUnknown, &ssz.DecodeOptions{Budget: 1}); err != nil {
+		t.Errorf("failed to redecode within budget: %v", err)
+	}
+}
+
+// Tests that the preallocation factor adds capacity headroom to the decoded
+// dynamic blobs (capped at their limits), which is reused by later decodes.
+func TestDecodePreallocFactor(t *testing.T) {
+	encode := func(obj *types.ExecutionPayload) []byte {
+		blob := make([]byte, ssz.Size(obj))
+		if err := ssz.EncodeToBytes(blob, obj); err != nil {
+			t.Fatalf("failed to encode object: %v", err)
+		}
+		return blob
+	}
+	small := encode(&types.ExecutionPayload{
+		ExtraData:     make([]byte, 10),
+		BaseFeePerGas: new(uint256.Int),
+		Transactions:  [][]byte{make([]byte, 100)},
+	})
+	large := encode(&types.ExecutionPayload{
+		ExtraData:     make([]byte, 20),
+		BaseFeePerGas: new(uint256.Int),
+		Transactions:  [][]byte{make([]byte, 150), make([]byte, 10)},
+	})
+	opts := &ssz.DecodeOptions{PreallocFactor: 4}
+
+	obj := new(types.ExecutionPayload)
+	if err := ssz.DecodeFromStreamWithOptions(bytes.NewReader(small), obj, uint32(len(small)), ssz.ForkUnknown, opts); err != nil {
+		t.Fatalf("failed to decode small payload: %v", err)
+	}
+	if len(obj.ExtraData) != 10 || cap(obj.ExtraData) != 32 {
+		t.Errorf("extra data size mismatch: have len %d cap %d, want len 10 cap 32", len(obj.ExtraData), cap(obj.ExtraData))
+	}
+	// Blob lists keep a spare slot past their capacity headroom for the arena
+	if len(obj.Transactions) != 1 || cap(obj.Transactions) != 4+1 || cap(obj.Transactions[0]) != 100 {
+		t.Errorf("transactions size mismatch: have len %d cap %d, tx cap %d", len(obj.Transactions), cap(obj.Transactions), cap(obj.Transactions[0]))
+	}
+	// Decoding a larger payload into the same object should reuse the memory
+	extra, txs := &obj.ExtraData[0], &obj.Transactions[0]
+	if err := ssz.DecodeFromBytesWithOptions(large, obj, ssz.ForkUnknown, opts); err != nil {
+		t.Fatalf("failed to decode large payload: %v", err)
+	}
+	if &obj.ExtraData[0] != extra || &obj.Transactions[0] != txs {
+		t.Errorf("dynamic fields reallocated")
+	}
+	// Without the factor, allocations should be exact
+	exact := new(types.ExecutionPayload)
+	if err := ssz.DecodeFromBytes(small, exact); err != nil {
+		t.Fatalf("failed to decode small payload: %v", err)
+	}
+	if cap(exact.ExtraData) != 10 || cap(exact.Transactions) != 1+1 {
+		t.Errorf("exact allocation mismatch: have extra cap %d, txs cap %d", cap(exact.ExtraData), cap(exact.Transactions))
+	}
+}
+
+// Tests that decoding slices of blobs into a used object reuses their memory,
+// even if the item count and sizes shift around or the object is reset between
+// decodes, as long as the total fits.
+func TestDecodeDynamicBytesReuse(t *testing.T) {
+	encode := func(sizes ...int) []byte {
+		obj := &types.ExecutionPayload{BaseFeePerGas: new(uint256.Int)}
+		for i, size := range sizes {
+			obj.Transactions = append(obj.Transactions, bytes.Repeat([]byte{byte(i + 1)}, size))
+		}
+		blob := make([]byte, ssz.Size(obj))
+		if err := ssz.EncodeToBytes(blob, obj); err != nil {
+			t.Fatalf("failed to encode object: %v", err)
+		}
+		return blob
+	}
+	// Decode a payload once to grow the buffers, then decode differently shaped
+	// ones of at most the same total size (larger blobs in slots only small ones
+	// were decoded into before, larger totals after smaller ones), which should
+	// not allocate
+	obj := new(types.ExecutionPayload)
+	if err := ssz.DecodeFromBytes(encode(100, 10, 0, 0), obj); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	blobs := [][]byte{encode(10, 100), encode(50), encode(0, 0, 10, 100), encode(20, 30), encode(110), encode(40, 30, 40)}
+
+	var (
+		start = uintptr(unsafe.Pointer(&obj.Transactions[0][0]))
+		end   = start + 110
+	)
+	for _, blob := range blobs {
+		if err := ssz.DecodeFromBytes(blob, obj); err != nil {
+			t.Fatalf("failed to decode object: %v", err)
+		}
+		for j, tx := range obj.Transactions {
+			if ptr := uintptr(unsafe.Pointer(unsafe.SliceData(tx))); len(tx) > 0 && (ptr < start || ptr+uintptr(len(tx)) > end) {
+				t.Fatalf("transaction %d reallocated", j)
+			}
+		}
+	}
+	// Run through all the shapes in every measured run, so that a single stray
+	// allocation is not averaged away
+	allocs := testing.AllocsPerRun(10, func() {
+		for _, blob := range blobs {
+			if err := ssz.DecodeFromBytes(blob, obj); err != nil {
+				t.Fatalf("failed to decode object: %v", err)
+			}
+		}
+	})
+	if allocs != 0 && !raceEnabled {
+		t.Errorf("steady state allocations: have %v, want 0", allocs)
+	}
+	// Resetting the object between decodes should retain the memory too
+	monolith := new(types.ExecutionPayloadMonolith)
+	if err := ssz.DecodeFromBytesOnFork(encode(100, 10), monolith, ssz.ForkBellatrix); err != nil {
+		t.Fatalf("failed to decode monolith: %v", err)
+	}
+	allocs = testing.AllocsPerRun(10, func() {
+		for _, blob := range blobs {
+			monolith.ResetSSZ()
+			if err := ssz.DecodeFromBytesOnFork(blob, monolith, ssz.ForkBellatrix); err != nil {
+				t.Fatalf("failed to decode monolith: %v", err)
+			}
+		}
+	})
+	if allocs != 0 && !raceEnabled {
+		t.Errorf("steady state allocations with resets: have %v, want 0", allocs)
+	}
+	// The reused memory should still decode correctly
+	if err := ssz.DecodeFromBytes(blobs[5], obj); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	reenc := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(reenc, obj); err != nil {
+		t.Fatalf("failed to re-encode object: %v", err)
+	}
+	if !bytes.Equal(reenc, blobs[5]) {
+		t.Errorf("re-encoded object mismatch")
+	}
+	// Appending to any blob should not overwrite the next one
+	for i := range obj.Transactions {
+		obj.Transactions[i] = append(obj.Transactions[i], 0xff)
+	}
+	for i, want := range [][]byte{bytes.Repeat([]byte{1}, 40), bytes.Repeat([]byte{2}, 30), bytes.Repeat([]byte{3}, 40)} {
+		if !bytes.Equal(obj.Transactions[i], append(want, 0xff)) {
+			t.Errorf("transaction %d corrupted by appends: have %x, want %x", i, obj.Transactions[i], append(want, 0xff))
+		}
+	}
+	// Grown blobs are not laid out back to back any more, so the next decode
+	// must not write into them
+	grown := obj.Transactions[0]
+	if err := ssz.DecodeFromBytes(blobs[4], obj); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	if !bytes.Equal(grown, append(bytes.Repeat([]byte{1}, 40), 0xff)) {
+		t.Errorf("decoding overwrote a grown blob")
+	}
+}
@@ -0,0 +1,108 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/holiman/uint256"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that dumping an object annotates every field of the encoding, including
+// offsets, dynamic regions and nested objects.
+func TestDump(t *testing.T) {
+	obj := &types.ExecutionPayloadCapella{
+		BlockNumber:   7,
+		ExtraData:     []byte{1, 2, 3},
+		BaseFeePerGas: uint256.NewInt(8),
+		Transactions:  [][]byte{{9}, {}},
+		Withdrawals:   []*types.Withdrawal{{Index: 11}, {Index: 13}},
+	}
+	dump := ssz.Dump(obj, ssz.ForkUnknown)
+	for _, want := range []string{
+		"ExecutionPayloadCapella: 612 bytes\n",
+		"  0x000194-0x00019c BlockNumber: 0x0700000000000000\n",
+		"  0x0001b4-0x0001b8 ExtraData: offset -> 0x000200\n",
+		"  0x0001fc-0x000200 Withdrawals: offset -> 0x00020c\n",
+		"  -- dynamic region 0x000200-0x000264 --\n  0x000200-0x000203 ExtraData: 0x010203\n",
+		"  0x000203-0x00020c Transactions: 0x080000000900000009\n",
+		"  0x00020c-0x000264 Withdrawals: 2 items\n    [0] Withdrawal: 44 bytes\n      0x00020c-0x000214 Index: 0x0b00000000000000\n",
+		"    [1] Withdrawal: 44 bytes\n      0x000238-0x000240 Index: 0x0d00000000000000\n",
+	} {
+		if !strings.Contains(dump, want) {
+			t.Errorf("dump missing %q:\n%s", want, dump)
+		}
+	}
+	// Ensure fields inactive in a fork are still listed, but empty
+	monolith := &types.ExecutionPayloadMonolith{BaseFeePerGas: uint256.NewInt(1), ExtraData: []byte{2}}
+
+	dump = ssz.Dump(monolith, ssz.ForkBellatrix)
+	for _, want := range []string{
+		"ExecutionPayloadMonolith: 509 bytes\n",
+		"  0x0001fc-0x0001fc BlobGasUsed: -\n",
+		"  -- dynamic region 0x0001fc-0x0001fd --\n  0x0001fc-0x0001fd ExtraData: 0x02\n",
+	} {
+		if !strings.Contains(dump, want) {
+			t.Errorf("monolith dump missing %q:\n%s", want, dump)
+		}
+	}
+	// Ensure codec failures (e.g. limit violations caught on decode) are reported
+	bad := &types.ExecutionPayloadCapella{BaseFeePerGas: uint256.NewInt(0), ExtraData: make([]byte, 33)}
+	if dump = ssz.Dump(bad, ssz.ForkUnknown); !strings.Contains(dump, "error: ") {
+		t.Errorf("dump missing codec error:\n%s", dump)
+	}
+}
+
+// Tests that diffing objects reports the innermost differing fields by path.
+func TestDiff(t *testing.T) {
+	a := &types.ExecutionPayloadCapella{
+		BlockNumber:   7,
+		ExtraData:     []byte{1, 2, 3},
+		BaseFeePerGas: uint256.NewInt(8),
+		Transactions:  [][]byte{{9}, {}},
+		Withdrawals:   []*types.Withdrawal{{Index: 11}},
+	}
+	if diffs := ssz.Diff(a, a, ssz.ForkUnknown); len(diffs) != 0 {
+		t.Errorf("identical objects reported different: %v", diffs)
+	}
+	b := &types.ExecutionPayloadCapella{
+		BlockNumber:   8,
+		ExtraData:     []byte{1, 2, 3, 4},
+		BaseFeePerGas: uint256.NewInt(8),
+		Transactions:  [][]byte{{9}, {}},
+		Withdrawals:   []*types.Withdrawal{{Index: 11, Amount: 12}, {Index: 13}},
+	}
+	diffs := ssz.Diff(a, b, ssz.ForkUnknown)
+
+	want := []ssz.FieldDiff{
+		{Path: "BlockNumber", A: []byte{7, 0, 0, 0, 0, 0, 0, 0}, B: []byte{8, 0, 0, 0, 0, 0, 0, 0}},
+		{Path: "ExtraData", A: []byte{1, 2, 3}, B: []byte{1, 2, 3, 4}},
+		{Path: "Withdrawals[0].Amount", A: make([]byte, 8), B: []byte{12, 0, 0, 0, 0, 0, 0, 0}},
+	}
+	if len(diffs) != len(want)+1 {
+		t.Fatalf("diff count mismatch: have %d, want %d: %v", len(diffs), len(want)+1, diffs)
+	}
+	for i := range want {
+		if diffs[i].Path != want[i].Path || !bytes.Equal(diffs[i].A, want[i].A) || !bytes.Equal(diffs[i].B, want[i].B) {
+			t.Errorf("diff %d mismatch: have %+v, want %+v", i, diffs[i], want[i])
+		}
+	}
+	if diffs[3].Path != "Withdrawals[1]" || diffs[3].A != nil || len(diffs[3].B) != 44 {
+		t.Errorf("missing item diff mismatch: have %+v", diffs[3])
+	}
+	// Nested single objects should be descended into by field name
+	blockA := &types.BeaconBlock{Slot: 1, Body: &types.BeaconBlockBody{Graffiti: [32]byte{1}}}
+	blockB := &types.BeaconBlock{Slot: 1, Body: &types.BeaconBlockBody{Graffiti: [32]byte{2}}}
+
+	diffs = ssz.Diff(blockA, blockB, ssz.ForkUnknown)
+	if len(diffs) != 1 || diffs[0].Path != "Body.Graffiti" {
+		t.Errorf("nested diff mismatch: have %v, want path Body.Graffiti", diffs)
+	}
+}
@@ -0,0 +1,322 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/prysmaticlabs/go-bitfield"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that encoding errors (e.g. failed stream writes) are annotated with the
+// path of the failing field.
+func TestEncodeErrorPath(t *testing.T) {
+	marker := []byte{0xab, 0xcd, 0xef, 0x01}
+	obj := &types.BeaconBlockBodyDeneb{
+		Attestations: []*types.Attestation{
+			{AggregationBits: bitfield.NewBitlist(8)},
+			{AggregationBits: bitfield.NewBitlist(8)},
+			{AggregationBits: bitfield.NewBitlist(8)},
+			{AggregationBits: bitfield.Bitlist(marker)},
+		},
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	// Cut the stream short in the middle of the last attestation's bits
+	sink := make([]byte, bytes.Index(blob, marker)+1)
+	err := ssz.EncodeToStream(&testEncodeOversizedStream{sink}, obj)
+
+	var eerr *ssz.EncodeError
+	if !errors.As(err, &eerr) {
+		t.Fatalf("encode error type mismatch: have %T, want %T", err, eerr)
+	}
+	if want := "BeaconBlockBodyDeneb.Attestations[3].AggregationBits"; eerr.Path != want {
+		t.Errorf("encode error path mismatch: have %s, want %s", eerr.Path, want)
+	}
+	if eerr.Err == nil || eerr.Err.Error() != "stream full" {
+		t.Errorf("encode error cause mismatch: have %v, want stream full", eerr.Err)
+	}
+}
+
+// Tests that validating an encoding detects non-canonical inputs.
+func TestValidateCanonical(t *testing.T) {
+	blob := make([]byte, 16)
+	blob[9] = 0x01 // 2nd field, 2nd byte
+
+	if err := ssz.Validate(blob, new(testMissizedType)); err != nil {
+		t.Errorf("failed to validate canonical encoding: %v", err)
+	}
+	blob[8] = 0x01 // 2nd field, 1st byte (lowest bit dropped by the encoder)
+
+	err := ssz.Validate(blob, new(testNonCanonicalType))
+	if !errors.Is(err, ssz.ErrNonCanonicalEncoding) {
+		t.Errorf("validation error mismatch: have %v, want %v", err, ssz.ErrNonCanonicalEncoding)
+	}
+}
+
+type testNonCanonicalType struct {
+	A, B uint64
+}
+
+func (t *testNonCanonicalType) SizeSSZ(sizer *ssz.Sizer) uint32 { return 16 }
+func (t *testNonCanonicalType) DefineSSZ(codec *ssz.Codec) {
+	codec.DefineEncoder(func(enc *ssz.Encoder) {
+		ssz.EncodeUint64(enc, t.A)
+		ssz.EncodeUint64(enc, t.B&^1)
+	})
+	codec.DefineDecoder(func(dec *ssz.Decoder) {
+		ssz.DecodeUint64(dec, &t.A)
+		ssz.DecodeUint64(dec, &t.B)
+	})
+	codec.DefineHasher(func(has *ssz.Hasher) {
+		ssz.HashUint64(has, t.A)
+		ssz.HashUint64(has, t.B)
+	})
+}
+
+// Tests that standard objects can be nested into custom wire formats.
+func TestNestedObjects(t *testing.T) {
+	obj := &testNestedType{
+		A:          1,
+		Payload:    &types.ExecutionPayload{ExtraData: []byte{0x01, 0x02}, Transactions: [][]byte{{0x03}}},
+		Checkpoint: &types.Checkpoint{Epoch: 2},
+		B:          3,
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	stream := new(bytes.Buffer)
+	if err := ssz.EncodeToStream(stream, obj); err != nil {
+		t.Fatalf("failed to stream object: %v", err)
+	}
+	if !bytes.Equal(blob, stream.Bytes()) {
+		t.Fatalf("buffer/stream mismatch: buffer %x, stream %x", blob, stream.Bytes())
+	}
+	for _, decode := range []func(obj ssz.Object) error{
+		func(obj ssz.Object) error { return ssz.DecodeFromBytes(blob, obj) },
+		func(obj ssz.Object) error { return ssz.DecodeFromStream(bytes.NewReader(blob), obj, uint32(len(blob))) },
+	} {
+		dec := new(testNestedType)
+		if err := decode(dec); err != nil {
+			t.Fatalf("failed to decode object: %v", err)
+		}
+		if dec.A != obj.A || dec.B != obj.B || dec.Checkpoint.Epoch != obj.Checkpoint.Epoch {
+			t.Errorf("decoded fields mismatch: have %+v, want %+v", dec, obj)
+		}
+		if ssz.HashSequential(dec.Payload) != ssz.HashSequential(obj.Payload) {
+			t.Errorf("decoded payload mismatch")
+		}
+	}
+}
+
+// testNestedType is a custom wire format embedding a size-prefixed dynamic and
+// a static standard ssz object between plain fields.
+type testNestedType struct {
+	A          uint64
+	Payload    *types.ExecutionPayload
+	Checkpoint *types.Checkpoint
+	B          uint64
+}
+
+func (t *testNestedType) SizeSSZ(sizer *ssz.Sizer, fixed bool) uint32 {
+	size := uint32(8 + 4 + 40 + 8)
+	if fixed {
+		return size
+	}
+	return size + ssz.Size(t.Payload)
+}
+func (t *testNestedType) DefineSSZ(codec *ssz.Codec) {
+	codec.DefineEncoder(func(enc *ssz.Encoder) {
+		ssz.EncodeUint64(enc, t.A)
+		ssz.EncodeUint32(enc, ssz.Size(t.Payload))
+		codec.EncodeNested(t.Payload)
+		codec.EncodeNested(t.Checkpoint)
+		ssz.EncodeUint64(enc, t.B)
+	})
+	codec.DefineDecoder(func(dec *ssz.Decoder) {
+		var size uint32
+
+		ssz.DecodeUint64(dec, &t.A)
+		ssz.DecodeUint32(dec, &size)
+		t.Payload = new(types.ExecutionPayload)
+		codec.DecodeNested(t.Payload, size)
+		t.Checkpoint = new(types.Checkpoint)
+		codec.DecodeNested(t.Checkpoint, 40)
+		ssz.DecodeUint64(dec, &t.B)
+	})
+}
+
+// testMemoizedSizeType is a tree of dynamic objects counting how many times the
+// total sizes of its nodes are computed.
+type testMemoizedSizeType struct {
+	Children []*testMemoizedSizeType
+	sizes    *int
+}
+
+func (t *testMemoizedSizeType) SizeSSZ(sizer *ssz.Sizer, fixed bool) uint32 {
+	size := uint32(4)
+	if fixed {
+		return size
+	}
+	*t.sizes++
+	return size + ssz.SizeSliceOfDynamicObjects(sizer, t.Children)
+}
+func (t *testMemoizedSizeType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineSliceOfDynamicObjectsOffset(codec, &t.Children, 8)
+	ssz.DefineSliceOfDynamicObjectsContent(codec, &t.Children, 8)
+}
+
+// Tests that the sizes of nested dynamic objects are computed only once during
+// an encoding, instead of at every level of nesting when calculating offsets.
+func TestEncodeMemoizedSizes(t *testing.T) {
+	var (
+		sizes int
+		depth = 8
+		root  = &testMemoizedSizeType{sizes: &sizes}
+	)
+	for node, i := root, 1; i < depth; i++ {
+		child := &testMemoizedSizeType{sizes: &sizes}
+		node.Children = []*testMemoizedSizeType{child}
+		node = child
+	}
+	size := ssz.Size(root)
+	if sizes != depth {
+		t.Fatalf("sizing: size computations mismatch: have %d, want %d", sizes, depth)
+	}
+	sizes = 0
+	if err := ssz.EncodeToBytes(make([]byte, size), root); err != nil {
+		t.Fatalf("failed to encode to bytes: %v", err)
+	}
+	if sizes != depth {
+		t.Errorf("bytes encoding: size computations mismatch: have %d, want %d", sizes, depth)
+	}
+	sizes = 0
+	if err := ssz.EncodeToStream(new(bytes.Buffer), root); err != nil {
+		t.Fatalf("failed to encode to stream: %v", err)
+	}
+	if sizes != depth-1 { // root size not needed when streaming
+		t.Errorf("stream encoding: size computations mismatch: have %d, want %d", sizes, depth-1)
+	}
+	// Ensure the memoized sizes don't leak into subsequent encodings
+	root.Children = nil
+	if have, want := ssz.Size(root), uint32(4); have != want {
+		t.Fatalf("size mismatch after modification: have %d, want %d", have, want)
+	}
+	blob := make([]byte, 4)
+	if err := ssz.EncodeToBytes(blob, root); err != nil {
+		t.Fatalf("failed to encode modified object: %v", err)
+	}
+	if want := []byte{4, 0, 0, 0}; !bytes.Equal(blob, want) {
+		t.Errorf("modified encoding mismatch: have %x, want %x", blob, want)
+	}
+}
+
+// Tests that the Sizer methods can be used by hand-written codecs to size nested
+// objects and dynamic fields, matching the free function variants.
+func TestSizerMethods(t *testing.T) {
+	obj := &testSizerMethods{
+		Withdrawal: new(types.Withdrawal),
+		Payload:    &types.ExecutionPayload{ExtraData: []byte{1, 2, 3}, Transactions: [][]byte{{4}, {5, 6}}},
+		Blob:       []byte{7, 8, 9, 10},
+		Nums:       []uint64{11, 12},
+	}
+	blob := new(bytes.Buffer)
+	if err := ssz.EncodeToStream(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	if size := ssz.Size(obj); int(size) != blob.Len() {
+		t.Fatalf("size mismatch: have %d, want %d", size, blob.Len())
+	}
+	// Nil nested objects must be sized as their zero values, same as the free
+	// size functions do
+	obj = &testSizerMethods{Blob: []byte{7, 8, 9, 10}}
+	blob.Reset()
+	if err := ssz.EncodeToStream(blob, obj); err != nil {
+		t.Fatalf("failed to encode nil fields: %v", err)
+	}
+	if size := ssz.Size(obj); int(size) != blob.Len() {
+		t.Fatalf("nil fields size mismatch: have %d, want %d", size, blob.Len())
+	}
+}
+
+// testSizerMethods is a hand-written dynamic type sizing itself via the methods
+// of the ssz.Sizer instead of the free functions.
+type testSizerMethods struct {
+	Withdrawal *types.Withdrawal
+	Payload    *types.ExecutionPayload
+	Blob       []byte
+	Nums       []uint64
+}
+
+func (t *testSizerMethods) SizeSSZ(siz *ssz.Sizer, fixed bool) uint32 {
+	size := siz.SizeOf(t.Withdrawal) + 4 + 4 + 4
+	if fixed {
+		return size
+	}
+	return size + siz.SizeOf(t.Payload) + siz.SizeDynamicBytes(t.Blob) + siz.SizeSliceOfUint64s(t.Nums)
+}
+func (t *testSizerMethods) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineStaticObject(codec, &t.Withdrawal)
+	ssz.DefineDynamicObjectOffset(codec, &t.Payload)
+	ssz.DefineDynamicBytesOffset(codec, &t.Blob, 32)
+	ssz.DefineSliceOfUint64sOffset(codec, &t.Nums, 16)
+
+	ssz.DefineDynamicObjectContent(codec, &t.Payload)
+	ssz.DefineDynamicBytesContent(codec, &t.Blob, 32)
+	ssz.DefineSliceOfUint64sContent(codec, &t.Nums, 16)
+}
+
+// Tests that checked encoding rejects objects exceeding their size limits, but
+// otherwise produces the exact same output as the unchecked encoder.
+func TestEncodeChecked(t *testing.T) {
+	tests := []struct {
+		obj  ssz.Object
+		fork ssz.Fork
+		err  error
+	}{
+		// Objects within their limits
+		{&types.ExecutionPayload{ExtraData: make([]byte, 32), BaseFeePerGas: new(uint256.Int)}, ssz.ForkUnknown, nil},
+		{&types.Attestation{AggregationBits: bitfield.NewBitlist(2048), Data: new(types.AttestationData)}, ssz.ForkUnknown, nil},
+		{&types.ForkRangesMonolith{C: make([]byte, 33)}, ssz.ForkPhase0, nil}, // Field inactive in fork
+
+		// Objects exceeding their limits
+		{&types.ExecutionPayload{ExtraData: make([]byte, 33), BaseFeePerGas: new(uint256.Int)}, ssz.ForkUnknown, ssz.ErrMaxLengthExceeded},
+		{&types.Attestation{AggregationBits: bitfield.NewBitlist(2049), Data: new(types.AttestationData)}, ssz.ForkUnknown, ssz.ErrMaxItemsExceeded},
+		{&types.ForkRangesMonolith{C: make([]byte, 33)}, ssz.ForkBellatrix, ssz.ErrMaxLengthExceeded},
+		{&types.PackedListsVariation{Extras: make([]uint16, 9)}, ssz.ForkDeneb, ssz.ErrMaxItemsExceeded},
+	}
+	for i, tt := range tests {
+		want := make([]byte, ssz.SizeOnFork(tt.obj, tt.fork))
+		if err := ssz.EncodeToBytesOnFork(want, tt.obj, tt.fork); err != nil {
+			t.Errorf("test %d: failed to encode unchecked: %v", i, err)
+			continue
+		}
+		have := make([]byte, len(want))
+		err := ssz.EncodeToBytesCheckedOnFork(have, tt.obj, tt.fork)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("test %d: checked encoding error mismatch: have %v, want %v", i, err, tt.err)
+			continue
+		}
+		if tt.err == nil && !bytes.Equal(have, want) {
+			t.Errorf("test %d: checked encoding mismatch: have %x, want %x", i, have, want)
+		}
+	}
+	// Ensure the checked mode does not leak into subsequent unchecked encodings
+	obj := &types.ExecutionPayload{ExtraData: make([]byte, 33), BaseFeePerGas: new(uint256.Int)}
+	for i := 0; i < 2; i++ {
+		if err := ssz.EncodeToBytes(make([]byte, ssz.Size(obj)), obj); err != nil {
+			t.Errorf("unchecked encoding failed after checked one: %v", err)
+		}
+	}
+}
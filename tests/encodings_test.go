@@ -0,0 +1,919 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	bitops "math/bits"
+	"reflect"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/prysmaticlabs/go-bitfield"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+	"github.com/karalabe/ssz/tests/testtypes/external"
+)
+
+// Tests that static arrays of dynamic blobs round-trip, and that nil arrays are
+// encoded the same as arrays of empty blobs.
+func TestCheckedArrayOfDynamicBytes(t *testing.T) {
+	obj := &types.ArrayOfDynamicBytesVariation{Blobs: [][]byte{{0x01}, nil, {0x02, 0x03}}}
+
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	dec := new(types.ArrayOfDynamicBytesVariation)
+	if err := ssz.DecodeFromBytes(blob, dec); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	if len(dec.Blobs) != 3 || !bytes.Equal(dec.Blobs[0], obj.Blobs[0]) || len(dec.Blobs[1]) != 0 || !bytes.Equal(dec.Blobs[2], obj.Blobs[2]) {
+		t.Errorf("decoded array mismatch: have %x, want %x", dec.Blobs, obj.Blobs)
+	}
+	if ssz.HashSequential(dec) != ssz.HashSequential(obj) {
+		t.Errorf("decoded hash mismatch")
+	}
+	// Ensure nil arrays are padded to the expected number of empty items
+	var (
+		empty = new(types.ArrayOfDynamicBytesVariation)
+		blank = &types.ArrayOfDynamicBytesVariation{Blobs: make([][]byte, 3)}
+	)
+	enc1 := make([]byte, ssz.Size(empty))
+	if err := ssz.EncodeToBytes(enc1, empty); err != nil {
+		t.Fatalf("failed to encode nil array: %v", err)
+	}
+	enc2 := make([]byte, ssz.Size(blank))
+	if err := ssz.EncodeToBytes(enc2, blank); err != nil {
+		t.Fatalf("failed to encode empty array: %v", err)
+	}
+	if !bytes.Equal(enc1, enc2) {
+		t.Errorf("nil array encoding mismatch: have %x, want %x", enc1, enc2)
+	}
+	if ssz.HashSequential(empty) != ssz.HashSequential(blank) {
+		t.Errorf("nil array hash mismatch")
+	}
+	// Ensure that the offset table must match the array size
+	if err := ssz.DecodeFromBytes(enc1[:len(enc1)-4], dec); err == nil {
+		t.Errorf("truncated array decoded successfully")
+	}
+	// Ensure non-nil arrays of a different length are rejected, not padded or truncated
+	for _, blobs := range [][][]byte{{{0x01}, {0x02}}, {{0x01}, {0x02}, {0x03}, {0x04}}} {
+		obj := &types.ArrayOfDynamicBytesVariation{Blobs: blobs}
+
+		blob := make([]byte, ssz.Size(obj))
+		if err := ssz.EncodeToBytes(blob, obj); !errors.Is(err, ssz.ErrArrayLengthMismatch) {
+			t.Errorf("%d items: encoding error mismatch: have %v, want %v", len(blobs), err, ssz.ErrArrayLengthMismatch)
+		}
+		if err := ssz.EncodeToStream(new(bytes.Buffer), obj); !errors.Is(err, ssz.ErrArrayLengthMismatch) {
+			t.Errorf("%d items: stream encoding error mismatch: have %v, want %v", len(blobs), err, ssz.ErrArrayLengthMismatch)
+		}
+		if _, err := ssz.HashRoot(obj); !errors.Is(err, ssz.ErrArrayLengthMismatch) {
+			t.Errorf("%d items: hashing error mismatch: have %v, want %v", len(blobs), err, ssz.ErrArrayLengthMismatch)
+		}
+	}
+}
+
+// Tests that maps are encoded deterministically as sorted lists of key/value
+// containers, and that non-canonical orderings are rejected on decode.
+func TestMapEncoding(t *testing.T) {
+	obj := &types.MapsVariation{
+		Slot: 1,
+		Balances: map[types.Address]uint64{
+			{0x03}: 3, {0x01}: 1, {0x02}: 2,
+		},
+		Withdrawals: map[uint64]*types.Withdrawal{
+			9: {Index: 9}, 4: {Index: 4, Amount: 4},
+		},
+		Extras: map[uint64][]byte{
+			7: {0x07}, 5: {0x05, 0x05}, 6: nil,
+		},
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode map: %v", err)
+	}
+	// Encoding the same content must always produce the same bytes
+	for i := 0; i < 16; i++ {
+		other := make([]byte, ssz.Size(obj.Clone()))
+		if err := ssz.EncodeToBytes(other, obj.Clone()); err != nil {
+			t.Fatalf("failed to re-encode map: %v", err)
+		}
+		if !bytes.Equal(blob, other) {
+			t.Fatalf("non-deterministic map encoding")
+		}
+	}
+	// Decoding must rebuild the same map, hashing to the same root
+	dec := new(types.MapsVariation)
+	if err := ssz.DecodeFromBytes(blob, dec); err != nil {
+		t.Fatalf("failed to decode map: %v", err)
+	}
+	if !dec.EqualSSZ(obj) {
+		t.Fatalf("decoded map mismatch")
+	}
+	if ssz.HashSequential(dec) != ssz.HashSequential(obj) {
+		t.Fatalf("decoded map hash mismatch")
+	}
+	// Swap the first two (static) balance entries and ensure decoding fails
+	swapped := bytes.Clone(blob)
+	copy(swapped[20:48], blob[48:76])
+	copy(swapped[48:76], blob[20:48])
+	if err := ssz.DecodeFromBytes(swapped, new(types.MapsVariation)); !errors.Is(err, ssz.ErrUnorderedMapKeys) {
+		t.Errorf("unordered map error mismatch: have %v, want %v", err, ssz.ErrUnorderedMapKeys)
+	}
+	// Duplicate the first balance entry and ensure decoding fails
+	copy(swapped[48:76], blob[20:48])
+	if err := ssz.DecodeFromBytes(swapped, new(types.MapsVariation)); !errors.Is(err, ssz.ErrUnorderedMapKeys) {
+		t.Errorf("duplicate map error mismatch: have %v, want %v", err, ssz.ErrUnorderedMapKeys)
+	}
+}
+
+// Tests that the sorted entries of a map are only built once per operation, but
+// that modifications to the map in between operations are picked up.
+func TestMapEntriesCaching(t *testing.T) {
+	obj := &types.MapsVariation{Balances: make(map[types.Address]uint64)}
+	for i := 0; i < 1000; i++ {
+		obj.Balances[types.Address{byte(i), byte(i >> 8)}] = uint64(i)
+	}
+	blob := make([]byte, ssz.Size(obj))
+
+	// Sizing, encoding the offsets and the contents all need the entries, each
+	// of which is allocated separately, so the allocation count shows rebuilds
+	allocs := testing.AllocsPerRun(10, func() {
+		if err := ssz.EncodeToBytes(blob, obj); err != nil {
+			t.Fatalf("failed to encode map: %v", err)
+		}
+	})
+	if allocs > 1500 {
+		t.Errorf("map entries rebuilt within an operation: %v allocs", allocs)
+	}
+	// Modify the map and ensure the next operations see the new entries
+	root := ssz.HashSequential(obj)
+	obj.Balances[types.Address{0xff, 0xff}] = 1
+
+	if size := ssz.Size(obj); size != uint32(len(blob))+28 {
+		t.Errorf("modified map size mismatch: have %d, want %d", size, len(blob)+28)
+	}
+	if ssz.HashSequential(obj) == root {
+		t.Errorf("modified map hashed to stale root")
+	}
+	blob = make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode modified map: %v", err)
+	}
+	dec := new(types.MapsVariation)
+	if err := ssz.DecodeFromBytes(blob, dec); err != nil {
+		t.Fatalf("failed to decode modified map: %v", err)
+	}
+	if !dec.EqualSSZ(obj) {
+		t.Errorf("modified map roundtrip mismatch")
+	}
+}
+
+// Tests that strings are encoded and hashed exactly as byte lists, and that the
+// UTF-8 validation is only enforced on fields that opted into it.
+func TestStringFields(t *testing.T) {
+	obj := &types.StringsVariation{Name: "validator", Nonce: 7, Memo: "héllo"}
+
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode strings: %v", err)
+	}
+	ref := &types.StringBytesVariation{Name: []byte(obj.Name), Nonce: obj.Nonce, Memo: []byte(obj.Memo)}
+	want := make([]byte, ssz.Size(ref))
+	if err := ssz.EncodeToBytes(want, ref); err != nil {
+		t.Fatalf("failed to encode bytes: %v", err)
+	}
+	if !bytes.Equal(blob, want) {
+		t.Fatalf("string encoding mismatch: have %x, want %x", blob, want)
+	}
+	if ssz.HashSequential(obj) != ssz.HashSequential(ref) {
+		t.Fatalf("string hash mismatch")
+	}
+	// Decode from both buffers and streams
+	dec := new(types.StringsVariation)
+	if err := ssz.DecodeFromBytes(blob, dec); err != nil {
+		t.Fatalf("failed to decode strings: %v", err)
+	}
+	if *dec != *obj {
+		t.Fatalf("decoded strings mismatch: have %+v, want %+v", dec, obj)
+	}
+	dec = new(types.StringsVariation)
+	if err := ssz.DecodeFromStream(bytes.NewReader(blob), dec, uint32(len(blob))); err != nil {
+		t.Fatalf("failed to stream decode strings: %v", err)
+	}
+	if *dec != *obj {
+		t.Fatalf("stream decoded strings mismatch: have %+v, want %+v", dec, obj)
+	}
+	// Invalid UTF-8 is accepted in plain strings, but rejected in validated ones
+	ref = &types.StringBytesVariation{Name: []byte{0xff}, Memo: []byte("ok")}
+	blob = make([]byte, ssz.Size(ref))
+	ssz.EncodeToBytes(blob, ref)
+	if err := ssz.DecodeFromBytes(blob, new(types.StringsVariation)); err != nil {
+		t.Errorf("unvalidated string rejected: %v", err)
+	}
+	ref = &types.StringBytesVariation{Name: []byte("ok"), Memo: []byte{0xff}}
+	blob = make([]byte, ssz.Size(ref))
+	ssz.EncodeToBytes(blob, ref)
+	if err := ssz.DecodeFromBytes(blob, new(types.StringsVariation)); !errors.Is(err, ssz.ErrInvalidUTF8) {
+		t.Errorf("invalid UTF-8 error mismatch: have %v, want %v", err, ssz.ErrInvalidUTF8)
+	}
+}
+
+// Tests that arrays of uint16s and uint32s are serialized and hashed the same way
+// as their little endian packed byte representations.
+func TestPackedArrays(t *testing.T) {
+	obj := &types.PackedArraysVariation{Extra: new([16]uint32)}
+	for i := range obj.Flags {
+		obj.Flags[i] = uint16(0x0101 * (i + 1))
+	}
+	for i := range obj.Counters {
+		obj.Counters[i] = uint32(0x01010101 * (i + 1))
+	}
+	for i := range obj.Extra {
+		obj.Extra[i] = uint32(i + 1)
+	}
+	for _, fork := range []ssz.Fork{ssz.ForkCapella, ssz.ForkDeneb} {
+		blob := make([]byte, ssz.SizeOnFork(obj, fork))
+		if err := ssz.EncodeToBytesOnFork(blob, obj, fork); err != nil {
+			t.Fatalf("fork %v: failed to encode object: %v", fork, err)
+		}
+		packed := new(types.PackedArraysBytesVariation)
+		if err := ssz.DecodeFromBytesOnFork(blob, packed, fork); err != nil {
+			t.Fatalf("fork %v: failed to decode packed object: %v", fork, err)
+		}
+		if binary.LittleEndian.Uint16(packed.Flags[2:]) != obj.Flags[1] || binary.LittleEndian.Uint32(packed.Counters[4:]) != obj.Counters[1] {
+			t.Errorf("fork %v: packed encoding mismatch", fork)
+		}
+		if have, want := ssz.HashSequentialOnFork(obj, fork), ssz.HashSequentialOnFork(packed, fork); have != want {
+			t.Errorf("fork %v: hash mismatch: have %x, want %x", fork, have, want)
+		}
+		dec := new(types.PackedArraysVariation)
+		if err := ssz.DecodeFromStreamOnFork(bytes.NewReader(blob), dec, uint32(len(blob)), fork); err != nil {
+			t.Fatalf("fork %v: failed to decode object: %v", fork, err)
+		}
+		if dec.Flags != obj.Flags || dec.Counters != obj.Counters || (fork >= ssz.ForkDeneb && *dec.Extra != *obj.Extra) {
+			t.Errorf("fork %v: decoded object mismatch", fork)
+		}
+	}
+}
+
+// Tests that lists of uint8s, uint16s and uint32s are serialized and hashed with
+// their items tightly packed into chunks.
+func TestPackedLists(t *testing.T) {
+	obj := &types.PackedListsVariation{
+		Bytes:  make([]uint8, 33),
+		Shorts: make([]uint16, 17),
+		Words:  make([]uint32, 9),
+		Extras: make([]uint16, 3),
+	}
+	for i := range obj.Bytes {
+		obj.Bytes[i] = uint8(i + 1)
+	}
+	for i := range obj.Shorts {
+		obj.Shorts[i] = uint16(0x0101 * (i + 1))
+	}
+	for i := range obj.Words {
+		obj.Words[i] = uint32(0x01010101 * (i + 1))
+	}
+	for i := range obj.Extras {
+		obj.Extras[i] = uint16(i + 1)
+	}
+	// Compute the expected list roots by packing the items manually
+	var (
+		shorts = make([]byte, 2*len(obj.Shorts))
+		words  = make([]byte, 4*len(obj.Words))
+		extras = make([]byte, 2*len(obj.Extras))
+	)
+	for i, n := range obj.Shorts {
+		binary.LittleEndian.PutUint16(shorts[2*i:], n)
+	}
+	for i, n := range obj.Words {
+		binary.LittleEndian.PutUint32(words[4*i:], n)
+	}
+	for i, n := range obj.Extras {
+		binary.LittleEndian.PutUint16(extras[2*i:], n)
+	}
+	roots := [][32]byte{
+		testPackedListRoot(obj.Bytes, len(obj.Bytes), 4),
+		testPackedListRoot(shorts, len(obj.Shorts), 7),
+		testPackedListRoot(words, len(obj.Words), 13),
+		testPackedListRoot(extras, len(obj.Extras), 1),
+	}
+	for _, fork := range []ssz.Fork{ssz.ForkCapella, ssz.ForkDeneb} {
+		blob := make([]byte, ssz.SizeOnFork(obj, fork))
+		if err := ssz.EncodeToBytesOnFork(blob, obj, fork); err != nil {
+			t.Fatalf("fork %v: failed to encode object: %v", fork, err)
+		}
+		dec := new(types.PackedListsVariation)
+		if err := ssz.DecodeFromStreamOnFork(bytes.NewReader(blob), dec, uint32(len(blob)), fork); err != nil {
+			t.Fatalf("fork %v: failed to decode object: %v", fork, err)
+		}
+		offset := binary.LittleEndian.Uint32(blob)
+		if !bytes.Equal(dec.Bytes, obj.Bytes) || !bytes.Equal(blob[offset:offset+uint32(len(obj.Bytes))], obj.Bytes) {
+			t.Errorf("fork %v: decoded bytes mismatch", fork)
+		}
+		for i := range obj.Shorts {
+			if dec.Shorts[i] != obj.Shorts[i] {
+				t.Errorf("fork %v: decoded short %d mismatch: have %d, want %d", fork, i, dec.Shorts[i], obj.Shorts[i])
+			}
+		}
+		for i := range obj.Words {
+			if dec.Words[i] != obj.Words[i] {
+				t.Errorf("fork %v: decoded word %d mismatch: have %d, want %d", fork, i, dec.Words[i], obj.Words[i])
+			}
+		}
+		fields := roots
+		if fork < ssz.ForkDeneb {
+			fields = [][32]byte{roots[0], roots[1], roots[2], {}}
+		}
+		want := testHashPair(testHashPair(fields[0], fields[1]), testHashPair(fields[2], fields[3]))
+		if have := ssz.HashSequentialOnFork(dec, fork); have != want {
+			t.Errorf("fork %v: hash mismatch: have %x, want %x", fork, have, want)
+		}
+	}
+	// Ensure item sizes and limits are enforced on decode
+	blob := make([]byte, 16+3)
+	for i := 0; i < 4; i++ {
+		binary.LittleEndian.PutUint32(blob[4*i:], 16)
+	}
+	binary.LittleEndian.PutUint32(blob[8:], 16+3)
+	binary.LittleEndian.PutUint32(blob[12:], 16+3)
+	if err := ssz.DecodeFromBytesOnFork(blob, new(types.PackedListsVariation), ssz.ForkDeneb); !errors.Is(err, ssz.ErrDynamicStaticsIndivisible) {
+		t.Errorf("indivisible list error mismatch: have %v, want %v", err, ssz.ErrDynamicStaticsIndivisible)
+	}
+	obj.Extras = make([]uint16, 9)
+	blob = make([]byte, ssz.SizeOnFork(obj, ssz.ForkDeneb))
+	if err := ssz.EncodeToBytesOnFork(blob, obj, ssz.ForkDeneb); err != nil {
+		t.Fatalf("failed to encode oversized object: %v", err)
+	}
+	if err := ssz.DecodeFromBytesOnFork(blob, new(types.PackedListsVariation), ssz.ForkDeneb); !errors.Is(err, ssz.ErrMaxItemsExceeded) {
+		t.Errorf("oversized list error mismatch: have %v, want %v", err, ssz.ErrMaxItemsExceeded)
+	}
+}
+
+// testPackedListRoot computes the root of a list of packed basic items, by right
+// padding it with zero chunks up to the chunk limit and mixing in the length.
+func testPackedListRoot(packed []byte, items int, limit int) [32]byte {
+	chunks := make([][32]byte, 1<<bitops.Len(uint(limit-1)))
+	for i := 0; i < len(packed); i += 32 {
+		copy(chunks[i/32][:], packed[i:])
+	}
+	for len(chunks) > 1 {
+		for i := 0; i < len(chunks)/2; i++ {
+			chunks[i] = testHashPair(chunks[2*i], chunks[2*i+1])
+		}
+		chunks = chunks[:len(chunks)/2]
+	}
+	var length [32]byte
+	binary.LittleEndian.PutUint64(length[:], uint64(items))
+	return testHashPair(chunks[0], length)
+}
+
+// Tests that uint256 fields bounded by a maximum value reject larger values when
+// decoding, and when encoding in checked mode.
+func TestMaxValues(t *testing.T) {
+	overflow := new(uint256.Int).Lsh(uint256.NewInt(1), 128)
+
+	tests := []struct {
+		obj  *types.BoundedValuesVariation
+		fork ssz.Fork
+		err  error
+	}{
+		// Objects within their bounds
+		{&types.BoundedValuesVariation{}, ssz.ForkDeneb, nil},
+		{&types.BoundedValuesVariation{BaseFee: uint256.NewInt(1000000000000), BlobFee: uint256.NewInt(1000000)}, ssz.ForkDeneb, nil},
+		{&types.BoundedValuesVariation{Difficulty: new(uint256.Int).SubUint64(overflow, 1).ToBig()}, ssz.ForkDeneb, nil},
+		{&types.BoundedValuesVariation{BlobFee: uint256.NewInt(1000001)}, ssz.ForkCapella, nil}, // Field inactive in fork
+
+		// Objects exceeding their bounds
+		{&types.BoundedValuesVariation{BaseFee: uint256.NewInt(1000000000001)}, ssz.ForkDeneb, ssz.ErrMaxValueExceeded},
+		{&types.BoundedValuesVariation{Difficulty: overflow.ToBig()}, ssz.ForkDeneb, ssz.ErrMaxValueExceeded},
+		{&types.BoundedValuesVariation{BlobFee: uint256.NewInt(1000001)}, ssz.ForkDeneb, ssz.ErrMaxValueExceeded},
+	}
+	for i, tt := range tests {
+		blob := make([]byte, ssz.SizeOnFork(tt.obj, tt.fork))
+		if err := ssz.EncodeToBytesOnFork(blob, tt.obj, tt.fork); err != nil {
+			t.Errorf("test %d: failed to encode unchecked: %v", i, err)
+			continue
+		}
+		if err := ssz.EncodeToBytesCheckedOnFork(make([]byte, len(blob)), tt.obj, tt.fork); !errors.Is(err, tt.err) {
+			t.Errorf("test %d: checked encoding error mismatch: have %v, want %v", i, err, tt.err)
+		}
+		if err := ssz.DecodeFromBytesOnFork(blob, new(types.BoundedValuesVariation), tt.fork); !errors.Is(err, tt.err) {
+			t.Errorf("test %d: decoding error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+}
+
+// Tests that types using named types, aliases and generic instantiations from
+// other packages are encoded the same way as their underlying ssz types.
+func TestExternalTypes(t *testing.T) {
+	obj := &types.ExternalTypesVariation{
+		Hash:       external.Hash{1},
+		Root:       external.Root{2},
+		Address:    external.Address{3},
+		Local:      types.ExternalHash{4},
+		Recent:     external.Vector[external.Root]{{5}, {6}, {7}, {8}},
+		Roots:      []external.Root{{9}, {10}},
+		Checkpoint: &external.Checkpoint{Epoch: 11, Root: external.Root{12}},
+		Finalized:  &external.Checkpoint{Epoch: 13, Root: external.Root{14}},
+	}
+	for _, fork := range []ssz.Fork{ssz.ForkCapella, ssz.ForkDeneb} {
+		size := uint32(32 + 32 + 20 + 32 + 4*32 + 4 + 40 + 2*32)
+		if fork >= ssz.ForkDeneb {
+			size += 40
+		}
+		if have := ssz.SizeOnFork(obj, fork); have != size {
+			t.Errorf("fork %d: size mismatch: have %d, want %d", fork, have, size)
+		}
+		blob := make([]byte, ssz.SizeOnFork(obj, fork))
+		if err := ssz.EncodeToBytesOnFork(blob, obj, fork); err != nil {
+			t.Fatalf("fork %d: failed to encode object: %v", fork, err)
+		}
+		dec := new(types.ExternalTypesVariation)
+		if err := ssz.DecodeFromBytesOnFork(blob, dec, fork); err != nil {
+			t.Fatalf("fork %d: failed to decode object: %v", fork, err)
+		}
+		want := obj.Clone()
+		if fork < ssz.ForkDeneb {
+			want.Finalized = nil
+		}
+		if !dec.EqualSSZ(want) {
+			t.Errorf("fork %d: decoded object mismatch: have %+v, want %+v", fork, dec, want)
+		}
+	}
+}
+
+// Tests that generic containers encode, decode and hash their type parameters
+// the same way as concrete containers would.
+func TestGenericContainers(t *testing.T) {
+	header := &types.BeaconBlockHeader{Slot: 1, ProposerIndex: 2, BodyRoot: types.Hash{3}}
+
+	obj := &types.SignedGenericVariation[*types.BeaconBlockHeader]{Message: header, Signature: [96]byte{4}}
+	ref := &types.SignedBeaconBlockHeader{Header: header, Signature: [96]byte{4}}
+
+	if have, want := ssz.Size(obj), ssz.Size(ref); have != want {
+		t.Fatalf("size mismatch: have %d, want %d", have, want)
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	want := make([]byte, ssz.Size(ref))
+	if err := ssz.EncodeToBytes(want, ref); err != nil {
+		t.Fatalf("failed to encode reference: %v", err)
+	}
+	if !bytes.Equal(blob, want) {
+		t.Errorf("encoding mismatch: have %x, want %x", blob, want)
+	}
+	if have, want := ssz.HashSequential(obj), ssz.HashSequential(ref); have != want {
+		t.Errorf("root mismatch: have %x, want %x", have, want)
+	}
+	dec := new(types.SignedGenericVariation[*types.BeaconBlockHeader])
+	if err := ssz.DecodeFromBytes(blob, dec); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	if *dec.Message != *header || dec.Signature != obj.Signature {
+		t.Errorf("decoded object mismatch: have %+v, want %+v", dec, obj)
+	}
+	// Ensure nil type parameters are handled as zero values
+	empty := new(types.SignedGenericVariation[*types.BeaconBlockHeader])
+	if have, want := ssz.HashSequential(empty), ssz.HashSequential(new(types.SignedBeaconBlockHeader)); have != want {
+		t.Errorf("empty root mismatch: have %x, want %x", have, want)
+	}
+	// Ensure dynamic type parameters are supported, including across forks
+	envelope := &types.EnvelopeGenericVariation[*types.IndexedAttestation]{
+		Slot:    5,
+		Payload: &types.IndexedAttestation{AttestationIndices: []uint64{6, 7}, Data: new(types.AttestationData)},
+		Blob:    []byte{8},
+		Extra:   &types.IndexedAttestation{AttestationIndices: []uint64{9}, Data: new(types.AttestationData)},
+	}
+	for _, fork := range []ssz.Fork{ssz.ForkCapella, ssz.ForkDeneb} {
+		blob := make([]byte, ssz.SizeOnFork(envelope, fork))
+		if err := ssz.EncodeToBytesOnFork(blob, envelope, fork); err != nil {
+			t.Fatalf("fork %d: failed to encode envelope: %v", fork, err)
+		}
+		dec := new(types.EnvelopeGenericVariation[*types.IndexedAttestation])
+		if err := ssz.DecodeFromBytesOnFork(blob, dec, fork); err != nil {
+			t.Fatalf("fork %d: failed to decode envelope: %v", fork, err)
+		}
+		if have, want := ssz.HashSequentialOnFork(dec, fork), ssz.HashSequentialOnFork(envelope, fork); have != want {
+			t.Errorf("fork %d: root mismatch: have %x, want %x", fork, have, want)
+		}
+		if (dec.Extra != nil) != (fork >= ssz.ForkDeneb) {
+			t.Errorf("fork %d: extra presence mismatch: have %v", fork, dec.Extra)
+		}
+	}
+}
+
+// Tests that multi-dimensional arrays of unsigned integers are packed and
+// merkleized per inner array, and that they round-trip through the codec.
+func TestMultiDimArrays(t *testing.T) {
+	obj := new(types.MultiDimArraysVariation)
+	for i := range obj.Custody {
+		for j := range obj.Custody[i] {
+			obj.Custody[i][j] = uint64(i<<32 | j)
+		}
+	}
+	for i := range obj.Flags {
+		for j := range obj.Flags[i] {
+			obj.Flags[i][j] = uint16(i<<8 | j)
+		}
+	}
+	for i := range obj.Counts {
+		for j := range obj.Counts[i] {
+			obj.Counts[i][j] = uint32(i<<16 | j)
+		}
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	if have, want := binary.LittleEndian.Uint64(blob[(8192+3)*8:]), obj.Custody[1][3]; have != want {
+		t.Errorf("custody encoding mismatch: have %x, want %x", have, want)
+	}
+	dec := new(types.MultiDimArraysVariation)
+	if err := ssz.DecodeFromStream(bytes.NewReader(blob), dec, uint32(len(blob))); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	if *dec != *obj {
+		t.Errorf("decoded object mismatch")
+	}
+	// Merkleize the packed inner arrays separately and combine them into the root
+	merkleize := func(roots [][32]byte) [32]byte {
+		for len(roots)&(len(roots)-1) != 0 {
+			roots = append(roots, [32]byte{})
+		}
+		for len(roots) > 1 {
+			for i := 0; i < len(roots)/2; i++ {
+				roots[i] = sha256.Sum256(append(roots[2*i][:], roots[2*i+1][:]...))
+			}
+			roots = roots[:len(roots)/2]
+		}
+		return roots[0]
+	}
+	inner := func(data []byte, size int) [][32]byte {
+		var roots [][32]byte
+		for ; len(data) > 0; data = data[size:] {
+			var chunks [][32]byte
+			for i := 0; i < size; i += 32 {
+				chunks = append(chunks, [32]byte(data[i:i+32]))
+			}
+			roots = append(roots, merkleize(chunks))
+		}
+		return roots
+	}
+	var (
+		custody = inner(blob[:4*8192*8], 8192*8)
+		flags   = inner(blob[4*8192*8:4*8192*8+2*16*2], 16*2)
+		counts  = inner(blob[4*8192*8+2*16*2:], 64*4)
+	)
+	want := merkleize([][32]byte{merkleize(custody), merkleize(flags), merkleize(counts)})
+	if have := ssz.HashSequential(obj); have != want {
+		t.Errorf("root mismatch: have %x, want %x", have, want)
+	}
+	// Ensure schema based hashing agrees with the generated code
+	schema := &ssz.Schema{Name: "MultiDimArrays", Fields: []*ssz.SchemaField{
+		{Name: "Custody", Encoding: "ArrayOfArrayOfUint64s", Size: 4 * 8192 * 8, Sizes: []int{4, 8192, 8}},
+		{Name: "Flags", Encoding: "ArrayOfArrayOfUint16s", Size: 2 * 16 * 2, Sizes: []int{2, 16, 2}},
+		{Name: "Counts", Encoding: "ArrayOfArrayOfUint32s", Size: 3 * 64 * 4, Sizes: []int{3, 64, 4}},
+	}}
+	root, err := ssz.HashRootOfSchema(blob, schema)
+	if err != nil {
+		t.Fatalf("failed to hash blob: %v", err)
+	}
+	if root != want {
+		t.Errorf("schema root mismatch: have %x, want %x", root, want)
+	}
+}
+
+// Tests that list limits declared via named constants are enforced the same way
+// as literal ones, including any fork specific overrides.
+func TestNamedLimits(t *testing.T) {
+	obj := &types.NamedLimitsVariation{
+		ExtraData:    []byte{0x01, 0x02},
+		Transactions: [][]byte{{0x03}, {0x04, 0x05}},
+		Withdrawals:  make([]*types.Withdrawal, types.MaxNamedWithdrawals+1),
+		Balances:     map[uint64][]byte{1: {0x06}, 2: {0x07, 0x08}},
+	}
+	for i := range obj.Withdrawals {
+		obj.Withdrawals[i] = &types.Withdrawal{Index: uint64(i)}
+	}
+	blob := make([]byte, ssz.SizeOnFork(obj, ssz.ForkDeneb))
+	if err := ssz.EncodeToBytesOnFork(blob, obj, ssz.ForkDeneb); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	if err := ssz.DecodeFromBytesOnFork(blob, new(types.NamedLimitsVariation), ssz.ForkDeneb); err != nil {
+		t.Errorf("failed to decode object with overridden limit: %v", err)
+	}
+	if err := ssz.DecodeFromBytesOnFork(blob, new(types.NamedLimitsVariation), ssz.ForkCapella); !errors.Is(err, ssz.ErrMaxItemsExceeded) {
+		t.Errorf("named limit error mismatch: have %v, want %v", err, ssz.ErrMaxItemsExceeded)
+	}
+	// Check that the named limits of synthesized map entries are also enforced
+	obj.Withdrawals = obj.Withdrawals[:types.MaxNamedWithdrawals]
+	obj.Balances[3] = make([]byte, types.MaxNamedBalanceBytes+1)
+
+	blob = make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	if err := ssz.DecodeFromBytes(blob, new(types.NamedLimitsVariation)); !errors.Is(err, ssz.ErrMaxLengthExceeded) {
+		t.Errorf("named map limit error mismatch: have %v, want %v", err, ssz.ErrMaxLengthExceeded)
+	}
+}
+
+// Tests that slices of static objects stored by value encode and hash the same
+// as their pointer counterparts.
+func TestSliceOfStaticObjectValues(t *testing.T) {
+	values := &types.ValueObjectsVariation{
+		Slot:        1,
+		Withdrawals: []types.Withdrawal{{Index: 2, Amount: 3}, {Index: 4, Amount: 5}},
+	}
+	pointers := &types.PointerObjectsVariation{
+		Slot:        1,
+		Withdrawals: []*types.Withdrawal{{Index: 2, Amount: 3}, {Index: 4, Amount: 5}},
+	}
+	if have, want := ssz.Size(values), ssz.Size(pointers); have != want {
+		t.Fatalf("size mismatch: have %d, want %d", have, want)
+	}
+	blob := make([]byte, ssz.Size(values))
+	if err := ssz.EncodeToBytes(blob, values); err != nil {
+		t.Fatalf("failed to encode values: %v", err)
+	}
+	want := make([]byte, ssz.Size(pointers))
+	if err := ssz.EncodeToBytes(want, pointers); err != nil {
+		t.Fatalf("failed to encode pointers: %v", err)
+	}
+	if !bytes.Equal(blob, want) {
+		t.Fatalf("encoding mismatch: have %x, want %x", blob, want)
+	}
+	if have, want := ssz.HashSequential(values), ssz.HashSequential(pointers); have != want {
+		t.Errorf("sequential hash mismatch: have %x, want %x", have, want)
+	}
+	if have, want := ssz.HashConcurrent(values), ssz.HashConcurrent(pointers); have != want {
+		t.Errorf("concurrent hash mismatch: have %x, want %x", have, want)
+	}
+	decoded := new(types.ValueObjectsVariation)
+	if err := ssz.DecodeFromBytes(blob, decoded); err != nil {
+		t.Fatalf("failed to decode values: %v", err)
+	}
+	if !decoded.EqualSSZ(values) {
+		t.Errorf("decoded values mismatch: have %+v, want %+v", decoded, values)
+	}
+	if clone := values.Clone(); !clone.EqualSSZ(values) || &clone.Withdrawals[0] == &values.Withdrawals[0] {
+		t.Errorf("cloned values mismatch")
+	}
+	// Exceeding the item limit should be rejected both ways
+	values.Withdrawals = make([]types.Withdrawal, 17)
+	blob = make([]byte, ssz.Size(values))
+	if err := ssz.EncodeToBytes(blob, values); err != nil {
+		t.Fatalf("failed to encode oversized values: %v", err)
+	}
+	if err := ssz.DecodeFromBytes(blob, new(types.ValueObjectsVariation)); !errors.Is(err, ssz.ErrMaxItemsExceeded) {
+		t.Errorf("oversized decode error mismatch: have %v, want %v", err, ssz.ErrMaxItemsExceeded)
+	}
+}
+
+// Tests that vectors of bitvectors round-trip through the codec, that each item
+// is merkleized as a separate bitvector, and that junk bits are rejected.
+func TestArrayOfBitvectors(t *testing.T) {
+	obj := &types.BitvectorsVariation{Committees: make([][4]byte, 8)}
+	for i := range obj.Participation {
+		for j := range obj.Participation[i] {
+			obj.Participation[i][j] = byte(i*64 + j)
+		}
+	}
+	for i := range obj.Committees {
+		obj.Committees[i] = [4]byte{byte(i), byte(i + 1), byte(i + 2), 0x3f} // 30 bits
+	}
+	for i := range obj.Aggregates {
+		obj.Aggregates[i] = bitfield.NewBitvector128()
+		obj.Aggregates[i].SetBitAt(uint64(i*7), true)
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	dec := new(types.BitvectorsVariation)
+	if err := ssz.DecodeFromStream(bytes.NewReader(blob), dec, uint32(len(blob))); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	if !reflect.DeepEqual(dec, obj) {
+		t.Errorf("decoded object mismatch: have %+v, want %+v", dec, obj)
+	}
+	// Merkleize the bitvectors separately and combine them into the root
+	merkleize := func(roots [][32]byte) [32]byte {
+		for len(roots)&(len(roots)-1) != 0 {
+			roots = append(roots, [32]byte{})
+		}
+		for len(roots) > 1 {
+			for i := 0; i < len(roots)/2; i++ {
+				roots[i] = sha256.Sum256(append(roots[2*i][:], roots[2*i+1][:]...))
+			}
+			roots = roots[:len(roots)/2]
+		}
+		return roots[0]
+	}
+	var participation, committees, aggregates [][32]byte
+	for i := range obj.Participation {
+		var chunks [2][32]byte
+		copy(chunks[0][:], obj.Participation[i][:32])
+		copy(chunks[1][:], obj.Participation[i][32:])
+		participation = append(participation, merkleize(chunks[:]))
+	}
+	for i := range obj.Committees {
+		var chunk [32]byte
+		copy(chunk[:], obj.Committees[i][:])
+		committees = append(committees, chunk)
+	}
+	for i := range obj.Aggregates {
+		var chunk [32]byte
+		copy(chunk[:], obj.Aggregates[i])
+		aggregates = append(aggregates, chunk)
+	}
+	want := merkleize([][32]byte{merkleize(participation), merkleize(committees), merkleize(aggregates)})
+	if have := ssz.HashSequential(obj); have != want {
+		t.Errorf("root mismatch: have %x, want %x", have, want)
+	}
+	if have, err := ssz.HashRootFromBytes(blob, new(types.BitvectorsVariation), ssz.ForkUnknown); err != nil || have != want {
+		t.Errorf("serialized root mismatch: have %x (%v), want %x", have, err, want)
+	}
+	// Ensure that nil items are handled as zero bitvectors
+	zero := make([]byte, ssz.Size(new(types.BitvectorsVariation)))
+	if err := ssz.EncodeToBytes(zero, new(types.BitvectorsVariation)); err != nil {
+		t.Fatalf("failed to encode zero object: %v", err)
+	}
+	if !bytes.Equal(zero, make([]byte, len(zero))) {
+		t.Errorf("zero object encoding not all zeroes: %x", zero)
+	}
+	dec = new(types.BitvectorsVariation)
+	if err := ssz.DecodeFromBytes(zero, dec); err != nil {
+		t.Fatalf("failed to decode zero object: %v", err)
+	}
+	if have, want := ssz.HashSequential(new(types.BitvectorsVariation)), ssz.HashSequential(dec); have != want {
+		t.Errorf("zero root mismatch: have %x, want %x", have, want)
+	}
+	// Ensure that junk in the unused bits of the inner bitvectors is rejected
+	blob[len(obj.Participation)*64+3] |= 0x40 // 31st bit of the first committee
+	if err := ssz.DecodeFromBytes(blob, new(types.BitvectorsVariation)); !errors.Is(err, ssz.ErrJunkInBitvector) {
+		t.Errorf("junk bits error mismatch: have %v, want %v", err, ssz.ErrJunkInBitvector)
+	}
+}
+
+// Tests that lists tagged as sorted are validated to be strictly increasing on
+// decode, while their encoding and hashing is unaffected.
+func TestSortedLists(t *testing.T) {
+	encode := func(obj *types.SortedListsVariation) []byte {
+		blob := make([]byte, ssz.Size(obj))
+		if err := ssz.EncodeToBytes(blob, obj); err != nil {
+			t.Fatalf("failed to encode object: %v", err)
+		}
+		return blob
+	}
+	// Sorted lists should round trip, unsorted untagged lists too
+	obj := &types.SortedListsVariation{AttestingIndices: []uint64{1, 5, 9}, Balances: []uint64{3, 2, 2}}
+	blob := encode(obj)
+
+	dec := new(types.SortedListsVariation)
+	if err := ssz.DecodeFromBytes(blob, dec); err != nil {
+		t.Fatalf("failed to decode sorted lists: %v", err)
+	}
+	if !reflect.DeepEqual(dec, obj) {
+		t.Errorf("decoded lists mismatch: have %+v, want %+v", dec, obj)
+	}
+	// Unsorted and duplicate items should be rejected from both buffers and streams
+	for _, indices := range [][]uint64{{5, 1}, {1, 1}} {
+		blob := encode(&types.SortedListsVariation{AttestingIndices: indices})
+		if err := ssz.DecodeFromBytes(blob, new(types.SortedListsVariation)); !errors.Is(err, ssz.ErrUnsortedItems) {
+			t.Errorf("indices %v: error mismatch: have %v, want %v", indices, err, ssz.ErrUnsortedItems)
+		}
+		if err := ssz.DecodeFromStream(bytes.NewReader(blob), new(types.SortedListsVariation), uint32(len(blob))); !errors.Is(err, ssz.ErrUnsortedItems) {
+			t.Errorf("indices %v: stream error mismatch: have %v, want %v", indices, err, ssz.ErrUnsortedItems)
+		}
+	}
+}
+
+// Tests that static arrays of uint64s backed by plain slices encode and hash the
+// same way as their array backed equivalents, with nil slices standing in for
+// zero arrays.
+func TestCheckedStaticUint64s(t *testing.T) {
+	obj := &types.StaticUint64sVariation{
+		Slot:      1,
+		Slashings: make([]uint64, types.EpochsPerSlashingsVector),
+		Weights:   []uint64{2, 3, 4, 5, 6},
+	}
+	for i := range obj.Slashings {
+		obj.Slashings[i] = uint64(i)
+	}
+	ref := &types.StaticUint64sBytesVariation{Slot: 1, Weights: make([]byte, 40)}
+	copy(ref.Slashings[:], obj.Slashings)
+	for i, w := range obj.Weights {
+		binary.LittleEndian.PutUint64(ref.Weights[8*i:], w)
+	}
+
+	for _, test := range []struct {
+		obj *types.StaticUint64sVariation
+		ref *types.StaticUint64sBytesVariation
+	}{
+		{obj, ref},
+		{new(types.StaticUint64sVariation), &types.StaticUint64sBytesVariation{Weights: make([]byte, 40)}},
+	} {
+		blob := make([]byte, ssz.Size(test.obj))
+		if err := ssz.EncodeToBytes(blob, test.obj); err != nil {
+			t.Fatalf("failed to encode object: %v", err)
+		}
+		want := make([]byte, ssz.Size(test.ref))
+		if err := ssz.EncodeToBytes(want, test.ref); err != nil {
+			t.Fatalf("failed to encode reference: %v", err)
+		}
+		if !bytes.Equal(blob, want) {
+			t.Errorf("encoding mismatch")
+		}
+		if have, want := ssz.HashSequential(test.obj), ssz.HashSequential(test.ref); have != want {
+			t.Errorf("hash mismatch: have %x, want %x", have, want)
+		}
+		dec := new(types.StaticUint64sVariation)
+		if err := ssz.DecodeFromStream(bytes.NewReader(blob), dec, uint32(len(blob))); err != nil {
+			t.Fatalf("failed to decode object: %v", err)
+		}
+		if len(dec.Slashings) != types.EpochsPerSlashingsVector || len(dec.Weights) != 5 {
+			t.Fatalf("decoded sizes mismatch: have %d/%d, want %d/%d", len(dec.Slashings), len(dec.Weights), types.EpochsPerSlashingsVector, 5)
+		}
+		if !reflect.DeepEqual(dec.Slashings, test.ref.Slashings[:]) {
+			t.Errorf("decoded slashings mismatch")
+		}
+	}
+	// Truncated inputs must be rejected
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	if err := ssz.DecodeFromBytes(blob[:len(blob)-8], new(types.StaticUint64sVariation)); err == nil {
+		t.Errorf("truncated input accepted")
+	}
+}
+
+// Tests that go-bitfield bitvectors without ssz tags encode and hash the same way
+// as their raw byte array equivalents, and that junk bits are rejected.
+func TestBitfieldVectors(t *testing.T) {
+	obj := &types.BitfieldVectorsVariation{
+		Flags:      bitfield.Bitvector4{0x05},
+		Justified:  bitfield.NewBitvector64(),
+		Attesters:  bitfield.NewBitvector512(),
+		Aggregates: [2]bitfield.Bitvector128{bitfield.NewBitvector128(), bitfield.NewBitvector128()},
+	}
+	obj.Justified.SetBitAt(63, true)
+	obj.Attesters.SetBitAt(300, true)
+	obj.Aggregates[1].SetBitAt(7, true)
+
+	ref := &types.BitfieldVectorsBytesVariation{Flags: [1]byte{0x05}}
+	copy(ref.Justified[:], obj.Justified)
+	copy(ref.Attesters[:], obj.Attesters)
+	copy(ref.Aggregates[0][:], obj.Aggregates[0])
+	copy(ref.Aggregates[1][:], obj.Aggregates[1])
+
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	want := make([]byte, ssz.Size(ref))
+	if err := ssz.EncodeToBytes(want, ref); err != nil {
+		t.Fatalf("failed to encode reference: %v", err)
+	}
+	if !bytes.Equal(blob, want) {
+		t.Errorf("encoding mismatch: have %x, want %x", blob, want)
+	}
+	if have, want := ssz.HashSequential(obj), ssz.HashSequential(ref); have != want {
+		t.Errorf("hash mismatch: have %x, want %x", have, want)
+	}
+	dec := new(types.BitfieldVectorsVariation)
+	if err := ssz.DecodeFromBytes(blob, dec); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	if !reflect.DeepEqual(dec, obj) {
+		t.Errorf("decoded object mismatch: have %v, want %v", dec, obj)
+	}
+	// Bits set past the size of a bitvector must be rejected
+	blob[0] |= 0x10
+	if err := ssz.DecodeFromBytes(blob, new(types.BitfieldVectorsVariation)); !errors.Is(err, ssz.ErrJunkInBitvector) {
+		t.Errorf("junk bits error mismatch: have %v, want %v", err, ssz.ErrJunkInBitvector)
+	}
+	// Nil bitvectors must stand in for all zero ones
+	zero := make([]byte, ssz.Size(new(types.BitfieldVectorsVariation)))
+	if err := ssz.EncodeToBytes(zero, new(types.BitfieldVectorsVariation)); err != nil {
+		t.Fatalf("failed to encode zero object: %v", err)
+	}
+	if !bytes.Equal(zero, make([]byte, len(zero))) {
+		t.Errorf("zero encoding mismatch: have %x", zero)
+	}
+	if have, want := ssz.HashSequential(new(types.BitfieldVectorsVariation)), ssz.HashSequential(new(types.BitfieldVectorsBytesVariation)); have != want {
+		t.Errorf("zero hash mismatch: have %x, want %x", have, want)
+	}
+}
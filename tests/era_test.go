@@ -0,0 +1,140 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	"github.com/karalabe/ssz/era"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that blocks and states written into an era file can be located and read
+// back via the slot indices, including empty slots.
+func TestEraRoundTrip(t *testing.T) {
+	var (
+		buf    = new(bytes.Buffer)
+		start  = uint64(128)
+		blocks = map[uint64]*types.BeaconBlock{
+			128: {Slot: 128, ProposerIndex: 1, Body: &types.BeaconBlockBody{Graffiti: [32]byte{1}}},
+			130: {Slot: 130, ProposerIndex: 2, Body: &types.BeaconBlockBody{Graffiti: [32]byte{2}}},
+			135: {Slot: 135, ProposerIndex: 3, Body: &types.BeaconBlockBody{Graffiti: [32]byte{3}}},
+		}
+		state = &types.BeaconState{Slot: 136, GenesisTime: 7}
+	)
+	writer, err := era.NewWriter(buf, start)
+	if err != nil {
+		t.Fatalf("failed to create era writer: %v", err)
+	}
+	for _, slot := range []uint64{128, 130, 135} {
+		if err := writer.WriteBlock(slot, blocks[slot], ssz.ForkPhase0); err != nil {
+			t.Fatalf("failed to write block %d: %v", slot, err)
+		}
+	}
+	if err := writer.WriteBlock(131, blocks[130], ssz.ForkPhase0); err == nil {
+		t.Errorf("out of order block accepted")
+	}
+	if err := writer.WriteState(136, state, ssz.ForkPhase0); err != nil {
+		t.Fatalf("failed to write state: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close era writer: %v", err)
+	}
+	// Read everything back and check that it matches
+	reader, err := era.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("failed to open era reader: %v", err)
+	}
+	if have, want := reader.StartSlot(), start; have != want {
+		t.Errorf("start slot mismatch: have %d, want %d", have, want)
+	}
+	if have, want := reader.StateSlot(), uint64(136); have != want {
+		t.Errorf("state slot mismatch: have %d, want %d", have, want)
+	}
+	for slot := start; slot < 136; slot++ {
+		block := new(types.BeaconBlock)
+		err := reader.ReadBlock(slot, block, ssz.ForkPhase0)
+		if want, ok := blocks[slot]; ok {
+			if err != nil {
+				t.Errorf("failed to read block %d: %v", slot, err)
+			} else if ssz.HashSequential(block) != ssz.HashSequential(want) {
+				t.Errorf("block %d mismatch", slot)
+			}
+		} else if !errors.Is(err, era.ErrEmptySlot) {
+			t.Errorf("slot %d: empty slot error mismatch: have %v, want %v", slot, err, era.ErrEmptySlot)
+		}
+	}
+	if err := reader.ReadBlock(136, new(types.BeaconBlock), ssz.ForkPhase0); !errors.Is(err, era.ErrSlotOutOfRange) {
+		t.Errorf("out of range error mismatch: have %v, want %v", err, era.ErrSlotOutOfRange)
+	}
+	have := new(types.BeaconState)
+	if err := reader.ReadState(have, ssz.ForkPhase0); err != nil {
+		t.Fatalf("failed to read state: %v", err)
+	}
+	if ssz.HashSequential(have) != ssz.HashSequential(state) {
+		t.Errorf("state mismatch")
+	}
+	// Corrupting the file should be detected
+	if _, err := era.NewReader(bytes.NewReader(buf.Bytes()[8:]), int64(buf.Len()-8)); !errors.Is(err, era.ErrInvalidFormat) {
+		t.Errorf("missing version error mismatch: have %v, want %v", err, era.ErrInvalidFormat)
+	}
+}
+
+// Tests that eras without any blocks (e.g. genesis) only contain a state index.
+func TestEraGenesis(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	writer, err := era.NewWriter(buf, 0)
+	if err != nil {
+		t.Fatalf("failed to create era writer: %v", err)
+	}
+	if err := writer.WriteState(0, &types.BeaconState{GenesisTime: 7}, ssz.ForkPhase0); err != nil {
+		t.Fatalf("failed to write state: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close era writer: %v", err)
+	}
+	reader, err := era.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("failed to open era reader: %v", err)
+	}
+	if err := reader.ReadBlock(0, new(types.BeaconBlock), ssz.ForkPhase0); !errors.Is(err, era.ErrSlotOutOfRange) {
+		t.Errorf("out of range error mismatch: have %v, want %v", err, era.ErrSlotOutOfRange)
+	}
+	state := new(types.BeaconState)
+	if err := reader.ReadState(state, ssz.ForkPhase0); err != nil {
+		t.Fatalf("failed to read state: %v", err)
+	}
+	if state.GenesisTime != 7 {
+		t.Errorf("genesis time mismatch: have %d, want %d", state.GenesisTime, 7)
+	}
+	// Read failures while looking for the block index should not be mistaken
+	// for a genesis era, only a missing index should
+	failure := errors.New("read failure")
+	faulty := &faultyReaderAt{r: bytes.NewReader(buf.Bytes()), from: 1, to: int64(buf.Len()) - 32, err: failure}
+	if _, err := era.NewReader(faulty, int64(buf.Len())); !errors.Is(err, failure) {
+		t.Errorf("read failure mismatch: have %v, want %v", err, failure)
+	}
+}
+
+// faultyReaderAt is an io.ReaderAt failing all reads that start within a given
+// range of offsets.
+type faultyReaderAt struct {
+	r    io.ReaderAt
+	from int64 // First offset to fail reads at
+	to   int64 // First offset to succeed reads at again
+	err  error // Error to fail the reads with
+}
+
+func (r *faultyReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off >= r.from && off < r.to {
+		return 0, r.err
+	}
+	return r.r.ReadAt(p, off)
+}
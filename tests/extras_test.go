@@ -0,0 +1,233 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/holiman/uint256"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+	"github.com/karalabe/ssz/tests/testtypes/external"
+)
+
+// Tests that generated clones are deep copies and that the generated equality
+// checks treat nil and zero values the same.
+func TestCloneEqual(t *testing.T) {
+	blobGas := uint64(1)
+	obj := &types.ExecutionPayloadMonolith{
+		ExtraData:     []byte{0x01},
+		BaseFeePerGas: uint256.NewInt(7),
+		Transactions:  [][]byte{{0x02}, {0x03}},
+		Withdrawals:   []*types.Withdrawal{{Index: 1}, nil},
+		BlobGasUsed:   &blobGas,
+	}
+	clone := obj.Clone()
+	if !clone.EqualSSZ(obj) {
+		t.Fatalf("clone mismatch")
+	}
+	clone.ExtraData[0] = 0xff
+	clone.BaseFeePerGas.SetUint64(8)
+	clone.Transactions[1][0] = 0xff
+	clone.Withdrawals[0].Index = 2
+	*clone.BlobGasUsed = 2
+
+	if obj.ExtraData[0] != 0x01 || obj.BaseFeePerGas.Uint64() != 7 || obj.Transactions[1][0] != 0x03 || obj.Withdrawals[0].Index != 1 || blobGas != 1 {
+		t.Fatalf("clone aliases the original")
+	}
+	if clone.EqualSSZ(obj) {
+		t.Fatalf("modified clone still equal")
+	}
+	// Nil and zero values should be equal as they encode the same way
+	zero := &types.ExecutionPayloadMonolith{
+		ExtraData:     []byte{},
+		BaseFeePerGas: new(uint256.Int),
+		Withdrawals:   []*types.Withdrawal{},
+		BlobGasUsed:   new(uint64),
+	}
+	if !zero.EqualSSZ(new(types.ExecutionPayloadMonolith)) || !zero.EqualSSZ(nil) {
+		t.Errorf("zero value mismatch")
+	}
+}
+
+// Tests that generated resets clear all the ssz fields while retaining the allocated
+// memory, and that reset objects can be reused for decoding across forks.
+func TestResetSSZ(t *testing.T) {
+	blobGas := uint64(1)
+	obj := &types.ExecutionPayloadMonolith{
+		BlockNumber:   1,
+		ExtraData:     []byte{0x01},
+		BaseFeePerGas: uint256.NewInt(7),
+		Transactions:  [][]byte{{0x02}, {0x03}},
+		Withdrawals:   []*types.Withdrawal{{Index: 1}, {Index: 2}},
+		BlobGasUsed:   &blobGas,
+		ExcessBlobGas: new(uint64),
+	}
+	blob := make([]byte, ssz.SizeOnFork(obj, ssz.ForkCancun))
+	if err := ssz.EncodeToBytesOnFork(blob, obj, ssz.ForkCancun); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	pooled := new(types.ExecutionPayloadMonolith)
+	if err := ssz.DecodeFromBytesOnFork(blob, pooled, ssz.ForkCancun); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	withdrawal := pooled.Withdrawals[1]
+
+	pooled.ResetSSZ()
+	if !pooled.EqualSSZ(new(types.ExecutionPayloadMonolith)) {
+		t.Fatalf("reset object not zero")
+	}
+	if cap(pooled.Transactions) < 2 || cap(pooled.Withdrawals) < 2 {
+		t.Errorf("reset dropped slice capacity: transactions %d, withdrawals %d", cap(pooled.Transactions), cap(pooled.Withdrawals))
+	}
+	if pooled.Withdrawals[:2][1] != withdrawal || withdrawal.Index != 0 {
+		t.Errorf("reset did not clear retained withdrawal in place")
+	}
+	if pooled.BlobGasUsed != nil || pooled.ExcessBlobGas != nil {
+		t.Errorf("reset retained fork specific pointers: %v, %v", pooled.BlobGasUsed, pooled.ExcessBlobGas)
+	}
+	if pooled.BaseFeePerGas == nil {
+		t.Errorf("reset dropped pointer present in all forks")
+	}
+	// Decode an older fork into the reset object, which must match a fresh decode
+	old := &types.ExecutionPayloadMonolith{BlockNumber: 2, ExtraData: []byte{0x04}, BaseFeePerGas: uint256.NewInt(9)}
+	blob = make([]byte, ssz.SizeOnFork(old, ssz.ForkLondon))
+	if err := ssz.EncodeToBytesOnFork(blob, old, ssz.ForkLondon); err != nil {
+		t.Fatalf("failed to encode old object: %v", err)
+	}
+	pooled.ResetSSZ()
+	if err := ssz.DecodeFromBytesOnFork(blob, pooled, ssz.ForkLondon); err != nil {
+		t.Fatalf("failed to decode old object: %v", err)
+	}
+	if !pooled.EqualSSZ(old) {
+		t.Errorf("reused decode mismatch: have %+v, want %+v", pooled, old)
+	}
+	// Maps are emptied, but retained
+	maps := &types.MapsVariation{Slot: 1, Balances: map[types.Address]uint64{{0x01}: 1}}
+	maps.ResetSSZ()
+	if maps.Slot != 0 || maps.Balances == nil || len(maps.Balances) != 0 {
+		t.Errorf("reset map mismatch: %+v", maps)
+	}
+	// Resetting a nil object is a noop
+	(*types.ExecutionPayloadMonolith)(nil).ResetSSZ()
+}
+
+// Tests that the generated getters return the fields of an object and are safe
+// to call on nil objects, matching the protobuf conventions.
+func TestGetters(t *testing.T) {
+	var (
+		nilExt *types.ExternalTypesVariation
+		nilEnv *types.EnvelopeGenericVariation[*types.ExecutionPayload]
+	)
+	if nilExt.GetHash() != (external.Hash{}) || nilExt.GetRoots() != nil || nilExt.GetCheckpoint() != nil {
+		t.Errorf("nil external object getters returned non-zero values")
+	}
+	if nilEnv.GetSlot() != 0 || nilEnv.GetPayload() != nil || nilEnv.GetBlob() != nil {
+		t.Errorf("nil generic object getters returned non-zero values")
+	}
+	ext := &types.ExternalTypesVariation{
+		Hash:       external.Hash{1},
+		Roots:      []external.Root{{2}},
+		Checkpoint: &external.Checkpoint{Epoch: 3},
+	}
+	if ext.GetHash() != ext.Hash || !reflect.DeepEqual(ext.GetRoots(), ext.Roots) || ext.GetCheckpoint() != ext.Checkpoint {
+		t.Errorf("external object getters mismatch")
+	}
+	env := &types.EnvelopeGenericVariation[*types.ExecutionPayload]{Slot: 4, Payload: new(types.ExecutionPayload)}
+	if env.GetSlot() != env.Slot || env.GetPayload() != env.Payload {
+		t.Errorf("generic object getters mismatch")
+	}
+	// Ensure the getters can back an interface, which is their primary use case
+	var _ interface{ GetSlot() uint64 } = nilEnv
+}
+
+// Tests that objects held by value can be encoded, both if generated with the
+// default pointer receivers and if generated with value receivers, and that the
+// latter refuse being decoded into.
+func TestEncodeValue(t *testing.T) {
+	withdrawal := types.WithdrawalVariation{Index: 1, Validator: 2, Address: bytes.Repeat([]byte{3}, 20), Amount: 4}
+
+	blob := make([]byte, ssz.Size(&withdrawal))
+	if err := ssz.EncodeToBytes(blob, &withdrawal); err != nil {
+		t.Fatalf("failed to encode withdrawal: %v", err)
+	}
+	// Values of pointer receiver types should be encoded via a copy
+	var cached any = withdrawal
+	have, err := ssz.EncodeValue(cached)
+	if err != nil {
+		t.Fatalf("failed to encode withdrawal value: %v", err)
+	}
+	if !bytes.Equal(have, blob) {
+		t.Errorf("withdrawal encoding mismatch: have %x, want %x", have, blob)
+	}
+	if _, err := ssz.EncodeValue(struct{}{}); err == nil {
+		t.Errorf("non-ssz value encoded")
+	}
+	if _, err := ssz.EncodeValue(nil); err == nil {
+		t.Errorf("nil value encoded")
+	}
+	// Values of value receiver types should be usable directly
+	obj := types.ReadOnlyVariation{Slot: 5, Withdrawal: &withdrawal, Extra: []byte{6, 7}}
+
+	have, err = ssz.EncodeValue(obj)
+	if err != nil {
+		t.Fatalf("failed to encode read-only value: %v", err)
+	}
+	want := binary.LittleEndian.AppendUint64(nil, 5)
+	want = append(want, blob...)
+	want = binary.LittleEndian.AppendUint32(want, uint32(len(want)+4))
+	want = append(want, 6, 7)
+	if !bytes.Equal(have, want) {
+		t.Errorf("read-only encoding mismatch: have %x, want %x", have, want)
+	}
+	root, err := ssz.HashRootFromBytes(want, &obj, ssz.ForkUnknown)
+	if err != nil {
+		t.Fatalf("failed to hash read-only encoding: %v", err)
+	}
+	if have := ssz.HashSequential(obj); have != root {
+		t.Errorf("read-only root mismatch: have %#x, want %#x", have, root)
+	}
+	// Decoding into value receiver types should be refused instead of silently
+	// filling a copy
+	if err := ssz.DecodeFromBytes(want, new(types.ReadOnlyVariation)); !errors.Is(err, ssz.ErrReadOnlyObject) {
+		t.Errorf("decode error mismatch: have %v, want %v", err, ssz.ErrReadOnlyObject)
+	}
+}
+
+// Tests that the reflection based helpers accept objects held by value (types
+// generated with value receivers) instead of assuming struct pointers.
+func TestValueReceiverHelpers(t *testing.T) {
+	obj := types.ReadOnlyVariation{Slot: 5, Withdrawal: new(types.WithdrawalVariation), Extra: []byte{6, 7}}
+
+	if have, want := ssz.ZeroRootOf(obj, ssz.ForkUnknown), ssz.HashSequential(new(types.ReadOnlyVariation)); have != want {
+		t.Errorf("zero root mismatch: have %x, want %x", have, want)
+	}
+	if have, want := ssz.ZeroRootOf(obj, ssz.ForkUnknown), ssz.ZeroRootOf(&obj, ssz.ForkUnknown); have != want {
+		t.Errorf("value and pointer zero root mismatch: have %x, want %x", have, want)
+	}
+	blob, err := ssz.EncodeValue(obj)
+	if err != nil {
+		t.Fatalf("failed to encode read-only value: %v", err)
+	}
+	// Helpers decoding into a fresh instance should refuse instead of panicking
+	if err := ssz.CheckOffsets(blob, obj, ssz.ForkUnknown); !errors.Is(err, ssz.ErrReadOnlyObject) {
+		t.Errorf("offset check error mismatch: have %v, want %v", err, ssz.ErrReadOnlyObject)
+	}
+	if err := ssz.DecodeFields(blob, obj, ssz.FieldMask{"Slot"}); !errors.Is(err, ssz.ErrReadOnlyObject) {
+		t.Errorf("masked decode error mismatch: have %v, want %v", err, ssz.ErrReadOnlyObject)
+	}
+	if dump := ssz.Dump(obj, ssz.ForkUnknown); !strings.Contains(dump, ssz.ErrReadOnlyObject.Error()) {
+		t.Errorf("dump missing read-only error:\n%s", dump)
+	}
+	if diffs := ssz.Diff(obj, obj, ssz.ForkUnknown); len(diffs) != 0 {
+		t.Errorf("identical values reported different: %v", diffs)
+	}
+}
@@ -0,0 +1,65 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that masked decoding only decodes the selected fields, seeking past the
+// others (static and dynamic) via the offsets.
+func TestDecodeFields(t *testing.T) {
+	state := &types.BeaconState{
+		GenesisTime:     1,
+		Slot:            2,
+		HistoricalRoots: [][32]byte{{3}, {4}},
+		Eth1DataVotes:   []*types.Eth1Data{{DepositCount: 5}},
+		Validators:      []*types.Validator{{EffectiveBalance: 6}, {EffectiveBalance: 7}},
+		Balances:        []uint64{8, 9},
+		PreviousEpochAttestations: []*types.PendingAttestation{
+			{AggregationBits: bitfield.Bitlist{0x03}, Data: new(types.AttestationData), InclusionDelay: 10},
+		},
+		FinalizedCheckpoint: &types.Checkpoint{Epoch: 11},
+	}
+	state.RandaoMixes[0][0] = 12
+
+	blob := make([]byte, ssz.Size(state))
+	if err := ssz.EncodeToBytes(blob, state); err != nil {
+		t.Fatalf("failed to encode state: %v", err)
+	}
+	have := new(types.BeaconState)
+	if err := ssz.DecodeFields(blob, have, ssz.FieldMask{"Validators", "Balances", "FinalizedCheckpoint"}); err != nil {
+		t.Fatalf("failed to decode masked state: %v", err)
+	}
+	if !reflect.DeepEqual(have.Validators, state.Validators) {
+		t.Errorf("validators mismatch: have %v, want %v", have.Validators, state.Validators)
+	}
+	if !reflect.DeepEqual(have.Balances, state.Balances) {
+		t.Errorf("balances mismatch: have %v, want %v", have.Balances, state.Balances)
+	}
+	if have.FinalizedCheckpoint == nil || have.FinalizedCheckpoint.Epoch != 11 {
+		t.Errorf("finalized checkpoint mismatch: have %v, want %v", have.FinalizedCheckpoint, state.FinalizedCheckpoint)
+	}
+	if have.GenesisTime != 0 || have.Slot != 0 || have.RandaoMixes[0][0] != 0 {
+		t.Errorf("unselected static fields decoded")
+	}
+	if have.HistoricalRoots != nil || have.Eth1DataVotes != nil || have.PreviousEpochAttestations != nil {
+		t.Errorf("unselected dynamic fields decoded")
+	}
+	// Truncated data and unknown fields should be rejected
+	if err := ssz.DecodeFields(blob[:len(blob)-1], new(types.BeaconState), ssz.FieldMask{"Slot"}); err == nil {
+		t.Errorf("truncated masked decoding succeeded")
+	}
+	if err := ssz.DecodeFields(blob, new(types.BeaconState), ssz.FieldMask{"Unknown"}); !errors.Is(err, ssz.ErrUnknownField) {
+		t.Errorf("unknown field error mismatch: have %v, want %v", err, ssz.ErrUnknownField)
+	}
+}
@@ -0,0 +1,58 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that objects can be encoded into and decoded from memory mapped files,
+// with existing files being truncated to the encoded size.
+func TestFileRoundtrip(t *testing.T) {
+	obj := &types.ExecutionPayloadMonolith{ExtraData: []byte{0x01, 0x02}, Transactions: [][]byte{{0x03}}, Withdrawals: []*types.Withdrawal{{Index: 4}}}
+
+	blob := make([]byte, ssz.SizeOnFork(obj, ssz.ForkShanghai))
+	if err := ssz.EncodeToBytesOnFork(blob, obj, ssz.ForkShanghai); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "payload.ssz")
+	if err := os.WriteFile(path, make([]byte, 2*len(blob)), 0644); err != nil {
+		t.Fatalf("failed to create junk file: %v", err)
+	}
+	if err := ssz.EncodeToFileOnFork(path, obj, ssz.ForkShanghai); err != nil {
+		t.Fatalf("failed to encode object to file: %v", err)
+	}
+	have, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read encoded file: %v", err)
+	}
+	if !bytes.Equal(have, blob) {
+		t.Errorf("file content mismatch: have %x, want %x", have, blob)
+	}
+	dec := new(types.ExecutionPayloadMonolith)
+	if err := ssz.DecodeFromFileOnFork(path, dec, ssz.ForkShanghai); err != nil {
+		t.Fatalf("failed to decode object from file: %v", err)
+	}
+	if ssz.HashSequentialOnFork(dec, ssz.ForkShanghai) != ssz.HashSequentialOnFork(obj, ssz.ForkShanghai) {
+		t.Errorf("file decoded object mismatch")
+	}
+	// Decoding a truncated or missing file should fail
+	if err := os.Truncate(path, int64(len(blob)-1)); err != nil {
+		t.Fatalf("failed to truncate file: %v", err)
+	}
+	if err := ssz.DecodeFromFileOnFork(path, new(types.ExecutionPayloadMonolith), ssz.ForkShanghai); err == nil {
+		t.Errorf("truncated file decoded")
+	}
+	if err := ssz.DecodeFromFile(path+".missing", new(types.ExecutionPayload)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("missing file error mismatch: have %v, want %v", err, os.ErrNotExist)
+	}
+}
@@ -0,0 +1,470 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that types restricted to certain forks refuse to be encoded, decoded or
+// hashed in earlier forks, but work fine in later or fork agnostic contexts.
+func TestObjectFork(t *testing.T) {
+	obj := &types.SidecarMonolith{Index: 1, Blob: []byte{2}}
+
+	for _, fork := range []ssz.Fork{ssz.ForkUnknown, ssz.ForkDeneb, ssz.ForkElectra} {
+		blob := make([]byte, ssz.SizeOnFork(obj, fork))
+		if err := ssz.EncodeToBytesOnFork(blob, obj, fork); err != nil {
+			t.Errorf("fork %v: failed to encode: %v", fork, err)
+			continue
+		}
+		if err := ssz.DecodeFromBytesOnFork(blob, new(types.SidecarMonolith), fork); err != nil {
+			t.Errorf("fork %v: failed to decode: %v", fork, err)
+		}
+		if _, err := ssz.HashRootOnFork(obj, fork); err != nil {
+			t.Errorf("fork %v: failed to hash: %v", fork, err)
+		}
+	}
+	blob := make([]byte, ssz.SizeOnFork(obj, ssz.ForkCapella))
+	if err := ssz.EncodeToBytesOnFork(blob, obj, ssz.ForkCapella); !errors.Is(err, ssz.ErrObjectNotInFork) {
+		t.Errorf("encoding error mismatch: have %v, want %v", err, ssz.ErrObjectNotInFork)
+	}
+	if err := ssz.EncodeToStreamOnFork(io.Discard, obj, ssz.ForkCapella); !errors.Is(err, ssz.ErrObjectNotInFork) {
+		t.Errorf("streaming error mismatch: have %v, want %v", err, ssz.ErrObjectNotInFork)
+	}
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode fork agnostic: %v", err)
+	}
+	if err := ssz.DecodeFromBytesOnFork(blob, new(types.SidecarMonolith), ssz.ForkCapella); !errors.Is(err, ssz.ErrObjectNotInFork) {
+		t.Errorf("decoding error mismatch: have %v, want %v", err, ssz.ErrObjectNotInFork)
+	}
+	if _, err := ssz.HashRootOnFork(obj, ssz.ForkCapella); !errors.Is(err, ssz.ErrObjectNotInFork) {
+		t.Errorf("hashing error mismatch: have %v, want %v", err, ssz.ErrObjectNotInFork)
+	}
+}
+
+// Tests that fork dependent list limits are resolved based on the active fork.
+func TestLimitOnFork(t *testing.T) {
+	obj := &types.ForkLimitsVariation{List: make([]uint64, 6)}
+
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	for _, tt := range []struct {
+		fork ssz.Fork
+		fail bool
+	}{
+		{ssz.ForkCapella, true},
+		{ssz.ForkDeneb, false},
+		{ssz.ForkElectra, true},
+		{ssz.ForkFuture, true},
+	} {
+		err := ssz.DecodeFromBytesOnFork(blob, new(types.ForkLimitsVariation), tt.fork)
+		if tt.fail && !errors.Is(err, ssz.ErrMaxItemsExceeded) {
+			t.Errorf("fork %v: decoding error mismatch: have %v, want %v", tt.fork, err, ssz.ErrMaxItemsExceeded)
+		}
+		if !tt.fail && err != nil {
+			t.Errorf("fork %v: failed to decode object: %v", tt.fork, err)
+		}
+	}
+}
+
+// testForkRanges is a fork range set used to sanity check fork filters directly.
+var testForkRanges = ssz.ForkFilterSet{
+	{Added: ssz.ForkAltair, Removed: ssz.ForkCapella},
+	{Added: ssz.ForkElectra},
+}
+
+// Tests that fields present in multiple disjoint fork ranges are encoded only in
+// the forks covered by any of their ranges.
+func TestForkRanges(t *testing.T) {
+	b, d := uint64(2), uint32(4)
+	obj := &types.ForkRangesMonolith{A: 1, B: &b, C: []byte{3, 3, 3}, D: &d}
+
+	for _, tt := range []struct {
+		fork ssz.Fork
+		size uint32
+		b    bool
+		c    bool
+		d    bool
+	}{
+		{ssz.ForkPhase0, 12, false, false, true},
+		{ssz.ForkAltair, 16, true, false, false},
+		{ssz.ForkBellatrix, 27, true, true, true},
+		{ssz.ForkCapella, 15, false, true, false},
+		{ssz.ForkDeneb, 12, false, false, true},
+		{ssz.ForkElectra, 20, true, false, true},
+	} {
+		if size := ssz.SizeOnFork(obj, tt.fork); size != tt.size {
+			t.Errorf("fork %v: size mismatch: have %d, want %d", tt.fork, size, tt.size)
+			continue
+		}
+		blob := make([]byte, tt.size)
+		if err := ssz.EncodeToBytesOnFork(blob, obj, tt.fork); err != nil {
+			t.Errorf("fork %v: failed to encode object: %v", tt.fork, err)
+			continue
+		}
+		dec := new(types.ForkRangesMonolith)
+		if err := ssz.DecodeFromBytesOnFork(blob, dec, tt.fork); err != nil {
+			t.Errorf("fork %v: failed to decode object: %v", tt.fork, err)
+			continue
+		}
+		if (dec.B != nil) != tt.b || (dec.C != nil) != tt.c || (dec.D != nil) != tt.d {
+			t.Errorf("fork %v: field presence mismatch: have B=%v C=%v D=%v, want B=%v C=%v D=%v",
+				tt.fork, dec.B != nil, dec.C != nil, dec.D != nil, tt.b, tt.c, tt.d)
+		}
+		if have, want := ssz.HashSequentialOnFork(dec, tt.fork), ssz.HashSequentialOnFork(obj, tt.fork); have != want {
+			t.Errorf("fork %v: hash mismatch: have %x, want %x", tt.fork, have, want)
+		}
+	}
+	// Sanity check the filter set directly too, including the open ended range
+	filter := ssz.ForkFilter{Ranges: &testForkRanges}
+	for fork, active := range map[ssz.Fork]bool{
+		ssz.ForkPhase0:    false,
+		ssz.ForkAltair:    true,
+		ssz.ForkBellatrix: true,
+		ssz.ForkCapella:   false,
+		ssz.ForkDeneb:     false,
+		ssz.ForkElectra:   true,
+		ssz.ForkFuture:    true,
+	} {
+		if filter.Active(fork) != active {
+			t.Errorf("fork %v: filter activity mismatch: have %v, want %v", fork, !active, active)
+		}
+	}
+	// Filters must stay comparable (usable as map keys) even with ranges set
+	seen := map[ssz.ForkFilter]bool{filter: true}
+	if !seen[ssz.ForkFilter{Ranges: &testForkRanges}] {
+		t.Errorf("fork filter with ranges not comparable")
+	}
+}
+
+// Tests that custom forks can be registered after the built-in ones and that the
+// fork navigation helpers take them into account.
+func TestCustomForks(t *testing.T) {
+	if fork, ok := ssz.ForkMapping["custom"]; !ok || fork != types.ForkCustom {
+		t.Fatalf("custom fork registration mismatch: have %v/%v, want %v/true", fork, ok, types.ForkCustom)
+	}
+	if fork := ssz.ForkAfter(ssz.ForkElectra); fork != types.ForkCustom {
+		t.Errorf("fork after electra mismatch: have %v, want %v", fork, types.ForkCustom)
+	}
+	if fork := ssz.ForkAfter(types.ForkCustom); fork != ssz.ForkFuture {
+		t.Errorf("fork after custom mismatch: have %v, want %v", fork, ssz.ForkFuture)
+	}
+	if fork := ssz.ForkBefore(ssz.ForkFuture); fork != types.ForkCustom {
+		t.Errorf("fork before future mismatch: have %v, want %v", fork, types.ForkCustom)
+	}
+	if fork := ssz.ForkBefore(types.ForkCustom); fork != ssz.ForkElectra {
+		t.Errorf("fork before custom mismatch: have %v, want %v", fork, ssz.ForkElectra)
+	}
+	if fork := ssz.ForkBefore(ssz.ForkFrontier); fork != ssz.ForkUnknown {
+		t.Errorf("fork before frontier mismatch: have %v, want %v", fork, ssz.ForkUnknown)
+	}
+	// Ensure generated code respects the custom fork boundaries
+	b, c := uint64(2), uint64(3)
+	obj := &types.CustomForkMonolith{A: 1, B: &b, C: &c}
+
+	for fork, size := range map[ssz.Fork]uint32{
+		ssz.ForkCapella:  8,
+		ssz.ForkDeneb:    16,
+		types.ForkCustom: 16,
+		ssz.ForkElectra:  16,
+	} {
+		if have := ssz.SizeOnFork(obj, fork); have != size {
+			t.Errorf("fork %v: size mismatch: have %d, want %d", fork, have, size)
+		}
+	}
+	blob := make([]byte, 16)
+	if err := ssz.EncodeToBytesOnFork(blob, obj, types.ForkCustom); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	if want := []byte{1, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0}; !bytes.Equal(blob, want) {
+		t.Errorf("custom fork encoding mismatch: have %x, want %x", blob, want)
+	}
+	// Ensure duplicate registrations and forks amongst the built-in ones are rejected
+	for name, fork := range map[string]ssz.Fork{
+		"deneb":    types.ForkCustom + 1,
+		"midcycle": ssz.ForkDeneb + 1,
+		"toolate":  ssz.ForkFuture,
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("fork %q (%d) registration did not panic", name, fork)
+				}
+			}()
+			ssz.RegisterFork(name, fork)
+		}()
+	}
+}
+
+// Tests that lists behind pointers distinguish being missing from a fork (nil)
+// from being empty, and that nil lists in their forks are handled as empty ones.
+func TestSlicePointers(t *testing.T) {
+	obj := &types.ListForksMonolith{
+		Slot:                1,
+		InactivityScores:    &[]uint64{2, 3},
+		HistoricalRoots:     &[][32]byte{{4}},
+		HistoricalSummaries: &[]*types.HistoricalSummary{{BlockSummaryRoot: [32]byte{5}}},
+		PendingAttestations: new([]*types.PendingAttestation),
+	}
+	for _, tt := range []struct {
+		fork    ssz.Fork
+		size    uint32
+		present int // Number of lists active in the fork
+	}{
+		{ssz.ForkPhase0, 8, 0},
+		{ssz.ForkAltair, 28, 1},
+		{ssz.ForkBellatrix, 64, 2},
+		{ssz.ForkCapella, 132, 3},
+		{ssz.ForkDeneb, 136, 4},
+	} {
+		if size := ssz.SizeOnFork(obj, tt.fork); size != tt.size {
+			t.Errorf("fork %v: size mismatch: have %d, want %d", tt.fork, size, tt.size)
+			continue
+		}
+		blob := make([]byte, tt.size)
+		if err := ssz.EncodeToBytesOnFork(blob, obj, tt.fork); err != nil {
+			t.Errorf("fork %v: failed to encode object: %v", tt.fork, err)
+			continue
+		}
+		dec := new(types.ListForksMonolith)
+		if err := ssz.DecodeFromBytesOnFork(blob, dec, tt.fork); err != nil {
+			t.Errorf("fork %v: failed to decode object: %v", tt.fork, err)
+			continue
+		}
+		present := []bool{dec.InactivityScores != nil, dec.HistoricalRoots != nil, dec.HistoricalSummaries != nil, dec.PendingAttestations != nil}
+		for i, have := range present {
+			if want := i < tt.present; have != want {
+				t.Errorf("fork %v: list %d presence mismatch: have %v, want %v", tt.fork, i, have, want)
+			}
+		}
+		if have, want := ssz.HashSequentialOnFork(dec, tt.fork), ssz.HashSequentialOnFork(obj, tt.fork); have != want {
+			t.Errorf("fork %v: hash mismatch: have %x, want %x", tt.fork, have, want)
+		}
+	}
+	// Nil lists within their forks should be handled as empty ones, and decode
+	// into allocated empty lists
+	empty := &types.ListForksMonolith{Slot: 1, InactivityScores: new([]uint64)}
+	blob := make([]byte, ssz.SizeOnFork(empty, ssz.ForkDeneb))
+	if err := ssz.EncodeToBytesOnFork(blob, empty, ssz.ForkDeneb); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	dec := new(types.ListForksMonolith)
+	if err := ssz.DecodeFromBytesOnFork(blob, dec, ssz.ForkDeneb); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	if dec.InactivityScores == nil || dec.HistoricalRoots == nil || dec.HistoricalSummaries == nil || dec.PendingAttestations == nil {
+		t.Errorf("empty lists not allocated: %+v", dec)
+	}
+	if have, want := ssz.HashSequentialOnFork(dec, ssz.ForkDeneb), ssz.HashSequentialOnFork(empty, ssz.ForkDeneb); have != want {
+		t.Errorf("hash mismatch: have %x, want %x", have, want)
+	}
+	// Decoding into a previously populated object in an older fork should clear
+	// out the lists not present in that fork
+	blob = make([]byte, ssz.SizeOnFork(obj, ssz.ForkAltair))
+	if err := ssz.EncodeToBytesOnFork(blob, obj, ssz.ForkAltair); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	if err := ssz.DecodeFromBytesOnFork(blob, dec, ssz.ForkAltair); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	if dec.InactivityScores == nil || len(*dec.InactivityScores) != 2 || dec.HistoricalRoots != nil || dec.HistoricalSummaries != nil || dec.PendingAttestations != nil {
+		t.Errorf("lists not cleared: %+v", dec)
+	}
+}
+
+// Tests that decoding data encoded in a different fork attributes the leftover
+// data to the fields skipped by their fork filters.
+func TestFieldNotInFork(t *testing.T) {
+	obj := &types.PackedArraysVariation{Extra: new([16]uint32)}
+
+	blob := make([]byte, ssz.SizeOnFork(obj, ssz.ForkDeneb))
+	if err := ssz.EncodeToBytesOnFork(blob, obj, ssz.ForkDeneb); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	err := ssz.DecodeFromBytesOnFork(blob, new(types.PackedArraysVariation), ssz.ForkCapella)
+	if !errors.Is(err, ssz.ErrFieldNotInFork) || !errors.Is(err, ssz.ErrObjectSlotSizeMismatch) {
+		t.Fatalf("decoding error mismatch: have %v, want %v", err, ssz.ErrFieldNotInFork)
+	}
+	if !strings.Contains(err.Error(), "Extra") || !strings.Contains(err.Error(), "added in cancun/dencun/deneb") {
+		t.Errorf("decoding error does not name the skipped field: %v", err)
+	}
+	err = ssz.DecodeFromStreamOnFork(bytes.NewReader(blob), new(types.PackedArraysVariation), uint32(len(blob)), ssz.ForkCapella)
+	if !errors.Is(err, ssz.ErrFieldNotInFork) {
+		t.Errorf("stream decoding error mismatch: have %v, want %v", err, ssz.ErrFieldNotInFork)
+	}
+	// Ensure leftover data without skipped fields is reported as before
+	err = ssz.DecodeFromBytesOnFork(append(blob, 0), new(types.PackedArraysVariation), ssz.ForkDeneb)
+	if !errors.Is(err, ssz.ErrObjectSlotSizeMismatch) || errors.Is(err, ssz.ErrFieldNotInFork) {
+		t.Errorf("plain decoding error mismatch: have %v, want %v", err, ssz.ErrObjectSlotSizeMismatch)
+	}
+}
+
+// Tests that fields defined with an overridden fork are encoded, decoded and
+// hashed in that fork, independent of the fork of the containing object.
+func TestDefineWithFork(t *testing.T) {
+	blobGasUsed := uint64(1)
+	payload := &types.ExecutionPayloadHeaderMonolith{
+		ExtraData:      []byte{0xca, 0xfe},
+		BlockNumber:    2,
+		WithdrawalRoot: &[32]byte{0x03},
+		BlobGasUsed:    &blobGasUsed,
+		ExcessBlobGas:  &blobGasUsed,
+	}
+	obj := &testLegacyEnvelope{Payload: payload}
+
+	blob := make([]byte, ssz.SizeOnFork(obj, ssz.ForkCancun))
+	if err := ssz.EncodeToBytesOnFork(blob, obj, ssz.ForkCancun); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	// The payload must be embedded in its own fork, not the envelope's
+	legacy := make([]byte, ssz.SizeOnFork(payload, ssz.ForkShanghai))
+	if err := ssz.EncodeToBytesOnFork(legacy, payload, ssz.ForkShanghai); err != nil {
+		t.Fatalf("failed to encode payload: %v", err)
+	}
+	if !bytes.Equal(blob[4:], legacy) {
+		t.Fatalf("embedded payload mismatch: have %x, want %x", blob[4:], legacy)
+	}
+	if obj.forks != [3]ssz.Fork{ssz.ForkCancun, ssz.ForkShanghai, ssz.ForkCancun} {
+		t.Errorf("codec fork mismatch: have %v", obj.forks)
+	}
+	dec := new(testLegacyEnvelope)
+	if err := ssz.DecodeFromBytesOnFork(blob, dec, ssz.ForkCancun); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	if dec.Payload.BlockNumber != payload.BlockNumber || dec.Payload.BlobGasUsed != nil {
+		t.Errorf("decoded payload mismatch: have %+v", dec.Payload)
+	}
+	// A single field container's root is the root of the field itself
+	if have, want := ssz.HashSequentialOnFork(obj, ssz.ForkCancun), ssz.HashSequentialOnFork(payload, ssz.ForkShanghai); have != want {
+		t.Errorf("hash mismatch: have %x, want %x", have, want)
+	}
+}
+
+// testLegacyEnvelope is a container embedding a payload fixed to a legacy fork,
+// tracking the codec forks seen before, within and after the override.
+type testLegacyEnvelope struct {
+	Payload *types.ExecutionPayloadHeaderMonolith
+	forks   [3]ssz.Fork
+}
+
+func (t *testLegacyEnvelope) SizeSSZ(siz *ssz.Sizer, fixed bool) uint32 {
+	if fixed {
+		return 4
+	}
+	return 4 + ssz.SizeWithFork(siz, ssz.ForkShanghai, func() uint32 {
+		return ssz.SizeDynamicObject(siz, t.Payload)
+	})
+}
+func (t *testLegacyEnvelope) DefineSSZ(codec *ssz.Codec) {
+	t.forks[0] = codec.Fork()
+	ssz.DefineWithFork(codec, ssz.ForkShanghai, func() {
+		t.forks[1] = codec.Fork()
+		ssz.DefineDynamicObjectOffset(codec, &t.Payload)
+		ssz.DefineDynamicObjectContent(codec, &t.Payload)
+	})
+	t.forks[2] = codec.Fork()
+}
+
+// Tests that fields switching their encodings in later forks get encoded, decoded
+// and hashed according to the fork being operated on.
+func TestForkTypes(t *testing.T) {
+	// Attestations before Electra must match the legacy layout
+	bits := bitfield.NewBitlist(3000)
+	bits.SetBitAt(2999, true)
+
+	obj := &types.AttestationMonolith{
+		AggregationBits: bitfield.NewBitlist(100),
+		Data:            &types.AttestationData{Slot: 1, Source: new(types.Checkpoint), Target: new(types.Checkpoint)},
+	}
+	legacy := &types.Attestation{AggregationBits: obj.AggregationBits, Data: obj.Data}
+
+	blob := make([]byte, ssz.SizeOnFork(obj, ssz.ForkDeneb))
+	if err := ssz.EncodeToBytesOnFork(blob, obj, ssz.ForkDeneb); err != nil {
+		t.Fatalf("failed to encode deneb attestation: %v", err)
+	}
+	want := make([]byte, ssz.Size(legacy))
+	if err := ssz.EncodeToBytes(want, legacy); err != nil {
+		t.Fatalf("failed to encode legacy attestation: %v", err)
+	}
+	if !bytes.Equal(blob, want) {
+		t.Errorf("deneb encoding mismatch: have %x, want %x", blob, want)
+	}
+	if have, want := ssz.HashSequentialOnFork(obj, ssz.ForkDeneb), ssz.HashSequential(legacy); have != want {
+		t.Errorf("deneb root mismatch: have %x, want %x", have, want)
+	}
+	// Attestations from Electra on must use the extended limits
+	obj.AggregationBits, obj.CommitteeBits = bits, &[8]byte{1}
+
+	blob = make([]byte, ssz.SizeOnFork(obj, ssz.ForkElectra))
+	if err := ssz.EncodeToBytesOnFork(blob, obj, ssz.ForkElectra); err != nil {
+		t.Fatalf("failed to encode electra attestation: %v", err)
+	}
+	dec := new(types.AttestationMonolith)
+	if err := ssz.DecodeFromBytesOnFork(blob, dec, ssz.ForkElectra); err != nil {
+		t.Fatalf("failed to decode electra attestation: %v", err)
+	}
+	if !bytes.Equal(dec.AggregationBits, bits) || *dec.CommitteeBits != *obj.CommitteeBits {
+		t.Errorf("electra decoding mismatch: have %x/%x, want %x/%x", dec.AggregationBits, *dec.CommitteeBits, bits, *obj.CommitteeBits)
+	}
+	legacy.AggregationBits = bits
+
+	blob = make([]byte, ssz.Size(legacy))
+	if err := ssz.EncodeToBytes(blob, legacy); err != nil {
+		t.Fatalf("failed to encode legacy attestation: %v", err)
+	}
+	if err := ssz.DecodeFromBytesOnFork(blob, new(types.AttestationMonolith), ssz.ForkDeneb); !errors.Is(err, ssz.ErrMaxItemsExceeded) {
+		t.Errorf("deneb oversized bits error mismatch: have %v, want %v", err, ssz.ErrMaxItemsExceeded)
+	}
+	electra := &types.AttestationElectraVariation{AggregationBits: bits, Data: obj.Data, CommitteeBits: *obj.CommitteeBits}
+	if have, want := ssz.HashSequentialOnFork(obj, ssz.ForkElectra), ssz.HashSequential(electra); have != want {
+		t.Errorf("electra root mismatch: have %x, want %x", have, want)
+	}
+	// Static fields switching sizes must change the layout of the container
+	multi := &types.ForkTypesMonolith{Payload: make([]byte, 20)}
+	for _, tt := range []struct {
+		name  string
+		fork  ssz.Fork
+		flags int
+		fails bool
+	}{
+		{"capella", ssz.ForkCapella, 4, true},
+		{"deneb", ssz.ForkDeneb, 8, true},
+		{"electra", ssz.ForkElectra, 16, false},
+	} {
+		multi.Flags = make([]byte, tt.flags)
+		if have, want := ssz.SizeOnFork(multi, tt.fork), uint32(tt.flags+1+4+20); have != want {
+			t.Errorf("%s: size mismatch: have %d, want %d", tt.name, have, want)
+		}
+		blob := make([]byte, ssz.SizeOnFork(multi, tt.fork))
+		if err := ssz.EncodeToBytesOnFork(blob, multi, tt.fork); err != nil {
+			t.Fatalf("%s: failed to encode object: %v", tt.name, err)
+		}
+		dec := new(types.ForkTypesMonolith)
+		err := ssz.DecodeFromBytesOnFork(blob, dec, tt.fork)
+		if tt.fails {
+			if !errors.Is(err, ssz.ErrMaxLengthExceeded) {
+				t.Errorf("%s: oversized payload error mismatch: have %v, want %v", tt.name, err, ssz.ErrMaxLengthExceeded)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: failed to decode object: %v", tt.name, err)
+		}
+		if len(dec.Flags) != tt.flags || len(dec.Payload) != 20 {
+			t.Errorf("%s: decoding mismatch: have %d/%d bytes, want %d/20", tt.name, len(dec.Flags), len(dec.Payload), tt.flags)
+		}
+	}
+}
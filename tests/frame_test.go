@@ -0,0 +1,91 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that size-prefixed frames round-trip and that oversized ones are rejected.
+func TestFrameRoundtrip(t *testing.T) {
+	obj := &types.ExecutionPayload{ExtraData: []byte{0x01, 0x02}, Transactions: [][]byte{{0x03}}}
+
+	for _, format := range []ssz.FrameFormat{ssz.FrameUvarint, ssz.FrameUint32, ssz.FrameSnappy} {
+		buf := new(bytes.Buffer)
+		if err := ssz.WriteFrame(buf, obj, format); err != nil {
+			t.Fatalf("format %d: failed to write frame: %v", format, err)
+		}
+		buf.WriteString("trailer")
+
+		dec := new(types.ExecutionPayload)
+		if err := ssz.ReadFrame(buf, dec, ssz.Size(obj), format); err != nil {
+			t.Fatalf("format %d: failed to read frame: %v", format, err)
+		}
+		if ssz.HashSequential(dec) != ssz.HashSequential(obj) {
+			t.Errorf("format %d: decoded object mismatch", format)
+		}
+		if buf.String() != "trailer" {
+			t.Errorf("format %d: stream position mismatch: have %q, want %q", format, buf.String(), "trailer")
+		}
+		buf.Reset()
+		if err := ssz.WriteFrame(buf, obj, format); err != nil {
+			t.Fatalf("format %d: failed to write frame: %v", format, err)
+		}
+		if err := ssz.ReadFrame(buf, dec, ssz.Size(obj)-1, format); !errors.Is(err, ssz.ErrMaxFrameSizeExceeded) {
+			t.Errorf("format %d: oversized frame error mismatch: have %v, want %v", format, err, ssz.ErrMaxFrameSizeExceeded)
+		}
+	}
+	// Frames spanning multiple snappy chunks must be consumed exactly, leaving
+	// the next frame in the stream intact
+	tx := make([]byte, 200*1024)
+	for i := range tx {
+		tx[i] = byte(i * 7)
+	}
+	big := &types.ExecutionPayload{Transactions: [][]byte{tx}}
+
+	buf := new(bytes.Buffer)
+	if err := ssz.WriteFrame(buf, big, ssz.FrameSnappy); err != nil {
+		t.Fatalf("failed to write frame: %v", err)
+	}
+	next := buf.Len()
+	if err := ssz.WriteFrame(buf, obj, ssz.FrameSnappy); err != nil {
+		t.Fatalf("failed to write frame: %v", err)
+	}
+	next = buf.Len() - next
+
+	dec := new(types.ExecutionPayload)
+	if err := ssz.ReadFrame(buf, dec, ssz.Size(big), ssz.FrameSnappy); err != nil {
+		t.Fatalf("failed to read first frame: %v", err)
+	}
+	if buf.Len() != next {
+		t.Fatalf("stream position mismatch: have %d bytes left, want %d", buf.Len(), next)
+	}
+	if err := ssz.ReadFrame(buf, dec, ssz.Size(obj), ssz.FrameSnappy); err != nil {
+		t.Fatalf("failed to read second frame: %v", err)
+	}
+	if ssz.HashSequential(dec) != ssz.HashSequential(obj) {
+		t.Errorf("second frame mismatch")
+	}
+	// Corrupt frames announcing chunks longer than their declared size must not
+	// read arbitrarily far into the data following them
+	buf.Reset()
+	buf.Write([]byte{0x01})                                                 // frame size prefix: 1 byte
+	buf.Write([]byte{0xff, 0x06, 0x00, 0x00, 's', 'N', 'a', 'P', 'p', 'Y'}) // stream identifier
+	buf.Write([]byte{0x01, 0x00, 0x10, 0x00})                               // uncompressed chunk of 4KiB
+	buf.Write(make([]byte, 4096))
+
+	if err := ssz.ReadFrame(buf, new(types.Checkpoint), 1, ssz.FrameSnappy); err == nil {
+		t.Errorf("corrupt frame accepted")
+	}
+	// Worst case framing of 1 byte: identifier, chunk header, max encoded block
+	if consumed := 1 + 10 + 4 + 4096 - buf.Len(); consumed > 1+10+8+32+1 {
+		t.Errorf("corrupt frame over-read: consumed %d bytes", consumed)
+	}
+}
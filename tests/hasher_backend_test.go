@@ -0,0 +1,144 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"crypto/sha256"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that custom hasher backends are used for all the inner hashing, including
+// zero sub-tries, nil objects and concurrent hashing.
+func TestHasherBackend(t *testing.T) {
+	obj := &types.ExecutionPayloadMonolith{
+		ExtraData:    []byte{0x01, 0x02},
+		Transactions: make([][]byte, 5000),
+		Withdrawals:  make([]*types.Withdrawal, types.MaxWithdrawalsPerPayload),
+	}
+	for i := range obj.Transactions {
+		obj.Transactions[i] = make([]byte, 100)
+		obj.Transactions[i][0] = byte(i)
+	}
+	for i := range obj.Withdrawals {
+		obj.Withdrawals[i] = &types.Withdrawal{Index: uint64(i)}
+	}
+	want := ssz.HashSequentialOnFork(obj, ssz.ForkDeneb)
+
+	// Nil sub-objects are hashed via cached zero roots, which depend on the backend
+	att := new(types.Attestation)
+	if have, want := ssz.HashSequentialWithBackend(att, testSHA256Backend{}), ssz.HashSequential(att); have != want {
+		t.Errorf("nil object root mismatch: have %x, want %x", have, want)
+	}
+	if ssz.HashSequentialWithBackend(att, testTaggedBackend{}) == ssz.HashSequential(att) {
+		t.Errorf("nil object root not affected by backend")
+	}
+
+	// A backend equivalent to the default one must produce the same roots
+	for _, backend := range []ssz.HasherBackend{ssz.SHA256Backend, testSHA256Backend{}} {
+		if have := ssz.HashSequentialWithBackendOnFork(obj, ssz.ForkDeneb, backend); have != want {
+			t.Errorf("%T: sequential root mismatch: have %x, want %x", backend, have, want)
+		}
+		if have := ssz.HashConcurrentWithBackendOnFork(obj, ssz.ForkDeneb, backend); have != want {
+			t.Errorf("%T: concurrent root mismatch: have %x, want %x", backend, have, want)
+		}
+	}
+	// A different backend must produce different, but consistent roots
+	seq := ssz.HashSequentialWithBackendOnFork(obj, ssz.ForkDeneb, testTaggedBackend{})
+	if seq == want {
+		t.Errorf("tagged backend root matches sha256 one")
+	}
+	if con := ssz.HashConcurrentWithBackendOnFork(obj, ssz.ForkDeneb, testTaggedBackend{}); con != seq {
+		t.Errorf("tagged backend concurrent root mismatch: have %x, want %x", con, seq)
+	}
+	// Backends configured via profiles must be used too
+	profile := ssz.Profile{Backend: testTaggedBackend{}}
+	if have := profile.HashSequentialOnFork(obj, ssz.ForkDeneb); have != seq {
+		t.Errorf("profile sequential root mismatch: have %x, want %x", have, seq)
+	}
+	if have := profile.HashConcurrentOnFork(obj, ssz.ForkDeneb); have != seq {
+		t.Errorf("profile concurrent root mismatch: have %x, want %x", have, seq)
+	}
+	// Subsequent default hashing must not be affected by pooled hashers
+	if have := ssz.HashSequentialOnFork(obj, ssz.ForkDeneb); have != want {
+		t.Errorf("default root changed after custom backend: have %x, want %x", have, want)
+	}
+}
+
+// Tests that failures of custom hasher backends are surfaced as errors from the
+// HashRoot family of methods and as panics from the rest.
+func TestHasherBackendFailure(t *testing.T) {
+	obj := &types.ExecutionPayloadMonolith{
+		ExtraData:    []byte{0x01, 0x02},
+		Transactions: make([][]byte, 5000),
+	}
+	for i := range obj.Transactions {
+		obj.Transactions[i] = make([]byte, 100)
+	}
+	// Failures both during the zero hash precomputation and in the middle of
+	// hashing must be reported
+	for _, limit := range []int64{0, 100} {
+		if _, err := ssz.HashRootWithBackendOnFork(obj, ssz.ForkDeneb, &testFailingBackend{limit: limit}); !errors.Is(err, ssz.ErrHasherBackendFailed) {
+			t.Errorf("limit %d: backend error mismatch: have %v, want %v", limit, err, ssz.ErrHasherBackendFailed)
+		}
+		if _, err := (ssz.Profile{Backend: &testFailingBackend{limit: limit}}).HashRootOnFork(obj, ssz.ForkDeneb); !errors.Is(err, ssz.ErrHasherBackendFailed) {
+			t.Errorf("limit %d: profile backend error mismatch: have %v, want %v", limit, err, ssz.ErrHasherBackendFailed)
+		}
+		for name, hash := range map[string]func(){
+			"sequential": func() { ssz.HashSequentialWithBackendOnFork(obj, ssz.ForkDeneb, &testFailingBackend{limit: limit}) },
+			"concurrent": func() { ssz.HashConcurrentWithBackendOnFork(obj, ssz.ForkDeneb, &testFailingBackend{limit: limit}) },
+		} {
+			func() {
+				defer func() {
+					if r := recover(); r == nil {
+						t.Errorf("limit %d: %s hashing did not panic", limit, name)
+					}
+				}()
+				hash()
+			}()
+		}
+	}
+	// Nil sub-objects are hashed via zero roots, whose failures must be reported
+	if _, err := ssz.HashRootWithBackend(new(types.Attestation), &testFailingBackend{limit: 70}); !errors.Is(err, ssz.ErrHasherBackendFailed) {
+		t.Errorf("nil object backend error mismatch: have %v, want %v", err, ssz.ErrHasherBackendFailed)
+	}
+}
+
+// testFailingBackend is a hasher backend failing after a given number of calls.
+type testFailingBackend struct {
+	calls atomic.Int64
+	limit int64
+}
+
+func (b *testFailingBackend) HashChunks(digests [][32]byte, chunks [][32]byte) error {
+	if b.calls.Add(1) > b.limit {
+		return errors.New("backend exhausted")
+	}
+	return testSHA256Backend{}.HashChunks(digests, chunks)
+}
+
+// testSHA256Backend is a hasher backend using the standard library sha256.
+type testSHA256Backend struct{}
+
+func (testSHA256Backend) HashChunks(digests [][32]byte, chunks [][32]byte) error {
+	for i := range digests {
+		digests[i] = sha256.Sum256(append(chunks[2*i][:], chunks[2*i+1][:]...))
+	}
+	return nil
+}
+
+// testTaggedBackend is a hasher backend using a domain separated sha256.
+type testTaggedBackend struct{}
+
+func (testTaggedBackend) HashChunks(digests [][32]byte, chunks [][32]byte) error {
+	for i := range digests {
+		digests[i] = sha256.Sum256(append([]byte("tag"), append(chunks[2*i][:], chunks[2*i+1][:]...)...))
+	}
+	return nil
+}
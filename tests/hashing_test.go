@@ -0,0 +1,613 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+	bitops "math/bits"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/holiman/uint256"
+	"github.com/prysmaticlabs/go-bitfield"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that objects with precomputed roots are not rehashed by the hasher, but
+// rather their roots are inserted directly into the merkle tree.
+func TestRootedObjectHashing(t *testing.T) {
+	withdrawal := &types.Withdrawal{Index: 1, Validator: 2, Address: [20]byte{3}, Amount: 4}
+
+	plain := &testPlainContainerType{A: withdrawal}
+	rooted := &testRootedContainerType{A: &testRootedWithdrawalType{root: ssz.HashSequential(withdrawal)}}
+
+	if have, want := ssz.HashSequential(rooted), ssz.HashSequential(plain); have != want {
+		t.Errorf("sequential root mismatch: have %x, want %x", have, want)
+	}
+	if have, want := ssz.HashConcurrent(rooted), ssz.HashConcurrent(plain); have != want {
+		t.Errorf("concurrent root mismatch: have %x, want %x", have, want)
+	}
+}
+
+type testPlainContainerType struct {
+	A *types.Withdrawal
+}
+
+func (t *testPlainContainerType) SizeSSZ(sizer *ssz.Sizer) uint32 { return 44 }
+func (t *testPlainContainerType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineStaticObject(codec, &t.A)
+}
+
+type testRootedContainerType struct {
+	A *testRootedWithdrawalType
+}
+
+func (t *testRootedContainerType) SizeSSZ(sizer *ssz.Sizer) uint32 { return 44 }
+func (t *testRootedContainerType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineStaticObject(codec, &t.A)
+}
+
+type testRootedWithdrawalType struct {
+	types.Withdrawal
+	root [32]byte
+}
+
+func (t *testRootedWithdrawalType) HashTreeRootSSZ() [32]byte { return t.root }
+
+// Tests that nil sub-objects hash to the same root as their explicit zero values,
+// even when the cached zero roots differ across forks.
+func TestNilObjectHashing(t *testing.T) {
+	for _, fork := range []ssz.Fork{ssz.ForkBellatrix, ssz.ForkCapella, ssz.ForkDeneb} {
+		for i := 0; i < 2; i++ { // Second run hits the cache
+			have := ssz.HashSequentialOnFork(&types.BeaconBlockBodyMonolith{}, fork)
+			want := ssz.HashSequentialOnFork(&types.BeaconBlockBodyMonolith{
+				Eth1Data:         new(types.Eth1Data),
+				SyncAggregate:    new(types.SyncAggregate),
+				ExecutionPayload: new(types.ExecutionPayloadMonolith),
+			}, fork)
+			if have != want {
+				t.Errorf("fork %v, run %d: root mismatch: have %x, want %x", fork, i, have, want)
+			}
+		}
+	}
+}
+
+// Tests that the exported zero hashes and zero roots match explicit hashing.
+func TestZeroRoots(t *testing.T) {
+	chunk := ssz.ZeroHash(0)
+	for i := 1; i <= 64; i++ {
+		chunk = sha256.Sum256(append(chunk[:], chunk[:]...))
+		if have := ssz.ZeroHash(i); have != chunk {
+			t.Errorf("depth %d: zero hash mismatch: have %x, want %x", i, have, chunk)
+		}
+	}
+	for _, fork := range []ssz.Fork{ssz.ForkBellatrix, ssz.ForkDeneb} {
+		want := ssz.HashSequentialOnFork(new(types.ExecutionPayloadMonolith), fork)
+		if have := ssz.ZeroRootOf((*types.ExecutionPayloadMonolith)(nil), fork); have != want {
+			t.Errorf("fork %v: zero root mismatch: have %x, want %x", fork, have, want)
+		}
+	}
+}
+
+// Tests that batch hashing produces the same roots as hashing one by one, both
+// below and above the concurrency threshold.
+func TestHashRoots(t *testing.T) {
+	for _, n := range []int{0, 1, 10, 10000} {
+		objs := make([]*types.Validator, n)
+		for i := range objs {
+			objs[i] = &types.Validator{EffectiveBalance: uint64(i), Slashed: i%2 == 0}
+		}
+		roots := ssz.HashRoots(objs, ssz.ForkUnknown)
+		if len(roots) != n {
+			t.Fatalf("n=%d: root count mismatch: have %d, want %d", n, len(roots), n)
+		}
+		for i, obj := range objs {
+			if want := ssz.HashSequential(obj); roots[i] != want {
+				t.Fatalf("n=%d, obj %d: root mismatch: have %x, want %x", n, i, roots[i], want)
+			}
+		}
+	}
+	// Objects with differently shaped tries must not be merkleized together
+	var mixed []ssz.Object
+	for i := 0; i < 1000; i++ {
+		switch i % 3 {
+		case 0:
+			mixed = append(mixed, &types.Validator{EffectiveBalance: uint64(i)})
+		case 1:
+			mixed = append(mixed, &types.Checkpoint{Epoch: uint64(i)})
+		default:
+			mixed = append(mixed, &types.BeaconBlockHeader{Slot: uint64(i)})
+		}
+	}
+	roots := ssz.HashRoots(mixed, ssz.ForkUnknown)
+	for i, obj := range mixed {
+		if want := ssz.HashSequential(obj); roots[i] != want {
+			t.Fatalf("mixed obj %d: root mismatch: have %x, want %x", i, roots[i], want)
+		}
+	}
+}
+
+// Tests that batch hashing failures are raised on the calling goroutine, both
+// below and above the concurrency threshold.
+func TestHashRootsFailure(t *testing.T) {
+	for _, n := range []int{1, 10000} {
+		objs := make([]*types.ArrayOfDynamicBytesVariation, n)
+		for i := range objs {
+			objs[i] = &types.ArrayOfDynamicBytesVariation{Blobs: make([][]byte, 3)}
+		}
+		objs[n-1].Blobs = objs[n-1].Blobs[:2]
+
+		func() {
+			defer func() {
+				err, _ := recover().(error)
+				if !errors.Is(err, ssz.ErrArrayLengthMismatch) {
+					t.Errorf("n=%d: failure mismatch: have %v, want %v", n, err, ssz.ErrArrayLengthMismatch)
+				}
+			}()
+			ssz.HashRoots(objs, ssz.ForkUnknown)
+		}()
+	}
+}
+
+// Benchmarks batch hashing many small objects against hashing them one by one.
+func BenchmarkHashRoots(b *testing.B) {
+	objs := make([]*types.Validator, 65536)
+	for i := range objs {
+		objs[i] = &types.Validator{EffectiveBalance: uint64(i), ActivationEpoch: uint64(i)}
+	}
+	b.Run("sequential", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, obj := range objs {
+				ssz.HashSequential(obj)
+			}
+		}
+	})
+	b.Run("batched", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			ssz.HashRoots(objs, ssz.ForkUnknown)
+		}
+	})
+}
+
+// Tests that serialized objects can be merkleized directly from their encodings
+// using the schemas derived from their Go types, matching the roots of the
+// decoded objects across encodings, forks and nil fields.
+func TestHashRootFromBytes(t *testing.T) {
+	var (
+		b, d    = uint64(2), uint32(4)
+		history = &types.HistoricalBatchVariation{StateRoots: make([]types.Hash, types.SlotsPerHistoricalRoot)}
+		custody = &types.MultiDimArraysVariation{}
+	)
+	history.BlockRoots[1], history.StateRoots[2] = types.Hash{1}, types.Hash{2}
+	custody.Custody[1][2], custody.Flags[1][3], custody.Counts[2][4] = 1, 2, 3
+
+	for i, tt := range []struct {
+		obj  ssz.Object
+		fork ssz.Fork
+	}{
+		{&types.Attestation{
+			AggregationBits: bitfield.Bitlist{0x0f, 0x03},
+			Data:            &types.AttestationData{Slot: 1, Source: &types.Checkpoint{Epoch: 3}, Target: &types.Checkpoint{Root: types.Hash{5}}},
+		}, ssz.ForkUnknown},
+		{&types.ExecutionPayloadCapella{
+			ExtraData:     []byte{1, 2, 3},
+			BaseFeePerGas: uint256.NewInt(8),
+			Transactions:  [][]byte{{9}, {}, bytes.Repeat([]byte{10}, 100)},
+			Withdrawals:   []*types.Withdrawal{{Index: 11, Amount: 12}, {Index: 13, Address: types.Address{14}}},
+		}, ssz.ForkUnknown},
+		{&types.WithdrawalVariation{Index: 1, Address: bytes.Repeat([]byte{2}, 20)}, ssz.ForkUnknown},
+		{history, ssz.ForkUnknown},
+		{custody, ssz.ForkUnknown},
+		{&types.StringsVariation{Name: "ssz", Nonce: 1, Memo: "héllo"}, ssz.ForkUnknown},
+		{&types.MapsVariation{
+			Balances:    map[types.Address]uint64{{1}: 1, {2}: 2},
+			Withdrawals: map[uint64]*types.Withdrawal{3: {Index: 3}},
+			Extras:      map[uint64][]byte{4: {4, 4}},
+		}, ssz.ForkUnknown},
+		{&types.ValueObjectsVariation{Slot: 1, Withdrawals: []types.Withdrawal{{Index: 2}, {Index: 3}}}, ssz.ForkUnknown},
+		{&types.SignedGenericVariation[*types.BeaconBlockHeader]{Message: &types.BeaconBlockHeader{Slot: 1}}, ssz.ForkUnknown},
+		{&types.EnvelopeGenericVariation[*types.IndexedAttestation]{
+			Payload: &types.IndexedAttestation{AttestationIndices: []uint64{1, 2}},
+			Blob:    []byte{3},
+		}, ssz.ForkDeneb},
+		{&types.PackedArraysVariation{Flags: [16]uint16{1}}, ssz.ForkDeneb},
+		{&types.BitsStructMonolith{A: bitfield.Bitlist{0x03}, D: bitfield.Bitlist{0x05}}, ssz.ForkUnknown},
+		{&types.BeaconStateMonolith{Validators: []*types.Validator{{EffectiveBalance: 32}}, Balances: []uint64{32}}, ssz.ForkDeneb},
+		{&types.SidecarMonolith{Index: 1, Blob: []byte{2}}, ssz.ForkDeneb},
+		{&types.ForkRangesMonolith{A: 1, B: &b, C: []byte{3, 3, 3}, D: &d}, ssz.ForkBellatrix},
+		{&types.ForkRangesMonolith{A: 1, B: &b, C: []byte{3, 3, 3}, D: &d}, ssz.ForkElectra},
+	} {
+		blob := make([]byte, ssz.SizeOnFork(tt.obj, tt.fork))
+		if err := ssz.EncodeToBytesOnFork(blob, tt.obj, tt.fork); err != nil {
+			t.Fatalf("test %d: failed to encode %T: %v", i, tt.obj, err)
+		}
+		root, err := ssz.HashRootFromBytes(blob, reflect.New(reflect.TypeOf(tt.obj).Elem()).Interface().(ssz.Object), tt.fork)
+		if err != nil {
+			t.Errorf("test %d: failed to hash %T: %v", i, tt.obj, err)
+			continue
+		}
+		if want := ssz.HashSequentialOnFork(tt.obj, tt.fork); root != want {
+			t.Errorf("test %d: %T root mismatch: have %x, want %x", i, tt.obj, root, want)
+		}
+	}
+	// Ensure malformed blobs and objects outside of their forks are rejected
+	obj := &types.ExecutionPayloadCapella{ExtraData: []byte{1}, BaseFeePerGas: new(uint256.Int)}
+	blob := make([]byte, ssz.Size(obj))
+	ssz.EncodeToBytes(blob, obj)
+
+	if _, err := ssz.HashRootFromBytes(blob[:100], obj, ssz.ForkUnknown); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("short blob error mismatch: have %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+	if _, err := ssz.HashRootFromBytes(make([]byte, 60), new(types.SidecarMonolith), ssz.ForkCapella); !errors.Is(err, ssz.ErrObjectNotInFork) {
+		t.Errorf("fork error mismatch: have %v, want %v", err, ssz.ErrObjectNotInFork)
+	}
+}
+
+// Tests that the generated cached hashing only rehashes objects modified via the
+// generated setters, and that the embedded cache is not part of the ssz schema.
+func TestHashTreeRootCached(t *testing.T) {
+	data := &types.AttestationData{Slot: 1, Source: new(types.Checkpoint), Target: new(types.Checkpoint)}
+
+	obj := &types.CachedAttestationVariation{AggregationBits: bitfield.Bitlist{0x03}, Data: data}
+	ref := &types.Attestation{AggregationBits: bitfield.Bitlist{0x03}, Data: data}
+
+	if have, want := ssz.Size(obj), ssz.Size(ref); have != want {
+		t.Fatalf("size mismatch: have %d, want %d", have, want)
+	}
+	if have, want := obj.HashTreeRootCached(ssz.ForkUnknown), ssz.HashSequential(ref); have != want {
+		t.Fatalf("initial root mismatch: have %x, want %x", have, want)
+	}
+	// Modify the object without the setters and ensure the cached root is served
+	stale := obj.HashTreeRootCached(ssz.ForkUnknown)
+
+	obj.Signature[0] = 1
+	if have := obj.HashTreeRootCached(ssz.ForkUnknown); have != stale {
+		t.Errorf("cached root not served: have %x, want %x", have, stale)
+	}
+	// Modify the object via the setters and ensure the root is recomputed
+	ref.Signature[0], ref.Signature[1] = 1, 2
+	obj.SetSignature(ref.Signature)
+
+	if have, want := obj.HashTreeRootCached(ssz.ForkUnknown), ssz.HashSequential(ref); have != want {
+		t.Errorf("updated root mismatch: have %x, want %x", have, want)
+	}
+	if gen := obj.Generation(); gen != 1 {
+		t.Errorf("generation mismatch: have %d, want %d", gen, 1)
+	}
+}
+
+// Tests that hashing objects with broken DefineSSZ methods reports an error via
+// HashRoot instead of panicking.
+func TestUnbalancedHashing(t *testing.T) {
+	for _, obj := range []ssz.Object{new(testEmptyContainerType), &testEmptyNestedType{Nested: new(testEmptyContainerType)}} {
+		if _, err := ssz.HashRoot(obj); !errors.Is(err, ssz.ErrUnbalancedHashing) {
+			t.Errorf("%T: hashing error mismatch: have %v, want %v", obj, err, ssz.ErrUnbalancedHashing)
+		}
+	}
+	// Ensure balanced objects hash the same as via the legacy API
+	obj := &types.Withdrawal{Index: 1, Validator: 2, Amount: 3}
+
+	root, err := ssz.HashRoot(obj)
+	if err != nil {
+		t.Fatalf("failed to hash object: %v", err)
+	}
+	if want := ssz.HashSequential(obj); root != want {
+		t.Errorf("root mismatch: have %x, want %x", root, want)
+	}
+}
+
+type testEmptyContainerType struct{}
+
+func (t *testEmptyContainerType) SizeSSZ(sizer *ssz.Sizer) uint32 { return 0 }
+func (t *testEmptyContainerType) DefineSSZ(codec *ssz.Codec)      {}
+
+type testEmptyNestedType struct {
+	Value  uint64
+	Nested *testEmptyContainerType
+}
+
+func (t *testEmptyNestedType) SizeSSZ(sizer *ssz.Sizer) uint32 { return 8 }
+func (t *testEmptyNestedType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &t.Value)
+	ssz.DefineStaticObject(codec, &t.Nested)
+}
+
+// Tests that the exported chunk math helpers match the hasher's behavior.
+func TestChunkMath(t *testing.T) {
+	for _, tt := range []struct{ n, want uint64 }{{0, 1}, {1, 1}, {2, 2}, {3, 4}, {4, 4}, {5, 8}, {1 << 40, 1 << 40}, {1<<40 + 1, 1 << 41}} {
+		if have := ssz.NextPowerOfTwo(tt.n); have != tt.want {
+			t.Errorf("next power of two of %d mismatch: have %d, want %d", tt.n, have, tt.want)
+		}
+	}
+	if have := ssz.ChunkCountOfList(2048, 1); have != 64 {
+		t.Errorf("byte list chunk count mismatch: have %d, want %d", have, 64)
+	}
+	if have := ssz.ChunkCountOfList(16, ssz.BytesPerChunk); have != 16 {
+		t.Errorf("composite list chunk count mismatch: have %d, want %d", have, 16)
+	}
+	// Merkleize a list of uint64s manually via the helpers and compare the root
+	obj := &types.ForkLimitsVariation{List: []uint64{1, 2, 3, 4, 5}}
+
+	leaves := make([][32]byte, ssz.NextPowerOfTwo(ssz.ChunkCountOfList(8, 8)))
+	for i, n := range obj.List {
+		binary.LittleEndian.PutUint64(leaves[i/4][i%4*8:], n)
+	}
+	for len(leaves) > 1 {
+		for i := 0; i < len(leaves)/2; i++ {
+			leaves[i] = sha256.Sum256(append(leaves[2*i][:], leaves[2*i+1][:]...))
+		}
+		leaves = leaves[:len(leaves)/2]
+	}
+	var mixin [ssz.BytesPerChunk]byte
+	binary.LittleEndian.PutUint64(mixin[:], uint64(len(obj.List)))
+	want := sha256.Sum256(append(leaves[0][:], mixin[:]...))
+
+	if have := ssz.HashSequentialOnFork(obj, ssz.ForkDeneb); have != want {
+		t.Errorf("root mismatch: have %x, want %x", have, want)
+	}
+	if have := ssz.SizeOnFork(obj, ssz.ForkDeneb); have != ssz.OffsetSize+5*8 {
+		t.Errorf("size mismatch: have %d, want %d", have, ssz.OffsetSize+5*8)
+	}
+}
+
+// Tests that large slices of dynamic objects and dynamic blobs are hashed the
+// same way concurrently as sequentially, including in monolithic contexts.
+func TestConcurrentDynamicSlices(t *testing.T) {
+	var (
+		txs      [][]byte
+		payloads []*types.ExecutionPayloadDeneb
+	)
+	for i := 0; i < 300; i++ {
+		txs = append(txs, bytes.Repeat([]byte{byte(i)}, 1+i*3))
+	}
+	for i := 0; i < 9; i++ {
+		payloads = append(payloads, &types.ExecutionPayloadDeneb{
+			BlockNumber:   uint64(i),
+			BaseFeePerGas: uint256.NewInt(uint64(i)),
+			Transactions:  txs[i*30 : (i+1)*30],
+			BlobGasUsed:   uint64(i),
+		})
+	}
+	for _, obj := range []ssz.Object{
+		&types.ExecutionPayloadDeneb{BaseFeePerGas: new(uint256.Int), Transactions: txs},
+		&types.DynamicObjectsVariation{Payloads: payloads},
+	} {
+		if have, want := ssz.HashConcurrentOnFork(obj, ssz.ForkDeneb), ssz.HashSequentialOnFork(obj, ssz.ForkDeneb); have != want {
+			t.Errorf("%T: root mismatch: have %x, want %x", obj, have, want)
+		}
+	}
+}
+
+// Tests that lists and vectors of roots hashed via the bulk fast path produce the
+// same roots as hashing them chunk by chunk.
+func TestHashRootsBulk(t *testing.T) {
+	for _, items := range []int{0, 1, 255, 256, 257, 513, 1000, 2048} {
+		obj := &types.RootsListVariation{Roots: make([][32]byte, items)}
+		for i := range obj.Roots {
+			binary.LittleEndian.PutUint64(obj.Roots[i][:], uint64(i+1))
+			obj.Roots[i][31] = byte(i)
+		}
+		// Merkleize the roots manually, padded to the list limit, mixing in the length
+		layer := append([][32]byte{}, obj.Roots...)
+		for depth := 0; depth < 12; depth++ { // 4096 limit
+			if len(layer)%2 == 1 {
+				layer = append(layer, ssz.ZeroHash(depth))
+			}
+			next := make([][32]byte, 0, len(layer)/2)
+			for i := 0; i < len(layer); i += 2 {
+				next = append(next, sha256.Sum256(append(layer[i][:], layer[i+1][:]...)))
+			}
+			layer = next
+			if len(layer) == 0 {
+				layer = [][32]byte{ssz.ZeroHash(depth + 1)}
+			}
+		}
+		var mixin [32]byte
+		binary.LittleEndian.PutUint64(mixin[:], uint64(items))
+		want := sha256.Sum256(append(layer[0][:], mixin[:]...))
+
+		if have := ssz.HashSequential(obj); have != want {
+			t.Errorf("items %d: sequential root mismatch: have %x, want %x", items, have, want)
+		}
+		if have := ssz.HashSequentialWithBackend(obj, testSHA256Backend{}); have != want {
+			t.Errorf("items %d: backend root mismatch: have %x, want %x", items, have, want)
+		}
+		// Proof collection disables the bulk path, the root must still match
+		if have, _ := ssz.HashSequentialWithProofOnFork(obj, ssz.ForkUnknown, 2); have != want {
+			t.Errorf("items %d: proof root mismatch: have %x, want %x", items, have, want)
+		}
+	}
+}
+
+// Tests that lists of uint64s hashed directly from their backing array produce
+// the same roots as packing them chunk by chunk.
+func TestHashUint64sBulk(t *testing.T) {
+	for _, items := range []int{0, 3, 4, 5, 1023, 1024, 1025, 4000} {
+		obj := &types.Uint64sListVariation{Nums: make([]uint64, items)}
+		swapped := &types.Uint64sListVariation{Nums: make([]uint64, items)}
+		for i := range obj.Nums {
+			obj.Nums[i] = uint64(i+1)*0x0101010101 + uint64(i)<<56
+			swapped.Nums[i] = bitops.ReverseBytes64(obj.Nums[i])
+		}
+		// Proof collection disables the bulk path, the root must still match
+		want, _ := ssz.HashSequentialWithProofOnFork(obj, ssz.ForkUnknown, 2)
+		if have := ssz.HashSequential(obj); have != want {
+			t.Errorf("items %d: sequential root mismatch: have %x, want %x", items, have, want)
+		}
+		// Big-endian profiles cannot use the in-memory layout, but byte swapped
+		// numbers must serialize (and hash) identically
+		if have := ssz.BigEndianProfile.HashSequential(swapped); have != want {
+			t.Errorf("items %d: big-endian root mismatch: have %x, want %x", items, have, want)
+		}
+	}
+}
+
+// Tests that context aware concurrent hashing matches the plain one, and that it
+// aborts with the context's error if cancelled.
+func TestHashConcurrentCtx(t *testing.T) {
+	state := &types.BeaconState{Validators: make([]*types.Validator, 4096)}
+	for i := range state.Validators {
+		state.Validators[i] = &types.Validator{EffectiveBalance: uint64(i)}
+	}
+	root, err := ssz.HashConcurrentCtx(context.Background(), state)
+	if err != nil {
+		t.Fatalf("failed to hash state: %v", err)
+	}
+	if want := ssz.HashConcurrent(state); root != want {
+		t.Errorf("root mismatch: have %#x, want %#x", root, want)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ssz.HashConcurrentCtx(ctx, state); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancellation error mismatch: have %v, want %v", err, context.Canceled)
+	}
+	// Cancelling halfway through hashing must either abort with the context's
+	// error, or finish with the correct root if the workers were faster
+	for delay := time.Duration(0); delay < 2*time.Millisecond; delay += 100 * time.Microsecond {
+		ctx, cancel := context.WithTimeout(context.Background(), delay)
+		have, err := ssz.HashConcurrentCtx(ctx, state)
+		cancel()
+
+		switch {
+		case err != nil && !errors.Is(err, context.DeadlineExceeded):
+			t.Errorf("delay %v: cancellation error mismatch: have %v, want %v", delay, err, context.DeadlineExceeded)
+		case err == nil && have != root:
+			t.Errorf("delay %v: root mismatch: have %#x, want %#x", delay, have, root)
+		}
+	}
+	// The cancellation must not leak into subsequent hashes via pooled hashers
+	if have, want := ssz.HashConcurrent(state), ssz.HashSequential(state); have != want {
+		t.Errorf("root mismatch after cancellation: have %#x, want %#x", have, want)
+	}
+	// Invalid objects must be reported as errors, not panics
+	payload := &types.ExecutionPayload{ExtraData: make([]byte, 33)}
+	if _, err := ssz.HashConcurrentCtx(context.Background(), payload); !errors.Is(err, ssz.ErrMaxLengthExceeded) {
+		t.Errorf("invalid object error mismatch: have %v, want %v", err, ssz.ErrMaxLengthExceeded)
+	}
+}
+
+// Tests that encoding and hashing in one go produces the same results as doing
+// them separately, and that encoding failures are reported.
+func TestEncodeToStreamAndHash(t *testing.T) {
+	state := &types.BeaconState{
+		Validators: []*types.Validator{{EffectiveBalance: 1}, {EffectiveBalance: 2}},
+		Balances:   []uint64{3, 4},
+	}
+	var buf bytes.Buffer
+	root, err := ssz.EncodeToStreamAndHash(&buf, state)
+	if err != nil {
+		t.Fatalf("failed to encode and hash state: %v", err)
+	}
+	want := make([]byte, ssz.Size(state))
+	if err := ssz.EncodeToBytes(want, state); err != nil {
+		t.Fatalf("failed to encode state: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("encoding mismatch")
+	}
+	if have := ssz.HashSequential(state); root != have {
+		t.Errorf("root mismatch: have %#x, want %#x", root, have)
+	}
+	// Write failures should be propagated
+	if _, err := ssz.EncodeToStreamAndHash(failingWriter{}, state); !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("write error mismatch: have %v, want %v", err, io.ErrClosedPipe)
+	}
+}
+
+// failingWriter is an io.Writer rejecting all writes.
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, io.ErrClosedPipe
+}
+
+// Tests that the field roots of an object are the leaves it is merkleized from,
+// tracking the fields active in the different forks.
+func TestFieldRoots(t *testing.T) {
+	state := &types.BeaconStateMonolith{GenesisTime: 1, Slot: 7, Balances: []uint64{32, 31}}
+
+	for _, tt := range []struct {
+		fork   ssz.Fork
+		fields int
+	}{
+		{ssz.ForkPhase0, 21},
+		{ssz.ForkAltair, 24},
+		{ssz.ForkBellatrix, 25},
+		{ssz.ForkCapella, 28},
+		{ssz.ForkDeneb, 28},
+	} {
+		roots := ssz.FieldRootsOnFork(state, tt.fork)
+		if len(roots) != tt.fields {
+			t.Errorf("fork %v: field count mismatch: have %d, want %d", tt.fork, len(roots), tt.fields)
+			continue
+		}
+		var slot [32]byte
+		binary.LittleEndian.PutUint64(slot[:], state.Slot)
+		if roots[2] != slot {
+			t.Errorf("fork %v: slot root mismatch: have %x, want %x", tt.fork, roots[2], slot)
+		}
+		// Merkleize the field roots and check them against the object root
+		layer := append([][32]byte{}, roots...)
+		for len(layer)&(len(layer)-1) != 0 {
+			layer = append(layer, [32]byte{})
+		}
+		for len(layer) > 1 {
+			for i := 0; i < len(layer)/2; i++ {
+				layer[i] = sha256.Sum256(append(layer[2*i][:], layer[2*i+1][:]...))
+			}
+			layer = layer[:len(layer)/2]
+		}
+		if root := ssz.HashSequentialOnFork(state, tt.fork); layer[0] != root {
+			t.Errorf("fork %v: merkleized root mismatch: have %x, want %x", tt.fork, layer[0], root)
+		}
+		// Field roots should be provable against the object root
+		root, branch := ssz.HashSequentialWithProofOnFork(state, tt.fork, 32+12)
+		if !ssz.VerifyProof(root, roots[12], branch, 32+12) {
+			t.Errorf("fork %v: balances root not provable", tt.fork)
+		}
+	}
+}
+
+// Tests that lists and blobs exceeding their limits are rejected by the hasher
+// instead of being merkleized into a corrupt root.
+func TestHashLimitsExceeded(t *testing.T) {
+	withdrawals := make([]*types.Withdrawal, 17)
+	for i := range withdrawals {
+		withdrawals[i] = &types.Withdrawal{Index: uint64(i)}
+	}
+	for _, test := range []struct {
+		obj ssz.Object
+		err error
+	}{
+		{&types.ExecutionPayloadCapella{ExtraData: []byte{}, Withdrawals: withdrawals}, ssz.ErrMaxItemsExceeded},
+		{&types.ExecutionPayloadCapella{ExtraData: make([]byte, 33)}, ssz.ErrMaxLengthExceeded},
+		{&types.Attestation{AggregationBits: append(make([]byte, 256), 0x02)}, ssz.ErrMaxLengthExceeded},
+	} {
+		if _, err := ssz.HashRoot(test.obj); !errors.Is(err, test.err) {
+			t.Errorf("%T: hashing error mismatch: have %v, want %v", test.obj, err, test.err)
+		}
+	}
+	// Oversized lists hashed concurrently must also be detected
+	obj := &types.ExecutionPayloadCapella{ExtraData: []byte{}, Withdrawals: make([]*types.Withdrawal, 5000)}
+	for i := range obj.Withdrawals {
+		obj.Withdrawals[i] = &types.Withdrawal{Index: uint64(i)}
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("concurrent hashing of oversized list did not panic")
+		}
+	}()
+	ssz.HashConcurrent(obj)
+}
@@ -0,0 +1,77 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that the encoding and decoding hooks of top level objects are invoked,
+// and that their errors abort the operations.
+func TestEncodeDecodeHooks(t *testing.T) {
+	obj := &testHookedCheckpoint{Checkpoint: types.Checkpoint{Epoch: 1}}
+
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	if err := ssz.EncodeToStream(new(bytes.Buffer), obj); err != nil {
+		t.Fatalf("failed to stream encode object: %v", err)
+	}
+	if obj.encodes != 2 {
+		t.Errorf("encode hook calls mismatch: have %d, want 2", obj.encodes)
+	}
+	dec := new(testHookedCheckpoint)
+	if err := ssz.DecodeFromBytes(blob, dec); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	if err := ssz.DecodeFromStream(bytes.NewReader(blob), dec, uint32(len(blob))); err != nil {
+		t.Fatalf("failed to stream decode object: %v", err)
+	}
+	if dec.decodes != 2 {
+		t.Errorf("decode hook calls mismatch: have %d, want 2", dec.decodes)
+	}
+	// Failing hooks must abort the operations
+	obj.Epoch, dec.Epoch = 0, 0
+	if err := ssz.EncodeToBytes(blob, obj); !errors.Is(err, errTestZeroEpoch) {
+		t.Errorf("encode error mismatch: have %v, want %v", err, errTestZeroEpoch)
+	}
+	if err := ssz.DecodeFromBytes(make([]byte, len(blob)), dec); !errors.Is(err, errTestZeroEpoch) {
+		t.Errorf("decode error mismatch: have %v, want %v", err, errTestZeroEpoch)
+	}
+	if err := ssz.DecodeFromStream(bytes.NewReader(make([]byte, len(blob))), dec, uint32(len(blob))); !errors.Is(err, errTestZeroEpoch) {
+		t.Errorf("stream decode error mismatch: have %v, want %v", err, errTestZeroEpoch)
+	}
+}
+
+var errTestZeroEpoch = errors.New("zero epoch")
+
+// testHookedCheckpoint is a checkpoint counting its encoding and decoding hook
+// invocations, rejecting zero epochs.
+type testHookedCheckpoint struct {
+	types.Checkpoint
+	encodes int
+	decodes int
+}
+
+func (t *testHookedCheckpoint) BeforeSSZEncode() error {
+	t.encodes++
+	if t.Epoch == 0 {
+		return errTestZeroEpoch
+	}
+	return nil
+}
+func (t *testHookedCheckpoint) AfterSSZDecode() error {
+	t.decodes++
+	if t.Epoch == 0 {
+		return errTestZeroEpoch
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/holiman/uint256"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that list readers iterate over the items of encoded lists of static and
+// dynamic objects one by one, and that malformed lists are rejected.
+func TestListReader(t *testing.T) {
+	// Static objects are concatenated back to back
+	withdrawals := []*types.Withdrawal{{Index: 1}, {Index: 2, Amount: 3}, {Index: 4}}
+
+	var stream []byte
+	for _, withdrawal := range withdrawals {
+		blob := make([]byte, ssz.Size(withdrawal))
+		if err := ssz.EncodeToBytes(blob, withdrawal); err != nil {
+			t.Fatalf("failed to encode withdrawal: %v", err)
+		}
+		stream = append(stream, blob...)
+	}
+	wr := ssz.NewListReader[*types.Withdrawal](bytes.NewReader(stream), ssz.ForkUnknown)
+	for i, want := range withdrawals {
+		have, err := wr.Next()
+		if err != nil {
+			t.Fatalf("failed to read withdrawal %d: %v", i, err)
+		}
+		if *have != *want {
+			t.Errorf("withdrawal %d mismatch: have %+v, want %+v", i, have, want)
+		}
+	}
+	if _, err := wr.Next(); err != io.EOF {
+		t.Errorf("end of static list error mismatch: have %v, want %v", err, io.EOF)
+	}
+	wr = ssz.NewListReader[*types.Withdrawal](bytes.NewReader(stream[:len(stream)-1]), ssz.ForkUnknown)
+	for i := 0; i < len(withdrawals)-1; i++ {
+		if _, err := wr.Next(); err != nil {
+			t.Fatalf("failed to read withdrawal %d: %v", i, err)
+		}
+	}
+	if _, err := wr.Next(); err == nil || err == io.EOF {
+		t.Errorf("truncated static list accepted: %v", err)
+	}
+	// Dynamic objects are preceded by their offsets
+	payloads := []*types.ExecutionPayload{
+		{BlockNumber: 1, ExtraData: []byte{0x01}, BaseFeePerGas: uint256.NewInt(1)},
+		{BlockNumber: 2, BaseFeePerGas: uint256.NewInt(2), Transactions: [][]byte{{0x02}, {0x03, 0x04}}},
+		{BlockNumber: 3, ExtraData: []byte{0x05, 0x06}, BaseFeePerGas: uint256.NewInt(3)},
+	}
+	var (
+		table []byte
+		items []byte
+	)
+	for _, payload := range payloads {
+		table = binary.LittleEndian.AppendUint32(table, uint32(4*len(payloads)+len(items)))
+
+		blob := make([]byte, ssz.Size(payload))
+		if err := ssz.EncodeToBytes(blob, payload); err != nil {
+			t.Fatalf("failed to encode payload: %v", err)
+		}
+		items = append(items, blob...)
+	}
+	stream = append(table, items...)
+
+	pr := ssz.NewListReader[*types.ExecutionPayload](bytes.NewReader(stream), ssz.ForkUnknown)
+	for i, want := range payloads {
+		have, err := pr.Next()
+		if err != nil {
+			t.Fatalf("failed to read payload %d: %v", i, err)
+		}
+		if ssz.HashSequential(have) != ssz.HashSequential(want) {
+			t.Errorf("payload %d mismatch: have %+v, want %+v", i, have, want)
+		}
+	}
+	if _, err := pr.Next(); err != io.EOF {
+		t.Errorf("end of dynamic list error mismatch: have %v, want %v", err, io.EOF)
+	}
+	if _, err := ssz.NewListReader[*types.ExecutionPayload](bytes.NewReader(nil), ssz.ForkUnknown).Next(); err != io.EOF {
+		t.Errorf("empty dynamic list error mismatch: have %v, want %v", err, io.EOF)
+	}
+	// Malformed offsets must be rejected, and the failure must be sticky
+	bad := append([]byte{}, stream...)
+	binary.LittleEndian.PutUint32(bad[4:], 0)
+
+	pr = ssz.NewListReader[*types.ExecutionPayload](bytes.NewReader(bad), ssz.ForkUnknown)
+	if _, err := pr.Next(); !errors.Is(err, ssz.ErrBadOffsetProgression) {
+		t.Errorf("bad offset error mismatch: have %v, want %v", err, ssz.ErrBadOffsetProgression)
+	}
+	if _, err := pr.Next(); !errors.Is(err, ssz.ErrBadOffsetProgression) {
+		t.Errorf("sticky error mismatch: have %v, want %v", err, ssz.ErrBadOffsetProgression)
+	}
+	if _, err := ssz.NewListReader[*types.ExecutionPayload](bytes.NewReader([]byte{0xfc, 0xff, 0xff, 0x7f}), ssz.ForkUnknown).Next(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("bogus counter error mismatch: have %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
@@ -0,0 +1,85 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"encoding/binary"
+	bitops "math/bits"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that the big-endian profile only swaps the byte order of basic values,
+// leaving offsets and list length mixins untouched; and that it does not leak
+// into the standard codec functions.
+func TestBigEndianProfile(t *testing.T) {
+	obj := &types.IndexedAttestation{
+		AttestationIndices: []uint64{0x0102030405060708},
+		Data:               &types.AttestationData{Slot: 0x1122, Source: new(types.Checkpoint), Target: new(types.Checkpoint)},
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.BigEndianProfile.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	if have, want := binary.LittleEndian.Uint32(blob), ssz.Size(obj)-8; have != want {
+		t.Errorf("offset mismatch: have %d, want %d", have, want)
+	}
+	if have, want := binary.BigEndian.Uint64(blob[len(blob)-8:]), obj.AttestationIndices[0]; have != want {
+		t.Errorf("list item mismatch: have %#x, want %#x", have, want)
+	}
+	if have, want := types.Slot(binary.BigEndian.Uint64(blob[4:])), obj.Data.Slot; have != want {
+		t.Errorf("slot mismatch: have %#x, want %#x", have, want)
+	}
+	// Ensure the stream encoder produces the same output and that both decoders
+	// can parse it back
+	buf := new(bytes.Buffer)
+	if err := ssz.BigEndianProfile.EncodeToStream(buf, obj); err != nil {
+		t.Fatalf("failed to stream encode object: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), blob) {
+		t.Errorf("stream encoding mismatch: have %x, want %x", buf.Bytes(), blob)
+	}
+	dec := new(types.IndexedAttestation)
+	if err := ssz.BigEndianProfile.DecodeFromBytes(blob, dec); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	if dec.AttestationIndices[0] != obj.AttestationIndices[0] || dec.Data.Slot != obj.Data.Slot {
+		t.Errorf("decoded object mismatch: have %v, want %v", dec, obj)
+	}
+	dec = new(types.IndexedAttestation)
+	if err := ssz.BigEndianProfile.DecodeFromStream(bytes.NewReader(blob), dec, uint32(len(blob))); err != nil {
+		t.Fatalf("failed to stream decode object: %v", err)
+	}
+	if dec.AttestationIndices[0] != obj.AttestationIndices[0] || dec.Data.Slot != obj.Data.Slot {
+		t.Errorf("stream decoded object mismatch: have %v, want %v", dec, obj)
+	}
+	// Hashing big-endian should be equivalent to hashing the byte-swapped values
+	// little-endian
+	swapped := &types.IndexedAttestation{
+		AttestationIndices: []uint64{bitops.ReverseBytes64(obj.AttestationIndices[0])},
+		Data:               &types.AttestationData{Slot: types.Slot(bitops.ReverseBytes64(uint64(obj.Data.Slot))), Source: new(types.Checkpoint), Target: new(types.Checkpoint)},
+	}
+	want := ssz.HashSequential(swapped)
+	if have := ssz.BigEndianProfile.HashSequential(obj); have != want {
+		t.Errorf("sequential root mismatch: have %x, want %x", have, want)
+	}
+	if have := ssz.BigEndianProfile.HashConcurrent(obj); have != want {
+		t.Errorf("concurrent root mismatch: have %x, want %x", have, want)
+	}
+	// Ensure the standard functions are still little-endian after profile use
+	std := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(std, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	if have, want := binary.LittleEndian.Uint64(std[len(std)-8:]), obj.AttestationIndices[0]; have != want {
+		t.Errorf("standard list item mismatch: have %#x, want %#x", have, want)
+	}
+	if have := ssz.HashSequential(obj); have == want {
+		t.Errorf("standard root matches big-endian one: %x", have)
+	}
+}
@@ -0,0 +1,172 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	bitops "math/bits"
+	"slices"
+	"testing"
+
+	"github.com/holiman/uint256"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that merkle proofs can be collected while hashing, both for leaves and
+// inner nodes, at any depth within nested objects and lists.
+func TestHashWithProof(t *testing.T) {
+	// Prove a header field out of a beacon state, checking against a known leaf
+	state := &types.BeaconState{
+		Slot:              10,
+		LatestBlockHeader: &types.BeaconBlockHeader{Slot: 9, ProposerIndex: 3, StateRoot: types.Hash{1}},
+	}
+	root, branch := ssz.HashSequentialWithProofOnFork(state, ssz.ForkUnknown, 36)
+	if root != ssz.HashSequential(state) {
+		t.Fatalf("proof root mismatch: have %x, want %x", root, ssz.HashSequential(state))
+	}
+	if !ssz.VerifyProof(root, ssz.HashSequential(state.LatestBlockHeader), branch, 36) {
+		t.Fatalf("invalid header proof")
+	}
+	// Prove a basic field leaf within the header too
+	var slot [32]byte
+	binary.LittleEndian.PutUint64(slot[:], 9)
+
+	_, branch = ssz.HashSequentialWithProofOnFork(state, ssz.ForkUnknown, 36<<3)
+	if !ssz.VerifyProof(root, slot, branch, 36<<3) {
+		t.Fatalf("invalid header slot proof")
+	}
+	// Walk an entire payload trie (including list mixins and zero padding) and
+	// check that all the proofs of parent and child nodes are consistent
+	payload := &types.ExecutionPayload{
+		BlockNumber:   7,
+		ExtraData:     bytes.Repeat([]byte{0xff}, 32),
+		BaseFeePerGas: new(uint256.Int),
+		Transactions:  [][]byte{{1, 2, 3}, bytes.Repeat([]byte{4}, 100)},
+	}
+	root = ssz.HashSequential(payload)
+
+	gindices := []uint64{1, 22, 29, 58 << 20, 58<<20 + 1, 59, (58 << 20) << 26, ((58<<20 + 1) << 26) + 2}
+	for i := uint64(2); i < 1024; i++ {
+		gindices = append(gindices, i)
+	}
+	for _, gindex := range gindices {
+		have, branch := ssz.HashSequentialWithProof(payload, gindex)
+		if have != root {
+			t.Fatalf("gindex %d: root mismatch: have %x, want %x", gindex, have, root)
+		}
+		if _, forked := ssz.HashSequentialWithProofOnFork(payload, ssz.ForkUnknown, gindex); !slices.Equal(forked, branch) {
+			t.Fatalf("gindex %d: fork agnostic branch mismatch: have %x, want %x", gindex, branch, forked)
+		}
+		if branch == nil {
+			continue // not an internal node (e.g. inside a basic field)
+		}
+		if len(branch) != bitops.Len64(gindex)-1 {
+			t.Fatalf("gindex %d: branch length mismatch: have %d, want %d", gindex, len(branch), bitops.Len64(gindex)-1)
+		}
+		// If the node has children, reconstruct it from them and verify the proof
+		_, left := ssz.HashSequentialWithProof(payload, 2*gindex+1)
+		_, right := ssz.HashSequentialWithProof(payload, 2*gindex)
+		if left == nil || right == nil {
+			continue
+		}
+		node := sha256.Sum256(append(left[0][:], right[0][:]...))
+		if !ssz.VerifyProof(root, node, branch, gindex) {
+			t.Fatalf("gindex %d: invalid proof", gindex)
+		}
+	}
+	// Gindices pointing into basic fields should not be provable
+	if _, branch := ssz.HashSequentialWithProof(payload, 22<<1); branch != nil {
+		t.Fatalf("proof into basic field returned: %x", branch)
+	}
+	// Monolith types must be proven against the trie shape of the requested fork
+	blobGasUsed, excessBlobGas := uint64(1), uint64(2)
+	monolith := &types.ExecutionPayloadMonolith{
+		BlockNumber:   7,
+		ExtraData:     []byte{},
+		BaseFeePerGas: new(uint256.Int),
+		Withdrawals:   []*types.Withdrawal{{Index: 1}},
+		BlobGasUsed:   &blobGasUsed,
+		ExcessBlobGas: &excessBlobGas,
+	}
+	var number [32]byte
+	binary.LittleEndian.PutUint64(number[:], monolith.BlockNumber)
+
+	for _, tt := range []struct {
+		fork  ssz.Fork
+		width uint64 // Leaf count of the top level trie in the fork
+	}{
+		{ssz.ForkParis, 16},
+		{ssz.ForkShanghai, 16},
+		{ssz.ForkCancun, 32},
+	} {
+		root, branch := ssz.HashSequentialWithProofOnFork(monolith, tt.fork, tt.width+6)
+		if want := ssz.HashSequentialOnFork(monolith, tt.fork); root != want {
+			t.Errorf("fork %v: root mismatch: have %x, want %x", tt.fork, root, want)
+		}
+		if !ssz.VerifyProof(root, number, branch, tt.width+6) {
+			t.Errorf("fork %v: invalid block number proof", tt.fork)
+		}
+	}
+	// Fields only present in later forks should be provable in them
+	var excess [32]byte
+	binary.LittleEndian.PutUint64(excess[:], excessBlobGas)
+
+	root, branch = ssz.HashSequentialWithProofOnFork(monolith, ssz.ForkCancun, 32+16)
+	if !ssz.VerifyProof(root, excess, branch, 32+16) {
+		t.Errorf("invalid excess blob gas proof")
+	}
+}
+
+// Tests that the merkle proof verifier accepts the proofs collected while hashing
+// and rejects any tampered or malformed ones.
+func TestVerifyProof(t *testing.T) {
+	state := &types.BeaconState{
+		Slot:              10,
+		LatestBlockHeader: &types.BeaconBlockHeader{Slot: 9, ProposerIndex: 3, StateRoot: types.Hash{1}},
+	}
+	leaf := ssz.HashSequential(state.LatestBlockHeader)
+
+	root, branch := ssz.HashSequentialWithProofOnFork(state, ssz.ForkUnknown, 36)
+	if !ssz.VerifyProof(root, leaf, branch, 36) {
+		t.Fatalf("valid proof rejected")
+	}
+	if ssz.VerifyProof(root, [32]byte{1}, branch, 36) {
+		t.Errorf("proof of wrong leaf accepted")
+	}
+	if ssz.VerifyProof(root, leaf, branch, 37) {
+		t.Errorf("proof at wrong gindex accepted")
+	}
+	if ssz.VerifyProof(root, leaf, branch[:len(branch)-1], 36) {
+		t.Errorf("truncated proof accepted")
+	}
+	if ssz.VerifyProof(root, leaf, nil, 0) {
+		t.Errorf("proof at zero gindex accepted")
+	}
+	branch[0][0] ^= 1
+	if ssz.VerifyProof(root, leaf, branch, 36) {
+		t.Errorf("tampered proof accepted")
+	}
+	// The root itself is trivially provable with an empty branch
+	if !ssz.VerifyProof(root, root, nil, 1) {
+		t.Errorf("root proof rejected")
+	}
+	branch[0][0] ^= 1
+
+	// Custom backends must be used for the inner hashing, and their failures reported
+	if ok, err := ssz.VerifyProofWithBackend(root, leaf, branch, 36, testSHA256Backend{}); !ok || err != nil {
+		t.Errorf("valid proof rejected by equivalent backend: %v, %v", ok, err)
+	}
+	if ok, err := ssz.VerifyProofWithBackend(root, leaf, branch, 36, testTaggedBackend{}); ok || err != nil {
+		t.Errorf("valid proof accepted by different backend: %v, %v", ok, err)
+	}
+	if _, err := ssz.VerifyProofWithBackend(root, leaf, branch, 36, &testFailingBackend{}); !errors.Is(err, ssz.ErrHasherBackendFailed) {
+		t.Errorf("backend error mismatch: have %v, want %v", err, ssz.ErrHasherBackendFailed)
+	}
+}
@@ -0,0 +1,11 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build race
+
+package tests
+
+// raceEnabled is set if the tests are running with the race detector, which
+// randomly drops pooled objects and thus makes allocation counts meaningless.
+const raceEnabled = true
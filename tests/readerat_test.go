@@ -0,0 +1,91 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// countingReaderAt is a random access source counting the bytes read from it.
+type countingReaderAt struct {
+	src  *bytes.Reader
+	read int
+}
+
+func (r *countingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	n, err := r.src.ReadAt(p, off)
+	r.read += n
+	return n, err
+}
+
+// Tests that decoding from a random access source seeks past the masked fields
+// without reading them, and that full decodes match the buffered ones.
+func TestDecodeFromReaderAt(t *testing.T) {
+	state := &types.BeaconState{
+		Validators:          []*types.Validator{{EffectiveBalance: 1}, {EffectiveBalance: 2}},
+		Balances:            []uint64{3, 4},
+		FinalizedCheckpoint: &types.Checkpoint{Epoch: 5},
+	}
+	state.RandaoMixes[0][0] = 6
+
+	blob := make([]byte, ssz.Size(state))
+	if err := ssz.EncodeToBytes(blob, state); err != nil {
+		t.Fatalf("failed to encode state: %v", err)
+	}
+	// Decode the entire state and ensure it matches the original
+	full := new(types.BeaconState)
+	if err := ssz.DecodeFromReaderAt(bytes.NewReader(blob), int64(len(blob)), full); err != nil {
+		t.Fatalf("failed to decode state: %v", err)
+	}
+	want := new(types.BeaconState)
+	if err := ssz.DecodeFromBytes(blob, want); err != nil {
+		t.Fatalf("failed to decode state from bytes: %v", err)
+	}
+	if !reflect.DeepEqual(full, want) {
+		t.Errorf("decoded state mismatch")
+	}
+	// Decode only a few fields and ensure the large ones were not read
+	src := &countingReaderAt{src: bytes.NewReader(blob)}
+
+	have := new(types.BeaconState)
+	if err := ssz.DecodeFieldsFromReaderAt(src, int64(len(blob)), have, ssz.FieldMask{"Validators", "Balances", "FinalizedCheckpoint"}); err != nil {
+		t.Fatalf("failed to decode masked state: %v", err)
+	}
+	if !reflect.DeepEqual(have.Validators, state.Validators) {
+		t.Errorf("validators mismatch: have %v, want %v", have.Validators, state.Validators)
+	}
+	if !reflect.DeepEqual(have.Balances, state.Balances) {
+		t.Errorf("balances mismatch: have %v, want %v", have.Balances, state.Balances)
+	}
+	if have.FinalizedCheckpoint == nil || have.FinalizedCheckpoint.Epoch != 5 {
+		t.Errorf("finalized checkpoint mismatch: have %v, want %v", have.FinalizedCheckpoint, state.FinalizedCheckpoint)
+	}
+	if have.RandaoMixes[0][0] != 0 {
+		t.Errorf("unselected static fields decoded")
+	}
+	if src.read >= len(blob)/2 {
+		t.Errorf("masked decode read too much: have %d, total %d", src.read, len(blob))
+	}
+	// Truncated data and unknown fields should be rejected
+	if err := ssz.DecodeFieldsFromReaderAt(bytes.NewReader(blob[:len(blob)-1]), int64(len(blob)-1), new(types.BeaconState), ssz.FieldMask{"Slot"}); err == nil {
+		t.Errorf("truncated masked decoding succeeded")
+	}
+	if err := ssz.DecodeFieldsFromReaderAt(bytes.NewReader(blob), int64(len(blob)), new(types.BeaconState), ssz.FieldMask{"Unknown"}); !errors.Is(err, ssz.ErrUnknownField) {
+		t.Errorf("unknown field error mismatch: have %v, want %v", err, ssz.ErrUnknownField)
+	}
+	// Negative sizes should be rejected instead of crashing
+	if err := ssz.DecodeFromReaderAt(bytes.NewReader(blob), -1, new(types.BeaconState)); err == nil {
+		t.Errorf("negative size decoding succeeded")
+	}
+	if err := ssz.DecodeFieldsFromReaderAt(bytes.NewReader(blob), -1, new(types.BeaconState), ssz.FieldMask{"Slot"}); err == nil {
+		t.Errorf("negative size masked decoding succeeded")
+	}
+}
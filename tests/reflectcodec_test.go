@@ -0,0 +1,172 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/prysmaticlabs/go-bitfield"
+
+	"github.com/karalabe/ssz"
+	"github.com/karalabe/ssz/reflectcodec"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Make the preset constants referenced by the test types' tags known to the
+// reflection based codec.
+func init() {
+	reflectcodec.RegisterConstant("SlotsPerHistoricalRoot", types.SlotsPerHistoricalRoot)
+	reflectcodec.RegisterConstant("EpochsPerHistoricalVector", types.EpochsPerHistoricalVector)
+	reflectcodec.RegisterConstant("EpochsPerSlashingsVector", types.EpochsPerSlashingsVector)
+	reflectcodec.RegisterConstant("MaxEth1DataVotes", types.MaxEth1DataVotes)
+	reflectcodec.RegisterConstant("MaxPendingAttestations", types.MaxPendingAttestations)
+	reflectcodec.RegisterConstant("SyncCommitteeSize", types.SyncCommitteeSize)
+	reflectcodec.RegisterConstant("SyncCommitteeBitsSize", types.SyncCommitteeBitsSize)
+	reflectcodec.RegisterConstant("MaxWithdrawalsPerPayload", types.MaxWithdrawalsPerPayload)
+	reflectcodec.RegisterConstant("MaxBlobCommitmentsPerBlock", types.MaxBlobCommitmentsPerBlock)
+}
+
+// Tests that the reflection based codec produces the same sizes, encodings and
+// hashes as the generated code, and that it can decode the generated encodings.
+func TestReflectCodec(t *testing.T) {
+	b, d := uint64(2), uint32(4)
+
+	testReflectCodec(t, ssz.ForkUnknown, &types.Attestation{
+		AggregationBits: bitfield.Bitlist{0x0f, 0x03},
+		Data:            &types.AttestationData{Slot: 1, Index: 2, Source: &types.Checkpoint{Epoch: 3}, Target: &types.Checkpoint{Epoch: 4, Root: types.Hash{5}}},
+		Signature:       [96]byte{6},
+	})
+	testReflectCodec(t, ssz.ForkUnknown, &types.Attestation{Data: new(types.AttestationData)}) // nil bitlist
+	testReflectCodec(t, ssz.ForkUnknown, &types.CachedAttestationVariation{
+		AggregationBits: bitfield.Bitlist{0x03},
+		Data:            new(types.AttestationData),
+	})
+	testReflectCodec(t, ssz.ForkUnknown, &types.IndexedAttestation{
+		AttestationIndices: []uint64{1, 2, 3},
+		Data:               new(types.AttestationData),
+	})
+	testReflectCodec(t, ssz.ForkUnknown, &types.ExecutionPayloadCapella{
+		BlockNumber:   7,
+		ExtraData:     []byte{1, 2, 3},
+		BaseFeePerGas: uint256.NewInt(8),
+		Transactions:  [][]byte{{9}, {}, bytes.Repeat([]byte{10}, 100)},
+		Withdrawals:   []*types.Withdrawal{{Index: 11, Amount: 12}, {Index: 13, Address: types.Address{14}}},
+	})
+	testReflectCodec(t, ssz.ForkUnknown, &types.HistoricalBatch{BlockRoots: [types.SlotsPerHistoricalRoot]types.Hash{{1}, {2}}})
+	testReflectCodec(t, ssz.ForkUnknown, &types.SyncAggregate{SyncCommiteeBits: [types.SyncCommitteeBitsSize]byte{0xff}})
+	testReflectCodec(t, ssz.ForkUnknown, &types.BitsStructMonolith{A: bitfield.Bitlist{0x21}, D: bitfield.Bitlist{0x01}, E: [1]byte{0x80}})
+	testReflectCodec(t, ssz.ForkUnknown, &types.StringsVariation{Name: "ssz", Nonce: 1, Memo: "héllo"})
+	testReflectCodec(t, ssz.ForkUnknown, &types.SortedListsVariation{AttestingIndices: []uint64{1, 5, 9}, Balances: []uint64{3, 2}})
+	testReflectCodec(t, ssz.ForkUnknown, &types.PackedListsVariation{Bytes: []uint8{1}, Shorts: []uint16{2, 3}, Words: []uint32{4, 5, 6}})
+	testReflectCodec(t, ssz.ForkUnknown, &types.PackedArraysVariation{Flags: [16]uint16{1}, Counters: [64]uint32{2}})
+	testReflectCodec(t, ssz.ForkUnknown, new(types.BeaconState))
+	testReflectCodec(t, ssz.ForkUnknown, new(types.BeaconBlockBodyDeneb))
+
+	for _, fork := range []ssz.Fork{ssz.ForkPhase0, ssz.ForkAltair, ssz.ForkBellatrix, ssz.ForkCapella, ssz.ForkDeneb, ssz.ForkElectra} {
+		testReflectCodec(t, fork, &types.ForkRangesMonolith{A: 1, B: &b, C: []byte{3, 3, 3}, D: &d})
+		testReflectCodec(t, fork, new(types.BeaconStateMonolith))
+		testReflectCodec(t, fork, &types.ExecutionPayloadMonolith{BaseFeePerGas: uint256.NewInt(1), ExtraData: []byte{2}})
+	}
+}
+
+// testReflectCodec checks the reflection based codec against the generated code
+// for a single object in a single fork.
+func testReflectCodec[T newableObject[U], U any](t *testing.T, fork ssz.Fork, obj T) {
+	t.Helper()
+
+	size, err := reflectcodec.SizeOnFork(obj, fork)
+	if err != nil {
+		t.Fatalf("%T/%v: failed to size object: %v", obj, fork, err)
+	}
+	if want := ssz.SizeOnFork(obj, fork); size != want {
+		t.Fatalf("%T/%v: size mismatch: have %d, want %d", obj, fork, size, want)
+	}
+	have := make([]byte, size)
+	if err := reflectcodec.EncodeToBytesOnFork(have, obj, fork); err != nil {
+		t.Fatalf("%T/%v: failed to encode object: %v", obj, fork, err)
+	}
+	want := make([]byte, size)
+	if err := ssz.EncodeToBytesOnFork(want, obj, fork); err != nil {
+		t.Fatalf("%T/%v: failed to encode object via generated code: %v", obj, fork, err)
+	}
+	if !bytes.Equal(have, want) {
+		t.Fatalf("%T/%v: encoding mismatch: have %x, want %x", obj, fork, have, want)
+	}
+	root, err := reflectcodec.HashSequentialOnFork(obj, fork)
+	if err != nil {
+		t.Fatalf("%T/%v: failed to hash object: %v", obj, fork, err)
+	}
+	if want := ssz.HashSequentialOnFork(obj, fork); root != want {
+		t.Fatalf("%T/%v: hash mismatch: have %x, want %x", obj, fork, root, want)
+	}
+	dec := T(new(U))
+	if err := reflectcodec.DecodeFromBytesOnFork(want, dec, fork); err != nil {
+		t.Fatalf("%T/%v: failed to decode object: %v", obj, fork, err)
+	}
+	if err := ssz.EncodeToBytesOnFork(have, dec, fork); err != nil {
+		t.Fatalf("%T/%v: failed to re-encode decoded object: %v", obj, fork, err)
+	}
+	if !bytes.Equal(have, want) {
+		t.Fatalf("%T/%v: re-encoding mismatch: have %x, want %x", obj, fork, have, want)
+	}
+}
+
+// Tests that the reflection based codec rejects the same malformed inputs as the
+// generated code, and that unsupported types are reported.
+func TestReflectCodecErrors(t *testing.T) {
+	obj := &types.Attestation{AggregationBits: bitfield.Bitlist{0x01}}
+	blob := make([]byte, ssz.Size(obj))
+	ssz.EncodeToBytes(blob, obj)
+
+	for _, tt := range []struct {
+		name string
+		blob []byte
+		obj  any
+		err  error
+	}{
+		{"empty", nil, new(types.Attestation), io.ErrUnexpectedEOF},
+		{"short", blob[:100], new(types.Attestation), io.ErrUnexpectedEOF},
+		{"offset", append([]byte{0xff, 0xff, 0, 0}, blob[4:]...), new(types.Attestation), ssz.ErrFirstOffsetMismatch},
+		{"bitlist", append(blob[:len(blob)-1:len(blob)-1], 0x00), new(types.Attestation), ssz.ErrJunkInBitlist},
+		{"trailing", make([]byte, 41), new(types.Checkpoint), ssz.ErrObjectSlotSizeMismatch},
+		{"utf8", []byte{16, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 16, 0, 0, 0, 0xff}, new(types.StringsVariation), ssz.ErrInvalidUTF8},
+		{"sorted", []byte{8, 0, 0, 0, 24, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0}, new(types.SortedListsVariation), ssz.ErrUnsortedItems},
+		{"map", blob, new(types.MapsVariation), reflectcodec.ErrUnsupportedType},
+		{"value", blob, types.Checkpoint{}, reflectcodec.ErrUnsupportedType},
+	} {
+		if err := reflectcodec.DecodeFromBytes(tt.blob, tt.obj); !errors.Is(err, tt.err) {
+			t.Errorf("%s: error mismatch: have %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
+
+// Tests that fork specific limits resolve to the override of the latest fork
+// already activated, irrespective of the order they are listed in.
+func TestReflectCodecForkLimits(t *testing.T) {
+	type unordered struct {
+		List []uint64 `ssz-max:"4" ssz-max-fork:"electra=2,deneb=8"`
+	}
+	blob := make([]byte, ssz.SizeOnFork(&types.ForkLimitsVariation{List: make([]uint64, 3)}, ssz.ForkDeneb))
+	if err := ssz.EncodeToBytesOnFork(blob, &types.ForkLimitsVariation{List: make([]uint64, 3)}, ssz.ForkDeneb); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	for _, tt := range []struct {
+		fork ssz.Fork
+		err  error
+	}{
+		{ssz.ForkCapella, nil},
+		{ssz.ForkDeneb, nil},
+		{ssz.ForkElectra, ssz.ErrMaxItemsExceeded},
+		{ssz.ForkFuture, ssz.ErrMaxItemsExceeded},
+	} {
+		if err := reflectcodec.DecodeFromBytesOnFork(blob, new(unordered), tt.fork); !errors.Is(err, tt.err) {
+			t.Errorf("fork %v: error mismatch: have %v, want %v", tt.fork, err, tt.err)
+		}
+	}
+}
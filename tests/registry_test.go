@@ -0,0 +1,109 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that objects can be decoded by the name of their registered type.
+func TestDecodeAny(t *testing.T) {
+	ssz.RegisterType("Withdrawal", func() ssz.Object { return new(types.Withdrawal) })
+	ssz.RegisterType("ExecutionPayloadMonolith", func() ssz.Object { return new(types.ExecutionPayloadMonolith) })
+	t.Cleanup(func() {
+		ssz.UnregisterType("Withdrawal")
+		ssz.UnregisterType("ExecutionPayloadMonolith")
+	})
+
+	withdrawal := &types.Withdrawal{Index: 1, Validator: 2, Amount: 3}
+	blob := make([]byte, ssz.Size(withdrawal))
+	if err := ssz.EncodeToBytes(blob, withdrawal); err != nil {
+		t.Fatalf("failed to encode withdrawal: %v", err)
+	}
+	obj, err := ssz.DecodeAny(blob, "Withdrawal")
+	if err != nil {
+		t.Fatalf("failed to decode withdrawal: %v", err)
+	}
+	if have, ok := obj.(*types.Withdrawal); !ok || *have != *withdrawal {
+		t.Errorf("decoded withdrawal mismatch: have %+v, want %+v", obj, withdrawal)
+	}
+	// Monolithic types need to be decoded in the fork they were encoded in
+	blobGas := uint64(4)
+	payload := &types.ExecutionPayloadMonolith{BlockNumber: 5, ExtraData: []byte{}, Transactions: [][]byte{}, Withdrawals: []*types.Withdrawal{withdrawal}, BlobGasUsed: &blobGas, ExcessBlobGas: new(uint64)}
+	blob = make([]byte, ssz.SizeOnFork(payload, ssz.ForkDeneb))
+	if err := ssz.EncodeToBytesOnFork(blob, payload, ssz.ForkDeneb); err != nil {
+		t.Fatalf("failed to encode payload: %v", err)
+	}
+	if obj, err = ssz.DecodeAnyOnFork(blob, "ExecutionPayloadMonolith", ssz.ForkDeneb); err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	if have, ok := obj.(*types.ExecutionPayloadMonolith); !ok || ssz.HashSequentialOnFork(have, ssz.ForkDeneb) != ssz.HashSequentialOnFork(payload, ssz.ForkDeneb) {
+		t.Errorf("decoded payload mismatch: have %+v, want %+v", obj, payload)
+	}
+	if _, err = ssz.DecodeAnyOnFork(blob, "ExecutionPayloadMonolith", ssz.ForkBellatrix); err == nil {
+		t.Errorf("decoded payload in wrong fork")
+	}
+	// Unknown types must be rejected
+	if _, err := ssz.DecodeAny(blob, "Unknown"); !errors.Is(err, ssz.ErrUnknownType) {
+		t.Errorf("unknown type error mismatch: have %v, want %v", err, ssz.ErrUnknownType)
+	}
+}
+
+// Tests that encodings can be validated against the schemas of registered types
+// without decoding them.
+func TestValidateAny(t *testing.T) {
+	ssz.RegisterType("Withdrawal", func() ssz.Object { return new(types.Withdrawal) })
+	ssz.RegisterType("ExecutionPayloadMonolith", func() ssz.Object { return new(types.ExecutionPayloadMonolith) })
+	t.Cleanup(func() {
+		ssz.UnregisterType("Withdrawal")
+		ssz.UnregisterType("ExecutionPayloadMonolith")
+	})
+	blobGas := uint64(4)
+	payload := &types.ExecutionPayloadMonolith{BlockNumber: 5, ExtraData: []byte{1}, Transactions: [][]byte{{2}}, Withdrawals: []*types.Withdrawal{{Index: 1}}, BlobGasUsed: &blobGas, ExcessBlobGas: new(uint64)}
+	blob := make([]byte, ssz.SizeOnFork(payload, ssz.ForkDeneb))
+	if err := ssz.EncodeToBytesOnFork(blob, payload, ssz.ForkDeneb); err != nil {
+		t.Fatalf("failed to encode payload: %v", err)
+	}
+	root, err := ssz.ValidateAnyOnFork(blob, "ExecutionPayloadMonolith", ssz.ForkDeneb)
+	if err != nil {
+		t.Fatalf("failed to validate payload: %v", err)
+	}
+	if want := ssz.HashSequentialOnFork(payload, ssz.ForkDeneb); root != want {
+		t.Errorf("validated root mismatch: have %x, want %x", root, want)
+	}
+	// The exported schema should only contain the fields active in the fork
+	schema, err := ssz.SchemaOfType("ExecutionPayloadMonolith", ssz.ForkBellatrix)
+	if err != nil {
+		t.Fatalf("failed to derive schema: %v", err)
+	}
+	if schema.Name != "ExecutionPayloadMonolith" || len(schema.Fields) != 14 {
+		t.Errorf("bellatrix schema mismatch: have %s with %d fields, want %s with %d", schema.Name, len(schema.Fields), "ExecutionPayloadMonolith", 14)
+	}
+	// Invalid encodings, wrong forks and unknown types must be rejected
+	if _, err := ssz.ValidateAnyOnFork(blob[:len(blob)-1], "ExecutionPayloadMonolith", ssz.ForkDeneb); err == nil {
+		t.Errorf("truncated payload validated")
+	}
+	if _, err := ssz.ValidateAnyOnFork(blob, "ExecutionPayloadMonolith", ssz.ForkBellatrix); err == nil {
+		t.Errorf("payload validated in wrong fork")
+	}
+	if _, err := ssz.ValidateAny(blob, "Withdrawal"); err == nil {
+		t.Errorf("payload validated as withdrawal")
+	}
+	if _, err := ssz.ValidateAny(blob, "Unknown"); !errors.Is(err, ssz.ErrUnknownType) {
+		t.Errorf("unknown type error mismatch: have %v, want %v", err, ssz.ErrUnknownType)
+	}
+	if _, err := ssz.SchemaOfType("Unknown", ssz.ForkDeneb); !errors.Is(err, ssz.ErrUnknownType) {
+		t.Errorf("unknown schema error mismatch: have %v, want %v", err, ssz.ErrUnknownType)
+	}
+	// Unregistered types must not be usable any more
+	ssz.UnregisterType("Withdrawal")
+	if _, ok := ssz.LookupType("Withdrawal"); ok {
+		t.Errorf("unregistered type still registered")
+	}
+}
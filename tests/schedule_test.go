@@ -0,0 +1,98 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/karalabe/ssz"
+)
+
+// Tests that fork schedules can be loaded from consensus configs and that forks
+// are resolved correctly around the activation boundaries.
+func TestForkAtEpoch(t *testing.T) {
+	config := `
+PRESET_BASE: 'mainnet'
+CONFIG_NAME: 'mainnet'
+ALTAIR_FORK_VERSION: 0x01000000
+ALTAIR_FORK_EPOCH: 74240
+BELLATRIX_FORK_EPOCH: 144896
+CAPELLA_FORK_EPOCH: 194048
+DENEB_FORK_EPOCH: 269568
+ELECTRA_FORK_EPOCH: 18446744073709551615
+UNKNOWN_FORK_EPOCH: 1
+BLOB_SCHEDULE:
+  - EPOCH: 269568
+    MAX_BLOBS_PER_BLOCK: 6
+  - EPOCH: 364032
+    MAX_BLOBS_PER_BLOCK: 9
+`
+	schedule, err := ssz.LoadForkSchedule(strings.NewReader(config))
+	if err != nil {
+		t.Fatalf("failed to load fork schedule: %v", err)
+	}
+	tests := []struct {
+		epoch uint64
+		fork  ssz.Fork
+	}{
+		{0, ssz.ForkPhase0},
+		{74239, ssz.ForkPhase0},
+		{74240, ssz.ForkAltair},
+		{144896, ssz.ForkBellatrix},
+		{194047, ssz.ForkBellatrix},
+		{194048, ssz.ForkCapella},
+		{269568, ssz.ForkDeneb},
+		{1 << 40, ssz.ForkDeneb},
+		{18446744073709551615, ssz.ForkElectra},
+	}
+	for _, tt := range tests {
+		if have := ssz.ForkAtEpoch(schedule, tt.epoch); have != tt.fork {
+			t.Errorf("epoch %d: fork mismatch: have %d, want %d", tt.epoch, have, tt.fork)
+		}
+	}
+	// Ensure forks activated in the same epoch resolve to the latest one
+	devnet := ssz.ForkSchedule{Epochs: map[ssz.Fork]uint64{ssz.ForkPhase0: 0, ssz.ForkAltair: 0, ssz.ForkBellatrix: 0}}
+	if have := ssz.ForkAtEpoch(devnet, 0); have != ssz.ForkBellatrix {
+		t.Errorf("devnet fork mismatch: have %d, want %d", have, ssz.ForkBellatrix)
+	}
+	// Ensure invalid epochs are rejected
+	if _, err := ssz.LoadForkSchedule(strings.NewReader("DENEB_FORK_EPOCH: soon")); err == nil {
+		t.Errorf("invalid epoch accepted")
+	}
+	if _, err := ssz.LoadForkSchedule(strings.NewReader("- DENEB_FORK_EPOCH: 1")); err == nil {
+		t.Errorf("non-mapping config accepted")
+	}
+}
+
+// Tests that fork digests match the ones of the live mainnet chain.
+func TestComputeForkDigest(t *testing.T) {
+	var root [32]byte
+	hex.Decode(root[:], []byte("4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"))
+
+	tests := []struct {
+		version [4]byte
+		digest  string
+	}{
+		{[4]byte{0x00, 0x00, 0x00, 0x00}, "b5303f2a"}, // phase0
+		{[4]byte{0x01, 0x00, 0x00, 0x00}, "afcaaba0"}, // altair
+		{[4]byte{0x02, 0x00, 0x00, 0x00}, "4a26c58b"}, // bellatrix
+		{[4]byte{0x03, 0x00, 0x00, 0x00}, "bba4da96"}, // capella
+		{[4]byte{0x04, 0x00, 0x00, 0x00}, "6a95a1a9"}, // deneb
+	}
+	for _, tt := range tests {
+		digest := ssz.ComputeForkDigest(tt.version, root)
+		if have := hex.EncodeToString(digest[:]); have != tt.digest {
+			t.Errorf("version %x: digest mismatch: have %s, want %s", tt.version, have, tt.digest)
+		}
+		var chunk [32]byte
+		copy(chunk[:], tt.version[:])
+		if have, want := ssz.ComputeForkDataRoot(tt.version, root), sha256.Sum256(append(chunk[:], root[:]...)); have != want {
+			t.Errorf("version %x: fork data root mismatch: have %x, want %x", tt.version, have, want)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/prysmaticlabs/go-bitfield"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that raw ssz blobs can be merkleized based on a dynamic schema, without
+// having the Go types around, and that malformed blobs are rejected.
+func TestHashRootOfSchema(t *testing.T) {
+	var (
+		checkpoint = &ssz.Schema{Name: "Checkpoint", Fields: []*ssz.SchemaField{
+			{Name: "Epoch", Encoding: "Uint64", Size: 8},
+			{Name: "Root", Encoding: "StaticBytes", Size: 32},
+		}}
+		attestation = &ssz.Schema{Name: "Attestation", Fields: []*ssz.SchemaField{
+			{Name: "AggregationBits", Encoding: "SliceOfBits", Limits: []int{2048}},
+			{Name: "Data", Encoding: "StaticObject", Schema: &ssz.Schema{Name: "AttestationData", Fields: []*ssz.SchemaField{
+				{Name: "Slot", Encoding: "Uint64", Size: 8},
+				{Name: "Index", Encoding: "Uint64", Size: 8},
+				{Name: "BeaconBlockHash", Encoding: "StaticBytes", Size: 32},
+				{Name: "Source", Encoding: "StaticObject", Schema: checkpoint},
+				{Name: "Target", Encoding: "StaticObject", Schema: checkpoint},
+			}}},
+			{Name: "Signature", Encoding: "StaticBytes", Size: 96},
+		}}
+		payload = &ssz.Schema{Name: "ExecutionPayloadCapella", Fields: []*ssz.SchemaField{
+			{Name: "ParentHash", Encoding: "StaticBytes", Size: 32},
+			{Name: "FeeRecipient", Encoding: "StaticBytes", Size: 20},
+			{Name: "StateRoot", Encoding: "StaticBytes", Size: 32},
+			{Name: "ReceiptsRoot", Encoding: "StaticBytes", Size: 32},
+			{Name: "LogsBloom", Encoding: "StaticBytes", Size: 256},
+			{Name: "PrevRandao", Encoding: "StaticBytes", Size: 32},
+			{Name: "BlockNumber", Encoding: "Uint64", Size: 8},
+			{Name: "GasLimit", Encoding: "Uint64", Size: 8},
+			{Name: "GasUsed", Encoding: "Uint64", Size: 8},
+			{Name: "Timestamp", Encoding: "Uint64", Size: 8},
+			{Name: "ExtraData", Encoding: "DynamicBytes", Limits: []int{32}},
+			{Name: "BaseFeePerGas", Encoding: "Uint256", Size: 32},
+			{Name: "BlockHash", Encoding: "StaticBytes", Size: 32},
+			{Name: "Transactions", Encoding: "SliceOfDynamicBytes", Limits: []int{1048576, 1073741824}},
+			{Name: "Withdrawals", Encoding: "SliceOfStaticObjects", Limits: []int{types.MaxWithdrawalsPerPayload}, Schema: &ssz.Schema{Name: "Withdrawal", Fields: []*ssz.SchemaField{
+				{Name: "Index", Encoding: "Uint64", Size: 8},
+				{Name: "Validator", Encoding: "Uint64", Size: 8},
+				{Name: "Address", Encoding: "StaticBytes", Size: 20},
+				{Name: "Amount", Encoding: "Uint64", Size: 8},
+			}}},
+		}}
+		monolith = &ssz.Schema{Name: "ForkRangesMonolith", Fields: []*ssz.SchemaField{
+			{Name: "A", Encoding: "Uint64", Size: 8},
+			{Name: "B", Encoding: "Uint64", Size: 8, Ranges: []ssz.SchemaRange{{Added: "Altair", Removed: "Capella"}, {Added: "Electra"}}},
+			{Name: "C", Encoding: "DynamicBytes", Limits: []int{32}, Added: "Bellatrix", Removed: "Deneb"},
+			{Name: "D", Encoding: "Uint32", Size: 4, Ranges: []ssz.SchemaRange{{Added: "Phase0", Removed: "Altair"}, {Added: "Bellatrix", Removed: "Capella"}, {Added: "Deneb"}}},
+		}}
+	)
+	// Hash a few non-monolithic objects and compare against the generated code
+	for _, tt := range []struct {
+		obj    ssz.Object
+		schema *ssz.Schema
+	}{
+		{&types.Attestation{
+			AggregationBits: bitfield.Bitlist{0x0f, 0x03},
+			Data:            &types.AttestationData{Slot: 1, Index: 2, Source: &types.Checkpoint{Epoch: 3}, Target: &types.Checkpoint{Epoch: 4, Root: types.Hash{5}}},
+			Signature:       [96]byte{6},
+		}, attestation},
+		{&types.ExecutionPayloadCapella{
+			BlockNumber:   7,
+			ExtraData:     []byte{1, 2, 3},
+			BaseFeePerGas: uint256.NewInt(8),
+			Transactions:  [][]byte{{9}, {}, bytes.Repeat([]byte{10}, 100)},
+			Withdrawals:   []*types.Withdrawal{{Index: 11, Amount: 12}, {Index: 13, Address: types.Address{14}}},
+		}, payload},
+		{&types.ExecutionPayloadCapella{BaseFeePerGas: new(uint256.Int)}, payload},
+	} {
+		blob := make([]byte, ssz.Size(tt.obj))
+		if err := ssz.EncodeToBytes(blob, tt.obj); err != nil {
+			t.Fatalf("%s: failed to encode object: %v", tt.schema.Name, err)
+		}
+		root, err := ssz.HashRootOfSchema(blob, tt.schema)
+		if err != nil {
+			t.Errorf("%s: failed to hash blob: %v", tt.schema.Name, err)
+			continue
+		}
+		if want := ssz.HashSequential(tt.obj); root != want {
+			t.Errorf("%s: root mismatch: have %x, want %x", tt.schema.Name, root, want)
+		}
+	}
+	// Hash a monolithic object across forks and compare against the generated code
+	b, d := uint64(2), uint32(4)
+	obj := &types.ForkRangesMonolith{A: 1, B: &b, C: []byte{3, 3, 3}, D: &d}
+
+	for _, fork := range []ssz.Fork{ssz.ForkPhase0, ssz.ForkAltair, ssz.ForkBellatrix, ssz.ForkCapella, ssz.ForkDeneb, ssz.ForkElectra} {
+		blob := make([]byte, ssz.SizeOnFork(obj, fork))
+		if err := ssz.EncodeToBytesOnFork(blob, obj, fork); err != nil {
+			t.Fatalf("fork %v: failed to encode object: %v", fork, err)
+		}
+		root, err := ssz.HashRootOfSchemaOnFork(blob, monolith, fork)
+		if err != nil {
+			t.Errorf("fork %v: failed to hash blob: %v", fork, err)
+			continue
+		}
+		if want := ssz.HashSequentialOnFork(obj, fork); root != want {
+			t.Errorf("fork %v: root mismatch: have %x, want %x", fork, root, want)
+		}
+	}
+	// Hash monolithic objects with fork specific limits and encodings, as exported
+	// by sszgen describe, and compare against the generated code
+	var limited, switched ssz.Schema
+	if err := json.Unmarshal([]byte(`{"name": "ForkLimitsVariation", "fields": [
+		{"name": "List", "encoding": "SliceOfUint64s", "limits": [4], "forkLimits": [{"fork": "Deneb", "limit": 8}, {"fork": "Electra", "limit": 2}]}
+	]}`), &limited); err != nil {
+		t.Fatalf("failed to parse fork limited schema: %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"name": "ForkTypesMonolith", "fields": [
+		{"name": "Flags", "encoding": "CheckedStaticBytes", "size": 4, "forkTypes": [{"fork": "Deneb", "encoding": "CheckedStaticBytes", "size": 8}, {"fork": "Electra", "encoding": "CheckedStaticBytes", "size": 16}]},
+		{"name": "Bits", "encoding": "ArrayOfBits", "size": 1, "forkTypes": [{"fork": "Electra", "encoding": "ArrayOfBits", "size": 1}]},
+		{"name": "Payload", "encoding": "DynamicBytes", "sizes": [0], "limits": [16], "forkTypes": [{"fork": "Electra", "encoding": "DynamicBytes", "sizes": [0], "limits": [32]}]}
+	]}`), &switched); err != nil {
+		t.Fatalf("failed to parse fork switched schema: %v", err)
+	}
+	for _, fork := range []ssz.Fork{ssz.ForkCapella, ssz.ForkDeneb, ssz.ForkElectra} {
+		flags := map[ssz.Fork]int{ssz.ForkCapella: 4, ssz.ForkDeneb: 8, ssz.ForkElectra: 16}[fork]
+		for _, tt := range []struct {
+			obj    ssz.Object
+			schema *ssz.Schema
+		}{
+			{&types.ForkLimitsVariation{List: []uint64{1, 2}}, &limited},
+			{&types.ForkTypesMonolith{Flags: make([]byte, flags), Bits: [1]byte{0x05}, Payload: []byte{1, 2, 3}}, &switched},
+		} {
+			blob := make([]byte, ssz.SizeOnFork(tt.obj, fork))
+			if err := ssz.EncodeToBytesOnFork(blob, tt.obj, fork); err != nil {
+				t.Fatalf("%s: fork %v: failed to encode object: %v", tt.schema.Name, fork, err)
+			}
+			root, err := ssz.HashRootOfSchemaOnFork(blob, tt.schema, fork)
+			if err != nil {
+				t.Errorf("%s: fork %v: failed to hash blob: %v", tt.schema.Name, fork, err)
+				continue
+			}
+			if want := ssz.HashSequentialOnFork(tt.obj, fork); root != want {
+				t.Errorf("%s: fork %v: root mismatch: have %x, want %x", tt.schema.Name, fork, root, want)
+			}
+		}
+	}
+	// Ensure malformed blobs and schemas are rejected
+	blob := make([]byte, ssz.Size(&types.Attestation{AggregationBits: bitfield.Bitlist{0x01}}))
+	ssz.EncodeToBytes(blob, &types.Attestation{AggregationBits: bitfield.Bitlist{0x01}})
+
+	for _, tt := range []struct {
+		name   string
+		blob   []byte
+		schema *ssz.Schema
+		err    error
+	}{
+		{"short", blob[:100], attestation, io.ErrUnexpectedEOF},
+		{"trailing", make([]byte, 41), checkpoint, ssz.ErrObjectSlotSizeMismatch},
+		{"offset", append([]byte{0xff, 0xff, 0, 0}, blob[4:]...), attestation, ssz.ErrFirstOffsetMismatch},
+		{"bitlist", append(blob[:len(blob)-1:len(blob)-1], 0x00), attestation, ssz.ErrJunkInBitlist},
+		{"encoding", blob, &ssz.Schema{Fields: []*ssz.SchemaField{{Name: "X", Encoding: "Unknown", Size: len(blob)}}}, ssz.ErrInvalidSchema},
+		{"fork", blob, &ssz.Schema{Fields: []*ssz.SchemaField{{Name: "X", Encoding: "StaticBytes", Size: len(blob), Added: "Unknownium"}}}, ssz.ErrInvalidSchema},
+	} {
+		if _, err := ssz.HashRootOfSchema(tt.blob, tt.schema); !errors.Is(err, tt.err) {
+			t.Errorf("%s: error mismatch: have %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/golang/snappy"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that snappy compressed encodings round-trip and match the reference
+// compression of the plain ssz encoding.
+func TestSnappyRoundtrip(t *testing.T) {
+	obj := &types.ExecutionPayload{ExtraData: []byte{0x01, 0x02}, Transactions: [][]byte{{0x03}}}
+
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	// Block format encoding and decoding
+	comp, err := ssz.EncodeToSnappyBytes(nil, obj)
+	if err != nil {
+		t.Fatalf("failed to snappy encode object: %v", err)
+	}
+	if want := snappy.Encode(nil, blob); !bytes.Equal(comp, want) {
+		t.Errorf("snappy block mismatch: have %x, want %x", comp, want)
+	}
+	dec := new(types.ExecutionPayload)
+	if err := ssz.DecodeFromSnappyBytes(comp, dec); err != nil {
+		t.Fatalf("failed to snappy decode object: %v", err)
+	}
+	if ssz.HashSequential(dec) != ssz.HashSequential(obj) {
+		t.Errorf("snappy block decoded object mismatch")
+	}
+	// Framed format encoding and decoding
+	buf := new(bytes.Buffer)
+	if err := ssz.EncodeToSnappyStream(buf, obj); err != nil {
+		t.Fatalf("failed to snappy stream object: %v", err)
+	}
+	dec = new(types.ExecutionPayload)
+	if err := ssz.DecodeFromSnappyStream(buf, dec, uint32(len(blob))); err != nil {
+		t.Fatalf("failed to snappy stream decode object: %v", err)
+	}
+	if ssz.HashSequential(dec) != ssz.HashSequential(obj) {
+		t.Errorf("snappy stream decoded object mismatch")
+	}
+}
+
+// Tests that snappy blocks announcing bogus decompressed lengths are rejected
+// before allocating memory for them.
+func TestSnappyBogusLength(t *testing.T) {
+	// A handful of bytes claiming to inflate into 4GB should be rejected outright
+	bogus := append(binary.AppendUvarint(nil, 0xffffffff), 0x00, 0x01, 0x02, 0x03)
+	allocs := testing.AllocsPerRun(10, func() {
+		if err := ssz.DecodeFromSnappyBytes(bogus, new(types.ExecutionPayload)); !errors.Is(err, snappy.ErrCorrupt) {
+			t.Errorf("bogus length error mismatch: have %v, want %v", err, snappy.ErrCorrupt)
+		}
+	})
+	if allocs > 10 {
+		t.Errorf("bogus length allocations: have %v, want at most 10", allocs)
+	}
+	// Static objects must inflate into exactly their size
+	blob := snappy.Encode(nil, make([]byte, ssz.Size(new(types.Withdrawal))+1))
+	if err := ssz.DecodeFromSnappyBytes(blob, new(types.Withdrawal)); !errors.Is(err, ssz.ErrObjectSlotSizeMismatch) {
+		t.Errorf("static size error mismatch: have %v, want %v", err, ssz.ErrObjectSlotSizeMismatch)
+	}
+	// Failed decompressions should not break subsequent ones
+	withdrawal := &types.Withdrawal{Index: 1, Validator: 2, Amount: 3}
+	comp, err := ssz.EncodeToSnappyBytes(nil, withdrawal)
+	if err != nil {
+		t.Fatalf("failed to snappy encode object: %v", err)
+	}
+	corrupt := append(comp[:1:1], bytes.Repeat([]byte{0xff}, 16)...) // literals overflowing the data
+	for i := 0; i < 2; i++ {
+		if err := ssz.DecodeFromSnappyBytes(corrupt, new(types.Withdrawal)); err == nil {
+			t.Errorf("corrupt block decoded")
+		}
+		dec := new(types.Withdrawal)
+		if err := ssz.DecodeFromSnappyBytes(comp, dec); err != nil {
+			t.Fatalf("failed to snappy decode object: %v", err)
+		}
+		if *dec != *withdrawal {
+			t.Errorf("decoded object mismatch: have %+v, want %+v", dec, withdrawal)
+		}
+	}
+}
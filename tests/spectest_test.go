@@ -0,0 +1,80 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/karalabe/ssz/spectest"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+// Tests that the YAML value representation of the consensus spec tests can be
+// parsed into the generated types.
+func TestSpecTestUnmarshal(t *testing.T) {
+	// Nested containers, bitlists and numbers
+	var att types.PendingAttestation
+	err := spectest.Unmarshal([]byte(`
+aggregation_bits: '0x0b'
+data:
+  slot: 1
+  index: 2
+  beacon_block_root: '0x0300000000000000000000000000000000000000000000000000000000000000'
+  source: {epoch: 4, root: '0x0500000000000000000000000000000000000000000000000000000000000000'}
+  target: {epoch: 6, root: '0x0700000000000000000000000000000000000000000000000000000000000000'}
+inclusion_delay: 8
+proposer_index: 18446744073709551615
+`), &att)
+	if err != nil {
+		t.Fatalf("failed to parse pending attestation: %v", err)
+	}
+	want := types.PendingAttestation{
+		AggregationBits: bitfield.Bitlist{0x0b},
+		Data: &types.AttestationData{
+			Slot:            1,
+			Index:           2,
+			BeaconBlockHash: types.Hash{3},
+			Source:          &types.Checkpoint{Epoch: 4, Root: types.Hash{5}},
+			Target:          &types.Checkpoint{Epoch: 6, Root: types.Hash{7}},
+		},
+		InclusionDelay: 8,
+		ProposerIndex:  18446744073709551615,
+	}
+	if !reflect.DeepEqual(att, want) {
+		t.Errorf("pending attestation mismatch: have %+v, want %+v", att, want)
+	}
+	// Big numbers and lists of blobs
+	var payload types.ExecutionPayload
+	err = spectest.Unmarshal([]byte(`
+logs_bloom: '0x`+strings.Repeat("00", 256)+`'
+extra_data: '0x0102'
+base_fee_per_gas: '115792089237316195423570985008687907853269984665640564039457584007913129639935'
+transactions: ['0x01', '0x0203']
+`), &payload)
+	if err != nil {
+		t.Fatalf("failed to parse execution payload: %v", err)
+	}
+	if payload.BaseFeePerGas.Cmp(new(uint256.Int).SetAllOne()) != 0 {
+		t.Errorf("base fee mismatch: have %v, want %v", payload.BaseFeePerGas, new(uint256.Int).SetAllOne())
+	}
+	if !reflect.DeepEqual(payload.ExtraData, []byte{1, 2}) || !reflect.DeepEqual(payload.Transactions, [][]byte{{1}, {2, 3}}) {
+		t.Errorf("dynamic blobs mismatch: have %x, %x", payload.ExtraData, payload.Transactions)
+	}
+	// Malformed values should be rejected
+	for _, blob := range []string{
+		`slot: -1`,
+		`unknown_field: 1`,
+		`beacon_block_root: '0x00'`,
+		`source: [1, 2]`,
+	} {
+		if err := spectest.Unmarshal([]byte(blob), new(types.AttestationData)); err == nil {
+			t.Errorf("invalid value %q accepted", blob)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build sszdebug
+
+package tests
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that the structural statistics collected by the encoder and decoder in
+// debug builds agree with each other and with the shape of the encoded object.
+//
+// Run with: go test -tags sszdebug ./tests -run TestStats
+func TestStats(t *testing.T) {
+	obj := &types.BeaconBlock{
+		Body: &types.BeaconBlockBody{
+			Eth1Data: new(types.Eth1Data),
+			AttesterSlashings: []*types.AttesterSlashing{{
+				Attestation1: &types.IndexedAttestation{AttestationIndices: []uint64{1, 2}, Data: new(types.AttestationData)},
+				Attestation2: &types.IndexedAttestation{AttestationIndices: []uint64{3}, Data: new(types.AttestationData)},
+			}},
+			Deposits: []*types.Deposit{{Data: new(types.DepositData)}},
+		},
+	}
+	var (
+		enc, dec ssz.Stats
+		profile  = ssz.Profile{Stats: &enc}
+	)
+	blob := make([]byte, ssz.Size(obj))
+	if err := profile.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode block: %v", err)
+	}
+	// The block nests body -> slashing -> attestation, each of them dynamic
+	if enc.MaxDepth != 4 {
+		t.Errorf("encoder depth mismatch: have %d, want %d", enc.MaxDepth, 4)
+	}
+	if enc.Offsets != enc.Contents {
+		t.Errorf("encoder offsets/contents mismatch: %d offsets, %d contents", enc.Offsets, enc.Contents)
+	}
+	// Encoding into a stream must walk the same structure as into a buffer
+	var stream ssz.Stats
+	if err := (ssz.Profile{Stats: &stream}).EncodeToStream(new(bytes.Buffer), obj); err != nil {
+		t.Fatalf("failed to stream encode block: %v", err)
+	}
+	if stream != enc {
+		t.Errorf("stream encoder stats mismatch: have %+v, want %+v", stream, enc)
+	}
+	// Decoding the blob back, both from a buffer and a stream, must walk the same
+	// structure as the encoding did
+	if err := ssz.DecodeFromBytesWithOptions(blob, new(types.BeaconBlock), ssz.ForkUnknown, &ssz.DecodeOptions{Stats: &dec}); err != nil {
+		t.Fatalf("failed to decode block: %v", err)
+	}
+	if dec != enc {
+		t.Errorf("buffer decoder stats mismatch: have %+v, want %+v", dec, enc)
+	}
+	dec = ssz.Stats{}
+	if err := (ssz.Profile{Stats: &dec}).DecodeFromStream(bytes.NewReader(blob), new(types.BeaconBlock), uint32(len(blob))); err != nil {
+		t.Fatalf("failed to stream decode block: %v", err)
+	}
+	if dec != enc {
+		t.Errorf("stream decoder stats mismatch: have %+v, want %+v", dec, enc)
+	}
+	// Statistics must not leak from one operation into the next, nor into the
+	// operations that did not request them
+	want := enc
+	if err := ssz.EncodeToBytes(blob, new(types.BeaconBlock)); err != nil {
+		t.Fatalf("failed to encode empty block: %v", err)
+	}
+	if err := profile.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to re-encode block: %v", err)
+	}
+	if enc != want {
+		t.Errorf("encoder stats not reset: have %+v, want %+v", enc, want)
+	}
+	// Concurrent operations must each collect their own statistics
+	var (
+		pend  sync.WaitGroup
+		stats = make([]ssz.Stats, 8)
+	)
+	for i := range stats {
+		pend.Add(1)
+		go func() {
+			defer pend.Done()
+
+			buf := make([]byte, len(blob))
+			if i%2 == 0 {
+				(ssz.Profile{Stats: &stats[i]}).EncodeToBytes(buf, obj)
+			} else {
+				(ssz.Profile{Stats: &stats[i]}).EncodeToBytes(buf, new(types.BeaconBlock))
+			}
+		}()
+	}
+	pend.Wait()
+	for i, have := range stats {
+		if i%2 == 0 && have != want {
+			t.Errorf("concurrent encoder %d stats mismatch: have %+v, want %+v", i, have, want)
+		}
+		if i%2 == 1 && have.Contents >= want.Contents {
+			t.Errorf("concurrent encoder %d stats leaked: have %+v", i, have)
+		}
+	}
+}
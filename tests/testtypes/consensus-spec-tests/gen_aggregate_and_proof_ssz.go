@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 0e6e34f063f4ef16734b88b79a1fe5140b5b8742218c56a0585f973e5a0c4a5a
 
 package consensus_spec_tests
 
@@ -26,3 +27,8 @@ func (obj *AggregateAndProof) DefineSSZ(codec *ssz.Codec) {
 	// Define the dynamic data (fields)
 	ssz.DefineDynamicObjectContent(codec, &obj.Aggregate) // Field  (1) -      Aggregate - ? bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *AggregateAndProof) NamesSSZ() []string {
+	return []string{"Index", "Aggregate", "SelectionProof", "Aggregate"}
+}
@@ -0,0 +1,32 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 05d6bc24628282d14439b7ab58d577134823beb1f0a8670da90c59fb6a746461
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *ArrayOfDynamicBytesVariation) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
+	size = 4
+	if fixed {
+		return size
+	}
+	size += ssz.SizeCheckedArrayOfDynamicBytes(sizer, obj.Blobs, 3)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *ArrayOfDynamicBytesVariation) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineCheckedArrayOfDynamicBytesOffset(codec, &obj.Blobs, 3, 16) // Offset (0) - Blobs - 4 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineCheckedArrayOfDynamicBytesContent(codec, &obj.Blobs, 3, 16) // Field  (0) - Blobs - ? bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *ArrayOfDynamicBytesVariation) NamesSSZ() []string {
+	return []string{"Blobs", "Blobs"}
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 7d0af56bb199e2d0344c19f33b5a35d055eda8cdd6be0a7c20d09360fbbc0491
 
 package consensus_spec_tests
 
@@ -24,3 +25,8 @@ func (obj *AttestationData) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineStaticObject(codec, &obj.Source)         // Field  (3) -          Source -  ? bytes (Checkpoint)
 	ssz.DefineStaticObject(codec, &obj.Target)         // Field  (4) -          Target -  ? bytes (Checkpoint)
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *AttestationData) NamesSSZ() []string {
+	return []string{"Slot", "Index", "BeaconBlockHash", "Source", "Target"}
+}
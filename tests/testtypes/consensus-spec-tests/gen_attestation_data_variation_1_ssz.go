@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp e311e598aa15dbdc22dd54ae63d732dce0535e89139c63d3207c6ac3b71e6acd
 
 package consensus_spec_tests
 
@@ -28,3 +29,8 @@ func (obj *AttestationDataVariation1) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineStaticObject(codec, &obj.Source)                                               // Field  (4) -          Source -  ? bytes (Checkpoint)
 	ssz.DefineStaticObject(codec, &obj.Target)                                               // Field  (5) -          Target -  ? bytes (Checkpoint)
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *AttestationDataVariation1) NamesSSZ() []string {
+	return []string{"Future", "Slot", "Index", "BeaconBlockHash", "Source", "Target"}
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp d6e2b264a0137c6964827e409f8d1f1b311da53460a7bdce2f8ffdb4ef5ec429
 
 package consensus_spec_tests
 
@@ -29,3 +30,8 @@ func (obj *AttestationDataVariation2) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineStaticObject(codec, &obj.Source)                                               // Field  (4) -          Source -  ? bytes (Checkpoint)
 	ssz.DefineStaticObject(codec, &obj.Target)                                               // Field  (5) -          Target -  ? bytes (Checkpoint)
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *AttestationDataVariation2) NamesSSZ() []string {
+	return []string{"Slot", "Index", "BeaconBlockHash", "Future", "Source", "Target"}
+}
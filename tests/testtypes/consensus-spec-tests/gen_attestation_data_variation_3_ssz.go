@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 6a9d973b74850a3b0fbf5133cd5bdf902715225167fc785f5ced967c8ef261cd
 
 package consensus_spec_tests
 
@@ -28,3 +29,8 @@ func (obj *AttestationDataVariation3) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineStaticObject(codec, &obj.Target)                                               // Field  (4) -          Target -  ? bytes (Checkpoint)
 	ssz.DefineUint64PointerOnFork(codec, &obj.Future, ssz.ForkFilter{Added: ssz.ForkFuture}) // Field  (5) -          Future -  8 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *AttestationDataVariation3) NamesSSZ() []string {
+	return []string{"Slot", "Index", "BeaconBlockHash", "Source", "Target", "Future"}
+}
@@ -0,0 +1,44 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 59401cc31fda865d8af3d1c0e0f392a6f2a8dc06c7b2c5e23b235097e5b60e93
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// Cached static size computed on package init.
+var staticSizeCacheAttestationElectraVariation = ssz.PrecomputeStaticSizeCache((*AttestationElectraVariation)(nil))
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *AttestationElectraVariation) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
+	// Load static size if already precomputed, calculate otherwise
+	if fork := int(sizer.Fork()); fork < len(staticSizeCacheAttestationElectraVariation) {
+		size = staticSizeCacheAttestationElectraVariation[fork]
+	} else {
+		size = 4 + (*AttestationData)(nil).SizeSSZ(sizer) + 96 + 8
+	}
+	// Either return the static size or accumulate the dynamic too
+	if fixed {
+		return size
+	}
+	size += ssz.SizeSliceOfBits(sizer, obj.AggregationBits)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *AttestationElectraVariation) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineSliceOfBitsOffset(codec, &obj.AggregationBits, 131072) // Offset (0) - AggregationBits -  4 bytes
+	ssz.DefineStaticObject(codec, &obj.Data)                         // Field  (1) -            Data -  ? bytes (AttestationData)
+	ssz.DefineStaticBytes(codec, &obj.Signature)                     // Field  (2) -       Signature - 96 bytes
+	ssz.DefineArrayOfBits(codec, &obj.CommitteeBits, 64)             // Field  (3) -   CommitteeBits -  8 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineSliceOfBitsContent(codec, &obj.AggregationBits, 131072) // Field  (0) - AggregationBits - ? bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *AttestationElectraVariation) NamesSSZ() []string {
+	return []string{"AggregationBits", "Data", "Signature", "CommitteeBits", "AggregationBits"}
+}
@@ -0,0 +1,47 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp cf3e0d9364506a4eda97adaf4baea2f65e631756c0f9a07f677850980526f4cf
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *AttestationMonolith) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
+	if fork := sizer.Fork(); fork >= ssz.ForkElectra {
+		size = 236
+	} else {
+		size = 228
+	}
+	if fixed {
+		return size
+	}
+	size += ssz.SizeSliceOfBits(sizer, obj.AggregationBits)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *AttestationMonolith) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	if codec.Fork() >= ssz.ForkElectra {
+		ssz.DefineSliceOfBitsOffset(codec, &obj.AggregationBits, 131072) // Offset (0) - AggregationBits -  4 bytes
+	} else {
+		ssz.DefineSliceOfBitsOffset(codec, &obj.AggregationBits, 2048) // Offset (0) - AggregationBits -  4 bytes
+	}
+	ssz.DefineStaticObject(codec, &obj.Data)                                                                  // Field  (1) -            Data -  ? bytes (AttestationData)
+	ssz.DefineStaticBytes(codec, &obj.Signature)                                                              // Field  (2) -       Signature - 96 bytes
+	ssz.DefineArrayOfBitsPointerOnFork(codec, &obj.CommitteeBits, 64, ssz.ForkFilter{Added: ssz.ForkElectra}) // Field  (3) -   CommitteeBits -  8 bytes
+
+	// Define the dynamic data (fields)
+	if codec.Fork() >= ssz.ForkElectra {
+		ssz.DefineSliceOfBitsContent(codec, &obj.AggregationBits, 131072) // Field  (0) - AggregationBits - ? bytes
+	} else {
+		ssz.DefineSliceOfBitsContent(codec, &obj.AggregationBits, 2048) // Field  (0) - AggregationBits - ? bytes
+	}
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *AttestationMonolith) NamesSSZ() []string {
+	return []string{"AggregationBits", "Data", "Signature", "CommitteeBits", "AggregationBits"}
+}
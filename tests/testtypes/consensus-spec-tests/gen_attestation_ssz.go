@@ -1,22 +1,14 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 8d50d20d1239e641163ccbfa04f85ea9ff247fe388652ac71e29690bad47b19f
 
 package consensus_spec_tests
 
 import "github.com/karalabe/ssz"
 
-// Cached static size computed on package init.
-var staticSizeCacheAttestation = ssz.PrecomputeStaticSizeCache((*Attestation)(nil))
-
 // SizeSSZ returns either the static size of the object if fixed == true, or
 // the total size otherwise.
 func (obj *Attestation) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
-	// Load static size if already precomputed, calculate otherwise
-	if fork := int(sizer.Fork()); fork < len(staticSizeCacheAttestation) {
-		size = staticSizeCacheAttestation[fork]
-	} else {
-		size = 4 + (*AttestationData)(nil).SizeSSZ(sizer) + 96
-	}
-	// Either return the static size or accumulate the dynamic too
+	size = 228
 	if fixed {
 		return size
 	}
@@ -35,3 +27,8 @@ func (obj *Attestation) DefineSSZ(codec *ssz.Codec) {
 	// Define the dynamic data (fields)
 	ssz.DefineSliceOfBitsContent(codec, &obj.AggregationBits, 2048) // Field  (0) - AggregationBits - ? bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *Attestation) NamesSSZ() []string {
+	return []string{"AggregationBits", "Data", "Signature", "AggregationBits"}
+}
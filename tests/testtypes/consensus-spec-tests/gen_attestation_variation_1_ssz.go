@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 0952a654f7bf584126a51876fdeeaf99085aa7bf803515dad4f53d5fc27754a0
 
 package consensus_spec_tests
 
@@ -39,3 +40,8 @@ func (obj *AttestationVariation1) DefineSSZ(codec *ssz.Codec) {
 	// Define the dynamic data (fields)
 	ssz.DefineSliceOfBitsContent(codec, &obj.AggregationBits, 2048) // Field  (1) - AggregationBits - ? bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *AttestationVariation1) NamesSSZ() []string {
+	return []string{"Future", "AggregationBits", "Data", "Signature", "AggregationBits"}
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp e65d0538e72c5e842b80fe9f4c046894587e1e36cbb64e3de259a39a4eb9245c
 
 package consensus_spec_tests
 
@@ -40,3 +41,8 @@ func (obj *AttestationVariation2) DefineSSZ(codec *ssz.Codec) {
 	// Define the dynamic data (fields)
 	ssz.DefineSliceOfBitsContent(codec, &obj.AggregationBits, 2048) // Field  (0) - AggregationBits - ? bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *AttestationVariation2) NamesSSZ() []string {
+	return []string{"AggregationBits", "Data", "Future", "Signature", "AggregationBits"}
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp fa9d1a9bd250c70abbef9e28bd6a16d53f455252e0b3079e1dbcbb94a1db9939
 
 package consensus_spec_tests
 
@@ -39,3 +40,8 @@ func (obj *AttestationVariation3) DefineSSZ(codec *ssz.Codec) {
 	// Define the dynamic data (fields)
 	ssz.DefineSliceOfBitsContent(codec, &obj.AggregationBits, 2048) // Field  (0) - AggregationBits - ? bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *AttestationVariation3) NamesSSZ() []string {
+	return []string{"AggregationBits", "Data", "Signature", "Future", "AggregationBits"}
+}
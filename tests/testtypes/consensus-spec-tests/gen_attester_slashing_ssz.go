@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp b92236f008f5ed8bd5df6425331e5f8882273ff3d232d50eae05f6b28e65908a
 
 package consensus_spec_tests
 
@@ -27,3 +28,8 @@ func (obj *AttesterSlashing) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineDynamicObjectContent(codec, &obj.Attestation1) // Field  (0) - Attestation1 - ? bytes
 	ssz.DefineDynamicObjectContent(codec, &obj.Attestation2) // Field  (1) - Attestation2 - ? bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *AttesterSlashing) NamesSSZ() []string {
+	return []string{"Attestation1", "Attestation2", "Attestation1", "Attestation2"}
+}
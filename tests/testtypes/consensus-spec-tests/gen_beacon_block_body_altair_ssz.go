@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 23d317d12014ee371892b98a775b34605b187a2a6e6372ba885cb845825f328c
 
 package consensus_spec_tests
 
@@ -49,3 +50,8 @@ func (obj *BeaconBlockBodyAltair) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.Deposits, 16)          // Field  (6) -          Deposits - ? bytes
 	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.VoluntaryExits, 16)    // Field  (7) -    VoluntaryExits - ? bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *BeaconBlockBodyAltair) NamesSSZ() []string {
+	return []string{"RandaoReveal", "Eth1Data", "Graffiti", "ProposerSlashings", "AttesterSlashings", "Attestations", "Deposits", "VoluntaryExits", "SyncAggregate", "ProposerSlashings", "AttesterSlashings", "Attestations", "Deposits", "VoluntaryExits"}
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 7ecc396beac94b2fa34e748f23dee3cabcc4bdea6fee9e25d19645a11f62bc2e
 
 package consensus_spec_tests
 
@@ -52,3 +53,8 @@ func (obj *BeaconBlockBodyBellatrix) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.VoluntaryExits, 16)    // Field  (7) -    VoluntaryExits - ? bytes
 	ssz.DefineDynamicObjectContent(codec, &obj.ExecutionPayload)             // Field  (9) -  ExecutionPayload - ? bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *BeaconBlockBodyBellatrix) NamesSSZ() []string {
+	return []string{"RandaoReveal", "Eth1Data", "Graffiti", "ProposerSlashings", "AttesterSlashings", "Attestations", "Deposits", "VoluntaryExits", "SyncAggregate", "ExecutionPayload", "ProposerSlashings", "AttesterSlashings", "Attestations", "Deposits", "VoluntaryExits", "ExecutionPayload"}
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 0ccc9d0bcc81f25d551d349923ec34a6ee6e8ce63279e9ae7c8483825c6d60a6
 
 package consensus_spec_tests
 
@@ -55,3 +56,8 @@ func (obj *BeaconBlockBodyCapella) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineDynamicObjectContent(codec, &obj.ExecutionPayload)                 // Field  ( 9) -      ExecutionPayload - ? bytes
 	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.BlsToExecutionChanges, 16) // Field  (10) - BlsToExecutionChanges - ? bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *BeaconBlockBodyCapella) NamesSSZ() []string {
+	return []string{"RandaoReveal", "Eth1Data", "Graffiti", "ProposerSlashings", "AttesterSlashings", "Attestations", "Deposits", "VoluntaryExits", "SyncAggregate", "ExecutionPayload", "BlsToExecutionChanges", "ProposerSlashings", "AttesterSlashings", "Attestations", "Deposits", "VoluntaryExits", "ExecutionPayload", "BlsToExecutionChanges"}
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp bfb0c19cccb672f44fc85a0c2efaf32e8efc71ca5e8c79ab71b9aff82985d975
 
 package consensus_spec_tests
 
@@ -35,26 +36,31 @@ func (obj *BeaconBlockBodyDeneb) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uin
 // DefineSSZ defines how an object is encoded/decoded.
 func (obj *BeaconBlockBodyDeneb) DefineSSZ(codec *ssz.Codec) {
 	// Define the static data (fields and dynamic offsets)
-	ssz.DefineStaticBytes(codec, &obj.RandaoReveal)                             // Field  ( 0) -          RandaoReveal - 96 bytes
-	ssz.DefineStaticObject(codec, &obj.Eth1Data)                                // Field  ( 1) -              Eth1Data -  ? bytes (Eth1Data)
-	ssz.DefineStaticBytes(codec, &obj.Graffiti)                                 // Field  ( 2) -              Graffiti - 32 bytes
-	ssz.DefineSliceOfStaticObjectsOffset(codec, &obj.ProposerSlashings, 16)     // Offset ( 3) -     ProposerSlashings -  4 bytes
-	ssz.DefineSliceOfDynamicObjectsOffset(codec, &obj.AttesterSlashings, 2)     // Offset ( 4) -     AttesterSlashings -  4 bytes
-	ssz.DefineSliceOfDynamicObjectsOffset(codec, &obj.Attestations, 128)        // Offset ( 5) -          Attestations -  4 bytes
-	ssz.DefineSliceOfStaticObjectsOffset(codec, &obj.Deposits, 16)              // Offset ( 6) -              Deposits -  4 bytes
-	ssz.DefineSliceOfStaticObjectsOffset(codec, &obj.VoluntaryExits, 16)        // Offset ( 7) -        VoluntaryExits -  4 bytes
-	ssz.DefineStaticObject(codec, &obj.SyncAggregate)                           // Field  ( 8) -         SyncAggregate -  ? bytes (SyncAggregate)
-	ssz.DefineDynamicObjectOffset(codec, &obj.ExecutionPayload)                 // Offset ( 9) -      ExecutionPayload -  4 bytes
-	ssz.DefineSliceOfStaticObjectsOffset(codec, &obj.BlsToExecutionChanges, 16) // Offset (10) - BlsToExecutionChanges -  4 bytes
-	ssz.DefineSliceOfStaticBytesOffset(codec, &obj.BlobKzgCommitments, 4096)    // Offset (11) -    BlobKzgCommitments -  4 bytes
+	ssz.DefineStaticBytes(codec, &obj.RandaoReveal)                                                // Field  ( 0) -          RandaoReveal - 96 bytes
+	ssz.DefineStaticObject(codec, &obj.Eth1Data)                                                   // Field  ( 1) -              Eth1Data -  ? bytes (Eth1Data)
+	ssz.DefineStaticBytes(codec, &obj.Graffiti)                                                    // Field  ( 2) -              Graffiti - 32 bytes
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &obj.ProposerSlashings, 16)                        // Offset ( 3) -     ProposerSlashings -  4 bytes
+	ssz.DefineSliceOfDynamicObjectsOffset(codec, &obj.AttesterSlashings, 2)                        // Offset ( 4) -     AttesterSlashings -  4 bytes
+	ssz.DefineSliceOfDynamicObjectsOffset(codec, &obj.Attestations, 128)                           // Offset ( 5) -          Attestations -  4 bytes
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &obj.Deposits, 16)                                 // Offset ( 6) -              Deposits -  4 bytes
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &obj.VoluntaryExits, 16)                           // Offset ( 7) -        VoluntaryExits -  4 bytes
+	ssz.DefineStaticObject(codec, &obj.SyncAggregate)                                              // Field  ( 8) -         SyncAggregate -  ? bytes (SyncAggregate)
+	ssz.DefineDynamicObjectOffset(codec, &obj.ExecutionPayload)                                    // Offset ( 9) -      ExecutionPayload -  4 bytes
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &obj.BlsToExecutionChanges, 16)                    // Offset (10) - BlsToExecutionChanges -  4 bytes
+	ssz.DefineSliceOfStaticBytesOffset(codec, &obj.BlobKzgCommitments, MaxBlobCommitmentsPerBlock) // Offset (11) -    BlobKzgCommitments -  4 bytes
 
 	// Define the dynamic data (fields)
-	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.ProposerSlashings, 16)     // Field  ( 3) -     ProposerSlashings - ? bytes
-	ssz.DefineSliceOfDynamicObjectsContent(codec, &obj.AttesterSlashings, 2)     // Field  ( 4) -     AttesterSlashings - ? bytes
-	ssz.DefineSliceOfDynamicObjectsContent(codec, &obj.Attestations, 128)        // Field  ( 5) -          Attestations - ? bytes
-	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.Deposits, 16)              // Field  ( 6) -              Deposits - ? bytes
-	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.VoluntaryExits, 16)        // Field  ( 7) -        VoluntaryExits - ? bytes
-	ssz.DefineDynamicObjectContent(codec, &obj.ExecutionPayload)                 // Field  ( 9) -      ExecutionPayload - ? bytes
-	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.BlsToExecutionChanges, 16) // Field  (10) - BlsToExecutionChanges - ? bytes
-	ssz.DefineSliceOfStaticBytesContent(codec, &obj.BlobKzgCommitments, 4096)    // Field  (11) -    BlobKzgCommitments - ? bytes
+	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.ProposerSlashings, 16)                        // Field  ( 3) -     ProposerSlashings - ? bytes
+	ssz.DefineSliceOfDynamicObjectsContent(codec, &obj.AttesterSlashings, 2)                        // Field  ( 4) -     AttesterSlashings - ? bytes
+	ssz.DefineSliceOfDynamicObjectsContent(codec, &obj.Attestations, 128)                           // Field  ( 5) -          Attestations - ? bytes
+	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.Deposits, 16)                                 // Field  ( 6) -              Deposits - ? bytes
+	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.VoluntaryExits, 16)                           // Field  ( 7) -        VoluntaryExits - ? bytes
+	ssz.DefineDynamicObjectContent(codec, &obj.ExecutionPayload)                                    // Field  ( 9) -      ExecutionPayload - ? bytes
+	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.BlsToExecutionChanges, 16)                    // Field  (10) - BlsToExecutionChanges - ? bytes
+	ssz.DefineSliceOfStaticBytesContent(codec, &obj.BlobKzgCommitments, MaxBlobCommitmentsPerBlock) // Field  (11) -    BlobKzgCommitments - ? bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *BeaconBlockBodyDeneb) NamesSSZ() []string {
+	return []string{"RandaoReveal", "Eth1Data", "Graffiti", "ProposerSlashings", "AttesterSlashings", "Attestations", "Deposits", "VoluntaryExits", "SyncAggregate", "ExecutionPayload", "BlsToExecutionChanges", "BlobKzgCommitments", "ProposerSlashings", "AttesterSlashings", "Attestations", "Deposits", "VoluntaryExits", "ExecutionPayload", "BlsToExecutionChanges", "BlobKzgCommitments"}
 }
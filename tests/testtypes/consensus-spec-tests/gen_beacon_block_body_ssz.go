@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp d31313c530965325ee30f9fcfd9e73fff6733b5ac9491193ce5cb453c20f6a0a
 
 package consensus_spec_tests
 
@@ -48,3 +49,8 @@ func (obj *BeaconBlockBody) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.Deposits, 16)          // Field  (6) -          Deposits - ? bytes
 	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.VoluntaryExits, 16)    // Field  (7) -    VoluntaryExits - ? bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *BeaconBlockBody) NamesSSZ() []string {
+	return []string{"RandaoReveal", "Eth1Data", "Graffiti", "ProposerSlashings", "AttesterSlashings", "Attestations", "Deposits", "VoluntaryExits", "ProposerSlashings", "AttesterSlashings", "Attestations", "Deposits", "VoluntaryExits"}
+}
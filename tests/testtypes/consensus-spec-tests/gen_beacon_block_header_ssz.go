@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp e6d69d054ca7cc6c24a59d7eaecb700692922bf55f967dabcdd7ef83c10ab5de
 
 package consensus_spec_tests
 
@@ -17,3 +18,8 @@ func (obj *BeaconBlockHeader) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineStaticBytes(codec, &obj.StateRoot)  // Field  (3) -     StateRoot - 32 bytes
 	ssz.DefineStaticBytes(codec, &obj.BodyRoot)   // Field  (4) -      BodyRoot - 32 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *BeaconBlockHeader) NamesSSZ() []string {
+	return []string{"Slot", "ProposerIndex", "ParentRoot", "StateRoot", "BodyRoot"}
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 603a9aa59812280f1382801255d3af12530b1a07bb21e9753e0b6a42c53ae2c8
 
 package consensus_spec_tests
 
@@ -28,3 +29,8 @@ func (obj *BeaconBlock) DefineSSZ(codec *ssz.Codec) {
 	// Define the dynamic data (fields)
 	ssz.DefineDynamicObjectContent(codec, &obj.Body) // Field  (4) -          Body - ? bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *BeaconBlock) NamesSSZ() []string {
+	return []string{"Slot", "ProposerIndex", "ParentRoot", "StateRoot", "Body", "Body"}
+}
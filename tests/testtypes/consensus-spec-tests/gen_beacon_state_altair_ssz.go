@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp f724281d40358fcbdff0dc2d180959c359c743fec590d950afb4010f0693b888
 
 package consensus_spec_tests
 
@@ -14,7 +15,7 @@ func (obj *BeaconStateAltair) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32
 	if fork := int(sizer.Fork()); fork < len(staticSizeCacheBeaconStateAltair) {
 		size = staticSizeCacheBeaconStateAltair[fork]
 	} else {
-		size = 8 + 32 + 8 + (*Fork)(nil).SizeSSZ(sizer) + (*BeaconBlockHeader)(nil).SizeSSZ(sizer) + 8192*32 + 8192*32 + 4 + (*Eth1Data)(nil).SizeSSZ(sizer) + 4 + 8 + 4 + 4 + 65536*32 + 8192*8 + 4 + 4 + 1 + (*Checkpoint)(nil).SizeSSZ(sizer) + (*Checkpoint)(nil).SizeSSZ(sizer) + (*Checkpoint)(nil).SizeSSZ(sizer) + 4 + (*SyncCommittee)(nil).SizeSSZ(sizer) + (*SyncCommittee)(nil).SizeSSZ(sizer)
+		size = 8 + 32 + 8 + (*Fork)(nil).SizeSSZ(sizer) + (*BeaconBlockHeader)(nil).SizeSSZ(sizer) + SlotsPerHistoricalRoot*32 + SlotsPerHistoricalRoot*32 + 4 + (*Eth1Data)(nil).SizeSSZ(sizer) + 4 + 8 + 4 + 4 + EpochsPerHistoricalVector*32 + EpochsPerSlashingsVector*8 + 4 + 4 + 1 + (*Checkpoint)(nil).SizeSSZ(sizer) + (*Checkpoint)(nil).SizeSSZ(sizer) + (*Checkpoint)(nil).SizeSSZ(sizer) + 4 + (*SyncCommittee)(nil).SizeSSZ(sizer) + (*SyncCommittee)(nil).SizeSSZ(sizer)
 	}
 	// Either return the static size or accumulate the dynamic too
 	if fixed {
@@ -43,7 +44,7 @@ func (obj *BeaconStateAltair) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.StateRoots[:])                        // Field  ( 6) -                  StateRoots -  262144 bytes
 	ssz.DefineSliceOfStaticBytesOffset(codec, &obj.HistoricalRoots, 16777216)           // Offset ( 7) -             HistoricalRoots -       4 bytes
 	ssz.DefineStaticObject(codec, &obj.Eth1Data)                                        // Field  ( 8) -                    Eth1Data -       ? bytes (Eth1Data)
-	ssz.DefineSliceOfStaticObjectsOffset(codec, &obj.Eth1DataVotes, 2048)               // Offset ( 9) -               Eth1DataVotes -       4 bytes
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &obj.Eth1DataVotes, MaxEth1DataVotes)   // Offset ( 9) -               Eth1DataVotes -       4 bytes
 	ssz.DefineUint64(codec, &obj.Eth1DepositIndex)                                      // Field  (10) -            Eth1DepositIndex -       8 bytes
 	ssz.DefineSliceOfStaticObjectsOffset(codec, &obj.Validators, 1099511627776)         // Offset (11) -                  Validators -       4 bytes
 	ssz.DefineSliceOfUint64sOffset(codec, &obj.Balances, 1099511627776)                 // Offset (12) -                    Balances -       4 bytes
@@ -61,10 +62,15 @@ func (obj *BeaconStateAltair) DefineSSZ(codec *ssz.Codec) {
 
 	// Define the dynamic data (fields)
 	ssz.DefineSliceOfStaticBytesContent(codec, &obj.HistoricalRoots, 16777216)           // Field  ( 7) -             HistoricalRoots - ? bytes
-	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.Eth1DataVotes, 2048)               // Field  ( 9) -               Eth1DataVotes - ? bytes
+	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.Eth1DataVotes, MaxEth1DataVotes)   // Field  ( 9) -               Eth1DataVotes - ? bytes
 	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.Validators, 1099511627776)         // Field  (11) -                  Validators - ? bytes
 	ssz.DefineSliceOfUint64sContent(codec, &obj.Balances, 1099511627776)                 // Field  (12) -                    Balances - ? bytes
 	ssz.DefineDynamicBytesContent(codec, &obj.PreviousEpochParticipation, 1099511627776) // Field  (15) -  PreviousEpochParticipation - ? bytes
 	ssz.DefineDynamicBytesContent(codec, &obj.CurrentEpochParticipation, 1099511627776)  // Field  (16) -   CurrentEpochParticipation - ? bytes
 	ssz.DefineSliceOfUint64sContent(codec, &obj.InactivityScores, 1099511627776)         // Field  (21) -            InactivityScores - ? bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *BeaconStateAltair) NamesSSZ() []string {
+	return []string{"GenesisTime", "GenesisValidatorsRoot", "Slot", "Fork", "LatestBlockHeader", "BlockRoots", "StateRoots", "HistoricalRoots", "Eth1Data", "Eth1DataVotes", "Eth1DepositIndex", "Validators", "Balances", "RandaoMixes", "Slashings", "PreviousEpochParticipation", "CurrentEpochParticipation", "JustificationBits", "PreviousJustifiedCheckpoint", "CurrentJustifiedCheckpoint", "FinalizedCheckpoint", "InactivityScores", "CurrentSyncCommittee", "NextSyncCommittee", "HistoricalRoots", "Eth1DataVotes", "Validators", "Balances", "PreviousEpochParticipation", "CurrentEpochParticipation", "InactivityScores"}
+}
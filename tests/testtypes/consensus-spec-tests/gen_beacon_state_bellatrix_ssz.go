@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 137e776f371bda196ec59e24d0c980a1386c0c8d0935c8f2d8a43f1c95d170bf
 
 package consensus_spec_tests
 
@@ -14,7 +15,7 @@ func (obj *BeaconStateBellatrix) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uin
 	if fork := int(sizer.Fork()); fork < len(staticSizeCacheBeaconStateBellatrix) {
 		size = staticSizeCacheBeaconStateBellatrix[fork]
 	} else {
-		size = 8 + 32 + 8 + (*Fork)(nil).SizeSSZ(sizer) + (*BeaconBlockHeader)(nil).SizeSSZ(sizer) + 8192*32 + 8192*32 + 4 + (*Eth1Data)(nil).SizeSSZ(sizer) + 4 + 8 + 4 + 4 + 65536*32 + 8192*8 + 4 + 4 + 1 + (*Checkpoint)(nil).SizeSSZ(sizer) + (*Checkpoint)(nil).SizeSSZ(sizer) + (*Checkpoint)(nil).SizeSSZ(sizer) + 4 + (*SyncCommittee)(nil).SizeSSZ(sizer) + (*SyncCommittee)(nil).SizeSSZ(sizer) + 4
+		size = 8 + 32 + 8 + (*Fork)(nil).SizeSSZ(sizer) + (*BeaconBlockHeader)(nil).SizeSSZ(sizer) + SlotsPerHistoricalRoot*32 + SlotsPerHistoricalRoot*32 + 4 + (*Eth1Data)(nil).SizeSSZ(sizer) + 4 + 8 + 4 + 4 + EpochsPerHistoricalVector*32 + EpochsPerSlashingsVector*8 + 4 + 4 + 1 + (*Checkpoint)(nil).SizeSSZ(sizer) + (*Checkpoint)(nil).SizeSSZ(sizer) + (*Checkpoint)(nil).SizeSSZ(sizer) + 4 + (*SyncCommittee)(nil).SizeSSZ(sizer) + (*SyncCommittee)(nil).SizeSSZ(sizer) + 4
 	}
 	// Either return the static size or accumulate the dynamic too
 	if fixed {
@@ -44,7 +45,7 @@ func (obj *BeaconStateBellatrix) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.StateRoots[:])                        // Field  ( 6) -                   StateRoots -  262144 bytes
 	ssz.DefineSliceOfStaticBytesOffset(codec, &obj.HistoricalRoots, 16777216)           // Offset ( 7) -              HistoricalRoots -       4 bytes
 	ssz.DefineStaticObject(codec, &obj.Eth1Data)                                        // Field  ( 8) -                     Eth1Data -       ? bytes (Eth1Data)
-	ssz.DefineSliceOfStaticObjectsOffset(codec, &obj.Eth1DataVotes, 2048)               // Offset ( 9) -                Eth1DataVotes -       4 bytes
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &obj.Eth1DataVotes, MaxEth1DataVotes)   // Offset ( 9) -                Eth1DataVotes -       4 bytes
 	ssz.DefineUint64(codec, &obj.Eth1DepositIndex)                                      // Field  (10) -             Eth1DepositIndex -       8 bytes
 	ssz.DefineSliceOfStaticObjectsOffset(codec, &obj.Validators, 1099511627776)         // Offset (11) -                   Validators -       4 bytes
 	ssz.DefineSliceOfUint64sOffset(codec, &obj.Balances, 1099511627776)                 // Offset (12) -                     Balances -       4 bytes
@@ -63,7 +64,7 @@ func (obj *BeaconStateBellatrix) DefineSSZ(codec *ssz.Codec) {
 
 	// Define the dynamic data (fields)
 	ssz.DefineSliceOfStaticBytesContent(codec, &obj.HistoricalRoots, 16777216)           // Field  ( 7) -              HistoricalRoots - ? bytes
-	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.Eth1DataVotes, 2048)               // Field  ( 9) -                Eth1DataVotes - ? bytes
+	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.Eth1DataVotes, MaxEth1DataVotes)   // Field  ( 9) -                Eth1DataVotes - ? bytes
 	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.Validators, 1099511627776)         // Field  (11) -                   Validators - ? bytes
 	ssz.DefineSliceOfUint64sContent(codec, &obj.Balances, 1099511627776)                 // Field  (12) -                     Balances - ? bytes
 	ssz.DefineDynamicBytesContent(codec, &obj.PreviousEpochParticipation, 1099511627776) // Field  (15) -   PreviousEpochParticipation - ? bytes
@@ -71,3 +72,8 @@ func (obj *BeaconStateBellatrix) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineSliceOfUint64sContent(codec, &obj.InactivityScores, 1099511627776)         // Field  (21) -             InactivityScores - ? bytes
 	ssz.DefineDynamicObjectContent(codec, &obj.LatestExecutionPayloadHeader)             // Field  (24) - LatestExecutionPayloadHeader - ? bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *BeaconStateBellatrix) NamesSSZ() []string {
+	return []string{"GenesisTime", "GenesisValidatorsRoot", "Slot", "Fork", "LatestBlockHeader", "BlockRoots", "StateRoots", "HistoricalRoots", "Eth1Data", "Eth1DataVotes", "Eth1DepositIndex", "Validators", "Balances", "RandaoMixes", "Slashings", "PreviousEpochParticipation", "CurrentEpochParticipation", "JustificationBits", "PreviousJustifiedCheckpoint", "CurrentJustifiedCheckpoint", "FinalizedCheckpoint", "InactivityScores", "CurrentSyncCommittee", "NextSyncCommittee", "LatestExecutionPayloadHeader", "HistoricalRoots", "Eth1DataVotes", "Validators", "Balances", "PreviousEpochParticipation", "CurrentEpochParticipation", "InactivityScores", "LatestExecutionPayloadHeader"}
+}
@@ -0,0 +1,24 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 3cd0b0edbc18faa4c4f2676d7744e2688bf91b2a39ef401d22c0c52a0dd5f542
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *BitfieldVectorsBytesVariation) SizeSSZ(sizer *ssz.Sizer) uint32 {
+	return 1 + 8 + 64 + 2*16
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *BitfieldVectorsBytesVariation) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineArrayOfBits(codec, &obj.Flags, 4)                // Field  (0) -      Flags -  1 bytes
+	ssz.DefineArrayOfBits(codec, &obj.Justified, 64)           // Field  (1) -  Justified -  8 bytes
+	ssz.DefineArrayOfBits(codec, &obj.Attesters, 512)          // Field  (2) -  Attesters - 64 bytes
+	ssz.DefineUnsafeArrayOfBits(codec, obj.Aggregates[:], 128) // Field  (3) - Aggregates - 32 bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *BitfieldVectorsBytesVariation) NamesSSZ() []string {
+	return []string{"Flags", "Justified", "Attesters", "Aggregates"}
+}
@@ -0,0 +1,24 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 8ec54a567aaf2f3de81174590b52f9bb7e783394409f09e84a100c50a74e8a55
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *BitfieldVectorsVariation) SizeSSZ(sizer *ssz.Sizer) uint32 {
+	return 1 + 8 + 64 + 2*16
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *BitfieldVectorsVariation) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineCheckedBits(codec, &obj.Flags, 4)                       // Field  (0) -      Flags -  1 bytes
+	ssz.DefineCheckedBits(codec, &obj.Justified, 64)                  // Field  (1) -  Justified -  8 bytes
+	ssz.DefineCheckedBits(codec, &obj.Attesters, 512)                 // Field  (2) -  Attesters - 64 bytes
+	ssz.DefineUnsafeArrayOfCheckedBits(codec, obj.Aggregates[:], 128) // Field  (3) - Aggregates - 32 bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *BitfieldVectorsVariation) NamesSSZ() []string {
+	return []string{"Flags", "Justified", "Attesters", "Aggregates"}
+}
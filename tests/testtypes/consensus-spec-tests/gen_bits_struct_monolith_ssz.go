@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 608e986445a5c73afd8d0de65802b4001dc9417cd9615859bbd29f7548b3d369
 
 package consensus_spec_tests
 
@@ -35,3 +36,8 @@ func (obj *BitsStructMonolith) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineSliceOfBitsContentOnFork(codec, &obj.A, 5, ssz.ForkFilter{Added: ssz.ForkUnknown}) // Field  (0) - A - ? bytes
 	ssz.DefineSliceOfBitsContent(codec, &obj.D, 6)                                               // Field  (3) - D - ? bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *BitsStructMonolith) NamesSSZ() []string {
+	return []string{"A", "B", "C", "D", "E", "A", "D"}
+}
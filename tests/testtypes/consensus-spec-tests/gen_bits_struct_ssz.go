@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp e3c3f5d2acd447259a560dad791015babf54f02f85e5d8e05f9c02c2c0f77bd5
 
 package consensus_spec_tests
 
@@ -30,3 +31,8 @@ func (obj *BitsStruct) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineSliceOfBitsContent(codec, &obj.A, 5) // Field  (0) - A - ? bytes
 	ssz.DefineSliceOfBitsContent(codec, &obj.D, 6) // Field  (3) - D - ? bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *BitsStruct) NamesSSZ() []string {
+	return []string{"A", "B", "C", "D", "E", "A", "D"}
+}
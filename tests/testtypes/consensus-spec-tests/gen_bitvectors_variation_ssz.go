@@ -0,0 +1,23 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 5da1b920d2ff36c2e882f68943781572fc67846896a0f8fbaed739671773d020
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *BitvectorsVariation) SizeSSZ(sizer *ssz.Sizer) uint32 {
+	return 4*64 + 8*4 + 2*16
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *BitvectorsVariation) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUnsafeArrayOfBits(codec, obj.Participation[:], 512)     // Field  (0) - Participation - 256 bytes
+	ssz.DefineCheckedArrayOfBits(codec, &obj.Committees, 8, 30)       // Field  (1) -    Committees -  32 bytes
+	ssz.DefineUnsafeArrayOfCheckedBits(codec, obj.Aggregates[:], 128) // Field  (2) -    Aggregates -  32 bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *BitvectorsVariation) NamesSSZ() []string {
+	return []string{"Participation", "Committees", "Aggregates"}
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 39d61e6c870c44af95cbab247878521ed89e576c8d783c0256e0a787bd3613a4
 
 package consensus_spec_tests
 
@@ -15,3 +16,8 @@ func (obj *BLSToExecutionChange) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineStaticBytes(codec, &obj.FromBLSPubKey)      // Field  (1) -      FromBLSPubKey - 48 bytes
 	ssz.DefineStaticBytes(codec, &obj.ToExecutionAddress) // Field  (2) - ToExecutionAddress - 20 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *BLSToExecutionChange) NamesSSZ() []string {
+	return []string{"ValidatorIndex", "FromBLSPubKey", "ToExecutionAddress"}
+}
@@ -0,0 +1,30 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 8f545ee09e97717593a3f495bae9fb2eb6f1859cc4614baabb728e4d9056edf2
+
+package consensus_spec_tests
+
+import (
+	"github.com/holiman/uint256"
+	"github.com/karalabe/ssz"
+)
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *BoundedValuesVariation) SizeSSZ(sizer *ssz.Sizer) (size uint32) {
+	size = 32 + 32
+	if sizer.Fork() >= ssz.ForkDeneb {
+		size += 32
+	}
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *BoundedValuesVariation) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint256Max(codec, &obj.BaseFee, uint256.Int{1000000000000})                                       // Field  (0) -    BaseFee - 32 bytes
+	ssz.DefineUint256BigIntMax(codec, &obj.Difficulty, uint256.Int{18446744073709551615, 18446744073709551615}) // Field  (1) - Difficulty - 32 bytes
+	ssz.DefineUint256MaxOnFork(codec, &obj.BlobFee, uint256.Int{1000000}, ssz.ForkFilter{Added: ssz.ForkDeneb}) // Field  (2) -    BlobFee - 32 bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *BoundedValuesVariation) NamesSSZ() []string {
+	return []string{"BaseFee", "Difficulty", "BlobFee"}
+}
@@ -0,0 +1,70 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 24a1ccc129c8ae3a000eafe39f204fe012497abc73864bf108d009613b9d21c0
+
+package consensus_spec_tests
+
+import (
+	"github.com/karalabe/ssz"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+// Cached static size computed on package init.
+var staticSizeCacheCachedAttestationVariation = ssz.PrecomputeStaticSizeCache((*CachedAttestationVariation)(nil))
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *CachedAttestationVariation) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
+	// Load static size if already precomputed, calculate otherwise
+	if fork := int(sizer.Fork()); fork < len(staticSizeCacheCachedAttestationVariation) {
+		size = staticSizeCacheCachedAttestationVariation[fork]
+	} else {
+		size = 4 + (*AttestationData)(nil).SizeSSZ(sizer) + 96
+	}
+	// Either return the static size or accumulate the dynamic too
+	if fixed {
+		return size
+	}
+	size += ssz.SizeSliceOfBits(sizer, obj.AggregationBits)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *CachedAttestationVariation) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineSliceOfBitsOffset(codec, &obj.AggregationBits, 2048) // Offset (0) - AggregationBits -  4 bytes
+	ssz.DefineStaticObject(codec, &obj.Data)                       // Field  (1) -            Data -  ? bytes (AttestationData)
+	ssz.DefineStaticBytes(codec, &obj.Signature)                   // Field  (2) -       Signature - 96 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineSliceOfBitsContent(codec, &obj.AggregationBits, 2048) // Field  (0) - AggregationBits - ? bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *CachedAttestationVariation) NamesSSZ() []string {
+	return []string{"AggregationBits", "Data", "Signature", "AggregationBits"}
+}
+
+// HashTreeRootCached returns the merkle root of the object in the given fork,
+// only rehashing it if it was modified (via the setters) since the last call.
+func (obj *CachedAttestationVariation) HashTreeRootCached(fork ssz.Fork) [32]byte {
+	return ssz.HashSequentialCached(obj, &obj.RootCache, fork)
+}
+
+// SetAggregationBits sets the AggregationBits field, invalidating the cached merkle root.
+func (obj *CachedAttestationVariation) SetAggregationBits(v bitfield.Bitlist) {
+	obj.AggregationBits = v
+	obj.RootCache.Invalidate()
+}
+
+// SetData sets the Data field, invalidating the cached merkle root.
+func (obj *CachedAttestationVariation) SetData(v *AttestationData) {
+	obj.Data = v
+	obj.RootCache.Invalidate()
+}
+
+// SetSignature sets the Signature field, invalidating the cached merkle root.
+func (obj *CachedAttestationVariation) SetSignature(v [96]byte) {
+	obj.Signature = v
+	obj.RootCache.Invalidate()
+}
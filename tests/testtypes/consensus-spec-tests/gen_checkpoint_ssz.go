@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 18e344ef74017fb6cf44ee0be08ed153f6cdd4a5c6274325c1e3c59e6fc255dc
 
 package consensus_spec_tests
 
@@ -14,3 +15,8 @@ func (obj *Checkpoint) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineUint64(codec, &obj.Epoch)     // Field  (0) - Epoch -  8 bytes
 	ssz.DefineStaticBytes(codec, &obj.Root) // Field  (1) -  Root - 32 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *Checkpoint) NamesSSZ() []string {
+	return []string{"Epoch", "Root"}
+}
@@ -0,0 +1,30 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 8ace4433ecd312963f57473e44ce0a12c00a35940e45950609bafe6f4b409b43
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *CustomForkMonolith) SizeSSZ(sizer *ssz.Sizer) (size uint32) {
+	size = 8
+	if sizer.Fork() >= ForkCustom {
+		size += 8
+	}
+	if sizer.Fork() >= ssz.ForkDeneb && sizer.Fork() < ForkCustom {
+		size += 8
+	}
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *CustomForkMonolith) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &obj.A)                                                                         // Field  (0) - A - 8 bytes
+	ssz.DefineUint64PointerOnFork(codec, &obj.B, ssz.ForkFilter{Added: ForkCustom})                         // Field  (1) - B - 8 bytes
+	ssz.DefineUint64PointerOnFork(codec, &obj.C, ssz.ForkFilter{Added: ssz.ForkDeneb, Removed: ForkCustom}) // Field  (2) - C - 8 bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *CustomForkMonolith) NamesSSZ() []string {
+	return []string{"A", "B", "C"}
+}
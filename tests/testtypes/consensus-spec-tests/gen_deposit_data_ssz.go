@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp ae556c0c8637815f3e2f6437ac422aa6e4dab98b546831a59da01d7fbee018c6
 
 package consensus_spec_tests
 
@@ -16,3 +17,8 @@ func (obj *DepositData) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineUint64(codec, &obj.Amount)                     // Field  (2) -                Amount -  8 bytes
 	ssz.DefineStaticBytes(codec, &obj.Signature)             // Field  (3) -             Signature - 96 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *DepositData) NamesSSZ() []string {
+	return []string{"Pubkey", "WithdrawalCredentials", "Amount", "Signature"}
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 992fee7e712f49916bfcd38c79d07e06f86c1ecc938918cdb939e1fb084604cc
 
 package consensus_spec_tests
 
@@ -15,3 +16,8 @@ func (obj *DepositMessage) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineStaticBytes(codec, &obj.WithdrawalCredentials) // Field  (1) - WithdrawalCredentials - 32 bytes
 	ssz.DefineUint64(codec, &obj.Amount)                     // Field  (2) -                Amount -  8 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *DepositMessage) NamesSSZ() []string {
+	return []string{"Pubkey", "WithdrawalCredentials", "Amount"}
+}
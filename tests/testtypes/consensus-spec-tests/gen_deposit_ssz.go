@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp eba83ecf62a2e9e239bf662a4cc6140b2449bcc0a674f2035299a2f774e03371
 
 package consensus_spec_tests
 
@@ -21,3 +22,8 @@ func (obj *Deposit) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.Proof[:]) // Field  (0) - Proof - 1056 bytes
 	ssz.DefineStaticObject(codec, &obj.Data)                // Field  (1) -  Data -    ? bytes (DepositData)
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *Deposit) NamesSSZ() []string {
+	return []string{"Proof", "Data"}
+}
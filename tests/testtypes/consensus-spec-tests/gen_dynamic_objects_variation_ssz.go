@@ -0,0 +1,32 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 5b1f20b95112e7fe0e748e14bac093084946af774bac2d3abef67bf4e668da44
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *DynamicObjectsVariation) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
+	size = 4
+	if fixed {
+		return size
+	}
+	size += ssz.SizeSliceOfDynamicObjects(sizer, obj.Payloads)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *DynamicObjectsVariation) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineSliceOfDynamicObjectsOffset(codec, &obj.Payloads, 16) // Offset (0) - Payloads - 4 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineSliceOfDynamicObjectsContent(codec, &obj.Payloads, 16) // Field  (0) - Payloads - ? bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *DynamicObjectsVariation) NamesSSZ() []string {
+	return []string{"Payloads", "Payloads"}
+}
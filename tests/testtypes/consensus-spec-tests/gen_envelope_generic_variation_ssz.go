@@ -0,0 +1,75 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp b037c3698380b417b520a3dff2a7a3437e9132d39082be4adeb7444e2ec473f2
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *EnvelopeGenericVariation[T]) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
+	size = 8 + 4 + 4
+	if sizer.Fork() >= ssz.ForkDeneb {
+		size += 4
+	}
+	if fixed {
+		return size
+	}
+	size += ssz.SizeGenericDynamicObject(sizer, obj.Payload)
+	size += ssz.SizeDynamicBytes(sizer, obj.Blob)
+	if sizer.Fork() >= ssz.ForkDeneb {
+		size += ssz.SizeGenericDynamicObject(sizer, obj.Extra)
+	}
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *EnvelopeGenericVariation[T]) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineUint64(codec, &obj.Slot)                                                                  // Field  (0) -    Slot - 8 bytes
+	ssz.DefineGenericDynamicObjectOffset(codec, &obj.Payload)                                           // Offset (1) - Payload - 4 bytes
+	ssz.DefineDynamicBytesOffset(codec, &obj.Blob, 32)                                                  // Offset (2) -    Blob - 4 bytes
+	ssz.DefineGenericDynamicObjectOffsetOnFork(codec, &obj.Extra, ssz.ForkFilter{Added: ssz.ForkDeneb}) // Offset (3) -   Extra - 4 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineGenericDynamicObjectContent(codec, &obj.Payload)                                           // Field  (1) - Payload - ? bytes
+	ssz.DefineDynamicBytesContent(codec, &obj.Blob, 32)                                                  // Field  (2) -    Blob - ? bytes
+	ssz.DefineGenericDynamicObjectContentOnFork(codec, &obj.Extra, ssz.ForkFilter{Added: ssz.ForkDeneb}) // Field  (3) -   Extra - ? bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *EnvelopeGenericVariation[T]) NamesSSZ() []string {
+	return []string{"Slot", "Payload", "Blob", "Extra", "Payload", "Blob", "Extra"}
+}
+
+// GetSlot returns the Slot field, or its zero value if the object is nil.
+func (obj *EnvelopeGenericVariation[T]) GetSlot() uint64 {
+	if obj != nil {
+		return obj.Slot
+	}
+	return 0
+}
+
+// GetPayload returns the Payload field, or its zero value if the object is nil.
+func (obj *EnvelopeGenericVariation[T]) GetPayload() T {
+	if obj != nil {
+		return obj.Payload
+	}
+	return *new(T)
+}
+
+// GetBlob returns the Blob field, or its zero value if the object is nil.
+func (obj *EnvelopeGenericVariation[T]) GetBlob() []byte {
+	if obj != nil {
+		return obj.Blob
+	}
+	return nil
+}
+
+// GetExtra returns the Extra field, or its zero value if the object is nil.
+func (obj *EnvelopeGenericVariation[T]) GetExtra() T {
+	if obj != nil {
+		return obj.Extra
+	}
+	return *new(T)
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 39a2d58a85a28eccdb12b9fd5f2c187571c13ad0adf0ed6da7a5d4b1db9e44e0
 
 package consensus_spec_tests
 
@@ -15,3 +16,8 @@ func (obj *Eth1Block) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineStaticBytes(codec, &obj.DepositRoot) // Field  (1) -  DepositRoot - 32 bytes
 	ssz.DefineUint64(codec, &obj.DepositCount)     // Field  (2) - DepositCount -  8 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *Eth1Block) NamesSSZ() []string {
+	return []string{"Timestamp", "DepositRoot", "DepositCount"}
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp e270616c32548792ecb6087a9213112c6211858bdd63c237ba2a9d2b0d389729
 
 package consensus_spec_tests
 
@@ -15,3 +16,8 @@ func (obj *Eth1Data) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineUint64(codec, &obj.DepositCount)     // Field  (1) - DepositCount -  8 bytes
 	ssz.DefineStaticBytes(codec, &obj.BlockHash)   // Field  (2) -    BlockHash - 32 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *Eth1Data) NamesSSZ() []string {
+	return []string{"DepositRoot", "DepositCount", "BlockHash"}
+}
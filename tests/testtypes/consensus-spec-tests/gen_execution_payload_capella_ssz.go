@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 564b3a8c570e16c0ce782bd3e080824ae4a0ed38468e8dd2bd547889fec244f4
 
 package consensus_spec_tests
 
@@ -21,24 +22,29 @@ func (obj *ExecutionPayloadCapella) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size
 // DefineSSZ defines how an object is encoded/decoded.
 func (obj *ExecutionPayloadCapella) DefineSSZ(codec *ssz.Codec) {
 	// Define the static data (fields and dynamic offsets)
-	ssz.DefineStaticBytes(codec, &obj.ParentHash)                                      // Field  ( 0) -    ParentHash -  32 bytes
-	ssz.DefineStaticBytes(codec, &obj.FeeRecipient)                                    // Field  ( 1) -  FeeRecipient -  20 bytes
-	ssz.DefineStaticBytes(codec, &obj.StateRoot)                                       // Field  ( 2) -     StateRoot -  32 bytes
-	ssz.DefineStaticBytes(codec, &obj.ReceiptsRoot)                                    // Field  ( 3) -  ReceiptsRoot -  32 bytes
-	ssz.DefineStaticBytes(codec, &obj.LogsBloom)                                       // Field  ( 4) -     LogsBloom - 256 bytes
-	ssz.DefineStaticBytes(codec, &obj.PrevRandao)                                      // Field  ( 5) -    PrevRandao -  32 bytes
-	ssz.DefineUint64(codec, &obj.BlockNumber)                                          // Field  ( 6) -   BlockNumber -   8 bytes
-	ssz.DefineUint64(codec, &obj.GasLimit)                                             // Field  ( 7) -      GasLimit -   8 bytes
-	ssz.DefineUint64(codec, &obj.GasUsed)                                              // Field  ( 8) -       GasUsed -   8 bytes
-	ssz.DefineUint64(codec, &obj.Timestamp)                                            // Field  ( 9) -     Timestamp -   8 bytes
-	ssz.DefineDynamicBytesOffset(codec, &obj.ExtraData, 32)                            // Offset (10) -     ExtraData -   4 bytes
-	ssz.DefineUint256(codec, &obj.BaseFeePerGas)                                       // Field  (11) - BaseFeePerGas -  32 bytes
-	ssz.DefineStaticBytes(codec, &obj.BlockHash)                                       // Field  (12) -     BlockHash -  32 bytes
-	ssz.DefineSliceOfDynamicBytesOffset(codec, &obj.Transactions, 1048576, 1073741824) // Offset (13) -  Transactions -   4 bytes
-	ssz.DefineSliceOfStaticObjectsOffset(codec, &obj.Withdrawals, 16)                  // Offset (14) -   Withdrawals -   4 bytes
+	ssz.DefineStaticBytes(codec, &obj.ParentHash)                                           // Field  ( 0) -    ParentHash -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.FeeRecipient)                                         // Field  ( 1) -  FeeRecipient -  20 bytes
+	ssz.DefineStaticBytes(codec, &obj.StateRoot)                                            // Field  ( 2) -     StateRoot -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.ReceiptsRoot)                                         // Field  ( 3) -  ReceiptsRoot -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.LogsBloom)                                            // Field  ( 4) -     LogsBloom - 256 bytes
+	ssz.DefineStaticBytes(codec, &obj.PrevRandao)                                           // Field  ( 5) -    PrevRandao -  32 bytes
+	ssz.DefineUint64(codec, &obj.BlockNumber)                                               // Field  ( 6) -   BlockNumber -   8 bytes
+	ssz.DefineUint64(codec, &obj.GasLimit)                                                  // Field  ( 7) -      GasLimit -   8 bytes
+	ssz.DefineUint64(codec, &obj.GasUsed)                                                   // Field  ( 8) -       GasUsed -   8 bytes
+	ssz.DefineUint64(codec, &obj.Timestamp)                                                 // Field  ( 9) -     Timestamp -   8 bytes
+	ssz.DefineDynamicBytesOffset(codec, &obj.ExtraData, 32)                                 // Offset (10) -     ExtraData -   4 bytes
+	ssz.DefineUint256(codec, &obj.BaseFeePerGas)                                            // Field  (11) - BaseFeePerGas -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.BlockHash)                                            // Field  (12) -     BlockHash -  32 bytes
+	ssz.DefineSliceOfDynamicBytesOffset(codec, &obj.Transactions, 1048576, 1073741824)      // Offset (13) -  Transactions -   4 bytes
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &obj.Withdrawals, MaxWithdrawalsPerPayload) // Offset (14) -   Withdrawals -   4 bytes
 
 	// Define the dynamic data (fields)
-	ssz.DefineDynamicBytesContent(codec, &obj.ExtraData, 32)                            // Field  (10) -     ExtraData - ? bytes
-	ssz.DefineSliceOfDynamicBytesContent(codec, &obj.Transactions, 1048576, 1073741824) // Field  (13) -  Transactions - ? bytes
-	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.Withdrawals, 16)                  // Field  (14) -   Withdrawals - ? bytes
+	ssz.DefineDynamicBytesContent(codec, &obj.ExtraData, 32)                                 // Field  (10) -     ExtraData - ? bytes
+	ssz.DefineSliceOfDynamicBytesContent(codec, &obj.Transactions, 1048576, 1073741824)      // Field  (13) -  Transactions - ? bytes
+	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.Withdrawals, MaxWithdrawalsPerPayload) // Field  (14) -   Withdrawals - ? bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *ExecutionPayloadCapella) NamesSSZ() []string {
+	return []string{"ParentHash", "FeeRecipient", "StateRoot", "ReceiptsRoot", "LogsBloom", "PrevRandao", "BlockNumber", "GasLimit", "GasUsed", "Timestamp", "ExtraData", "BaseFeePerGas", "BlockHash", "Transactions", "Withdrawals", "ExtraData", "Transactions", "Withdrawals"}
 }
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 1037562570be0e76150f52848c7bf1feee72de10c42a471fc3c8f1b602d59c03
 
 package consensus_spec_tests
 
@@ -38,3 +39,8 @@ func (obj *ExecutionPayloadHeaderCapella) DefineSSZ(codec *ssz.Codec) {
 	// Define the dynamic data (fields)
 	ssz.DefineDynamicBytesContent(codec, &obj.ExtraData, 32) // Field  (10) -        ExtraData - ? bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *ExecutionPayloadHeaderCapella) NamesSSZ() []string {
+	return []string{"ParentHash", "FeeRecipient", "StateRoot", "ReceiptsRoot", "LogsBloom", "PrevRandao", "BlockNumber", "GasLimit", "GasUsed", "Timestamp", "ExtraData", "BaseFeePerGas", "BlockHash", "TransactionsRoot", "WithdrawalRoot", "ExtraData"}
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 515bb0de04cdf2c7738f9a2fbf6913093d097dfc5ff83a7d6efeafd2a2f3184d
 
 package consensus_spec_tests
 
@@ -40,3 +41,8 @@ func (obj *ExecutionPayloadHeaderDeneb) DefineSSZ(codec *ssz.Codec) {
 	// Define the dynamic data (fields)
 	ssz.DefineDynamicBytesContent(codec, &obj.ExtraData, 32) // Field  (10) -        ExtraData - ? bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *ExecutionPayloadHeaderDeneb) NamesSSZ() []string {
+	return []string{"ParentHash", "FeeRecipient", "StateRoot", "ReceiptsRoot", "LogsBloom", "PrevRandao", "BlockNumber", "GasLimit", "GasUsed", "Timestamp", "ExtraData", "BaseFeePerGas", "BlockHash", "TransactionsRoot", "WithdrawalRoot", "BlobGasUsed", "ExcessBlobGas", "ExtraData"}
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 3e3543352a7afe6a88d6a6d6335e4fec37fcff3fb9469eedda14b33b3f46f9f3
 
 package consensus_spec_tests
 
@@ -51,3 +52,8 @@ func (obj *ExecutionPayloadHeaderMonolith) DefineSSZ(codec *ssz.Codec) {
 	// Define the dynamic data (fields)
 	ssz.DefineDynamicBytesContentOnFork(codec, &obj.ExtraData, 32, ssz.ForkFilter{Added: ssz.ForkFrontier}) // Field  (10) -        ExtraData - ? bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *ExecutionPayloadHeaderMonolith) NamesSSZ() []string {
+	return []string{"ParentHash", "FeeRecipient", "StateRoot", "ReceiptsRoot", "LogsBloom", "PrevRandao", "BlockNumber", "GasLimit", "GasUsed", "Timestamp", "ExtraData", "BaseFeePerGas", "BlockHash", "TransactionsRoot", "WithdrawalRoot", "BlobGasUsed", "ExcessBlobGas", "ExtraData"}
+}
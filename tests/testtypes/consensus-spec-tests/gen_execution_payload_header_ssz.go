@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 72be0a320ecac5735cd0937a0f779679e7547d77099553c1d893c6fb5f3a0c69
 
 package consensus_spec_tests
 
@@ -37,3 +38,8 @@ func (obj *ExecutionPayloadHeader) DefineSSZ(codec *ssz.Codec) {
 	// Define the dynamic data (fields)
 	ssz.DefineDynamicBytesContent(codec, &obj.ExtraData, 32) // Field  (10) -        ExtraData - ? bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *ExecutionPayloadHeader) NamesSSZ() []string {
+	return []string{"ParentHash", "FeeRecipient", "StateRoot", "ReceiptsRoot", "LogsBloom", "PrevRandao", "BlockNumber", "GasLimit", "GasUsed", "Timestamp", "ExtraData", "BaseFeePerGas", "BlockHash", "TransactionsRoot", "ExtraData"}
+}
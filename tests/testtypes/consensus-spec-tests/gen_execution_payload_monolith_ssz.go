@@ -1,8 +1,13 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 3b5fcb601fae85637d0922f9ea392d52ca7b2db07094ce541642f68389cd6ff0
 
 package consensus_spec_tests
 
-import "github.com/karalabe/ssz"
+import (
+	"bytes"
+	"github.com/holiman/uint256"
+	"github.com/karalabe/ssz"
+)
 
 // SizeSSZ returns either the static size of the object if fixed == true, or
 // the total size otherwise.
@@ -42,26 +47,242 @@ func (obj *ExecutionPayloadMonolith) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size
 // DefineSSZ defines how an object is encoded/decoded.
 func (obj *ExecutionPayloadMonolith) DefineSSZ(codec *ssz.Codec) {
 	// Define the static data (fields and dynamic offsets)
-	ssz.DefineStaticBytes(codec, &obj.ParentHash)                                                                                    // Field  ( 0) -    ParentHash -  32 bytes
-	ssz.DefineStaticBytes(codec, &obj.FeeRecipient)                                                                                  // Field  ( 1) -  FeeRecipient -  20 bytes
-	ssz.DefineStaticBytes(codec, &obj.StateRoot)                                                                                     // Field  ( 2) -     StateRoot -  32 bytes
-	ssz.DefineStaticBytes(codec, &obj.ReceiptsRoot)                                                                                  // Field  ( 3) -  ReceiptsRoot -  32 bytes
-	ssz.DefineStaticBytes(codec, &obj.LogsBloom)                                                                                     // Field  ( 4) -     LogsBloom - 256 bytes
-	ssz.DefineStaticBytes(codec, &obj.PrevRandao)                                                                                    // Field  ( 5) -    PrevRandao -  32 bytes
-	ssz.DefineUint64(codec, &obj.BlockNumber)                                                                                        // Field  ( 6) -   BlockNumber -   8 bytes
-	ssz.DefineUint64(codec, &obj.GasLimit)                                                                                           // Field  ( 7) -      GasLimit -   8 bytes
-	ssz.DefineUint64(codec, &obj.GasUsed)                                                                                            // Field  ( 8) -       GasUsed -   8 bytes
-	ssz.DefineUint64(codec, &obj.Timestamp)                                                                                          // Field  ( 9) -     Timestamp -   8 bytes
-	ssz.DefineDynamicBytesOffsetOnFork(codec, &obj.ExtraData, 32, ssz.ForkFilter{Added: ssz.ForkFrontier})                           // Offset (10) -     ExtraData -   4 bytes
-	ssz.DefineUint256OnFork(codec, &obj.BaseFeePerGas, ssz.ForkFilter{Added: ssz.ForkUnknown})                                       // Field  (11) - BaseFeePerGas -  32 bytes
-	ssz.DefineStaticBytes(codec, &obj.BlockHash)                                                                                     // Field  (12) -     BlockHash -  32 bytes
-	ssz.DefineSliceOfDynamicBytesOffsetOnFork(codec, &obj.Transactions, 1048576, 1073741824, ssz.ForkFilter{Added: ssz.ForkUnknown}) // Offset (13) -  Transactions -   4 bytes
-	ssz.DefineSliceOfStaticObjectsOffsetOnFork(codec, &obj.Withdrawals, 16, ssz.ForkFilter{Added: ssz.ForkShanghai})                 // Offset (14) -   Withdrawals -   4 bytes
-	ssz.DefineUint64PointerOnFork(codec, &obj.BlobGasUsed, ssz.ForkFilter{Added: ssz.ForkCancun})                                    // Field  (15) -   BlobGasUsed -   8 bytes
-	ssz.DefineUint64PointerOnFork(codec, &obj.ExcessBlobGas, ssz.ForkFilter{Added: ssz.ForkCancun})                                  // Field  (16) - ExcessBlobGas -   8 bytes
+	ssz.DefineStaticBytes(codec, &obj.ParentHash)                                                                                          // Field  ( 0) -    ParentHash -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.FeeRecipient)                                                                                        // Field  ( 1) -  FeeRecipient -  20 bytes
+	ssz.DefineStaticBytes(codec, &obj.StateRoot)                                                                                           // Field  ( 2) -     StateRoot -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.ReceiptsRoot)                                                                                        // Field  ( 3) -  ReceiptsRoot -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.LogsBloom)                                                                                           // Field  ( 4) -     LogsBloom - 256 bytes
+	ssz.DefineStaticBytes(codec, &obj.PrevRandao)                                                                                          // Field  ( 5) -    PrevRandao -  32 bytes
+	ssz.DefineUint64(codec, &obj.BlockNumber)                                                                                              // Field  ( 6) -   BlockNumber -   8 bytes
+	ssz.DefineUint64(codec, &obj.GasLimit)                                                                                                 // Field  ( 7) -      GasLimit -   8 bytes
+	ssz.DefineUint64(codec, &obj.GasUsed)                                                                                                  // Field  ( 8) -       GasUsed -   8 bytes
+	ssz.DefineUint64(codec, &obj.Timestamp)                                                                                                // Field  ( 9) -     Timestamp -   8 bytes
+	ssz.DefineDynamicBytesOffsetOnFork(codec, &obj.ExtraData, 32, ssz.ForkFilter{Added: ssz.ForkFrontier})                                 // Offset (10) -     ExtraData -   4 bytes
+	ssz.DefineUint256OnFork(codec, &obj.BaseFeePerGas, ssz.ForkFilter{Added: ssz.ForkUnknown})                                             // Field  (11) - BaseFeePerGas -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.BlockHash)                                                                                           // Field  (12) -     BlockHash -  32 bytes
+	ssz.DefineSliceOfDynamicBytesOffsetOnFork(codec, &obj.Transactions, 1048576, 1073741824, ssz.ForkFilter{Added: ssz.ForkUnknown})       // Offset (13) -  Transactions -   4 bytes
+	ssz.DefineSliceOfStaticObjectsOffsetOnFork(codec, &obj.Withdrawals, MaxWithdrawalsPerPayload, ssz.ForkFilter{Added: ssz.ForkShanghai}) // Offset (14) -   Withdrawals -   4 bytes
+	ssz.DefineUint64PointerOnFork(codec, &obj.BlobGasUsed, ssz.ForkFilter{Added: ssz.ForkCancun})                                          // Field  (15) -   BlobGasUsed -   8 bytes
+	ssz.DefineUint64PointerOnFork(codec, &obj.ExcessBlobGas, ssz.ForkFilter{Added: ssz.ForkCancun})                                        // Field  (16) - ExcessBlobGas -   8 bytes
 
 	// Define the dynamic data (fields)
-	ssz.DefineDynamicBytesContentOnFork(codec, &obj.ExtraData, 32, ssz.ForkFilter{Added: ssz.ForkFrontier})                           // Field  (10) -     ExtraData - ? bytes
-	ssz.DefineSliceOfDynamicBytesContentOnFork(codec, &obj.Transactions, 1048576, 1073741824, ssz.ForkFilter{Added: ssz.ForkUnknown}) // Field  (13) -  Transactions - ? bytes
-	ssz.DefineSliceOfStaticObjectsContentOnFork(codec, &obj.Withdrawals, 16, ssz.ForkFilter{Added: ssz.ForkShanghai})                 // Field  (14) -   Withdrawals - ? bytes
+	ssz.DefineDynamicBytesContentOnFork(codec, &obj.ExtraData, 32, ssz.ForkFilter{Added: ssz.ForkFrontier})                                 // Field  (10) -     ExtraData - ? bytes
+	ssz.DefineSliceOfDynamicBytesContentOnFork(codec, &obj.Transactions, 1048576, 1073741824, ssz.ForkFilter{Added: ssz.ForkUnknown})       // Field  (13) -  Transactions - ? bytes
+	ssz.DefineSliceOfStaticObjectsContentOnFork(codec, &obj.Withdrawals, MaxWithdrawalsPerPayload, ssz.ForkFilter{Added: ssz.ForkShanghai}) // Field  (14) -   Withdrawals - ? bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *ExecutionPayloadMonolith) NamesSSZ() []string {
+	return []string{"ParentHash", "FeeRecipient", "StateRoot", "ReceiptsRoot", "LogsBloom", "PrevRandao", "BlockNumber", "GasLimit", "GasUsed", "Timestamp", "ExtraData", "BaseFeePerGas", "BlockHash", "Transactions", "Withdrawals", "BlobGasUsed", "ExcessBlobGas", "ExtraData", "Transactions", "Withdrawals"}
+}
+
+// Clone creates a deep copy of the object, retaining only the ssz fields.
+func (obj *ExecutionPayloadMonolith) Clone() *ExecutionPayloadMonolith {
+	if obj == nil {
+		return nil
+	}
+	clone := new(ExecutionPayloadMonolith)
+	clone.ParentHash = obj.ParentHash
+	clone.FeeRecipient = obj.FeeRecipient
+	clone.StateRoot = obj.StateRoot
+	clone.ReceiptsRoot = obj.ReceiptsRoot
+	clone.LogsBloom = obj.LogsBloom
+	clone.PrevRandao = obj.PrevRandao
+	clone.BlockNumber = obj.BlockNumber
+	clone.GasLimit = obj.GasLimit
+	clone.GasUsed = obj.GasUsed
+	clone.Timestamp = obj.Timestamp
+	clone.ExtraData = append(obj.ExtraData[:0:0], obj.ExtraData...)
+	if obj.BaseFeePerGas != nil {
+		clone.BaseFeePerGas = new(uint256.Int).Set(obj.BaseFeePerGas)
+	}
+	clone.BlockHash = obj.BlockHash
+	if obj.Transactions != nil {
+		clone.Transactions = make([][]byte, len(obj.Transactions))
+		for i := range obj.Transactions {
+			clone.Transactions[i] = append(obj.Transactions[i][:0:0], obj.Transactions[i]...)
+		}
+	}
+	if obj.Withdrawals != nil {
+		clone.Withdrawals = make([]*Withdrawal, len(obj.Withdrawals))
+		for i := range obj.Withdrawals {
+			clone.Withdrawals[i] = obj.Withdrawals[i].Clone()
+		}
+	}
+	if obj.BlobGasUsed != nil {
+		clone.BlobGasUsed = new(uint64)
+		*clone.BlobGasUsed = *obj.BlobGasUsed
+	}
+	if obj.ExcessBlobGas != nil {
+		clone.ExcessBlobGas = new(uint64)
+		*clone.ExcessBlobGas = *obj.ExcessBlobGas
+	}
+	return clone
+}
+
+// EqualSSZ checks whether two objects are equal in their ssz representation. Nil
+// and zero values are considered equal, as they would encode the same.
+func (obj *ExecutionPayloadMonolith) EqualSSZ(other *ExecutionPayloadMonolith) bool {
+	if obj == nil {
+		obj = new(ExecutionPayloadMonolith)
+	}
+	if other == nil {
+		other = new(ExecutionPayloadMonolith)
+	}
+	if obj.ParentHash != other.ParentHash {
+		return false
+	}
+	if obj.FeeRecipient != other.FeeRecipient {
+		return false
+	}
+	if obj.StateRoot != other.StateRoot {
+		return false
+	}
+	if obj.ReceiptsRoot != other.ReceiptsRoot {
+		return false
+	}
+	if obj.LogsBloom != other.LogsBloom {
+		return false
+	}
+	if obj.PrevRandao != other.PrevRandao {
+		return false
+	}
+	if obj.BlockNumber != other.BlockNumber {
+		return false
+	}
+	if obj.GasLimit != other.GasLimit {
+		return false
+	}
+	if obj.GasUsed != other.GasUsed {
+		return false
+	}
+	if obj.Timestamp != other.Timestamp {
+		return false
+	}
+	if !bytes.Equal(obj.ExtraData, other.ExtraData) {
+		return false
+	}
+	{
+		a, b := obj.BaseFeePerGas, other.BaseFeePerGas
+		if a == nil {
+			a = new(uint256.Int)
+		}
+		if b == nil {
+			b = new(uint256.Int)
+		}
+		if !a.Eq(b) {
+			return false
+		}
+	}
+	if obj.BlockHash != other.BlockHash {
+		return false
+	}
+	if len(obj.Transactions) != len(other.Transactions) {
+		return false
+	}
+	for i := range obj.Transactions {
+		if !bytes.Equal(obj.Transactions[i], other.Transactions[i]) {
+			return false
+		}
+	}
+	if len(obj.Withdrawals) != len(other.Withdrawals) {
+		return false
+	}
+	for i := range obj.Withdrawals {
+		if !obj.Withdrawals[i].EqualSSZ(other.Withdrawals[i]) {
+			return false
+		}
+	}
+	{
+		a, b := obj.BlobGasUsed, other.BlobGasUsed
+		if a == nil {
+			a = new(uint64)
+		}
+		if b == nil {
+			b = new(uint64)
+		}
+		if *a != *b {
+			return false
+		}
+	}
+	{
+		a, b := obj.ExcessBlobGas, other.ExcessBlobGas
+		if a == nil {
+			a = new(uint64)
+		}
+		if b == nil {
+			b = new(uint64)
+		}
+		if *a != *b {
+			return false
+		}
+	}
+	return true
+}
+
+// ResetSSZ clears all the ssz fields of the object, retaining the capacity of any
+// slices, so that pooled objects don't leak data across decodes.
+func (obj *ExecutionPayloadMonolith) ResetSSZ() {
+	if obj == nil {
+		return
+	}
+	obj.ParentHash = Hash{}
+	obj.FeeRecipient = Address{}
+	obj.StateRoot = Hash{}
+	obj.ReceiptsRoot = Hash{}
+	obj.LogsBloom = LogsBloom{}
+	obj.PrevRandao = Hash{}
+	obj.BlockNumber = 0
+	obj.GasLimit = 0
+	obj.GasUsed = 0
+	obj.Timestamp = 0
+	obj.ExtraData = obj.ExtraData[:0]
+	if obj.BaseFeePerGas != nil {
+		obj.BaseFeePerGas.Clear()
+	}
+	obj.BlockHash = Hash{}
+	obj.Transactions = obj.Transactions[:cap(obj.Transactions)]
+	for i := range obj.Transactions {
+		obj.Transactions[i] = obj.Transactions[i][:0]
+	}
+	obj.Transactions = obj.Transactions[:0]
+	obj.Withdrawals = obj.Withdrawals[:cap(obj.Withdrawals)]
+	for i := range obj.Withdrawals {
+		obj.Withdrawals[i].ResetSSZ()
+	}
+	obj.Withdrawals = obj.Withdrawals[:0]
+	obj.BlobGasUsed = nil
+	obj.ExcessBlobGas = nil
+}
+
+// UpgradeSSZ converts the object from the layout of one fork to another, zero
+// initializing the fields added and clearing the fields removed in between.
+func (obj *ExecutionPayloadMonolith) UpgradeSSZ(from ssz.Fork, to ssz.Fork) {
+	if obj == nil {
+		return
+	}
+	if was, now := from >= ssz.ForkFrontier, to >= ssz.ForkFrontier; was != now {
+		obj.ExtraData = nil
+	}
+	if was, now := from >= ssz.ForkUnknown, to >= ssz.ForkUnknown; was && !now {
+		obj.BaseFeePerGas = nil
+	} else if !was && now {
+		obj.BaseFeePerGas = new(uint256.Int)
+	}
+	if was, now := from >= ssz.ForkUnknown, to >= ssz.ForkUnknown; was != now {
+		obj.Transactions = nil
+	}
+	if was, now := from >= ssz.ForkShanghai, to >= ssz.ForkShanghai; was != now {
+		obj.Withdrawals = nil
+	}
+	if was, now := from >= ssz.ForkCancun, to >= ssz.ForkCancun; was && !now {
+		obj.BlobGasUsed = nil
+		obj.ExcessBlobGas = nil
+	} else if !was && now {
+		obj.BlobGasUsed = new(uint64)
+		obj.ExcessBlobGas = new(uint64)
+	}
+	for i := range obj.Withdrawals {
+		obj.Withdrawals[i].UpgradeSSZ(from, to)
+	}
 }
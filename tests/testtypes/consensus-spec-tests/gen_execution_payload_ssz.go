@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp c4468bd5a8c610b3737d60a3b8008bad124fbe6e1cde8ec46d07090104f85166
 
 package consensus_spec_tests
 
@@ -39,3 +40,8 @@ func (obj *ExecutionPayload) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineDynamicBytesContent(codec, &obj.ExtraData, 32)                            // Field  (10) -     ExtraData - ? bytes
 	ssz.DefineSliceOfDynamicBytesContent(codec, &obj.Transactions, 1048576, 1073741824) // Field  (13) -  Transactions - ? bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *ExecutionPayload) NamesSSZ() []string {
+	return []string{"ParentHash", "FeeRecipient", "StateRoot", "ReceiptsRoot", "LogsBloom", "PrevRandao", "BlockNumber", "GasLimit", "GasUsed", "Timestamp", "ExtraData", "BaseFeePerGas", "BlockHash", "Transactions", "ExtraData", "Transactions"}
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 492ac2bf540fc0b0d081bc156ce1ba7122b4c5353df4409207cea165f5b2753b
 
 package consensus_spec_tests
 
@@ -18,3 +19,8 @@ func (obj *FixedTestStructMonolith) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineUint64PointerOnFork(codec, &obj.B, ssz.ForkFilter{Added: ssz.ForkUnknown}) // Field  (1) - B - 8 bytes
 	ssz.DefineUint32PointerOnFork(codec, &obj.C, ssz.ForkFilter{Added: ssz.ForkUnknown}) // Field  (2) - C - 4 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *FixedTestStructMonolith) NamesSSZ() []string {
+	return []string{"A", "B", "C"}
+}
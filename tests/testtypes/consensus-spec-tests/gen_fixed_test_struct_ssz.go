@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp d4ea8fc7472ea15af3a84a65aa2f4d697270ff5b23b74c415013da686baa3d86
 
 package consensus_spec_tests
 
@@ -15,3 +16,8 @@ func (obj *FixedTestStruct) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineUint64(codec, &obj.B) // Field  (1) - B - 8 bytes
 	ssz.DefineUint32(codec, &obj.C) // Field  (2) - C - 4 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *FixedTestStruct) NamesSSZ() []string {
+	return []string{"A", "B", "C"}
+}
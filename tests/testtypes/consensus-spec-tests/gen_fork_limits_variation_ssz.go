@@ -0,0 +1,32 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 5cc639517e147bade2e68fb0bfc78934845ebc1ce48ea463e29f13fb0d075cbd
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *ForkLimitsVariation) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
+	size = 4
+	if fixed {
+		return size
+	}
+	size += ssz.SizeSliceOfUint64s(sizer, obj.List)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *ForkLimitsVariation) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineSliceOfUint64sOffset(codec, &obj.List, ssz.LimitOnFork(codec, 4, ssz.ForkLimit{Fork: ssz.ForkDeneb, Limit: 8}, ssz.ForkLimit{Fork: ssz.ForkElectra, Limit: 2})) // Offset (0) - List - 4 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineSliceOfUint64sContent(codec, &obj.List, ssz.LimitOnFork(codec, 4, ssz.ForkLimit{Fork: ssz.ForkDeneb, Limit: 8}, ssz.ForkLimit{Fork: ssz.ForkElectra, Limit: 2})) // Field  (0) - List - ? bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *ForkLimitsVariation) NamesSSZ() []string {
+	return []string{"List", "List"}
+}
@@ -0,0 +1,51 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 0e437fe88903ccd8fdbdee013831460642fe6a9ef39c0e381f90b5c36b8a182e
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *ForkRangesMonolith) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
+	size = 8
+	if (sizer.Fork() >= ssz.ForkAltair && sizer.Fork() < ssz.ForkCapella) || sizer.Fork() >= ssz.ForkElectra {
+		size += 8
+	}
+	if sizer.Fork() >= ssz.ForkBellatrix && sizer.Fork() < ssz.ForkDeneb {
+		size += 4
+	}
+	if (sizer.Fork() >= ssz.ForkPhase0 && sizer.Fork() < ssz.ForkAltair) || (sizer.Fork() >= ssz.ForkBellatrix && sizer.Fork() < ssz.ForkCapella) || sizer.Fork() >= ssz.ForkDeneb {
+		size += 4
+	}
+	if fixed {
+		return size
+	}
+	if sizer.Fork() >= ssz.ForkBellatrix && sizer.Fork() < ssz.ForkDeneb {
+		size += ssz.SizeDynamicBytes(sizer, obj.C)
+	}
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *ForkRangesMonolith) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineUint64(codec, &obj.A)                                                                                                              // Field  (0) - A - 8 bytes
+	ssz.DefineUint64PointerOnFork(codec, &obj.B, ssz.ForkFilter{Ranges: &forkRangesForkRangesMonolithAltairToCapellaOrElectra})                  // Field  (1) - B - 8 bytes
+	ssz.DefineDynamicBytesOffsetOnFork(codec, &obj.C, 32, ssz.ForkFilter{Added: ssz.ForkBellatrix, Removed: ssz.ForkDeneb})                      // Offset (2) - C - 4 bytes
+	ssz.DefineUint32PointerOnFork(codec, &obj.D, ssz.ForkFilter{Ranges: &forkRangesForkRangesMonolithPhase0ToAltairOrBellatrixToCapellaOrDeneb}) // Field  (3) - D - 4 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineDynamicBytesContentOnFork(codec, &obj.C, 32, ssz.ForkFilter{Added: ssz.ForkBellatrix, Removed: ssz.ForkDeneb}) // Field  (2) - C - ? bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *ForkRangesMonolith) NamesSSZ() []string {
+	return []string{"A", "B", "C", "D", "C"}
+}
+
+// Fork ranges of the fields present in multiple disjoint fork ranges.
+var (
+	forkRangesForkRangesMonolithAltairToCapellaOrElectra                  = ssz.ForkFilterSet{ssz.ForkFilter{Added: ssz.ForkAltair, Removed: ssz.ForkCapella}, ssz.ForkFilter{Added: ssz.ForkElectra}}
+	forkRangesForkRangesMonolithPhase0ToAltairOrBellatrixToCapellaOrDeneb = ssz.ForkFilterSet{ssz.ForkFilter{Added: ssz.ForkPhase0, Removed: ssz.ForkAltair}, ssz.ForkFilter{Added: ssz.ForkBellatrix, Removed: ssz.ForkCapella}, ssz.ForkFilter{Added: ssz.ForkDeneb}}
+)
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 176b53c95a5abaab65fec84337f0ec2ee801acb2f756c56cdd41861d8ce78364
 
 package consensus_spec_tests
 
@@ -15,3 +16,8 @@ func (obj *Fork) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineStaticBytes(codec, &obj.CurrentVersion)  // Field  (1) -  CurrentVersion - 4 bytes
 	ssz.DefineUint64(codec, &obj.Epoch)                // Field  (2) -           Epoch - 8 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *Fork) NamesSSZ() []string {
+	return []string{"PreviousVersion", "CurrentVersion", "Epoch"}
+}
@@ -0,0 +1,59 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp b59d13d3431b2270d2bd92d69296cedb7f6cc070999400ae38ea3c999bc0ae22
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *ForkTypesMonolith) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
+	if sizer.Fork() >= ssz.ForkElectra {
+		size += 16
+	} else if sizer.Fork() >= ssz.ForkDeneb {
+		size += 8
+	} else {
+		size += 4
+	}
+	size += 1 + 4
+	if fixed {
+		return size
+	}
+	size += ssz.SizeDynamicBytes(sizer, obj.Payload)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *ForkTypesMonolith) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	if codec.Fork() >= ssz.ForkElectra {
+		ssz.DefineCheckedStaticBytes(codec, &obj.Flags, 16) // Field  (0) -   Flags - 16 bytes
+	} else if codec.Fork() >= ssz.ForkDeneb {
+		ssz.DefineCheckedStaticBytes(codec, &obj.Flags, 8) // Field  (0) -   Flags -  8 bytes
+	} else {
+		ssz.DefineCheckedStaticBytes(codec, &obj.Flags, 4) // Field  (0) -   Flags -  4 bytes
+	}
+	if codec.Fork() >= ssz.ForkElectra {
+		ssz.DefineArrayOfBits(codec, &obj.Bits, 8) // Field  (1) -    Bits -  1 bytes
+	} else {
+		ssz.DefineArrayOfBits(codec, &obj.Bits, 4) // Field  (1) -    Bits -  1 bytes
+	}
+	if codec.Fork() >= ssz.ForkElectra {
+		ssz.DefineDynamicBytesOffset(codec, &obj.Payload, 32) // Offset (2) - Payload -  4 bytes
+	} else {
+		ssz.DefineDynamicBytesOffset(codec, &obj.Payload, 16) // Offset (2) - Payload -  4 bytes
+	}
+
+	// Define the dynamic data (fields)
+	if codec.Fork() >= ssz.ForkElectra {
+		ssz.DefineDynamicBytesContent(codec, &obj.Payload, 32) // Field  (2) - Payload - ? bytes
+	} else {
+		ssz.DefineDynamicBytesContent(codec, &obj.Payload, 16) // Field  (2) - Payload - ? bytes
+	}
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *ForkTypesMonolith) NamesSSZ() []string {
+	return []string{"Flags", "Bits", "Payload", "Payload"}
+}
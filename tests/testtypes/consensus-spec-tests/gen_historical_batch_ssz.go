@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 14ebdc7e950194c94a74263b20563a0803ef14d556c53475cc86e690acf6833b
 
 package consensus_spec_tests
 
@@ -6,7 +7,7 @@ import "github.com/karalabe/ssz"
 
 // SizeSSZ returns the total size of the static ssz object.
 func (obj *HistoricalBatch) SizeSSZ(sizer *ssz.Sizer) uint32 {
-	return 8192*32 + 8192*32
+	return SlotsPerHistoricalRoot*32 + SlotsPerHistoricalRoot*32
 }
 
 // DefineSSZ defines how an object is encoded/decoded.
@@ -14,3 +15,8 @@ func (obj *HistoricalBatch) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.BlockRoots[:]) // Field  (0) - BlockRoots - 262144 bytes
 	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.StateRoots[:]) // Field  (1) - StateRoots - 262144 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *HistoricalBatch) NamesSSZ() []string {
+	return []string{"BlockRoots", "StateRoots"}
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp dbf0499715c5756dfe712dcc2bf3bd4ae19a4194d96c58340f78d3749214f493
 
 package consensus_spec_tests
 
@@ -6,11 +7,16 @@ import "github.com/karalabe/ssz"
 
 // SizeSSZ returns the total size of the static ssz object.
 func (obj *HistoricalBatchVariation) SizeSSZ(sizer *ssz.Sizer) uint32 {
-	return 8192*32 + 8192*32
+	return SlotsPerHistoricalRoot*32 + SlotsPerHistoricalRoot*32
 }
 
 // DefineSSZ defines how an object is encoded/decoded.
 func (obj *HistoricalBatchVariation) DefineSSZ(codec *ssz.Codec) {
-	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.BlockRoots[:])      // Field  (0) - BlockRoots - 262144 bytes
-	ssz.DefineCheckedArrayOfStaticBytes(codec, &obj.StateRoots, 8192) // Field  (1) - StateRoots - 262144 bytes
+	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.BlockRoots[:])                        // Field  (0) - BlockRoots - 262144 bytes
+	ssz.DefineCheckedArrayOfStaticBytes(codec, &obj.StateRoots, SlotsPerHistoricalRoot) // Field  (1) - StateRoots - 262144 bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *HistoricalBatchVariation) NamesSSZ() []string {
+	return []string{"BlockRoots", "StateRoots"}
 }
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp a1d2891895569df31dac1330a55a6bc9c5acc4b860df92bf460b3351d0e9b1af
 
 package consensus_spec_tests
 
@@ -14,3 +15,8 @@ func (obj *HistoricalSummary) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineStaticBytes(codec, &obj.BlockSummaryRoot) // Field  (0) - BlockSummaryRoot - 32 bytes
 	ssz.DefineStaticBytes(codec, &obj.StateSummaryRoot) // Field  (1) - StateSummaryRoot - 32 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *HistoricalSummary) NamesSSZ() []string {
+	return []string{"BlockSummaryRoot", "StateSummaryRoot"}
+}
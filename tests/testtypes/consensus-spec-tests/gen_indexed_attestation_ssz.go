@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 49bf082431719cdd9fd1b8025a2d307ff6986acc74d78bf0779f583b5a68a30a
 
 package consensus_spec_tests
 
@@ -35,3 +36,8 @@ func (obj *IndexedAttestation) DefineSSZ(codec *ssz.Codec) {
 	// Define the dynamic data (fields)
 	ssz.DefineSliceOfUint64sContent(codec, &obj.AttestationIndices, 2048) // Field  (0) - AttestationIndices - ? bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *IndexedAttestation) NamesSSZ() []string {
+	return []string{"AttestationIndices", "Data", "Signature", "AttestationIndices"}
+}
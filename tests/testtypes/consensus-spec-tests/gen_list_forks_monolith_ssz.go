@@ -0,0 +1,61 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 7605570a88351992d7e6bc632c25cc971b3d3a0f6710f3c05eb596657577a3aa
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *ListForksMonolith) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
+	size = 8
+	if sizer.Fork() >= ssz.ForkAltair {
+		size += 4
+	}
+	if sizer.Fork() >= ssz.ForkBellatrix {
+		size += 4
+	}
+	if sizer.Fork() >= ssz.ForkCapella {
+		size += 4
+	}
+	if sizer.Fork() >= ssz.ForkDeneb {
+		size += 4
+	}
+	if fixed {
+		return size
+	}
+	if sizer.Fork() >= ssz.ForkAltair {
+		size += ssz.SizeSliceOfUint64sPointer(sizer, obj.InactivityScores)
+	}
+	if sizer.Fork() >= ssz.ForkBellatrix {
+		size += ssz.SizeSliceOfStaticBytesPointer(sizer, obj.HistoricalRoots)
+	}
+	if sizer.Fork() >= ssz.ForkCapella {
+		size += ssz.SizeSliceOfStaticObjectsPointer(sizer, obj.HistoricalSummaries)
+	}
+	if sizer.Fork() >= ssz.ForkDeneb {
+		size += ssz.SizeSliceOfDynamicObjectsPointer(sizer, obj.PendingAttestations)
+	}
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *ListForksMonolith) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineUint64(codec, &obj.Slot)                                                                                                   // Field  (0) -                Slot - 8 bytes
+	ssz.DefineSliceOfUint64sPointerOffsetOnFork(codec, &obj.InactivityScores, 1099511627776, ssz.ForkFilter{Added: ssz.ForkAltair})      // Offset (1) -    InactivityScores - 4 bytes
+	ssz.DefineSliceOfStaticBytesPointerOffsetOnFork(codec, &obj.HistoricalRoots, 16777216, ssz.ForkFilter{Added: ssz.ForkBellatrix})     // Offset (2) -     HistoricalRoots - 4 bytes
+	ssz.DefineSliceOfStaticObjectsPointerOffsetOnFork(codec, &obj.HistoricalSummaries, 16777216, ssz.ForkFilter{Added: ssz.ForkCapella}) // Offset (3) - HistoricalSummaries - 4 bytes
+	ssz.DefineSliceOfDynamicObjectsPointerOffsetOnFork(codec, &obj.PendingAttestations, 4096, ssz.ForkFilter{Added: ssz.ForkDeneb})      // Offset (4) - PendingAttestations - 4 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineSliceOfUint64sPointerContentOnFork(codec, &obj.InactivityScores, 1099511627776, ssz.ForkFilter{Added: ssz.ForkAltair})      // Field  (1) -    InactivityScores - ? bytes
+	ssz.DefineSliceOfStaticBytesPointerContentOnFork(codec, &obj.HistoricalRoots, 16777216, ssz.ForkFilter{Added: ssz.ForkBellatrix})     // Field  (2) -     HistoricalRoots - ? bytes
+	ssz.DefineSliceOfStaticObjectsPointerContentOnFork(codec, &obj.HistoricalSummaries, 16777216, ssz.ForkFilter{Added: ssz.ForkCapella}) // Field  (3) - HistoricalSummaries - ? bytes
+	ssz.DefineSliceOfDynamicObjectsPointerContentOnFork(codec, &obj.PendingAttestations, 4096, ssz.ForkFilter{Added: ssz.ForkDeneb})      // Field  (4) - PendingAttestations - ? bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *ListForksMonolith) NamesSSZ() []string {
+	return []string{"Slot", "InactivityScores", "HistoricalRoots", "HistoricalSummaries", "PendingAttestations", "InactivityScores", "HistoricalRoots", "HistoricalSummaries", "PendingAttestations"}
+}
@@ -0,0 +1,260 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp e4fb2a0f7aec876332a4e56a42f71d0e3fd7d3afdfd70c840f4d6b3132b126ee
+
+package consensus_spec_tests
+
+import (
+	"bytes"
+	"cmp"
+	"github.com/karalabe/ssz"
+)
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *MapsVariation) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
+	size = 8 + 4 + 4 + 4
+	if fixed {
+		return size
+	}
+	size += ssz.SizeMapOfStaticEntries[*mapsVariationBalancesEntry](sizer, obj.Balances)
+	size += ssz.SizeMapOfStaticEntries[*mapsVariationWithdrawalsEntry](sizer, obj.Withdrawals)
+	size += ssz.SizeMapOfDynamicEntries[*mapsVariationExtrasEntry](sizer, obj.Extras)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *MapsVariation) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineUint64(codec, &obj.Slot)                                                              // Field  (0) -        Slot - 8 bytes
+	ssz.DefineMapOfStaticEntriesOffset[*mapsVariationBalancesEntry](codec, &obj.Balances, 1024)     // Offset (1) -    Balances - 4 bytes
+	ssz.DefineMapOfStaticEntriesOffset[*mapsVariationWithdrawalsEntry](codec, &obj.Withdrawals, 16) // Offset (2) - Withdrawals - 4 bytes
+	ssz.DefineMapOfDynamicEntriesOffset[*mapsVariationExtrasEntry](codec, &obj.Extras, 16)          // Offset (3) -      Extras - 4 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineMapOfStaticEntriesContent[*mapsVariationBalancesEntry](codec, &obj.Balances, 1024)     // Field  (1) -    Balances - ? bytes
+	ssz.DefineMapOfStaticEntriesContent[*mapsVariationWithdrawalsEntry](codec, &obj.Withdrawals, 16) // Field  (2) - Withdrawals - ? bytes
+	ssz.DefineMapOfDynamicEntriesContent[*mapsVariationExtrasEntry](codec, &obj.Extras, 16)          // Field  (3) -      Extras - ? bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *MapsVariation) NamesSSZ() []string {
+	return []string{"Slot", "Balances", "Withdrawals", "Extras", "Balances", "Withdrawals", "Extras"}
+}
+
+// Clone creates a deep copy of the object, retaining only the ssz fields.
+func (obj *MapsVariation) Clone() *MapsVariation {
+	if obj == nil {
+		return nil
+	}
+	clone := new(MapsVariation)
+	clone.Slot = obj.Slot
+	if obj.Balances != nil {
+		clone.Balances = make(map[Address]uint64, len(obj.Balances))
+		for i := range obj.Balances {
+			clone.Balances[i] = obj.Balances[i]
+		}
+	}
+	if obj.Withdrawals != nil {
+		clone.Withdrawals = make(map[uint64]*Withdrawal, len(obj.Withdrawals))
+		for i := range obj.Withdrawals {
+			clone.Withdrawals[i] = obj.Withdrawals[i].Clone()
+		}
+	}
+	if obj.Extras != nil {
+		clone.Extras = make(map[uint64][]byte, len(obj.Extras))
+		for i := range obj.Extras {
+			clone.Extras[i] = append(obj.Extras[i][:0:0], obj.Extras[i]...)
+		}
+	}
+	return clone
+}
+
+// EqualSSZ checks whether two objects are equal in their ssz representation. Nil
+// and zero values are considered equal, as they would encode the same.
+func (obj *MapsVariation) EqualSSZ(other *MapsVariation) bool {
+	if obj == nil {
+		obj = new(MapsVariation)
+	}
+	if other == nil {
+		other = new(MapsVariation)
+	}
+	if obj.Slot != other.Slot {
+		return false
+	}
+	if len(obj.Balances) != len(other.Balances) {
+		return false
+	}
+	for i := range obj.Balances {
+		if _, ok := other.Balances[i]; !ok {
+			return false
+		}
+		if obj.Balances[i] != other.Balances[i] {
+			return false
+		}
+	}
+	if len(obj.Withdrawals) != len(other.Withdrawals) {
+		return false
+	}
+	for i := range obj.Withdrawals {
+		if _, ok := other.Withdrawals[i]; !ok {
+			return false
+		}
+		if !obj.Withdrawals[i].EqualSSZ(other.Withdrawals[i]) {
+			return false
+		}
+	}
+	if len(obj.Extras) != len(other.Extras) {
+		return false
+	}
+	for i := range obj.Extras {
+		if _, ok := other.Extras[i]; !ok {
+			return false
+		}
+		if !bytes.Equal(obj.Extras[i], other.Extras[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ResetSSZ clears all the ssz fields of the object, retaining the capacity of any
+// slices, so that pooled objects don't leak data across decodes.
+func (obj *MapsVariation) ResetSSZ() {
+	if obj == nil {
+		return
+	}
+	obj.Slot = 0
+	clear(obj.Balances)
+	clear(obj.Withdrawals)
+	clear(obj.Extras)
+}
+
+// mapsVariationBalancesEntry is the ssz key/value container of a map entry.
+type mapsVariationBalancesEntry struct {
+	Key   Address
+	Value uint64
+}
+
+// EntrySSZ returns the key and value stored in the container.
+func (obj *mapsVariationBalancesEntry) EntrySSZ() (Address, uint64) {
+	return obj.Key, obj.Value
+}
+
+// SetEntrySSZ sets the key and value stored in the container.
+func (obj *mapsVariationBalancesEntry) SetEntrySSZ(key Address, value uint64) {
+	obj.Key, obj.Value = key, value
+}
+
+// CompareKeySSZ compares the key stored in the container to another one.
+func (obj *mapsVariationBalancesEntry) CompareKeySSZ(key Address) int {
+	return bytes.Compare(obj.Key[:], key[:])
+}
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *mapsVariationBalancesEntry) SizeSSZ(sizer *ssz.Sizer) uint32 {
+	return 20 + 8
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *mapsVariationBalancesEntry) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineStaticBytes(codec, &obj.Key) // Field  (0) -   Key - 20 bytes
+	ssz.DefineUint64(codec, &obj.Value)    // Field  (1) - Value -  8 bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *mapsVariationBalancesEntry) NamesSSZ() []string {
+	return []string{"Key", "Value"}
+}
+
+// mapsVariationWithdrawalsEntry is the ssz key/value container of a map entry.
+type mapsVariationWithdrawalsEntry struct {
+	Index uint64
+	Value *Withdrawal
+}
+
+// EntrySSZ returns the key and value stored in the container.
+func (obj *mapsVariationWithdrawalsEntry) EntrySSZ() (uint64, *Withdrawal) {
+	return obj.Index, obj.Value
+}
+
+// SetEntrySSZ sets the key and value stored in the container.
+func (obj *mapsVariationWithdrawalsEntry) SetEntrySSZ(key uint64, value *Withdrawal) {
+	obj.Index, obj.Value = key, value
+}
+
+// CompareKeySSZ compares the key stored in the container to another one.
+func (obj *mapsVariationWithdrawalsEntry) CompareKeySSZ(key uint64) int {
+	return cmp.Compare(obj.Index, key)
+}
+
+// Cached static size computed on package init.
+var staticSizeCachemapsVariationWithdrawalsEntry = ssz.PrecomputeStaticSizeCache((*mapsVariationWithdrawalsEntry)(nil))
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *mapsVariationWithdrawalsEntry) SizeSSZ(sizer *ssz.Sizer) (size uint32) {
+	if fork := int(sizer.Fork()); fork < len(staticSizeCachemapsVariationWithdrawalsEntry) {
+		return staticSizeCachemapsVariationWithdrawalsEntry[fork]
+	}
+	size = 8 + (*Withdrawal)(nil).SizeSSZ(sizer)
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *mapsVariationWithdrawalsEntry) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &obj.Index)       // Field  (0) - Index - 8 bytes
+	ssz.DefineStaticObject(codec, &obj.Value) // Field  (1) - Value - ? bytes (Withdrawal)
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *mapsVariationWithdrawalsEntry) NamesSSZ() []string {
+	return []string{"Index", "Value"}
+}
+
+// mapsVariationExtrasEntry is the ssz key/value container of a map entry.
+type mapsVariationExtrasEntry struct {
+	Key   uint64
+	Value []byte
+}
+
+// EntrySSZ returns the key and value stored in the container.
+func (obj *mapsVariationExtrasEntry) EntrySSZ() (uint64, []byte) {
+	return obj.Key, obj.Value
+}
+
+// SetEntrySSZ sets the key and value stored in the container.
+func (obj *mapsVariationExtrasEntry) SetEntrySSZ(key uint64, value []byte) {
+	obj.Key, obj.Value = key, value
+}
+
+// CompareKeySSZ compares the key stored in the container to another one.
+func (obj *mapsVariationExtrasEntry) CompareKeySSZ(key uint64) int {
+	return cmp.Compare(obj.Key, key)
+}
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *mapsVariationExtrasEntry) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
+	size = 8 + 4
+	if fixed {
+		return size
+	}
+	size += ssz.SizeDynamicBytes(sizer, obj.Value)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *mapsVariationExtrasEntry) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineUint64(codec, &obj.Key)                   // Field  (0) -   Key - 8 bytes
+	ssz.DefineDynamicBytesOffset(codec, &obj.Value, 32) // Offset (1) - Value - 4 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineDynamicBytesContent(codec, &obj.Value, 32) // Field  (1) - Value - ? bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *mapsVariationExtrasEntry) NamesSSZ() []string {
+	return []string{"Key", "Value", "Value"}
+}
@@ -0,0 +1,23 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 8b18f27e044690f506e98cd1a9393121f84233c27b4e0f650cad70443ab02d43
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *MultiDimArraysVariation) SizeSSZ(sizer *ssz.Sizer) uint32 {
+	return 4*8192*8 + 2*16*2 + 3*64*4
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *MultiDimArraysVariation) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineArrayOfArrayOfUint64s(codec, obj.Custody[:]) // Field  (0) - Custody - 262144 bytes
+	ssz.DefineArrayOfArrayOfUint16s(codec, obj.Flags[:])   // Field  (1) -   Flags -     64 bytes
+	ssz.DefineArrayOfArrayOfUint32s(codec, obj.Counts[:])  // Field  (2) -  Counts -    768 bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *MultiDimArraysVariation) NamesSSZ() []string {
+	return []string{"Custody", "Flags", "Counts"}
+}
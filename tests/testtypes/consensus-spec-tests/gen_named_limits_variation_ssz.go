@@ -0,0 +1,92 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp c91945e9d33177284af71836335f7a15dca34134b61ccea3889b59fee156a3a0
+
+package consensus_spec_tests
+
+import (
+	"cmp"
+	"github.com/karalabe/ssz"
+)
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *NamedLimitsVariation) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
+	size = 4 + 4 + 4 + 4
+	if fixed {
+		return size
+	}
+	size += ssz.SizeDynamicBytes(sizer, obj.ExtraData)
+	size += ssz.SizeSliceOfDynamicBytes(sizer, obj.Transactions)
+	size += ssz.SizeSliceOfStaticObjects(sizer, obj.Withdrawals)
+	size += ssz.SizeMapOfDynamicEntries[*namedLimitsVariationBalancesEntry](sizer, obj.Balances)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *NamedLimitsVariation) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineDynamicBytesOffset(codec, &obj.ExtraData, 32)                                                                                                   // Offset (0) -    ExtraData - 4 bytes
+	ssz.DefineSliceOfDynamicBytesOffset(codec, &obj.Transactions, MaxNamedTransactions, MaxNamedBytesPerTransaction)                                          // Offset (1) - Transactions - 4 bytes
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &obj.Withdrawals, ssz.LimitOnFork(codec, MaxNamedWithdrawals, ssz.ForkLimit{Fork: ssz.ForkDeneb, Limit: 32})) // Offset (2) -  Withdrawals - 4 bytes
+	ssz.DefineMapOfDynamicEntriesOffset[*namedLimitsVariationBalancesEntry](codec, &obj.Balances, MaxNamedBalances)                                           // Offset (3) -     Balances - 4 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineDynamicBytesContent(codec, &obj.ExtraData, 32)                                                                                                   // Field  (0) -    ExtraData - ? bytes
+	ssz.DefineSliceOfDynamicBytesContent(codec, &obj.Transactions, MaxNamedTransactions, MaxNamedBytesPerTransaction)                                          // Field  (1) - Transactions - ? bytes
+	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.Withdrawals, ssz.LimitOnFork(codec, MaxNamedWithdrawals, ssz.ForkLimit{Fork: ssz.ForkDeneb, Limit: 32})) // Field  (2) -  Withdrawals - ? bytes
+	ssz.DefineMapOfDynamicEntriesContent[*namedLimitsVariationBalancesEntry](codec, &obj.Balances, MaxNamedBalances)                                           // Field  (3) -     Balances - ? bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *NamedLimitsVariation) NamesSSZ() []string {
+	return []string{"ExtraData", "Transactions", "Withdrawals", "Balances", "ExtraData", "Transactions", "Withdrawals", "Balances"}
+}
+
+// namedLimitsVariationBalancesEntry is the ssz key/value container of a map entry.
+type namedLimitsVariationBalancesEntry struct {
+	Key   uint64
+	Value []byte
+}
+
+// EntrySSZ returns the key and value stored in the container.
+func (obj *namedLimitsVariationBalancesEntry) EntrySSZ() (uint64, []byte) {
+	return obj.Key, obj.Value
+}
+
+// SetEntrySSZ sets the key and value stored in the container.
+func (obj *namedLimitsVariationBalancesEntry) SetEntrySSZ(key uint64, value []byte) {
+	obj.Key, obj.Value = key, value
+}
+
+// CompareKeySSZ compares the key stored in the container to another one.
+func (obj *namedLimitsVariationBalancesEntry) CompareKeySSZ(key uint64) int {
+	return cmp.Compare(obj.Key, key)
+}
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *namedLimitsVariationBalancesEntry) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
+	size = 8 + 4
+	if fixed {
+		return size
+	}
+	size += ssz.SizeDynamicBytes(sizer, obj.Value)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *namedLimitsVariationBalancesEntry) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineUint64(codec, &obj.Key)                                     // Field  (0) -   Key - 8 bytes
+	ssz.DefineDynamicBytesOffset(codec, &obj.Value, MaxNamedBalanceBytes) // Offset (1) - Value - 4 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineDynamicBytesContent(codec, &obj.Value, MaxNamedBalanceBytes) // Field  (1) - Value - ? bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *namedLimitsVariationBalancesEntry) NamesSSZ() []string {
+	return []string{"Key", "Value", "Value"}
+}
@@ -0,0 +1,27 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 5320a1a11bbfdbe841e4de52dd33d0d72fbef27ce07244d2d128a1fca9c0c211
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *PackedArraysBytesVariation) SizeSSZ(sizer *ssz.Sizer) (size uint32) {
+	size = 32 + 256
+	if sizer.Fork() >= ssz.ForkDeneb {
+		size += 64
+	}
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *PackedArraysBytesVariation) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineStaticBytes(codec, &obj.Flags)                                                    // Field  (0) -    Flags -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.Counters)                                                 // Field  (1) - Counters - 256 bytes
+	ssz.DefineStaticBytesPointerOnFork(codec, &obj.Extra, ssz.ForkFilter{Added: ssz.ForkDeneb}) // Field  (2) -    Extra -  64 bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *PackedArraysBytesVariation) NamesSSZ() []string {
+	return []string{"Flags", "Counters", "Extra"}
+}
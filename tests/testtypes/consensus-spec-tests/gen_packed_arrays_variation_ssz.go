@@ -0,0 +1,27 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp a07af5311e53681dbfc148b2295b13a2b24db6df2fdfc6a7caa3d2b380ba8b1b
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *PackedArraysVariation) SizeSSZ(sizer *ssz.Sizer) (size uint32) {
+	size = 16*2 + 64*4
+	if sizer.Fork() >= ssz.ForkDeneb {
+		size += 16 * 4
+	}
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *PackedArraysVariation) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineArrayOfUint16s(codec, &obj.Flags)                                                    // Field  (0) -    Flags -  32 bytes
+	ssz.DefineArrayOfUint32s(codec, &obj.Counters)                                                 // Field  (1) - Counters - 256 bytes
+	ssz.DefineArrayOfUint32sPointerOnFork(codec, &obj.Extra, ssz.ForkFilter{Added: ssz.ForkDeneb}) // Field  (2) -    Extra -  64 bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *PackedArraysVariation) NamesSSZ() []string {
+	return []string{"Flags", "Counters", "Extra"}
+}
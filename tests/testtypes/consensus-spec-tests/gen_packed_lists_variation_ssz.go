@@ -0,0 +1,45 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp abd58a15605664196be6ea6c63074ed4e983b800f2f3d7fa7807a1a186997f6f
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *PackedListsVariation) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
+	size = 4 + 4 + 4
+	if sizer.Fork() >= ssz.ForkDeneb {
+		size += 4
+	}
+	if fixed {
+		return size
+	}
+	size += ssz.SizeDynamicBytes(sizer, obj.Bytes)
+	size += ssz.SizeSliceOfUint16s(sizer, obj.Shorts)
+	size += ssz.SizeSliceOfUint32s(sizer, obj.Words)
+	if sizer.Fork() >= ssz.ForkDeneb {
+		size += ssz.SizeSliceOfUint16s(sizer, obj.Extras)
+	}
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *PackedListsVariation) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineDynamicBytesOffset(codec, &obj.Bytes, 100)                                              // Offset (0) -  Bytes - 4 bytes
+	ssz.DefineSliceOfUint16sOffset(codec, &obj.Shorts, 100)                                           // Offset (1) - Shorts - 4 bytes
+	ssz.DefineSliceOfUint32sOffset(codec, &obj.Words, 100)                                            // Offset (2) -  Words - 4 bytes
+	ssz.DefineSliceOfUint16sOffsetOnFork(codec, &obj.Extras, 8, ssz.ForkFilter{Added: ssz.ForkDeneb}) // Offset (3) - Extras - 4 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineDynamicBytesContent(codec, &obj.Bytes, 100)                                              // Field  (0) -  Bytes - ? bytes
+	ssz.DefineSliceOfUint16sContent(codec, &obj.Shorts, 100)                                           // Field  (1) - Shorts - ? bytes
+	ssz.DefineSliceOfUint32sContent(codec, &obj.Words, 100)                                            // Field  (2) -  Words - ? bytes
+	ssz.DefineSliceOfUint16sContentOnFork(codec, &obj.Extras, 8, ssz.ForkFilter{Added: ssz.ForkDeneb}) // Field  (3) - Extras - ? bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *PackedListsVariation) NamesSSZ() []string {
+	return []string{"Bytes", "Shorts", "Words", "Extras", "Bytes", "Shorts", "Words", "Extras"}
+}
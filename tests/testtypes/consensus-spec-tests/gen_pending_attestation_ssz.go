@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 97a0dd11c0dd08c2daa6800f11e1d92e45aaaab058bf9426ceb9c890eac06f61
 
 package consensus_spec_tests
 
@@ -36,3 +37,8 @@ func (obj *PendingAttestation) DefineSSZ(codec *ssz.Codec) {
 	// Define the dynamic data (fields)
 	ssz.DefineSliceOfBitsContent(codec, &obj.AggregationBits, 2048) // Field  (0) - AggregationBits - ? bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *PendingAttestation) NamesSSZ() []string {
+	return []string{"AggregationBits", "Data", "InclusionDelay", "ProposerIndex", "AggregationBits"}
+}
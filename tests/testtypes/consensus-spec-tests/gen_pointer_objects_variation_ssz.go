@@ -0,0 +1,33 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp fdca308f5e6c65f84b64d58f0fe64b78eaa5a5d3914af33920c54cf35958ade8
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *PointerObjectsVariation) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
+	size = 8 + 4
+	if fixed {
+		return size
+	}
+	size += ssz.SizeSliceOfStaticObjects(sizer, obj.Withdrawals)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *PointerObjectsVariation) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineUint64(codec, &obj.Slot)                                // Field  (0) -        Slot - 8 bytes
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &obj.Withdrawals, 16) // Offset (1) - Withdrawals - 4 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.Withdrawals, 16) // Field  (1) - Withdrawals - ? bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *PointerObjectsVariation) NamesSSZ() []string {
+	return []string{"Slot", "Withdrawals", "Withdrawals"}
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 7c53d470f2f7c10dc062e6674c7e7ca9b980cfb02551efea95e19d100fbefd5d
 
 package consensus_spec_tests
 
@@ -21,3 +22,8 @@ func (obj *ProposerSlashing) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineStaticObject(codec, &obj.Header1) // Field  (0) - Header1 - ? bytes (SignedBeaconBlockHeader)
 	ssz.DefineStaticObject(codec, &obj.Header2) // Field  (1) - Header2 - ? bytes (SignedBeaconBlockHeader)
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *ProposerSlashing) NamesSSZ() []string {
+	return []string{"Header1", "Header2"}
+}
@@ -0,0 +1,48 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp d21d847c83435b064274b2d61ed053256001b06fb32ece6cb95abb0e21855ee7
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// Cached static size computed on package init.
+var staticSizeCacheReadOnlyVariation = ssz.PrecomputeStaticSizeCache(ReadOnlyVariation{})
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj ReadOnlyVariation) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
+	// Load static size if already precomputed, calculate otherwise
+	if fork := int(sizer.Fork()); fork < len(staticSizeCacheReadOnlyVariation) {
+		size = staticSizeCacheReadOnlyVariation[fork]
+	} else {
+		size = 8 + (*WithdrawalVariation)(nil).SizeSSZ(sizer) + 4
+	}
+	// Either return the static size or accumulate the dynamic too
+	if fixed {
+		return size
+	}
+	size += ssz.SizeDynamicBytes(sizer, obj.Extra)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj ReadOnlyVariation) DefineSSZ(codec *ssz.Codec) {
+	// Refuse decoding into a copy of the object, the data would be lost
+	if !ssz.DefineReadOnly(codec) {
+		return
+	}
+
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineUint64(codec, &obj.Slot)                  // Field  (0) -       Slot - 8 bytes
+	ssz.DefineStaticObject(codec, &obj.Withdrawal)      // Field  (1) - Withdrawal - ? bytes (WithdrawalVariation)
+	ssz.DefineDynamicBytesOffset(codec, &obj.Extra, 32) // Offset (2) -      Extra - 4 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineDynamicBytesContent(codec, &obj.Extra, 32) // Field  (2) -      Extra - ? bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj ReadOnlyVariation) NamesSSZ() []string {
+	return []string{"Slot", "Withdrawal", "Extra", "Extra"}
+}
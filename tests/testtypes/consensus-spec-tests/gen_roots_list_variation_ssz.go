@@ -0,0 +1,32 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 45f7f2cf19f103b99178d5f359a27df276e66471757f49401228b9d1c40420b4
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *RootsListVariation) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
+	size = 4
+	if fixed {
+		return size
+	}
+	size += ssz.SizeSliceOfStaticBytes(sizer, obj.Roots)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *RootsListVariation) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineSliceOfStaticBytesOffset(codec, &obj.Roots, 4096) // Offset (0) - Roots - 4 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineSliceOfStaticBytesContent(codec, &obj.Roots, 4096) // Field  (0) - Roots - ? bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *RootsListVariation) NamesSSZ() []string {
+	return []string{"Roots", "Roots"}
+}
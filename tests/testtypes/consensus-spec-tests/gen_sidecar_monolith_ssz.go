@@ -0,0 +1,39 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp ef429ef0f6637363eba2be905cad73886a5769634545e197d198170a1802f1a3
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *SidecarMonolith) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
+	size = 8 + 48 + 4
+	if fixed {
+		return size
+	}
+	size += ssz.SizeDynamicBytes(sizer, obj.Blob)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *SidecarMonolith) DefineSSZ(codec *ssz.Codec) {
+	// Refuse operating on the object outside of the forks it exists in
+	if !ssz.DefineObjectFork(codec, ssz.ForkFilter{Added: ssz.ForkDeneb}) {
+		return
+	}
+
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineUint64(codec, &obj.Index)                    // Field  (0) -         Index -  8 bytes
+	ssz.DefineStaticBytes(codec, &obj.KZGCommitment)       // Field  (1) - KZGCommitment - 48 bytes
+	ssz.DefineDynamicBytesOffset(codec, &obj.Blob, 131072) // Offset (2) -          Blob -  4 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineDynamicBytesContent(codec, &obj.Blob, 131072) // Field  (2) -          Blob - ? bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *SidecarMonolith) NamesSSZ() []string {
+	return []string{"Index", "KZGCommitment", "Blob", "Blob"}
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 19a50668f513748b5dff4e2a5e68e944a738cbe30d4c410c96d1266a9ca80156
 
 package consensus_spec_tests
 
@@ -21,3 +22,8 @@ func (obj *SignedBeaconBlockHeader) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineStaticObject(codec, &obj.Header)   // Field  (0) -    Header -  ? bytes (BeaconBlockHeader)
 	ssz.DefineStaticBytes(codec, &obj.Signature) // Field  (1) - Signature - 96 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *SignedBeaconBlockHeader) NamesSSZ() []string {
+	return []string{"Header", "Signature"}
+}
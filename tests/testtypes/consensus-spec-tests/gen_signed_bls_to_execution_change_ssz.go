@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp a8c6ef4bd05ff40ca2426ee96ed3dc82573e081f021f0a739040b18ee3d4c73b
 
 package consensus_spec_tests
 
@@ -21,3 +22,8 @@ func (obj *SignedBLSToExecutionChange) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineStaticObject(codec, &obj.Message)  // Field  (0) -   Message -  ? bytes (BLSToExecutionChange)
 	ssz.DefineStaticBytes(codec, &obj.Signature) // Field  (1) - Signature - 96 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *SignedBLSToExecutionChange) NamesSSZ() []string {
+	return []string{"Message", "Signature"}
+}
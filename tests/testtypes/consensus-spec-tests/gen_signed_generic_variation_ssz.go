@@ -0,0 +1,23 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 36c387cdccaa55fa2ad53eeba84534fa0b9235c5c3690e1de7bf1de00c2bd5d0
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *SignedGenericVariation[T]) SizeSSZ(sizer *ssz.Sizer) (size uint32) {
+	size = (*new(T)).SizeSSZ(sizer) + 96
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *SignedGenericVariation[T]) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineGenericStaticObject(codec, &obj.Message) // Field  (0) -   Message -  ? bytes (T)
+	ssz.DefineStaticBytes(codec, &obj.Signature)       // Field  (1) - Signature - 96 bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *SignedGenericVariation[T]) NamesSSZ() []string {
+	return []string{"Message", "Signature"}
+}
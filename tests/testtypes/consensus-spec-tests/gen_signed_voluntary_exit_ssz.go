@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 93a17a0f1bd7b8aac7d4d9f9738e650c770fb6161d88b4889836c9f0277711d1
 
 package consensus_spec_tests
 
@@ -21,3 +22,8 @@ func (obj *SignedVoluntaryExit) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineStaticObject(codec, &obj.Exit)     // Field  (0) -      Exit -  ? bytes (VoluntaryExit)
 	ssz.DefineStaticBytes(codec, &obj.Signature) // Field  (1) - Signature - 96 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *SignedVoluntaryExit) NamesSSZ() []string {
+	return []string{"Exit", "Signature"}
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp bbd4669b01261d13129957a9cc1ff2e608ef09fea7cd3be41a31386326446d12
 
 package consensus_spec_tests
 
@@ -16,3 +17,8 @@ func (obj *SingleFieldTestStructMonolith) SizeSSZ(sizer *ssz.Sizer) (size uint32
 func (obj *SingleFieldTestStructMonolith) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineUint8PointerOnFork(codec, &obj.A, ssz.ForkFilter{Added: ssz.ForkUnknown}) // Field  (0) - A - 1 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *SingleFieldTestStructMonolith) NamesSSZ() []string {
+	return []string{"A"}
+}
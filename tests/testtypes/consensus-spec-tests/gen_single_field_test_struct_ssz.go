@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 2b8a022f4327fd80a21b7399038814d0a4d8edb38f5defd19ea3cfb5d5ceb1fa
 
 package consensus_spec_tests
 
@@ -13,3 +14,8 @@ func (obj *SingleFieldTestStruct) SizeSSZ(sizer *ssz.Sizer) uint32 {
 func (obj *SingleFieldTestStruct) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineUint8(codec, &obj.A) // Field  (0) - A - 1 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *SingleFieldTestStruct) NamesSSZ() []string {
+	return []string{"A"}
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 58b57df8943121a49f8a268b9608a44b5cc3cef33e9f9054682aa893ac83ca54
 
 package consensus_spec_tests
 
@@ -18,3 +19,8 @@ func (obj *SmallTestStructMonolith) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineUint16PointerOnFork(codec, &obj.A, ssz.ForkFilter{Added: ssz.ForkUnknown}) // Field  (0) - A - 2 bytes
 	ssz.DefineUint16(codec, &obj.B)                                                      // Field  (1) - B - 2 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *SmallTestStructMonolith) NamesSSZ() []string {
+	return []string{"A", "B"}
+}
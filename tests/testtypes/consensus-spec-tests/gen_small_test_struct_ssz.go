@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp c5688ed667995925b84a5090e4dd4967e3f710581fc9b8835c1c347a40dbbbab
 
 package consensus_spec_tests
 
@@ -14,3 +15,8 @@ func (obj *SmallTestStruct) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineUint16(codec, &obj.A) // Field  (0) - A - 2 bytes
 	ssz.DefineUint16(codec, &obj.B) // Field  (1) - B - 2 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *SmallTestStruct) NamesSSZ() []string {
+	return []string{"A", "B"}
+}
@@ -0,0 +1,35 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 36bda5312088f209c2655dc7352dc0954833b385850ca1d98b5dd144da5f2dca
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *SortedListsVariation) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
+	size = 4 + 4
+	if fixed {
+		return size
+	}
+	size += ssz.SizeSliceOfUint64s(sizer, obj.AttestingIndices)
+	size += ssz.SizeSliceOfUint64s(sizer, obj.Balances)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *SortedListsVariation) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineSliceOfUint64sOffset(codec, &obj.AttestingIndices, 2048) // Offset (0) - AttestingIndices - 4 bytes
+	ssz.DefineSliceOfUint64sOffset(codec, &obj.Balances, 2048)         // Offset (1) -         Balances - 4 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineSortedSliceOfUint64sContent(codec, &obj.AttestingIndices, 2048) // Field  (0) - AttestingIndices - ? bytes
+	ssz.DefineSliceOfUint64sContent(codec, &obj.Balances, 2048)               // Field  (1) -         Balances - ? bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *SortedListsVariation) NamesSSZ() []string {
+	return []string{"AttestingIndices", "Balances", "AttestingIndices", "Balances"}
+}
@@ -0,0 +1,23 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 519c671f6e4e49fabffe8cdcc053d9c0597e425b06e77c8ce16d9b41a2ae2dba
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *StaticUint64sBytesVariation) SizeSSZ(sizer *ssz.Sizer) uint32 {
+	return 8 + EpochsPerSlashingsVector*8 + 40
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *StaticUint64sBytesVariation) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &obj.Slot)                    // Field  (0) -      Slot -     8 bytes
+	ssz.DefineArrayOfUint64s(codec, &obj.Slashings)       // Field  (1) - Slashings - 65536 bytes
+	ssz.DefineCheckedStaticBytes(codec, &obj.Weights, 40) // Field  (2) -   Weights -    40 bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *StaticUint64sBytesVariation) NamesSSZ() []string {
+	return []string{"Slot", "Slashings", "Weights"}
+}
@@ -0,0 +1,23 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp db10fe5e2484ed12bad45633270d3a9e845a56dd968516ba048558422c3b5234
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *StaticUint64sVariation) SizeSSZ(sizer *ssz.Sizer) uint32 {
+	return 8 + EpochsPerSlashingsVector*8 + 5*8
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *StaticUint64sVariation) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &obj.Slot)                                              // Field  (0) -      Slot -     8 bytes
+	ssz.DefineCheckedStaticUint64s(codec, &obj.Slashings, EpochsPerSlashingsVector) // Field  (1) - Slashings - 65536 bytes
+	ssz.DefineCheckedStaticUint64s(codec, &obj.Weights, 5)                          // Field  (2) -   Weights -    40 bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *StaticUint64sVariation) NamesSSZ() []string {
+	return []string{"Slot", "Slashings", "Weights"}
+}
@@ -0,0 +1,36 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp ef86ba1710e09a49989d7a84d5dd99cc57bda04994fb4b9183af86778d55c33c
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *StringBytesVariation) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
+	size = 4 + 8 + 4
+	if fixed {
+		return size
+	}
+	size += ssz.SizeDynamicBytes(sizer, obj.Name)
+	size += ssz.SizeDynamicBytes(sizer, obj.Memo)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *StringBytesVariation) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineDynamicBytesOffset(codec, &obj.Name, 32) // Offset (0) -  Name - 4 bytes
+	ssz.DefineUint64(codec, &obj.Nonce)                // Field  (1) - Nonce - 8 bytes
+	ssz.DefineDynamicBytesOffset(codec, &obj.Memo, 64) // Offset (2) -  Memo - 4 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineDynamicBytesContent(codec, &obj.Name, 32) // Field  (0) -  Name - ? bytes
+	ssz.DefineDynamicBytesContent(codec, &obj.Memo, 64) // Field  (2) -  Memo - ? bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *StringBytesVariation) NamesSSZ() []string {
+	return []string{"Name", "Nonce", "Memo", "Name", "Memo"}
+}
@@ -0,0 +1,36 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 75b33718e3adffc2ea7c222bf475793f0b48861c7041e2e7387d87d337f19867
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *StringsVariation) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
+	size = 4 + 8 + 4
+	if fixed {
+		return size
+	}
+	size += ssz.SizeString(sizer, obj.Name)
+	size += ssz.SizeString(sizer, obj.Memo)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *StringsVariation) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineStringOffset(codec, &obj.Name, 32) // Offset (0) -  Name - 4 bytes
+	ssz.DefineUint64(codec, &obj.Nonce)          // Field  (1) - Nonce - 8 bytes
+	ssz.DefineStringOffset(codec, &obj.Memo, 64) // Offset (2) -  Memo - 4 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineStringContent(codec, &obj.Name, 32)     // Field  (0) -  Name - ? bytes
+	ssz.DefineUTF8StringContent(codec, &obj.Memo, 64) // Field  (2) -  Memo - ? bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *StringsVariation) NamesSSZ() []string {
+	return []string{"Name", "Nonce", "Memo", "Name", "Memo"}
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 0b1b06588572196302e875fa9b90cd1178f746a4e3daf72011aa3e98323dd1bc
 
 package consensus_spec_tests
 
@@ -6,7 +7,7 @@ import "github.com/karalabe/ssz"
 
 // SizeSSZ returns the total size of the static ssz object.
 func (obj *SyncAggregate) SizeSSZ(sizer *ssz.Sizer) uint32 {
-	return 64 + 96
+	return SyncCommitteeBitsSize + 96
 }
 
 // DefineSSZ defines how an object is encoded/decoded.
@@ -14,3 +15,8 @@ func (obj *SyncAggregate) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineStaticBytes(codec, &obj.SyncCommiteeBits)      // Field  (0) -      SyncCommiteeBits - 64 bytes
 	ssz.DefineStaticBytes(codec, &obj.SyncCommiteeSignature) // Field  (1) - SyncCommiteeSignature - 96 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *SyncAggregate) NamesSSZ() []string {
+	return []string{"SyncCommiteeBits", "SyncCommiteeSignature"}
+}
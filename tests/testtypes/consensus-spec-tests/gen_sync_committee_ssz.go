@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 4a6d525416b91d5b7fc33d0abf12b87cea249f86565a09ff1690c4f9f9ae4473
 
 package consensus_spec_tests
 
@@ -6,7 +7,7 @@ import "github.com/karalabe/ssz"
 
 // SizeSSZ returns the total size of the static ssz object.
 func (obj *SyncCommittee) SizeSSZ(sizer *ssz.Sizer) uint32 {
-	return 512*48 + 48
+	return SyncCommitteeSize*48 + 48
 }
 
 // DefineSSZ defines how an object is encoded/decoded.
@@ -14,3 +15,8 @@ func (obj *SyncCommittee) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.PubKeys[:]) // Field  (0) -         PubKeys - 24576 bytes
 	ssz.DefineStaticBytes(codec, &obj.AggregatePubKey)        // Field  (1) - AggregatePubKey -    48 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *SyncCommittee) NamesSSZ() []string {
+	return []string{"PubKeys", "AggregatePubKey"}
+}
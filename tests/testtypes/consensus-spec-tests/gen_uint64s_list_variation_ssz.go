@@ -0,0 +1,32 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 4ec610e9751a8d495cd02bad1bce25c1fce3e26d161b2972c823e1e91d6ed728
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *Uint64sListVariation) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
+	size = 4
+	if fixed {
+		return size
+	}
+	size += ssz.SizeSliceOfUint64s(sizer, obj.Nums)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *Uint64sListVariation) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineSliceOfUint64sOffset(codec, &obj.Nums, 4096) // Offset (0) - Nums - 4 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineSliceOfUint64sContent(codec, &obj.Nums, 4096) // Field  (0) - Nums - ? bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *Uint64sListVariation) NamesSSZ() []string {
+	return []string{"Nums", "Nums"}
+}
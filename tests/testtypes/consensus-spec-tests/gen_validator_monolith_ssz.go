@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 792bdc9bd9cef4311507d12876013e0d5a3367f2d5e727a81676cdbf29abd702
 
 package consensus_spec_tests
 
@@ -25,3 +26,8 @@ func (obj *ValidatorMonolith) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineUint64(codec, &obj.ExitEpoch)                                                  // Field  (6) -                  ExitEpoch -  8 bytes
 	ssz.DefineUint64(codec, &obj.WithdrawableEpoch)                                          // Field  (7) -          WithdrawableEpoch -  8 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *ValidatorMonolith) NamesSSZ() []string {
+	return []string{"Pubkey", "WithdrawalCredentials", "EffectiveBalance", "Slashed", "ActivationEligibilityEpoch", "ActivationEpoch", "ExitEpoch", "WithdrawableEpoch"}
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 58668b67c8a8fa6f43c65401fa9f7a2c72db30376ed2f78ec8b4c35e1d6220d0
 
 package consensus_spec_tests
 
@@ -20,3 +21,8 @@ func (obj *Validator) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineUint64(codec, &obj.ExitEpoch)                  // Field  (6) -                  ExitEpoch -  8 bytes
 	ssz.DefineUint64(codec, &obj.WithdrawableEpoch)          // Field  (7) -          WithdrawableEpoch -  8 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *Validator) NamesSSZ() []string {
+	return []string{"Pubkey", "WithdrawalCredentials", "EffectiveBalance", "Slashed", "ActivationEligibilityEpoch", "ActivationEpoch", "ExitEpoch", "WithdrawableEpoch"}
+}
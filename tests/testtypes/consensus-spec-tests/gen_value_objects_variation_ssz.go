@@ -0,0 +1,72 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 669f3a35bac7d6248a486974fe7f8130effdf5022620fe7477bb5543663dd7dc
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *ValueObjectsVariation) SizeSSZ(sizer *ssz.Sizer, fixed bool) (size uint32) {
+	size = 8 + 4
+	if fixed {
+		return size
+	}
+	size += ssz.SizeSliceOfStaticObjectValues(sizer, obj.Withdrawals)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *ValueObjectsVariation) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineUint64(codec, &obj.Slot)                                     // Field  (0) -        Slot - 8 bytes
+	ssz.DefineSliceOfStaticObjectValuesOffset(codec, &obj.Withdrawals, 16) // Offset (1) - Withdrawals - 4 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineSliceOfStaticObjectValuesContent(codec, &obj.Withdrawals, 16) // Field  (1) - Withdrawals - ? bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *ValueObjectsVariation) NamesSSZ() []string {
+	return []string{"Slot", "Withdrawals", "Withdrawals"}
+}
+
+// Clone creates a deep copy of the object, retaining only the ssz fields.
+func (obj *ValueObjectsVariation) Clone() *ValueObjectsVariation {
+	if obj == nil {
+		return nil
+	}
+	clone := new(ValueObjectsVariation)
+	clone.Slot = obj.Slot
+	if obj.Withdrawals != nil {
+		clone.Withdrawals = make([]Withdrawal, len(obj.Withdrawals))
+		for i := range obj.Withdrawals {
+			clone.Withdrawals[i] = *obj.Withdrawals[i].Clone()
+		}
+	}
+	return clone
+}
+
+// EqualSSZ checks whether two objects are equal in their ssz representation. Nil
+// and zero values are considered equal, as they would encode the same.
+func (obj *ValueObjectsVariation) EqualSSZ(other *ValueObjectsVariation) bool {
+	if obj == nil {
+		obj = new(ValueObjectsVariation)
+	}
+	if other == nil {
+		other = new(ValueObjectsVariation)
+	}
+	if obj.Slot != other.Slot {
+		return false
+	}
+	if len(obj.Withdrawals) != len(other.Withdrawals) {
+		return false
+	}
+	for i := range obj.Withdrawals {
+		if !obj.Withdrawals[i].EqualSSZ(&other.Withdrawals[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp d78a15cfe209d82e237ff841844dba44e29cdcd32529a578e8cea96c2473eba7
 
 package consensus_spec_tests
 
@@ -14,3 +15,8 @@ func (obj *VoluntaryExit) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineUint64(codec, &obj.Epoch)          // Field  (0) -          Epoch - 8 bytes
 	ssz.DefineUint64(codec, &obj.ValidatorIndex) // Field  (1) - ValidatorIndex - 8 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *VoluntaryExit) NamesSSZ() []string {
+	return []string{"Epoch", "ValidatorIndex"}
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 8326d3b69ea63773bda53689f47aecb267d58bdaf6d936a2793af597b26918fd
 
 package consensus_spec_tests
 
@@ -16,3 +17,61 @@ func (obj *Withdrawal) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineStaticBytes(codec, &obj.Address) // Field  (2) -   Address - 20 bytes
 	ssz.DefineUint64(codec, &obj.Amount)       // Field  (3) -    Amount -  8 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *Withdrawal) NamesSSZ() []string {
+	return []string{"Index", "Validator", "Address", "Amount"}
+}
+
+// Clone creates a deep copy of the object, retaining only the ssz fields.
+func (obj *Withdrawal) Clone() *Withdrawal {
+	if obj == nil {
+		return nil
+	}
+	clone := new(Withdrawal)
+	clone.Index = obj.Index
+	clone.Validator = obj.Validator
+	clone.Address = obj.Address
+	clone.Amount = obj.Amount
+	return clone
+}
+
+// EqualSSZ checks whether two objects are equal in their ssz representation. Nil
+// and zero values are considered equal, as they would encode the same.
+func (obj *Withdrawal) EqualSSZ(other *Withdrawal) bool {
+	if obj == nil {
+		obj = new(Withdrawal)
+	}
+	if other == nil {
+		other = new(Withdrawal)
+	}
+	if obj.Index != other.Index {
+		return false
+	}
+	if obj.Validator != other.Validator {
+		return false
+	}
+	if obj.Address != other.Address {
+		return false
+	}
+	if obj.Amount != other.Amount {
+		return false
+	}
+	return true
+}
+
+// ResetSSZ clears all the ssz fields of the object, retaining the capacity of any
+// slices, so that pooled objects don't leak data across decodes.
+func (obj *Withdrawal) ResetSSZ() {
+	if obj == nil {
+		return
+	}
+	obj.Index = 0
+	obj.Validator = 0
+	obj.Address = Address{}
+	obj.Amount = 0
+}
+
+// UpgradeSSZ converts the object from the layout of one fork to another, zero
+// initializing the fields added and clearing the fields removed in between.
+func (obj *Withdrawal) UpgradeSSZ(from ssz.Fork, to ssz.Fork) {}
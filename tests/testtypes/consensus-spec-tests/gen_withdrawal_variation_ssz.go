@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 9df8d427e92764185d2c8d1bcf6c1de2fe298f7ea7d275e161918855a4945d72
 
 package consensus_spec_tests
 
@@ -16,3 +17,8 @@ func (obj *WithdrawalVariation) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineCheckedStaticBytes(codec, &obj.Address, 20) // Field  (2) -   Address - 20 bytes
 	ssz.DefineUint64(codec, &obj.Amount)                  // Field  (3) -    Amount -  8 bytes
 }
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *WithdrawalVariation) NamesSSZ() []string {
+	return []string{"Index", "Validator", "Address", "Amount"}
+}
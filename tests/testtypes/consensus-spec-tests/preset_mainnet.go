@@ -0,0 +1,23 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build !minimal
+
+package consensus_spec_tests
+
+// Constants of the consensus spec "mainnet" preset, used by default. Build with
+// the "minimal" tag to switch the types over to the minimal preset.
+const (
+	Preset = "mainnet" // Name of the preset, as used by the spec test folders
+
+	SlotsPerHistoricalRoot     = 8192  // SLOTS_PER_HISTORICAL_ROOT
+	EpochsPerHistoricalVector  = 65536 // EPOCHS_PER_HISTORICAL_VECTOR
+	EpochsPerSlashingsVector   = 8192  // EPOCHS_PER_SLASHINGS_VECTOR
+	MaxEth1DataVotes           = 2048  // EPOCHS_PER_ETH1_VOTING_PERIOD * SLOTS_PER_EPOCH
+	MaxPendingAttestations     = 4096  // MAX_ATTESTATIONS * SLOTS_PER_EPOCH
+	SyncCommitteeSize          = 512   // SYNC_COMMITTEE_SIZE
+	SyncCommitteeBitsSize      = 64    // SYNC_COMMITTEE_SIZE / 8
+	MaxWithdrawalsPerPayload   = 16    // MAX_WITHDRAWALS_PER_PAYLOAD
+	MaxBlobCommitmentsPerBlock = 4096  // MAX_BLOB_COMMITMENTS_PER_BLOCK
+)
@@ -0,0 +1,23 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build minimal
+
+package consensus_spec_tests
+
+// Constants of the consensus spec "minimal" preset, used when building with the
+// "minimal" tag.
+const (
+	Preset = "minimal" // Name of the preset, as used by the spec test folders
+
+	SlotsPerHistoricalRoot     = 64   // SLOTS_PER_HISTORICAL_ROOT
+	EpochsPerHistoricalVector  = 64   // EPOCHS_PER_HISTORICAL_VECTOR
+	EpochsPerSlashingsVector   = 64   // EPOCHS_PER_SLASHINGS_VECTOR
+	MaxEth1DataVotes           = 32   // EPOCHS_PER_ETH1_VOTING_PERIOD * SLOTS_PER_EPOCH
+	MaxPendingAttestations     = 1024 // MAX_ATTESTATIONS * SLOTS_PER_EPOCH
+	SyncCommitteeSize          = 32   // SYNC_COMMITTEE_SIZE
+	SyncCommitteeBitsSize      = 4    // SYNC_COMMITTEE_SIZE / 8
+	MaxWithdrawalsPerPayload   = 4    // MAX_WITHDRAWALS_PER_PAYLOAD
+	MaxBlobCommitmentsPerBlock = 16   // MAX_BLOB_COMMITMENTS_PER_BLOCK
+)
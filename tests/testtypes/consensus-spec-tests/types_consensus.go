@@ -13,7 +13,7 @@ import (
 //go:generate go run -cover ../../../cmd/sszgen -type AttestationData -out gen_attestation_data_ssz.go
 //go:generate go run -cover ../../../cmd/sszgen -type BeaconBlockHeader -out gen_beacon_block_header_ssz.go
 //go:generate go run -cover ../../../cmd/sszgen -type BLSToExecutionChange -out gen_bls_to_execution_change_ssz.go
-//go:generate go run -cover ../../../cmd/sszgen -type Attestation -out gen_attestation_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type Attestation -sizetable -out gen_attestation_ssz.go
 //go:generate go run -cover ../../../cmd/sszgen -type AggregateAndProof -out gen_aggregate_and_proof_ssz.go
 //go:generate go run -cover ../../../cmd/sszgen -type DepositData -out gen_deposit_data_ssz.go
 //go:generate go run -cover ../../../cmd/sszgen -type DepositMessage -out gen_deposit_message_ssz.go
@@ -36,7 +36,7 @@ import (
 //go:generate go run -cover ../../../cmd/sszgen -type VoluntaryExit -out gen_voluntary_exit_ssz.go
 //go:generate go run -cover ../../../cmd/sszgen -type SignedVoluntaryExit -out gen_signed_voluntary_exit_ssz.go
 //go:generate go run -cover ../../../cmd/sszgen -type Validator -out gen_validator_ssz.go
-//go:generate go run -cover ../../../cmd/sszgen -type Withdrawal -out gen_withdrawal_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type Withdrawal -extras clone,equal,reset,upgrade -tests -out gen_withdrawal_ssz.go
 //go:generate go run -cover ../../../cmd/sszgen -type ExecutionPayloadCapella -out gen_execution_payload_capella_ssz.go
 //go:generate go run -cover ../../../cmd/sszgen -type ExecutionPayloadHeaderCapella -out gen_execution_payload_header_capella_ssz.go
 //go:generate go run -cover ../../../cmd/sszgen -type ExecutionPayloadDeneb -out gen_execution_payload_deneb_ssz.go
@@ -66,7 +66,7 @@ type Address [20]byte
 type LogsBloom [256]byte
 
 // Roots is a helper type to force a generator quirk.
-type Roots [8192]Hash
+type Roots [SlotsPerHistoricalRoot]Hash
 
 type AggregateAndProof struct {
 	Index          uint64
@@ -83,7 +83,7 @@ type Attestation struct {
 type AttestationData struct {
 	Slot            Slot
 	Index           uint64
-	BeaconBlockHash Hash
+	BeaconBlockHash Hash `yaml:"beacon_block_root"`
 	Source          *Checkpoint
 	Target          *Checkpoint
 }
@@ -171,7 +171,7 @@ type BeaconBlockBodyDeneb struct {
 	SyncAggregate         *SyncAggregate
 	ExecutionPayload      *ExecutionPayloadDeneb
 	BlsToExecutionChanges []*SignedBLSToExecutionChange `ssz-max:"16"`
-	BlobKzgCommitments    [][48]byte                    `ssz-max:"4096"`
+	BlobKzgCommitments    [][48]byte                    `ssz-max:"MaxBlobCommitmentsPerBlock"`
 }
 
 type BeaconState struct {
@@ -180,19 +180,19 @@ type BeaconState struct {
 	Slot                        uint64
 	Fork                        *Fork
 	LatestBlockHeader           *BeaconBlockHeader
-	BlockRoots                  [8192][32]byte
-	StateRoots                  [8192][32]byte
-	HistoricalRoots             [][32]byte `ssz-max:"16777216"`
+	BlockRoots                  [SlotsPerHistoricalRoot][32]byte `ssz-size:"SlotsPerHistoricalRoot,32"`
+	StateRoots                  [SlotsPerHistoricalRoot][32]byte `ssz-size:"SlotsPerHistoricalRoot,32"`
+	HistoricalRoots             [][32]byte                       `ssz-max:"16777216"`
 	Eth1Data                    *Eth1Data
-	Eth1DataVotes               []*Eth1Data `ssz-max:"2048"`
+	Eth1DataVotes               []*Eth1Data `ssz-max:"MaxEth1DataVotes"`
 	Eth1DepositIndex            uint64
-	Validators                  []*Validator `ssz-max:"1099511627776"`
-	Balances                    []uint64     `ssz-max:"1099511627776"`
-	RandaoMixes                 [65536][32]byte
-	Slashings                   [8192]uint64
-	PreviousEpochAttestations   []*PendingAttestation `ssz-max:"4096"`
-	CurrentEpochAttestations    []*PendingAttestation `ssz-max:"4096"`
-	JustificationBits           [1]byte               `ssz-size:"4" ssz:"bits"`
+	Validators                  []*Validator                        `ssz-max:"1099511627776"`
+	Balances                    []uint64                            `ssz-max:"1099511627776"`
+	RandaoMixes                 [EpochsPerHistoricalVector][32]byte `ssz-size:"EpochsPerHistoricalVector,32"`
+	Slashings                   [EpochsPerSlashingsVector]uint64    `ssz-size:"EpochsPerSlashingsVector"`
+	PreviousEpochAttestations   []*PendingAttestation               `ssz-max:"MaxPendingAttestations"`
+	CurrentEpochAttestations    []*PendingAttestation               `ssz-max:"MaxPendingAttestations"`
+	JustificationBits           [1]byte                             `ssz-size:"4" ssz:"bits"`
 	PreviousJustifiedCheckpoint *Checkpoint
 	CurrentJustifiedCheckpoint  *Checkpoint
 	FinalizedCheckpoint         *Checkpoint
@@ -204,19 +204,19 @@ type BeaconStateAltair struct {
 	Slot                        uint64
 	Fork                        *Fork
 	LatestBlockHeader           *BeaconBlockHeader
-	BlockRoots                  [8192][32]byte
-	StateRoots                  [8192][32]byte
-	HistoricalRoots             [][32]byte `ssz-max:"16777216"`
+	BlockRoots                  [SlotsPerHistoricalRoot][32]byte `ssz-size:"SlotsPerHistoricalRoot,32"`
+	StateRoots                  [SlotsPerHistoricalRoot][32]byte `ssz-size:"SlotsPerHistoricalRoot,32"`
+	HistoricalRoots             [][32]byte                       `ssz-max:"16777216"`
 	Eth1Data                    *Eth1Data
-	Eth1DataVotes               []*Eth1Data `ssz-max:"2048"`
+	Eth1DataVotes               []*Eth1Data `ssz-max:"MaxEth1DataVotes"`
 	Eth1DepositIndex            uint64
-	Validators                  []*Validator `ssz-max:"1099511627776"`
-	Balances                    []uint64     `ssz-max:"1099511627776"`
-	RandaoMixes                 [65536][32]byte
-	Slashings                   [8192]uint64
-	PreviousEpochParticipation  []byte  `ssz-max:"1099511627776"`
-	CurrentEpochParticipation   []byte  `ssz-max:"1099511627776"`
-	JustificationBits           [1]byte `ssz-size:"4" ssz:"bits"`
+	Validators                  []*Validator                        `ssz-max:"1099511627776"`
+	Balances                    []uint64                            `ssz-max:"1099511627776"`
+	RandaoMixes                 [EpochsPerHistoricalVector][32]byte `ssz-size:"EpochsPerHistoricalVector,32"`
+	Slashings                   [EpochsPerSlashingsVector]uint64    `ssz-size:"EpochsPerSlashingsVector"`
+	PreviousEpochParticipation  []byte                              `ssz-max:"1099511627776"`
+	CurrentEpochParticipation   []byte                              `ssz-max:"1099511627776"`
+	JustificationBits           [1]byte                             `ssz-size:"4" ssz:"bits"`
 	PreviousJustifiedCheckpoint *Checkpoint
 	CurrentJustifiedCheckpoint  *Checkpoint
 	FinalizedCheckpoint         *Checkpoint
@@ -231,19 +231,19 @@ type BeaconStateBellatrix struct {
 	Slot                         uint64
 	Fork                         *Fork
 	LatestBlockHeader            *BeaconBlockHeader
-	BlockRoots                   [8192][32]byte
-	StateRoots                   [8192][32]byte
-	HistoricalRoots              [][32]byte `ssz-max:"16777216"`
+	BlockRoots                   [SlotsPerHistoricalRoot][32]byte `ssz-size:"SlotsPerHistoricalRoot,32"`
+	StateRoots                   [SlotsPerHistoricalRoot][32]byte `ssz-size:"SlotsPerHistoricalRoot,32"`
+	HistoricalRoots              [][32]byte                       `ssz-max:"16777216"`
 	Eth1Data                     *Eth1Data
-	Eth1DataVotes                []*Eth1Data `ssz-max:"2048"`
+	Eth1DataVotes                []*Eth1Data `ssz-max:"MaxEth1DataVotes"`
 	Eth1DepositIndex             uint64
-	Validators                   []*Validator `ssz-max:"1099511627776"`
-	Balances                     []uint64     `ssz-max:"1099511627776"`
-	RandaoMixes                  [65536][32]byte
-	Slashings                    [8192]uint64
-	PreviousEpochParticipation   []byte  `ssz-max:"1099511627776"`
-	CurrentEpochParticipation    []byte  `ssz-max:"1099511627776"`
-	JustificationBits            [1]byte `ssz-size:"4" ssz:"bits"`
+	Validators                   []*Validator                        `ssz-max:"1099511627776"`
+	Balances                     []uint64                            `ssz-max:"1099511627776"`
+	RandaoMixes                  [EpochsPerHistoricalVector][32]byte `ssz-size:"EpochsPerHistoricalVector,32"`
+	Slashings                    [EpochsPerSlashingsVector]uint64    `ssz-size:"EpochsPerSlashingsVector"`
+	PreviousEpochParticipation   []byte                              `ssz-max:"1099511627776"`
+	CurrentEpochParticipation    []byte                              `ssz-max:"1099511627776"`
+	JustificationBits            [1]byte                             `ssz-size:"4" ssz:"bits"`
 	PreviousJustifiedCheckpoint  *Checkpoint
 	CurrentJustifiedCheckpoint   *Checkpoint
 	FinalizedCheckpoint          *Checkpoint
@@ -259,19 +259,19 @@ type BeaconStateCapella struct {
 	Slot                         uint64
 	Fork                         *Fork
 	LatestBlockHeader            *BeaconBlockHeader
-	BlockRoots                   [8192][32]byte
-	StateRoots                   [8192][32]byte
-	HistoricalRoots              [][32]byte `ssz-max:"16777216"`
+	BlockRoots                   [SlotsPerHistoricalRoot][32]byte `ssz-size:"SlotsPerHistoricalRoot,32"`
+	StateRoots                   [SlotsPerHistoricalRoot][32]byte `ssz-size:"SlotsPerHistoricalRoot,32"`
+	HistoricalRoots              [][32]byte                       `ssz-max:"16777216"`
 	Eth1Data                     *Eth1Data
-	Eth1DataVotes                []*Eth1Data `ssz-max:"2048"`
+	Eth1DataVotes                []*Eth1Data `ssz-max:"MaxEth1DataVotes"`
 	Eth1DepositIndex             uint64
-	Validators                   []*Validator `ssz-max:"1099511627776"`
-	Balances                     []uint64     `ssz-max:"1099511627776"`
-	RandaoMixes                  [65536][32]byte
-	Slashings                    [8192]uint64
-	PreviousEpochParticipation   []byte  `ssz-max:"1099511627776"`
-	CurrentEpochParticipation    []byte  `ssz-max:"1099511627776"`
-	JustificationBits            [1]byte `ssz-size:"4" ssz:"bits"`
+	Validators                   []*Validator                        `ssz-max:"1099511627776"`
+	Balances                     []uint64                            `ssz-max:"1099511627776"`
+	RandaoMixes                  [EpochsPerHistoricalVector][32]byte `ssz-size:"EpochsPerHistoricalVector,32"`
+	Slashings                    [EpochsPerSlashingsVector]uint64    `ssz-size:"EpochsPerSlashingsVector"`
+	PreviousEpochParticipation   []byte                              `ssz-max:"1099511627776"`
+	CurrentEpochParticipation    []byte                              `ssz-max:"1099511627776"`
+	JustificationBits            [1]byte                             `ssz-size:"4" ssz:"bits"`
 	PreviousJustifiedCheckpoint  *Checkpoint
 	CurrentJustifiedCheckpoint   *Checkpoint
 	FinalizedCheckpoint          *Checkpoint
@@ -290,19 +290,19 @@ type BeaconStateDeneb struct {
 	Slot                         uint64
 	Fork                         *Fork
 	LatestBlockHeader            *BeaconBlockHeader
-	BlockRoots                   [8192][32]byte
-	StateRoots                   [8192][32]byte
-	HistoricalRoots              [][32]byte `ssz-max:"16777216"`
+	BlockRoots                   [SlotsPerHistoricalRoot][32]byte `ssz-size:"SlotsPerHistoricalRoot,32"`
+	StateRoots                   [SlotsPerHistoricalRoot][32]byte `ssz-size:"SlotsPerHistoricalRoot,32"`
+	HistoricalRoots              [][32]byte                       `ssz-max:"16777216"`
 	Eth1Data                     *Eth1Data
-	Eth1DataVotes                []*Eth1Data `ssz-max:"2048"`
+	Eth1DataVotes                []*Eth1Data `ssz-max:"MaxEth1DataVotes"`
 	Eth1DepositIndex             uint64
-	Validators                   []*Validator `ssz-max:"1099511627776"`
-	Balances                     []uint64     `ssz-max:"1099511627776"`
-	RandaoMixes                  [65536][32]byte
-	Slashings                    [8192]uint64
-	PreviousEpochParticipation   []byte  `ssz-max:"1099511627776"`
-	CurrentEpochParticipation    []byte  `ssz-max:"1099511627776"`
-	JustificationBits            [1]byte `ssz-size:"4" ssz:"bits"`
+	Validators                   []*Validator                        `ssz-max:"1099511627776"`
+	Balances                     []uint64                            `ssz-max:"1099511627776"`
+	RandaoMixes                  [EpochsPerHistoricalVector][32]byte `ssz-size:"EpochsPerHistoricalVector,32"`
+	Slashings                    [EpochsPerSlashingsVector]uint64    `ssz-size:"EpochsPerSlashingsVector"`
+	PreviousEpochParticipation   []byte                              `ssz-max:"1099511627776"`
+	CurrentEpochParticipation    []byte                              `ssz-max:"1099511627776"`
+	JustificationBits            [1]byte                             `ssz-size:"4" ssz:"bits"`
 	PreviousJustifiedCheckpoint  *Checkpoint
 	CurrentJustifiedCheckpoint   *Checkpoint
 	FinalizedCheckpoint          *Checkpoint
@@ -387,7 +387,7 @@ type ExecutionPayloadCapella struct {
 	BaseFeePerGas *uint256.Int
 	BlockHash     Hash
 	Transactions  [][]byte      `ssz-max:"1048576,1073741824"`
-	Withdrawals   []*Withdrawal `ssz-max:"16"`
+	Withdrawals   []*Withdrawal `ssz-max:"MaxWithdrawalsPerPayload"`
 }
 
 type ExecutionPayloadDeneb struct {
@@ -405,7 +405,7 @@ type ExecutionPayloadDeneb struct {
 	BaseFeePerGas *uint256.Int
 	BlockHash     Hash
 	Transactions  [][]byte      `ssz-max:"1048576,1073741824"`
-	Withdrawals   []*Withdrawal `ssz-max:"16"`
+	Withdrawals   []*Withdrawal `ssz-max:"MaxWithdrawalsPerPayload"`
 	BlobGasUsed   uint64
 	ExcessBlobGas uint64
 }
@@ -442,7 +442,7 @@ type ExecutionPayloadHeaderCapella struct {
 	BaseFeePerGas    [32]byte
 	BlockHash        [32]byte
 	TransactionsRoot [32]byte
-	WithdrawalRoot   [32]byte
+	WithdrawalRoot   [32]byte `yaml:"withdrawals_root"`
 }
 
 type ExecutionPayloadHeaderDeneb struct {
@@ -460,7 +460,7 @@ type ExecutionPayloadHeaderDeneb struct {
 	BaseFeePerGas    [32]byte
 	BlockHash        [32]byte
 	TransactionsRoot [32]byte
-	WithdrawalRoot   [32]byte
+	WithdrawalRoot   [32]byte `yaml:"withdrawals_root"`
 	BlobGasUsed      uint64
 	ExcessBlobGas    uint64
 }
@@ -472,8 +472,8 @@ type Fork struct {
 }
 
 type HistoricalBatch struct {
-	BlockRoots [8192]Hash
-	StateRoots Roots
+	BlockRoots [SlotsPerHistoricalRoot]Hash `ssz-size:"SlotsPerHistoricalRoot,32"`
+	StateRoots Roots                        `ssz-size:"SlotsPerHistoricalRoot,32"`
 }
 
 type HistoricalSummary struct {
@@ -510,17 +510,17 @@ type SignedBLSToExecutionChange struct {
 }
 
 type SignedVoluntaryExit struct {
-	Exit      *VoluntaryExit
+	Exit      *VoluntaryExit `yaml:"message"`
 	Signature [96]byte
 }
 
 type SyncAggregate struct {
-	SyncCommiteeBits      [64]byte
-	SyncCommiteeSignature [96]byte
+	SyncCommiteeBits      [SyncCommitteeBitsSize]byte `ssz-size:"SyncCommitteeBitsSize" yaml:"sync_committee_bits"`
+	SyncCommiteeSignature [96]byte                    `yaml:"sync_committee_signature"`
 }
 
 type SyncCommittee struct {
-	PubKeys         [512][48]byte
+	PubKeys         [SyncCommitteeSize][48]byte `ssz-size:"SyncCommitteeSize,48"`
 	AggregatePubKey [48]byte
 }
 
@@ -542,7 +542,7 @@ type Validator struct {
 
 type Withdrawal struct {
 	Index     uint64
-	Validator uint64
+	Validator uint64 `yaml:"validator_index"`
 	Address   Address
 	Amount    uint64
 }
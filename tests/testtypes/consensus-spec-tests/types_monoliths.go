@@ -8,6 +8,7 @@ import (
 	"math/big"
 
 	"github.com/holiman/uint256"
+	"github.com/karalabe/ssz"
 	"github.com/prysmaticlabs/go-bitfield"
 )
 
@@ -16,12 +17,18 @@ import (
 //go:generate go run -cover ../../../cmd/sszgen -type FixedTestStructMonolith -out gen_fixed_test_struct_monolith_ssz.go
 //go:generate go run -cover ../../../cmd/sszgen -type BitsStructMonolith -out gen_bits_struct_monolith_ssz.go
 
-//go:generate go run -cover ../../../cmd/sszgen -type ExecutionPayloadMonolith -out gen_execution_payload_monolith_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type ExecutionPayloadMonolith -extras clone,equal,reset,upgrade -out gen_execution_payload_monolith_ssz.go
 //go:generate go run -cover ../../../cmd/sszgen -type ExecutionPayloadMonolith2 -out gen_execution_payload_monolith_2_ssz.go
 //go:generate go run -cover ../../../cmd/sszgen -type ExecutionPayloadHeaderMonolith -out gen_execution_payload_header_monolith_ssz.go
-//go:generate go run -cover ../../../cmd/sszgen -type BeaconBlockBodyMonolith -out gen_beacon_block_body_monolith_ssz.go
-//go:generate go run -cover ../../../cmd/sszgen -type BeaconStateMonolith -out gen_beacon_state_monolith_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type BeaconBlockBodyMonolith -sizetable -out gen_beacon_block_body_monolith_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type BeaconStateMonolith -sizetable -out gen_beacon_state_monolith_ssz.go
 //go:generate go run -cover ../../../cmd/sszgen -type ValidatorMonolith -out gen_validator_monolith_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type ForkRangesMonolith -out gen_fork_ranges_monolith_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type CustomForkMonolith -out gen_custom_fork_monolith_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type ListForksMonolith -out gen_list_forks_monolith_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type SidecarMonolith -out gen_sidecar_monolith_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type AttestationMonolith -sizetable -out gen_attestation_monolith_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type ForkTypesMonolith -out gen_fork_types_monolith_ssz.go
 
 type SingleFieldTestStructMonolith struct {
 	A *byte `ssz-fork:"unknown"`
@@ -58,7 +65,7 @@ type BeaconBlockBodyMonolith struct {
 	SyncAggregate         *SyncAggregate                `               ssz-fork:"altair"`
 	ExecutionPayload      *ExecutionPayloadMonolith     `               ssz-fork:"bellatrix"`
 	BlsToExecutionChanges []*SignedBLSToExecutionChange `ssz-max:"16"   ssz-fork:"capella"`
-	BlobKzgCommitments    [][48]byte                    `ssz-max:"4096" ssz-fork:"deneb"`
+	BlobKzgCommitments    [][48]byte                    `ssz-max:"MaxBlobCommitmentsPerBlock" ssz-fork:"deneb"`
 }
 
 type BeaconStateMonolith struct {
@@ -67,21 +74,21 @@ type BeaconStateMonolith struct {
 	Slot                         uint64
 	Fork                         *Fork
 	LatestBlockHeader            *BeaconBlockHeader
-	BlockRoots                   [8192][32]byte
-	StateRoots                   [8192][32]byte
-	HistoricalRoots              [][32]byte `ssz-max:"16777216"`
+	BlockRoots                   [SlotsPerHistoricalRoot][32]byte `ssz-size:"SlotsPerHistoricalRoot,32"`
+	StateRoots                   [SlotsPerHistoricalRoot][32]byte `ssz-size:"SlotsPerHistoricalRoot,32"`
+	HistoricalRoots              [][32]byte                       `ssz-max:"16777216"`
 	Eth1Data                     *Eth1Data
-	Eth1DataVotes                []*Eth1Data `ssz-max:"2048"`
+	Eth1DataVotes                []*Eth1Data `ssz-max:"MaxEth1DataVotes"`
 	Eth1DepositIndex             uint64
-	Validators                   []*Validator `ssz-max:"1099511627776"`
-	Balances                     []uint64     `ssz-max:"1099511627776"`
-	RandaoMixes                  [65536][32]byte
-	Slashings                    *[8192]uint64         `ssz-fork:"unknown"`
-	PreviousEpochAttestations    []*PendingAttestation `ssz-max:"4096"          ssz-fork:"!altair"`
-	CurrentEpochAttestations     []*PendingAttestation `ssz-max:"4096"          ssz-fork:"!altair"`
-	PreviousEpochParticipation   []byte                `ssz-max:"1099511627776" ssz-fork:"altair"`
-	CurrentEpochParticipation    []byte                `ssz-max:"1099511627776" ssz-fork:"altair"`
-	JustificationBits            [1]byte               `ssz-size:"4" ssz:"bits"`
+	Validators                   []*Validator                        `ssz-max:"1099511627776"`
+	Balances                     []uint64                            `ssz-max:"1099511627776"`
+	RandaoMixes                  [EpochsPerHistoricalVector][32]byte `ssz-size:"EpochsPerHistoricalVector,32"`
+	Slashings                    *[EpochsPerSlashingsVector]uint64   `ssz-size:"EpochsPerSlashingsVector" ssz-fork:"unknown"`
+	PreviousEpochAttestations    []*PendingAttestation               `ssz-max:"MaxPendingAttestations"          ssz-fork:"!altair"`
+	CurrentEpochAttestations     []*PendingAttestation               `ssz-max:"MaxPendingAttestations"          ssz-fork:"!altair"`
+	PreviousEpochParticipation   []byte                              `ssz-max:"1099511627776" ssz-fork:"altair"`
+	CurrentEpochParticipation    []byte                              `ssz-max:"1099511627776" ssz-fork:"altair"`
+	JustificationBits            [1]byte                             `ssz-size:"4" ssz:"bits"`
 	PreviousJustifiedCheckpoint  *Checkpoint
 	CurrentJustifiedCheckpoint   *Checkpoint
 	FinalizedCheckpoint          *Checkpoint
@@ -109,7 +116,7 @@ type ExecutionPayloadMonolith struct {
 	BaseFeePerGas *uint256.Int `ssz-fork:"unknown"`
 	BlockHash     Hash
 	Transactions  [][]byte      `ssz-max:"1048576,1073741824" ssz-fork:"unknown"`
-	Withdrawals   []*Withdrawal `ssz-max:"16" ssz-fork:"shanghai"`
+	Withdrawals   []*Withdrawal `ssz-max:"MaxWithdrawalsPerPayload" ssz-fork:"shanghai"`
 	BlobGasUsed   *uint64       `             ssz-fork:"cancun"`
 	ExcessBlobGas *uint64       `             ssz-fork:"cancun"`
 }
@@ -129,7 +136,7 @@ type ExecutionPayloadMonolith2 struct {
 	BaseFeePerGas *big.Int `ssz-fork:"unknown"`
 	BlockHash     Hash
 	Transactions  [][]byte      `ssz-max:"1048576,1073741824"`
-	Withdrawals   []*Withdrawal `ssz-max:"16" ssz-fork:"shanghai"`
+	Withdrawals   []*Withdrawal `ssz-max:"MaxWithdrawalsPerPayload" ssz-fork:"shanghai"`
 	BlobGasUsed   *uint64       `             ssz-fork:"cancun"`
 	ExcessBlobGas *uint64       `             ssz-fork:"cancun"`
 }
@@ -149,7 +156,7 @@ type ExecutionPayloadHeaderMonolith struct {
 	BaseFeePerGas    [32]byte
 	BlockHash        [32]byte
 	TransactionsRoot [32]byte
-	WithdrawalRoot   *[32]byte `ssz-fork:"shanghai"`
+	WithdrawalRoot   *[32]byte `ssz-fork:"shanghai" yaml:"withdrawals_root"`
 	BlobGasUsed      *uint64   `ssz-fork:"cancun"`
 	ExcessBlobGas    *uint64   `ssz-fork:"cancun"`
 }
@@ -164,3 +171,65 @@ type ValidatorMonolith struct {
 	ExitEpoch                  uint64
 	WithdrawableEpoch          uint64
 }
+
+// ForkRangesMonolith tests fields present in multiple disjoint fork ranges, such
+// as being removed and later re-introduced.
+type ForkRangesMonolith struct {
+	A uint64
+	B *uint64 `ssz-fork:"altair-capella,electra"`
+	C []byte  `ssz-max:"32" ssz-fork:"bellatrix-deneb"`
+	D *uint32 `ssz-fork:"phase0-altair,bellatrix-capella,deneb"`
+}
+
+// ForkCustom is a custom fork appended after the built-in ones, to test that
+// downstream chains can extend the fork schedule.
+const ForkCustom = ssz.ForkElectra + 1
+
+func init() {
+	ssz.RegisterFork("custom", ForkCustom)
+}
+
+// CustomForkMonolith tests fields guarded by custom forks declared outside of the
+// ssz library.
+type CustomForkMonolith struct {
+	A uint64
+	B *uint64 `ssz-fork:"custom"`
+	C *uint64 `ssz-fork:"deneb-custom"`
+}
+
+// ListForksMonolith tests lists added in later forks, which need to distinguish
+// between being missing from a fork (nil) and being empty.
+type ListForksMonolith struct {
+	Slot                uint64
+	InactivityScores    *[]uint64              `ssz-max:"1099511627776" ssz-fork:"altair"`
+	HistoricalRoots     *[][32]byte            `ssz-max:"16777216"      ssz-fork:"bellatrix"`
+	HistoricalSummaries *[]*HistoricalSummary  `ssz-max:"16777216"      ssz-fork:"capella"`
+	PendingAttestations *[]*PendingAttestation `ssz-max:"4096"          ssz-fork:"deneb"`
+}
+
+// SidecarMonolith tests types that don't exist at all before a fork, refusing to
+// be encoded, decoded or hashed in earlier ones.
+//
+//ssz:fork deneb
+type SidecarMonolith struct {
+	Index         uint64
+	KZGCommitment [48]byte
+	Blob          []byte `ssz-max:"131072"`
+}
+
+// AttestationMonolith tests fields switching their encoding in a later fork, here
+// the aggregation bits growing from a committee to a full slot in Electra.
+type AttestationMonolith struct {
+	AggregationBits bitfield.Bitlist `ssz-max:"2048" ssz-fork-type:"electra:SliceOfBits(131072)"`
+	Data            *AttestationData
+	Signature       [96]byte
+	CommitteeBits   *[8]byte `ssz-size:"64" ssz:"bits" ssz-fork:"electra"`
+}
+
+// ForkTypesMonolith tests fork specific encodings changing the static size of a
+// container, the bit size of a vector and the limit of a dynamic field.
+type ForkTypesMonolith struct {
+	Flags   []byte  `ssz-size:"4" ssz-fork-type:"deneb:CheckedStaticBytes(8),electra:CheckedStaticBytes(16)"`
+	Bits    [1]byte `ssz-size:"4" ssz:"bits" ssz-fork-type:"electra:ArrayOfBits(8)"`
+	Payload []byte  `ssz-max:"16" ssz-fork-type:"electra:DynamicBytes(32)"`
+}
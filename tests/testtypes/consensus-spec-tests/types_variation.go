@@ -7,6 +7,9 @@ package consensus_spec_tests
 import (
 	"math/big"
 
+	"github.com/holiman/uint256"
+	"github.com/karalabe/ssz"
+	"github.com/karalabe/ssz/tests/testtypes/external"
 	"github.com/prysmaticlabs/go-bitfield"
 )
 
@@ -19,17 +22,45 @@ import (
 //go:generate go run -cover ../../../cmd/sszgen -type AttestationDataVariation1 -out gen_attestation_data_variation_1_ssz.go
 //go:generate go run -cover ../../../cmd/sszgen -type AttestationDataVariation2 -out gen_attestation_data_variation_2_ssz.go
 //go:generate go run -cover ../../../cmd/sszgen -type AttestationDataVariation3 -out gen_attestation_data_variation_3_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type MapsVariation -out gen_maps_variation_ssz.go -extras clone,equal,reset
+//go:generate go run -cover ../../../cmd/sszgen -type StringsVariation -out gen_strings_variation_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type SortedListsVariation -out gen_sorted_lists_variation_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type ReadOnlyVariation -out gen_read_only_variation_ssz.go -valuereceivers
+//go:generate go run -cover ../../../cmd/sszgen -type PackedArraysVariation -out gen_packed_arrays_variation_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type PackedListsVariation -out gen_packed_lists_variation_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type MultiDimArraysVariation -out gen_multi_dim_arrays_variation_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type NamedLimitsVariation -out gen_named_limits_variation_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type CachedAttestationVariation -out gen_cached_attestation_variation_ssz.go -extras cache
+//go:generate go run -cover ../../../cmd/sszgen -type BoundedValuesVariation -out gen_bounded_values_variation_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type ExternalTypesVariation -out gen_external_types_variation_ssz.go -extras clone,equal,getters
+//go:generate go run -cover ../../../cmd/sszgen -type SignedGenericVariation[T] -out gen_signed_generic_variation_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type EnvelopeGenericVariation[T] -out gen_envelope_generic_variation_ssz.go -extras getters
+//go:generate go run -cover ../../../cmd/sszgen -type ValueObjectsVariation -out gen_value_objects_variation_ssz.go -extras clone,equal
+//go:generate go run -cover ../../../cmd/sszgen -type BitvectorsVariation -out gen_bitvectors_variation_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type StaticUint64sVariation -out gen_static_uint64s_variation_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type BitfieldVectorsVariation -out gen_bitfield_vectors_variation_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type ArrayOfDynamicBytesVariation -out gen_array_of_dynamic_bytes_variation_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type ForkLimitsVariation -out gen_fork_limits_variation_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type DynamicObjectsVariation -out gen_dynamic_objects_variation_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type RootsListVariation -out gen_roots_list_variation_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type Uint64sListVariation -out gen_uint64s_list_variation_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type StringBytesVariation -out gen_string_bytes_variation_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type PackedArraysBytesVariation -out gen_packed_arrays_bytes_variation_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type PointerObjectsVariation -out gen_pointer_objects_variation_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type StaticUint64sBytesVariation -out gen_static_uint64s_bytes_variation_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type BitfieldVectorsBytesVariation -out gen_bitfield_vectors_bytes_variation_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type AttestationElectraVariation -out gen_attestation_electra_variation_ssz.go
 
 type WithdrawalVariation struct {
 	Index     uint64
-	Validator uint64
+	Validator uint64 `yaml:"validator_index"`
 	Address   []byte `ssz-size:"20"` // Static bytes defined via ssz-size tag
 	Amount    uint64
 }
 
 type HistoricalBatchVariation struct {
-	BlockRoots [8192]Hash
-	StateRoots []Hash `ssz-size:"8192"` // Static array defined via ssz-size tag
+	BlockRoots [SlotsPerHistoricalRoot]Hash `ssz-size:"SlotsPerHistoricalRoot,32"`
+	StateRoots []Hash                       `ssz-size:"SlotsPerHistoricalRoot"` // Static array defined via ssz-size tag
 }
 
 type ExecutionPayloadVariation struct {
@@ -71,18 +102,27 @@ type AttestationVariation3 struct {
 	Future          *uint64 `ssz-fork:"future"` // Currently unused field
 }
 
+// CachedAttestationVariation is an Attestation with an embedded merkle root cache.
+type CachedAttestationVariation struct {
+	ssz.RootCache
+
+	AggregationBits bitfield.Bitlist `ssz-max:"2048"`
+	Data            *AttestationData
+	Signature       [96]byte
+}
+
 type AttestationDataVariation1 struct {
 	Future          *uint64 `ssz-fork:"future"` // Currently unused field
 	Slot            Slot
 	Index           uint64
-	BeaconBlockHash Hash
+	BeaconBlockHash Hash `yaml:"beacon_block_root"`
 	Source          *Checkpoint
 	Target          *Checkpoint
 }
 type AttestationDataVariation2 struct {
 	Slot            Slot
 	Index           uint64
-	BeaconBlockHash Hash
+	BeaconBlockHash Hash    `yaml:"beacon_block_root"`
 	Future          *uint64 `ssz-fork:"future"` // Currently unused field
 	Source          *Checkpoint
 	Target          *Checkpoint
@@ -90,8 +130,235 @@ type AttestationDataVariation2 struct {
 type AttestationDataVariation3 struct {
 	Slot            Slot
 	Index           uint64
-	BeaconBlockHash Hash
+	BeaconBlockHash Hash `yaml:"beacon_block_root"`
 	Source          *Checkpoint
 	Target          *Checkpoint
 	Future          *uint64 `ssz-fork:"future"` // Currently unused field
 }
+
+// The type below tests that maps are encoded as lists of key/value containers
+// sorted by key, for static and dynamic entries alike.
+
+type MapsVariation struct {
+	Slot        uint64
+	Balances    map[Address]uint64     `ssz-map-sorted:"true" ssz-max:"1024"`
+	Withdrawals map[uint64]*Withdrawal `ssz-map-key:"Index" ssz-max:"16"`
+	Extras      map[uint64][]byte      `ssz-map-sorted:"true" ssz-max:"16,32"`
+}
+
+// The type below tests that strings are encoded as lists of bytes, optionally
+// validated to be UTF-8 on decode.
+
+type StringsVariation struct {
+	Name  string `ssz-max:"32"`
+	Nonce uint64
+	Memo  string `ssz-max:"64" ssz:"utf8"`
+}
+
+// The type below tests that lists with set semantics can be validated to be
+// strictly increasing on decode.
+
+type SortedListsVariation struct {
+	AttestingIndices []uint64 `ssz-max:"2048" ssz-sorted:"asc"`
+	Balances         []uint64 `ssz-max:"2048"`
+}
+
+// The type below tests that types generated with value receivers can be encoded
+// and hashed from non-addressable values, but refuse being decoded into.
+type ReadOnlyVariation struct {
+	Slot       uint64
+	Withdrawal *WithdrawalVariation
+	Extra      []byte `ssz-max:"32"`
+}
+
+// The type below tests that arrays of small unsigned integers are packed into
+// chunks, optionally guarded by forks.
+
+type PackedArraysVariation struct {
+	Flags    [16]uint16
+	Counters [64]uint32
+	Extra    *[16]uint32 `ssz-fork:"deneb"`
+}
+
+// The type below tests that multi-dimensional arrays of unsigned integers are
+// packed into chunks per inner array.
+
+type MultiDimArraysVariation struct {
+	Custody [4][8192]uint64 `ssz-size:"4,8192"`
+	Flags   [2][16]uint16
+	Counts  [3][64]uint32 `ssz-size:"3,64,4"`
+}
+
+// The type below tests that lists of small unsigned integers are packed into
+// chunks, optionally guarded by forks.
+
+type PackedListsVariation struct {
+	Bytes  []uint8  `ssz-max:"100"`
+	Shorts []uint16 `ssz-max:"100"`
+	Words  []uint32 `ssz-max:"100"`
+	Extras []uint16 `ssz-max:"8" ssz-fork:"deneb"`
+}
+
+// The type below tests that list limits can reference named constants, which are
+// emitted by name into the generated code instead of by value.
+
+const (
+	MaxNamedTransactions        = 1048576
+	MaxNamedBytesPerTransaction = 1073741824
+	MaxNamedWithdrawals         = 16
+	MaxNamedBalances            = 1024
+	MaxNamedBalanceBytes        = 32
+)
+
+type NamedLimitsVariation struct {
+	ExtraData    []byte            `ssz-max:"32"`
+	Transactions [][]byte          `ssz-max:"MaxNamedTransactions,MaxNamedBytesPerTransaction"`
+	Withdrawals  []*Withdrawal     `ssz-max:"MaxNamedWithdrawals" ssz-max-fork:"deneb=32"`
+	Balances     map[uint64][]byte `ssz-map-sorted:"true" ssz-max:"MaxNamedBalances,MaxNamedBalanceBytes"`
+}
+
+// The type below tests that uint256 fields can be bounded to a maximum value,
+// optionally guarded by forks.
+
+type BoundedValuesVariation struct {
+	BaseFee    *uint256.Int `ssz-maxvalue:"1000000000000"`
+	Difficulty *big.Int     `ssz-maxvalue:"340282366920938463463374607431768211455"`
+	BlobFee    *uint256.Int `ssz-maxvalue:"1000000" ssz-fork:"deneb"`
+}
+
+// The type below tests that named types, aliases and generic instantiations from
+// other packages are resolved to their underlying ssz types.
+
+type ExternalHash = external.Hash
+
+type ExternalTypesVariation struct {
+	Hash       external.Hash
+	Root       external.Root
+	Address    external.Address
+	Local      ExternalHash
+	Recent     external.Vector[external.Root]
+	Roots      []external.Root `ssz-max:"16"`
+	Checkpoint *external.Checkpoint
+	Finalized  *external.Checkpoint `ssz-fork:"deneb"`
+}
+
+// The types below test that generic containers can wrap arbitrary static and
+// dynamic objects, optionally guarded by forks.
+
+type SignedGenericVariation[T ssz.StaticObject] struct {
+	Message   T
+	Signature [96]byte
+}
+
+type EnvelopeGenericVariation[T ssz.DynamicObject] struct {
+	Slot    uint64
+	Payload T
+	Blob    []byte `ssz-max:"32"`
+	Extra   T      `ssz-fork:"deneb"`
+}
+
+// The type below tests that slices of static objects can be stored by value,
+// without the per item indirection.
+
+type ValueObjectsVariation struct {
+	Slot        uint64
+	Withdrawals []Withdrawal `ssz-max:"16"`
+}
+
+// The type below tests that vectors of bitvectors (e.g. sync-committee style bit
+// matrices) can be expressed via fixed bit arrays, slices of them, as well as
+// via go-bitfield's bitvectors.
+
+type BitvectorsVariation struct {
+	Participation [4][64]byte              `ssz-size:"4,512" ssz:"bits"`
+	Committees    [][4]byte                `ssz-size:"8,30" ssz:"bits"`
+	Aggregates    [2]bitfield.Bitvector128 `ssz-size:"2,128" ssz:"bits"`
+}
+
+// The type below tests that static arrays of uint64s can be backed by plain
+// slices, sized via the ssz-size tag, including ones not filling a full chunk.
+
+type StaticUint64sVariation struct {
+	Slot      uint64
+	Slashings []uint64 `ssz-size:"EpochsPerSlashingsVector"`
+	Weights   []uint64 `ssz-size:"5"`
+}
+
+// The type below tests that go-bitfield's bitvectors are picked up without any
+// ssz tags, their sizes derived from the named types themselves.
+
+type BitfieldVectorsVariation struct {
+	Flags      bitfield.Bitvector4
+	Justified  bitfield.Bitvector64
+	Attesters  bitfield.Bitvector512
+	Aggregates [2]bitfield.Bitvector128
+}
+
+// The type below tests that static arrays of dynamic blobs can be backed by plain
+// slices, sized via the ssz-size tag.
+
+type ArrayOfDynamicBytesVariation struct {
+	Blobs [][]byte `ssz-size:"3,?" ssz-max:"?,16"`
+}
+
+// The type below tests that list limits can change across multiple forks.
+
+type ForkLimitsVariation struct {
+	List []uint64 `ssz-max:"4" ssz-max-fork:"deneb=8,electra=2"`
+}
+
+// The types below test that lists large enough to be split across threads or to
+// be hashed via the bulk fast paths produce the same roots as the plain hashing.
+
+type DynamicObjectsVariation struct {
+	Payloads []*ExecutionPayloadDeneb `ssz-max:"16"`
+}
+
+type RootsListVariation struct {
+	Roots [][32]byte `ssz-max:"4096"`
+}
+
+type Uint64sListVariation struct {
+	Nums []uint64 `ssz-max:"4096"`
+}
+
+// The types below are the reference equivalents of other variations, spelling out
+// the same encodings via different Go types (e.g. byte arrays instead of packed
+// integers or bitvectors), used to cross-check the specialized encodings.
+
+type StringBytesVariation struct {
+	Name  []byte `ssz-max:"32"`
+	Nonce uint64
+	Memo  []byte `ssz-max:"64"`
+}
+
+type PackedArraysBytesVariation struct {
+	Flags    [32]byte
+	Counters [256]byte
+	Extra    *[64]byte `ssz-fork:"deneb"`
+}
+
+type PointerObjectsVariation struct {
+	Slot        uint64
+	Withdrawals []*Withdrawal `ssz-max:"16"`
+}
+
+type StaticUint64sBytesVariation struct {
+	Slot      uint64
+	Slashings [EpochsPerSlashingsVector]uint64 `ssz-size:"EpochsPerSlashingsVector"`
+	Weights   []byte                           `ssz-size:"40"`
+}
+
+type BitfieldVectorsBytesVariation struct {
+	Flags      [1]byte     `ssz-size:"4" ssz:"bits"`
+	Justified  [8]byte     `ssz-size:"64" ssz:"bits"`
+	Attesters  [64]byte    `ssz-size:"512" ssz:"bits"`
+	Aggregates [2][16]byte `ssz-size:"2,128" ssz:"bits"`
+}
+
+type AttestationElectraVariation struct {
+	AggregationBits bitfield.Bitlist `ssz-max:"131072"`
+	Data            *AttestationData
+	Signature       [96]byte
+	CommitteeBits   [8]byte `ssz-size:"64" ssz:"bits"`
+}
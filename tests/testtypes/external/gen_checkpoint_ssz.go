@@ -0,0 +1,51 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+//ssz:stamp 84ab6195c9a6a0efc7de887c8c7f0421d53cdc0f2b66fb4ab58e6826852c09fc
+
+package external
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *Checkpoint) SizeSSZ(sizer *ssz.Sizer) uint32 {
+	return 8 + 32
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *Checkpoint) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &obj.Epoch)     // Field  (0) - Epoch -  8 bytes
+	ssz.DefineStaticBytes(codec, &obj.Root) // Field  (1) -  Root - 32 bytes
+}
+
+// NamesSSZ returns the field names in the order of their definitions.
+func (obj *Checkpoint) NamesSSZ() []string {
+	return []string{"Epoch", "Root"}
+}
+
+// Clone creates a deep copy of the object, retaining only the ssz fields.
+func (obj *Checkpoint) Clone() *Checkpoint {
+	if obj == nil {
+		return nil
+	}
+	clone := new(Checkpoint)
+	clone.Epoch = obj.Epoch
+	clone.Root = obj.Root
+	return clone
+}
+
+// EqualSSZ checks whether two objects are equal in their ssz representation. Nil
+// and zero values are considered equal, as they would encode the same.
+func (obj *Checkpoint) EqualSSZ(other *Checkpoint) bool {
+	if obj == nil {
+		obj = new(Checkpoint)
+	}
+	if other == nil {
+		other = new(Checkpoint)
+	}
+	if obj.Epoch != other.Epoch {
+		return false
+	}
+	if obj.Root != other.Root {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,30 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package external contains types used as fields from other packages, to test
+// the code generator resolving them across package boundaries.
+package external
+
+//go:generate go run -cover ../../../cmd/sszgen -type Checkpoint -out gen_checkpoint_ssz.go -extras clone,equal
+
+// Hash is a named type defined in a foreign package (e.g. go-ethereum's Hash).
+type Hash [32]byte
+
+// Root is an alias of a foreign named type.
+type Root = Hash
+
+// Address is an alias of an unnamed type.
+type Address = [20]byte
+
+// Bytes is a named dynamic type defined in a foreign package.
+type Bytes []byte
+
+// Vector is a generic named type, instantiated by the users.
+type Vector[T any] [4]T
+
+// Checkpoint is an ssz object defined in a foreign package.
+type Checkpoint struct {
+	Epoch uint64
+	Root  Root
+}
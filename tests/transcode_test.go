@@ -0,0 +1,72 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/holiman/uint256"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that monolith objects can be transcoded across forks, initializing the
+// added fields and clearing the removed ones.
+func TestTranscode(t *testing.T) {
+	obj := &types.ExecutionPayloadMonolith{
+		BlockNumber:   1,
+		ExtraData:     []byte{0x01},
+		BaseFeePerGas: uint256.NewInt(7),
+		Transactions:  [][]byte{{0x02}},
+		Withdrawals:   []*types.Withdrawal{{Index: 1}},
+	}
+	shanghai := obj.Clone()
+
+	// Upgrade into Cancun and ensure the new fields are present and zero
+	if err := ssz.Transcode(obj, ssz.ForkShanghai, ssz.ForkCancun); err != nil {
+		t.Fatalf("failed to upgrade object: %v", err)
+	}
+	if obj.BlobGasUsed == nil || *obj.BlobGasUsed != 0 || obj.ExcessBlobGas == nil || *obj.ExcessBlobGas != 0 {
+		t.Errorf("added fields not initialized: %v, %v", obj.BlobGasUsed, obj.ExcessBlobGas)
+	}
+	if !obj.EqualSSZ(shanghai) || len(obj.Withdrawals) != 1 || obj.Withdrawals[0].Index != 1 {
+		t.Errorf("retained fields modified")
+	}
+	if have, want := ssz.HashSequentialOnFork(obj, ssz.ForkCancun), ssz.HashSequentialOnFork(shanghai, ssz.ForkCancun); have != want {
+		t.Errorf("upgraded hash mismatch: have %x, want %x", have, want)
+	}
+	// Downgrade back into Shanghai and ensure the encoding matches the original
+	if err := ssz.Transcode(obj, ssz.ForkCancun, ssz.ForkShanghai); err != nil {
+		t.Fatalf("failed to downgrade object: %v", err)
+	}
+	if obj.BlobGasUsed != nil || obj.ExcessBlobGas != nil {
+		t.Errorf("removed fields not cleared: %v, %v", obj.BlobGasUsed, obj.ExcessBlobGas)
+	}
+	have := make([]byte, ssz.SizeOnFork(obj, ssz.ForkShanghai))
+	if err := ssz.EncodeToBytesOnFork(have, obj, ssz.ForkShanghai); err != nil {
+		t.Fatalf("failed to encode downgraded object: %v", err)
+	}
+	want := make([]byte, ssz.SizeOnFork(shanghai, ssz.ForkShanghai))
+	if err := ssz.EncodeToBytesOnFork(want, shanghai, ssz.ForkShanghai); err != nil {
+		t.Fatalf("failed to encode original object: %v", err)
+	}
+	if !bytes.Equal(have, want) {
+		t.Errorf("downgraded encoding mismatch: have %x, want %x", have, want)
+	}
+	// Downgrade past the introduction of withdrawals and ensure they are dropped
+	if err := ssz.Transcode(obj, ssz.ForkShanghai, ssz.ForkParis); err != nil {
+		t.Fatalf("failed to downgrade object: %v", err)
+	}
+	if obj.Withdrawals != nil || obj.BaseFeePerGas == nil {
+		t.Errorf("pre-withdrawal fields mismatch: withdrawals %v, base fee %v", obj.Withdrawals, obj.BaseFeePerGas)
+	}
+	// Objects without upgrade support must be rejected
+	if err := ssz.Transcode(new(types.ExecutionPayloadMonolith2), ssz.ForkShanghai, ssz.ForkCancun); !errors.Is(err, ssz.ErrNotUpgradable) {
+		t.Errorf("non-upgradable error mismatch: have %v, want %v", err, ssz.ErrNotUpgradable)
+	}
+}
@@ -67,6 +67,12 @@ func testZeroValue[T newableObject[U], U any](t *testing.T, fork ssz.Fork) {
 		"decoded-sequential":    ssz.HashSequentialOnFork(obj1, fork),
 		"decoded-concurrent":    ssz.HashSequentialOnFork(obj1, fork),
 	}
+	serialized, err := ssz.HashRootFromBytes(bin1, T(new(U)), fork)
+	if err != nil {
+		t.Fatalf("failed to hash zero-value encoding: %v", err)
+	}
+	hashes["zero-value-serialized"] = serialized
+
 	for key1, hash1 := range hashes {
 		for key2, hash2 := range hashes {
 			if hash1 != hash2 {
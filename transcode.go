@@ -0,0 +1,26 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import "fmt"
+
+// Transcode converts a monolith object in place from the layout of one fork to
+// that of another (e.g. upgrading a state at a fork boundary), so that it can be
+// encoded and hashed in the target fork. Fields added in between are initialized
+// to their zero values, fields removed in between are cleared, and the fields
+// present in both forks are retained as they are.
+//
+// The object needs to implement UpgradableObject, otherwise ErrNotUpgradable is
+// returned. Forks may be converted both forward and backward.
+func Transcode(obj Object, from Fork, to Fork) error {
+	upgradable, ok := obj.(UpgradableObject)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrNotUpgradable, obj)
+	}
+	if from != to {
+		upgradable.UpgradeSSZ(from, to)
+	}
+	return nil
+}
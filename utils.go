@@ -4,7 +4,11 @@
 
 package ssz
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+	"unsafe"
+)
 
 // PrecomputeStaticSizeCache is a helper to precompute SSZ (static) sizes for a
 // monolith type on different forks.
@@ -33,3 +37,57 @@ func PrecomputeStaticSizeCache(obj Object) []uint32 {
 	}
 	return sizes
 }
+
+// mapToEntries converts a map into a slice of key/value containers, sorted by the
+// keys to have a deterministic encoding.
+//
+// A single operation needs the entries of a map multiple times (e.g. sizing, then
+// encoding the offsets and the contents), so they are cached in the codec by map
+// pointer until the top level operation finishes (see releaseEntries).
+func mapToEntries[T newableMapEntry[K, V, U], U any, K comparable, V any](codec *Codec, m map[K]V) []T {
+	if m == nil {
+		return nil
+	}
+	id := *(*unsafe.Pointer)(unsafe.Pointer(&m))
+	if entries, ok := codec.entries[id].([]T); ok {
+		return entries
+	}
+	entries := make([]T, 0, len(m))
+	for key, value := range m {
+		entry := T(new(U))
+		entry.SetEntrySSZ(key, value)
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		key, _ := entries[j].EntrySSZ()
+		return entries[i].CompareKeySSZ(key) < 0
+	})
+	if codec.entries == nil {
+		codec.entries = make(map[unsafe.Pointer]any)
+	}
+	codec.entries[id] = entries
+	return entries
+}
+
+// releaseEntries drops the map entries cached during an operation, as the maps
+// might be modified afterwards.
+func (c *Codec) releaseEntries() {
+	clear(c.entries)
+}
+
+// entriesToMap converts a slice of key/value containers into a map, enforcing
+// that the keys are strictly increasing (i.e. canonical encoding).
+func entriesToMap[T MapEntry[K, V], K comparable, V any](entries []T) (map[K]V, error) {
+	if entries == nil {
+		return nil, nil
+	}
+	m := make(map[K]V, len(entries))
+	for i, entry := range entries {
+		key, value := entry.EntrySSZ()
+		if i > 0 && entries[i-1].CompareKeySSZ(key) >= 0 {
+			return nil, fmt.Errorf("%w: entry %d", ErrUnorderedMapKeys, i)
+		}
+		m[key] = value
+	}
+	return m, nil
+}
@@ -15,6 +15,20 @@ import (
 // of a sanity thing to handle weird corner-cases without blowing up.
 var zeroCache = new(sync.Map)
 
+// zeroRootCache contains the merkle roots of zero-values for objects that got
+// hit during hashing, keyed by type and fork. Compared to the zero values, the
+// roots are worth caching as large types are expensive to hash.
+var zeroRootCache = new(sync.Map)
+
+// zeroRootKey is the lookup key into the zero root cache. The fork is part of
+// the key as monolithic types can hash differently across forks, and the hash
+// backend as it changes all the roots (nil being the default sha256).
+type zeroRootKey struct {
+	kind    reflect.Type
+	fork    Fork
+	backend HasherBackend
+}
+
 // zeroValueStatic retrieves a previously created (or creates one on the fly)
 // zero value for a static object to support operating on half-initialized
 // objects (useful for tests mainly, but can also avoid crashes in case of bad
@@ -44,3 +58,114 @@ func zeroValueDynamic[T newableDynamicObject[U], U any]() T {
 	zeroCache.Store(kind, val)
 	return val
 }
+
+// zeroValueOf retrieves a previously created (or creates one on the fly) zero
+// value for an object passed as an interface, sharing the cache with the typed
+// zero values. The object is assumed to be a (nil) struct pointer.
+func zeroValueOf(obj Object) Object {
+	kind := reflect.TypeOf(obj).Elem()
+
+	if val, ok := zeroCache.Load(kind); ok {
+		return val.(Object)
+	}
+	val := reflect.New(kind).Interface().(Object)
+	zeroCache.Store(kind, val)
+	return val
+}
+
+// isNilObject checks whether an object passed as an interface is a nil pointer.
+func isNilObject(obj Object) bool {
+	val := reflect.ValueOf(obj)
+	return val.Kind() == reflect.Pointer && val.IsNil()
+}
+
+// zeroRootStatic retrieves a previously computed (or computes one on the fly)
+// merkle root of the zero value of a static object on a given fork and backend.
+// Failures (of custom backends) are not cached.
+func zeroRootStatic[T newableStaticObject[U], U any](fork Fork, backend HasherBackend) ([32]byte, error) {
+	key := zeroRootKey{kind: reflect.TypeFor[U](), fork: fork, backend: backend}
+
+	if root, ok := zeroRootCache.Load(key); ok {
+		return root.([32]byte), nil
+	}
+	root, err := hashRoot(zeroValueStatic[T, U](), fork, LittleEndian, backend)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	zeroRootCache.Store(key, root)
+	return root, nil
+}
+
+// zeroRootDynamic retrieves a previously computed (or computes one on the fly)
+// merkle root of the zero value of a dynamic object on a given fork and backend.
+// Failures (of custom backends) are not cached.
+func zeroRootDynamic[T newableDynamicObject[U], U any](fork Fork, backend HasherBackend) ([32]byte, error) {
+	key := zeroRootKey{kind: reflect.TypeFor[U](), fork: fork, backend: backend}
+
+	if root, ok := zeroRootCache.Load(key); ok {
+		return root.([32]byte), nil
+	}
+	root, err := hashRoot(zeroValueDynamic[T, U](), fork, LittleEndian, backend)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	zeroRootCache.Store(key, root)
+	return root, nil
+}
+
+// isNilGeneric checks whether an object passed as a type parameter constrained
+// only by the ssz interfaces is nil. The type is assumed to be a struct pointer,
+// as with the non-generic object methods.
+func isNilGeneric[T Object](obj T) bool {
+	var zero T
+	return any(obj) == any(zero)
+}
+
+// newObjectOf allocates a new, empty instance of an object's type. Objects are
+// usually struct pointers, but types generated with value receivers are objects
+// as plain values too, so the pointer is only dereferenced if present. Pointers
+// to value receiver types are objects too, so the result is always a pointer.
+func newObjectOf(obj Object) Object {
+	typ := reflect.TypeOf(obj)
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return reflect.New(typ).Interface().(Object)
+}
+
+// newGeneric allocates a new object for a type parameter constrained only by
+// the ssz interfaces, where the struct type cannot be named to call new on it.
+func newGeneric[T Object]() T {
+	return reflect.New(reflect.TypeFor[T]().Elem()).Interface().(T)
+}
+
+// zeroValueGeneric retrieves a previously created (or creates one on the fly)
+// zero value for an object type parameter, sharing the cache with the zero values
+// of the non-generic objects.
+func zeroValueGeneric[T Object]() T {
+	kind := reflect.TypeFor[T]().Elem()
+
+	if val, ok := zeroCache.Load(kind); ok {
+		return val.(T)
+	}
+	val := newGeneric[T]()
+	zeroCache.Store(kind, val)
+	return val
+}
+
+// zeroRootGeneric retrieves a previously computed (or computes one on the fly)
+// merkle root of the zero value of an object type parameter on a given fork and
+// backend. Failures (of custom backends) are not cached.
+func zeroRootGeneric[T Object](fork Fork, backend HasherBackend) ([32]byte, error) {
+	key := zeroRootKey{kind: reflect.TypeFor[T]().Elem(), fork: fork, backend: backend}
+
+	if root, ok := zeroRootCache.Load(key); ok {
+		return root.([32]byte), nil
+	}
+	root, err := hashRoot(zeroValueGeneric[T](), fork, LittleEndian, backend)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	zeroRootCache.Store(key, root)
+	return root, nil
+}